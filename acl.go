@@ -0,0 +1,155 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package goubus
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+// ubusSubscribeFunction is the ACL function uhttpd-mod-ubus checks before it accepts a subscription.
+const ubusSubscribeFunction = ":subscribe"
+
+// uciReadMethods lists the uci object methods that only need read access to a config.
+var uciReadMethods = map[string]bool{
+	"get":     true,
+	"state":   true,
+	"configs": true,
+	"changes": true,
+}
+
+// ubusReadMethods lists common side-effect free method names. Together with the "get" prefix
+// rule of isReadMethod they place hints in the ACL read group and keep reads out of the audit log.
+var ubusReadMethods = map[string]bool{
+	"access":                  true,
+	"assoclist":               true,
+	"board":                   true,
+	"browse":                  true,
+	"bss_info":                true,
+	"changes":                 true,
+	"configs":                 true,
+	"countrylist":             true,
+	"devices":                 true,
+	"dump":                    true,
+	"freqlist":                true,
+	"hosts":                   true,
+	"htmodelist":              true,
+	"info":                    true,
+	"list":                    true,
+	"md5":                     true,
+	"packagelist":             true,
+	"read":                    true,
+	"rrm_nr_list":             true,
+	"scan":                    true,
+	"stat":                    true,
+	"state":                   true,
+	"status":                  true,
+	"survey":                  true,
+	"txpowerlist":             true,
+	"validate_firmware_image": true,
+	"wps_status":              true,
+}
+
+// isReadMethod reports whether a ubus method only reads, such as iwinfo.assoclist, uci.get,
+// hostapd.get_clients or luci-rpc.getHostHints.
+func isReadMethod(method string) bool {
+	return ubusReadMethods[method] || strings.HasPrefix(method, "get")
+}
+
+// accessDenied inspects the session ACLs after a call was rejected and returns a *errdefs.PermissionError
+// naming the missing permission. The ACL lookups are best effort; when they fail the ubus permission
+// of the rejected call is reported.
+func (rc *RpcClient) accessDenied(ctx context.Context, sessionID, service, method string, data any) error {
+	perm := &errdefs.PermissionError{
+		Scope:  errdefs.ACLScopeUbus,
+		Object: service,
+		Method: method,
+		Access: ubusAccessFor(method),
+	}
+
+	if service != "uci" || !rc.sessionAccess(ctx, sessionID, errdefs.ACLScopeUbus, service, method) {
+		return perm
+	}
+
+	config := uciConfigOf(data)
+	if config == "" {
+		return perm
+	}
+
+	access := errdefs.ACLAccessWrite
+	if uciReadMethods[method] {
+		access = errdefs.ACLAccessRead
+	}
+
+	if rc.sessionAccess(ctx, sessionID, errdefs.ACLScopeUCI, config, access) {
+		return perm
+	}
+
+	return &errdefs.PermissionError{
+		Scope:  errdefs.ACLScopeUCI,
+		Object: config,
+		Access: access,
+	}
+}
+
+// sessionAccess asks rpcd whether the session is granted the given permission.
+func (rc *RpcClient) sessionAccess(ctx context.Context, sessionID, scope, object, function string) bool {
+	req := map[string]string{
+		"ubus_rpc_session": sessionID,
+		"scope":            scope,
+		"object":           object,
+		"function":         function,
+	}
+
+	res, err := rc.rawCall(ctx, sessionID, "session", "access", req)
+	if err != nil {
+		return false
+	}
+
+	var access struct {
+		Access bool `json:"access"`
+	}
+
+	err = res.Unmarshal(&access)
+	if err != nil {
+		return false
+	}
+
+	return access.Access
+}
+
+func isAccessDeniedResult(res Result) bool {
+	result, ok := res.(rpcResult)
+	if !ok || len(result) == 0 {
+		return false
+	}
+
+	code, ok := result[0].(float64)
+
+	return ok && int(code) == UbusStatusPermissionDenied
+}
+
+func ubusAccessFor(method string) string {
+	if isReadMethod(method) {
+		return errdefs.ACLAccessRead
+	}
+
+	return errdefs.ACLAccessWrite
+}
+
+func uciConfigOf(data any) string {
+	var req struct {
+		Config string `json:"config"`
+	}
+
+	err := json.Unmarshal([]byte(encodeRequestData(data)), &req)
+	if err != nil {
+		return ""
+	}
+
+	return req.Config
+}
@@ -0,0 +1,349 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package goubus
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/logging"
+)
+
+const (
+	defaultAuditLogSize = 64 * 1024
+	defaultAuditLogKeep = 3
+	auditRedacted       = "***"
+	auditLogMode        = 0o640
+)
+
+// auditSecretKeys are argument keys whose values are never written to an audit record.
+var auditSecretKeys = map[string]bool{
+	"data": true, "key": true, "password": true, "secret": true, "ubus_rpc_session": true,
+}
+
+// AuditRecord describes a call made through an AuditTransport.
+type AuditRecord struct {
+	Time time.Time `json:"ts"`
+	// Args are the call arguments with secrets and file contents redacted.
+	Args     map[string]any `json:"args,omitempty"`
+	Actor    string         `json:"actor,omitempty"`
+	Service  string         `json:"service"`
+	Method   string         `json:"method"`
+	Error    string         `json:"error,omitempty"`
+	Duration time.Duration  `json:"duration"`
+}
+
+// AuditSink stores audit records.
+type AuditSink interface {
+	Record(ctx context.Context, record AuditRecord) error
+}
+
+// AuditOption defines a functional option for an AuditTransport.
+type AuditOption func(*AuditTransport)
+
+// WithAuditSink adds a sink that receives every audited call.
+func WithAuditSink(sink AuditSink) AuditOption {
+	return func(at *AuditTransport) {
+		at.sinks = append(at.sinks, sink)
+	}
+}
+
+// WithAuditActor sets the actor stored in every record, e.g. the name of the automation.
+func WithAuditActor(actor string) AuditOption {
+	return func(at *AuditTransport) {
+		at.actor = actor
+	}
+}
+
+// WithAuditFilter replaces the default filter, which audits every call except well-known read-only methods.
+func WithAuditFilter(filter func(service, method string) bool) AuditOption {
+	return func(at *AuditTransport) {
+		at.filter = filter
+	}
+}
+
+// AuditTransport wraps a Transport and records the calls that change the device.
+// Sink failures are logged and never fail the call. Subscriptions, events and introspection are
+// forwarded to the wrapped transport unrecorded.
+type AuditTransport struct {
+	Transport
+
+	logger *slog.Logger
+	filter func(service, method string) bool
+	sinks  []AuditSink
+	actor  string
+}
+
+var (
+	_ Transport    = (*AuditTransport)(nil)
+	_ Subscriber   = (*AuditTransport)(nil)
+	_ Introspector = (*AuditTransport)(nil)
+	_ Batcher      = (*AuditTransport)(nil)
+)
+
+// NewAuditTransport wraps t with auditing.
+func NewAuditTransport(t Transport, opts ...AuditOption) *AuditTransport {
+	at := &AuditTransport{
+		Transport: t,
+		logger:    logging.Discard(),
+		filter:    isMutatingCall,
+	}
+
+	for _, opt := range opts {
+		opt(at)
+	}
+
+	return at
+}
+
+// Call performs the call and records it if it passes the audit filter.
+func (at *AuditTransport) Call(ctx context.Context, service, method string, data any) (Result, error) {
+	start := time.Now()
+	res, err := at.Transport.Call(ctx, service, method, data)
+	at.record(ctx, service, method, data, start, err)
+
+	return res, err
+}
+
+// CallBatch executes calls through the wrapped transport, in one round trip if it supports
+// batches, and records every call that passes the audit filter. When the batch as a whole fails,
+// its calls are recorded with that error, since some of them may have run.
+func (at *AuditTransport) CallBatch(ctx context.Context, calls []BatchCall) ([]BatchResult, error) {
+	start := time.Now()
+	results, err := CallBatch(ctx, at.Transport, calls)
+
+	for i, call := range calls {
+		callErr := err
+		if err == nil {
+			callErr = results[i].Err
+		}
+
+		at.record(ctx, call.Service, call.Method, call.Data, start, callErr)
+	}
+
+	return results, err
+}
+
+// Subscribe subscribes to the notifications of object through the wrapped transport.
+func (at *AuditTransport) Subscribe(ctx context.Context, object string) (*Subscription, error) {
+	return Subscribe(ctx, at.Transport, object)
+}
+
+// Listen listens for the events matching pattern through the wrapped transport.
+func (at *AuditTransport) Listen(ctx context.Context, pattern string) (*Subscription, error) {
+	return Listen(ctx, at.Transport, pattern)
+}
+
+// Objects lists the objects matching pattern through the wrapped transport.
+func (at *AuditTransport) Objects(ctx context.Context, pattern string) ([]ObjectInfo, error) {
+	return Objects(ctx, at.Transport, pattern)
+}
+
+// Lookup describes object through the wrapped transport.
+func (at *AuditTransport) Lookup(ctx context.Context, object string) (*ObjectInfo, error) {
+	return lookupObject(ctx, at.Transport, object)
+}
+
+// record passes a call that started at start and ended with err to the sinks if it passes the
+// audit filter.
+func (at *AuditTransport) record(ctx context.Context, service, method string, data any, start time.Time, err error) {
+	if !at.filter(service, method) {
+		return
+	}
+
+	record := AuditRecord{
+		Time:     start.UTC(),
+		Args:     redactAuditArgs(data),
+		Actor:    at.actor,
+		Service:  service,
+		Method:   method,
+		Duration: time.Since(start),
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+
+	for _, sink := range at.sinks {
+		errSink := sink.Record(context.WithoutCancel(ctx), record)
+		if errSink != nil {
+			at.logger.Warn("failed to record audit entry",
+				slog.String("call", service+"."+method),
+				slog.String("error", errSink.Error()))
+		}
+	}
+}
+
+// SetLogger sets the logger for the audit transport and the wrapped transport.
+func (at *AuditTransport) SetLogger(logger *slog.Logger) {
+	if logger == nil {
+		at.logger = logging.Discard()
+	} else {
+		at.logger = logger
+	}
+
+	at.Transport.SetLogger(logger)
+}
+
+func isMutatingCall(_, method string) bool {
+	return !isReadMethod(method)
+}
+
+func redactAuditArgs(data any) map[string]any {
+	if data == nil {
+		return nil
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil
+	}
+
+	var args map[string]any
+
+	err = json.Unmarshal(raw, &args)
+	if err != nil {
+		return nil
+	}
+
+	redactAuditValue(args)
+
+	return args
+}
+
+// redactAuditValue redacts the secrets of nested tables and arrays in place, such as the values
+// of a uci.set call.
+func redactAuditValue(value any) {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, nested := range value {
+			if auditSecretKeys[key] {
+				value[key] = auditRedacted
+			} else {
+				redactAuditValue(nested)
+			}
+		}
+	case []any:
+		for _, nested := range value {
+			redactAuditValue(nested)
+		}
+	}
+}
+
+// DeviceAuditLog is an AuditSink that appends one JSON line per record to a file on the device,
+// so the operations are visible on-site without access to the controller. The file is rotated
+// to "<path>.1" … "<path>.<keep>" once it would exceed its maximum size.
+// The session needs write permission for the log files and exec permission for /bin/mv.
+type DeviceAuditLog struct {
+	caller  Transport
+	path    string
+	maxSize int
+	keep    int
+	size    int
+	mu      sync.Mutex
+	sized   bool
+}
+
+var _ AuditSink = (*DeviceAuditLog)(nil)
+
+// NewDeviceAuditLog creates a device log at path. A non-positive maxSize defaults to 64 KiB
+// and a non-positive keep to 3 rotated files. Pass the unwrapped transport, so writing
+// the log is not audited itself.
+func NewDeviceAuditLog(t Transport, path string, maxSize, keep int) *DeviceAuditLog {
+	if maxSize <= 0 {
+		maxSize = defaultAuditLogSize
+	}
+
+	if keep <= 0 {
+		keep = defaultAuditLogKeep
+	}
+
+	return &DeviceAuditLog{caller: t, path: path, maxSize: maxSize, keep: keep}
+}
+
+// Record appends the record to the device log, rotating it first if needed.
+func (l *DeviceAuditLog) Record(ctx context.Context, record AuditRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "encode audit record: %v", err)
+	}
+
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.sized {
+		l.size = l.currentSize(ctx)
+		l.sized = true
+	}
+
+	if l.size > 0 && l.size+len(line) > l.maxSize {
+		err = l.rotate(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = l.caller.Call(ctx, "file", "write", map[string]any{
+		"path":   l.path,
+		"data":   string(line),
+		"append": true,
+		"mode":   auditLogMode,
+	})
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to append to %s", l.path)
+	}
+
+	l.size += len(line)
+
+	return nil
+}
+
+func (l *DeviceAuditLog) currentSize(ctx context.Context) int {
+	stat, err := Call[struct {
+		Size int `json:"size"`
+	}](ctx, l.caller, "file", "stat", map[string]any{"path": l.path})
+	if err != nil {
+		return 0
+	}
+
+	return stat.Size
+}
+
+// rotate shifts "<path>.N" to "<path>.N+1", dropping the oldest file, and moves the log to "<path>.1".
+func (l *DeviceAuditLog) rotate(ctx context.Context) error {
+	for index := l.keep - 1; index >= 0; index-- {
+		from := l.path
+		if index > 0 {
+			from += "." + strconv.Itoa(index)
+		}
+
+		to := l.path + "." + strconv.Itoa(index+1)
+
+		res, err := Call[struct {
+			Stderr string `json:"stderr"`
+			Code   int    `json:"code"`
+		}](ctx, l.caller, "file", "exec", map[string]any{
+			"command": "/bin/mv",
+			"params":  []string{"-f", from, to},
+		})
+		if err != nil {
+			return errdefs.Wrapf(err, "failed to rotate %s", from)
+		}
+
+		if res.Code != 0 && index == 0 {
+			return errdefs.Wrapf(errdefs.ErrUnknown, "mv exited with code %d: %s", res.Code, strings.TrimSpace(res.Stderr))
+		}
+	}
+
+	l.size = 0
+
+	return nil
+}
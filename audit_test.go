@@ -0,0 +1,205 @@
+package goubus_test
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/internal/testutil"
+)
+
+func TestAuditTransport_DeviceLog(t *testing.T) {
+	ctx := context.Background()
+
+	mock := testutil.NewMockTransport()
+	mock.AddResponse("uci", "get", map[string]any{"value": "lan"})
+	mock.AddResponse("rpc-sys", "password_set", map[string]any{})
+	mock.AddResponse("file", "write", map[string]any{})
+
+	auditLog := goubus.NewDeviceAuditLog(mock, "/root/goubus-audit.log", 0, 0)
+	client := goubus.NewAuditTransport(mock, goubus.WithAuditActor("provisioner"), goubus.WithAuditSink(auditLog))
+
+	_, _ = client.Call(ctx, "uci", "get", map[string]any{"config": "network"})
+
+	// Dashboard polls are reads and leave no record.
+	for _, call := range [][2]string{
+		{"iwinfo", "assoclist"}, {"iwinfo", "survey"}, {"luci-rpc", "getHostHints"},
+		{"hostapd.phy0-ap0", "get_data"}, {"hostapd.phy0-ap0", "wps_status"}, {"rpc-sys", "packagelist"},
+		{"umdns", "browse"},
+	} {
+		_, _ = client.Call(ctx, call[0], call[1], nil)
+	}
+
+	_, _ = client.Call(ctx, "rpc-sys", "password_set", map[string]any{"user": "root", "password": "hunter2"})
+
+	var writes []map[string]any
+
+	for _, call := range mock.Calls {
+		if call.Service == "file" && call.Method == "write" {
+			params, _ := call.Data.(map[string]any)
+			writes = append(writes, params)
+		}
+	}
+
+	if len(writes) != 1 || writes[0]["append"] != true || writes[0]["path"] != "/root/goubus-audit.log" {
+		t.Fatalf("expected one appended record, got %+v", writes)
+	}
+
+	line, _ := writes[0]["data"].(string)
+	if strings.Contains(line, "hunter2") || !strings.HasSuffix(line, "\n") {
+		t.Errorf("unexpected log line: %q", line)
+	}
+
+	var record goubus.AuditRecord
+
+	err := json.Unmarshal([]byte(line), &record)
+	if err != nil || record.Actor != "provisioner" || record.Service != "rpc-sys" || record.Args["user"] != "root" {
+		t.Errorf("unexpected record: %+v (%v)", record, err)
+	}
+}
+
+func TestAuditTransport_NestedSecrets(t *testing.T) {
+	ctx := context.Background()
+
+	mock := testutil.NewMockTransport()
+	mock.AddResponse("uci", "set", map[string]any{})
+	mock.AddResponse("file", "write", map[string]any{})
+
+	client := goubus.NewAuditTransport(mock, goubus.WithAuditSink(goubus.NewDeviceAuditLog(mock, "/tmp/audit.log", 0, 0)))
+
+	_, _ = client.Call(ctx, "uci", "set", map[string]any{
+		"config":  "wireless",
+		"section": "default_radio0",
+		"values": map[string]any{
+			"ssid":     "Home",
+			"key":      "hunter2",
+			"password": "hunter3",
+			"list":     []any{map[string]any{"secret": "hunter4"}},
+		},
+	})
+
+	params, _ := mock.GetLastCall().Data.(map[string]any)
+
+	line, _ := params["data"].(string)
+	if strings.Contains(line, "hunter") || !strings.Contains(line, `"ssid":"Home"`) {
+		t.Errorf("expected the nested secrets to be redacted, got %q", line)
+	}
+}
+
+func TestAuditTransport_Rotation(t *testing.T) {
+	ctx := context.Background()
+
+	mock := testutil.NewMockTransport()
+	mock.AddResponse("uci", "set", map[string]any{})
+	mock.AddResponse("file", "stat", map[string]any{"size": 4096})
+	mock.AddResponse("file", "exec", map[string]any{"code": 0})
+	mock.AddResponse("file", "write", map[string]any{})
+
+	sink := goubus.NewDeviceAuditLog(mock, "/tmp/audit.log", 4096, 2)
+	client := goubus.NewAuditTransport(mock, goubus.WithAuditSink(sink))
+
+	_, _ = client.Call(ctx, "uci", "set", map[string]any{"config": "network"})
+
+	var moves [][]string
+
+	for _, call := range mock.Calls {
+		if call.Method == "exec" {
+			params, _ := call.Data.(map[string]any)
+			args, _ := params["params"].([]string)
+			moves = append(moves, args)
+		}
+	}
+
+	want := [][]string{
+		{"-f", "/tmp/audit.log.1", "/tmp/audit.log.2"},
+		{"-f", "/tmp/audit.log", "/tmp/audit.log.1"},
+	}
+	if !slices.EqualFunc(moves, want, slices.Equal) {
+		t.Errorf("unexpected rotation: %v", moves)
+	}
+
+	if last := mock.GetLastCall(); last.Method != "write" {
+		t.Errorf("expected the record to be written after rotation, got %+v", last)
+	}
+}
+
+// introspectingMock is a mock transport that also lists objects.
+type introspectingMock struct {
+	*testutil.MockTransport
+
+	objects []goubus.ObjectInfo
+}
+
+func (m *introspectingMock) Objects(_ context.Context, _ string) ([]goubus.ObjectInfo, error) {
+	return m.objects, nil
+}
+
+func TestAuditTransport_Forwarding(t *testing.T) {
+	ctx := context.Background()
+
+	mock := &introspectingMock{
+		MockTransport: testutil.NewMockTransport(),
+		objects:       []goubus.ObjectInfo{{Path: "hostapd.phy0-ap0"}, {Path: "system"}},
+	}
+	mock.AddResponse("uci", "set", map[string]any{})
+	mock.AddResponse("uci", "get", map[string]any{})
+
+	var records []goubus.AuditRecord
+
+	client := goubus.NewAuditTransport(mock, goubus.WithAuditSink(auditSinkFunc(
+		func(_ context.Context, record goubus.AuditRecord) error {
+			records = append(records, record)
+
+			return nil
+		})))
+
+	sub, err := goubus.Listen(ctx, client, "ubus.object.*")
+	if err != nil {
+		t.Fatalf("expected events to reach the wrapped transport, got %v", err)
+	}
+	defer sub.Close()
+
+	mock.EmitEvent("ubus.object.add", map[string]any{"path": "hostapd.phy1-ap0"})
+
+	select {
+	case ev := <-sub.Events():
+		if ev.Type != "ubus.object.add" {
+			t.Errorf("unexpected event: %+v", ev)
+		}
+	case <-time.After(time.Second):
+		t.Error("no event delivered")
+	}
+
+	objects, err := goubus.Objects(ctx, client, "hostapd.*")
+	if err != nil || len(objects) != 2 {
+		t.Fatalf("expected the objects of the wrapped transport, got %v, %v", objects, err)
+	}
+
+	info, err := goubus.Lookup(ctx, client, "system")
+	if err != nil || info.Path != "system" {
+		t.Errorf("unexpected lookup: %+v, %v", info, err)
+	}
+
+	results, err := goubus.CallBatch(ctx, client, []goubus.BatchCall{
+		{Service: "uci", Method: "get", Data: map[string]any{"config": "network"}},
+		{Service: "uci", Method: "set", Data: map[string]any{"config": "network"}},
+	})
+	if err != nil || len(results) != 2 {
+		t.Fatalf("CallBatch failed: %v", err)
+	}
+
+	if len(records) != 1 || records[0].Method != "set" {
+		t.Errorf("expected only the batched write to be recorded, got %+v", records)
+	}
+}
+
+// auditSinkFunc adapts a function to an AuditSink.
+type auditSinkFunc func(ctx context.Context, record goubus.AuditRecord) error
+
+func (f auditSinkFunc) Record(ctx context.Context, record goubus.AuditRecord) error {
+	return f(ctx, record)
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package goubus
+
+import (
+	"context"
+)
+
+// BatchCall is a single invocation within a batch.
+type BatchCall struct {
+	Data    any
+	Service string
+	Method  string
+}
+
+// BatchResult is the outcome of a single invocation within a batch.
+// Err is set when the call failed; otherwise Result holds the reply.
+type BatchResult struct {
+	Result Result
+	Err    error
+}
+
+// Batcher is implemented by transports that can execute several calls in one round trip.
+type Batcher interface {
+	// CallBatch executes calls and returns one result per call, in order.
+	// The returned error is only set when the batch as a whole could not be executed.
+	CallBatch(ctx context.Context, calls []BatchCall) ([]BatchResult, error)
+}
+
+// CallBatch executes calls in one round trip if the transport supports it and one after another otherwise.
+func CallBatch(ctx context.Context, t Transport, calls []BatchCall) ([]BatchResult, error) {
+	if batcher, ok := t.(Batcher); ok {
+		return batcher.CallBatch(ctx, calls)
+	}
+
+	return callEach(ctx, t, calls)
+}
+
+// callEach executes calls one after another.
+func callEach(ctx context.Context, t Transport, calls []BatchCall) ([]BatchResult, error) {
+	results := make([]BatchResult, len(calls))
+
+	for i, call := range calls {
+		err := ctx.Err()
+		if err != nil {
+			return nil, err
+		}
+
+		results[i].Result, results[i].Err = t.Call(ctx, call.Service, call.Method, call.Data)
+	}
+
+	return results, nil
+}
+
+// Batch collects calls to be executed together, e.g. reading the status of every interface
+// for a dashboard refresh in a single round trip.
+type Batch struct {
+	transport Transport
+	calls     []BatchCall
+}
+
+// NewBatch creates an empty batch for the transport.
+func NewBatch(t Transport) *Batch {
+	return &Batch{transport: t}
+}
+
+// Add queues a call and returns its index in the results of Do.
+func (b *Batch) Add(service, method string, data any) int {
+	b.calls = append(b.calls, BatchCall{Service: service, Method: method, Data: data})
+
+	return len(b.calls) - 1
+}
+
+// Len returns the number of queued calls.
+func (b *Batch) Len() int {
+	return len(b.calls)
+}
+
+// Do executes the queued calls and returns one result per call, in the order they were added.
+func (b *Batch) Do(ctx context.Context) ([]BatchResult, error) {
+	if len(b.calls) == 0 {
+		return nil, nil
+	}
+
+	return CallBatch(ctx, b.transport, b.calls)
+}
+
+// BatchAs decodes the reply of a batch result into T, like Call.
+func BatchAs[T any](res BatchResult) (*T, error) {
+	if res.Err != nil {
+		return nil, res.Err
+	}
+
+	var target T
+
+	err := res.Result.Unmarshal(&target)
+	if err != nil {
+		return nil, err
+	}
+
+	return &target, nil
+}
@@ -0,0 +1,318 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package goubus
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/logging"
+)
+
+const (
+	// DefaultStaticTTL is the default TTL of replies that only change with a firmware upgrade,
+	// such as the board information or the supported frequencies.
+	DefaultStaticTTL = 10 * time.Minute
+	// DefaultConfigTTL is the default TTL of UCI reads.
+	DefaultConfigTTL = 30 * time.Second
+
+	// cacheSweepSize is the number of entries above which expired entries are dropped.
+	cacheSweepSize = 1024
+)
+
+// DefaultCacheTTLs are the read-only calls a CachedTransport caches unless configured otherwise,
+// keyed by "object.method".
+var DefaultCacheTTLs = map[string]time.Duration{
+	"system.board":          DefaultStaticTTL,
+	"iwinfo.freqlist":       DefaultStaticTTL,
+	"iwinfo.countrylist":    DefaultStaticTTL,
+	"iwinfo.txpowerlist":    DefaultStaticTTL,
+	"iwinfo.htmodelist":     DefaultStaticTTL,
+	"uci.configs":           DefaultConfigTTL,
+	"uci.get":               DefaultConfigTTL,
+	"uci.state":             DefaultConfigTTL,
+	"luci-rpc.getBoardJSON": DefaultStaticTTL,
+}
+
+// mutatingMethods are the ubus methods whose successful calls drop the cached replies of their
+// object, such as uci.set or file.write.
+var mutatingMethods = map[string]bool{
+	"add": true, "apply": true, "commit": true, "confirm": true, "delete": true, "down": true,
+	"exec": true, "init": true, "order": true, "reload": true, "remove": true, "rename": true,
+	"renew": true, "restart": true, "revert": true, "rollback": true, "set": true, "up": true,
+	"write": true,
+}
+
+// CacheOption defines a functional option for a CachedTransport.
+type CacheOption func(*CachedTransport)
+
+// WithCacheTTL caches successful replies of service.method for ttl; a non-positive ttl stops
+// caching the method. Replies are cached per argument set.
+func WithCacheTTL(service, method string, ttl time.Duration) CacheOption {
+	return func(ct *CachedTransport) {
+		key := service + "." + method
+		if ttl <= 0 {
+			delete(ct.ttls, key)
+
+			return
+		}
+
+		ct.ttls[key] = ttl
+	}
+}
+
+// WithoutDefaultCacheTTLs starts from an empty set of cached methods instead of DefaultCacheTTLs.
+func WithoutDefaultCacheTTLs() CacheOption {
+	return func(ct *CachedTransport) {
+		clear(ct.ttls)
+	}
+}
+
+// WithCacheInvalidation makes a successful call of service.method drop the cached replies of
+// service and objects, e.g. WithCacheInvalidation("uci", "commit", "network") when network status
+// is cached. Without objects, it marks a method that is not among the well-known mutating ones
+// as changing its own object.
+func WithCacheInvalidation(service, method string, objects ...string) CacheOption {
+	return func(ct *CachedTransport) {
+		key := service + "." + method
+		ct.invalidations[key] = append(ct.invalidations[key], objects...)
+	}
+}
+
+// WithInvalidationHook registers a function called with the objects whose cached replies were
+// dropped, e.g. to refresh a dashboard.
+func WithInvalidationHook(hook func(objects []string)) CacheOption {
+	return func(ct *CachedTransport) {
+		ct.hooks = append(ct.hooks, hook)
+	}
+}
+
+// CachedTransport wraps a Transport and serves repeated read-only calls from memory, reducing the
+// load dashboards put on a device when they refresh frequently. Only methods with a TTL are
+// cached, and only their successful replies. A successful call of a well-known mutating method,
+// such as set, commit, apply, write or exec, drops the cached replies of its object, so uci.set,
+// uci.commit or uci.apply invalidate the cached UCI reads; WithCacheInvalidation adds other
+// methods and cross-object invalidations. A reply that was in flight while its object was
+// invalidated is returned but not cached. Batches are sent as individual calls; subscriptions,
+// events and introspection are forwarded to the wrapped transport uncached.
+type CachedTransport struct {
+	Transport
+
+	logger        *slog.Logger
+	ttls          map[string]time.Duration
+	invalidations map[string][]string
+	entries       map[cacheKey]cacheEntry
+	// generations count the invalidations of each object and epoch those of the whole cache.
+	generations map[string]uint64
+	hooks       []func(objects []string)
+	epoch       uint64
+	mu          sync.Mutex
+}
+
+var (
+	_ Transport    = (*CachedTransport)(nil)
+	_ Subscriber   = (*CachedTransport)(nil)
+	_ Introspector = (*CachedTransport)(nil)
+)
+
+type cacheKey struct {
+	service string
+	method  string
+	args    string
+}
+
+type cacheEntry struct {
+	expires time.Time
+	result  cachedResult
+}
+
+// cachedResult is a reply held as JSON, so it can be decoded any number of times.
+type cachedResult json.RawMessage
+
+// Unmarshal decodes the cached reply into target.
+func (r cachedResult) Unmarshal(target any) error {
+	err := json.Unmarshal(r, target)
+	if err != nil {
+		return errdefs.Wrapf(errdefs.ErrInvalidResponse, "unmarshal result: %v", err)
+	}
+
+	return nil
+}
+
+// NewCachedTransport wraps t with a response cache for the DefaultCacheTTLs methods.
+func NewCachedTransport(t Transport, opts ...CacheOption) *CachedTransport {
+	ct := &CachedTransport{
+		Transport:     t,
+		logger:        logging.Discard(),
+		ttls:          make(map[string]time.Duration, len(DefaultCacheTTLs)),
+		invalidations: make(map[string][]string),
+		entries:       make(map[cacheKey]cacheEntry),
+		generations:   make(map[string]uint64),
+	}
+
+	maps.Copy(ct.ttls, DefaultCacheTTLs)
+
+	for _, opt := range opts {
+		opt(ct)
+	}
+
+	return ct
+}
+
+// Call serves cached methods from the cache when possible and invalidates the cache after
+// successful calls of other methods.
+func (ct *CachedTransport) Call(ctx context.Context, service, method string, data any) (Result, error) {
+	ttl, cached := ct.ttls[service+"."+method]
+	if !cached {
+		return ct.callUncached(ctx, service, method, data)
+	}
+
+	key := cacheKey{service: service, method: method, args: encodeRequestData(data)}
+
+	ct.mu.Lock()
+	entry, ok := ct.entries[key]
+	generation := ct.generation(service)
+	ct.mu.Unlock()
+
+	if ok && time.Now().Before(entry.expires) {
+		return entry.result, nil
+	}
+
+	res, err := ct.Transport.Call(ctx, service, method, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw json.RawMessage
+
+	err = res.Unmarshal(&raw)
+	if err != nil {
+		return nil, err
+	}
+
+	ct.store(key, generation, cacheEntry{expires: time.Now().Add(ttl), result: cachedResult(raw)})
+
+	return cachedResult(raw), nil
+}
+
+// Invalidate drops the cached replies of objects, or of every object if none are given. The
+// invalidation hooks run if any reply was dropped.
+func (ct *CachedTransport) Invalidate(objects ...string) {
+	ct.mu.Lock()
+
+	if len(objects) == 0 {
+		ct.epoch++
+	}
+
+	for _, object := range objects {
+		ct.generations[object]++
+	}
+
+	dropped := false
+
+	for key := range ct.entries {
+		if len(objects) == 0 || slices.Contains(objects, key.service) {
+			delete(ct.entries, key)
+
+			dropped = true
+		}
+	}
+
+	hooks := ct.hooks
+	ct.mu.Unlock()
+
+	if !dropped {
+		return
+	}
+
+	for _, hook := range hooks {
+		hook(objects)
+	}
+}
+
+// Subscribe subscribes to the notifications of object through the wrapped transport.
+func (ct *CachedTransport) Subscribe(ctx context.Context, object string) (*Subscription, error) {
+	return Subscribe(ctx, ct.Transport, object)
+}
+
+// Listen listens for the events matching pattern through the wrapped transport.
+func (ct *CachedTransport) Listen(ctx context.Context, pattern string) (*Subscription, error) {
+	return Listen(ctx, ct.Transport, pattern)
+}
+
+// Objects lists the objects matching pattern through the wrapped transport.
+func (ct *CachedTransport) Objects(ctx context.Context, pattern string) ([]ObjectInfo, error) {
+	return Objects(ctx, ct.Transport, pattern)
+}
+
+// Lookup describes object through the wrapped transport.
+func (ct *CachedTransport) Lookup(ctx context.Context, object string) (*ObjectInfo, error) {
+	return lookupObject(ctx, ct.Transport, object)
+}
+
+// SetLogger sets the logger for the cached transport and the wrapped transport.
+func (ct *CachedTransport) SetLogger(logger *slog.Logger) {
+	if logger == nil {
+		ct.logger = logging.Discard()
+	} else {
+		ct.logger = logger
+	}
+
+	ct.Transport.SetLogger(logger)
+}
+
+// callUncached performs a call that is not cached and drops the replies it may have changed.
+func (ct *CachedTransport) callUncached(ctx context.Context, service, method string, data any) (Result, error) {
+	res, err := ct.Transport.Call(ctx, service, method, data)
+	if err != nil {
+		return res, err
+	}
+
+	related, configured := ct.invalidations[service+"."+method]
+	if !configured && !mutatingMethods[method] {
+		return res, nil
+	}
+
+	objects := append([]string{service}, related...)
+	ct.logger.Debug("invalidating cache", slog.String("call", service+"."+method),
+		slog.String("objects", strings.Join(objects, ",")))
+	ct.Invalidate(objects...)
+
+	return res, nil
+}
+
+// generation returns a counter that changes whenever the replies of service are invalidated.
+// Both counters only grow, so their sum changes with either. ct.mu must be held.
+func (ct *CachedTransport) generation(service string) uint64 {
+	return ct.epoch + ct.generations[service]
+}
+
+// store adds an entry fetched at generation, unless its object was invalidated in the meantime,
+// and drops expired entries once the cache has grown large.
+func (ct *CachedTransport) store(key cacheKey, generation uint64, entry cacheEntry) {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	if ct.generation(key.service) != generation {
+		return
+	}
+
+	if len(ct.entries) >= cacheSweepSize {
+		now := time.Now()
+		for k, e := range ct.entries {
+			if !now.Before(e.expires) {
+				delete(ct.entries, k)
+			}
+		}
+	}
+
+	ct.entries[key] = entry
+}
@@ -0,0 +1,135 @@
+package goubus_test
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/honeybbq/goubus/v2"
+)
+
+func countingTransport(calls map[string]int) *mockTransport {
+	return &mockTransport{
+		callFunc: func(_ context.Context, service, method string, _ any) (goubus.Result, error) {
+			calls[service+"."+method]++
+			count := calls[service+"."+method]
+
+			return &mockResult{unmarshalFunc: func(target any) error {
+				return json.Unmarshal([]byte(`{"count":`+strconv.Itoa(count)+`}`), target)
+			}}, nil
+		},
+	}
+}
+
+func callCount(t *testing.T, tr goubus.Transport, service, method string, data any) int {
+	t.Helper()
+
+	res, err := tr.Call(context.Background(), service, method, data)
+	if err != nil {
+		t.Fatalf("%s.%s failed: %v", service, method, err)
+	}
+
+	var reply struct {
+		Count int `json:"count"`
+	}
+
+	err = res.Unmarshal(&reply)
+	if err != nil {
+		t.Fatalf("unmarshal %s.%s: %v", service, method, err)
+	}
+
+	return reply.Count
+}
+
+func TestCachedTransport(t *testing.T) {
+	calls := make(map[string]int)
+
+	var invalidated [][]string
+
+	ct := goubus.NewCachedTransport(countingTransport(calls),
+		goubus.WithCacheTTL("network.interface", "dump", time.Hour),
+		goubus.WithCacheInvalidation("uci", "commit", "network.interface"),
+		goubus.WithInvalidationHook(func(objects []string) { invalidated = append(invalidated, objects) }))
+
+	lan := map[string]any{"config": "network"}
+	wan := map[string]any{"config": "firewall"}
+
+	if callCount(t, ct, "uci", "get", lan) != 1 || callCount(t, ct, "uci", "get", lan) != 1 {
+		t.Error("expected the second uci.get to be served from the cache")
+	}
+
+	if callCount(t, ct, "uci", "get", wan) != 2 {
+		t.Error("expected other arguments to miss the cache")
+	}
+
+	callCount(t, ct, "network.interface", "dump", nil)
+	callCount(t, ct, "system", "info", nil)
+	callCount(t, ct, "iwinfo", "freqlist", nil)
+	callCount(t, ct, "iwinfo", "scan", nil)
+
+	if len(invalidated) != 0 {
+		t.Errorf("expected non-mutating calls to keep the cache, got %v", invalidated)
+	}
+
+	callCount(t, ct, "uci", "commit", lan)
+
+	if callCount(t, ct, "uci", "get", lan) != 3 || callCount(t, ct, "network.interface", "dump", nil) != 2 {
+		t.Error("expected uci.commit to invalidate uci and network.interface")
+	}
+
+	if len(invalidated) != 1 || len(invalidated[0]) != 2 {
+		t.Errorf("expected one invalidation of two objects, got %v", invalidated)
+	}
+
+	ct.Invalidate()
+
+	if callCount(t, ct, "system", "board", nil) != 1 || callCount(t, ct, "uci", "get", lan) != 4 {
+		t.Error("expected Invalidate to clear the cache")
+	}
+}
+
+func TestCachedTransport_TTL(t *testing.T) {
+	calls := make(map[string]int)
+
+	ct := goubus.NewCachedTransport(countingTransport(calls),
+		goubus.WithoutDefaultCacheTTLs(),
+		goubus.WithCacheTTL("iwinfo", "freqlist", time.Millisecond))
+
+	callCount(t, ct, "iwinfo", "freqlist", nil)
+	callCount(t, ct, "system", "board", nil)
+	callCount(t, ct, "system", "board", nil)
+
+	time.Sleep(5 * time.Millisecond)
+
+	if callCount(t, ct, "iwinfo", "freqlist", nil) != 2 || calls["system.board"] != 2 {
+		t.Errorf("expected expired and uncached calls to reach the device, got %v", calls)
+	}
+}
+
+func TestCachedTransport_InFlightInvalidation(t *testing.T) {
+	calls := make(map[string]int)
+	counting := countingTransport(calls)
+
+	var ct *goubus.CachedTransport
+
+	// The configuration changes while uci.get is in flight, so its reply may be stale.
+	ct = goubus.NewCachedTransport(&mockTransport{
+		callFunc: func(ctx context.Context, service, method string, data any) (goubus.Result, error) {
+			if calls["uci.get"] == 0 {
+				ct.Invalidate("uci")
+			}
+
+			return counting.Call(ctx, service, method, data)
+		},
+	})
+
+	if callCount(t, ct, "uci", "get", nil) != 1 || callCount(t, ct, "uci", "get", nil) != 2 {
+		t.Error("expected a reply fetched across an invalidation not to be cached")
+	}
+
+	if callCount(t, ct, "uci", "get", nil) != 2 {
+		t.Error("expected later replies to be cached")
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package goubus
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+// objectPackages maps ubus objects, or the part of their path before the first dot, to the OpenWrt
+// package that registers them.
+var objectPackages = map[string]string{
+	"container":      "procd-ujail",
+	"dhcp":           "odhcpd",
+	"dnsmasq":        "dnsmasq",
+	"file":           "rpcd-mod-file",
+	"hostapd":        "hostapd",
+	"iwinfo":         "rpcd-mod-iwinfo",
+	"log":            "logd",
+	"luci":           "luci-base",
+	"luci-rpc":       "rpcd-mod-luci",
+	"luci.upnp":      "luci-app-upnp",
+	"mwan3":          "mwan3",
+	"network":        "netifd",
+	"rc":             "rpcd",
+	"rpc-sys":        "rpcd-mod-rpcsys",
+	"service":        "procd",
+	"session":        "rpcd",
+	"system":         "procd",
+	"uci":            "rpcd",
+	"umdns":          "umdns",
+	"wpa_supplicant": "wpa-supplicant",
+}
+
+// Requirer is implemented by managers to list the ubus objects they call. Many of them, such as
+// iwinfo, luci-rpc or umdns, are optional packages on OpenWrt.
+type Requirer interface {
+	// RequiredObjects lists the objects; a trailing "*" matches any object with the given prefix.
+	RequiredObjects() []string
+}
+
+// ObjectPackage returns the OpenWrt package that registers a ubus object, or "" if it is not known.
+func ObjectPackage(object string) string {
+	if pkg, ok := objectPackages[object]; ok {
+		return pkg
+	}
+
+	prefix, _, _ := strings.Cut(object, ".")
+
+	return objectPackages[prefix]
+}
+
+// Supports reports whether every ubus object required by r is registered on the device,
+// allowing applications to adapt their features per device. It needs a transport with introspection.
+func Supports(ctx context.Context, t Transport, r Requirer) (bool, error) {
+	missing, err := MissingObjects(ctx, t, r.RequiredObjects()...)
+	if err != nil {
+		return false, err
+	}
+
+	return len(missing) == 0, nil
+}
+
+// MissingObjects returns the objects that are not registered on the device, in the given order.
+func MissingObjects(ctx context.Context, t Transport, objects ...string) ([]string, error) {
+	registered, err := Objects(ctx, t, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+
+	for _, object := range objects {
+		prefix, wildcard := strings.CutSuffix(object, "*")
+
+		found := false
+
+		for i := range registered {
+			path := registered[i].Path
+			if path == object || (wildcard && strings.HasPrefix(path, prefix)) {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			missing = append(missing, object)
+		}
+	}
+
+	return missing, nil
+}
+
+// registeredObjects remembers the objects a client found registered after a call to them failed
+// with ErrNotFound. Such a reply usually comes from the method, e.g. for a missing uci section or
+// file, so the object is looked up only once rather than after every such reply.
+type registeredObjects struct {
+	objects sync.Map
+}
+
+// missing reports whether lookup finds object not registered. Objects found registered are not
+// looked up again.
+func (r *registeredObjects) missing(object string, lookup func() error) bool {
+	if _, ok := r.objects.Load(object); ok {
+		return false
+	}
+
+	err := lookup()
+	if err == nil {
+		r.objects.Store(object, struct{}{})
+	}
+
+	return errdefs.IsNotFound(err)
+}
+
+// missingObject returns the error for a call to an object that is not registered.
+func missingObject(object string) error {
+	return &errdefs.CapabilityError{Object: object, Package: ObjectPackage(object)}
+}
+
+// Supports reports whether every ubus object required by r is registered on the device.
+func (rc *RpcClient) Supports(ctx context.Context, r Requirer) (bool, error) {
+	return Supports(ctx, rc, r)
+}
+
+// Supports reports whether every ubus object required by r is registered on the device.
+func (c *SocketClient) Supports(ctx context.Context, r Requirer) (bool, error) {
+	return Supports(ctx, c, r)
+}
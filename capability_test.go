@@ -0,0 +1,165 @@
+package goubus_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/opkg"
+	"github.com/honeybbq/goubus/v2/internal/base/rc"
+	"github.com/honeybbq/goubus/v2/internal/base/upnp"
+)
+
+type requirer []string
+
+func (r requirer) RequiredObjects() []string {
+	return r
+}
+
+// newCapabilityServer serves the registered objects. A call answered in results, keyed by
+// "object.method", returns that ubus result array; any other call fails like rpcd does for an
+// object it does not know.
+func newCapabilityServer(t *testing.T, results map[string]string, registered ...string) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+			Params []any  `json:"params"`
+		}
+
+		err := json.NewDecoder(request.Body).Decode(&req)
+		if err != nil || len(req.Params) == 0 {
+			t.Errorf("unexpected request: %v", err)
+
+			return
+		}
+
+		if req.Method == "list" {
+			pattern, _ := req.Params[0].(string)
+			prefix, wildcard := strings.CutSuffix(pattern, "*")
+
+			var entries []string
+
+			for _, object := range registered {
+				if object == pattern || (wildcard && strings.HasPrefix(object, prefix)) {
+					entries = append(entries, fmt.Sprintf("%q:{}", object))
+				}
+			}
+
+			_, _ = fmt.Fprintf(writer, `{"jsonrpc":"2.0","id":1,"result":{%s}}`, strings.Join(entries, ","))
+
+			return
+		}
+
+		if req.Params[0] == testUbusAuthSession {
+			_, _ = fmt.Fprint(writer, `{"jsonrpc":"2.0","id":1,"result":[0,`+
+				`{"ubus_rpc_session":"12345678901234567890123456789012","timeout":3600}]}`)
+
+			return
+		}
+
+		if len(req.Params) > 2 {
+			if result, ok := results[fmt.Sprintf("%v.%v", req.Params[1], req.Params[2])]; ok {
+				_, _ = fmt.Fprintf(writer, `{"jsonrpc":"2.0","id":1,"result":%s}`, result)
+
+				return
+			}
+		}
+
+		_, _ = fmt.Fprint(writer, `{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"Object not found"}}`)
+	}))
+}
+
+func TestSupports(t *testing.T) {
+	server := newCapabilityServer(t, nil, "system", "uci", "hostapd.phy0-ap0")
+	defer server.Close()
+
+	ctx := context.Background()
+
+	client, err := goubus.NewRpcClient(ctx, strings.TrimPrefix(server.URL, "http://"), "user", "pass")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Supports", func(t *testing.T) {
+		ok, err := client.Supports(ctx, requirer{"system", "hostapd.*"})
+		if err != nil || !ok {
+			t.Errorf("expected the objects to be supported, got %v (%v)", ok, err)
+		}
+
+		missing, err := goubus.MissingObjects(ctx, client, "uci", "iwinfo", "wpa_supplicant.*")
+		if err != nil || strings.Join(missing, ",") != "iwinfo,wpa_supplicant.*" {
+			t.Errorf("unexpected missing objects: %v (%v)", missing, err)
+		}
+	})
+
+	t.Run("CapabilityError", func(t *testing.T) {
+		_, err := client.Call(ctx, "iwinfo", "devices", nil)
+
+		var capErr *errdefs.CapabilityError
+		if !errors.As(err, &capErr) || !errdefs.IsNotSupported(err) || errdefs.IsNotFound(err) {
+			t.Fatalf("expected a capability error, got %v", err)
+		}
+
+		if capErr.Object != "iwinfo" || capErr.Package != "rpcd-mod-iwinfo" || capErr.Hint() == "" {
+			t.Errorf("unexpected capability error: %+v", capErr)
+		}
+	})
+
+	t.Run("ObjectPackage", func(t *testing.T) {
+		if pkg := goubus.ObjectPackage("network.interface.wan"); pkg != "netifd" {
+			t.Errorf("unexpected package: %q", pkg)
+		}
+
+		if pkg := goubus.ObjectPackage("custom"); pkg != "" {
+			t.Errorf("unexpected package: %q", pkg)
+		}
+	})
+}
+
+// TestCapabilityFallbacks checks that managers fall back when an optional object is missing,
+// which the transports report as a CapabilityError rather than ErrNotFound.
+func TestCapabilityFallbacks(t *testing.T) {
+	server := newCapabilityServer(t, map[string]string{
+		"file.read":    `[0,{"data":"TCP:8080:192.168.1.20:80:0:web\n"}]`,
+		"file.exec":    `[0,{"code":0,"stdout":"busybox - 1.36.1-1\n"}]`,
+		"file.list":    `[0,{"entries":[{"name":"dnsmasq","type":"file"}]}]`,
+		"service.list": `[0,{"dnsmasq":{"instances":{"instance1":{"running":true}}}}]`,
+	}, "file", "uci", "service")
+	defer server.Close()
+
+	ctx := context.Background()
+
+	client, err := goubus.NewRpcClient(ctx, strings.TrimPrefix(server.URL, "http://"), "user", "pass")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	leases, err := upnp.New(client).Leases(ctx)
+	if err != nil || len(leases) != 1 || leases[0].Description != "web" {
+		t.Errorf("expected the lease file without luci.upnp, got %+v (%v)", leases, err)
+	}
+
+	packages, err := opkg.New(client).ListInstalled(ctx)
+	if err != nil || len(packages) != 1 || packages[0].Name != "busybox" {
+		t.Errorf("expected opkg list-installed without rpc-sys, got %+v (%v)", packages, err)
+	}
+
+	scripts, err := rc.New(client).List(ctx, "", false)
+	if err != nil || !scripts["dnsmasq"].Running {
+		t.Errorf("expected the init scripts from /etc/init.d without rc, got %+v (%v)", scripts, err)
+	}
+
+	err = rc.New(client).Init(ctx, "dnsmasq", rc.ActionRestart)
+	if err != nil {
+		t.Errorf("expected the init script to run without rc, got %v", err)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+// Command goubus-gen generates typed Go managers from ubus method signatures.
+//
+// The signatures are read from a live device, over the unix socket or JSON-RPC, or from a dump
+// saved with "ubus -v list" or the JSON-RPC "list" method:
+//
+//	goubus-gen -input ubus-list.txt -pattern 'network.*' -package network -output network_gen.go
+//	goubus-gen -host 192.168.1.1 -user root -pattern system -package system
+//
+// The JSON-RPC password is read from the OPENWRT_PASSWORD environment variable.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/codegen"
+)
+
+const connectTimeout = 30 * time.Second
+
+type options struct {
+	input   string
+	socket  string
+	host    string
+	user    string
+	pattern string
+	pkg     string
+	output  string
+}
+
+func main() {
+	var opts options
+
+	flag.StringVar(&opts.input, "input", "", "read signatures from a dump file (\"-\" for stdin)")
+	flag.StringVar(&opts.socket, "socket", "", "read signatures from the ubus unix socket at this path")
+	flag.StringVar(&opts.host, "host", "", "read signatures from the JSON-RPC endpoint of this host")
+	flag.StringVar(&opts.user, "user", "root", "JSON-RPC user name")
+	flag.StringVar(&opts.pattern, "pattern", "*", "objects to generate; a trailing \"*\" matches a prefix")
+	flag.StringVar(&opts.pkg, "package", "ubus", "package name of the generated file")
+	flag.StringVar(&opts.output, "output", "", "write the generated file here instead of stdout")
+	flag.Parse()
+
+	err := run(opts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "goubus-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(opts options) error {
+	objects, source, err := loadObjects(opts)
+	if err != nil {
+		return err
+	}
+
+	matched := objects[:0]
+
+	for _, obj := range objects {
+		if goubus.MatchEventPattern(opts.pattern, obj.Path) {
+			matched = append(matched, obj)
+		}
+	}
+
+	if len(matched) == 0 {
+		return errdefs.Wrapf(errdefs.ErrNotFound, "no objects match %q", opts.pattern)
+	}
+
+	src, err := codegen.Generate(opts.pkg, source, matched)
+	if err != nil {
+		return err
+	}
+
+	if opts.output == "" {
+		_, err = os.Stdout.Write(src)
+
+		return err
+	}
+
+	return os.WriteFile(opts.output, src, 0o644) //nolint:gosec // generated source is not secret
+}
+
+func loadObjects(opts options) ([]goubus.ObjectInfo, string, error) {
+	if opts.input != "" {
+		data, err := readInput(opts.input)
+		if err != nil {
+			return nil, "", err
+		}
+
+		objects, err := codegen.ParseDump(data)
+
+		return objects, opts.input, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), connectTimeout)
+	defer cancel()
+
+	var (
+		transport goubus.Transport
+		source    string
+		err       error
+	)
+
+	if opts.host != "" {
+		transport, err = goubus.NewRpcClient(ctx, opts.host, opts.user, os.Getenv("OPENWRT_PASSWORD"))
+		source = "JSON-RPC " + opts.host
+	} else {
+		transport, err = goubus.NewSocketClient(ctx, opts.socket)
+		source = "ubus socket"
+	}
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	defer func() {
+		_ = transport.Close()
+	}()
+
+	objects, err := goubus.Objects(ctx, transport, opts.pattern)
+
+	return objects, source, err
+}
+
+func readInput(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+
+	return os.ReadFile(path) //nolint:gosec // the dump path is chosen by the user
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package errdefs
+
+import "fmt"
+
+// CapabilityError describes a call to a ubus object that is not registered on the device, usually
+// because the package providing it is not installed. It matches ErrNotSupported via errors.Is and
+// can be extracted with errors.As.
+type CapabilityError struct {
+	// Object is the missing ubus object.
+	Object string
+	// Package is the OpenWrt package that provides the object; it is empty when unknown.
+	Package string
+}
+
+// Error implements the error interface.
+func (e *CapabilityError) Error() string {
+	if e.Package == "" {
+		return fmt.Sprintf("%v: ubus object %q is not registered", ErrNotSupported, e.Object)
+	}
+
+	return fmt.Sprintf("%v: ubus object %q is not registered; %s", ErrNotSupported, e.Object, e.Hint())
+}
+
+// Unwrap returns ErrNotSupported.
+func (e *CapabilityError) Unwrap() error {
+	return ErrNotSupported
+}
+
+// Hint returns a short instruction for installing the missing package, or "" when the package is unknown.
+func (e *CapabilityError) Hint() string {
+	if e.Package == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("install it with 'opkg install %s'", e.Package)
+}
@@ -1,6 +1,21 @@
 // Copyright (c) 2026 honeybbq
 // Licensed under the MIT License. See LICENSE file in the project root for full license information.
 
+// Package errdefs defines the errors returned by goubus.
+//
+// Every error returned by the transports and managers matches at least one of the sentinels
+// listed below through errors.Is, however deeply it is wrapped, so callers never need to
+// match error strings. The IsX predicates test for a single sentinel and Kind reports which
+// one an error belongs to:
+//
+//   - ErrInvalidCommand, ErrInvalidParameter, ErrMethodNotFound, ErrNotFound, ErrNoData,
+//     ErrPermissionDenied, ErrTimeout, ErrNotSupported, ErrUnknown and ErrConnectionFailed
+//     mirror the ubus status codes;
+//   - ErrTimeout, ErrCanceled, ErrClosed and ErrConnectionFailed also cover transport failures
+//     (see FromTransport);
+//   - ErrInvalidResponse reports replies that cannot be decoded.
+//
+// More specific errors, such as PermissionError or ErrInvalidBlobLength, match one of these as well.
 package errdefs
 
 import (
@@ -32,28 +47,78 @@ var (
 	ErrConnectionFailed = errors.New("connection failed")
 	// ErrClosed represents a client closed error.
 	ErrClosed = errors.New("client closed")
+	// ErrCanceled represents a call abandoned because its context was canceled.
+	ErrCanceled = errors.New("canceled")
 
 	// ErrInvalidResponse represents an invalid response error.
 	ErrInvalidResponse = errors.New("invalid response")
 	// ErrTestSkipped represents a test skipped error.
 	ErrTestSkipped = errors.New("test skipped")
 
-	// ErrNotUnixSocket represents an error when the path is not a unix socket.
-	ErrNotUnixSocket = errors.New("not a unix socket")
+	// ErrNotUnixSocket represents an error when the path is not a unix socket. It matches ErrConnectionFailed.
+	ErrNotUnixSocket error = &kindError{msg: "not a unix socket", kind: ErrConnectionFailed}
 	// ErrUnsupportedAttributeType represents an unsupported attribute value type error.
-	ErrUnsupportedAttributeType = errors.New("unsupported attribute value type")
-	// ErrInvalidBlobLength represents an invalid blob length error.
-	ErrInvalidBlobLength = errors.New("invalid blob length")
+	// It matches ErrInvalidParameter.
+	ErrUnsupportedAttributeType error = &kindError{msg: "unsupported attribute value type", kind: ErrInvalidParameter}
+	// ErrInvalidBlobLength represents an invalid blob length error. It matches ErrInvalidResponse.
+	ErrInvalidBlobLength error = &kindError{msg: "invalid blob length", kind: ErrInvalidResponse}
 	// ErrArrayEntryNotExtended represents an error when an array entry is not extended.
-	ErrArrayEntryNotExtended = errors.New("array entry not extended")
+	// It matches ErrInvalidResponse.
+	ErrArrayEntryNotExtended error = &kindError{msg: "array entry not extended", kind: ErrInvalidResponse}
 	// ErrTableEntryNotExtended represents an error when a table entry is not extended.
-	ErrTableEntryNotExtended = errors.New("table entry not extended")
+	// It matches ErrInvalidResponse.
+	ErrTableEntryNotExtended error = &kindError{msg: "table entry not extended", kind: ErrInvalidResponse}
 	// ErrBlobmsgPayloadTooShort represents an error when a blobmsg payload is too short.
-	ErrBlobmsgPayloadTooShort = errors.New("blobmsg payload too short")
+	// It matches ErrInvalidResponse.
+	ErrBlobmsgPayloadTooShort error = &kindError{msg: "blobmsg payload too short", kind: ErrInvalidResponse}
 	// ErrInvalidBlobmsgHeaderLength represents an error when a blobmsg header length is invalid.
-	ErrInvalidBlobmsgHeaderLength = errors.New("invalid blobmsg header length")
+	// It matches ErrInvalidResponse.
+	ErrInvalidBlobmsgHeaderLength error = &kindError{msg: "invalid blobmsg header length", kind: ErrInvalidResponse}
 )
 
+// kinds lists the sentinels every error returned by goubus matches, most specific first.
+var kinds = []error{
+	ErrInvalidCommand,
+	ErrInvalidParameter,
+	ErrMethodNotFound,
+	ErrNotFound,
+	ErrNoData,
+	ErrPermissionDenied,
+	ErrTimeout,
+	ErrCanceled,
+	ErrNotSupported,
+	ErrClosed,
+	ErrConnectionFailed,
+	ErrInvalidResponse,
+	ErrUnknown,
+}
+
+// kindError is a specific sentinel that also matches a broader one.
+type kindError struct {
+	kind error
+	msg  string
+}
+
+func (e *kindError) Error() string {
+	return e.msg
+}
+
+func (e *kindError) Unwrap() error {
+	return e.kind
+}
+
+// Kind returns the sentinel err matches, such as ErrNotFound or ErrTimeout, or nil if it matches none.
+// It allows switching on the category of an error without string matching.
+func Kind(err error) error {
+	for _, kind := range kinds {
+		if errors.Is(err, kind) {
+			return kind
+		}
+	}
+
+	return nil
+}
+
 // IsInvalidCommand checks if err is ErrInvalidCommand.
 func IsInvalidCommand(err error) bool {
 	return errors.Is(err, ErrInvalidCommand)
@@ -89,6 +154,16 @@ func IsTimeout(err error) bool {
 	return errors.Is(err, ErrTimeout)
 }
 
+// IsCanceled checks if err is ErrCanceled.
+func IsCanceled(err error) bool {
+	return errors.Is(err, ErrCanceled)
+}
+
+// IsClosed checks if err is ErrClosed.
+func IsClosed(err error) bool {
+	return errors.Is(err, ErrClosed)
+}
+
 // IsNotSupported checks if err is ErrNotSupported.
 func IsNotSupported(err error) bool {
 	return errors.Is(err, ErrNotSupported)
@@ -0,0 +1,110 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package errdefs_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+func TestPredicates(t *testing.T) {
+	tests := []struct {
+		sentinel error
+		is       func(error) bool
+	}{
+		{errdefs.ErrInvalidCommand, errdefs.IsInvalidCommand},
+		{errdefs.ErrInvalidParameter, errdefs.IsInvalidParameter},
+		{errdefs.ErrMethodNotFound, errdefs.IsMethodNotFound},
+		{errdefs.ErrNotFound, errdefs.IsNotFound},
+		{errdefs.ErrNoData, errdefs.IsNoData},
+		{errdefs.ErrPermissionDenied, errdefs.IsPermissionDenied},
+		{errdefs.ErrTimeout, errdefs.IsTimeout},
+		{errdefs.ErrCanceled, errdefs.IsCanceled},
+		{errdefs.ErrNotSupported, errdefs.IsNotSupported},
+		{errdefs.ErrUnknown, errdefs.IsUnknown},
+		{errdefs.ErrConnectionFailed, errdefs.IsConnectionFailed},
+		{errdefs.ErrClosed, errdefs.IsClosed},
+		{errdefs.ErrInvalidResponse, errdefs.IsInvalidResponse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sentinel.Error(), func(t *testing.T) {
+			wrapped := errdefs.Wrapf(errdefs.Wrapf(tt.sentinel, "inner"), "outer %d", 1)
+
+			if !tt.is(wrapped) {
+				t.Errorf("predicate does not match wrapped %v", wrapped)
+			}
+
+			if !errors.Is(errdefs.Kind(wrapped), tt.sentinel) {
+				t.Errorf("Kind(%v) = %v", wrapped, errdefs.Kind(wrapped))
+			}
+
+			if tt.is(errors.New(tt.sentinel.Error())) {
+				t.Error("predicate matches an unrelated error with the same message")
+			}
+		})
+	}
+}
+
+func TestSpecificErrorsMatchKinds(t *testing.T) {
+	tests := map[error]error{
+		errdefs.ErrInvalidBlobLength:                          errdefs.ErrInvalidResponse,
+		errdefs.ErrArrayEntryNotExtended:                      errdefs.ErrInvalidResponse,
+		errdefs.ErrTableEntryNotExtended:                      errdefs.ErrInvalidResponse,
+		errdefs.ErrBlobmsgPayloadTooShort:                     errdefs.ErrInvalidResponse,
+		errdefs.ErrInvalidBlobmsgHeaderLength:                 errdefs.ErrInvalidResponse,
+		errdefs.ErrUnsupportedAttributeType:                   errdefs.ErrInvalidParameter,
+		errdefs.ErrNotUnixSocket:                              errdefs.ErrConnectionFailed,
+		&errdefs.PermissionError{Scope: errdefs.ACLScopeUbus}: errdefs.ErrPermissionDenied,
+		&errdefs.CapabilityError{Object: "iwinfo"}:            errdefs.ErrNotSupported,
+	}
+
+	for err, kind := range tests {
+		wrapped := errdefs.Wrapf(err, "context")
+		if !errors.Is(wrapped, err) || errdefs.Kind(wrapped) != kind {
+			t.Errorf("%v: Kind = %v, want %v", err, errdefs.Kind(wrapped), kind)
+		}
+	}
+
+	if errdefs.Kind(errors.New("plain")) != nil || errdefs.Kind(nil) != nil {
+		t.Error("Kind matched an unclassified error")
+	}
+}
+
+func TestFromTransport(t *testing.T) {
+	tests := []struct {
+		err  error
+		kind error
+	}{
+		{context.DeadlineExceeded, errdefs.ErrTimeout},
+		{&net.OpError{Op: "read", Err: timeoutError{}}, errdefs.ErrTimeout},
+		{context.Canceled, errdefs.ErrCanceled},
+		{&net.OpError{Op: "write", Err: net.ErrClosed}, errdefs.ErrClosed},
+		{io.EOF, errdefs.ErrConnectionFailed},
+		{&net.OpError{Op: "dial", Err: errors.New("connection refused")}, errdefs.ErrConnectionFailed},
+		{errdefs.Wrapf(errdefs.ErrNotFound, "object"), errdefs.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		converted := errdefs.FromTransport(tt.err)
+		if errdefs.Kind(converted) != tt.kind || !errors.Is(converted, tt.err) {
+			t.Errorf("FromTransport(%v) = %v, want kind %v", tt.err, converted, tt.kind)
+		}
+	}
+
+	if errdefs.FromTransport(nil) != nil {
+		t.Error("FromTransport(nil) is not nil")
+	}
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
@@ -0,0 +1,84 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package errdefs
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ACL scopes understood by rpcd.
+const (
+	ACLScopeUbus = "ubus"
+	ACLScopeUCI  = "uci"
+)
+
+// ACL access groups understood by rpcd.
+const (
+	ACLAccessRead  = "read"
+	ACLAccessWrite = "write"
+)
+
+// aclGroupName is the group name used in generated ACL snippets.
+const aclGroupName = "goubus"
+
+// PermissionError describes a call rejected by the rpcd ACLs together with the permission that is missing.
+// It matches ErrPermissionDenied via errors.Is and can be extracted with errors.As.
+type PermissionError struct {
+	// Scope is the ACL scope that denied the call (ACLScopeUbus or ACLScopeUCI).
+	Scope string
+	// Object is the ubus object for the ubus scope, or the config name for the uci scope.
+	Object string
+	// Method is the ubus method; it is empty for the uci scope.
+	Method string
+	// Access is the ACL group (ACLAccessRead or ACLAccessWrite) the permission belongs to.
+	Access string
+}
+
+// Error implements the error interface.
+func (e *PermissionError) Error() string {
+	return fmt.Sprintf("%v: %s; grant it with an rpcd ACL such as %s", ErrPermissionDenied, e.Missing(), e.ACLSnippet())
+}
+
+// Unwrap returns ErrPermissionDenied.
+func (e *PermissionError) Unwrap() error {
+	return ErrPermissionDenied
+}
+
+// Missing returns a short human readable description of the missing permission.
+func (e *PermissionError) Missing() string {
+	if e.Scope == ACLScopeUCI {
+		return fmt.Sprintf("missing uci %s permission on %q", e.Access, e.Object)
+	}
+
+	return fmt.Sprintf("missing ubus permission on %q method %q", e.Object, e.Method)
+}
+
+// ACLSnippet returns an rpcd ACL definition (as placed in /usr/share/rpcd/acl.d/) that grants the missing permission.
+// The "goubus" group still needs to be listed in the login's read or write lists in /etc/config/rpcd.
+func (e *PermissionError) ACLSnippet() string {
+	var grant any = map[string][]string{e.Object: {e.Method}}
+	if e.Scope == ACLScopeUCI {
+		grant = []string{e.Object}
+	}
+
+	access := e.Access
+	if access == "" {
+		access = ACLAccessRead
+	}
+
+	acl := map[string]map[string]any{
+		aclGroupName: {
+			"description": "goubus access",
+			access:        map[string]any{e.Scope: grant},
+		},
+	}
+
+	snippet, err := json.Marshal(acl)
+	if err != nil {
+		return "{}"
+	}
+
+	return string(snippet)
+}
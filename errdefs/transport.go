@@ -0,0 +1,46 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package errdefs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+)
+
+// FromTransport classifies an error raised below the ubus protocol, such as a network, I/O or
+// context error, so that it matches one of the sentinels:
+//
+//   - context.DeadlineExceeded and network timeouts match ErrTimeout,
+//   - context.Canceled matches ErrCanceled,
+//   - operations on a closed connection match ErrClosed,
+//   - any other failure matches ErrConnectionFailed.
+//
+// The original error stays in the chain, and errors that already match a sentinel are returned unchanged.
+func FromTransport(err error) error {
+	if err == nil || Kind(err) != nil {
+		return err
+	}
+
+	return fmt.Errorf("%w: %w", transportKind(err), err)
+}
+
+func transportKind(err error) error {
+	var netErr net.Error
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, os.ErrDeadlineExceeded):
+		return ErrTimeout
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return ErrTimeout
+	case errors.Is(err, context.Canceled):
+		return ErrCanceled
+	case errors.Is(err, net.ErrClosed), errors.Is(err, os.ErrClosed):
+		return ErrClosed
+	default:
+		return ErrConnectionFailed
+	}
+}
@@ -0,0 +1,545 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package goubus
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/logging"
+)
+
+const defaultFailoverRetry = 30 * time.Second
+
+// FailoverMember is one of the transports of a FailoverClient, in order of preference.
+type FailoverMember struct {
+	// Transport serves the calls. It may be nil when Dial is set.
+	Transport Transport
+	// Dial (re)creates the transport, both initially when Transport is nil and after it failed.
+	// Socket clients need it to reconnect, as they do not recover from a broken connection.
+	Dial func(ctx context.Context) (Transport, error)
+	// Name identifies the member in events and logs, e.g. "socket" or "rpc".
+	Name string
+}
+
+// FailoverEvent reports that a FailoverClient changed its active transport.
+type FailoverEvent struct {
+	// Err is the failure that caused the switch; it is nil when a preferred transport recovered.
+	Err  error
+	From string
+	To   string
+}
+
+// FailoverOption defines a functional option for a FailoverClient.
+type FailoverOption func(*FailoverClient)
+
+// WithFailoverLogger sets the logger for the failover client.
+func WithFailoverLogger(logger *slog.Logger) FailoverOption {
+	return func(fc *FailoverClient) {
+		fc.logger = logger
+	}
+}
+
+// WithFailoverHandler registers a function called whenever the active transport changes.
+func WithFailoverHandler(handler func(FailoverEvent)) FailoverOption {
+	return func(fc *FailoverClient) {
+		fc.handler = handler
+	}
+}
+
+// WithRetryInterval sets how long a failed transport is skipped before it is tried again.
+func WithRetryInterval(interval time.Duration) FailoverOption {
+	return func(fc *FailoverClient) {
+		fc.retry = interval
+	}
+}
+
+// WithHealthCheck probes failed transports every interval in the background, so the client
+// returns to a preferred transport as soon as it is healthy again. The probe defaults to
+// "system board".
+func WithHealthCheck(interval time.Duration, probe func(ctx context.Context, t Transport) error) FailoverOption {
+	return func(fc *FailoverClient) {
+		fc.healthInterval = interval
+		if probe != nil {
+			fc.probe = probe
+		}
+	}
+}
+
+// FailoverClient is a Transport that sends each call to the most preferred healthy member,
+// e.g. the unix socket when running on the device, and falls back to the next member, e.g.
+// JSON-RPC, when a transport fails. Only transport failures (connection errors, timeouts,
+// closed clients) mark a member as down; ubus errors are returned to the caller unchanged.
+// A failed call is only sent again through the next member when it cannot have reached the
+// device, i.e. the connection could not be established or the client was closed. Other
+// failures, such as a timeout, are returned, so that a call that changes the device is
+// never repeated; the next call uses the next member.
+type FailoverClient struct {
+	logger         *slog.Logger
+	handler        func(FailoverEvent)
+	probe          func(ctx context.Context, t Transport) error
+	stop           context.CancelFunc
+	members        []*failoverMember
+	retry          time.Duration
+	healthInterval time.Duration
+	active         int
+	mu             sync.Mutex
+	wg             sync.WaitGroup
+}
+
+type failoverMember struct {
+	FailoverMember
+
+	downUntil time.Time
+}
+
+var (
+	_ Transport    = (*FailoverClient)(nil)
+	_ Subscriber   = (*FailoverClient)(nil)
+	_ Introspector = (*FailoverClient)(nil)
+	_ Batcher      = (*FailoverClient)(nil)
+)
+
+// NewFailoverClient creates a failover client over members, listed in order of preference.
+// Members without a Transport are dialed immediately; a failed dial only marks them as down.
+func NewFailoverClient(ctx context.Context, members []FailoverMember, opts ...FailoverOption) (*FailoverClient, error) {
+	if len(members) == 0 {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "at least one transport is required")
+	}
+
+	fc := &FailoverClient{
+		logger: logging.Discard(),
+		retry:  defaultFailoverRetry,
+		probe:  probeBoard,
+		active: -1,
+	}
+
+	for _, opt := range opts {
+		opt(fc)
+	}
+
+	for _, member := range members {
+		if member.Transport == nil && member.Dial == nil {
+			return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "transport %q needs a Transport or Dial", member.Name)
+		}
+
+		fc.members = append(fc.members, &failoverMember{FailoverMember: member})
+	}
+
+	for _, member := range fc.members {
+		if member.Transport == nil {
+			fc.redial(ctx, member)
+		}
+	}
+
+	if fc.healthInterval > 0 {
+		healthCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		fc.stop = cancel
+
+		fc.wg.Go(func() { fc.healthLoop(healthCtx) })
+	}
+
+	return fc, nil
+}
+
+// Active returns the name of the transport that served the last call.
+func (fc *FailoverClient) Active() string {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	if fc.active < 0 {
+		return ""
+	}
+
+	return fc.members[fc.active].Name
+}
+
+// Call sends the call to the most preferred healthy transport and falls back on transport failures.
+func (fc *FailoverClient) Call(ctx context.Context, service, method string, data any) (Result, error) {
+	var res Result
+
+	err := fc.send(ctx, func(t Transport) error {
+		var err error
+
+		res, err = t.Call(ctx, service, method, data)
+
+		return err
+	})
+
+	return res, err
+}
+
+// CallBatch sends the calls through the most preferred healthy transport, as one batch if it
+// supports batches and one after another otherwise. Like a single call, the batch is only sent
+// again through the next member when it cannot have reached the device.
+func (fc *FailoverClient) CallBatch(ctx context.Context, calls []BatchCall) ([]BatchResult, error) {
+	var results []BatchResult
+
+	err := fc.send(ctx, func(t Transport) error {
+		var err error
+
+		results, err = CallBatch(ctx, t, calls)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// Objects lists the objects matching pattern through the most preferred healthy transport that
+// supports introspection. Listing only reads, so it moves on to the next member after any
+// transport failure.
+func (fc *FailoverClient) Objects(ctx context.Context, pattern string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+
+	err := fc.introspect(ctx, func(t Transport) error {
+		var err error
+
+		objects, err = Objects(ctx, t, pattern)
+
+		return err
+	})
+
+	return objects, err
+}
+
+// Lookup describes object through the most preferred healthy transport that supports
+// introspection.
+func (fc *FailoverClient) Lookup(ctx context.Context, object string) (*ObjectInfo, error) {
+	var info *ObjectInfo
+
+	err := fc.introspect(ctx, func(t Transport) error {
+		var err error
+
+		info, err = lookupObject(ctx, t, object)
+
+		return err
+	})
+
+	return info, err
+}
+
+// Subscribe subscribes through the most preferred healthy transport that supports subscriptions.
+// The subscription is not moved when the active transport changes.
+func (fc *FailoverClient) Subscribe(ctx context.Context, object string) (*Subscription, error) {
+	return fc.subscribe(ctx, object, false)
+}
+
+// Listen listens through the most preferred healthy transport that supports events.
+// The subscription is not moved when the active transport changes.
+func (fc *FailoverClient) Listen(ctx context.Context, pattern string) (*Subscription, error) {
+	return fc.subscribe(ctx, pattern, true)
+}
+
+// SetLogger sets the logger for the failover client and all of its transports.
+func (fc *FailoverClient) SetLogger(logger *slog.Logger) {
+	if logger == nil {
+		logger = logging.Discard()
+	}
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	fc.logger = logger
+
+	for _, member := range fc.members {
+		if member.Transport != nil {
+			member.Transport.SetLogger(logger)
+		}
+	}
+}
+
+// Close stops the health check and closes all transports.
+func (fc *FailoverClient) Close() error {
+	if fc.stop != nil {
+		fc.stop()
+	}
+
+	fc.wg.Wait()
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	var errs []error
+
+	for _, member := range fc.members {
+		if member.Transport != nil {
+			errs = append(errs, member.Transport.Close())
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// send runs send with the most preferred healthy transport and falls back on transport failures
+// that cannot have reached the device.
+func (fc *FailoverClient) send(ctx context.Context, send func(t Transport) error) error {
+	var lastErr error
+
+	for _, candidate := range fc.candidates(ctx) {
+		err := send(candidate.transport)
+		if err == nil || !isTransportFailure(ctx, err) {
+			fc.activate(candidate.index, lastErr)
+
+			return err
+		}
+
+		fc.failed(candidate, err)
+
+		if !isUndelivered(err) {
+			return err
+		}
+
+		lastErr = err
+	}
+
+	if lastErr == nil {
+		lastErr = errdefs.Wrapf(errdefs.ErrConnectionFailed, "no transport available")
+	}
+
+	return lastErr
+}
+
+// introspect runs introspect with the healthy transports in order of preference until one
+// supports introspection and does not fail at the transport level.
+func (fc *FailoverClient) introspect(ctx context.Context, introspect func(t Transport) error) error {
+	lastErr := errdefs.Wrapf(errdefs.ErrNotSupported, "no transport supports introspection")
+
+	for _, candidate := range fc.candidates(ctx) {
+		err := introspect(candidate.transport)
+		if errdefs.IsNotSupported(err) {
+			continue
+		}
+
+		if err == nil || !isTransportFailure(ctx, err) {
+			return err
+		}
+
+		fc.failed(candidate, err)
+
+		lastErr = err
+	}
+
+	return lastErr
+}
+
+// failed logs a transport failure of candidate and marks it as down.
+func (fc *FailoverClient) failed(candidate failoverCandidate, err error) {
+	fc.logger.Warn("transport failed",
+		slog.String("transport", fc.members[candidate.index].Name),
+		slog.String("error", err.Error()))
+	fc.markDown(candidate)
+}
+
+func (fc *FailoverClient) subscribe(ctx context.Context, source string, listen bool) (*Subscription, error) {
+	lastErr := errdefs.Wrapf(errdefs.ErrNotSupported, "no transport supports subscriptions")
+
+	for _, candidate := range fc.candidates(ctx) {
+		open := Subscribe
+		if listen {
+			open = Listen
+		}
+
+		sub, err := open(ctx, candidate.transport, source)
+		if err == nil {
+			return sub, nil
+		}
+
+		if !errdefs.IsNotSupported(err) {
+			lastErr = err
+		}
+	}
+
+	return nil, lastErr
+}
+
+type failoverCandidate struct {
+	transport Transport
+	index     int
+}
+
+// candidates returns the usable members in order of preference. Down members whose retry
+// interval has elapsed are redialed if needed and tried again.
+func (fc *FailoverClient) candidates(ctx context.Context) []failoverCandidate {
+	now := time.Now()
+
+	fc.mu.Lock()
+
+	var redial []*failoverMember
+
+	for _, member := range fc.members {
+		if member.Transport == nil && now.After(member.downUntil) {
+			redial = append(redial, member)
+		}
+	}
+
+	fc.mu.Unlock()
+
+	for _, member := range redial {
+		fc.redial(ctx, member)
+	}
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	candidates := make([]failoverCandidate, 0, len(fc.members))
+
+	for index, member := range fc.members {
+		if member.Transport != nil && now.After(member.downUntil) {
+			candidates = append(candidates, failoverCandidate{transport: member.Transport, index: index})
+		}
+	}
+
+	return candidates
+}
+
+func (fc *FailoverClient) markDown(candidate failoverCandidate) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	member := fc.members[candidate.index]
+	member.downUntil = time.Now().Add(fc.retry)
+
+	if member.Dial != nil && member.Transport == candidate.transport {
+		_ = member.Transport.Close()
+		member.Transport = nil
+	}
+}
+
+func (fc *FailoverClient) activate(index int, cause error) {
+	fc.mu.Lock()
+
+	if fc.active == index {
+		fc.mu.Unlock()
+
+		return
+	}
+
+	event := FailoverEvent{To: fc.members[index].Name, Err: cause}
+	if fc.active >= 0 {
+		event.From = fc.members[fc.active].Name
+	}
+
+	fc.active = index
+	handler := fc.handler
+	fc.mu.Unlock()
+
+	fc.logger.Info("active transport changed", slog.String("from", event.From), slog.String("to", event.To))
+
+	if handler != nil {
+		handler(event)
+	}
+}
+
+// redial recreates the transport of a member that has a Dial function. A transport that was
+// dialed concurrently in the meantime is replaced and closed.
+func (fc *FailoverClient) redial(ctx context.Context, member *failoverMember) {
+	transport, err := member.Dial(ctx)
+
+	fc.mu.Lock()
+
+	if err != nil {
+		fc.logger.Debug("dial failed", slog.String("transport", member.Name), slog.String("error", err.Error()))
+		member.downUntil = time.Now().Add(fc.retry)
+		fc.mu.Unlock()
+
+		return
+	}
+
+	transport.SetLogger(fc.logger)
+	replaced := member.Transport
+	member.Transport = transport
+	member.downUntil = time.Time{}
+	fc.mu.Unlock()
+
+	if replaced != nil {
+		_ = replaced.Close()
+	}
+}
+
+// transport returns the current transport of a member.
+func (fc *FailoverClient) transport(member *failoverMember) Transport {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	return member.Transport
+}
+
+func (fc *FailoverClient) healthLoop(ctx context.Context) {
+	ticker := time.NewTicker(fc.healthInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			fc.checkHealth(ctx)
+		}
+	}
+}
+
+// checkHealth probes the members preferred over the active one and switches back to the
+// first healthy one.
+func (fc *FailoverClient) checkHealth(ctx context.Context) {
+	fc.mu.Lock()
+	preferred := fc.members
+	if fc.active >= 0 {
+		preferred = fc.members[:fc.active]
+	}
+	fc.mu.Unlock()
+
+	for index, member := range preferred {
+		if fc.transport(member) == nil && member.Dial != nil {
+			fc.redial(ctx, member)
+		}
+
+		transport := fc.transport(member)
+		if transport == nil || fc.probe(ctx, transport) != nil {
+			continue
+		}
+
+		fc.mu.Lock()
+		member.downUntil = time.Time{}
+		fc.mu.Unlock()
+
+		fc.activate(index, nil)
+
+		return
+	}
+}
+
+func probeBoard(ctx context.Context, t Transport) error {
+	_, err := t.Call(ctx, "system", "board", nil)
+
+	return err
+}
+
+// isTransportFailure reports whether err means the transport itself failed rather than the call.
+func isTransportFailure(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	return errdefs.IsConnectionFailed(err) || errdefs.IsTimeout(err) || errdefs.IsClosed(err) ||
+		errors.Is(err, context.DeadlineExceeded)
+}
+
+// isUndelivered reports whether a transport failure happened before the call was sent, so that
+// it is safe to send it again: the client was closed, or dialing the device failed or was refused.
+func isUndelivered(err error) bool {
+	var opErr *net.OpError
+
+	return errdefs.IsClosed(err) || errors.Is(err, syscall.ECONNREFUSED) ||
+		(errors.As(err, &opErr) && opErr.Op == "dial")
+}
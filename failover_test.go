@@ -0,0 +1,307 @@
+package goubus_test
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+// errRefused is the failure of a transport whose device refuses the connection.
+var errRefused = errdefs.FromTransport(&net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED})
+
+// switchableTransport fails with a refused connection while down is set.
+func switchableTransport(down *atomic.Bool, calls *atomic.Int32) *mockTransport {
+	return &mockTransport{
+		callFunc: func(_ context.Context, service, _ string, _ any) (goubus.Result, error) {
+			calls.Add(1)
+
+			if down.Load() {
+				return nil, errRefused
+			}
+
+			if service == "slow" {
+				return nil, errdefs.Wrapf(errdefs.ErrTimeout, "no reply")
+			}
+
+			if service == "missing" {
+				return nil, errdefs.ErrNotFound
+			}
+
+			return &mockResult{unmarshalFunc: func(any) error { return nil }}, nil
+		},
+	}
+}
+
+func TestFailoverClient_Fallback(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		socketDown            atomic.Bool
+		socketCalls, rpcCalls atomic.Int32
+		rpcDown               atomic.Bool
+		events                []goubus.FailoverEvent
+	)
+
+	socketDown.Store(true)
+
+	client, err := goubus.NewFailoverClient(ctx, []goubus.FailoverMember{
+		{Name: "socket", Transport: switchableTransport(&socketDown, &socketCalls)},
+		{Name: "rpc", Transport: switchableTransport(&rpcDown, &rpcCalls)},
+	}, goubus.WithRetryInterval(time.Hour), goubus.WithFailoverHandler(func(ev goubus.FailoverEvent) {
+		events = append(events, ev)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		_ = client.Close()
+	}()
+
+	for range 2 {
+		_, err = client.Call(ctx, "system", "board", nil)
+		if err != nil {
+			t.Fatalf("call failed: %v", err)
+		}
+	}
+
+	if client.Active() != "rpc" || socketCalls.Load() != 1 || rpcCalls.Load() != 2 {
+		t.Errorf("unexpected routing: active=%s socket=%d rpc=%d", client.Active(), socketCalls.Load(), rpcCalls.Load())
+	}
+
+	if len(events) != 1 || events[0].To != "rpc" || !errdefs.IsConnectionFailed(events[0].Err) {
+		t.Errorf("unexpected events: %+v", events)
+	}
+
+	_, err = client.Call(ctx, "missing", "call", nil)
+	if !errdefs.IsNotFound(err) || rpcCalls.Load() != 3 {
+		t.Errorf("expected ubus errors to be returned without failover, got %v", err)
+	}
+
+	rpcDown.Store(true)
+
+	_, err = client.Call(ctx, "system", "board", nil)
+	if !errdefs.IsConnectionFailed(err) {
+		t.Errorf("expected connection failure once every transport is down, got %v", err)
+	}
+}
+
+func TestFailoverClient_Redial(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		socketDown, rpcDown   atomic.Bool
+		socketCalls, rpcCalls atomic.Int32
+		dials                 atomic.Int32
+	)
+
+	switched := make(chan goubus.FailoverEvent, 4)
+
+	dial := func(context.Context) (goubus.Transport, error) {
+		if dials.Add(1) == 1 {
+			return nil, errdefs.ErrConnectionFailed
+		}
+
+		return switchableTransport(&socketDown, &socketCalls), nil
+	}
+
+	client, err := goubus.NewFailoverClient(ctx, []goubus.FailoverMember{
+		{Name: "socket", Dial: dial},
+		{Name: "rpc", Transport: switchableTransport(&rpcDown, &rpcCalls)},
+	}, goubus.WithRetryInterval(time.Hour),
+		goubus.WithHealthCheck(10*time.Millisecond, nil),
+		goubus.WithFailoverHandler(func(ev goubus.FailoverEvent) { switched <- ev }))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		_ = client.Close()
+	}()
+
+	_, err = client.Call(ctx, "system", "board", nil)
+	if err != nil || client.Active() != "rpc" {
+		t.Fatalf("expected the call to fall back to rpc: %v", err)
+	}
+
+	<-switched
+
+	select {
+	case ev := <-switched:
+		if ev.From != "rpc" || ev.To != "socket" || ev.Err != nil {
+			t.Errorf("unexpected event: %+v", ev)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("health check did not switch back to the socket")
+	}
+
+	_, err = client.Call(ctx, "system", "board", nil)
+	if err != nil || socketCalls.Load() < 2 {
+		t.Errorf("expected the socket to serve calls again: %v (%d calls)", err, socketCalls.Load())
+	}
+}
+
+func TestFailoverClient_NoResend(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		socketDown, rpcDown   atomic.Bool
+		socketCalls, rpcCalls atomic.Int32
+	)
+
+	client, err := goubus.NewFailoverClient(ctx, []goubus.FailoverMember{
+		{Name: "socket", Transport: switchableTransport(&socketDown, &socketCalls)},
+		{Name: "rpc", Transport: switchableTransport(&rpcDown, &rpcCalls)},
+	}, goubus.WithRetryInterval(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		_ = client.Close()
+	}()
+
+	// The call may have been executed before the reply timed out, so it is not sent again.
+	_, err = client.Call(ctx, "slow", "apply", nil)
+	if !errdefs.IsTimeout(err) || socketCalls.Load() != 1 || rpcCalls.Load() != 0 {
+		t.Errorf("expected the timeout without a resend, got %v (socket=%d rpc=%d)", err, socketCalls.Load(), rpcCalls.Load())
+	}
+
+	_, err = client.Call(ctx, "system", "board", nil)
+	if err != nil || client.Active() != "rpc" || socketCalls.Load() != 1 {
+		t.Errorf("expected the next call to skip the failed transport: %v (active %s)", err, client.Active())
+	}
+}
+
+func TestFailoverClient_NoMembers(t *testing.T) {
+	_, err := goubus.NewFailoverClient(context.Background(), nil)
+	if !errdefs.IsInvalidParameter(err) {
+		t.Errorf("expected invalid parameter, got %v", err)
+	}
+}
+
+// capableTransport is a switchable transport that also lists objects and executes batches.
+type capableTransport struct {
+	*mockTransport
+
+	down    *atomic.Bool
+	batches atomic.Int32
+	name    string
+}
+
+func (c *capableTransport) Objects(_ context.Context, _ string) ([]goubus.ObjectInfo, error) {
+	if c.down.Load() {
+		return nil, errdefs.Wrapf(errdefs.ErrTimeout, "no reply")
+	}
+
+	return []goubus.ObjectInfo{{Path: c.name}}, nil
+}
+
+func (c *capableTransport) CallBatch(ctx context.Context, calls []goubus.BatchCall) ([]goubus.BatchResult, error) {
+	c.batches.Add(1)
+
+	if c.down.Load() {
+		return nil, errRefused
+	}
+
+	results := make([]goubus.BatchResult, len(calls))
+	for i, call := range calls {
+		results[i].Result, results[i].Err = c.Call(ctx, call.Service, call.Method, call.Data)
+	}
+
+	return results, nil
+}
+
+func TestFailoverClient_Introspection(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		socketDown, rpcDown   atomic.Bool
+		socketCalls, rpcCalls atomic.Int32
+	)
+
+	socket := &capableTransport{mockTransport: switchableTransport(&socketDown, &socketCalls), down: &socketDown, name: "socket"}
+	rpc := &capableTransport{mockTransport: switchableTransport(&rpcDown, &rpcCalls), down: &rpcDown, name: "rpc"}
+
+	client, err := goubus.NewFailoverClient(ctx, []goubus.FailoverMember{
+		{Name: "plain", Transport: switchableTransport(&atomic.Bool{}, &atomic.Int32{})},
+		{Name: "socket", Transport: socket},
+		{Name: "rpc", Transport: rpc},
+	}, goubus.WithRetryInterval(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		_ = client.Close()
+	}()
+
+	// The first member cannot introspect and is skipped without being marked down.
+	objects, err := goubus.Objects(ctx, client, "")
+	if err != nil || len(objects) != 1 || objects[0].Path != "socket" {
+		t.Fatalf("expected the socket to list the objects, got %v, %v", objects, err)
+	}
+
+	// Listing only reads, so a timeout moves on to the next member.
+	socketDown.Store(true)
+
+	info, err := goubus.Lookup(ctx, client, "rpc")
+	if err != nil || info.Path != "rpc" {
+		t.Errorf("expected the lookup to fall back, got %+v, %v", info, err)
+	}
+
+	_, err = goubus.Lookup(ctx, client, "network")
+	if !errdefs.IsNotFound(err) {
+		t.Errorf("expected a missing object to be reported, got %v", err)
+	}
+
+	ok, err := goubus.Supports(ctx, client, requirer{"rpc"})
+	if err != nil || !ok {
+		t.Errorf("expected the capability check to pass, got %v, %v", ok, err)
+	}
+}
+
+func TestFailoverClient_Batch(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		socketDown, rpcDown   atomic.Bool
+		socketCalls, rpcCalls atomic.Int32
+	)
+
+	socket := &capableTransport{mockTransport: switchableTransport(&socketDown, &socketCalls), down: &socketDown}
+	rpc := &capableTransport{mockTransport: switchableTransport(&rpcDown, &rpcCalls), down: &rpcDown}
+
+	client, err := goubus.NewFailoverClient(ctx, []goubus.FailoverMember{
+		{Name: "socket", Transport: socket},
+		{Name: "rpc", Transport: rpc},
+	}, goubus.WithRetryInterval(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		_ = client.Close()
+	}()
+
+	socketDown.Store(true)
+
+	results, err := goubus.CallBatch(ctx, client, []goubus.BatchCall{
+		{Service: "system", Method: "board"},
+		{Service: "missing", Method: "get"},
+	})
+	if err != nil || len(results) != 2 || results[0].Err != nil || !errdefs.IsNotFound(results[1].Err) {
+		t.Fatalf("unexpected results: %+v, %v", results, err)
+	}
+
+	if socket.batches.Load() != 1 || rpc.batches.Load() != 1 || client.Active() != "rpc" {
+		t.Errorf("expected the refused batch to be sent once more through rpc (socket=%d rpc=%d, active %s)",
+			socket.batches.Load(), rpc.batches.Load(), client.Active())
+	}
+}
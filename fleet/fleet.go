@@ -0,0 +1,304 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+// Package fleet manages many OpenWrt devices behind one API. A Registry holds named devices,
+// each with its own transport and tag metadata, and selector queries pick the devices an
+// operation runs on:
+//
+//	reg.Select("site=berlin", "model=ax3600").Run(ctx, func(ctx context.Context, d *fleet.Device) error {
+//		_, err := system.New(d.Transport).Board(ctx)
+//		return err
+//	})
+package fleet
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+// Device is a router managed by a Registry.
+type Device struct {
+	// Transport serves the calls to the device.
+	Transport goubus.Transport
+	// Tags are free-form metadata such as "site" or "model" matched by selectors.
+	Tags map[string]string
+	Name string
+}
+
+// Tag returns the value of the tag key, or "" if it is not set.
+func (d *Device) Tag(key string) string {
+	return d.Tags[key]
+}
+
+// Result is the outcome of an operation on one device.
+type Result struct {
+	Err    error
+	Device string
+}
+
+// Registry is a concurrency-safe set of named devices.
+type Registry struct {
+	devices map[string]*Device
+	mu      sync.RWMutex
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{devices: make(map[string]*Device)}
+}
+
+// Add registers a device under name with a copy of tags.
+func (r *Registry) Add(name string, t goubus.Transport, tags map[string]string) error {
+	if name == "" || t == nil {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "device name and transport are required")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, exists := r.devices[name]
+	if exists {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "device %s already registered", name)
+	}
+
+	r.devices[name] = &Device{Name: name, Transport: t, Tags: maps.Clone(tags)}
+
+	return nil
+}
+
+// Remove unregisters a device without closing its transport and returns it.
+func (r *Registry) Remove(name string) (*Device, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	device, ok := r.devices[name]
+	if !ok {
+		return nil, errdefs.Wrapf(errdefs.ErrNotFound, "device %s", name)
+	}
+
+	delete(r.devices, name)
+
+	return device, nil
+}
+
+// Device returns a copy of the named device.
+func (r *Registry) Device(name string) (*Device, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	device, ok := r.devices[name]
+	if !ok {
+		return nil, errdefs.Wrapf(errdefs.ErrNotFound, "device %s", name)
+	}
+
+	return device.clone(), nil
+}
+
+// Names returns the sorted names of all devices.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Sorted(maps.Keys(r.devices))
+}
+
+// SetTag sets the tag key of the named device to value.
+func (r *Registry) SetTag(name, key, value string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	device, ok := r.devices[name]
+	if !ok {
+		return errdefs.Wrapf(errdefs.ErrNotFound, "device %s", name)
+	}
+
+	tags := maps.Clone(device.Tags)
+	if tags == nil {
+		tags = make(map[string]string, 1)
+	}
+
+	tags[key] = value
+	device.Tags = tags
+
+	return nil
+}
+
+// DeleteTag removes the tag key from the named device.
+func (r *Registry) DeleteTag(name, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	device, ok := r.devices[name]
+	if !ok {
+		return errdefs.Wrapf(errdefs.ErrNotFound, "device %s", name)
+	}
+
+	tags := maps.Clone(device.Tags)
+	delete(tags, key)
+	device.Tags = tags
+
+	return nil
+}
+
+// Each calls fn for every device in parallel and returns one result per device, ordered by name.
+func (r *Registry) Each(
+	ctx context.Context, fn func(ctx context.Context, d *Device) error, opts ...RunOption,
+) ([]Result, error) {
+	return r.All().Run(ctx, fn, opts...)
+}
+
+// All selects every device.
+func (r *Registry) All() *Selection {
+	return r.Select()
+}
+
+// Select selects the devices matching all selectors; see ParseSelector for the syntax.
+// An invalid selector is reported by the operations of the returned selection.
+func (r *Registry) Select(selectors ...string) *Selection {
+	sel := &Selection{}
+
+	matchers := make([]Selector, 0, len(selectors))
+
+	for _, expr := range selectors {
+		selector, err := ParseSelector(expr)
+		if err != nil {
+			sel.err = err
+
+			return sel
+		}
+
+		matchers = append(matchers, selector)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, name := range slices.Sorted(maps.Keys(r.devices)) {
+		device := r.devices[name]
+		if matchesAll(matchers, device) {
+			sel.devices = append(sel.devices, device.clone())
+		}
+	}
+
+	return sel
+}
+
+// Close closes the transports of all devices.
+func (r *Registry) Close() error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var errs []error
+
+	for _, name := range slices.Sorted(maps.Keys(r.devices)) {
+		err := r.devices[name].Transport.Close()
+		if err != nil {
+			errs = append(errs, errdefs.Wrapf(err, "close %s", name))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (d *Device) clone() *Device {
+	return &Device{Name: d.Name, Transport: d.Transport, Tags: maps.Clone(d.Tags)}
+}
+
+// Selection is a snapshot of the devices matched by a query, ordered by name.
+type Selection struct {
+	err     error
+	devices []*Device
+}
+
+// Err returns the error of an invalid selector.
+func (s *Selection) Err() error {
+	return s.err
+}
+
+// Devices returns the selected devices.
+func (s *Selection) Devices() []*Device {
+	return s.devices
+}
+
+// Names returns the names of the selected devices.
+func (s *Selection) Names() []string {
+	names := make([]string, len(s.devices))
+	for i, device := range s.devices {
+		names[i] = device.Name
+	}
+
+	return names
+}
+
+// Len returns the number of selected devices.
+func (s *Selection) Len() int {
+	return len(s.devices)
+}
+
+// Run calls fn for every selected device in parallel and returns one result per device, in selection order.
+// The error is only set for an invalid selector; failures of fn are reported in the results.
+func (s *Selection) Run(
+	ctx context.Context, fn func(ctx context.Context, d *Device) error, opts ...RunOption,
+) ([]Result, error) {
+	stream, err := s.Stream(ctx, fn, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]int, len(s.devices))
+	for i, device := range s.devices {
+		index[device.Name] = i
+	}
+
+	results := make([]Result, len(s.devices))
+	for res := range stream.Results() {
+		results[index[res.Device]] = res
+	}
+
+	return results, nil
+}
+
+// Stream calls fn for every selected device in parallel and delivers each result as soon as it completes.
+// Devices still waiting for a worker when ctx ends are reported with the context error without calling fn.
+func (s *Selection) Stream(
+	ctx context.Context, fn func(ctx context.Context, d *Device) error, opts ...RunOption,
+) (*Stream, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	config := newRunConfig(opts)
+
+	stream := &Stream{
+		results:  make(chan Result, len(s.devices)),
+		progress: Progress{Total: len(s.devices)},
+	}
+
+	var wg sync.WaitGroup
+
+	for _, device := range s.devices {
+		wg.Go(func() {
+			err := config.acquire(ctx)
+			if err == nil {
+				defer config.release()
+
+				err = fn(ctx, device)
+			}
+
+			stream.deliver(Result{Device: device.Name, Err: err})
+		})
+	}
+
+	go func() {
+		wg.Wait()
+		close(stream.results)
+	}()
+
+	return stream, nil
+}
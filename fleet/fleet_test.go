@@ -0,0 +1,253 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package fleet_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/fleet"
+	"github.com/honeybbq/goubus/v2/internal/testutil"
+)
+
+func newRegistry(t *testing.T) *fleet.Registry {
+	t.Helper()
+
+	reg := fleet.NewRegistry()
+
+	devices := map[string]map[string]string{
+		"ber-1": {"site": "berlin", "model": "ax3600"},
+		"ber-2": {"site": "berlin", "model": "rax3000m", "canary": ""},
+		"muc-1": {"site": "munich", "model": "ax3600"},
+		"lab":   nil,
+	}
+
+	for name, tags := range devices {
+		err := reg.Add(name, testutil.NewMockTransport(), tags)
+		if err != nil {
+			t.Fatalf("Add %s failed: %v", name, err)
+		}
+	}
+
+	return reg
+}
+
+func TestRegistry_Select(t *testing.T) {
+	reg := newRegistry(t)
+
+	tests := []struct {
+		selectors []string
+		want      []string
+	}{
+		{nil, []string{"ber-1", "ber-2", "lab", "muc-1"}},
+		{[]string{"site=berlin", "model=ax3600"}, []string{"ber-1"}},
+		{[]string{"model=ax3600|rax3000m"}, []string{"ber-1", "ber-2", "muc-1"}},
+		{[]string{"site!=berlin"}, []string{"lab", "muc-1"}},
+		{[]string{"canary"}, []string{"ber-2"}},
+		{[]string{"site", "!canary"}, []string{"ber-1", "muc-1"}},
+		{[]string{"name=lab"}, []string{"lab"}},
+	}
+
+	for _, tt := range tests {
+		got := reg.Select(tt.selectors...).Names()
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Select(%q) = %v, want %v", tt.selectors, got, tt.want)
+		}
+	}
+
+	for _, expr := range []string{"", "=berlin", "a!=b=c"} {
+		_, err := reg.Select(expr).Run(context.Background(), nil)
+		if !errdefs.IsInvalidParameter(err) {
+			t.Errorf("Select(%q): expected invalid parameter, got %v", expr, err)
+		}
+	}
+}
+
+func TestRegistry_Tags(t *testing.T) {
+	reg := newRegistry(t)
+
+	err := reg.SetTag("lab", "site", "berlin")
+	if err != nil {
+		t.Fatalf("SetTag failed: %v", err)
+	}
+
+	err = reg.DeleteTag("ber-2", "site")
+	if err != nil {
+		t.Fatalf("DeleteTag failed: %v", err)
+	}
+
+	got := reg.Select("site=berlin").Names()
+	if !slices.Equal(got, []string{"ber-1", "lab"}) {
+		t.Errorf("unexpected selection after retagging: %v", got)
+	}
+
+	device, err := reg.Device("lab")
+	if err != nil || device.Tag("site") != "berlin" {
+		t.Fatalf("Device failed: %+v, %v", device, err)
+	}
+
+	device.Tags["site"] = "munich"
+
+	if reg.Select("site=munich").Len() != 1 {
+		t.Error("modifying a returned device changed the registry")
+	}
+
+	if !errdefs.IsNotFound(reg.SetTag("missing", "site", "x")) {
+		t.Error("expected not found for an unknown device")
+	}
+
+	if !errdefs.IsInvalidParameter(reg.Add("lab", testutil.NewMockTransport(), nil)) {
+		t.Error("expected duplicate device to be rejected")
+	}
+}
+
+func TestSelection_Run(t *testing.T) {
+	reg := newRegistry(t)
+
+	board := func(ctx context.Context, d *fleet.Device) error {
+		_, err := d.Transport.Call(ctx, "system", "board", nil)
+
+		return err
+	}
+
+	results, err := reg.Select("site=berlin").Run(context.Background(), board)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if len(results) != 2 || results[0].Device != "ber-1" || results[1].Device != "ber-2" {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+
+	for _, res := range results {
+		if !errdefs.IsNotFound(res.Err) {
+			t.Errorf("%s: expected the mock error, got %v", res.Device, res.Err)
+		}
+	}
+}
+
+func TestSelection_Stream(t *testing.T) {
+	reg := newRegistry(t)
+	release := make(chan struct{})
+
+	stream, err := reg.All().Stream(context.Background(), func(ctx context.Context, d *fleet.Device) error {
+		if d.Name != "lab" {
+			return nil
+		}
+
+		<-release
+
+		return errdefs.ErrTimeout
+	})
+	if err != nil {
+		t.Fatalf("Stream failed: %v", err)
+	}
+
+	for range 3 {
+		res := <-stream.Results()
+		if res.Device == "lab" || res.Err != nil {
+			t.Errorf("unexpected early result: %+v", res)
+		}
+	}
+
+	progress := stream.Progress()
+	if progress.Done != 3 || progress.Total != 4 || progress.Finished() {
+		t.Errorf("unexpected progress: %+v", progress)
+	}
+
+	close(release)
+
+	rest := stream.Wait()
+	if len(rest) != 1 || rest[0].Device != "lab" || !errdefs.IsTimeout(rest[0].Err) {
+		t.Errorf("unexpected final results: %+v", rest)
+	}
+
+	progress = stream.Progress()
+	if !progress.Finished() || progress.Failed != 1 {
+		t.Errorf("unexpected final progress: %+v", progress)
+	}
+}
+
+func TestRegistry_EachWorkers(t *testing.T) {
+	reg := newRegistry(t)
+
+	var (
+		mu            sync.Mutex
+		running, peak int
+	)
+
+	results, err := reg.Each(context.Background(), func(context.Context, *fleet.Device) error {
+		mu.Lock()
+		running++
+		peak = max(peak, running)
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+
+		return nil
+	}, fleet.WithWorkers(2))
+	if err != nil {
+		t.Fatalf("Each failed: %v", err)
+	}
+
+	if len(results) != 4 || peak != 2 {
+		t.Errorf("expected 4 results with 2 workers, got %d results and %d concurrent", len(results), peak)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, _ = reg.Each(ctx, func(context.Context, *fleet.Device) error { return nil }, fleet.WithWorkers(1))
+	for _, res := range results {
+		if !errdefs.IsCanceled(res.Err) {
+			t.Errorf("%s: expected cancellation while waiting for a worker, got %v", res.Device, res.Err)
+		}
+	}
+}
+
+func TestCollect(t *testing.T) {
+	reg := newRegistry(t)
+
+	model := func(_ context.Context, d *fleet.Device) (string, error) {
+		if d.Name == "muc-1" {
+			return "", errdefs.ErrTimeout
+		}
+
+		return d.Tag("model"), nil
+	}
+
+	outcomes, err := fleet.Collect(context.Background(), reg.Select("model"), model)
+	if err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+
+	values := fleet.Values(outcomes)
+	if len(values) != 2 || values["ber-1"] != "ax3600" || values["ber-2"] != "rax3000m" {
+		t.Errorf("unexpected values: %v", values)
+	}
+
+	results := make([]fleet.Result, len(outcomes))
+	for i, outcome := range outcomes {
+		results[i] = fleet.Result{Device: outcome.Device, Err: outcome.Err}
+	}
+
+	summary := fleet.Summarize(results)
+	if !slices.Equal(summary.Succeeded, []string{"ber-1", "ber-2"}) || !slices.Equal(summary.Failed, []string{"muc-1"}) {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+
+	err = summary.Err()
+	if !errdefs.IsTimeout(err) || !strings.HasPrefix(err.Error(), "muc-1: ") {
+		t.Errorf("unexpected summary error: %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package fleet
+
+import (
+	"context"
+	"errors"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+// RunOption configures how an operation fans out over the selected devices.
+type RunOption func(*runConfig)
+
+type runConfig struct {
+	slots chan struct{}
+}
+
+// WithWorkers runs the operation on at most n devices at a time, e.g. to keep a controller
+// managing hundreds of routers from opening hundreds of connections at once. A non-positive n
+// runs all devices at once, which is the default.
+func WithWorkers(n int) RunOption {
+	return func(c *runConfig) {
+		c.slots = nil
+		if n > 0 {
+			c.slots = make(chan struct{}, n)
+		}
+	}
+}
+
+func newRunConfig(opts []RunOption) *runConfig {
+	config := &runConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return config
+}
+
+// acquire waits for a free worker.
+func (c *runConfig) acquire(ctx context.Context) error {
+	if c.slots == nil {
+		return nil
+	}
+
+	if ctx.Err() != nil {
+		return errdefs.Wrapf(errdefs.FromTransport(ctx.Err()), "wait for a worker")
+	}
+
+	select {
+	case c.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return errdefs.Wrapf(errdefs.FromTransport(ctx.Err()), "wait for a worker")
+	}
+}
+
+func (c *runConfig) release() {
+	if c.slots != nil {
+		<-c.slots
+	}
+}
+
+// Outcome is the value an operation produced for one device, or its error.
+type Outcome[T any] struct {
+	Value  T
+	Err    error
+	Device string
+}
+
+// Collect calls fn for every selected device in parallel like Selection.Run and returns the
+// values and errors per device, in selection order.
+func Collect[T any](
+	ctx context.Context, sel *Selection, fn func(ctx context.Context, d *Device) (T, error), opts ...RunOption,
+) ([]Outcome[T], error) {
+	outcomes := make([]Outcome[T], sel.Len())
+
+	index := make(map[string]int, sel.Len())
+	for i, device := range sel.Devices() {
+		index[device.Name] = i
+	}
+
+	results, err := sel.Run(ctx, func(ctx context.Context, d *Device) error {
+		value, err := fn(ctx, d)
+		outcomes[index[d.Name]].Value = value
+
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, res := range results {
+		outcomes[i].Device = res.Device
+		outcomes[i].Err = res.Err
+	}
+
+	return outcomes, nil
+}
+
+// Values returns the values of the devices that succeeded, by device name.
+func Values[T any](outcomes []Outcome[T]) map[string]T {
+	values := make(map[string]T, len(outcomes))
+
+	for _, outcome := range outcomes {
+		if outcome.Err == nil {
+			values[outcome.Device] = outcome.Value
+		}
+	}
+
+	return values
+}
+
+// Summary aggregates the results of an operation.
+type Summary struct {
+	// Errors are the errors of the failed devices, by device name.
+	Errors    map[string]error
+	Succeeded []string
+	Failed    []string
+}
+
+// Summarize splits results into succeeded and failed devices, keeping their order.
+func Summarize(results []Result) Summary {
+	summary := Summary{Errors: make(map[string]error)}
+
+	for _, res := range results {
+		if res.Err == nil {
+			summary.Succeeded = append(summary.Succeeded, res.Device)
+
+			continue
+		}
+
+		summary.Failed = append(summary.Failed, res.Device)
+		summary.Errors[res.Device] = res.Err
+	}
+
+	return summary
+}
+
+// Err joins the errors of the failed devices, each prefixed with the device name, in the order
+// of the results. It is nil if every device succeeded.
+func (s Summary) Err() error {
+	errs := make([]error, 0, len(s.Failed))
+	for _, name := range s.Failed {
+		errs = append(errs, errdefs.Wrapf(s.Errors[name], "%s", name))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package fleet
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+// Selector matches devices by their tags.
+type Selector struct {
+	Key    string
+	Values []string
+	Op     SelectorOp
+}
+
+// SelectorOp is the comparison of a Selector.
+type SelectorOp string
+
+const (
+	OpEquals    SelectorOp = "="
+	OpNotEquals SelectorOp = "!="
+	OpExists    SelectorOp = "exists"
+	OpNotExists SelectorOp = "!exists"
+)
+
+// ParseSelector parses a tag selector:
+//
+//	key=value     the tag equals value
+//	key=a|b       the tag equals one of the values
+//	key!=value    the tag is unset or differs from every value
+//	key           the tag is set
+//	!key          the tag is unset
+//
+// The special key "name" matches the device name.
+func ParseSelector(expr string) (Selector, error) {
+	expr = strings.TrimSpace(expr)
+
+	key, value, found := strings.Cut(expr, "=")
+
+	switch {
+	case expr == "" || expr == "!":
+		return Selector{}, errdefs.Wrapf(errdefs.ErrInvalidParameter, "empty selector")
+	case !found:
+		if name, negated := strings.CutPrefix(expr, "!"); negated {
+			return Selector{Key: name, Op: OpNotExists}, nil
+		}
+
+		return Selector{Key: expr, Op: OpExists}, nil
+	}
+
+	op := OpEquals
+
+	if trimmed, negated := strings.CutSuffix(key, "!"); negated {
+		key, op = trimmed, OpNotEquals
+	}
+
+	key = strings.TrimSpace(key)
+	if key == "" || strings.Contains(key, "!") || strings.Contains(value, "=") {
+		return Selector{}, errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid selector %q", expr)
+	}
+
+	values := strings.Split(value, "|")
+	for i := range values {
+		values[i] = strings.TrimSpace(values[i])
+	}
+
+	return Selector{Key: key, Op: op, Values: values}, nil
+}
+
+// Matches reports whether the device satisfies the selector.
+func (s Selector) Matches(d *Device) bool {
+	value, ok := d.Tags[s.Key]
+	if s.Key == "name" {
+		value, ok = d.Name, true
+	}
+
+	switch s.Op {
+	case OpExists:
+		return ok
+	case OpNotExists:
+		return !ok
+	case OpNotEquals:
+		return !ok || !slices.Contains(s.Values, value)
+	default:
+		return ok && slices.Contains(s.Values, value)
+	}
+}
+
+// String returns the selector in the syntax accepted by ParseSelector.
+func (s Selector) String() string {
+	switch s.Op {
+	case OpExists:
+		return s.Key
+	case OpNotExists:
+		return "!" + s.Key
+	default:
+		return s.Key + string(s.Op) + strings.Join(s.Values, "|")
+	}
+}
+
+func matchesAll(selectors []Selector, d *Device) bool {
+	for _, selector := range selectors {
+		if !selector.Matches(d) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package fleet
+
+import "sync"
+
+// Progress counts the devices of a streamed operation.
+type Progress struct {
+	Total  int
+	Done   int
+	Failed int
+}
+
+// Finished reports whether every device has completed.
+func (p Progress) Finished() bool {
+	return p.Done == p.Total
+}
+
+// Stream delivers the per-device results of an operation in completion order.
+// Results are buffered, so abandoning the stream does not block the operation.
+type Stream struct {
+	results  chan Result
+	progress Progress
+	mu       sync.Mutex
+}
+
+// Results returns the channel of results. It is closed after the last device completed.
+func (st *Stream) Results() <-chan Result {
+	return st.results
+}
+
+// Progress returns a snapshot of the completed and failed devices.
+func (st *Stream) Progress() Progress {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	return st.progress
+}
+
+// Wait drains the stream and returns the remaining results in completion order.
+func (st *Stream) Wait() []Result {
+	results := make([]Result, 0, cap(st.results))
+	for res := range st.results {
+		results = append(results, res)
+	}
+
+	return results
+}
+
+func (st *Stream) deliver(res Result) {
+	st.mu.Lock()
+	st.progress.Done++
+
+	if res.Err != nil {
+		st.progress.Failed++
+	}
+
+	st.mu.Unlock()
+
+	st.results <- res
+}
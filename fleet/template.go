@@ -0,0 +1,206 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package fleet
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/system"
+)
+
+// Facts describe a device to a Template.
+type Facts struct {
+	// Tags are the tags of the device in the registry.
+	Tags map[string]string
+	// Vars are the variables passed to the render call, e.g. per-site settings.
+	Vars      map[string]string
+	Name      string
+	Hostname  string
+	Model     string
+	BoardName string
+	Target    string
+	Version   string
+}
+
+// GatherFacts reads the board information of d and combines it with its tags and vars.
+func GatherFacts(ctx context.Context, d *Device, vars map[string]string) (*Facts, error) {
+	board, err := system.New(d.Transport).Board(ctx)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read board of %s", d.Name)
+	}
+
+	return &Facts{
+		Tags:      maps.Clone(d.Tags),
+		Vars:      maps.Clone(vars),
+		Name:      d.Name,
+		Hostname:  board.Hostname,
+		Model:     board.Model,
+		BoardName: board.BoardName,
+		Target:    board.Release.Target,
+		Version:   board.Release.Version,
+	}, nil
+}
+
+// Bundle maps UCI package names to their configuration in UCI file format.
+type Bundle map[string]string
+
+// Packages returns the sorted package names of the bundle.
+func (b Bundle) Packages() []string {
+	return slices.Sorted(maps.Keys(b))
+}
+
+// Template renders a configuration bundle per device with text/template. The output is in
+// "uci export" format: every package starts with a "package <name>" line. Besides the standard
+// functions, templates can use
+//
+//	uci VALUE              quote VALUE for an option or list line
+//	default DEFAULT VALUE  VALUE, or DEFAULT if VALUE is empty
+//	required NAME VALUE    VALUE, failing the render if it is empty
+//
+// For example:
+//
+//	package system
+//
+//	config system
+//		option hostname {{ printf "%s-%s" .Tags.site .Name | uci }}
+//		option zonename {{ index .Vars "zone" | default "UTC" | uci }}
+//
+// Referencing a missing tag or variable as a field fails the render; index yields "" instead.
+type Template struct {
+	tmpl *template.Template
+}
+
+// ParseTemplate parses a template.
+func ParseTemplate(name, text string) (*Template, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Funcs(template.FuncMap{
+		"uci":      QuoteUCI,
+		"default":  defaultValue,
+		"required": requiredValue,
+	}).Parse(text)
+	if err != nil {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "parse template %s: %v", name, err)
+	}
+
+	return &Template{tmpl: tmpl}, nil
+}
+
+// Render renders the bundle for one device.
+func (t *Template) Render(facts *Facts) (Bundle, error) {
+	var out strings.Builder
+
+	err := t.tmpl.Execute(&out, facts)
+	if err != nil {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "render %s for %s: %v", t.tmpl.Name(), facts.Name, err)
+	}
+
+	return splitBundle(out.String())
+}
+
+// RenderAll gathers the facts of every selected device in parallel and renders its bundle.
+// Devices that fail are reported in the joined error and missing from the result.
+func (t *Template) RenderAll(ctx context.Context, sel *Selection, vars map[string]string) (map[string]Bundle, error) {
+	var mu sync.Mutex
+
+	bundles := make(map[string]Bundle, sel.Len())
+
+	results, err := sel.Run(ctx, func(ctx context.Context, d *Device) error {
+		facts, err := GatherFacts(ctx, d, vars)
+		if err != nil {
+			return err
+		}
+
+		bundle, err := t.Render(facts)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		bundles[d.Name] = bundle
+		mu.Unlock()
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+
+	for _, res := range results {
+		if res.Err != nil {
+			errs = append(errs, res.Err)
+		}
+	}
+
+	return bundles, errors.Join(errs...)
+}
+
+// QuoteUCI quotes value for a UCI file, where single quotes cannot be escaped inside a quoted string.
+func QuoteUCI(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+func defaultValue(fallback, value any) any {
+	if value == nil || value == "" {
+		return fallback
+	}
+
+	return value
+}
+
+func requiredValue(name string, value any) (any, error) {
+	if value == nil || value == "" {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "%s is required", name)
+	}
+
+	return value, nil
+}
+
+// splitBundle splits rendered output at its "package" lines.
+func splitBundle(text string) (Bundle, error) {
+	bundle := Bundle{}
+
+	var (
+		current string
+		body    strings.Builder
+	)
+
+	flush := func() {
+		if current != "" {
+			bundle[current] += strings.TrimSpace(body.String()) + "\n"
+		}
+
+		body.Reset()
+	}
+
+	for line := range strings.SplitSeq(text, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == "package" {
+			flush()
+
+			current = strings.Trim(fields[1], `'"`)
+
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if current == "" && trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "configuration outside a package: %q", line)
+		}
+
+		body.WriteString(line)
+		body.WriteString("\n")
+	}
+
+	flush()
+
+	return bundle, nil
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package fleet_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/fleet"
+	"github.com/honeybbq/goubus/v2/internal/testutil"
+)
+
+const siteTemplate = `# generated
+package system
+
+config system
+	option hostname {{ printf "%s-%s" .Tags.site .Name | uci }}
+	option zonename {{ index .Vars "zone" | default "UTC" | uci }}
+	option notes {{ .Vars.note | uci }}
+
+package 'network'
+
+config interface 'lan'
+	option ipaddr {{ required "lan_ip" (index .Tags "lan_ip") | uci }}
+`
+
+func TestTemplate_RenderAll(t *testing.T) {
+	ctx := context.Background()
+	reg := fleet.NewRegistry()
+
+	for name, tags := range map[string]map[string]string{
+		"ap1": {"site": "berlin", "lan_ip": "10.0.0.1"},
+		"ap2": {"site": "berlin"},
+	} {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("system", "board", map[string]any{"hostname": "OpenWrt", "model": "Xiaomi AX3600"})
+
+		err := reg.Add(name, mock, tags)
+		if err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+
+	tmpl, err := fleet.ParseTemplate("site", siteTemplate)
+	if err != nil {
+		t.Fatalf("ParseTemplate failed: %v", err)
+	}
+
+	bundles, err := tmpl.RenderAll(ctx, reg.All(), map[string]string{"note": "it's berlin"})
+	if !errdefs.IsInvalidParameter(err) {
+		t.Errorf("expected ap2 to miss lan_ip, got %v", err)
+	}
+
+	bundle, ok := bundles["ap1"]
+	if !ok || len(bundles) != 1 {
+		t.Fatalf("unexpected bundles: %v", bundles)
+	}
+
+	wantSystem := "config system\n\toption hostname 'berlin-ap1'\n\toption zonename 'UTC'\n" +
+		"\toption notes 'it'\\''s berlin'\n"
+	if bundle["system"] != wantSystem {
+		t.Errorf("unexpected system package:\n%s", bundle["system"])
+	}
+
+	if bundle["network"] != "config interface 'lan'\n\toption ipaddr '10.0.0.1'\n" {
+		t.Errorf("unexpected network package:\n%s", bundle["network"])
+	}
+
+	_, err = tmpl.Render(&fleet.Facts{Name: "x", Tags: map[string]string{"site": "a"}})
+	if !errdefs.IsInvalidParameter(err) {
+		t.Errorf("expected a missing variable to fail, got %v", err)
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"slices"
 	"testing"
 
 	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
 )
 
 // mockTransport is a mock implementation of Transport for testing.
@@ -118,3 +120,94 @@ func runCallTestCase(t *testing.T, tt callTestCase) {
 		}
 	}
 }
+
+func TestCallBatchFallback(t *testing.T) {
+	transport := &mockTransport{callFunc: func(ctx context.Context, service, method string, data any) (goubus.Result, error) {
+		if service != "system" {
+			return nil, errdefs.ErrNotFound
+		}
+
+		return &mockResult{unmarshalFunc: func(target any) error { return nil }}, nil
+	}}
+
+	batch := goubus.NewBatch(transport)
+	batch.Add("system", "board", nil)
+	batch.Add("mwan3", "status", nil)
+
+	results, err := batch.Do(context.Background())
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+
+	if len(results) != 2 || results[0].Err != nil || !errdefs.IsNotFound(results[1].Err) {
+		t.Errorf("unexpected results: %+v", results)
+	}
+}
+
+func TestSubscribeUnsupported(t *testing.T) {
+	_, err := goubus.Subscribe(context.Background(), &mockTransport{}, "system")
+	if !errdefs.IsNotSupported(err) {
+		t.Errorf("expected not supported, got %v", err)
+	}
+}
+
+func TestObjectsUnsupported(t *testing.T) {
+	_, err := goubus.Lookup(context.Background(), &mockTransport{}, "mwan3")
+	if !errdefs.IsNotSupported(err) {
+		t.Errorf("expected not supported, got %v", err)
+	}
+}
+
+func TestMergeAndFilterSubscriptions(t *testing.T) {
+	ctx := context.Background()
+
+	source := func(types ...string) *goubus.Subscription {
+		return goubus.NewSubscription(ctx, func(_ context.Context, emit func(goubus.Event) bool) error {
+			for _, typ := range types {
+				emit(goubus.Event{Type: typ})
+			}
+
+			return nil
+		})
+	}
+
+	merged := goubus.MergeSubscriptions(ctx, source("a", "b"), source("c"))
+	filtered := goubus.FilterSubscription(ctx, merged, func(ev goubus.Event) bool {
+		return ev.Type != "b"
+	})
+
+	var got []string
+	for ev := range filtered.Events() {
+		got = append(got, ev.Type)
+	}
+
+	slices.Sort(got)
+
+	if !slices.Equal(got, []string{"a", "c"}) {
+		t.Errorf("unexpected events: %v", got)
+	}
+
+	if filtered.Err() != nil {
+		t.Errorf("unexpected error: %v", filtered.Err())
+	}
+}
+
+func TestMatchEventPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		id      string
+		want    bool
+	}{
+		{"*", "config.change", true},
+		{"network.*", "network.interface", true},
+		{"network.*", "service.instance", false},
+		{"ubus.object.add", "ubus.object.add", true},
+		{"ubus.object.add", "ubus.object.remove", false},
+	}
+
+	for _, tt := range tests {
+		if got := goubus.MatchEventPattern(tt.pattern, tt.id); got != tt.want {
+			t.Errorf("MatchEventPattern(%q, %q) = %v, want %v", tt.pattern, tt.id, got, tt.want)
+		}
+	}
+}
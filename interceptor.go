@@ -0,0 +1,135 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package goubus
+
+import (
+	"context"
+	"time"
+)
+
+// Invoker performs a call; it is the next step of an interceptor chain.
+type Invoker func(ctx context.Context, service, method string, data any) (Result, error)
+
+// CallInterceptor runs around every call of a client. Intercept may inspect or replace the
+// arguments before calling next, retry next, or inspect and replace the result and error
+// afterwards. It must call next to perform the call and be safe for concurrent use.
+type CallInterceptor interface {
+	Intercept(ctx context.Context, service, method string, data any, next Invoker) (Result, error)
+}
+
+// InterceptorFunc adapts a function to a CallInterceptor.
+type InterceptorFunc func(ctx context.Context, service, method string, data any, next Invoker) (Result, error)
+
+// Intercept calls f.
+func (f InterceptorFunc) Intercept(
+	ctx context.Context, service, method string, data any, next Invoker,
+) (Result, error) {
+	return f(ctx, service, method, data, next)
+}
+
+// CallInfo describes a call to the hooks of a CallHooks interceptor.
+type CallInfo struct {
+	// Args are the call arguments; Before may replace them.
+	Args any
+	// Err is the error of the call; it is set for After only.
+	Err     error
+	Service string
+	Method  string
+	// Duration is the time the rest of the chain took; it is set for After only.
+	Duration time.Duration
+}
+
+// CallHooks is a CallInterceptor for the common before/after case, e.g. logging or metrics.
+// Either hook may be nil.
+type CallHooks struct {
+	// Before runs before the call. Returning an error fails the call without performing it.
+	Before func(ctx context.Context, call *CallInfo) error
+	// After runs once the call returned.
+	After func(ctx context.Context, call *CallInfo)
+}
+
+// Intercept runs the hooks around next.
+func (h CallHooks) Intercept(ctx context.Context, service, method string, data any, next Invoker) (Result, error) {
+	call := &CallInfo{Service: service, Method: method, Args: data}
+
+	if h.Before != nil {
+		err := h.Before(ctx, call)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	start := time.Now()
+	res, err := next(ctx, service, method, call.Args)
+
+	if h.After != nil {
+		call.Duration = time.Since(start)
+		call.Err = err
+		h.After(ctx, call)
+	}
+
+	return res, err
+}
+
+// chainInterceptors builds an Invoker that runs interceptors in order around final.
+func chainInterceptors(interceptors []CallInterceptor, final Invoker) Invoker {
+	invoker := final
+
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], invoker
+		invoker = func(ctx context.Context, service, method string, data any) (Result, error) {
+			return interceptor.Intercept(ctx, service, method, data, next)
+		}
+	}
+
+	return invoker
+}
+
+// InterceptedTransport wraps a Transport and runs interceptors around its calls, for transports
+// without an interceptor option. It does not implement Batcher even if the wrapped transport
+// does, so CallBatch and Batch send its calls one at a time, each through the interceptors.
+// Subscriptions, events and introspection are forwarded to the wrapped transport without
+// running the interceptors.
+type InterceptedTransport struct {
+	Transport
+
+	invoke Invoker
+}
+
+var (
+	_ Transport    = (*InterceptedTransport)(nil)
+	_ Subscriber   = (*InterceptedTransport)(nil)
+	_ Introspector = (*InterceptedTransport)(nil)
+)
+
+// NewInterceptedTransport wraps t so that interceptors run around every call, the first one
+// outermost.
+func NewInterceptedTransport(t Transport, interceptors ...CallInterceptor) *InterceptedTransport {
+	return &InterceptedTransport{Transport: t, invoke: chainInterceptors(interceptors, t.Call)}
+}
+
+// Call runs the call through the interceptors.
+func (it *InterceptedTransport) Call(ctx context.Context, service, method string, data any) (Result, error) {
+	return it.invoke(ctx, service, method, data)
+}
+
+// Subscribe subscribes to the notifications of object through the wrapped transport.
+func (it *InterceptedTransport) Subscribe(ctx context.Context, object string) (*Subscription, error) {
+	return Subscribe(ctx, it.Transport, object)
+}
+
+// Listen listens for the events matching pattern through the wrapped transport.
+func (it *InterceptedTransport) Listen(ctx context.Context, pattern string) (*Subscription, error) {
+	return Listen(ctx, it.Transport, pattern)
+}
+
+// Objects lists the objects matching pattern through the wrapped transport.
+func (it *InterceptedTransport) Objects(ctx context.Context, pattern string) ([]ObjectInfo, error) {
+	return Objects(ctx, it.Transport, pattern)
+}
+
+// Lookup describes object through the wrapped transport.
+func (it *InterceptedTransport) Lookup(ctx context.Context, object string) (*ObjectInfo, error) {
+	return lookupObject(ctx, it.Transport, object)
+}
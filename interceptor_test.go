@@ -0,0 +1,197 @@
+package goubus_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/testutil"
+)
+
+func TestInterceptedTransport(t *testing.T) {
+	var (
+		order    []string
+		received []any
+	)
+
+	inner := &mockTransport{
+		callFunc: func(_ context.Context, _, _ string, data any) (goubus.Result, error) {
+			order = append(order, "call")
+			received = append(received, data)
+
+			if len(received) == 1 {
+				return nil, errdefs.ErrTimeout
+			}
+
+			return &mockResult{unmarshalFunc: func(any) error { return nil }}, nil
+		},
+	}
+
+	trace := func(name string) goubus.CallInterceptor {
+		return goubus.InterceptorFunc(func(
+			ctx context.Context, service, method string, data any, next goubus.Invoker,
+		) (goubus.Result, error) {
+			order = append(order, name)
+
+			return next(ctx, service, method, data)
+		})
+	}
+
+	retry := goubus.InterceptorFunc(func(
+		ctx context.Context, service, method string, data any, next goubus.Invoker,
+	) (goubus.Result, error) {
+		res, err := next(ctx, service, method, data)
+		if errdefs.IsTimeout(err) {
+			return next(ctx, service, method, data)
+		}
+
+		return res, err
+	})
+
+	var after goubus.CallInfo
+
+	hooks := goubus.CallHooks{
+		Before: func(_ context.Context, call *goubus.CallInfo) error {
+			call.Args = map[string]any{"mutated": true}
+
+			return nil
+		},
+		After: func(_ context.Context, call *goubus.CallInfo) {
+			after = *call
+		},
+	}
+
+	client := goubus.NewInterceptedTransport(inner, trace("outer"), hooks, retry, trace("inner"))
+
+	_, err := client.Call(context.Background(), "system", "board", nil)
+	if err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+
+	if got := strings.Join(order, ","); got != "outer,inner,call,inner,call" {
+		t.Errorf("unexpected order %s", got)
+	}
+
+	if args, _ := received[1].(map[string]any); args["mutated"] != true {
+		t.Errorf("expected mutated args, got %v", received[1])
+	}
+
+	if after.Service != "system" || after.Method != "board" || after.Err != nil {
+		t.Errorf("unexpected call info %+v", after)
+	}
+}
+
+func TestWrappedTransports_Forwarding(t *testing.T) {
+	ctx := context.Background()
+	mock := testutil.NewMockTransport()
+
+	wrappers := map[string]goubus.Transport{
+		"intercepted": goubus.NewInterceptedTransport(mock, goubus.CallHooks{}),
+		"cached":      goubus.NewCachedTransport(mock),
+		"limited":     goubus.NewLimitedTransport(mock, goubus.WithObjectLimit("*", 1)),
+	}
+
+	for name, wrapper := range wrappers {
+		sub, err := goubus.Listen(ctx, wrapper, "network.interface")
+		if err != nil {
+			t.Fatalf("%s: expected events to reach the wrapped transport, got %v", name, err)
+		}
+
+		mock.EmitEvent("network.interface", map[string]any{"action": "ifup"})
+
+		select {
+		case ev := <-sub.Events():
+			if ev.Type != "network.interface" {
+				t.Errorf("%s: unexpected event: %+v", name, ev)
+			}
+		case <-time.After(time.Second):
+			t.Errorf("%s: no event delivered", name)
+		}
+
+		sub.Close()
+
+		// The mock cannot introspect, which the wrapper reports instead of hiding it.
+		_, err = goubus.Objects(ctx, wrapper, "")
+		if !errdefs.IsNotSupported(err) || !strings.Contains(err.Error(), "MockTransport") {
+			t.Errorf("%s: expected the wrapped transport to decide on introspection, got %v", name, err)
+		}
+	}
+}
+
+func TestCallHooks_BeforeError(t *testing.T) {
+	called := false
+
+	inner := &mockTransport{
+		callFunc: func(context.Context, string, string, any) (goubus.Result, error) {
+			called = true
+
+			return nil, nil
+		},
+	}
+
+	errDenied := errors.New("denied by policy")
+	hooks := goubus.CallHooks{
+		Before: func(context.Context, *goubus.CallInfo) error { return errDenied },
+	}
+
+	_, err := goubus.NewInterceptedTransport(inner, hooks).Call(context.Background(), "system", "reboot", nil)
+	if !errors.Is(err, errDenied) || called {
+		t.Errorf("expected the call to be blocked, got %v (called %v)", err, called)
+	}
+}
+
+func TestRpcClient_Interceptors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		handleRpcCall(t, writer, request, "12345678901234567890123456789012")
+	}))
+	defer server.Close()
+
+	var calls []goubus.CallInfo
+
+	hooks := goubus.CallHooks{
+		After: func(_ context.Context, call *goubus.CallInfo) {
+			calls = append(calls, *call)
+		},
+	}
+
+	client, err := goubus.NewRpcClient(context.Background(), strings.TrimPrefix(server.URL, "http://"),
+		"user", "pass", goubus.WithRpcInterceptors(hooks))
+	if err != nil {
+		t.Fatalf("NewRpcClient failed: %v", err)
+	}
+
+	res, err := client.Call(context.Background(), "system", "info", nil)
+	if err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+
+	var info map[string]any
+
+	err = res.Unmarshal(&info)
+	if err != nil || info["hostname"] != "OpenWrt" {
+		t.Errorf("unexpected result %v (%v)", info, err)
+	}
+
+	if len(calls) != 1 || calls[0].Service != "system" || calls[0].Duration <= 0 {
+		t.Errorf("expected one intercepted call without the login, got %+v", calls)
+	}
+
+	batch := client.Batch()
+	batch.Add("system", "info", nil)
+	batch.Add("system", "board", nil)
+
+	_, err = batch.Do(context.Background())
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+
+	if len(calls) != 3 || calls[2].Method != "board" {
+		t.Errorf("expected the batched calls to be intercepted, got %+v", calls)
+	}
+}
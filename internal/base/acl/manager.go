@@ -0,0 +1,290 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package acl
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/file"
+	"github.com/honeybbq/goubus/v2/internal/base/rc"
+	"github.com/honeybbq/goubus/v2/internal/base/uci"
+)
+
+const (
+	// Dir is where rpcd reads its ACL definitions from.
+	Dir            = "/usr/share/rpcd/acl.d/"
+	aclFileMode    = 0o644
+	uciPackage     = "rpcd"
+	uciLoginType   = "login"
+	rpcdInitScript = "rpcd"
+)
+
+var fileNamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// Manager reads and changes the rpcd access control lists, which decide what a session may
+// call. Changing them needs write access to /usr/share/rpcd/acl.d and rc init of rpcd.
+type Manager struct {
+	file *file.Manager
+	uci  *uci.Manager
+	rc   *rc.Manager
+}
+
+// New creates a new base ACL Manager.
+func New(t goubus.Transport) *Manager {
+	return &Manager{file: file.New(t), uci: uci.New(t, nil), rc: rc.New(t)}
+}
+
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"file", "uci", "rc"}
+}
+
+// Groups retrieves the ACL groups of all files in the acl.d directory, sorted by name.
+func (m *Manager) Groups(ctx context.Context) ([]Group, error) {
+	list, err := m.file.List(ctx, Dir)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to list %s", Dir)
+	}
+
+	var groups []Group
+
+	for _, entry := range list.Entries {
+		if !strings.HasSuffix(entry.Name, ".json") {
+			continue
+		}
+
+		res, err := m.file.Read(ctx, Dir+entry.Name, false)
+		if err != nil {
+			return nil, errdefs.Wrapf(err, "failed to read %s", entry.Name)
+		}
+
+		parsed, err := ParseGroups(entry.Name, res.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		groups = append(groups, parsed...)
+	}
+
+	slices.SortFunc(groups, func(a, b Group) int { return cmp.Compare(a.Name, b.Name) })
+
+	return groups, nil
+}
+
+// Logins retrieves the login sections of /etc/config/rpcd in configuration order.
+func (m *Manager) Logins(ctx context.Context) ([]Login, error) {
+	sections, err := m.uci.Package(uciPackage).GetAll(ctx)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read rpcd logins")
+	}
+
+	var logins []Login
+
+	for _, section := range uci.SortedSections(sections) {
+		if section.Type == uciLoginType {
+			logins = append(logins, LoginFromSection(section))
+		}
+	}
+
+	return logins, nil
+}
+
+// Effective resolves the permissions rpcd grants to sessions of username.
+func (m *Manager) Effective(ctx context.Context, username string) (*Access, error) {
+	logins, err := m.Logins(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	index := slices.IndexFunc(logins, func(l Login) bool { return l.Username == username })
+	if index < 0 {
+		return nil, errdefs.Wrapf(errdefs.ErrNotFound, "rpcd login %s", username)
+	}
+
+	groups, err := m.Groups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return Resolve(logins[index], groups), nil
+}
+
+// Install writes groups to <name>.json in the acl.d directory, replacing an existing file,
+// and reloads rpcd. New sessions get the permissions once a login lists the groups.
+func (m *Manager) Install(ctx context.Context, name string, groups ...Group) error {
+	err := validateFileName(name)
+	if err != nil {
+		return err
+	}
+
+	definitions := make(map[string]Group, len(groups))
+
+	for _, group := range groups {
+		if group.Name == "" {
+			return errdefs.Wrapf(errdefs.ErrInvalidParameter, "ACL group without a name")
+		}
+
+		definitions[group.Name] = group
+	}
+
+	data, err := json.MarshalIndent(definitions, "", "\t")
+	if err != nil {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "failed to encode ACL groups: %v", err)
+	}
+
+	err = m.file.Replace(ctx, Dir+name+".json", string(data)+"\n", aclFileMode, false)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to write ACL file %s", name)
+	}
+
+	return m.reload(ctx)
+}
+
+// Remove deletes <name>.json from the acl.d directory and reloads rpcd.
+func (m *Manager) Remove(ctx context.Context, name string) error {
+	err := validateFileName(name)
+	if err != nil {
+		return err
+	}
+
+	err = m.file.Remove(ctx, Dir+name+".json")
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to remove ACL file %s", name)
+	}
+
+	return m.reload(ctx)
+}
+
+func (m *Manager) reload(ctx context.Context) error {
+	err := m.rc.Init(ctx, rpcdInitScript, "reload")
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to reload rpcd")
+	}
+
+	return nil
+}
+
+// GroupForError returns a group named name that grants the permission a PermissionError
+// reports as missing, ready to be installed.
+func GroupForError(name string, e *errdefs.PermissionError) Group {
+	grant := Permissions{Ubus: map[string][]string{e.Object: {e.Method}}}
+	if e.Scope == errdefs.ACLScopeUCI {
+		grant = Permissions{UCI: []string{e.Object}}
+	}
+
+	group := Group{Name: name, Description: "goubus access"}
+	if e.Access == errdefs.ACLAccessWrite {
+		group.Write = grant
+	} else {
+		group.Read = grant
+	}
+
+	return group
+}
+
+// ParseGroups decodes the groups defined in an acl.d file.
+func ParseGroups(fileName, data string) ([]Group, error) {
+	var definitions map[string]Group
+
+	err := json.Unmarshal([]byte(data), &definitions)
+	if err != nil {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidResponse, "invalid ACL file %s: %v", fileName, err)
+	}
+
+	groups := make([]Group, 0, len(definitions))
+
+	for name, group := range definitions {
+		group.Name = name
+		group.File = fileName
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+// Resolve computes the permissions login gets from groups, the way rpcd does when a session
+// is created.
+func Resolve(login Login, groups []Group) *Access {
+	access := &Access{}
+
+	for _, group := range groups {
+		write := matchesAny(login.Write, group.Name)
+		if !write && !matchesAny(login.Read, group.Name) {
+			continue
+		}
+
+		access.ReadGroups = append(access.ReadGroups, group.Name)
+		access.Read.merge(group.Read)
+
+		if write {
+			access.WriteGroups = append(access.WriteGroups, group.Name)
+			access.Write.merge(group.Write)
+		}
+	}
+
+	return access
+}
+
+// LoginFromSection converts a UCI login section into a Login.
+func LoginFromSection(section *uci.Section) Login {
+	return Login{
+		Section:  section.Name,
+		Username: section.GetString("username"),
+		Read:     section.Get("read"),
+		Write:    section.Get("write"),
+		Timeout:  section.GetInt("timeout"),
+	}
+}
+
+func (p *Permissions) merge(other Permissions) {
+	p.Ubus = mergeGrants(p.Ubus, other.Ubus)
+	p.File = mergeGrants(p.File, other.File)
+
+	for _, config := range other.UCI {
+		if !slices.Contains(p.UCI, config) {
+			p.UCI = append(p.UCI, config)
+		}
+	}
+}
+
+func mergeGrants(dst, src map[string][]string) map[string][]string {
+	for key, values := range src {
+		if dst == nil {
+			dst = make(map[string][]string)
+		}
+
+		for _, value := range values {
+			if !slices.Contains(dst[key], value) {
+				dst[key] = append(dst[key], value)
+			}
+		}
+	}
+
+	return dst
+}
+
+// matchesAny reports whether name matches one of the fnmatch patterns of a login.
+func matchesAny(patterns []string, name string) bool {
+	return slices.ContainsFunc(patterns, func(pattern string) bool {
+		ok, _ := path.Match(pattern, name)
+
+		return ok
+	})
+}
+
+func validateFileName(name string) error {
+	if !fileNamePattern.MatchString(name) || strings.HasPrefix(name, ".") {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid ACL file name %q", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package acl_test
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/acl"
+	"github.com/honeybbq/goubus/v2/internal/testutil"
+)
+
+func newACLMock() *testutil.MockTransport {
+	mock := testutil.NewMockTransport()
+	mock.AddResponse("file", "list", map[string]any{
+		"entries": []any{
+			map[string]any{"name": "luci-base.json", "type": "file"},
+			map[string]any{"name": "unauthenticated.json", "type": "file"},
+			map[string]any{"name": "README", "type": "file"},
+		},
+	})
+	mock.AddResponseForArgs("file", "read", map[string]any{"path": acl.Dir + "luci-base.json"}, map[string]any{
+		"data": `{"luci-base":{"description":"LuCI","read":{"ubus":{"luci":["getVersion"]},"uci":["luci"]},` +
+			`"write":{"uci":["luci"],"file":{"/tmp/upload.bin":["write"]}}},` +
+			`"luci-app-upnp":{"read":{"ubus":{"luci":["getFeatures"],"luci.upnp":["*"]}}}}`,
+	})
+	mock.AddResponseForArgs("file", "read", map[string]any{"path": acl.Dir + "unauthenticated.json"}, map[string]any{
+		"data": `{"unauthenticated":{"read":{"ubus":{"session":["access","login"]}}}}`,
+	})
+	mock.AddResponse("uci", "get", map[string]any{
+		"values": map[string]any{
+			"cfg01": map[string]any{
+				".type": "login", ".name": "cfg01", ".index": 0,
+				"username": "monitor", "read": []string{"luci-*"}, "write": []string{"luci-base"}, "timeout": "600",
+			},
+		},
+	})
+
+	return mock
+}
+
+func TestACLManager(t *testing.T) {
+	ctx := context.Background()
+	mock := newACLMock()
+	mgr := acl.New(mock)
+
+	t.Run("Groups", func(t *testing.T) {
+		groups, err := mgr.Groups(ctx)
+		if err != nil {
+			t.Fatalf("Groups failed: %v", err)
+		}
+
+		if len(groups) != 3 || groups[0].Name != "luci-app-upnp" || groups[1].File != "luci-base.json" ||
+			groups[1].Description != "LuCI" {
+			t.Errorf("unexpected groups: %+v", groups)
+		}
+	})
+
+	t.Run("Effective", func(t *testing.T) {
+		access, err := mgr.Effective(ctx, "monitor")
+		if err != nil {
+			t.Fatalf("Effective failed: %v", err)
+		}
+
+		if !slices.Equal(access.ReadGroups, []string{"luci-app-upnp", "luci-base"}) ||
+			!slices.Equal(access.WriteGroups, []string{"luci-base"}) {
+			t.Errorf("unexpected groups: %+v", access)
+		}
+
+		if !slices.Equal(access.Read.Ubus["luci"], []string{"getFeatures", "getVersion"}) ||
+			access.Write.File["/tmp/upload.bin"] == nil || access.Read.Ubus["session"] != nil {
+			t.Errorf("unexpected permissions: %+v", access)
+		}
+
+		_, err = mgr.Effective(ctx, "nobody")
+		if !errdefs.IsNotFound(err) {
+			t.Errorf("expected an unknown login error, got %v", err)
+		}
+	})
+
+	t.Run("Install", func(t *testing.T) {
+		testInstall(t, ctx, mock, mgr)
+	})
+}
+
+func testInstall(t *testing.T, ctx context.Context, mock *testutil.MockTransport, mgr *acl.Manager) {
+	t.Helper()
+
+	mock.AddResponse("file", "write", map[string]any{})
+	mock.AddResponse("file", "exec", map[string]any{"code": 0})
+	mock.AddResponse("file", "remove", map[string]any{})
+	mock.AddResponse("rc", "init", map[string]any{})
+
+	perr := &errdefs.PermissionError{Scope: errdefs.ACLScopeUbus, Object: "iwinfo", Method: "scan"}
+
+	err := mgr.Install(ctx, "goubus", acl.GroupForError("goubus", perr))
+	if err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+
+	var written map[string]acl.Group
+
+	for _, call := range mock.Calls {
+		params, _ := call.Data.(map[string]any)
+		if path, _ := params["path"].(string); call.Method == "write" && strings.HasPrefix(path, acl.Dir+"goubus.json.tmp-") {
+			_ = json.Unmarshal([]byte(params["data"].(string)), &written)
+		}
+	}
+
+	if !slices.Equal(written["goubus"].Read.Ubus["iwinfo"], []string{"scan"}) {
+		t.Errorf("unexpected ACL file: %+v", written)
+	}
+
+	if call := mock.GetLastCall(); call.Service != "rc" || call.Method != "init" {
+		t.Errorf("expected rpcd to be reloaded, got %+v", call)
+	}
+
+	err = mgr.Remove(ctx, "../config/rpcd")
+	if !errdefs.IsInvalidParameter(err) {
+		t.Errorf("expected an invalid file name error, got %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package acl
+
+// Permissions are the grants of one access level of an ACL group.
+type Permissions struct {
+	// Ubus maps ubus objects to the methods that may be called; both may use wildcards.
+	Ubus map[string][]string `json:"ubus,omitempty"`
+	// UCI lists the configs that may be read or written.
+	UCI []string `json:"uci,omitempty"`
+	// File maps paths to file operations such as "read", "write", "list" or "exec".
+	File map[string][]string `json:"file,omitempty"`
+}
+
+// Group is an ACL group as defined in /usr/share/rpcd/acl.d.
+type Group struct {
+	// Name is the group name, the key of the definition in its file.
+	Name string `json:"-"`
+	// File is the acl.d file that defines the group.
+	File        string      `json:"-"`
+	Description string      `json:"description,omitempty"`
+	Read        Permissions `json:"read,omitzero"`
+	Write       Permissions `json:"write,omitzero"`
+}
+
+// Login is a "login" section of /etc/config/rpcd.
+type Login struct {
+	Section  string
+	Username string
+	// Read and Write list the ACL groups the login gets read or write access to. They may
+	// use wildcards such as "*" or "luci-*"; write access implies read access.
+	Read  []string
+	Write []string
+	// Timeout is the session timeout in seconds; zero uses the rpcd default.
+	Timeout int
+}
+
+// Access is the effective permissions of a login.
+type Access struct {
+	// ReadGroups and WriteGroups are the names of the groups granted read or write access.
+	ReadGroups  []string
+	WriteGroups []string
+	Read        Permissions
+	Write       Permissions
+}
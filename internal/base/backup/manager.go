@@ -0,0 +1,124 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/file"
+)
+
+const (
+	sysupgradeBinary = "/sbin/sysupgrade"
+	// ArchivePath is where archives are staged on the device, matching LuCI.
+	ArchivePath = "/tmp/backup.tar.gz"
+	archiveMode = 0o600
+)
+
+// gzipMagic is the header every sysupgrade configuration archive starts with.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// Manager creates and restores configuration archives with sysupgrade.
+// The session needs exec permission for /sbin/sysupgrade and /bin/dd, and
+// read/write permission for ArchivePath in its rpcd ACL.
+type Manager struct {
+	file *file.Manager
+}
+
+// New creates a new base backup Manager.
+func New(t goubus.Transport) *Manager {
+	return &Manager{
+		file: file.New(t),
+	}
+}
+
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"file"}
+}
+
+// CreateArchive runs "sysupgrade --create-backup" and streams the archive to w.
+// It returns the archive size; the staged copy on the device is removed afterwards.
+func (m *Manager) CreateArchive(ctx context.Context, w io.Writer) (int64, error) {
+	_, err := m.sysupgrade(ctx, "--create-backup", ArchivePath)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() { _ = m.file.Remove(ctx, ArchivePath) }()
+
+	n, err := m.file.Download(ctx, ArchivePath, w, file.DefaultChunkSize)
+	if err != nil {
+		return n, errdefs.Wrapf(err, "failed to download backup archive")
+	}
+
+	return n, nil
+}
+
+// RestoreArchive uploads an archive created by CreateArchive and applies it with
+// "sysupgrade --restore-backup". The restored configuration takes effect after a reboot.
+func (m *Manager) RestoreArchive(ctx context.Context, archive io.Reader) error {
+	reader := bufio.NewReader(archive)
+
+	header, err := reader.Peek(len(gzipMagic))
+	if err != nil || !bytes.Equal(header, gzipMagic) {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "backup archive is not gzip compressed")
+	}
+
+	defer func() { _ = m.file.Remove(ctx, ArchivePath) }()
+
+	_, err = m.file.Upload(ctx, ArchivePath, reader, archiveMode, file.DefaultChunkSize)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to upload backup archive")
+	}
+
+	_, err = m.sysupgrade(ctx, "--restore-backup", ArchivePath)
+
+	return err
+}
+
+// ListChangedFiles lists the files a backup would contain: modified configuration files
+// and the paths listed in /etc/sysupgrade.conf.
+func (m *Manager) ListChangedFiles(ctx context.Context) ([]string, error) {
+	output, err := m.sysupgrade(ctx, "--list-backup")
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseFileList(output), nil
+}
+
+// ParseFileList parses the output of "sysupgrade --list-backup".
+func ParseFileList(output string) []string {
+	files := []string{}
+
+	for line := range strings.SplitSeq(output, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "/") {
+			files = append(files, line)
+		}
+	}
+
+	return files
+}
+
+func (m *Manager) sysupgrade(ctx context.Context, args ...string) (string, error) {
+	res, err := m.file.Exec(ctx, sysupgradeBinary, args, nil)
+	if err != nil {
+		return "", errdefs.Wrapf(err, "failed to run sysupgrade %s", args[0])
+	}
+
+	if res.Code != 0 {
+		return "", errdefs.Wrapf(errdefs.ErrUnknown, "sysupgrade %s exited with code %d: %s",
+			args[0], res.Code, strings.TrimSpace(res.Stderr))
+	}
+
+	return res.Stdout, nil
+}
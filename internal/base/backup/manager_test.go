@@ -0,0 +1,94 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package backup_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/backup"
+	"github.com/honeybbq/goubus/v2/internal/testutil"
+)
+
+var testArchive = []byte{0x1f, 0x8b, 0x08, 0x00, 'c', 'o', 'n', 'f'}
+
+func TestBackupManager(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("CreateArchive", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("file", "exec", map[string]any{"code": 0})
+		mock.AddResponse("file", "stat", map[string]any{"path": backup.ArchivePath, "type": "file", "size": len(testArchive)})
+		mock.AddResponse("file", "read", map[string]any{"data": base64.StdEncoding.EncodeToString(testArchive)})
+		mock.AddResponse("file", "remove", map[string]any{})
+
+		var buf bytes.Buffer
+
+		n, err := backup.New(mock).CreateArchive(ctx, &buf)
+		if err != nil {
+			t.Fatalf("CreateArchive failed: %v", err)
+		}
+
+		if n != int64(len(testArchive)) || !bytes.Equal(buf.Bytes(), testArchive) {
+			t.Errorf("unexpected archive: %d %x", n, buf.Bytes())
+		}
+
+		params, _ := mock.Calls[0].Data.(map[string]any)
+
+		args, ok := params["params"].([]string)
+		if !ok || !slices.Equal(args, []string{"--create-backup", backup.ArchivePath}) {
+			t.Errorf("unexpected sysupgrade call: %+v", mock.Calls[0])
+		}
+	})
+
+	t.Run("RestoreArchive", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("file", "write", map[string]any{})
+		mock.AddResponse("file", "exec", map[string]any{"code": 0})
+		mock.AddResponse("file", "remove", map[string]any{})
+
+		mgr := backup.New(mock)
+
+		err := mgr.RestoreArchive(ctx, bytes.NewReader(testArchive))
+		if err != nil {
+			t.Fatalf("RestoreArchive failed: %v", err)
+		}
+
+		methods := make([]string, 0, len(mock.Calls))
+		for _, call := range mock.Calls {
+			methods = append(methods, call.Method)
+		}
+
+		if !slices.Equal(methods, []string{"write", "exec", "remove"}) {
+			t.Errorf("unexpected calls: %v", methods)
+		}
+
+		err = mgr.RestoreArchive(ctx, strings.NewReader("not an archive"))
+		if !errdefs.IsInvalidParameter(err) {
+			t.Errorf("expected invalid parameter, got %v", err)
+		}
+	})
+
+	t.Run("ListChangedFiles", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("file", "exec", map[string]any{
+			"code":   0,
+			"stdout": "/etc/config/dhcp\n/etc/config/network\n/etc/dropbear/authorized_keys\n",
+		})
+
+		files, err := backup.New(mock).ListChangedFiles(ctx)
+		if err != nil {
+			t.Fatalf("ListChangedFiles failed: %v", err)
+		}
+
+		if len(files) != 3 || files[1] != "/etc/config/network" {
+			t.Errorf("unexpected files: %v", files)
+		}
+	})
+}
@@ -0,0 +1,310 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package clients
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"maps"
+	"net"
+	"net/netip"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/dhcp"
+	"github.com/honeybbq/goubus/v2/internal/base/luci"
+	"github.com/honeybbq/goubus/v2/internal/base/network"
+	"github.com/honeybbq/goubus/v2/internal/base/wireless"
+)
+
+const (
+	// duidLLT and duidLL are the DUID types that embed the link-layer address of the client.
+	duidLLT = 1
+	duidLL  = 3
+	// duidHeader is the length of the type and hardware type fields, duidTime the length of
+	// the time field of a DUID-LLT.
+	duidHeader = 4
+	duidTime   = 4
+	// hardwareEthernet is the hardware type of Ethernet and Wi-Fi addresses.
+	hardwareEthernet = 1
+	macLength        = 6
+)
+
+// activeStates are the neighbor states that prove a client was recently reachable.
+var activeStates = []string{"REACHABLE", "DELAY", "PROBE"}
+
+// Manager merges the DHCP leases, the neighbor table with the LuCI host hints and the wireless
+// associations into one record per client. It remembers when it saw every client, so keep one
+// Manager for the lifetime of a monitor to track first and last seen times across calls.
+type Manager struct {
+	seen     map[string]seen
+	luci     *luci.Manager
+	dhcp     *dhcp.Manager
+	network  *network.Manager
+	wireless *wireless.Manager
+	mu       sync.Mutex
+}
+
+// New creates a new base clients Manager.
+func New(t goubus.Transport) *Manager {
+	return &Manager{
+		seen:     make(map[string]seen),
+		luci:     luci.New(t, nil),
+		dhcp:     dhcp.New(t, nil),
+		network:  network.New(t, nil),
+		wireless: wireless.New(t),
+	}
+}
+
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"luci-rpc", "dhcp", "network.interface", "iwinfo", "file"}
+}
+
+// Connected lists the clients of the local network, sorted by MAC address. The leases come
+// from luci-rpc, or from the dhcp object of odhcpd without it. A source that is not available
+// is skipped; Connected only fails if all of them fail. Clients without a known MAC address,
+// such as incomplete neighbor entries, are left out.
+func (m *Manager) Connected(ctx context.Context) ([]Client, error) {
+	table := make(map[string]*Client)
+	now := time.Now()
+
+	leaseErr := m.addLeases(ctx, table)
+	neighborErr := m.addNeighbors(ctx, table, now)
+	wirelessErr := m.addStations(ctx, table, now)
+
+	if leaseErr != nil && neighborErr != nil && wirelessErr != nil {
+		return nil, errdefs.Wrapf(leaseErr, "failed to read clients")
+	}
+
+	clients := make([]Client, 0, len(table))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, mac := range slices.Sorted(maps.Keys(table)) {
+		client := table[mac]
+		m.track(client, now)
+
+		slices.SortFunc(client.IPv4, compareAddr)
+		slices.SortFunc(client.IPv6, compareAddr)
+		clients = append(clients, *client)
+	}
+
+	return clients, nil
+}
+
+// track merges the seen times of client with those the manager remembers.
+func (m *Manager) track(client *Client, now time.Time) {
+	prev, ok := m.seen[client.MAC]
+	if !ok {
+		prev.first = now
+	}
+
+	if client.FirstSeen.IsZero() || prev.first.Before(client.FirstSeen) {
+		client.FirstSeen = prev.first
+	}
+
+	if prev.last.After(client.LastSeen) {
+		client.LastSeen = prev.last
+	}
+
+	m.seen[client.MAC] = seen{first: client.FirstSeen, last: client.LastSeen}
+}
+
+// addLeases adds the IPv4 and IPv6 leases.
+func (m *Manager) addLeases(ctx context.Context, table map[string]*Client) error {
+	leases, err := m.luci.GetDHCPLeases(ctx, 0)
+	if err != nil {
+		leases = &dhcp.Leases{}
+
+		leases.IPv4Leases, err = m.dhcp.IPv4Leases(ctx)
+		if err != nil {
+			return errdefs.Wrapf(err, "failed to read DHCP leases")
+		}
+
+		// odhcpd without DHCPv6 still serves IPv4 leases.
+		leases.IPv6Leases, _ = m.dhcp.IPv6Leases(ctx)
+	}
+
+	for _, lease := range leases.IPv4Leases {
+		client := entry(table, lease.MACAddr)
+		if client == nil {
+			continue
+		}
+
+		client.add(SourceDHCP)
+		client.IPv4 = appendAddr(client.IPv4, lease.IPAddr)
+		client.LeaseExpires = lease.Expires
+		client.Hostname = strings.TrimSpace(lease.Hostname)
+	}
+
+	for _, lease := range leases.IPv6Leases {
+		client := entry(table, DUIDMAC(lease.DUID))
+		if client == nil {
+			continue
+		}
+
+		client.add(SourceDHCP)
+
+		for _, addr := range lease.IPAddr {
+			client.IPv6 = appendAddr(client.IPv6, addr)
+		}
+
+		if client.Hostname == "" {
+			client.Hostname = strings.TrimSpace(lease.Hostname)
+		}
+	}
+
+	return nil
+}
+
+// addNeighbors adds the neighbor table, which includes the LuCI host hints.
+func (m *Manager) addNeighbors(ctx context.Context, table map[string]*Client, now time.Time) error {
+	neighbors, err := m.network.Neighbors(ctx)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to read neighbors")
+	}
+
+	for _, neighbor := range neighbors {
+		client := entry(table, neighbor.MAC)
+		if client == nil {
+			continue
+		}
+
+		client.add(SourceNeighbor)
+
+		if strings.Contains(neighbor.IP, ":") {
+			client.IPv6 = appendAddr(client.IPv6, neighbor.IP)
+		} else {
+			client.IPv4 = appendAddr(client.IPv4, neighbor.IP)
+		}
+
+		if client.Hostname == "" {
+			client.Hostname = neighbor.Hostname
+		}
+
+		if client.Interface == "" {
+			client.Interface = neighbor.Device
+		}
+
+		if slices.Contains(activeStates, neighbor.State) {
+			client.LastSeen = now
+		}
+	}
+
+	return nil
+}
+
+// addStations adds the stations associated with the wireless interfaces. An interface whose
+// stations cannot be read, e.g. because it is down, is skipped.
+func (m *Manager) addStations(ctx context.Context, table map[string]*Client, now time.Time) error {
+	devices, err := m.wireless.Devices(ctx)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to list wireless interfaces")
+	}
+
+	for _, device := range devices {
+		stations, err := m.wireless.Stations(ctx, device)
+		if err != nil {
+			continue
+		}
+
+		for _, station := range stations {
+			client := entry(table, station.Mac)
+			if client == nil {
+				continue
+			}
+
+			client.add(SourceWireless)
+			client.Wireless = &Station{Device: device, Station: station}
+
+			if client.Interface == "" {
+				client.Interface = device
+			}
+
+			client.FirstSeen = now.Add(-time.Duration(station.ConnectedTime) * time.Second)
+
+			lastSeen := now.Add(-time.Duration(station.Inactive) * time.Millisecond)
+			if lastSeen.After(client.LastSeen) {
+				client.LastSeen = lastSeen
+			}
+		}
+	}
+
+	return nil
+}
+
+// entry returns the client with the MAC address mac, adding it to the table. It returns nil
+// for an empty or invalid address.
+func entry(table map[string]*Client, mac string) *Client {
+	hw, err := net.ParseMAC(mac)
+	if err != nil || len(hw) != macLength {
+		return nil
+	}
+
+	key := hw.String()
+
+	client, ok := table[key]
+	if !ok {
+		client = &Client{MAC: key, Sources: []string{}}
+		table[key] = client
+	}
+
+	return client
+}
+
+func (c *Client) add(source string) {
+	if !slices.Contains(c.Sources, source) {
+		c.Sources = append(c.Sources, source)
+	}
+}
+
+// DUIDMAC returns the MAC address embedded in a hex-encoded DUID-LLT or DUID-LL of an Ethernet
+// client, or "" for other DUIDs.
+func DUIDMAC(duid string) string {
+	raw, err := hex.DecodeString(strings.ReplaceAll(duid, ":", ""))
+	if err != nil || len(raw) < duidHeader || binary.BigEndian.Uint16(raw[2:]) != hardwareEthernet {
+		return ""
+	}
+
+	var mac []byte
+
+	switch binary.BigEndian.Uint16(raw) {
+	case duidLLT:
+		mac = raw[duidHeader+duidTime:]
+	case duidLL:
+		mac = raw[duidHeader:]
+	}
+
+	if len(mac) != macLength {
+		return ""
+	}
+
+	return net.HardwareAddr(mac).String()
+}
+
+func appendAddr(addrs []string, addr string) []string {
+	if addr == "" || slices.Contains(addrs, addr) {
+		return addrs
+	}
+
+	return append(addrs, addr)
+}
+
+func compareAddr(a, b string) int {
+	x, errX := netip.ParseAddr(a)
+	y, errY := netip.ParseAddr(b)
+
+	if errX != nil || errY != nil {
+		return strings.Compare(a, b)
+	}
+
+	return x.Compare(y)
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package clients_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/clients"
+	"github.com/honeybbq/goubus/v2/internal/testutil"
+)
+
+func TestClientsManager(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Connected", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("luci-rpc", "getDHCPLeases", map[string]any{
+			"dhcp_leases": []any{
+				map[string]any{"hostname": "laptop", "ipaddr": "192.168.1.20", "macaddr": "AA:BB:CC:DD:EE:01", "expires": 3600},
+			},
+			"dhcp6_leases": []any{
+				map[string]any{"duid": "00030001aabbccddee01", "ip6addr": []string{"fd00::20"}, "expires": 3600},
+			},
+		})
+		mock.AddResponse("file", "exec", map[string]any{
+			"code": 0,
+			"stdout": "192.168.1.20 dev br-lan lladdr aa:bb:cc:dd:ee:01 STALE\n" +
+				"192.168.1.30 dev br-lan lladdr aa:bb:cc:dd:ee:02 REACHABLE\n" +
+				"192.168.1.9 dev br-lan  FAILED\n",
+		})
+		mock.AddResponse("luci-rpc", "getHostHints", map[string]any{
+			"AA:BB:CC:DD:EE:02": map[string]any{"name": "phone", "ipaddrs": []string{"192.168.1.30"}},
+		})
+		mock.AddResponse("iwinfo", "devices", map[string]any{"devices": []string{"phy0-ap0"}})
+		mock.AddResponse("iwinfo", "assoclist", map[string]any{"results": []any{
+			map[string]any{"mac": "AA:BB:CC:DD:EE:02", "signal": -52, "connected_time": 600, "inactive": 2000},
+			map[string]any{"mac": "AA:BB:CC:DD:EE:03", "signal": -71, "connected_time": 5, "inactive": 100},
+		}})
+
+		mgr := clients.New(mock)
+		before := time.Now()
+
+		list, err := mgr.Connected(ctx)
+		if err != nil {
+			t.Fatalf("Connected failed: %v", err)
+		}
+
+		if len(list) != 3 {
+			t.Fatalf("expected 3 clients, got %+v", list)
+		}
+
+		laptop, phone, tablet := list[0], list[1], list[2]
+
+		if laptop.MAC != "aa:bb:cc:dd:ee:01" || laptop.Hostname != "laptop" || laptop.LeaseExpires != 3600 ||
+			!slices.Equal(laptop.IPv6, []string{"fd00::20"}) || laptop.Interface != "br-lan" ||
+			!slices.Equal(laptop.Sources, []string{clients.SourceDHCP, clients.SourceNeighbor}) {
+			t.Errorf("unexpected wired client: %+v", laptop)
+		}
+
+		if !laptop.LastSeen.IsZero() || laptop.FirstSeen.Before(before) {
+			t.Errorf("unexpected seen times of a stale client: %v, %v", laptop.FirstSeen, laptop.LastSeen)
+		}
+
+		if phone.Hostname != "phone" || phone.Wireless == nil || phone.Wireless.Device != "phy0-ap0" ||
+			phone.Wireless.Signal != -52 || phone.Interface != "br-lan" || phone.LastSeen.Before(before) {
+			t.Errorf("unexpected wireless client: %+v", phone)
+		}
+
+		if !phone.FirstSeen.Before(before.Add(-9 * time.Minute)) {
+			t.Errorf("expected the association start as first seen time, got %v", phone.FirstSeen)
+		}
+
+		if tablet.Interface != "phy0-ap0" || len(tablet.IPv4) != 0 ||
+			!slices.Equal(tablet.Sources, []string{clients.SourceWireless}) {
+			t.Errorf("unexpected station without neighbor entry: %+v", tablet)
+		}
+
+		// The first seen time is kept across calls.
+		again, err := mgr.Connected(ctx)
+		if err != nil {
+			t.Fatalf("Connected failed: %v", err)
+		}
+
+		if !again[0].FirstSeen.Equal(laptop.FirstSeen) {
+			t.Errorf("expected the first seen time to be kept, got %v and %v", laptop.FirstSeen, again[0].FirstSeen)
+		}
+	})
+
+	t.Run("Unavailable", func(t *testing.T) {
+		_, err := clients.New(testutil.NewMockTransport()).Connected(ctx)
+		if !errdefs.IsNotFound(err) {
+			t.Errorf("expected not found without any source, got %v", err)
+		}
+	})
+
+	t.Run("DUIDMAC", func(t *testing.T) {
+		if mac := clients.DUIDMAC("000100012b3c4d5e001122334455"); mac != "00:11:22:33:44:55" {
+			t.Errorf("unexpected DUID-LLT MAC %q", mac)
+		}
+
+		if mac := clients.DUIDMAC("0004a1b2c3d4e5f60718293a4b5c6d7e8f90"); mac != "" {
+			t.Errorf("expected no MAC for a DUID-UUID, got %q", mac)
+		}
+	})
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package clients
+
+import (
+	"time"
+
+	"github.com/honeybbq/goubus/v2/internal/base/wireless"
+)
+
+// Sources a client can be known from.
+const (
+	// SourceDHCP marks a client holding a DHCP or DHCPv6 lease.
+	SourceDHCP = "dhcp"
+	// SourceNeighbor marks a client in the neighbor table or the LuCI host hints.
+	SourceNeighbor = "neighbor"
+	// SourceWireless marks a station associated with a wireless interface.
+	SourceWireless = "wireless"
+)
+
+// Client is a device on the local network, merged from all sources by MAC address.
+type Client struct {
+	// FirstSeen is the earliest time the client is known to have been present: when the
+	// manager first listed it, or the start of its wireless association if that is earlier.
+	FirstSeen time.Time `json:"first_seen"`
+	// LastSeen is the last time the client was active, i.e. sent a wireless frame or had a
+	// reachable neighbor entry. It is zero for clients that were never seen active.
+	LastSeen time.Time `json:"last_seen,omitzero"`
+	// Wireless is the association of a wireless client.
+	Wireless *Station `json:"wireless,omitempty"`
+	MAC      string   `json:"mac"`
+	Hostname string   `json:"hostname,omitempty"`
+	// Interface is the network device the client was seen on, e.g. "br-lan", or the wireless
+	// interface of a station that has no neighbor entry yet.
+	Interface string   `json:"interface,omitempty"`
+	IPv4      []string `json:"ipv4,omitempty"`
+	IPv6      []string `json:"ipv6,omitempty"`
+	// Sources lists the sources the client was found in, as Source constants.
+	Sources []string `json:"sources"`
+	// LeaseExpires is the remaining time of the IPv4 DHCP lease in seconds as reported by the
+	// lease source; it is 0 without a lease.
+	LeaseExpires int64 `json:"lease_expires,omitempty"`
+}
+
+// Station is the wireless association of a client.
+type Station struct {
+	// Device is the wireless interface the station is associated with.
+	Device string `json:"device"`
+	wireless.Station
+}
+
+// seen records when the manager saw a client.
+type seen struct {
+	first time.Time
+	last  time.Time
+}
@@ -0,0 +1,277 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package conntrack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/netip"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/file"
+)
+
+const (
+	procPath      = "/proc/net/nf_conntrack"
+	conntrackPath = "/usr/sbin/conntrack"
+	// procHeaderFields is the number of leading fields of a /proc/net/nf_conntrack line:
+	// family name and number, protocol name and number, and the timeout.
+	procHeaderFields = 5
+)
+
+var deletedPattern = regexp.MustCompile(`(\d+) flow entries have been deleted`)
+
+// Manager provides methods to list and delete connection tracking entries.
+type Manager struct {
+	caller goubus.Transport
+	file   *file.Manager
+}
+
+// New creates a new base conntrack Manager.
+func New(t goubus.Transport) *Manager {
+	return &Manager{caller: t, file: file.New(t)}
+}
+
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"file"}
+}
+
+// Flows returns the tracked connections. It reads /proc/net/nf_conntrack in full through the file
+// object, which needs exec permission for /bin/dd since file.read returns only the first page of
+// a procfs file, and falls back to luci getConntrackList if the session may not read it; in that
+// case Original carries the counters of both directions and Reply is empty.
+func (m *Manager) Flows(ctx context.Context) ([]Flow, error) {
+	var table strings.Builder
+
+	_, err := m.file.Download(ctx, procPath, &table, 0)
+	if err == nil {
+		return ParseFlows(table.String()), nil
+	}
+
+	entries, luciErr := goubus.Call[[]luciFlow](ctx, m.caller, "luci", "getConntrackList", nil)
+	if luciErr != nil {
+		return nil, errdefs.Wrapf(err, "failed to read conntrack table")
+	}
+
+	flows := make([]Flow, 0, len(*entries))
+	for _, entry := range *entries {
+		flows = append(flows, entry.flow())
+	}
+
+	return flows, nil
+}
+
+// UsageByHost sums the flows per original source address, e.g. per LAN client.
+func UsageByHost(flows []Flow) map[string]Usage {
+	usage := make(map[string]Usage)
+
+	for i := range flows {
+		entry := usage[flows[i].Original.Src]
+		entry.Flows++
+		entry.Packets += flows[i].Packets()
+		entry.Bytes += flows[i].Bytes()
+		usage[flows[i].Original.Src] = entry
+	}
+
+	return usage
+}
+
+// Delete removes the flows matching filter with conntrack(8), which needs the conntrack
+// package and exec permission for /usr/sbin/conntrack, and returns how many were removed.
+// Use it to cut off a client after blocking it in the firewall.
+func (m *Manager) Delete(ctx context.Context, filter Filter) (int, error) {
+	args, err := filter.args()
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := m.file.Exec(ctx, conntrackPath, args, nil)
+	if err != nil {
+		return 0, errdefs.Wrapf(err, "failed to run conntrack")
+	}
+
+	// conntrack prints the count on stderr and exits with 1 when nothing matched.
+	match := deletedPattern.FindStringSubmatch(res.Stderr + res.Stdout)
+	if match == nil {
+		return 0, errdefs.Wrapf(errdefs.ErrUnknown, "conntrack exited with code %d: %s",
+			res.Code, strings.TrimSpace(res.Stderr))
+	}
+
+	return strconv.Atoi(match[1])
+}
+
+// Flush removes all flows by writing "f" to /proc/net/nf_conntrack, which OpenWrt kernels support.
+func (m *Manager) Flush(ctx context.Context) error {
+	return m.file.Write(ctx, procPath, "f", false, 0, false)
+}
+
+// ParseFlows parses the contents of /proc/net/nf_conntrack. Malformed lines are skipped.
+func ParseFlows(data string) []Flow {
+	var flows []Flow
+
+	for line := range strings.Lines(data) {
+		flow, ok := parseFlow(line)
+		if ok {
+			flows = append(flows, flow)
+		}
+	}
+
+	return flows
+}
+
+// parseFlow parses a line such as "ipv4 2 tcp 6 431999 ESTABLISHED src=... dst=... sport=...
+// dport=... packets=... bytes=... src=... [ASSURED] mark=0 zone=0 use=2". The first set of
+// tuple fields belongs to the original direction, the second to the reply.
+func parseFlow(line string) (Flow, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < procHeaderFields {
+		return Flow{}, false
+	}
+
+	timeout, err := strconv.Atoi(fields[4])
+	if err != nil {
+		return Flow{}, false
+	}
+
+	flow := Flow{Family: fields[0], Protocol: fields[2], Timeout: timeout}
+	tuple := &flow.Original
+
+	for _, field := range fields[procHeaderFields:] {
+		key, value, ok := strings.Cut(field, "=")
+
+		switch {
+		case !ok:
+			flow.setFlag(field)
+		case key == "src" && tuple.Src != "":
+			tuple = &flow.Reply
+			tuple.Src = value
+		case key == "mark":
+			flow.Mark, _ = strconv.Atoi(value)
+		default:
+			tuple.set(key, value)
+		}
+	}
+
+	return flow, flow.Original.Src != ""
+}
+
+// setFlag handles a field without value: the TCP state or a status such as "[ASSURED]".
+func (f *Flow) setFlag(field string) {
+	switch {
+	case field == "[ASSURED]":
+		f.Assured = true
+	case !strings.HasPrefix(field, "["):
+		f.State = field
+	}
+}
+
+func (t *Tuple) set(key, value string) {
+	switch key {
+	case "src":
+		t.Src = value
+	case "dst":
+		t.Dst = value
+	case "sport":
+		t.SrcPort, _ = strconv.Atoi(value)
+	case "dport":
+		t.DstPort, _ = strconv.Atoi(value)
+	case "packets":
+		t.Packets, _ = strconv.ParseInt(value, 10, 64)
+	case "bytes":
+		t.Bytes, _ = strconv.ParseInt(value, 10, 64)
+	}
+}
+
+func (e *luciFlow) flow() Flow {
+	return Flow{
+		Family:   e.Layer3,
+		Protocol: e.Layer4,
+		Timeout:  numberInt(e.Timeout),
+		Original: Tuple{
+			Src:     e.Src,
+			Dst:     e.Dst,
+			SrcPort: numberInt(e.SrcPort),
+			DstPort: numberInt(e.DstPort),
+			Packets: int64(numberInt(e.Packets)),
+			Bytes:   int64(numberInt(e.Bytes)),
+		},
+	}
+}
+
+func numberInt(n json.Number) int {
+	value, _ := n.Int64()
+
+	return int(value)
+}
+
+// args converts the filter into conntrack(8) delete arguments.
+func (f *Filter) args() ([]string, error) {
+	err := f.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{"-D"}
+	if f.Family == "ipv6" {
+		args = append(args, "-f", "ipv6")
+	}
+
+	for _, opt := range []struct{ flag, value string }{
+		{"-s", f.Src},
+		{"-d", f.Dst},
+		{"-p", f.Protocol},
+		{"--sport", portString(f.SrcPort)},
+		{"--dport", portString(f.DstPort)},
+	} {
+		if opt.value != "" {
+			args = append(args, opt.flag, opt.value)
+		}
+	}
+
+	return args, nil
+}
+
+func (f *Filter) validate() error {
+	if f.Protocol == "" && f.Src == "" && f.Dst == "" {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "empty conntrack filter, use Flush to remove all flows")
+	}
+
+	if (f.SrcPort != 0 || f.DstPort != 0) && f.Protocol != "tcp" && f.Protocol != "udp" {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "port filters need protocol tcp or udp")
+	}
+
+	if !slices.Contains([]string{"", "ipv4", "ipv6"}, f.Family) {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid family %q", f.Family)
+	}
+
+	return errors.Join(validateAddr(f.Src), validateAddr(f.Dst))
+}
+
+func validateAddr(addr string) error {
+	if addr == "" {
+		return nil
+	}
+
+	_, err := netip.ParseAddr(addr)
+	if err != nil {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid address %q", addr)
+	}
+
+	return nil
+}
+
+func portString(port int) string {
+	if port == 0 {
+		return ""
+	}
+
+	return strconv.Itoa(port)
+}
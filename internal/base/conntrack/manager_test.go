@@ -0,0 +1,167 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package conntrack_test
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/conntrack"
+	"github.com/honeybbq/goubus/v2/internal/testutil"
+)
+
+const procTable = "ipv4     2 tcp      6 431999 ESTABLISHED src=192.168.1.10 dst=93.184.216.34 sport=51234 " +
+	"dport=443 packets=10 bytes=1200 src=93.184.216.34 dst=203.0.113.5 sport=443 dport=51234 packets=8 " +
+	"bytes=6400 [ASSURED] mark=0 zone=0 use=2\n" +
+	"ipv4     2 udp      17 25 src=192.168.1.10 dst=1.1.1.1 sport=40000 dport=53 packets=1 bytes=60 " +
+	"src=1.1.1.1 dst=203.0.113.5 sport=53 dport=40000 packets=1 bytes=120 mark=0 zone=0 use=2\n" +
+	"ipv6     10 udp      17 30 src=fd00::20 dst=fd00::1 sport=5353 dport=5353 [UNREPLIED] " +
+	"src=fd00::1 dst=fd00::20 sport=5353 dport=5353 mark=0 zone=0 use=2\n" +
+	"garbage\n"
+
+func TestConntrackManager(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Flows", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponseForArgs("file", "stat", map[string]any{"path": "/proc/net/nf_conntrack"},
+			map[string]any{"type": "file", "size": 0})
+		mock.AddResponse("file", "stat", map[string]any{"type": "file", "size": len(procTable)})
+		mock.AddResponse("file", "exec", map[string]any{"code": 0})
+		mock.AddResponse("file", "read", map[string]any{"data": base64.StdEncoding.EncodeToString([]byte(procTable))})
+		mock.AddResponse("file", "remove", map[string]any{})
+
+		testFlows(t, ctx, conntrack.New(mock))
+	})
+
+	t.Run("LuCIFallback", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("file", "stat", map[string]any{"type": "file", "size": 0})
+		mock.AddResponse("file", "exec", errdefs.Wrapf(errdefs.ErrPermissionDenied, "access denied"))
+		mock.AddResponse("luci", "getConntrackList", []any{
+			map[string]any{
+				"layer3": "ipv4", "layer4": "tcp", "src": "192.168.1.10", "dst": "93.184.216.34",
+				"sport": "51234", "dport": "443", "bytes": 7600, "packets": 18,
+			},
+		})
+
+		flows, err := conntrack.New(mock).Flows(ctx)
+		if err != nil {
+			t.Fatalf("Flows failed: %v", err)
+		}
+
+		if len(flows) != 1 || flows[0].Original.DstPort != 443 || flows[0].Bytes() != 7600 {
+			t.Errorf("unexpected flows: %+v", flows)
+		}
+	})
+
+	t.Run("Unreadable", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("file", "stat", map[string]any{"type": "file", "size": 0})
+		mock.AddResponse("file", "exec", map[string]any{"code": 1, "stderr": "dd: No space left on device"})
+		mock.AddResponse("file", "read", map[string]any{"data": "aXB2NA=="})
+
+		flows, err := conntrack.New(mock).Flows(ctx)
+		if err == nil {
+			t.Errorf("expected an error instead of a partial table, got %+v", flows)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		testDelete(t, ctx)
+	})
+
+	t.Run("Flush", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("file", "write", map[string]any{})
+
+		err := conntrack.New(mock).Flush(ctx)
+		if err != nil {
+			t.Fatalf("Flush failed: %v", err)
+		}
+
+		params, _ := mock.GetLastCall().Data.(map[string]any)
+		if params["path"] != "/proc/net/nf_conntrack" || params["data"] != "f" {
+			t.Errorf("unexpected write: %+v", params)
+		}
+	})
+}
+
+func testFlows(t *testing.T, ctx context.Context, mgr *conntrack.Manager) {
+	t.Helper()
+
+	flows, err := mgr.Flows(ctx)
+	if err != nil {
+		t.Fatalf("Flows failed: %v", err)
+	}
+
+	if len(flows) != 3 {
+		t.Fatalf("expected 3 flows, got %d", len(flows))
+	}
+
+	tcp := flows[0]
+	if tcp.Protocol != "tcp" || tcp.State != "ESTABLISHED" || !tcp.Assured || tcp.Timeout != 431999 {
+		t.Errorf("unexpected flow: %+v", tcp)
+	}
+
+	want := conntrack.Tuple{Src: "93.184.216.34", Dst: "203.0.113.5", SrcPort: 443, DstPort: 51234, Packets: 8, Bytes: 6400}
+	if tcp.Reply != want || tcp.Original.Bytes != 1200 {
+		t.Errorf("unexpected tuples: %+v", tcp)
+	}
+
+	if flows[1].State != "" || flows[2].Family != "ipv6" || flows[2].Assured {
+		t.Errorf("unexpected flows: %+v", flows[1:])
+	}
+
+	usage := conntrack.UsageByHost(flows)
+	if got := usage["192.168.1.10"]; got != (conntrack.Usage{Flows: 2, Packets: 20, Bytes: 7780}) {
+		t.Errorf("unexpected usage: %+v", got)
+	}
+}
+
+func testDelete(t *testing.T, ctx context.Context) {
+	t.Helper()
+
+	mock := testutil.NewMockTransport()
+	mock.AddResponse("file", "exec", map[string]any{
+		"code":   0,
+		"stderr": "conntrack v1.4.8 (conntrack-tools): 3 flow entries have been deleted.\n",
+	})
+
+	mgr := conntrack.New(mock)
+
+	deleted, err := mgr.Delete(ctx, conntrack.Filter{Src: "192.168.1.10", Protocol: "tcp", DstPort: 443})
+	if err != nil || deleted != 3 {
+		t.Fatalf("Delete: %d, %v", deleted, err)
+	}
+
+	params, _ := mock.GetLastCall().Data.(map[string]any)
+	args, _ := params["params"].([]string)
+
+	if got := strings.Join(args, " "); got != "-D -s 192.168.1.10 -p tcp --dport 443" {
+		t.Errorf("unexpected arguments: %s", got)
+	}
+
+	for _, filter := range []conntrack.Filter{
+		{},
+		{Src: "192.168.1.10", DstPort: 443},
+		{Src: "192.168.1"},
+		{Src: "fd00::20", Family: "inet6"},
+	} {
+		_, err = mgr.Delete(ctx, filter)
+		if !errdefs.IsInvalidParameter(err) {
+			t.Errorf("expected %+v to be rejected, got %v", filter, err)
+		}
+	}
+
+	mock.AddResponse("file", "exec", map[string]any{"code": 127, "stderr": "not found"})
+
+	_, err = mgr.Delete(ctx, conntrack.Filter{Src: "192.168.1.10"})
+	if !errdefs.IsUnknown(err) {
+		t.Errorf("expected a missing conntrack binary to fail, got %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package conntrack
+
+import "encoding/json"
+
+// Flow is a tracked connection.
+type Flow struct {
+	// Family is "ipv4" or "ipv6".
+	Family string
+	// Protocol is the layer 4 protocol, e.g. "tcp", "udp" or "icmp".
+	Protocol string
+	// State is the TCP state, e.g. "ESTABLISHED"; it is empty for other protocols.
+	State string
+	// Original is the tuple of the direction that opened the connection.
+	Original Tuple
+	// Reply is the tuple of the answering direction. Its addresses differ from the swapped
+	// original tuple when the flow is NATed.
+	Reply Tuple
+	// Timeout is the number of seconds until the entry expires without traffic.
+	Timeout int
+	Mark    int
+	// Assured is set once traffic was seen in both directions.
+	Assured bool
+}
+
+// Tuple is one direction of a flow. The counters need nf_conntrack_acct enabled and stay
+// zero otherwise.
+type Tuple struct {
+	Src     string
+	Dst     string
+	SrcPort int
+	DstPort int
+	Packets int64
+	Bytes   int64
+}
+
+// Packets returns the packets of both directions.
+func (f *Flow) Packets() int64 {
+	return f.Original.Packets + f.Reply.Packets
+}
+
+// Bytes returns the bytes of both directions.
+func (f *Flow) Bytes() int64 {
+	return f.Original.Bytes + f.Reply.Bytes
+}
+
+// Usage sums the flows of one host.
+type Usage struct {
+	Flows   int
+	Packets int64
+	Bytes   int64
+}
+
+// Filter selects the flows Delete removes. Empty fields match any flow; ports need Protocol.
+type Filter struct {
+	// Family is "ipv4" or "ipv6" and defaults to "ipv4", like conntrack(8).
+	Family   string
+	Protocol string
+	Src      string
+	Dst      string
+	SrcPort  int
+	DstPort  int
+}
+
+// luciFlow is an entry of luci getConntrackList, which sums the counters of both directions
+// and reports the original tuple only.
+type luciFlow struct {
+	Layer3  string      `json:"layer3"`
+	Layer4  string      `json:"layer4"`
+	Src     string      `json:"src"`
+	Dst     string      `json:"dst"`
+	SrcPort json.Number `json:"sport"`
+	DstPort json.Number `json:"dport"`
+	Bytes   json.Number `json:"bytes"`
+	Packets json.Number `json:"packets"`
+	Timeout json.Number `json:"timeout"`
+}
@@ -5,8 +5,12 @@ package container
 
 import (
 	"context"
+	"maps"
+	"slices"
 
 	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/service"
 )
 
 // Manager provides an interface for managing LxC containers.
@@ -19,6 +23,11 @@ func New(t goubus.Transport) *Manager {
 	return &Manager{caller: t}
 }
 
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"container"}
+}
+
 // Set configures a container.
 func (m *Manager) Set(ctx context.Context, req SetRequest) error {
 	_, err := m.caller.Call(ctx, "container", "set", req)
@@ -52,6 +61,61 @@ func (m *Manager) List(ctx context.Context, name string, verbose bool) (map[stri
 	return *res, nil
 }
 
+// Containers lists the containers of procd, sorted by name. It fails with ErrNotFound where
+// procd is built without container support.
+func (m *Manager) Containers(ctx context.Context) ([]Container, error) {
+	res, err := goubus.Call[map[string]service.Info](ctx, m.caller, "container", "list",
+		map[string]any{"verbose": true})
+	if err != nil {
+		return nil, err
+	}
+
+	containers := make([]Container, 0, len(*res))
+
+	for _, name := range slices.Sorted(maps.Keys(*res)) {
+		c := Container{Name: name, Instances: (*res)[name].Instances}
+		if c.Instances == nil {
+			c.Instances = map[string]service.Instance{}
+		}
+
+		for _, instance := range c.Instances {
+			c.Running = c.Running || bool(instance.Running)
+		}
+
+		containers = append(containers, c)
+	}
+
+	return containers, nil
+}
+
+// Start starts a container created without starting it, as "uxc start" does, through the
+// object ujail registers for the container while it waits.
+func (m *Manager) Start(ctx context.Context, name string) error {
+	if name == "" {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "container name is required")
+	}
+
+	// The transports report the missing object of a container that is not waiting as a
+	// CapabilityError, whose package hint does not apply here.
+	_, err := m.caller.Call(ctx, "container."+name, "start", nil)
+	if errdefs.IsNotFound(err) || errdefs.IsNotSupported(err) {
+		return errdefs.Wrapf(errdefs.ErrNotFound, "container %s is not waiting to be started", name)
+	}
+
+	return err
+}
+
+// Stop stops a container and removes it from procd, as "uxc stop" does.
+func (m *Manager) Stop(ctx context.Context, name string) error {
+	if name == "" {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "container name is required")
+	}
+
+	_, err := m.caller.Call(ctx, "container", "delete", map[string]any{"name": name})
+
+	return err
+}
+
 // Delete removes a container instance.
 func (m *Manager) Delete(ctx context.Context, name, instance string) error {
 	params := map[string]any{
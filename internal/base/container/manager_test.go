@@ -7,6 +7,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/honeybbq/goubus/v2/errdefs"
 	"github.com/honeybbq/goubus/v2/internal/base/container"
 	"github.com/honeybbq/goubus/v2/internal/testutil"
 )
@@ -33,4 +34,57 @@ func TestContainerManager(t *testing.T) {
 			t.Error("expected test-container in list")
 		}
 	})
+	t.Run("Containers", func(t *testing.T) {
+		mock.AddResponse("container", "list", map[string]any{
+			"web": map[string]any{"instances": map[string]any{"web": map[string]any{
+				"running": true, "pid": 2301, "bundle": "/opt/uxc/web",
+				"seccomp": "/etc/seccomp/web.json", "capabilities": "/etc/capabilities/web.json",
+				"jail": map[string]any{"name": "web", "netns": true, "mount": map[string]any{"/srv": "1"}},
+			}}},
+			"db": map[string]any{"instances": map[string]any{"db": map[string]any{"running": false}}},
+		})
+
+		containers, err := container.New(mock).Containers(ctx)
+		if err != nil {
+			t.Fatalf("Containers failed: %v", err)
+		}
+
+		if len(containers) != 2 || containers[0].Name != "db" || containers[0].Running || !containers[1].Running {
+			t.Fatalf("unexpected containers: %+v", containers)
+		}
+
+		web := containers[1].Instances["web"]
+		if web.Bundle != "/opt/uxc/web" || web.Seccomp != "/etc/seccomp/web.json" ||
+			web.Capabilities != "/etc/capabilities/web.json" || !bool(web.Jail.NetNS) || web.Jail.Mount["/srv"] != "1" {
+			t.Errorf("unexpected instance: %+v", web)
+		}
+	})
+
+	t.Run("StartStop", func(t *testing.T) {
+		mock.AddResponse("container.web", "start", map[string]any{})
+		mock.AddResponse("container", "delete", map[string]any{})
+		mgr := container.New(mock)
+
+		err := mgr.Start(ctx, "web")
+		if err != nil {
+			t.Fatalf("Start failed: %v", err)
+		}
+
+		err = mgr.Stop(ctx, "web")
+		if err != nil {
+			t.Fatalf("Stop failed: %v", err)
+		}
+
+		call := mock.GetLastCall()
+		if params, _ := call.Data.(map[string]any); call.Method != "delete" || params["name"] != "web" {
+			t.Errorf("unexpected call: %+v", call)
+		}
+
+		mock.AddResponse("container.db", "start", &errdefs.CapabilityError{Object: "container.db"})
+
+		err = mgr.Start(ctx, "db")
+		if !errdefs.IsNotFound(err) {
+			t.Errorf("expected a container that is not waiting to be reported, got %v", err)
+		}
+	})
 }
@@ -5,6 +5,7 @@ package container
 
 import (
 	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/internal/base/service"
 )
 
 // SetRequest represents parameters for setting up or adding a container.
@@ -17,3 +18,11 @@ type SetRequest struct {
 	Validate  []any          `json:"validate,omitempty"`
 	Autostart goubus.Bool    `json:"autostart,omitempty"`
 }
+
+// Container is a container procd runs through ujail, as created by uxc.
+type Container struct {
+	Instances map[string]service.Instance `json:"instances"`
+	Name      string                      `json:"name"`
+	// Running is set when any instance of the container is running.
+	Running bool `json:"running"`
+}
@@ -0,0 +1,358 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package cron
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/file"
+	"github.com/honeybbq/goubus/v2/internal/base/rc"
+)
+
+const (
+	crontabPath = "/etc/crontabs/root"
+	crontabMode = 0o600
+	initScript  = "cron"
+
+	// scheduleFields is the number of time fields of a crontab line.
+	scheduleFields = 5
+)
+
+// crontabLine splits a crontab line into its schedule and command.
+var crontabLine = regexp.MustCompile(`^((?:\S+\s+){4}\S+)\s+(\S.*)$`)
+
+// fieldRanges are the bounds of the minute, hour, day of month, month and day of week fields.
+var fieldRanges = [scheduleFields]struct {
+	name     string
+	min, max int
+	names    []string
+}{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{
+		"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec",
+	}},
+	{name: "day of week", min: 0, max: 7, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}},
+}
+
+// Manager provides methods to manage the scheduled jobs of the root crontab, which busybox
+// crond runs. It edits /etc/crontabs/root through the file object and restarts the cron
+// service to apply changes.
+type Manager struct {
+	file *file.Manager
+	rc   *rc.Manager
+}
+
+// New creates a new base cron Manager.
+func New(t goubus.Transport) *Manager {
+	return &Manager{
+		file: file.New(t),
+		rc:   rc.New(t),
+	}
+}
+
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"file", "rc"}
+}
+
+// Entries lists the jobs of the root crontab in file order, including disabled ones.
+func (m *Manager) Entries(ctx context.Context) ([]Entry, error) {
+	lines, err := m.crontabLines(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseCrontab(strings.Join(lines, "\n")), nil
+}
+
+// AddEntry appends a job unless the same schedule and command is already present. A present job
+// in the other state is enabled or disabled in place to match entry. The rest of the crontab is
+// left as it is.
+func (m *Manager) AddEntry(ctx context.Context, entry Entry) error {
+	err := entry.Validate()
+	if err != nil {
+		return err
+	}
+
+	lines, err := m.crontabLines(ctx)
+	if err != nil {
+		return err
+	}
+
+	match := -1
+
+	for i, line := range lines {
+		existing, ok := parseEntry(line)
+		if !ok || !entry.same(&existing) {
+			continue
+		}
+
+		if existing.Disabled == entry.Disabled {
+			return nil
+		}
+
+		if match < 0 {
+			match = i
+		}
+	}
+
+	if match >= 0 {
+		lines[match] = entry.line()
+	} else {
+		lines = append(lines, entry.line())
+	}
+
+	return m.writeCrontab(ctx, lines)
+}
+
+// RemoveEntry removes every job with the schedule and command of entry, enabled or not.
+// Comments and lines that are not jobs are kept.
+func (m *Manager) RemoveEntry(ctx context.Context, entry Entry) error {
+	lines, err := m.crontabLines(ctx)
+	if err != nil {
+		return err
+	}
+
+	kept := slices.DeleteFunc(slices.Clone(lines), func(line string) bool {
+		existing, ok := parseEntry(line)
+
+		return ok && entry.same(&existing)
+	})
+	if len(kept) == len(lines) {
+		return nil
+	}
+
+	return m.writeCrontab(ctx, kept)
+}
+
+// SetEntries validates the jobs, replaces the jobs of the crontab with them and restarts cron.
+// Variable assignments such as MAILTO=, comments and other lines that are not jobs are kept;
+// jobs already in the crontab are rewritten in place and new jobs are appended.
+func (m *Manager) SetEntries(ctx context.Context, entries []Entry) error {
+	for _, entry := range entries {
+		err := entry.Validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	lines, err := m.crontabLines(ctx)
+	if err != nil {
+		return err
+	}
+
+	pending := slices.Clone(entries)
+	result := make([]string, 0, len(lines)+len(entries))
+
+	for _, line := range lines {
+		existing, ok := parseEntry(line)
+		if !ok {
+			result = append(result, line)
+
+			continue
+		}
+
+		i := slices.IndexFunc(pending, func(entry Entry) bool { return entry.same(&existing) })
+		if i < 0 {
+			continue
+		}
+
+		result = append(result, pending[i].line())
+		pending = slices.Delete(pending, i, i+1)
+	}
+
+	for _, entry := range pending {
+		result = append(result, entry.line())
+	}
+
+	return m.writeCrontab(ctx, result)
+}
+
+// crontabLines reads the lines of the root crontab, which may not exist yet.
+func (m *Manager) crontabLines(ctx context.Context) ([]string, error) {
+	content, err := m.file.Read(ctx, crontabPath, false)
+	if err != nil {
+		if errdefs.IsNotFound(err) || errdefs.IsNoData(err) {
+			return nil, nil
+		}
+
+		return nil, errdefs.Wrapf(err, "failed to read the crontab")
+	}
+
+	data := strings.TrimSuffix(content.Data, "\n")
+	if data == "" {
+		return nil, nil
+	}
+
+	return strings.Split(data, "\n"), nil
+}
+
+// writeCrontab replaces the root crontab with the given lines and restarts cron.
+func (m *Manager) writeCrontab(ctx context.Context, lines []string) error {
+	var content string
+	if len(lines) > 0 {
+		content = strings.Join(lines, "\n") + "\n"
+	}
+
+	err := m.file.Replace(ctx, crontabPath, content, crontabMode, false)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to write the crontab")
+	}
+
+	err = m.rc.Init(ctx, initScript, "restart")
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to restart cron")
+	}
+
+	return nil
+}
+
+// Validate checks the schedule and that the command is a single non-empty line.
+func (e *Entry) Validate() error {
+	err := ValidateSchedule(e.Schedule)
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(e.Command) == "" || strings.ContainsAny(e.Command, "\r\n") {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "cron command must be a single non-empty line")
+	}
+
+	return nil
+}
+
+// same reports whether other runs the same command on the same schedule.
+func (e *Entry) same(other *Entry) bool {
+	return strings.Join(strings.Fields(e.Schedule), " ") == strings.Join(strings.Fields(other.Schedule), " ") &&
+		strings.TrimSpace(e.Command) == strings.TrimSpace(other.Command)
+}
+
+// ValidateSchedule checks the five time fields of a crontab line. Each field is "*" or a
+// comma-separated list of values and ranges, optionally with a step such as "*/15" or "1-5/2";
+// months and days of the week may be given by their English abbreviations.
+func ValidateSchedule(schedule string) error {
+	fields := strings.Fields(schedule)
+	if len(fields) != scheduleFields {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "cron schedule %q must have %d fields",
+			schedule, scheduleFields)
+	}
+
+	for i, field := range fields {
+		for item := range strings.SplitSeq(field, ",") {
+			if !validItem(item, i) {
+				return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid %s %q in cron schedule %q",
+					fieldRanges[i].name, item, schedule)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validItem checks an item of the field at index i, such as "*/15", "1-5" or "mon".
+func validItem(item string, i int) bool {
+	span, step, stepped := strings.Cut(item, "/")
+	if stepped {
+		n, err := strconv.Atoi(step)
+		if err != nil || n < 1 {
+			return false
+		}
+	}
+
+	if span == "*" {
+		return true
+	}
+
+	low, high, ranged := strings.Cut(span, "-")
+
+	first, ok := fieldValue(low, i)
+	if !ok {
+		return false
+	}
+
+	if !ranged {
+		return true
+	}
+
+	last, ok := fieldValue(high, i)
+
+	return ok && first <= last
+}
+
+// fieldValue parses a value of the field at index i.
+func fieldValue(value string, i int) (int, bool) {
+	bounds := fieldRanges[i]
+
+	if n := slices.Index(bounds.names, strings.ToLower(value)); n >= 0 {
+		return n + bounds.min, true
+	}
+
+	n, err := strconv.Atoi(value)
+
+	return n, err == nil && n >= bounds.min && n <= bounds.max
+}
+
+// ParseCrontab parses the jobs of a crontab. Jobs commented out with "#" are disabled entries;
+// other comments, blank lines and malformed lines are skipped.
+func ParseCrontab(content string) []Entry {
+	entries := []Entry{}
+
+	for line := range strings.SplitSeq(content, "\n") {
+		if entry, ok := parseEntry(line); ok {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
+
+// parseEntry parses a crontab line as a job, reporting false for lines that are not jobs.
+func parseEntry(line string) (Entry, bool) {
+	line = strings.TrimSpace(line)
+	disabled := strings.HasPrefix(line, "#")
+	line = strings.TrimSpace(strings.TrimLeft(line, "#"))
+
+	match := crontabLine.FindStringSubmatch(line)
+	if match == nil {
+		return Entry{}, false
+	}
+
+	entry := Entry{
+		Schedule: strings.Join(strings.Fields(match[1]), " "),
+		Command:  match[2],
+		Disabled: disabled,
+	}
+
+	return entry, ValidateSchedule(entry.Schedule) == nil
+}
+
+// FormatCrontab renders entries in crontab format, commenting out disabled ones.
+func FormatCrontab(entries []Entry) string {
+	var builder strings.Builder
+
+	for _, entry := range entries {
+		builder.WriteString(entry.line())
+		builder.WriteString("\n")
+	}
+
+	return builder.String()
+}
+
+// line renders the entry as a crontab line, commented out when it is disabled.
+func (e *Entry) line() string {
+	line := strings.Join(strings.Fields(e.Schedule), " ") + " " + strings.TrimSpace(e.Command)
+	if e.Disabled {
+		return "# " + line
+	}
+
+	return line
+}
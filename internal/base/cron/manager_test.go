@@ -0,0 +1,168 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package cron_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/cron"
+	"github.com/honeybbq/goubus/v2/internal/testutil"
+)
+
+const crontab = `MAILTO=""
+# nightly jobs
+0 4 * * *  sleep 70 && touch /etc/banner && reboot
+#*/15 * * * * /usr/bin/backup.sh  --quiet
+# not a job
+30 2 * * sun,sat sysupgrade -c
+`
+
+func TestCronManager(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Entries", func(t *testing.T) {
+		entries := cron.ParseCrontab(crontab)
+		if len(entries) != 3 {
+			t.Fatalf("unexpected entries: %+v", entries)
+		}
+
+		if entries[0].Command != "sleep 70 && touch /etc/banner && reboot" || entries[0].Disabled {
+			t.Errorf("unexpected entry: %+v", entries[0])
+		}
+
+		if entries[1].Schedule != "*/15 * * * *" || entries[1].Command != "/usr/bin/backup.sh  --quiet" ||
+			!entries[1].Disabled {
+			t.Errorf("unexpected disabled entry: %+v", entries[1])
+		}
+
+		if cron.FormatCrontab(entries[1:2]) != "# */15 * * * * /usr/bin/backup.sh  --quiet\n" {
+			t.Errorf("unexpected format: %q", cron.FormatCrontab(entries[1:2]))
+		}
+	})
+
+	t.Run("ValidateSchedule", func(t *testing.T) {
+		for _, schedule := range []string{"* * * * *", "*/5 0-6,22,23 1 JAN-jun mon-fri", "0 0 31 12 7"} {
+			err := cron.ValidateSchedule(schedule)
+			if err != nil {
+				t.Errorf("expected %q to be valid, got %v", schedule, err)
+			}
+		}
+
+		for _, schedule := range []string{"@daily", "60 * * * *", "* 24 * * *", "* * 0 * *", "5-1 * * * *",
+			"*/0 * * * *", "* * * foo *", "* * * * * *", "1,,2 * * * *"} {
+			err := cron.ValidateSchedule(schedule)
+			if !errdefs.IsInvalidParameter(err) {
+				t.Errorf("expected %q to be rejected, got %v", schedule, err)
+			}
+		}
+	})
+
+	t.Run("AddEntry", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("file", "read", map[string]any{"data": crontab})
+		mock.AddResponse("file", "write", map[string]any{})
+		mock.AddResponse("file", "exec", map[string]any{"code": 0})
+		mock.AddResponse("rc", "init", map[string]any{})
+
+		mgr := cron.New(mock)
+
+		err := mgr.AddEntry(ctx, cron.Entry{Schedule: "0  4 * * *", Command: "sleep 70 && touch /etc/banner && reboot"})
+		if err != nil || len(mock.Calls) != 1 {
+			t.Fatalf("expected an existing job not to be added again, got %v after %d calls", err, len(mock.Calls))
+		}
+
+		err = mgr.AddEntry(ctx, cron.Entry{Schedule: "0 3 * * 0", Command: "/sbin/backup"})
+		if err != nil {
+			t.Fatalf("AddEntry failed: %v", err)
+		}
+
+		want := `MAILTO=""
+# nightly jobs
+0 4 * * *  sleep 70 && touch /etc/banner && reboot
+#*/15 * * * * /usr/bin/backup.sh  --quiet
+# not a job
+30 2 * * sun,sat sysupgrade -c
+0 3 * * 0 /sbin/backup
+`
+		if written := lastWrite(mock); written != want {
+			t.Errorf("unexpected crontab: %q", written)
+		}
+
+		if call := mock.GetLastCall(); call.Service != "rc" || call.Method != "init" {
+			t.Errorf("expected cron to be restarted, got %+v", call)
+		}
+
+		err = mgr.AddEntry(ctx, cron.Entry{Schedule: "0 3 * * 0", Command: "reboot\n* * * * * rm -rf /"})
+		if !errdefs.IsInvalidParameter(err) {
+			t.Errorf("expected a multi-line command to be rejected, got %v", err)
+		}
+
+		err = mgr.AddEntry(ctx, cron.Entry{Schedule: "*/15 * * * *", Command: "/usr/bin/backup.sh  --quiet"})
+		if err != nil {
+			t.Fatalf("AddEntry failed: %v", err)
+		}
+
+		want = `MAILTO=""
+# nightly jobs
+0 4 * * *  sleep 70 && touch /etc/banner && reboot
+*/15 * * * * /usr/bin/backup.sh  --quiet
+# not a job
+30 2 * * sun,sat sysupgrade -c
+`
+		if written := lastWrite(mock); written != want {
+			t.Errorf("expected the disabled job to be enabled in place, got %q", written)
+		}
+	})
+
+	t.Run("SetEntries", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("file", "read", map[string]any{"data": crontab})
+		mock.AddResponse("file", "write", map[string]any{})
+		mock.AddResponse("file", "exec", map[string]any{"code": 0})
+		mock.AddResponse("rc", "init", map[string]any{})
+
+		mgr := cron.New(mock)
+
+		err := mgr.SetEntries(ctx, []cron.Entry{
+			{Schedule: "30 2 * * sun,sat", Command: "sysupgrade -c", Disabled: true},
+			{Schedule: "@reboot", Command: "true"},
+		})
+		if !errdefs.IsInvalidParameter(err) || len(mock.Calls) != 0 {
+			t.Fatalf("expected an invalid entry to be rejected before reading, got %v", err)
+		}
+
+		err = mgr.SetEntries(ctx, []cron.Entry{
+			{Schedule: "0 5 * * *", Command: "/sbin/backup"},
+			{Schedule: "30 2 * * sun,sat", Command: "sysupgrade -c", Disabled: true},
+		})
+		if err != nil {
+			t.Fatalf("SetEntries failed: %v", err)
+		}
+
+		want := `MAILTO=""
+# nightly jobs
+# not a job
+# 30 2 * * sun,sat sysupgrade -c
+0 5 * * * /sbin/backup
+`
+		if written := lastWrite(mock); written != want {
+			t.Errorf("expected comments and variables to be kept, got %q", written)
+		}
+	})
+}
+
+// lastWrite returns the data of the last file write.
+func lastWrite(mock *testutil.MockTransport) string {
+	var written string
+
+	for _, call := range mock.Calls {
+		if call.Method == "write" {
+			written, _ = call.Data.(map[string]any)["data"].(string)
+		}
+	}
+
+	return written
+}
@@ -0,0 +1,13 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package cron
+
+// Entry is a job of the root crontab.
+type Entry struct {
+	// Schedule holds the five time fields, e.g. "0 4 * * 1-5".
+	Schedule string `json:"schedule"`
+	Command  string `json:"command"`
+	// Disabled is set for jobs commented out in the crontab.
+	Disabled bool `json:"disabled"`
+}
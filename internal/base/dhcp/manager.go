@@ -5,8 +5,19 @@ package dhcp
 
 import (
 	"context"
+	"maps"
+	"slices"
+	"strings"
 
 	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/uci"
+)
+
+const (
+	uciPackage  = "dhcp"
+	uciHostType = "host"
+	dnsmasqObj  = "dnsmasq"
 )
 
 // Dialect defines the differences in DHCP ubus calls.
@@ -18,11 +29,17 @@ type Dialect interface {
 type Manager struct {
 	caller  goubus.Transport
 	dialect Dialect
+	uci     *uci.Manager
 }
 
 // New creates a new base DHCP Manager.
 func New(t goubus.Transport, d Dialect) *Manager {
-	return &Manager{caller: t, dialect: d}
+	return &Manager{caller: t, dialect: d, uci: uci.New(t, nil)}
+}
+
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"dhcp", "uci"}
 }
 
 // AddLease creates a new static DHCP lease.
@@ -33,35 +50,41 @@ func (m *Manager) AddLease(ctx context.Context, req AddLeaseRequest) error {
 	return err
 }
 
-// IPv4Leases retrieves current IPv4 DHCP leases.
+// IPv4Leases retrieves the current IPv4 DHCP leases of odhcpd, ordered by interface.
 func (m *Manager) IPv4Leases(ctx context.Context) ([]IPv4Lease, error) {
-	res, err := goubus.Call[map[string][]IPv4Lease](ctx, m.caller, "dhcp", "ipv4leases", nil)
+	res, err := goubus.Call[DeviceLeases[ipv4LeaseReply]](ctx, m.caller, "dhcp", "ipv4leases", nil)
 	if err != nil {
 		return nil, err
 	}
 
-	// The response is usually a map where keys are interface names
-	var allLeases []IPv4Lease
-	for _, leases := range *res {
-		allLeases = append(allLeases, leases...)
+	replies := res.Flatten(nil)
+
+	leases := make([]IPv4Lease, len(replies))
+	for i, reply := range replies {
+		leases[i] = IPv4Lease{Hostname: reply.Hostname, IPAddr: reply.Address, MACAddr: reply.MAC, Expires: reply.Valid}
 	}
 
-	return allLeases, nil
+	return leases, nil
 }
 
-// IPv6Leases retrieves current IPv6 DHCP leases.
+// IPv6Leases retrieves the current IPv6 DHCP leases of odhcpd, ordered by interface.
 func (m *Manager) IPv6Leases(ctx context.Context) ([]IPv6Lease, error) {
-	res, err := goubus.Call[map[string][]IPv6Lease](ctx, m.caller, "dhcp", "ipv6leases", nil)
+	res, err := goubus.Call[DeviceLeases[ipv6LeaseReply]](ctx, m.caller, "dhcp", "ipv6leases", nil)
 	if err != nil {
 		return nil, err
 	}
 
-	var allLeases []IPv6Lease
-	for _, leases := range *res {
-		allLeases = append(allLeases, leases...)
+	replies := res.Flatten(nil)
+
+	leases := make([]IPv6Lease, len(replies))
+	for i, reply := range replies {
+		leases[i] = IPv6Lease{Hostname: reply.Hostname, DUID: reply.DUID, Expires: reply.Valid}
+		for _, addr := range reply.Addresses {
+			leases[i].IPAddr = append(leases[i].IPAddr, addr.Address)
+		}
 	}
 
-	return allLeases, nil
+	return leases, nil
 }
 
 // IPv6RA retrieves current IPv6 Router Advertisement information.
@@ -78,3 +101,181 @@ func (m *Manager) IPv6RA(ctx context.Context) ([]IPv6RA, error) {
 
 	return allRAs, nil
 }
+
+// StaticLeases retrieves the static leases configured in /etc/config/dhcp, in configuration order.
+func (m *Manager) StaticLeases(ctx context.Context) ([]StaticLease, error) {
+	sections, err := m.uci.Package(uciPackage).GetAll(ctx)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read dhcp hosts")
+	}
+
+	leases := []StaticLease{}
+
+	for _, section := range uci.SortedSections(sections) {
+		if section.Type == uciHostType {
+			leases = append(leases, StaticLeaseFromSection(section))
+		}
+	}
+
+	return leases, nil
+}
+
+// SaveStaticLease adds lease as a host section, or updates the section named by lease.Section,
+// and commits the dhcp package. procd reloads dnsmasq on the commit.
+func (m *Manager) SaveStaticLease(ctx context.Context, lease StaticLease) error {
+	if len(lease.MACs) == 0 && lease.DUID == "" {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "static lease needs a MAC address or DUID")
+	}
+
+	pkg := m.uci.Package(uciPackage)
+
+	var err error
+	if lease.Section == "" {
+		err = pkg.Add(ctx, uciHostType, "", lease.SectionValues())
+	} else {
+		err = pkg.Section(lease.Section).SetValues(ctx, lease.SectionValues())
+	}
+
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to save static lease")
+	}
+
+	return pkg.Commit(ctx)
+}
+
+// RemoveLease removes mac from the static leases and commits the dhcp package. A lease left
+// without MAC address or DUID is deleted; other addresses of the lease stay reserved.
+// It returns a NotFound error if no lease has that MAC address.
+func (m *Manager) RemoveLease(ctx context.Context, mac string) error {
+	leases, err := m.StaticLeases(ctx)
+	if err != nil {
+		return err
+	}
+
+	pkg := m.uci.Package(uciPackage)
+	removed := 0
+
+	for _, lease := range leases {
+		if !lease.HasMAC(mac) {
+			continue
+		}
+
+		lease.MACs = slices.DeleteFunc(lease.MACs, func(candidate string) bool {
+			return strings.EqualFold(candidate, mac)
+		})
+
+		section := pkg.Section(lease.Section)
+		if len(lease.MACs) == 0 && lease.DUID == "" {
+			err = section.Delete(ctx)
+		} else {
+			err = section.SetValues(ctx, lease.SectionValues())
+		}
+
+		if err != nil {
+			return errdefs.Wrapf(err, "failed to remove %s from static lease %s", mac, lease.Section)
+		}
+
+		removed++
+	}
+
+	if removed == 0 {
+		return errdefs.Wrapf(errdefs.ErrNotFound, "no static lease for %s", mac)
+	}
+
+	return pkg.Commit(ctx)
+}
+
+// WatchLeases delivers the lease notifications of dnsmasq, such as a client being acknowledged.
+// Every event carries a LeaseEvent payload that can be decoded with ParseLeaseEvent.
+func (m *Manager) WatchLeases(ctx context.Context) (*goubus.Subscription, error) {
+	sub, err := goubus.Subscribe(ctx, m.caller, dnsmasqObj)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to subscribe to %s", dnsmasqObj)
+	}
+
+	return goubus.FilterSubscription(ctx, sub, isLeaseEvent), nil
+}
+
+// ParseLeaseEvent decodes a dnsmasq lease notification. It returns false for other notifications.
+func ParseLeaseEvent(ev goubus.Event) (LeaseEvent, bool) {
+	if !isLeaseEvent(ev) {
+		return LeaseEvent{}, false
+	}
+
+	var lease LeaseEvent
+
+	err := ev.Unmarshal(&lease)
+	if err != nil {
+		return LeaseEvent{}, false
+	}
+
+	lease.Type = ev.Type
+
+	return lease, true
+}
+
+func isLeaseEvent(ev goubus.Event) bool {
+	return strings.HasPrefix(ev.Type, "dhcp.")
+}
+
+// Flatten returns the leases ordered by device, passing each to setInterface, if not nil,
+// with its device.
+func (d *DeviceLeases[T]) Flatten(setInterface func(*T, string)) []T {
+	leases := []T{}
+
+	for _, iface := range slices.Sorted(maps.Keys(d.Device)) {
+		for _, lease := range d.Device[iface].Leases {
+			if setInterface != nil {
+				setInterface(&lease, iface)
+			}
+
+			leases = append(leases, lease)
+		}
+	}
+
+	return leases
+}
+
+// StaticLeaseFromSection converts a UCI host section into a StaticLease.
+// MAC addresses may be given as a list or as one space-separated option.
+func StaticLeaseFromSection(section *uci.Section) StaticLease {
+	var macs []string
+	for _, value := range section.Get("mac") {
+		macs = append(macs, strings.Fields(value)...)
+	}
+
+	return StaticLease{
+		Section:   section.Name,
+		Name:      section.GetString("name"),
+		IP:        section.GetString("ip"),
+		DUID:      section.GetString("duid"),
+		HostID:    section.GetString("hostid"),
+		LeaseTime: section.GetString("leasetime"),
+		MACs:      macs,
+	}
+}
+
+// SectionValues converts the lease into UCI option values.
+func (l *StaticLease) SectionValues() uci.SectionValues {
+	values := uci.NewSectionValues()
+	values.SetScalar("name", l.Name)
+	values.SetScalar("ip", l.IP)
+	values.SetScalar("duid", l.DUID)
+	values.SetScalar("hostid", l.HostID)
+	values.SetScalar("leasetime", l.LeaseTime)
+
+	if len(l.MACs) > 0 {
+		values.SetList("mac", l.MACs...)
+	} else {
+		values.Set("mac")
+	}
+
+	return values
+}
+
+// HasMAC reports whether the lease applies to mac, ignoring case.
+func (l *StaticLease) HasMAC(mac string) bool {
+	return slices.ContainsFunc(l.MACs, func(candidate string) bool {
+		return strings.EqualFold(candidate, mac)
+	})
+}
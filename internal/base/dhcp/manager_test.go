@@ -5,9 +5,12 @@ package dhcp_test
 
 import (
 	"context"
+	"reflect"
 	"testing"
 
+	"github.com/honeybbq/goubus/v2/errdefs"
 	"github.com/honeybbq/goubus/v2/internal/base/dhcp"
+	"github.com/honeybbq/goubus/v2/internal/base/uci"
 	"github.com/honeybbq/goubus/v2/internal/testutil"
 )
 
@@ -42,4 +45,124 @@ func TestDhcpManager(t *testing.T) {
 			t.Errorf("expected method add_lease, got %s", call.Method)
 		}
 	})
+	t.Run("Leases", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("dhcp", "ipv4leases", map[string]any{"device": map[string]any{
+			"br-lan": map[string]any{"leases": []map[string]any{
+				{"mac": "00:11:22:33:44:55", "hostname": "laptop", "address": "192.168.1.20", "valid": 3500},
+			}},
+		}})
+		mock.AddResponse("dhcp", "ipv6leases", map[string]any{"device": map[string]any{
+			"br-lan": map[string]any{"leases": []map[string]any{
+				{"duid": "000100012b3c4d5e", "iaid": 1, "hostname": "laptop", "valid": 3500, "ipv6-addr": []map[string]any{
+					{"address": "fd00::20", "preferred-lifetime": 1800, "valid-lifetime": 3500},
+				}},
+			}},
+		}})
+
+		mgr := dhcp.New(mock, mockDhcpDialect{})
+
+		v4, err := mgr.IPv4Leases(ctx)
+		if err != nil {
+			t.Fatalf("IPv4Leases failed: %v", err)
+		}
+
+		want := []dhcp.IPv4Lease{{Hostname: "laptop", IPAddr: "192.168.1.20", MACAddr: "00:11:22:33:44:55", Expires: 3500}}
+		if !reflect.DeepEqual(v4, want) {
+			t.Errorf("unexpected IPv4 leases: %+v", v4)
+		}
+
+		v6, err := mgr.IPv6Leases(ctx)
+		if err != nil {
+			t.Fatalf("IPv6Leases failed: %v", err)
+		}
+
+		if len(v6) != 1 || v6[0].DUID != "000100012b3c4d5e" ||
+			!reflect.DeepEqual(v6[0].IPAddr, []string{"fd00::20"}) || v6[0].Expires != 3500 {
+			t.Errorf("unexpected IPv6 leases: %+v", v6)
+		}
+	})
+
+	t.Run("StaticLeases_Remove", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("uci", "get", map[string]any{
+			"values": map[string]any{
+				"cfg01": map[string]any{".type": "dnsmasq", ".name": "cfg01", ".index": 0},
+				"nas": map[string]any{
+					".type": "host", ".name": "nas", ".index": 1,
+					"name": "nas", "ip": "192.168.1.10", "mac": "00:11:22:33:44:55 00:11:22:33:44:56",
+				},
+				"cfg03": map[string]any{
+					".type": "host", ".name": "cfg03", ".index": 2,
+					"ip": "192.168.1.11", "mac": []string{"AA:BB:CC:DD:EE:FF"},
+				},
+			},
+		})
+		mock.AddResponse("uci", "delete", map[string]any{})
+		mock.AddResponse("uci", "set", map[string]any{})
+		mock.AddResponse("uci", "commit", map[string]any{})
+
+		mgr := dhcp.New(mock, mockDhcpDialect{})
+
+		leases, err := mgr.StaticLeases(ctx)
+		if err != nil {
+			t.Fatalf("StaticLeases failed: %v", err)
+		}
+
+		if len(leases) != 2 || leases[0].Section != "nas" || len(leases[0].MACs) != 2 ||
+			!leases[1].HasMAC("aa:bb:cc:dd:ee:ff") {
+			t.Fatalf("unexpected leases: %+v", leases)
+		}
+
+		err = mgr.RemoveLease(ctx, "aa:bb:cc:dd:ee:ff")
+		if err != nil {
+			t.Fatalf("RemoveLease failed: %v", err)
+		}
+
+		deleted := mock.Calls[len(mock.Calls)-2]
+
+		req, _ := deleted.Data.(uci.RequestGeneric)
+		if deleted.Method != "delete" || req.Section != "cfg03" || mock.GetLastCall().Method != "commit" {
+			t.Errorf("unexpected removal calls: %+v", mock.Calls)
+		}
+
+		// The other address of nas stays reserved.
+		err = mgr.RemoveLease(ctx, "00:11:22:33:44:56")
+		if err != nil {
+			t.Fatalf("RemoveLease failed: %v", err)
+		}
+
+		set, _ := mock.Calls[len(mock.Calls)-2].Data.(uci.Request)
+		if set.Section != "nas" || !reflect.DeepEqual(set.Values["mac"], []string{"00:11:22:33:44:55"}) ||
+			set.Values["ip"] != "192.168.1.10" {
+			t.Errorf("unexpected update: %+v", set)
+		}
+
+		err = mgr.RemoveLease(ctx, "00:00:00:00:00:00")
+		if !errdefs.IsNotFound(err) {
+			t.Errorf("expected not found, got %v", err)
+		}
+	})
+
+	t.Run("WatchLeases", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mgr := dhcp.New(mock, mockDhcpDialect{})
+
+		sub, err := mgr.WatchLeases(ctx)
+		if err != nil {
+			t.Fatalf("WatchLeases failed: %v", err)
+		}
+
+		defer func() { _ = sub.Close() }()
+
+		mock.Emit("dnsmasq", "dns.query", map[string]any{"name": "example.org"})
+		mock.Emit("dnsmasq", dhcp.LeaseEventAck, map[string]any{
+			"mac": "00:11:22:33:44:55", "ip": "192.168.1.20", "name": "phone", "interface": "br-lan",
+		})
+
+		lease, ok := dhcp.ParseLeaseEvent(<-sub.Events())
+		if !ok || lease.Type != dhcp.LeaseEventAck || lease.IP != "192.168.1.20" || lease.Interface != "br-lan" {
+			t.Errorf("unexpected lease event: %+v", lease)
+		}
+	})
 }
@@ -25,6 +25,32 @@ type IPv6Lease struct {
 	Expires  int64    `json:"expires"`
 }
 
+// DeviceLeases is the reply of the odhcpd ipv4leases and ipv6leases methods, which group the
+// leases by device.
+type DeviceLeases[T any] struct {
+	Device map[string]struct {
+		Leases []T `json:"leases"`
+	} `json:"device"`
+}
+
+// ipv4LeaseReply is a lease of the odhcpd ipv4leases reply.
+type ipv4LeaseReply struct {
+	Hostname string `json:"hostname"`
+	Address  string `json:"address"`
+	MAC      string `json:"mac"`
+	Valid    int64  `json:"valid"`
+}
+
+// ipv6LeaseReply is a lease of the odhcpd ipv6leases reply.
+type ipv6LeaseReply struct {
+	Hostname  string `json:"hostname"`
+	DUID      string `json:"duid"`
+	Addresses []struct {
+		Address string `json:"address"`
+	} `json:"ipv6-addr"`
+	Valid int64 `json:"valid"`
+}
+
 // IPv6RA represents an IPv6 Router Advertisement entry.
 type IPv6RA struct {
 	Hostname string   `json:"hostname"`
@@ -42,3 +68,31 @@ type AddLeaseRequest struct {
 	LeaseTime string
 	Name      string
 }
+
+// StaticLease is a "host" section of /etc/config/dhcp, served by dnsmasq.
+type StaticLease struct {
+	// Section is the UCI section name; it is empty for leases that were not saved yet.
+	Section   string   `json:"section,omitempty"`
+	Name      string   `json:"name,omitempty"`
+	IP        string   `json:"ip,omitempty"`
+	DUID      string   `json:"duid,omitempty"`
+	HostID    string   `json:"hostid,omitempty"`
+	LeaseTime string   `json:"leasetime,omitempty"`
+	MACs      []string `json:"mac,omitempty"`
+}
+
+// Lease event types sent by dnsmasq on its ubus object.
+const (
+	LeaseEventAck     = "dhcp.ack"
+	LeaseEventRelease = "dhcp.release"
+)
+
+// LeaseEvent is a lease change reported by dnsmasq through a ubus notification.
+type LeaseEvent struct {
+	// Type is the notification type, e.g. "dhcp.ack".
+	Type      string `json:"type"`
+	MAC       string `json:"mac"`
+	IP        string `json:"ip"`
+	Name      string `json:"name"`
+	Interface string `json:"interface"`
+}
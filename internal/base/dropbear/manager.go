@@ -0,0 +1,376 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package dropbear
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/file"
+	"github.com/honeybbq/goubus/v2/internal/base/uci"
+)
+
+const (
+	uciPackage         = "dropbear"
+	uciSectionType     = "dropbear"
+	authorizedKeysPath = "/etc/dropbear/authorized_keys"
+	authorizedKeysMode = 0o600
+)
+
+// Manager provides methods to configure the dropbear SSH server and its authorized keys.
+type Manager struct {
+	uci  *uci.Manager
+	file *file.Manager
+}
+
+// New creates a new base dropbear Manager.
+func New(t goubus.Transport) *Manager {
+	return &Manager{
+		uci:  uci.New(t, nil),
+		file: file.New(t),
+	}
+}
+
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"uci", "file"}
+}
+
+// Configs retrieves all dropbear instances in configuration order.
+func (m *Manager) Configs(ctx context.Context) ([]Config, error) {
+	sections, err := m.uci.Package(uciPackage).GetAll(ctx)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read dropbear config")
+	}
+
+	var configs []Config
+
+	for _, section := range uci.SortedSections(sections) {
+		if section.Type != uciSectionType {
+			continue
+		}
+
+		configs = append(configs, ConfigFromSection(section))
+	}
+
+	return configs, nil
+}
+
+// SetConfig stages the configuration of the named dropbear section and commits the package.
+func (m *Manager) SetConfig(ctx context.Context, cfg Config) error {
+	if cfg.Name == "" {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "dropbear section name is required")
+	}
+
+	pkg := m.uci.Package(uciPackage)
+
+	err := pkg.Section(cfg.Name).SetValues(ctx, cfg.SectionValues())
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to set dropbear config")
+	}
+
+	return pkg.Commit(ctx)
+}
+
+// AuthorizedKeys lists the public keys allowed to log in as root.
+func (m *Manager) AuthorizedKeys(ctx context.Context) ([]AuthorizedKey, error) {
+	lines, err := m.authorizedKeysLines(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseAuthorizedKeys(strings.Join(lines, "\n")), nil
+}
+
+// AddAuthorizedKey appends a public key unless an entry with the same key material already exists.
+// The rest of the file is left as it is.
+func (m *Manager) AddAuthorizedKey(ctx context.Context, line string) error {
+	key, err := ParseAuthorizedKey(line)
+	if err != nil {
+		return err
+	}
+
+	lines, err := m.authorizedKeysLines(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, existing := range lines {
+		if parsed, err := ParseAuthorizedKey(existing); err == nil && parsed.Key == key.Key {
+			return nil
+		}
+	}
+
+	return m.writeAuthorizedKeys(ctx, append(lines, key.String()))
+}
+
+// RemoveAuthorizedKey removes every entry whose key material or comment matches the given value.
+// Comments and lines that are not keys are kept.
+func (m *Manager) RemoveAuthorizedKey(ctx context.Context, keyOrComment string) error {
+	match := strings.TrimSpace(keyOrComment)
+	if parsed, err := ParseAuthorizedKey(match); err == nil {
+		match = parsed.Key
+	}
+
+	lines, err := m.authorizedKeysLines(ctx)
+	if err != nil {
+		return err
+	}
+
+	kept := slices.DeleteFunc(slices.Clone(lines), func(line string) bool {
+		key, err := ParseAuthorizedKey(line)
+
+		return err == nil && (key.Key == match || (key.Comment != "" && key.Comment == match))
+	})
+	if len(kept) == len(lines) {
+		return nil
+	}
+
+	return m.writeAuthorizedKeys(ctx, kept)
+}
+
+// SetAuthorizedKeys replaces the keys of the authorized_keys file. Comments and lines that are not
+// keys are kept; keys already in the file are rewritten in place and new keys are appended.
+func (m *Manager) SetAuthorizedKeys(ctx context.Context, keys []AuthorizedKey) error {
+	lines, err := m.authorizedKeysLines(ctx)
+	if err != nil {
+		return err
+	}
+
+	pending := slices.Clone(keys)
+	result := make([]string, 0, len(lines)+len(keys))
+
+	for _, line := range lines {
+		existing, err := ParseAuthorizedKey(line)
+		if err != nil {
+			result = append(result, line)
+
+			continue
+		}
+
+		i := slices.IndexFunc(pending, func(key AuthorizedKey) bool { return key.Key == existing.Key })
+		if i < 0 {
+			continue
+		}
+
+		result = append(result, pending[i].String())
+		pending = slices.Delete(pending, i, i+1)
+	}
+
+	for _, key := range pending {
+		result = append(result, key.String())
+	}
+
+	return m.writeAuthorizedKeys(ctx, result)
+}
+
+// authorizedKeysLines reads the lines of the authorized_keys file, which may not exist yet.
+func (m *Manager) authorizedKeysLines(ctx context.Context) ([]string, error) {
+	content, err := m.file.Read(ctx, authorizedKeysPath, false)
+	if err != nil {
+		if errdefs.IsNotFound(err) || errdefs.IsNoData(err) {
+			return nil, nil
+		}
+
+		return nil, errdefs.Wrapf(err, "failed to read authorized keys")
+	}
+
+	data := strings.TrimSuffix(content.Data, "\n")
+	if data == "" {
+		return nil, nil
+	}
+
+	return strings.Split(data, "\n"), nil
+}
+
+// writeAuthorizedKeys replaces the authorized_keys file with the given lines.
+// The content is written to a temporary file first and then moved into place so a partial
+// upload never leaves the device without its previous keys.
+func (m *Manager) writeAuthorizedKeys(ctx context.Context, lines []string) error {
+	var content string
+	if len(lines) > 0 {
+		content = strings.Join(lines, "\n") + "\n"
+	}
+
+	err := m.file.Replace(ctx, authorizedKeysPath, content, authorizedKeysMode, false)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to write authorized keys")
+	}
+
+	return nil
+}
+
+// ParseAuthorizedKeys parses the content of an authorized_keys file, skipping blank lines,
+// comments, and malformed entries.
+func ParseAuthorizedKeys(content string) []AuthorizedKey {
+	keys := []AuthorizedKey{}
+
+	for line := range strings.SplitSeq(content, "\n") {
+		key, err := ParseAuthorizedKey(line)
+		if err != nil {
+			continue
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// ParseAuthorizedKey parses a single authorized_keys line of the form "[options] type key [comment]".
+func ParseAuthorizedKey(line string) (AuthorizedKey, error) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return AuthorizedKey{}, errdefs.Wrapf(errdefs.ErrInvalidParameter, "empty authorized key")
+	}
+
+	fields := splitKeyFields(line)
+
+	var key AuthorizedKey
+	if !isKeyType(fields[0]) {
+		key.Options = fields[0]
+		fields = fields[1:]
+	}
+
+	const minKeyFields = 2
+	if len(fields) < minKeyFields || !isKeyType(fields[0]) {
+		return AuthorizedKey{}, errdefs.Wrapf(errdefs.ErrInvalidParameter, "malformed authorized key: %q", line)
+	}
+
+	key.Type = fields[0]
+	key.Key = fields[1]
+	key.Comment = strings.Join(fields[minKeyFields:], " ")
+
+	return key, nil
+}
+
+// FormatAuthorizedKeys renders keys in authorized_keys file format.
+func FormatAuthorizedKeys(keys []AuthorizedKey) string {
+	var builder strings.Builder
+
+	for _, key := range keys {
+		builder.WriteString(key.String())
+		builder.WriteByte('\n')
+	}
+
+	return builder.String()
+}
+
+// String renders the key as a single authorized_keys line.
+func (k AuthorizedKey) String() string {
+	parts := make([]string, 0, 4)
+	if k.Options != "" {
+		parts = append(parts, k.Options)
+	}
+
+	parts = append(parts, k.Type, k.Key)
+	if k.Comment != "" {
+		parts = append(parts, k.Comment)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// ConfigFromSection converts a UCI section into a Config.
+func ConfigFromSection(section *uci.Section) Config {
+	cfg := Config{
+		Name:             section.Name,
+		Interface:        section.GetString("Interface"),
+		BannerFile:       section.GetString("BannerFile"),
+		Port:             section.GetInt("Port"),
+		MaxAuthTries:     section.GetInt("MaxAuthTries"),
+		IdleTimeout:      section.GetInt("IdleTimeout"),
+		Enabled:          true,
+		PasswordAuth:     true,
+		RootPasswordAuth: true,
+		RootLogin:        true,
+		GatewayPorts:     section.GetBool("GatewayPorts"),
+	}
+
+	// Options below default to enabled in the dropbear init script when absent.
+	if _, ok := section.GetFirst("enable"); ok {
+		cfg.Enabled = section.GetBool("enable")
+	}
+
+	if _, ok := section.GetFirst("PasswordAuth"); ok {
+		cfg.PasswordAuth = section.GetBool("PasswordAuth")
+	}
+
+	if _, ok := section.GetFirst("RootPasswordAuth"); ok {
+		cfg.RootPasswordAuth = section.GetBool("RootPasswordAuth")
+	}
+
+	if _, ok := section.GetFirst("RootLogin"); ok {
+		cfg.RootLogin = section.GetBool("RootLogin")
+	}
+
+	return cfg
+}
+
+// SectionValues converts the Config into UCI option values.
+func (c *Config) SectionValues() uci.SectionValues {
+	values := uci.NewSectionValues()
+	values.SetBool("enable", c.Enabled)
+	values.SetBool("PasswordAuth", c.PasswordAuth)
+	values.SetBool("RootPasswordAuth", c.RootPasswordAuth)
+	values.SetBool("RootLogin", c.RootLogin)
+	values.SetBool("GatewayPorts", c.GatewayPorts)
+	values.SetScalar("Interface", c.Interface)
+	values.SetScalar("BannerFile", c.BannerFile)
+
+	if c.Port > 0 {
+		values.Set("Port", strconv.Itoa(c.Port))
+	}
+
+	if c.MaxAuthTries > 0 {
+		values.Set("MaxAuthTries", strconv.Itoa(c.MaxAuthTries))
+	}
+
+	if c.IdleTimeout > 0 {
+		values.Set("IdleTimeout", strconv.Itoa(c.IdleTimeout))
+	}
+
+	return values
+}
+
+func isKeyType(field string) bool {
+	return strings.HasPrefix(field, "ssh-") ||
+		strings.HasPrefix(field, "ecdsa-") ||
+		strings.HasPrefix(field, "sk-")
+}
+
+// splitKeyFields splits on whitespace while keeping double-quoted option values intact.
+func splitKeyFields(line string) []string {
+	var (
+		fields  []string
+		current strings.Builder
+		quoted  bool
+	)
+
+	for _, r := range line {
+		switch {
+		case r == '"':
+			quoted = !quoted
+
+			current.WriteRune(r)
+		case (r == ' ' || r == '\t') && !quoted:
+			if current.Len() > 0 {
+				fields = append(fields, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+
+	if current.Len() > 0 {
+		fields = append(fields, current.String())
+	}
+
+	return fields
+}
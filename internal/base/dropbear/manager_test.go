@@ -0,0 +1,156 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package dropbear_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/honeybbq/goubus/v2/internal/base/dropbear"
+	"github.com/honeybbq/goubus/v2/internal/testutil"
+)
+
+const testKeys = `ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOne admin@laptop
+# disabled key
+no-pty,command="echo hello world" ssh-rsa AAAAB3NzaC1yc2EAAAADTwo backup
+`
+
+func TestDropbearManager(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Configs", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("uci", "get", map[string]any{
+			"values": map[string]any{
+				"cfg014dd4": map[string]any{
+					".type":        "dropbear",
+					".name":        "cfg014dd4",
+					".index":       0,
+					".anonymous":   true,
+					"PasswordAuth": "off",
+					"Port":         "22",
+					"Interface":    "lan",
+				},
+			},
+		})
+
+		mgr := dropbear.New(mock)
+
+		configs, err := mgr.Configs(ctx)
+		if err != nil {
+			t.Fatalf("Configs failed: %v", err)
+		}
+
+		if len(configs) != 1 {
+			t.Fatalf("expected 1 config, got %d", len(configs))
+		}
+
+		cfg := configs[0]
+		if cfg.Port != 22 || cfg.PasswordAuth || !cfg.RootPasswordAuth || !cfg.Enabled || cfg.Interface != "lan" {
+			t.Errorf("unexpected config: %+v", cfg)
+		}
+	})
+
+	t.Run("AuthorizedKeys", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("file", "read", map[string]any{"data": testKeys})
+
+		mgr := dropbear.New(mock)
+
+		keys, err := mgr.AuthorizedKeys(ctx)
+		if err != nil {
+			t.Fatalf("AuthorizedKeys failed: %v", err)
+		}
+
+		if len(keys) != 2 {
+			t.Fatalf("expected 2 keys, got %d", len(keys))
+		}
+
+		if keys[1].Options != `no-pty,command="echo hello world"` || keys[1].Comment != "backup" {
+			t.Errorf("unexpected key: %+v", keys[1])
+		}
+	})
+
+	t.Run("RemoveAuthorizedKey_AtomicReplace", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("file", "read", map[string]any{"data": testKeys})
+		mock.AddResponse("file", "write", map[string]any{})
+		mock.AddResponse("file", "exec", map[string]any{"code": 0})
+
+		mgr := dropbear.New(mock)
+
+		err := mgr.RemoveAuthorizedKey(ctx, "admin@laptop")
+		if err != nil {
+			t.Fatalf("RemoveAuthorizedKey failed: %v", err)
+		}
+
+		write := mock.Calls[len(mock.Calls)-2]
+
+		params, ok := write.Data.(map[string]any)
+		tmp, _ := params["path"].(string)
+		if !ok || !strings.HasPrefix(tmp, "/etc/dropbear/authorized_keys.tmp-") {
+			t.Fatalf("unexpected write call: %+v", write)
+		}
+
+		data, _ := params["data"].(string)
+		if strings.Contains(data, "admin@laptop") || !strings.Contains(data, "backup") ||
+			!strings.Contains(data, "# disabled key\n") {
+			t.Errorf("unexpected written keys: %q", data)
+		}
+
+		if call := mock.GetLastCall(); call.Method != "exec" {
+			t.Errorf("expected exec as last call, got %s", call.Method)
+		}
+	})
+
+	t.Run("SetAuthorizedKeys_KeepsOtherLines", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("file", "read", map[string]any{"data": testKeys + "from=\"10.0.0.1\" unknown-key AAAA\n"})
+		mock.AddResponse("file", "write", map[string]any{})
+		mock.AddResponse("file", "exec", map[string]any{"code": 0})
+
+		err := dropbear.New(mock).SetAuthorizedKeys(ctx, []dropbear.AuthorizedKey{
+			{Type: "ssh-rsa", Key: "AAAAB3NzaC1yc2EAAAADTwo", Comment: "renamed"},
+			{Type: "ssh-ed25519", Key: "AAAAC3NzaC1lZDI1NTE5AAAAINew", Comment: "new"},
+		})
+		if err != nil {
+			t.Fatalf("SetAuthorizedKeys failed: %v", err)
+		}
+
+		params, _ := mock.Calls[len(mock.Calls)-2].Data.(map[string]any)
+
+		want := "# disabled key\n" +
+			"ssh-rsa AAAAB3NzaC1yc2EAAAADTwo renamed\n" +
+			"from=\"10.0.0.1\" unknown-key AAAA\n" +
+			"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAINew new\n"
+		if params["data"] != want {
+			t.Errorf("unexpected written keys: %q", params["data"])
+		}
+	})
+
+	t.Run("AddAuthorizedKey_KeepsOtherLines", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("file", "read", map[string]any{"data": testKeys})
+		mock.AddResponse("file", "write", map[string]any{})
+		mock.AddResponse("file", "exec", map[string]any{"code": 0})
+
+		err := dropbear.New(mock).AddAuthorizedKey(ctx, "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAINew new")
+		if err != nil {
+			t.Fatalf("AddAuthorizedKey failed: %v", err)
+		}
+
+		params, _ := mock.Calls[len(mock.Calls)-2].Data.(map[string]any)
+		if params["data"] != testKeys+"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAINew new\n" {
+			t.Errorf("unexpected written keys: %q", params["data"])
+		}
+	})
+
+	t.Run("ParseAuthorizedKey_Invalid", func(t *testing.T) {
+		_, err := dropbear.ParseAuthorizedKey("not a key")
+		if err == nil {
+			t.Error("expected error for malformed key")
+		}
+	})
+}
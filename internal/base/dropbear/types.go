@@ -0,0 +1,27 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package dropbear
+
+// Config represents a "dropbear" section in /etc/config/dropbear.
+type Config struct {
+	Name             string `json:"name"`
+	Interface        string `json:"Interface"`
+	BannerFile       string `json:"BannerFile"`
+	Port             int    `json:"Port"`
+	MaxAuthTries     int    `json:"MaxAuthTries"`
+	IdleTimeout      int    `json:"IdleTimeout"`
+	Enabled          bool   `json:"enable"`
+	PasswordAuth     bool   `json:"PasswordAuth"`
+	RootPasswordAuth bool   `json:"RootPasswordAuth"`
+	RootLogin        bool   `json:"RootLogin"`
+	GatewayPorts     bool   `json:"GatewayPorts"`
+}
+
+// AuthorizedKey represents a single entry of /etc/dropbear/authorized_keys.
+type AuthorizedKey struct {
+	Options string `json:"options,omitempty"`
+	Type    string `json:"type"`
+	Key     string `json:"key"`
+	Comment string `json:"comment,omitempty"`
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package file
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+var checksumCommands = map[ChecksumAlgorithm]string{
+	ChecksumMD5:    "/usr/bin/md5sum",
+	ChecksumSHA256: "/usr/bin/sha256sum",
+}
+
+var checksumLengths = map[ChecksumAlgorithm]int{
+	ChecksumMD5:    32,
+	ChecksumSHA256: 64,
+}
+
+// Checksum computes the digest of path on the device, so a transfer can be verified without reading it back.
+// MD5 prefers the rpcd file md5 method; SHA-256, and MD5 when that method is missing or denied,
+// run md5sum or sha256sum through exec.
+func (m *Manager) Checksum(ctx context.Context, path string, algo ChecksumAlgorithm) (*Checksum, error) {
+	command, ok := checksumCommands[algo]
+	if !ok {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "unsupported checksum algorithm %q", algo)
+	}
+
+	if algo == ChecksumMD5 {
+		sum, err := m.MD5(ctx, path)
+
+		switch {
+		case err == nil && sum != "":
+			return newChecksum(path, algo, sum)
+		case err != nil && !canExecChecksum(err):
+			return nil, errdefs.Wrapf(err, "failed to checksum %s", path)
+		}
+	}
+
+	// "--" keeps a path starting with "-" from being parsed as an option.
+	res, err := m.Exec(ctx, command, []string{"--", path}, nil)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to checksum %s", path)
+	}
+
+	if res.Code != 0 {
+		return nil, errdefs.Wrapf(errdefs.ErrUnknown, "%s exited with code %d: %s",
+			command, res.Code, strings.TrimSpace(res.Stderr))
+	}
+
+	fields := strings.Fields(res.Stdout)
+	if len(fields) == 0 {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidResponse, "%s printed no digest", command)
+	}
+
+	return newChecksum(path, algo, fields[0])
+}
+
+// canExecChecksum reports whether a failed md5 call may still be answered by md5sum.
+func canExecChecksum(err error) bool {
+	return errdefs.IsMethodNotFound(err) || errdefs.IsPermissionDenied(err) || errdefs.IsNotSupported(err)
+}
+
+func newChecksum(path string, algo ChecksumAlgorithm, sum string) (*Checksum, error) {
+	sum = strings.ToLower(sum)
+
+	_, err := hex.DecodeString(sum)
+	if err != nil || len(sum) != checksumLengths[algo] {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidResponse, "malformed %s digest %q", algo, sum)
+	}
+
+	return &Checksum{Path: path, Algorithm: algo, Sum: sum}, nil
+}
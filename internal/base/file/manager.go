@@ -5,12 +5,18 @@ package file
 
 import (
 	"context"
+	"errors"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/honeybbq/goubus/v2"
 	"github.com/honeybbq/goubus/v2/errdefs"
 )
 
+// DefaultChunkSize is the default number of bytes moved per call by the chunked transfers.
+const DefaultChunkSize = 64 * 1024
+
 // Manager provides methods to interact with the device's filesystem.
 type Manager struct {
 	caller goubus.Transport
@@ -21,6 +27,11 @@ func New(t goubus.Transport) *Manager {
 	return &Manager{caller: t}
 }
 
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"file"}
+}
+
 // Read retrieves file contents.
 func (m *Manager) Read(ctx context.Context, path string, base64 bool) (*Read, error) {
 	params := map[string]any{"path": path}
@@ -59,6 +70,41 @@ func (m *Manager) Write(ctx context.Context, path, data string, isAppend bool, m
 	return err
 }
 
+// Replace atomically replaces a file: data is written to "<path>.tmp-<random>" next to path and
+// then moved over path, so a failed upload never leaves a truncated file behind and concurrent
+// replacements of the same file do not share a temporary file. The temporary file is removed
+// when the upload or the move fails.
+func (m *Manager) Replace(ctx context.Context, path, data string, mode os.FileMode, base64 bool) error {
+	id, err := uniqueID()
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp-" + id
+
+	err = m.Write(ctx, tmp, data, false, mode, base64)
+	if err != nil {
+		_ = m.Remove(ctx, tmp)
+
+		return errdefs.Wrapf(err, "failed to write %s", tmp)
+	}
+
+	res, err := m.Exec(ctx, "/bin/mv", []string{"-f", tmp, path}, nil)
+	if err != nil {
+		_ = m.Remove(ctx, tmp)
+
+		return errdefs.Wrapf(err, "failed to replace %s", path)
+	}
+
+	if res.Code != 0 {
+		_ = m.Remove(ctx, tmp)
+
+		return errdefs.Wrapf(errdefs.ErrUnknown, "mv exited with code %d: %s", res.Code, strings.TrimSpace(res.Stderr))
+	}
+
+	return nil
+}
+
 // List lists directory contents.
 func (m *Manager) List(ctx context.Context, path string) (*List, error) {
 	params := map[string]any{"path": path}
@@ -115,3 +161,49 @@ func (m *Manager) LStat(ctx context.Context, path string) (*Stat, error) {
 
 	return goubus.Call[Stat](ctx, m.caller, "file", "lstat", params)
 }
+
+// Upload streams src into path in chunks of chunk bytes using appending base64 writes,
+// so files larger than the ubus message limit can be transferred. It returns the bytes written.
+func (m *Manager) Upload(ctx context.Context, path string, src io.Reader, mode os.FileMode, chunk int) (int64, error) {
+	if chunk <= 0 {
+		chunk = DefaultChunkSize
+	}
+
+	buf := make([]byte, chunk)
+
+	var written int64
+
+	for {
+		n, readErr := io.ReadFull(src, buf)
+		if n > 0 || written == 0 {
+			err := m.writeChunk(ctx, path, buf[:n], written > 0, mode)
+			if err != nil {
+				return written, errdefs.Wrapf(err, "failed to upload %s at offset %d", path, written)
+			}
+
+			written += int64(n)
+		}
+
+		if errors.Is(readErr, io.EOF) || errors.Is(readErr, io.ErrUnexpectedEOF) {
+			return written, nil
+		}
+
+		if readErr != nil {
+			return written, errdefs.Wrapf(errdefs.ErrInvalidParameter, "read upload data: %v", readErr)
+		}
+	}
+}
+
+// Download streams the content of path to w in chunks of chunkSize bytes and returns the bytes copied.
+// It reads through ReadStream, so files larger than one chunk, and files without a size such as
+// those of procfs, need exec permission for /bin/dd.
+func (m *Manager) Download(ctx context.Context, path string, w io.Writer, chunkSize int) (int64, error) {
+	src, err := m.ReadStream(ctx, path, 0, chunkSize)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() { _ = src.Close() }()
+
+	return io.Copy(w, src)
+}
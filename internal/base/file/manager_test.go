@@ -4,9 +4,14 @@
 package file_test
 
 import (
+	"bytes"
 	"context"
+	"io"
+	"slices"
+	"strings"
 	"testing"
 
+	"github.com/honeybbq/goubus/v2/errdefs"
 	"github.com/honeybbq/goubus/v2/internal/base/file"
 	"github.com/honeybbq/goubus/v2/internal/testutil"
 )
@@ -58,4 +63,291 @@ func TestFileManager(t *testing.T) {
 			t.Errorf("unexpected list data: %+v", list)
 		}
 	})
+
+	t.Run("Upload_Chunked", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("file", "write", map[string]any{})
+
+		mgr := file.New(mock)
+
+		n, err := mgr.Upload(ctx, "/tmp/blob", strings.NewReader("abcde"), 0o600, 2)
+		if err != nil || n != 5 {
+			t.Fatalf("Upload failed: %d, %v", n, err)
+		}
+
+		var chunks []string
+
+		for i, call := range mock.Calls {
+			params, ok := call.Data.(map[string]any)
+			if !ok || (i > 0) != (params["append"] == true) || params["base64"] != true {
+				t.Errorf("unexpected write: %+v", call.Data)
+
+				continue
+			}
+
+			chunk, _ := params["data"].(string)
+			chunks = append(chunks, chunk)
+		}
+
+		want := []string{"YWI=", "Y2Q=", "ZQ=="}
+		if !slices.Equal(chunks, want) {
+			t.Errorf("expected chunks %v, got %v", want, chunks)
+		}
+	})
+
+	t.Run("Download_Chunked", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("file", "stat", map[string]any{"path": "/tmp/blob", "type": "file", "size": 5})
+		mock.AddResponse("file", "exec", map[string]any{"code": 0})
+		mock.AddResponse("file", "read", map[string]any{"data": "YWI="})
+		mock.AddResponse("file", "remove", map[string]any{})
+
+		mgr := file.New(mock)
+
+		var buf bytes.Buffer
+
+		n, err := mgr.Download(ctx, "/tmp/blob", &buf, 2)
+		if err != nil {
+			t.Fatalf("Download failed: %v", err)
+		}
+
+		if n != 6 || buf.String() != "ababab" {
+			t.Errorf("unexpected download: %d %q", n, buf.String())
+		}
+
+		cuts := 0
+
+		for _, call := range mock.Calls {
+			if call.Method == "exec" {
+				cuts++
+			}
+		}
+
+		if cuts != 3 || mock.GetLastCall().Method != "remove" {
+			t.Errorf("expected 3 dd cuts and a cleanup, got %+v", mock.Calls)
+		}
+	})
+	t.Run("Replace", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("file", "write", map[string]any{})
+		mock.AddResponse("file", "exec", map[string]any{"code": 1, "stderr": "mv: can't rename: No space left on device"})
+		mock.AddResponse("file", "remove", map[string]any{})
+
+		mgr := file.New(mock)
+
+		var temps []string
+
+		for range 2 {
+			err := mgr.Replace(ctx, "/etc/sysctl.d/90-tuning.conf", "net.ipv4.tcp_ecn = 1\n", 0o644, false)
+			if !errdefs.IsUnknown(err) {
+				t.Fatalf("expected the failed move to be reported, got %v", err)
+			}
+
+			params, _ := mock.GetLastCall().Data.(map[string]any)
+			temp, _ := params["path"].(string)
+
+			if mock.GetLastCall().Method != "remove" || !strings.HasPrefix(temp, "/etc/sysctl.d/90-tuning.conf.tmp-") {
+				t.Fatalf("expected the temporary file to be removed, got %+v", mock.GetLastCall())
+			}
+
+			temps = append(temps, temp)
+		}
+
+		if temps[0] == temps[1] {
+			t.Errorf("expected unique temporary files, got %v", temps)
+		}
+	})
+	t.Run("ReadStream_Procfs", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponseForArgs("file", "stat", map[string]any{"path": "/proc/net/nf_conntrack"},
+			map[string]any{"path": "/proc/net/nf_conntrack", "type": "file", "size": 0})
+		mock.AddResponse("file", "stat", map[string]any{"type": "file", "size": 3})
+		mock.AddResponse("file", "exec", map[string]any{"code": 0})
+		mock.AddResponse("file", "read", map[string]any{"data": "YWJj"})
+		mock.AddResponse("file", "remove", map[string]any{})
+
+		src, err := file.New(mock).ReadStream(ctx, "/proc/net/nf_conntrack", 0, 64)
+		if err != nil {
+			t.Fatalf("ReadStream failed: %v", err)
+		}
+
+		data, err := io.ReadAll(src)
+		if err != nil || string(data) != "abc" {
+			t.Fatalf("unexpected stream: %q, %v", data, err)
+		}
+
+		params, _ := mock.Calls[1].Data.(map[string]any)
+		args, _ := params["params"].([]string)
+
+		if len(args) != 2 || args[0] != "if=/proc/net/nf_conntrack" || !strings.HasPrefix(args[1], "of=/tmp/goubus-") {
+			t.Errorf("expected the file to be copied in full, got %v", args)
+		}
+
+		err = src.Close()
+		if err != nil || mock.GetLastCall().Method != "remove" {
+			t.Errorf("expected the copy to be removed, got %v", err)
+		}
+	})
+	t.Run("ReadStream_Offset", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("file", "stat", map[string]any{"path": "/tmp/blob", "type": "file", "size": 5})
+		mock.AddResponse("file", "exec", map[string]any{"code": 0})
+		mock.AddResponse("file", "read", map[string]any{"data": "YWI="})
+		mock.AddResponse("file", "remove", map[string]any{})
+
+		mgr := file.New(mock)
+
+		src, err := mgr.ReadStream(ctx, "/tmp/blob", 3, 2)
+		if err != nil {
+			t.Fatalf("ReadStream failed: %v", err)
+		}
+
+		data, err := io.ReadAll(src)
+		if err != nil || string(data) != "bab" {
+			t.Fatalf("unexpected stream: %q, %v", data, err)
+		}
+
+		var skips, parts []string
+
+		for _, call := range mock.Calls {
+			params, _ := call.Data.(map[string]any)
+			if args, ok := params["params"].([]string); ok {
+				skips = append(skips, args[3])
+				parts = append(parts, args[1])
+			}
+		}
+
+		if !slices.Equal(skips, []string{"skip=1", "skip=2"}) {
+			t.Errorf("unexpected dd cuts: %v", skips)
+		}
+
+		if !strings.HasPrefix(parts[0], "of=/tmp/goubus-") {
+			t.Errorf("expected the chunks to be cut into /tmp, got %v", parts)
+		}
+
+		err = src.Close()
+		if err != nil || mock.GetLastCall().Method != "remove" {
+			t.Errorf("expected part file cleanup, got %v", err)
+		}
+
+		_, err = mgr.ReadStream(ctx, "/tmp/blob", -1, 2)
+		if !errdefs.IsInvalidParameter(err) {
+			t.Errorf("expected invalid parameter for negative offset, got %v", err)
+		}
+	})
+
+	t.Run("WriteStream_Buffered", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("file", "write", map[string]any{})
+
+		mgr := file.New(mock)
+
+		dst, err := mgr.WriteStream(ctx, "/tmp/blob", 0o600, 4)
+		if err != nil {
+			t.Fatalf("WriteStream failed: %v", err)
+		}
+
+		for _, part := range []string{"abc", "de"} {
+			_, err = io.WriteString(dst, part)
+			if err != nil {
+				t.Fatalf("Write failed: %v", err)
+			}
+		}
+
+		if len(mock.Calls) != 1 {
+			t.Fatalf("expected one full chunk before Close, got %d writes", len(mock.Calls))
+		}
+
+		err = dst.Close()
+		if err != nil {
+			t.Fatalf("Close failed: %v", err)
+		}
+
+		var chunks []string
+
+		for _, call := range mock.Calls {
+			params, _ := call.Data.(map[string]any)
+			chunk, _ := params["data"].(string)
+			chunks = append(chunks, chunk)
+		}
+
+		if !slices.Equal(chunks, []string{"YWJjZA==", "ZQ=="}) {
+			t.Errorf("unexpected chunks %v", chunks)
+		}
+
+		_, err = dst.Write([]byte("f"))
+		if !errdefs.IsClosed(err) {
+			t.Errorf("expected closed error, got %v", err)
+		}
+	})
+}
+
+func TestFileChecksum(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("MD5_Native", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("file", "md5", map[string]any{"md5": "D41D8CD98F00B204E9800998ECF8427E"})
+
+		sum, err := file.New(mock).Checksum(ctx, "/tmp/fw.bin", file.ChecksumMD5)
+		if err != nil {
+			t.Fatalf("Checksum failed: %v", err)
+		}
+
+		if !sum.Matches("d41d8cd98f00b204e9800998ecf8427e") || len(mock.Calls) != 1 {
+			t.Errorf("unexpected checksum %+v after %d calls", sum, len(mock.Calls))
+		}
+	})
+
+	t.Run("MD5_ExecFallback", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("file", "md5", errdefs.ErrMethodNotFound)
+		mock.AddResponse("file", "exec", map[string]any{
+			"code":   0,
+			"stdout": "d41d8cd98f00b204e9800998ecf8427e  /tmp/fw.bin\n",
+		})
+
+		sum, err := file.New(mock).Checksum(ctx, "/tmp/fw.bin", file.ChecksumMD5)
+		if err != nil || sum.Sum != "d41d8cd98f00b204e9800998ecf8427e" {
+			t.Fatalf("Checksum failed: %+v, %v", sum, err)
+		}
+
+		params, _ := mock.GetLastCall().Data.(map[string]any)
+		args, _ := params["params"].([]string)
+		if params["command"] != "/usr/bin/md5sum" || !slices.Equal(args, []string{"--", "/tmp/fw.bin"}) {
+			t.Errorf("expected md5sum fallback, got %+v", params)
+		}
+	})
+
+	t.Run("SHA256", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("file", "exec", map[string]any{"code": 0, "stdout": strings.Repeat("ab", 32) + "  /tmp/fw.bin\n"})
+
+		sum, err := file.New(mock).Checksum(ctx, "/tmp/fw.bin", file.ChecksumSHA256)
+		if err != nil || sum.Algorithm != file.ChecksumSHA256 || len(sum.Sum) != 64 {
+			t.Fatalf("Checksum failed: %+v, %v", sum, err)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("file", "exec", map[string]any{"code": 0, "stdout": "garbage"})
+
+		_, err := file.New(mock).Checksum(ctx, "/tmp/fw.bin", file.ChecksumSHA256)
+		if !errdefs.IsInvalidResponse(err) {
+			t.Errorf("expected invalid response, got %v", err)
+		}
+
+		_, err = file.New(mock).Checksum(ctx, "/tmp/fw.bin", "crc32")
+		if !errdefs.IsInvalidParameter(err) {
+			t.Errorf("expected invalid parameter, got %v", err)
+		}
+
+		mock.AddResponse("file", "md5", errdefs.ErrNotFound)
+
+		_, err = file.New(mock).Checksum(ctx, "/tmp/missing", file.ChecksumMD5)
+		if !errdefs.IsNotFound(err) || mock.GetLastCall().Method != "md5" {
+			t.Errorf("expected not found without fallback, got %v", err)
+		}
+	})
 }
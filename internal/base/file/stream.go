@@ -0,0 +1,312 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package file
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+// ReadStream opens path for reading from offset, fetching chunk bytes per call only when the
+// returned reader needs them, so multi-megabyte files are never held in memory at once.
+// Files larger than one chunk, or reads from a non-zero offset, are cut on the device with dd
+// into a temporary file under /tmp, which Close removes; the session then needs exec permission
+// for /bin/dd. Files that report a size of 0, such as those of procfs, are first copied to /tmp
+// in full, since rpcd reads only their first page, and are then streamed from the copy.
+func (m *Manager) ReadStream(ctx context.Context, path string, offset int64, chunk int) (io.ReadCloser, error) {
+	if chunk <= 0 {
+		chunk = DefaultChunkSize
+	}
+
+	if offset < 0 {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "negative offset %d", offset)
+	}
+
+	stat, err := m.Stat(ctx, path)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to stat %s", path)
+	}
+
+	tmp, err := tempPath()
+	if err != nil {
+		return nil, err
+	}
+
+	r := &streamReader{
+		ctx:     ctx,
+		manager: m,
+		path:    path,
+		tmp:     tmp,
+		size:    int64(stat.Size),
+		offset:  offset,
+		chunk:   chunk,
+	}
+
+	if stat.Size == 0 {
+		err = r.snapshot()
+		if err != nil {
+			_ = r.Close()
+
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// WriteStream opens path for writing. Data is sent in appending base64 writes of chunk bytes;
+// Close sends the remainder and must be called. The file is truncated by the first write.
+func (m *Manager) WriteStream(ctx context.Context, path string, mode os.FileMode, chunk int) (io.WriteCloser, error) {
+	if path == "" {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "path is required")
+	}
+
+	if chunk <= 0 {
+		chunk = DefaultChunkSize
+	}
+
+	return &streamWriter{
+		ctx:     ctx,
+		manager: m,
+		path:    path,
+		buf:     make([]byte, 0, chunk),
+		mode:    mode,
+	}, nil
+}
+
+type streamReader struct {
+	ctx     context.Context
+	manager *Manager
+	path    string
+	// tmp is the prefix of the temporary files on the device: the copy of a file without a
+	// size and the chunk cut by dd.
+	tmp    string
+	buf    []byte
+	size   int64
+	offset int64
+	chunk  int
+	cut    bool
+	copied bool
+}
+
+func (r *streamReader) Read(p []byte) (int, error) {
+	if len(r.buf) == 0 {
+		if r.offset >= r.size {
+			return 0, io.EOF
+		}
+
+		err := r.fill()
+		if err != nil {
+			return 0, err
+		}
+
+		if len(r.buf) == 0 {
+			// The file shrank since it was opened.
+			r.size = r.offset
+
+			return 0, io.EOF
+		}
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	r.offset += int64(n)
+
+	return n, nil
+}
+
+// snapshot copies a file that reports no size, such as a procfs table, to the device's /tmp and
+// switches the reader to the copy. dd reads until the end of the file, so the copy is complete.
+func (r *streamReader) snapshot() error {
+	err := r.manager.dd(r.ctx, "if="+r.path, "of="+r.tmp)
+	r.copied = true
+
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to copy %s", r.path)
+	}
+
+	stat, err := r.manager.Stat(r.ctx, r.tmp)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to stat the copy of %s", r.path)
+	}
+
+	r.path = r.tmp
+	r.size = int64(stat.Size)
+
+	return nil
+}
+
+// fill fetches the chunk holding the current offset.
+func (r *streamReader) fill() error {
+	if r.offset == 0 && r.size <= int64(r.chunk) {
+		data, err := r.manager.readBase64(r.ctx, r.path)
+		r.buf = data
+
+		return err
+	}
+
+	index := r.offset / int64(r.chunk)
+	part := r.tmp + ".part"
+
+	err := r.manager.dd(r.ctx, "if="+r.path, "of="+part, "bs="+strconv.Itoa(r.chunk),
+		"skip="+strconv.FormatInt(index, 10), "count=1")
+	r.cut = true
+
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to cut chunk %d of %s", index, r.path)
+	}
+
+	data, err := r.manager.readBase64(r.ctx, part)
+	if err != nil {
+		return err
+	}
+
+	skip := min(int(r.offset-index*int64(r.chunk)), len(data))
+	r.buf = data[skip:]
+
+	return nil
+}
+
+func (r *streamReader) Close() error {
+	r.buf = nil
+
+	ctx := context.WithoutCancel(r.ctx)
+
+	var errs []error
+
+	if r.cut {
+		r.cut = false
+		errs = append(errs, r.manager.Remove(ctx, r.tmp+".part"))
+	}
+
+	if r.copied {
+		r.copied = false
+		errs = append(errs, r.manager.Remove(ctx, r.tmp))
+	}
+
+	return errors.Join(errs...)
+}
+
+type streamWriter struct {
+	ctx     context.Context
+	manager *Manager
+	path    string
+	buf     []byte
+	written int64
+	mode    os.FileMode
+	closed  bool
+}
+
+func (w *streamWriter) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, errdefs.Wrapf(errdefs.ErrClosed, "write to closed stream %s", w.path)
+	}
+
+	accepted := 0
+
+	for len(p) > 0 {
+		n := copy(w.buf[len(w.buf):cap(w.buf)], p)
+		w.buf = w.buf[:len(w.buf)+n]
+		p = p[n:]
+		accepted += n
+
+		if len(w.buf) == cap(w.buf) {
+			err := w.flush()
+			if err != nil {
+				return accepted, err
+			}
+		}
+	}
+
+	return accepted, nil
+}
+
+func (w *streamWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+
+	w.closed = true
+
+	if len(w.buf) == 0 && w.written > 0 {
+		return nil
+	}
+
+	return w.flush()
+}
+
+func (w *streamWriter) flush() error {
+	err := w.manager.writeChunk(w.ctx, w.path, w.buf, w.written > 0, w.mode)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to write %s at offset %d", w.path, w.written)
+	}
+
+	w.written += int64(len(w.buf))
+	w.buf = w.buf[:0]
+
+	return nil
+}
+
+func (m *Manager) writeChunk(ctx context.Context, path string, data []byte, isAppend bool, mode os.FileMode) error {
+	return m.Write(ctx, path, base64.StdEncoding.EncodeToString(data), isAppend, mode, true)
+}
+
+// dd runs /bin/dd on the device with args.
+func (m *Manager) dd(ctx context.Context, args ...string) error {
+	res, err := m.Exec(ctx, "/bin/dd", args, nil)
+	if err != nil {
+		return err
+	}
+
+	if res.Code != 0 {
+		return errdefs.Wrapf(errdefs.ErrUnknown, "dd exited with code %d: %s", res.Code, strings.TrimSpace(res.Stderr))
+	}
+
+	return nil
+}
+
+// tempPath returns a unique path under /tmp for the temporary files of a stream.
+func tempPath() (string, error) {
+	id, err := uniqueID()
+	if err != nil {
+		return "", err
+	}
+
+	return "/tmp/goubus-" + id, nil
+}
+
+// uniqueID returns a random name component for temporary files.
+func uniqueID() (string, error) {
+	var id [8]byte
+
+	_, err := rand.Read(id[:])
+	if err != nil {
+		return "", errdefs.Wrapf(errdefs.ErrUnknown, "generate temporary file name: %v", err)
+	}
+
+	return hex.EncodeToString(id[:]), nil
+}
+
+func (m *Manager) readBase64(ctx context.Context, path string) ([]byte, error) {
+	content, err := m.Read(ctx, path, true)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read %s", path)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(content.Data)
+	if err != nil {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidResponse, "decode %s: %v", path, err)
+	}
+
+	return data, nil
+}
@@ -3,6 +3,8 @@
 
 package file
 
+import "strings"
+
 // List represents directory listing.
 type List struct {
 	Entries []ListData `json:"entries"`
@@ -33,3 +35,23 @@ type Exec struct {
 	Stderr string `json:"stderr"`
 	Code   int    `json:"code"`
 }
+
+// ChecksumAlgorithm names a hash supported by Checksum.
+type ChecksumAlgorithm string
+
+const (
+	ChecksumMD5    ChecksumAlgorithm = "md5"
+	ChecksumSHA256 ChecksumAlgorithm = "sha256"
+)
+
+// Checksum is the digest of a file computed on the device.
+type Checksum struct {
+	Path      string            `json:"path"`
+	Algorithm ChecksumAlgorithm `json:"algorithm"`
+	Sum       string            `json:"sum"`
+}
+
+// Matches reports whether the checksum equals the hex digest sum, ignoring case.
+func (c *Checksum) Matches(sum string) bool {
+	return strings.EqualFold(c.Sum, strings.TrimSpace(sum))
+}
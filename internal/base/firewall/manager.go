@@ -0,0 +1,337 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/file"
+	"github.com/honeybbq/goubus/v2/internal/base/rc"
+	"github.com/honeybbq/goubus/v2/internal/base/uci"
+)
+
+const (
+	initScript = "firewall"
+	fw4Binary  = "/sbin/fw4"
+	nftBinary  = "/usr/sbin/nft"
+	shBinary   = "/bin/sh"
+	fw4Table   = "fw4"
+
+	// zoneInputPrefix prefixes the chain fw4 creates for the input of each zone.
+	zoneInputPrefix = "input_"
+
+	maxPort = 65535
+	// maxCommentLength is the longest comment nftables stores.
+	maxCommentLength = 128
+
+	// TemporaryCommentPrefix starts the generated comments of temporary rules.
+	TemporaryCommentPrefix = "goubus-temporary-"
+)
+
+var (
+	handleRe  = regexp.MustCompile(`\s*# handle (\d+)$`)
+	commentRe = regexp.MustCompile(`comment "((?:[^"\\]|\\.)*)"`)
+	hookRe    = regexp.MustCompile(`^type \w+ hook (\w+)`)
+	// zoneRe matches the zone names fw4 accepts, which become part of chain names.
+	zoneRe = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`)
+	// commentTextRe matches comments that survive nft quoting unchanged.
+	commentTextRe = regexp.MustCompile(`^[^"\\\x00-\x1f\x7f]*$`)
+)
+
+// Manager controls the running fw4 firewall: it reloads the firewall, inspects the active nftables
+// ruleset, adds rules that do not persist and manages port forwards. Other configuration is
+// managed through UCI. It runs fw4 and nft through rpcd's file.exec, so the session needs exec permission
+// for them, and for /bin/sh when temporary rules expire.
+type Manager struct {
+	caller goubus.Transport
+	uci    *uci.Manager
+	file   *file.Manager
+	rc     *rc.Manager
+}
+
+// New creates a new base firewall Manager.
+func New(t goubus.Transport) *Manager {
+	return &Manager{
+		caller: t,
+		uci:    uci.New(t, nil),
+		file:   file.New(t),
+		rc:     rc.New(t),
+	}
+}
+
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"uci", "file", "rc"}
+}
+
+// Reload reloads the firewall configuration, removing temporary rules.
+func (m *Manager) Reload(ctx context.Context) error {
+	return m.rc.Init(ctx, initScript, "reload")
+}
+
+// Restart restarts the firewall, removing temporary rules.
+func (m *Manager) Restart(ctx context.Context) error {
+	return m.rc.Init(ctx, initScript, "restart")
+}
+
+// Print returns the ruleset fw4 renders from the current configuration, like "fw4 print".
+func (m *Manager) Print(ctx context.Context) (string, error) {
+	return m.exec(ctx, fw4Binary, "print")
+}
+
+// Ruleset returns the chains of the active fw4 table with their rules.
+func (m *Manager) Ruleset(ctx context.Context) ([]Chain, error) {
+	output, err := m.exec(ctx, nftBinary, "-a", "list", "table", "inet", fw4Table)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseRuleset(output), nil
+}
+
+// Zones returns the names of the zones in the active ruleset.
+func (m *Manager) Zones(ctx context.Context) ([]string, error) {
+	chains, err := m.Ruleset(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var zones []string
+
+	for _, chain := range chains {
+		zone, ok := strings.CutPrefix(chain.Name, zoneInputPrefix)
+		if ok {
+			zones = append(zones, zone)
+		}
+	}
+
+	return zones, nil
+}
+
+// AddTemporaryRule opens a port by inserting a rule at the top of the input chain of the zone.
+// With a TTL, a background job on the device deletes the rule when it elapses, even if the client
+// is gone by then.
+func (m *Manager) AddTemporaryRule(ctx context.Context, rule TemporaryRule) (*InstalledRule, error) {
+	expr, err := rule.expr()
+	if err != nil {
+		return nil, err
+	}
+
+	if rule.Comment == "" {
+		rule.Comment = TemporaryCommentPrefix + strconv.FormatInt(time.Now().UnixNano(), 10)
+	}
+
+	chain := zoneInputPrefix + rule.Zone
+	args := slices.Concat([]string{"--echo", "--handle", "insert", "rule", "inet", fw4Table, chain},
+		expr, []string{"comment", strconv.Quote(rule.Comment)})
+
+	output, err := m.exec(ctx, nftBinary, args...)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to insert rule into %s", chain)
+	}
+
+	match := handleRe.FindStringSubmatch(strings.TrimSpace(output))
+	if match == nil {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidResponse, "nft did not report the rule handle: %q", output)
+	}
+
+	installed := &InstalledRule{Chain: chain, Comment: rule.Comment}
+	installed.Handle, _ = strconv.Atoi(match[1])
+
+	if rule.TTL > 0 {
+		err = m.expire(ctx, installed, rule.TTL)
+		if err != nil {
+			return installed, err
+		}
+	}
+
+	return installed, nil
+}
+
+// DeleteRule deletes a rule from the running firewall.
+func (m *Manager) DeleteRule(ctx context.Context, rule InstalledRule) error {
+	_, err := m.exec(ctx, nftBinary, "delete", "rule", "inet", fw4Table, rule.Chain, "handle",
+		strconv.Itoa(rule.Handle))
+
+	return err
+}
+
+// TemporaryRules lists the rules with a generated temporary comment in the running firewall.
+func (m *Manager) TemporaryRules(ctx context.Context) ([]InstalledRule, error) {
+	chains, err := m.Ruleset(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []InstalledRule
+
+	for _, chain := range chains {
+		for _, rule := range chain.Rules {
+			if strings.HasPrefix(rule.Comment, TemporaryCommentPrefix) {
+				rules = append(rules, InstalledRule{Chain: chain.Name, Comment: rule.Comment, Handle: rule.Handle})
+			}
+		}
+	}
+
+	return rules, nil
+}
+
+// expire starts a job on the device that deletes the rule after ttl. The job checks that the
+// handle still carries the rule's comment, since a firewall reload reuses handles. Every word of
+// the script is quoted.
+func (m *Manager) expire(ctx context.Context, rule *InstalledRule, ttl time.Duration) error {
+	handle := strconv.Itoa(rule.Handle)
+	list := shellWords(nftBinary, "-a", "list", "chain", "inet", fw4Table, rule.Chain)
+	match := shellQuote(fmt.Sprintf("comment %q # handle %d", rule.Comment, rule.Handle))
+	del := shellWords(nftBinary, "delete", "rule", "inet", fw4Table, rule.Chain, "handle", handle)
+	script := fmt.Sprintf("(sleep %d; %s | grep -qF %s && %s) >/dev/null 2>&1 &",
+		int(ttl.Round(time.Second).Seconds()), list, match, del)
+
+	_, err := m.exec(ctx, shBinary, "-c", script)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to schedule the expiry of %s handle %d", rule.Chain, rule.Handle)
+	}
+
+	return nil
+}
+
+// exec runs a command on the device and returns its output, failing on a non-zero exit code.
+func (m *Manager) exec(ctx context.Context, command string, args ...string) (string, error) {
+	res, err := m.file.Exec(ctx, command, args, nil)
+	if err != nil {
+		return "", errdefs.Wrapf(err, "failed to run %s", command)
+	}
+
+	if res.Code != 0 {
+		return "", errdefs.Wrapf(errdefs.ErrUnknown, "%s exited with code %d: %s",
+			command, res.Code, strings.TrimSpace(res.Stderr))
+	}
+
+	return res.Stdout, nil
+}
+
+// expr validates a temporary rule and returns its match and verdict.
+func (r TemporaryRule) expr() ([]string, error) {
+	if !zoneRe.MatchString(r.Zone) || !validPort(r.Port) || (r.Proto != "tcp" && r.Proto != "udp") ||
+		len(r.Comment) > maxCommentLength || !commentTextRe.MatchString(r.Comment) {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid temporary rule %+v", r)
+	}
+
+	var expr []string
+
+	if r.Source != "" {
+		family, err := sourceFamily(r.Source)
+		if err != nil {
+			return nil, err
+		}
+
+		expr = append(expr, family, "saddr", r.Source)
+	}
+
+	return append(expr, r.Proto, "dport", strconv.Itoa(r.Port), "counter", "accept"), nil
+}
+
+// ParseRuleset parses the output of "nft -a list table" into its chains. Sets, maps and other
+// objects of the table are skipped.
+func ParseRuleset(output string) []Chain {
+	var (
+		chains  []Chain
+		current *Chain
+		depth   int
+	)
+
+	for line := range strings.Lines(output) {
+		line = strings.TrimSpace(line)
+		// Block headers carry their handle after the brace with -a.
+		header := handleRe.ReplaceAllString(line, "")
+
+		switch {
+		case strings.HasSuffix(header, "{"):
+			depth++
+
+			name, ok := strings.CutPrefix(strings.TrimSuffix(header, "{"), "chain ")
+			if ok && depth == 2 {
+				chains = append(chains, Chain{Name: strings.TrimSpace(name)})
+				current = &chains[len(chains)-1]
+			}
+		case line == "}":
+			depth--
+			if depth < 2 {
+				current = nil
+			}
+		case current != nil && depth == 2 && line != "":
+			current.add(line)
+		}
+	}
+
+	return chains
+}
+
+// add adds a line of the chain body, which is either its base chain header or a rule.
+func (c *Chain) add(line string) {
+	match := hookRe.FindStringSubmatch(line)
+	if match != nil {
+		c.Hook = match[1]
+
+		return
+	}
+
+	rule := Rule{Expr: line}
+
+	handle := handleRe.FindStringSubmatchIndex(line)
+	if handle != nil {
+		rule.Handle, _ = strconv.Atoi(line[handle[2]:handle[3]])
+		rule.Expr = line[:handle[0]]
+	}
+
+	comment := commentRe.FindStringSubmatch(rule.Expr)
+	if comment != nil {
+		rule.Comment = strings.ReplaceAll(comment[1], `\"`, `"`)
+	}
+
+	c.Rules = append(c.Rules, rule)
+}
+
+// sourceFamily returns the nft address family of a source address or prefix.
+func sourceFamily(source string) (string, error) {
+	addr, err := netip.ParseAddr(source)
+	if err != nil {
+		prefix, prefixErr := netip.ParsePrefix(source)
+		if prefixErr != nil {
+			return "", errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid source %q", source)
+		}
+
+		addr = prefix.Addr()
+	}
+
+	if addr.Is6() {
+		return "ip6", nil
+	}
+
+	return "ip", nil
+}
+
+// shellQuote quotes a word for /bin/sh.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// shellWords quotes the words of a command for /bin/sh.
+func shellWords(words ...string) string {
+	quoted := make([]string, len(words))
+	for i, word := range words {
+		quoted[i] = shellQuote(word)
+	}
+
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,309 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package firewall_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/firewall"
+	"github.com/honeybbq/goubus/v2/internal/base/rc"
+	"github.com/honeybbq/goubus/v2/internal/base/uci"
+	"github.com/honeybbq/goubus/v2/internal/testutil"
+)
+
+const ruleset = `table inet fw4 { # handle 1
+	set wan_blocked { # handle 3
+		type ipv4_addr
+		elements = { 10.0.0.1 }
+	}
+
+	chain input { # handle 5
+		type filter hook input priority filter; policy drop;
+		iifname "lo" accept comment "!fw4: Accept traffic from loopback" # handle 12
+		iifname "br-lan" jump input_lan comment "!fw4: Handle lan IPv4/IPv6 input traffic" # handle 14
+	}
+
+	chain input_lan { # handle 20
+		jump accept_from_lan # handle 21
+	}
+
+	chain input_wan { # handle 22
+		tcp dport 8443 counter packets 0 bytes 0 accept comment "goubus-temporary-1" # handle 40
+		meta nfproto ipv4 udp dport 68 counter packets 3 bytes 1032 accept comment "!fw4: Allow-DHCP-Renew" # handle 23
+	}
+}
+`
+
+func execArgs(t *testing.T, mock *testutil.MockTransport) (string, []string) {
+	t.Helper()
+
+	req, _ := mock.GetLastCall().Data.(map[string]any)
+	command, _ := req["command"].(string)
+	params, _ := req["params"].([]string)
+
+	return command, params
+}
+
+func TestFirewallManager(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Reload", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("rc", "init", map[string]any{})
+
+		err := firewall.New(mock).Reload(ctx)
+		if err != nil {
+			t.Fatalf("Reload failed: %v", err)
+		}
+
+		req, _ := mock.GetLastCall().Data.(rc.InitRequest)
+		if req.Name != "firewall" || req.Action != "reload" {
+			t.Errorf("unexpected init request: %+v", req)
+		}
+	})
+
+	t.Run("Ruleset", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("file", "exec", map[string]any{"code": 0, "stdout": ruleset})
+
+		chains, err := firewall.New(mock).Ruleset(ctx)
+		if err != nil {
+			t.Fatalf("Ruleset failed: %v", err)
+		}
+
+		if len(chains) != 3 || chains[0].Name != "input" || chains[0].Hook != "input" || chains[1].Hook != "" {
+			t.Fatalf("unexpected chains: %+v", chains)
+		}
+
+		rule := chains[0].Rules[1]
+		if rule.Handle != 14 || rule.Comment != "!fw4: Handle lan IPv4/IPv6 input traffic" ||
+			!strings.HasSuffix(rule.Expr, `IPv4/IPv6 input traffic"`) {
+			t.Errorf("unexpected rule: %+v", rule)
+		}
+	})
+
+	t.Run("Zones", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("file", "exec", map[string]any{"code": 0, "stdout": ruleset})
+
+		zones, err := firewall.New(mock).Zones(ctx)
+		if err != nil {
+			t.Fatalf("Zones failed: %v", err)
+		}
+
+		if !slices.Equal(zones, []string{"lan", "wan"}) {
+			t.Errorf("unexpected zones: %v", zones)
+		}
+	})
+
+	t.Run("TemporaryRules", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("file", "exec", map[string]any{"code": 0, "stdout": ruleset})
+
+		rules, err := firewall.New(mock).TemporaryRules(ctx)
+		if err != nil {
+			t.Fatalf("TemporaryRules failed: %v", err)
+		}
+
+		want := firewall.InstalledRule{Chain: "input_wan", Comment: "goubus-temporary-1", Handle: 40}
+		if len(rules) != 1 || rules[0] != want {
+			t.Errorf("unexpected rules: %+v", rules)
+		}
+	})
+
+	t.Run("AddTemporaryRule", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("file", "exec", map[string]any{
+			"code": 0,
+			"stdout": "insert rule inet fw4 input_wan ip saddr 203.0.113.7 tcp dport 22 counter accept " +
+				"comment \"ssh\" # handle 41\n",
+		})
+
+		rule, err := firewall.New(mock).AddTemporaryRule(ctx, firewall.TemporaryRule{
+			Zone: "wan", Proto: "tcp", Port: 22, Source: "203.0.113.7", Comment: "ssh", TTL: 10 * time.Minute,
+		})
+		if err != nil {
+			t.Fatalf("AddTemporaryRule failed: %v", err)
+		}
+
+		if *rule != (firewall.InstalledRule{Chain: "input_wan", Comment: "ssh", Handle: 41}) {
+			t.Errorf("unexpected rule: %+v", rule)
+		}
+
+		if len(mock.Calls) != 2 {
+			t.Fatalf("expected insert and expiry calls, got %d", len(mock.Calls))
+		}
+
+		insert, _ := mock.Calls[0].Data.(map[string]any)
+		if params, _ := insert["params"].([]string); !slices.Contains(params, "saddr") {
+			t.Errorf("unexpected insert request: %+v", insert)
+		}
+
+		command, params := execArgs(t, mock)
+		if command != "/bin/sh" || !strings.Contains(params[1], "sleep 600;") ||
+			!strings.Contains(params[1], "'delete' 'rule' 'inet' 'fw4' 'input_wan' 'handle' '41'") {
+			t.Errorf("unexpected expiry job: %s %v", command, params)
+		}
+	})
+
+	t.Run("AddTemporaryRule_Invalid", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		manager := firewall.New(mock)
+
+		for _, rule := range []firewall.TemporaryRule{
+			{Zone: "wan", Proto: "icmp", Port: 1},
+			{Zone: "wan; reboot", Proto: "tcp", Port: 22},
+			{Zone: "wan", Proto: "tcp", Port: 22, Source: "$(reboot)"},
+			{Zone: "wan", Proto: "tcp", Port: 22, Comment: `ssh" accept`},
+		} {
+			if _, err := manager.AddTemporaryRule(ctx, rule); !errdefs.IsInvalidParameter(err) {
+				t.Errorf("expected invalid parameter for %+v, got %v", rule, err)
+			}
+		}
+
+		if len(mock.Calls) != 0 {
+			t.Errorf("expected no calls, got %d", len(mock.Calls))
+		}
+	})
+
+	t.Run("DeleteRule_Failure", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("file", "exec", map[string]any{"code": 1, "stderr": "Error: Could not process rule\n"})
+
+		err := firewall.New(mock).DeleteRule(ctx, firewall.InstalledRule{Chain: "input_wan", Handle: 41})
+		if err == nil || !strings.Contains(err.Error(), "Could not process rule") {
+			t.Errorf("expected nft failure, got %v", err)
+		}
+
+		_, params := execArgs(t, mock)
+		if !slices.Equal(params, []string{"delete", "rule", "inet", "fw4", "input_wan", "handle", "41"}) {
+			t.Errorf("unexpected params: %v", params)
+		}
+	})
+}
+
+func TestPortForwards(t *testing.T) {
+	ctx := context.Background()
+	redirects := map[string]any{
+		"values": map[string]any{
+			"cfg0a1b2c": map[string]any{
+				".type": "redirect", ".name": "cfg0a1b2c", ".anonymous": true, ".index": 0,
+				"name": "web", "target": "DNAT", "src": "wan", "src_dport": "8080",
+				"dest": "lan", "dest_ip": "192.168.1.10", "dest_port": "80", "proto": []string{"tcp"},
+			},
+			"cfg0b1b2c": map[string]any{
+				".type": "redirect", ".name": "cfg0b1b2c", ".anonymous": true, ".index": 1,
+				"name": "game", "src": "wan", "src_dport": "3074", "dest_ip": "192.168.1.20",
+				"proto": "udp", "enabled": "0",
+			},
+			"cfg0c1b2c": map[string]any{
+				".type": "redirect", ".name": "cfg0c1b2c", ".anonymous": true, ".index": 2,
+				"name": "masq", "target": "SNAT", "src": "lan", "dest": "wan",
+			},
+		},
+	}
+
+	t.Run("List", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("uci", "get", redirects)
+		mock.AddResponse("file", "exec", map[string]any{"code": 0, "stdout": `table inet fw4 {
+	chain dstnat_wan { # handle 30
+		meta nfproto ipv4 tcp dport 8080 counter dnat ip to 192.168.1.10:80 comment "!fw4: web" # handle 31
+	}
+}
+`})
+
+		forwards, err := firewall.New(mock).PortForwards().List(ctx)
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+
+		if len(forwards) != 2 {
+			t.Fatalf("expected 2 forwards, got %+v", forwards)
+		}
+
+		web, game := forwards[0], forwards[1]
+		if web.Name != "web" || !web.Enabled || !web.Active || web.ExtPort != "8080" || web.Section != "cfg0a1b2c" {
+			t.Errorf("unexpected web forward: %+v", web)
+		}
+
+		if game.Name != "game" || game.Enabled || game.Active || game.Proto != "udp" {
+			t.Errorf("unexpected game forward: %+v", game)
+		}
+	})
+
+	t.Run("List_NoRuntimeAccess", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("uci", "get", redirects)
+		mock.AddResponse("file", "exec", errdefs.ErrPermissionDenied)
+
+		forwards, err := firewall.New(mock).PortForwards().List(ctx)
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+
+		if len(forwards) != 2 || forwards[0].Active {
+			t.Errorf("unexpected forwards: %+v", forwards)
+		}
+	})
+
+	t.Run("Add", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("uci", "get", map[string]any{"values": map[string]any{}})
+		mock.AddResponse("uci", "add", map[string]any{"section": "cfg0d1b2c"})
+		mock.AddResponse("uci", "commit", map[string]any{})
+		mock.AddResponse("rc", "init", map[string]any{})
+
+		forward, err := firewall.New(mock).PortForwards().Add(ctx, "ssh", "tcp", 2222, "192.168.1.2", 22)
+		if err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+
+		if forward.ExtPort != "2222" || forward.DestPort != "22" || forward.SrcZone != "wan" {
+			t.Errorf("unexpected forward: %+v", forward)
+		}
+
+		methods := make([]string, len(mock.Calls))
+		for i, call := range mock.Calls {
+			methods[i] = call.Service + "." + call.Method
+		}
+
+		if !slices.Equal(methods, []string{"uci.get", "uci.add", "uci.commit", "rc.init"}) {
+			t.Errorf("unexpected calls: %v", methods)
+		}
+	})
+
+	t.Run("Add_Duplicate", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("uci", "get", redirects)
+
+		_, err := firewall.New(mock).PortForwards().Add(ctx, "web", "tcp", 8080, "192.168.1.10", 80)
+		if !errdefs.IsInvalidParameter(err) {
+			t.Errorf("expected invalid parameter, got %v", err)
+		}
+	})
+
+	t.Run("Disable", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("uci", "get", redirects)
+		mock.AddResponse("uci", "set", map[string]any{})
+		mock.AddResponse("uci", "commit", map[string]any{})
+		mock.AddResponse("rc", "init", map[string]any{})
+
+		err := firewall.New(mock).PortForwards().Disable(ctx, "web")
+		if err != nil {
+			t.Fatalf("Disable failed: %v", err)
+		}
+
+		set, ok := mock.Calls[1].Data.(uci.Request)
+		if !ok || set.Section != "cfg0a1b2c" || set.Values["enabled"] != "0" {
+			t.Errorf("unexpected set request: %+v", mock.Calls[1].Data)
+		}
+	})
+}
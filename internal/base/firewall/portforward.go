@@ -0,0 +1,274 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package firewall
+
+import (
+	"context"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/uci"
+)
+
+const (
+	uciPackage      = "firewall"
+	uciRedirectType = "redirect"
+
+	defaultSrcZone  = "wan"
+	defaultDestZone = "lan"
+
+	// dstnatChainPrefix prefixes the chain fw4 renders the redirects of each source zone into.
+	dstnatChainPrefix = "dstnat_"
+	// fw4CommentPrefix starts the comments fw4 gives the rules it renders from sections.
+	fw4CommentPrefix = "!fw4: "
+)
+
+// PortForwardContext manages port forwards, the DNAT "redirect" sections of the firewall package,
+// by their name option. Changes are committed and the firewall is reloaded right away, which also
+// drops temporary rules.
+type PortForwardContext struct {
+	manager *Manager
+}
+
+// PortForwards returns a context for port forward operations.
+func (m *Manager) PortForwards() *PortForwardContext {
+	return &PortForwardContext{manager: m}
+}
+
+// List returns the port forwards in configuration order. Active reports whether fw4 has rendered
+// a forward into the running ruleset; it stays false for all of them when the session may not
+// read the ruleset.
+func (pf *PortForwardContext) List(ctx context.Context) ([]PortForward, error) {
+	sections, err := pf.manager.uci.Package(uciPackage).Query(uciRedirectType).All(ctx)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read port forwards")
+	}
+
+	active, err := pf.active(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	forwards := make([]PortForward, 0, len(sections))
+
+	for _, section := range sections {
+		if section.GetString("target") != "" && section.GetString("target") != "DNAT" {
+			continue
+		}
+
+		forward := PortForwardFromSection(section)
+		forward.Active = active[dstnatChainPrefix+forward.SrcZone][forward.Name]
+		forwards = append(forwards, forward)
+	}
+
+	return forwards, nil
+}
+
+// Add forwards extPort of the wan zone to destPort of destIP in the lan zone. proto is "tcp",
+// "udp" or "tcp udp"; name identifies the forward and must be unique.
+func (pf *PortForwardContext) Add(
+	ctx context.Context, name, proto string, extPort int, destIP string, destPort int,
+) (*PortForward, error) {
+	forward := PortForward{
+		Name:     name,
+		Proto:    proto,
+		SrcZone:  defaultSrcZone,
+		ExtPort:  strconv.Itoa(extPort),
+		DestZone: defaultDestZone,
+		DestIP:   destIP,
+		DestPort: strconv.Itoa(destPort),
+		Enabled:  true,
+	}
+
+	err := forward.validate(extPort, destPort)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := pf.manager.uci.Package(uciPackage)
+
+	_, err = pkg.Query(uciRedirectType).Where("name", name).First(ctx)
+	if err == nil {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "port forward %q already exists", name)
+	}
+
+	if !errdefs.IsNotFound(err) {
+		return nil, errdefs.Wrapf(err, "failed to read port forwards")
+	}
+
+	err = pkg.Add(ctx, uciRedirectType, "", forward.SectionValues())
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to add port forward %s", name)
+	}
+
+	err = pf.apply(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &forward, nil
+}
+
+// Remove deletes the port forward called name.
+func (pf *PortForwardContext) Remove(ctx context.Context, name string) error {
+	section, err := pf.section(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	err = section.Delete(ctx)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to delete port forward %s", name)
+	}
+
+	return pf.apply(ctx)
+}
+
+// Enable enables the port forward called name.
+func (pf *PortForwardContext) Enable(ctx context.Context, name string) error {
+	return pf.setEnabled(ctx, name, true)
+}
+
+// Disable disables the port forward called name without deleting it.
+func (pf *PortForwardContext) Disable(ctx context.Context, name string) error {
+	return pf.setEnabled(ctx, name, false)
+}
+
+func (pf *PortForwardContext) setEnabled(ctx context.Context, name string, enabled bool) error {
+	section, err := pf.section(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	values := uci.NewSectionValues()
+	values.SetBool("enabled", enabled)
+
+	err = section.SetValues(ctx, values)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to set port forward %s enabled", name)
+	}
+
+	return pf.apply(ctx)
+}
+
+// section returns the redirect section of the port forward called name.
+func (pf *PortForwardContext) section(ctx context.Context, name string) (*uci.SectionContext, error) {
+	pkg := pf.manager.uci.Package(uciPackage)
+
+	section, err := pkg.Query(uciRedirectType).Where("name", name).First(ctx)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "port forward %s", name)
+	}
+
+	return pkg.Section(section.Name), nil
+}
+
+// apply commits the firewall package and reloads the firewall.
+func (pf *PortForwardContext) apply(ctx context.Context) error {
+	err := pf.manager.uci.Package(uciPackage).Commit(ctx)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to commit firewall")
+	}
+
+	return pf.manager.Reload(ctx)
+}
+
+// active returns the names of the forwards in the running ruleset, keyed by their dstnat chain.
+func (pf *PortForwardContext) active(ctx context.Context) (map[string]map[string]bool, error) {
+	chains, err := pf.manager.Ruleset(ctx)
+	if errdefs.IsPermissionDenied(err) || errdefs.IsNotFound(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read the running firewall")
+	}
+
+	active := make(map[string]map[string]bool)
+
+	for _, chain := range chains {
+		if !strings.HasPrefix(chain.Name, dstnatChainPrefix) {
+			continue
+		}
+
+		active[chain.Name] = make(map[string]bool)
+
+		for _, rule := range chain.Rules {
+			name, ok := strings.CutPrefix(rule.Comment, fw4CommentPrefix)
+			if ok {
+				active[chain.Name][name] = true
+			}
+		}
+	}
+
+	return active, nil
+}
+
+// validate checks a port forward before it is added.
+func (f *PortForward) validate(extPort, destPort int) error {
+	if f.Name == "" {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "port forward name is required")
+	}
+
+	protos := strings.Fields(f.Proto)
+	if len(protos) == 0 {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "protocol is required")
+	}
+
+	for _, proto := range protos {
+		if proto != "tcp" && proto != "udp" {
+			return errdefs.Wrapf(errdefs.ErrInvalidParameter, "unsupported protocol %q", proto)
+		}
+	}
+
+	if !validPort(extPort) || !validPort(destPort) {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid ports %d -> %d", extPort, destPort)
+	}
+
+	_, err := netip.ParseAddr(f.DestIP)
+	if err != nil {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid destination %q", f.DestIP)
+	}
+
+	return nil
+}
+
+func validPort(port int) bool {
+	return port > 0 && port <= maxPort
+}
+
+// PortForwardFromSection converts a "redirect" section into a PortForward.
+func PortForwardFromSection(section *uci.Section) PortForward {
+	_, set := section.GetFirst("enabled")
+
+	return PortForward{
+		Section:  section.Name,
+		Name:     section.GetString("name"),
+		Proto:    strings.Join(section.Get("proto"), " "),
+		SrcZone:  section.GetString("src"),
+		ExtPort:  section.GetString("src_dport"),
+		DestZone: section.GetString("dest"),
+		DestIP:   section.GetString("dest_ip"),
+		DestPort: section.GetString("dest_port"),
+		Enabled:  !set || section.GetBool("enabled"),
+	}
+}
+
+// SectionValues converts the PortForward into the options of a "redirect" section.
+func (f *PortForward) SectionValues() uci.SectionValues {
+	values := uci.NewSectionValues()
+	values.Set("name", f.Name)
+	values.Set("target", "DNAT")
+	values.SetList("proto", strings.Fields(f.Proto)...)
+	values.SetScalar("src", f.SrcZone)
+	values.SetScalar("src_dport", f.ExtPort)
+	values.SetScalar("dest", f.DestZone)
+	values.SetScalar("dest_ip", f.DestIP)
+	values.SetScalar("dest_port", f.DestPort)
+	values.SetBool("enabled", f.Enabled)
+
+	return values
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package firewall
+
+import "time"
+
+// Chain is a chain of the active fw4 nftables table.
+type Chain struct {
+	Name string `json:"name"`
+	// Hook is the netfilter hook of a base chain, such as "input"; it is empty for regular chains.
+	Hook  string `json:"hook,omitempty"`
+	Rules []Rule `json:"rules"`
+}
+
+// Rule is a rule of a chain in nftables syntax, e.g. `tcp dport 22 counter accept`.
+type Rule struct {
+	Expr string `json:"expr"`
+	// Comment is the text of the rule's comment expression, if any.
+	Comment string `json:"comment,omitempty"`
+	Handle  int    `json:"handle"`
+}
+
+// TemporaryRule describes a port opening that is inserted into the running firewall but not into
+// its configuration, so it disappears with the next firewall reload or restart.
+type TemporaryRule struct {
+	// Zone is the firewall zone whose input the port is opened on, e.g. "wan".
+	Zone string `json:"zone"`
+	// Proto is "tcp" or "udp".
+	Proto string `json:"proto"`
+	// Source restricts the rule to an IPv4 or IPv6 address or prefix; empty allows any source.
+	Source string `json:"source,omitempty"`
+	// Comment labels the rule; a unique comment starting with TemporaryCommentPrefix is generated
+	// when it is empty.
+	Comment string `json:"comment,omitempty"`
+	Port    int    `json:"port"`
+	// TTL removes the rule on the device once it elapses; zero keeps it until it is deleted or
+	// the firewall is reloaded.
+	TTL time.Duration `json:"ttl,omitempty"`
+}
+
+// InstalledRule is a temporary rule inserted into the running firewall.
+type InstalledRule struct {
+	Chain   string `json:"chain"`
+	Comment string `json:"comment"`
+	Handle  int    `json:"handle"`
+}
+
+// PortForward is a port forward, a DNAT "redirect" section of the firewall package.
+type PortForward struct {
+	// Section is the name of the UCI section, which is usually anonymous.
+	Section string `json:"section"`
+	Name    string `json:"name"`
+	// Proto lists the protocols separated by spaces, e.g. "tcp udp".
+	Proto   string `json:"proto"`
+	SrcZone string `json:"src"`
+	// ExtPort is the external port or port range, e.g. "8080" or "8000-8100".
+	ExtPort  string `json:"src_dport"`
+	DestZone string `json:"dest"`
+	DestIP   string `json:"dest_ip"`
+	// DestPort is the internal port or port range; empty keeps the external port.
+	DestPort string `json:"dest_port"`
+	Enabled  bool   `json:"enabled"`
+	// Active reports whether the forward is in the running ruleset.
+	Active bool `json:"active"`
+}
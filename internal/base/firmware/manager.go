@@ -0,0 +1,300 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package firmware
+
+import (
+	"context"
+	"crypto/md5" //nolint:gosec // rpcd only offers MD5 to verify uploads.
+	"encoding/base64"
+	"encoding/hex"
+	"slices"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/file"
+	"github.com/honeybbq/goubus/v2/internal/base/rpcsys"
+	"github.com/honeybbq/goubus/v2/internal/base/system"
+)
+
+const (
+	// DefaultImagePath is where LuCI and rpcd-mod-rpcsys expect an uploaded image.
+	DefaultImagePath = "/tmp/firmware.bin"
+	// DefaultChunkSize is the default number of image bytes sent per file.write call.
+	DefaultChunkSize = 64 * 1024
+
+	sysupgradeBinary  = "/sbin/sysupgrade"
+	configBackupPath  = "/tmp/sysupgrade.tgz"
+	testDeviceMatch   = "fwtool_device_match"
+	imageFileMode     = 0o600
+	sysupgradeTestArg = "--test"
+)
+
+// Manager drives the sysupgrade workflow: upload, verification, validation and flashing.
+// Uploading needs file write permission for the image path, and "sysupgrade --test" and
+// configuration backups need exec permission for /sbin/sysupgrade in the rpcd ACL.
+type Manager struct {
+	caller goubus.Transport
+	file   *file.Manager
+	system *system.Manager
+	rpcsys *rpcsys.Manager
+}
+
+// New creates a new base firmware Manager.
+func New(t goubus.Transport) *Manager {
+	return &Manager{
+		caller: t,
+		file:   file.New(t),
+		system: system.New(t),
+		rpcsys: rpcsys.New(t),
+	}
+}
+
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"system", "rpc-sys", "file"}
+}
+
+// Upgrade uploads the image, checks it against the board and triggers the upgrade.
+// With DryRun set, the image is removed after the checks and nothing is flashed.
+// The device reboots shortly after the upgrade has started.
+func (m *Manager) Upgrade(ctx context.Context, image []byte, opts *Options) (*Report, error) {
+	opts = withDefaults(opts)
+
+	err := m.Upload(ctx, image, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	rep, err := m.Check(ctx, opts.Path, opts.Progress)
+	if err != nil {
+		_ = m.file.Remove(ctx, opts.Path)
+
+		return rep, err
+	}
+
+	rep.MD5 = md5Hex(image)
+
+	if !rep.Validation.Valid && (!opts.Force || !rep.Validation.Forceable) {
+		_ = m.file.Remove(ctx, opts.Path)
+
+		return rep, errdefs.Wrapf(errdefs.ErrInvalidParameter, "image %s failed validation: %s",
+			opts.Path, failedTests(rep.Validation))
+	}
+
+	if opts.DryRun {
+		return rep, m.file.Remove(ctx, opts.Path)
+	}
+
+	err = m.Start(ctx, opts)
+	if err != nil {
+		return rep, err
+	}
+
+	rep.Started = true
+
+	return rep, nil
+}
+
+// Upload writes the image to opts.Path in chunks and verifies its MD5 checksum on the device.
+func (m *Manager) Upload(ctx context.Context, image []byte, opts *Options) error {
+	if len(image) == 0 {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "image is empty")
+	}
+
+	opts = withDefaults(opts)
+	notify(opts.Progress, Progress{Stage: StageUpload, Total: len(image)})
+
+	for written := 0; written < len(image); {
+		end := min(written+opts.ChunkSize, len(image))
+		chunk := base64.StdEncoding.EncodeToString(image[written:end])
+
+		err := m.file.Write(ctx, opts.Path, chunk, written > 0, imageFileMode, true)
+		if err != nil {
+			return errdefs.Wrapf(err, "failed to upload %s at offset %d", opts.Path, written)
+		}
+
+		written = end
+		notify(opts.Progress, Progress{Stage: StageUpload, Written: written, Total: len(image)})
+	}
+
+	notify(opts.Progress, Progress{Stage: StageVerify})
+
+	sum, err := m.file.MD5(ctx, opts.Path)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to checksum %s", opts.Path)
+	}
+
+	want := md5Hex(image)
+	if !strings.EqualFold(sum, want) {
+		_ = m.file.Remove(ctx, opts.Path)
+
+		return errdefs.Wrapf(errdefs.ErrInvalidResponse, "checksum mismatch for %s: got %s, want %s",
+			opts.Path, sum, want)
+	}
+
+	return nil
+}
+
+// Check validates an image already stored on the device against the running board.
+// It does not fail when the image is invalid; inspect Report.Validation instead.
+func (m *Manager) Check(ctx context.Context, path string, progress func(Progress)) (*Report, error) {
+	board, err := m.system.Board(ctx)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read board info")
+	}
+
+	notify(progress, Progress{Stage: StageValidate})
+
+	validation, err := m.Validate(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	rep := &Report{
+		Board:      *board,
+		Validation: *validation,
+		Path:       path,
+		Compatible: validation.Compatible,
+	}
+
+	if !validation.Valid {
+		return rep, nil
+	}
+
+	notify(progress, Progress{Stage: StageTest})
+
+	rep.TestOutput, err = m.Test(ctx, path)
+	if err != nil {
+		return rep, err
+	}
+
+	return rep, nil
+}
+
+// Validate runs the image checks of system validate_firmware_image.
+func (m *Manager) Validate(ctx context.Context, path string) (*Validation, error) {
+	req := system.ValidateFirmwareImageRequest{Path: path}
+
+	res, err := goubus.Call[Validation](ctx, m.caller, "system", "validate_firmware_image", req)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to validate %s", path)
+	}
+
+	res.Compatible = res.Valid
+	if match, ok := res.Tests[testDeviceMatch]; ok {
+		res.Compatible = match
+	}
+
+	return res, nil
+}
+
+// Test runs "sysupgrade --test" on the image and returns its output.
+// When the session may not execute sysupgrade, it falls back to rpc-sys upgrade_test,
+// which only supports images stored at DefaultImagePath.
+func (m *Manager) Test(ctx context.Context, path string) (string, error) {
+	res, err := m.file.Exec(ctx, sysupgradeBinary, []string{sysupgradeTestArg, path}, nil)
+	if err != nil {
+		if path == DefaultImagePath && errdefs.IsPermissionDenied(err) {
+			return "", m.rpcsys.UpgradeTest(ctx)
+		}
+
+		return "", errdefs.Wrapf(err, "failed to test %s", path)
+	}
+
+	output := strings.TrimSpace(res.Stdout + res.Stderr)
+	if res.Code != 0 {
+		return output, errdefs.Wrapf(errdefs.ErrInvalidParameter, "sysupgrade rejected %s: %s", path, output)
+	}
+
+	return output, nil
+}
+
+// Start triggers system sysupgrade for the image at opts.Path.
+// With KeepSettings, the configuration is first archived with "sysupgrade --create-backup".
+func (m *Manager) Start(ctx context.Context, opts *Options) error {
+	opts = withDefaults(opts)
+	req := system.SysupgradeRequest{Path: opts.Path, Force: goubus.Bool(opts.Force)}
+
+	if opts.KeepSettings {
+		notify(opts.Progress, Progress{Stage: StageBackup})
+
+		err := m.backup(ctx)
+		if err != nil {
+			return err
+		}
+
+		req.Backup = configBackupPath
+	}
+
+	notify(opts.Progress, Progress{Stage: StageUpgrade})
+
+	err := m.system.Sysupgrade(ctx, req)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to start sysupgrade")
+	}
+
+	return nil
+}
+
+func (m *Manager) backup(ctx context.Context) error {
+	res, err := m.file.Exec(ctx, sysupgradeBinary, []string{"--create-backup", configBackupPath}, nil)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to back up configuration")
+	}
+
+	if res.Code != 0 {
+		return errdefs.Wrapf(errdefs.ErrUnknown, "sysupgrade backup exited with code %d: %s",
+			res.Code, strings.TrimSpace(res.Stderr))
+	}
+
+	return nil
+}
+
+func withDefaults(opts *Options) *Options {
+	resolved := Options{}
+	if opts != nil {
+		resolved = *opts
+	}
+
+	if resolved.Path == "" {
+		resolved.Path = DefaultImagePath
+	}
+
+	if resolved.ChunkSize <= 0 {
+		resolved.ChunkSize = DefaultChunkSize
+	}
+
+	return &resolved
+}
+
+func notify(progress func(Progress), p Progress) {
+	if progress != nil {
+		progress(p)
+	}
+}
+
+func failedTests(v Validation) string {
+	var failed []string
+
+	for name, ok := range v.Tests {
+		if !ok {
+			failed = append(failed, name)
+		}
+	}
+
+	if len(failed) == 0 {
+		return "invalid image"
+	}
+
+	slices.Sort(failed)
+
+	return strings.Join(failed, ", ")
+}
+
+func md5Hex(data []byte) string {
+	sum := md5.Sum(data) //nolint:gosec // must match the file md5 method.
+
+	return hex.EncodeToString(sum[:])
+}
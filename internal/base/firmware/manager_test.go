@@ -0,0 +1,194 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package firmware_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5" //nolint:gosec // matches the file md5 method.
+	"encoding/binary"
+	"encoding/hex"
+	"slices"
+	"testing"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/firmware"
+	"github.com/honeybbq/goubus/v2/internal/base/system"
+	"github.com/honeybbq/goubus/v2/internal/testutil"
+)
+
+var testImage = []byte("0123456789")
+
+func newUpgradeMock(t *testing.T, checksum string, valid bool) *testutil.MockTransport {
+	t.Helper()
+
+	mock := testutil.NewMockTransport()
+	mock.AddResponse("file", "write", map[string]any{})
+	mock.AddResponse("file", "md5", map[string]any{"md5": checksum})
+	mock.AddResponse("file", "remove", map[string]any{})
+	mock.AddResponse("file", "exec", map[string]any{"code": 0, "stdout": "Image check passed.\n"})
+	mock.AddResponse("system", "board", map[string]any{"board_name": "cmcc,rax3000m", "model": "CMCC RAX3000M"})
+	mock.AddResponse("system", "validate_firmware_image", map[string]any{
+		"valid":        valid,
+		"forceable":    true,
+		"allow_backup": true,
+		"tests":        map[string]any{"fwtool_signature": true, "fwtool_device_match": valid},
+	})
+	mock.AddResponse("system", "sysupgrade", map[string]any{})
+
+	return mock
+}
+
+func imageMD5() string {
+	sum := md5.Sum(testImage) //nolint:gosec // matches the file md5 method.
+
+	return hex.EncodeToString(sum[:])
+}
+
+func TestFirmwareManager(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Upgrade_KeepSettings", func(t *testing.T) {
+		mock := newUpgradeMock(t, imageMD5(), true)
+
+		var stages []firmware.Stage
+
+		opts := &firmware.Options{
+			ChunkSize:    4,
+			KeepSettings: true,
+			Progress:     func(p firmware.Progress) { stages = append(stages, p.Stage) },
+		}
+
+		report, err := firmware.New(mock).Upgrade(ctx, testImage, opts)
+		if err != nil {
+			t.Fatalf("Upgrade failed: %v", err)
+		}
+
+		if !report.Started || !report.Compatible || report.MD5 != imageMD5() || report.Board.BoardName != "cmcc,rax3000m" {
+			t.Errorf("unexpected report: %+v", report)
+		}
+
+		writes := 0
+
+		for _, call := range mock.Calls {
+			if call.Method == "write" {
+				params, ok := call.Data.(map[string]any)
+				if !ok || params["path"] != firmware.DefaultImagePath || (writes > 0) != (params["append"] == true) {
+					t.Errorf("unexpected write: %+v", call.Data)
+				}
+
+				writes++
+			}
+		}
+
+		if writes != 3 {
+			t.Errorf("expected 3 chunks, got %d", writes)
+		}
+
+		req, ok := mock.GetLastCall().Data.(system.SysupgradeRequest)
+		if !ok || req.Path != firmware.DefaultImagePath || req.Backup != "/tmp/sysupgrade.tgz" {
+			t.Errorf("unexpected sysupgrade call: %+v", mock.GetLastCall())
+		}
+
+		if stages[0] != firmware.StageUpload || stages[len(stages)-1] != firmware.StageUpgrade {
+			t.Errorf("unexpected stages: %v", stages)
+		}
+	})
+
+	t.Run("Upgrade_DryRun", func(t *testing.T) {
+		mock := newUpgradeMock(t, imageMD5(), true)
+
+		report, err := firmware.New(mock).Upgrade(ctx, testImage, &firmware.Options{DryRun: true})
+		if err != nil {
+			t.Fatalf("Upgrade failed: %v", err)
+		}
+
+		if report.Started || report.TestOutput != "Image check passed." {
+			t.Errorf("unexpected report: %+v", report)
+		}
+
+		if call := mock.GetLastCall(); call.Service != "file" || call.Method != "remove" {
+			t.Errorf("expected image removal, got %+v", call)
+		}
+	})
+
+	t.Run("Upgrade_Incompatible", func(t *testing.T) {
+		mock := newUpgradeMock(t, imageMD5(), false)
+
+		report, err := firmware.New(mock).Upgrade(ctx, testImage, nil)
+		if !errdefs.IsInvalidParameter(err) {
+			t.Fatalf("expected invalid parameter, got %v", err)
+		}
+
+		if report == nil || report.Compatible || report.Started {
+			t.Errorf("unexpected report: %+v", report)
+		}
+	})
+
+	t.Run("Upload_ChecksumMismatch", func(t *testing.T) {
+		mock := newUpgradeMock(t, "d41d8cd98f00b204e9800998ecf8427e", true)
+
+		err := firmware.New(mock).Upload(ctx, testImage, nil)
+		if !errdefs.IsInvalidResponse(err) {
+			t.Errorf("expected invalid response, got %v", err)
+		}
+	})
+	t.Run("ValidateImage", func(t *testing.T) {
+		image := withMetadata(testImage, `{"metadata_version":"1.1","compat_version":"1.0",`+
+			`"supported_devices":["cmcc,rax3000m"],"version":{"dist":"OpenWrt","version":"24.10.0"}}`)
+		sum := md5.Sum(image) //nolint:gosec // matches the file md5 method.
+		mock := newUpgradeMock(t, hex.EncodeToString(sum[:]), true)
+
+		report, err := firmware.New(mock).ValidateImage(ctx, bytes.NewReader(image), nil)
+		if err != nil {
+			t.Fatalf("ValidateImage failed: %v", err)
+		}
+
+		if !report.Compatible || !report.Validation.Compatible || report.Started ||
+			report.Metadata == nil || report.Metadata.Version.Version != "24.10.0" {
+			t.Errorf("unexpected report: %+v", report)
+		}
+
+		if call := mock.GetLastCall(); call.Service != "file" || call.Method != "remove" {
+			t.Errorf("expected image removal, got %+v", call)
+		}
+	})
+
+	t.Run("ValidateImage_OtherBoard", func(t *testing.T) {
+		mock := newUpgradeMock(t, imageMD5(), true)
+		image := withMetadata(testImage, `{"supported_devices":["glinet,gl-mt6000"]}`)
+
+		report, err := firmware.New(mock).ValidateImage(ctx, bytes.NewReader(image), nil)
+		if err != nil {
+			t.Fatalf("ValidateImage failed: %v", err)
+		}
+
+		if report.Compatible || len(mock.Calls) != 1 {
+			t.Errorf("expected the image to be refused before the upload: %+v, %d calls", report, len(mock.Calls))
+		}
+	})
+
+	t.Run("ParseMetadata", func(t *testing.T) {
+		_, err := firmware.ParseMetadata(testImage)
+		if !errdefs.IsNotFound(err) {
+			t.Errorf("expected an image without metadata to be reported, got %v", err)
+		}
+	})
+}
+
+// withMetadata appends the fwtool metadata and an empty signature trailer to image.
+func withMetadata(image []byte, metadata string) []byte {
+	trailer := func(typ byte, data []byte) []byte {
+		tr := make([]byte, 16)
+		binary.BigEndian.PutUint32(tr, 0x46577830)
+		tr[8] = typ
+		binary.BigEndian.PutUint32(tr[12:], uint32(len(data)+len(tr))) //nolint:gosec // test images are small.
+
+		return append(data, tr...)
+	}
+
+	image = append(slices.Clone(image), trailer(1, []byte(metadata))...)
+
+	return append(image, trailer(0, nil)...)
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package firmware
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"slices"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+const (
+	// fwtoolMagic is "FWx0", the magic of the trailers fwtool appends to sysupgrade images.
+	fwtoolMagic = 0x46577830
+	// fwtoolTrailerSize is the size of a trailer: magic, CRC32, type, padding and size, of
+	// which the type and the size, including the trailer, follow at these offsets.
+	fwtoolTrailerSize = 16
+	fwtoolTypeOffset  = 8
+	fwtoolSizeOffset  = 12
+
+	fwtoolTypeSignature = 0
+	fwtoolTypeInfo      = 1
+)
+
+// ImageMetadata is the metadata fwtool appends to sysupgrade images.
+type ImageMetadata struct {
+	Version          ImageVersion `json:"version"`
+	MetadataVersion  string       `json:"metadata_version"`
+	CompatVersion    string       `json:"compat_version"`
+	SupportedDevices []string     `json:"supported_devices"`
+}
+
+// ImageVersion describes the build of an image.
+type ImageVersion struct {
+	Dist     string `json:"dist"`
+	Version  string `json:"version"`
+	Revision string `json:"revision"`
+	Target   string `json:"target"`
+	Board    string `json:"board"`
+}
+
+// Supports reports whether the image may be flashed on the board, the way sysupgrade matches
+// the board name against the supported devices. An image that lists none supports any board.
+func (md *ImageMetadata) Supports(boardName string) bool {
+	return len(md.SupportedDevices) == 0 || slices.Contains(md.SupportedDevices, boardName)
+}
+
+// ParseMetadata extracts the metadata from the fwtool trailers at the end of an image,
+// skipping a signature. It fails with ErrNotFound when the image has no metadata.
+func ParseMetadata(image []byte) (*ImageMetadata, error) {
+	for {
+		if len(image) < fwtoolTrailerSize {
+			return nil, errdefs.Wrapf(errdefs.ErrNotFound, "image has no metadata")
+		}
+
+		trailer := image[len(image)-fwtoolTrailerSize:]
+		size := int(binary.BigEndian.Uint32(trailer[fwtoolSizeOffset:]))
+
+		if binary.BigEndian.Uint32(trailer) != fwtoolMagic || size < fwtoolTrailerSize || size > len(image) {
+			return nil, errdefs.Wrapf(errdefs.ErrNotFound, "image has no metadata")
+		}
+
+		data := image[len(image)-size : len(image)-fwtoolTrailerSize]
+		image = image[:len(image)-size]
+
+		switch trailer[fwtoolTypeOffset] {
+		case fwtoolTypeInfo:
+			var md ImageMetadata
+
+			err := json.Unmarshal(bytes.TrimRight(data, "\x00"), &md)
+			if err != nil {
+				return nil, errdefs.Wrapf(errdefs.ErrInvalidResponse, "invalid image metadata: %v", err)
+			}
+
+			return &md, nil
+		case fwtoolTypeSignature:
+			continue
+		default:
+			return nil, errdefs.Wrapf(errdefs.ErrNotFound, "image has no metadata")
+		}
+	}
+}
+
+// ValidateImage checks an image before it is flashed. The metadata of the image is first
+// matched against the board, so that an image built for another device is refused without
+// uploading it; otherwise the image is uploaded to opts.Path, checked like Check does and
+// removed again. Like Check, it does not fail when the image is invalid; inspect
+// Report.Compatible and Report.Validation instead.
+func (m *Manager) ValidateImage(ctx context.Context, image io.Reader, opts *Options) (*Report, error) {
+	data, err := io.ReadAll(image)
+	if err != nil {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "failed to read image: %v", err)
+	}
+
+	opts = withDefaults(opts)
+
+	md, err := ParseMetadata(data)
+	if err != nil && !errdefs.IsNotFound(err) {
+		return nil, err
+	}
+
+	board, err := m.system.Board(ctx)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read board info")
+	}
+
+	if md != nil && !md.Supports(board.BoardName) {
+		return &Report{Board: *board, Metadata: md, Path: opts.Path, MD5: md5Hex(data)}, nil
+	}
+
+	rep, err := m.checkImage(ctx, data, opts)
+	if err != nil {
+		return rep, err
+	}
+
+	rep.Metadata = md
+	rep.Compatible = rep.Compatible && (md == nil || md.Supports(rep.Board.BoardName))
+
+	return rep, nil
+}
+
+// checkImage uploads the image to opts.Path, checks it and removes it again.
+func (m *Manager) checkImage(ctx context.Context, image []byte, opts *Options) (*Report, error) {
+	err := m.Upload(ctx, image, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	rep, err := m.Check(ctx, opts.Path, opts.Progress)
+
+	removeErr := m.file.Remove(ctx, opts.Path)
+	if err != nil {
+		return rep, err
+	}
+
+	rep.MD5 = md5Hex(image)
+
+	return rep, removeErr
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package firmware
+
+import (
+	"github.com/honeybbq/goubus/v2/internal/base/system"
+)
+
+// Stage identifies a step of the upgrade workflow.
+type Stage string
+
+// Upgrade workflow stages, in the order they run.
+const (
+	StageUpload   Stage = "upload"
+	StageVerify   Stage = "verify"
+	StageValidate Stage = "validate"
+	StageTest     Stage = "test"
+	StageBackup   Stage = "backup"
+	StageUpgrade  Stage = "upgrade"
+)
+
+// Progress reports the advance of the upgrade workflow.
+// Written and Total are only set during StageUpload.
+type Progress struct {
+	Stage   Stage `json:"stage"`
+	Written int   `json:"written"`
+	Total   int   `json:"total"`
+}
+
+// Options controls the upgrade workflow.
+type Options struct {
+	// Progress is called at the start of every stage and after every uploaded chunk.
+	Progress func(Progress)
+	// Path is where the image is stored on the device. It defaults to /tmp/firmware.bin.
+	Path string
+	// ChunkSize is the number of image bytes sent per file.write call. It defaults to 64 KiB.
+	ChunkSize int
+	// KeepSettings preserves the configuration across the upgrade.
+	KeepSettings bool
+	// Force flashes an image that failed validation, as long as the device reports it as forceable.
+	Force bool
+	// DryRun uploads and checks the image, then removes it instead of flashing.
+	DryRun bool
+}
+
+// Validation is the result of system validate_firmware_image.
+type Validation struct {
+	Tests       map[string]bool `json:"tests"`
+	Valid       bool            `json:"valid"`
+	Forceable   bool            `json:"forceable"`
+	AllowBackup bool            `json:"allow_backup"`
+	// Compatible reports whether the device accepted the image metadata for its board. It is
+	// taken from the fwtool_device_match test, or from Valid when the device does not run it.
+	Compatible bool `json:"compatible"`
+}
+
+// Report describes an image on the device and whether it can be flashed.
+type Report struct {
+	Board      system.BoardInfo `json:"board"`
+	Validation Validation       `json:"validation"`
+	Path       string           `json:"path"`
+	MD5        string           `json:"md5,omitempty"`
+	TestOutput string           `json:"test_output,omitempty"`
+	// Metadata is the fwtool metadata of the image; only ValidateImage reads it.
+	Metadata *ImageMetadata `json:"metadata,omitempty"`
+	// Compatible reports whether the image metadata matches the board.
+	Compatible bool `json:"compatible"`
+	// Started reports whether the upgrade was triggered; it is false for dry runs.
+	Started bool `json:"started"`
+}
@@ -0,0 +1,358 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package guest
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/uci"
+)
+
+const (
+	// DefaultLeaseTime is the DHCP lease time of guest networks that set none.
+	DefaultLeaseTime = "1h"
+	// DefaultDHCPStart is the pool offset of guest networks that set none.
+	DefaultDHCPStart = 100
+	// DefaultDHCPLimit is the pool size of guest networks that set none.
+	DefaultDHCPLimit = 150
+
+	defaultUpstream = "wan"
+	bridgePrefix    = "br-"
+
+	maxNameLength = 11
+	maxSSIDLength = 32
+	minKeyLength  = 8
+	maxKeyLength  = 63
+)
+
+// nameRe matches names usable as UCI section, interface and zone names; zone names are limited
+// to 11 characters by fw4.
+var nameRe = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`)
+
+// Manager provisions guest networks. A guest network spans the network, dhcp, firewall and
+// wireless packages; the manager stages all of their sections in one UCI transaction and applies
+// it with rollback.
+type Manager struct {
+	caller goubus.Transport
+	uci    *uci.Manager
+}
+
+// New creates a new base guest network Manager.
+func New(t goubus.Transport) *Manager {
+	return &Manager{
+		caller: t,
+		uci:    uci.New(t, nil),
+	}
+}
+
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"uci"}
+}
+
+// Provision creates the guest network n: a bridge device, a static interface on it, a DHCP pool,
+// a firewall zone that rejects input and forwarding except DHCP, DNS and forwarding to the
+// upstream zone, and an access point on the radio bound to the interface. If a section cannot be
+// staged, the sections staged so far are deleted again and nothing is applied. Otherwise the
+// changes are applied with rollback like network.ApplyChanges, so rpcd restores the previous
+// configuration after timeout if the device stops answering.
+//
+// Provision needs a session without staged changes: uci apply commits everything staged in the
+// session, so changes staged by others are applied along with the guest network, and they are
+// left staged when provisioning fails.
+func (m *Manager) Provision(ctx context.Context, n Network, timeout time.Duration) error {
+	n.applyDefaults()
+
+	err := n.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = m.checkFree(ctx, n)
+	if err != nil {
+		return err
+	}
+
+	tx := m.uci.Transaction()
+	n.queue(tx)
+
+	return m.apply(ctx, tx, nil, timeout)
+}
+
+// Remove deletes the sections Provision created for the guest network called name and applies
+// the change with rollback. Sections that are already gone are skipped; if none is left, it
+// returns an error matching errdefs.ErrNotFound. Like Provision, it needs a session without
+// staged changes.
+func (m *Manager) Remove(ctx context.Context, name string, timeout time.Duration) error {
+	tx := m.uci.Transaction()
+	removed := make(map[packageSection]*uci.Section)
+
+	for _, section := range sections(name) {
+		current, err := m.uci.Package(section.pkg).Section(section.name).Get(ctx)
+		if errdefs.IsNotFound(err) {
+			continue
+		}
+
+		if err != nil {
+			return errdefs.Wrapf(err, "failed to read %s.%s", section.pkg, section.name)
+		}
+
+		tx.Delete(section.pkg, section.name)
+		removed[section] = current
+	}
+
+	if len(tx.Operations()) == 0 {
+		return errdefs.Wrapf(errdefs.ErrNotFound, "guest network %s is not configured", name)
+	}
+
+	return m.apply(ctx, tx, removed, timeout)
+}
+
+// apply applies the transaction with rollback and undoes its staged operations on failure.
+// removed holds the sections the transaction deletes, to add them back.
+func (m *Manager) apply(
+	ctx context.Context, tx *uci.Transaction, removed map[packageSection]*uci.Section, timeout time.Duration,
+) error {
+	err := tx.Apply(ctx, true, timeout)
+	if err != nil {
+		// Changes rpcd already applied roll back on their own; the undo discards them otherwise.
+		return m.revert(ctx, tx, removed, err)
+	}
+
+	return nil
+}
+
+// revert stages the inverse of the operations tx staged, latest first, and returns err. Unlike
+// a revert of the whole packages, it leaves other changes staged in the session alone.
+func (m *Manager) revert(
+	ctx context.Context, tx *uci.Transaction, removed map[packageSection]*uci.Section, err error,
+) error {
+	undo := m.uci.Transaction()
+	staged := tx.Staged()
+
+	for i := len(staged) - 1; i >= 0; i-- {
+		op := staged[i]
+
+		switch op.Kind {
+		case uci.OperationAdd:
+			undo.Delete(op.Package, op.Section)
+		case uci.OperationDelete:
+			section := removed[packageSection{op.Package, op.Section}]
+			if section != nil {
+				undo.Add(op.Package, section.Type, section.Name, section.Values)
+			}
+		}
+	}
+
+	undoErr := undo.Stage(ctx)
+	if undoErr != nil {
+		return errdefs.Wrapf(err, "undoing the staged changes failed too (%v)", undoErr)
+	}
+
+	return err
+}
+
+// checkFree checks that the radio exists and the name is not in use.
+func (m *Manager) checkFree(ctx context.Context, n Network) error {
+	_, err := m.uci.Package("wireless").Section(n.Radio).Get(ctx)
+	if err != nil {
+		return errdefs.Wrapf(err, "radio %s", n.Radio)
+	}
+
+	for _, section := range sections(n.Name) {
+		_, err = m.uci.Package(section.pkg).Section(section.name).Get(ctx)
+		if err == nil {
+			return errdefs.Wrapf(errdefs.ErrInvalidParameter, "%s.%s already exists", section.pkg, section.name)
+		}
+
+		if !errdefs.IsNotFound(err) {
+			return errdefs.Wrapf(err, "failed to read %s.%s", section.pkg, section.name)
+		}
+	}
+
+	return nil
+}
+
+// applyDefaults fills in the optional fields.
+func (n *Network) applyDefaults() {
+	if n.Encryption == "" {
+		n.Encryption = "none"
+		if n.Key != "" {
+			n.Encryption = "psk2"
+		}
+	}
+
+	if n.Upstream == "" {
+		n.Upstream = defaultUpstream
+	}
+
+	if n.LeaseTime == "" {
+		n.LeaseTime = DefaultLeaseTime
+	}
+
+	if n.DHCPStart == 0 {
+		n.DHCPStart = DefaultDHCPStart
+	}
+
+	if n.DHCPLimit == 0 {
+		n.DHCPLimit = DefaultDHCPLimit
+	}
+}
+
+// Validate checks the names, SSID, key and address of the network.
+func (n *Network) Validate() error {
+	if len(n.Name) > maxNameLength || !nameRe.MatchString(n.Name) {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid guest network name %q", n.Name)
+	}
+
+	err := n.validateWireless()
+	if err != nil {
+		return err
+	}
+
+	prefix, err := netip.ParsePrefix(n.Address)
+	if err != nil || !prefix.Addr().Is4() || prefix.Masked().Addr() == prefix.Addr() {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid address %q, want one like 192.168.3.1/24", n.Address)
+	}
+
+	if n.DHCPStart < 0 || n.DHCPLimit < 0 {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid DHCP pool %d+%d", n.DHCPStart, n.DHCPLimit)
+	}
+
+	return nil
+}
+
+// validateWireless checks the radio, SSID and key of the network.
+func (n *Network) validateWireless() error {
+	if !nameRe.MatchString(n.Radio) {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid radio %q", n.Radio)
+	}
+
+	if n.SSID == "" || len(n.SSID) > maxSSIDLength {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid SSID %q", n.SSID)
+	}
+
+	if n.Encryption != "none" && (len(n.Key) < minKeyLength || len(n.Key) > maxKeyLength) {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "the key must have %d to %d characters",
+			minKeyLength, maxKeyLength)
+	}
+
+	return nil
+}
+
+// queue adds the sections of the network to tx.
+func (n *Network) queue(tx *uci.Transaction) {
+	prefix := netip.MustParsePrefix(n.Address)
+	names := sectionNames(n.Name)
+
+	device := uci.NewSectionValues()
+	device.Set("name", bridgePrefix+n.Name)
+	device.Set("type", "bridge")
+	device.SetBool("bridge_empty", true)
+	tx.Add("network", "device", names.device, device)
+
+	iface := uci.NewSectionValues()
+	iface.Set("device", bridgePrefix+n.Name)
+	iface.Set("proto", "static")
+	iface.Set("ipaddr", prefix.Addr().String())
+	iface.Set("netmask", net.IP(net.CIDRMask(prefix.Bits(), net.IPv4len*8)).String())
+	tx.Add("network", "interface", names.iface, iface)
+
+	dhcp := uci.NewSectionValues()
+	dhcp.Set("interface", n.Name)
+	dhcp.Set("start", strconv.Itoa(n.DHCPStart))
+	dhcp.Set("limit", strconv.Itoa(n.DHCPLimit))
+	dhcp.Set("leasetime", n.LeaseTime)
+	tx.Add("dhcp", "dhcp", names.dhcp, dhcp)
+
+	n.queueFirewall(tx, names)
+
+	wifi := uci.NewSectionValues()
+	wifi.Set("device", n.Radio)
+	wifi.Set("mode", "ap")
+	wifi.Set("network", n.Name)
+	wifi.Set("ssid", n.SSID)
+	wifi.Set("encryption", n.Encryption)
+	wifi.SetScalar("key", n.Key)
+	wifi.SetBool("isolate", n.Isolate)
+	tx.Add("wireless", "wifi-iface", names.wifi, wifi)
+}
+
+// queueFirewall adds the zone, forwarding and service rules of the network to tx.
+func (n *Network) queueFirewall(tx *uci.Transaction, names guestSections) {
+	zone := uci.NewSectionValues()
+	zone.Set("name", n.Name)
+	zone.SetList("network", n.Name)
+	zone.Set("input", "REJECT")
+	zone.Set("output", "ACCEPT")
+	zone.Set("forward", "REJECT")
+	tx.Add("firewall", "zone", names.zone, zone)
+
+	forwarding := uci.NewSectionValues()
+	forwarding.Set("src", n.Name)
+	forwarding.Set("dest", n.Upstream)
+	tx.Add("firewall", "forwarding", names.forwarding, forwarding)
+
+	for _, rule := range []struct {
+		section, name, port string
+		proto               []string
+	}{
+		{names.dhcpRule, "Allow-" + n.Name + "-DHCP", "67", []string{"udp"}},
+		{names.dnsRule, "Allow-" + n.Name + "-DNS", "53", []string{"tcp", "udp"}},
+	} {
+		values := uci.NewSectionValues()
+		values.Set("name", rule.name)
+		values.Set("src", n.Name)
+		values.SetList("proto", rule.proto...)
+		values.Set("dest_port", rule.port)
+		values.Set("target", "ACCEPT")
+		tx.Add("firewall", "rule", rule.section, values)
+	}
+}
+
+// guestSections names the sections of a guest network.
+type guestSections struct {
+	device, iface, dhcp, zone, forwarding, dhcpRule, dnsRule, wifi string
+}
+
+func sectionNames(name string) guestSections {
+	return guestSections{
+		device:     name + "_dev",
+		iface:      name,
+		dhcp:       name,
+		zone:       name,
+		forwarding: name + "_fwd",
+		dhcpRule:   name + "_dhcp",
+		dnsRule:    name + "_dns",
+		wifi:       name,
+	}
+}
+
+// packageSection is a section of a package.
+type packageSection struct {
+	pkg, name string
+}
+
+// sections lists the sections of the guest network called name.
+func sections(name string) []packageSection {
+	names := sectionNames(name)
+
+	return []packageSection{
+		{"wireless", names.wifi},
+		{"firewall", names.dnsRule},
+		{"firewall", names.dhcpRule},
+		{"firewall", names.forwarding},
+		{"firewall", names.zone},
+		{"dhcp", names.dhcp},
+		{"network", names.iface},
+		{"network", names.device},
+	}
+}
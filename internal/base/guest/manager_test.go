@@ -0,0 +1,215 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package guest_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/guest"
+	"github.com/honeybbq/goubus/v2/internal/base/uci"
+	"github.com/honeybbq/goubus/v2/internal/testutil"
+)
+
+func addSection(mock *testutil.MockTransport, pkg, section, sectionType string) {
+	mock.AddResponseForArgs("uci", "get",
+		uci.GetRequest{RequestGeneric: uci.RequestGeneric{Config: pkg, Section: section}},
+		map[string]any{"values": map[string]any{".type": sectionType, ".name": section}})
+}
+
+func calls[T any](mock *testutil.MockTransport, method string) []T {
+	var requests []T
+
+	for _, call := range mock.Calls {
+		req, ok := call.Data.(T)
+		if call.Service == "uci" && call.Method == method && ok {
+			requests = append(requests, req)
+		}
+	}
+
+	return requests
+}
+
+func newMock() *testutil.MockTransport {
+	mock := testutil.NewMockTransport()
+	addSection(mock, "wireless", "radio0", "wifi-device")
+	mock.AddResponse("uci", "add", map[string]any{})
+	mock.AddResponse("uci", "delete", map[string]any{})
+	mock.AddResponse("uci", "revert", map[string]any{})
+	mock.AddResponse("uci", "apply", map[string]any{})
+	mock.AddResponse("uci", "confirm", map[string]any{})
+	mock.AddResponse("uci", "configs", map[string]any{"configs": []string{"network"}})
+
+	return mock
+}
+
+func TestGuestManager(t *testing.T) {
+	ctx := context.Background()
+	network := guest.Network{
+		Name: "guest", Radio: "radio0", SSID: "Guests", Key: "welcome123", Address: "192.168.3.1/24", Isolate: true,
+	}
+
+	t.Run("Provision", func(t *testing.T) {
+		mock := newMock()
+
+		err := guest.New(mock).Provision(ctx, network, time.Millisecond)
+		if err != nil {
+			t.Fatalf("Provision failed: %v", err)
+		}
+
+		adds := calls[uci.Request](mock, "add")
+
+		sections := make([]string, len(adds))
+		for i, add := range adds {
+			sections[i] = add.Config + "." + add.Type
+		}
+
+		want := []string{
+			"network.device", "network.interface", "dhcp.dhcp", "firewall.zone", "firewall.forwarding",
+			"firewall.rule", "firewall.rule", "wireless.wifi-iface",
+		}
+		if !slices.Equal(sections, want) {
+			t.Fatalf("unexpected sections: %v", sections)
+		}
+
+		iface := adds[1].Values
+		if iface["device"] != "br-guest" || iface["ipaddr"] != "192.168.3.1" || iface["netmask"] != "255.255.255.0" {
+			t.Errorf("unexpected interface: %+v", iface)
+		}
+
+		wifi := adds[7].Values
+		if wifi["encryption"] != "psk2" || wifi["network"] != "guest" || wifi["isolate"] != "1" {
+			t.Errorf("unexpected wifi-iface: %+v", wifi)
+		}
+
+		if adds[4].Values["dest"] != "wan" || adds[2].Values["leasetime"] != guest.DefaultLeaseTime {
+			t.Errorf("unexpected defaults: %+v %+v", adds[4].Values, adds[2].Values)
+		}
+
+		if call := mock.GetLastCall(); call.Method != "confirm" {
+			t.Errorf("expected the change to be confirmed, last call %+v", call)
+		}
+	})
+
+	t.Run("Provision_StageFailure", func(t *testing.T) {
+		mock := newMock()
+		mock.AddResponse("uci", "add", errdefs.Wrapf(errdefs.ErrPermissionDenied, "no write access"))
+
+		err := guest.New(mock).Provision(ctx, network, time.Millisecond)
+		if !errdefs.IsPermissionDenied(err) {
+			t.Fatalf("expected the add to fail, got %v", err)
+		}
+
+		if len(calls[uci.ApplyRequest](mock, "apply")) != 0 {
+			t.Fatal("changes were applied although staging failed")
+		}
+
+		// Nothing was staged, and other changes staged in the session are kept.
+		if len(calls[uci.RequestGeneric](mock, "delete")) != 0 || len(calls[uci.RevertRequest](mock, "revert")) != 0 {
+			t.Errorf("unexpected undo: %+v", mock.Calls)
+		}
+	})
+
+	t.Run("Provision_ApplyFailure", func(t *testing.T) {
+		mock := newMock()
+		mock.AddResponse("uci", "apply", errdefs.Wrapf(errdefs.ErrPermissionDenied, "no apply access"))
+
+		err := guest.New(mock).Provision(ctx, network, time.Millisecond)
+		if !errdefs.IsPermissionDenied(err) {
+			t.Fatalf("expected the apply to fail, got %v", err)
+		}
+
+		var deleted []string
+		for _, req := range calls[uci.RequestGeneric](mock, "delete") {
+			deleted = append(deleted, req.Config+"."+req.Section)
+		}
+
+		want := []string{
+			"wireless.guest", "firewall.guest_dns", "firewall.guest_dhcp", "firewall.guest_fwd", "firewall.guest",
+			"dhcp.guest", "network.guest", "network.guest_dev",
+		}
+		if !slices.Equal(deleted, want) || len(calls[uci.RevertRequest](mock, "revert")) != 0 {
+			t.Errorf("unexpected undo: %v", deleted)
+		}
+	})
+
+	t.Run("Provision_Exists", func(t *testing.T) {
+		mock := newMock()
+		addSection(mock, "firewall", "guest", "zone")
+
+		err := guest.New(mock).Provision(ctx, network, time.Millisecond)
+		if !errdefs.IsInvalidParameter(err) {
+			t.Errorf("expected an existing zone to fail, got %v", err)
+		}
+
+		if len(calls[uci.Request](mock, "add")) != 0 {
+			t.Error("sections were added although the name is taken")
+		}
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		for _, n := range []guest.Network{
+			{Name: "guest-net", Radio: "radio0", SSID: "Guests", Address: "192.168.3.1/24"},
+			{Name: "guestnetwork1", Radio: "radio0", SSID: "Guests", Address: "192.168.3.1/24"},
+			{Name: "guest", Radio: "radio0", SSID: "Guests", Encryption: "psk2", Key: "short", Address: "192.168.3.1/24"},
+			{Name: "guest", Radio: "radio0", SSID: "Guests", Address: "192.168.3.0/24"},
+			{Name: "guest", Radio: "radio0", SSID: "", Address: "192.168.3.1/24"},
+		} {
+			err := guest.New(newMock()).Provision(ctx, n, time.Millisecond)
+			if !errdefs.IsInvalidParameter(err) {
+				t.Errorf("expected %+v to be invalid, got %v", n, err)
+			}
+		}
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		mock := newMock()
+		addSection(mock, "network", "guest", "interface")
+		addSection(mock, "firewall", "guest", "zone")
+		addSection(mock, "wireless", "guest", "wifi-iface")
+
+		err := guest.New(mock).Remove(ctx, "guest", time.Millisecond)
+		if err != nil {
+			t.Fatalf("Remove failed: %v", err)
+		}
+
+		var deleted []string
+		for _, req := range calls[uci.RequestGeneric](mock, "delete") {
+			deleted = append(deleted, req.Config+"."+req.Section)
+		}
+
+		if !slices.Equal(deleted, []string{"wireless.guest", "firewall.guest", "network.guest"}) {
+			t.Errorf("unexpected deletes: %v", deleted)
+		}
+
+		err = guest.New(newMock()).Remove(ctx, "guest", time.Millisecond)
+		if !errdefs.IsNotFound(err) {
+			t.Errorf("expected a missing network to fail, got %v", err)
+		}
+	})
+
+	t.Run("Remove_ApplyFailure", func(t *testing.T) {
+		mock := newMock()
+		addSection(mock, "network", "guest", "interface")
+		addSection(mock, "wireless", "guest", "wifi-iface")
+		mock.AddResponse("uci", "apply", errdefs.Wrapf(errdefs.ErrPermissionDenied, "no apply access"))
+
+		err := guest.New(mock).Remove(ctx, "guest", time.Millisecond)
+		if !errdefs.IsPermissionDenied(err) {
+			t.Fatalf("expected the apply to fail, got %v", err)
+		}
+
+		var added []string
+		for _, req := range calls[uci.Request](mock, "add") {
+			added = append(added, req.Config+"."+req.Type+"."+req.Name)
+		}
+
+		if !slices.Equal(added, []string{"network.interface.guest", "wireless.wifi-iface.guest"}) {
+			t.Errorf("unexpected restored sections: %v", added)
+		}
+	})
+}
@@ -0,0 +1,30 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package guest
+
+// Network describes a guest Wi-Fi network: an isolated bridge with its own subnet, DHCP pool and
+// firewall zone that may only reach the upstream zone, served by an access point on one radio.
+type Network struct {
+	// Name names the interface, zone and sections of the network, e.g. "guest".
+	Name string `json:"name"`
+	// Radio is the wifi-device section the access point runs on, e.g. "radio0".
+	Radio string `json:"radio"`
+	SSID  string `json:"ssid"`
+	// Encryption is the wifi-iface encryption, e.g. "psk2" or "sae-mixed"; it defaults to "psk2"
+	// with a key and "none" without.
+	Encryption string `json:"encryption,omitempty"`
+	Key        string `json:"key,omitempty"`
+	// Address is the router address and prefix of the guest subnet, e.g. "192.168.3.1/24".
+	Address string `json:"address"`
+	// Upstream is the zone guests may forward to; it defaults to "wan".
+	Upstream string `json:"upstream,omitempty"`
+	// LeaseTime is the DHCP lease time, e.g. "1h"; it defaults to DefaultLeaseTime.
+	LeaseTime string `json:"leasetime,omitempty"`
+	// DHCPStart and DHCPLimit select the pool as offset and size within the subnet; they default
+	// to DefaultDHCPStart and DefaultDHCPLimit.
+	DHCPStart int `json:"start,omitempty"`
+	DHCPLimit int `json:"limit,omitempty"`
+	// Isolate keeps wireless clients from reaching each other.
+	Isolate bool `json:"isolate"`
+}
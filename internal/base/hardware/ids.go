@@ -0,0 +1,77 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package hardware
+
+import (
+	"strconv"
+	"strings"
+)
+
+// idLength is the length of a hexadecimal vendor or product ID in an ids file.
+const idLength = 4
+
+// IDs resolves vendor and product IDs to names from a usb.ids or pci.ids database.
+type IDs struct {
+	vendors  map[uint16]string
+	products map[[2]uint16]string
+}
+
+// ParseIDs parses the vendor and product lists of a usb.ids or pci.ids file. The class lists
+// and the subsystem entries of pci.ids are skipped.
+func ParseIDs(data string) *IDs {
+	ids := &IDs{vendors: make(map[uint16]string), products: make(map[[2]uint16]string)}
+
+	var vendor uint16
+
+	inVendor := false
+
+	for line := range strings.Lines(data) {
+		line = strings.TrimRight(line, "\r\n")
+
+		switch {
+		case line == "" || line[0] == '#' || strings.HasPrefix(line, "\t\t"):
+			continue
+		case line[0] == '\t':
+			id, name, ok := parseIDLine(line[1:])
+			if ok && inVendor {
+				ids.products[[2]uint16{vendor, id}] = name
+			}
+		default:
+			id, name, ok := parseIDLine(line)
+			if ok {
+				ids.vendors[id] = name
+				vendor = id
+			}
+
+			// Class sections such as "C 03  Human Interface Device" end the vendor list.
+			inVendor = ok
+		}
+	}
+
+	return ids
+}
+
+// parseIDLine parses an "xxxx  name" line.
+func parseIDLine(line string) (uint16, string, bool) {
+	if len(line) <= idLength || line[idLength] != ' ' {
+		return 0, "", false
+	}
+
+	id, err := strconv.ParseUint(line[:idLength], 16, 16)
+	if err != nil {
+		return 0, "", false
+	}
+
+	return uint16(id), strings.TrimSpace(line[idLength:]), true
+}
+
+// Vendor returns the name of a vendor, or "" when it is unknown.
+func (ids *IDs) Vendor(vendor uint16) string {
+	return ids.vendors[vendor]
+}
+
+// Product returns the name of a product of a vendor, or "" when it is unknown.
+func (ids *IDs) Product(vendor, product uint16) string {
+	return ids.products[[2]uint16{vendor, product}]
+}
@@ -0,0 +1,252 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package hardware
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/file"
+)
+
+const (
+	usbDevicesDir = "/sys/bus/usb/devices/"
+	pciDevicesDir = "/sys/bus/pci/devices/"
+
+	// USBIDsFile and PCIIDsFile are the ID databases of the usbids and pciids packages.
+	USBIDsFile = "/usr/share/hwdata/usb.ids"
+	PCIIDsFile = "/usr/share/hwdata/pci.ids"
+
+	// pciBaseClassShift extracts the base class from a 24 bit PCI class code.
+	pciBaseClassShift = 16
+)
+
+// Manager lists the USB and PCI devices of the system. It reads sysfs through rpcd's file
+// object, which needs read access to /sys/bus and, for names, to /usr/share/hwdata.
+type Manager struct {
+	file *file.Manager
+
+	mu sync.Mutex
+	// ids caches the parsed ID databases by path, empty for those that are not installed.
+	ids map[string]*IDs
+}
+
+// New creates a new base hardware Manager.
+func New(t goubus.Transport) *Manager {
+	return &Manager{file: file.New(t), ids: make(map[string]*IDs)}
+}
+
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"file"}
+}
+
+// USBDevices lists the attached USB devices including the root hubs, sorted by bus and
+// device number.
+func (m *Manager) USBDevices(ctx context.Context) ([]USBDevice, error) {
+	names, err := m.list(ctx, usbDevicesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := m.database(ctx, USBIDsFile)
+	devices := []USBDevice{}
+
+	for _, name := range names {
+		if strings.Contains(name, ":") {
+			continue
+		}
+
+		device, ok := m.usbDevice(ctx, name, ids)
+		if !ok {
+			continue
+		}
+
+		for _, iface := range names {
+			if strings.HasPrefix(iface, name+":") {
+				device.Interfaces = append(device.Interfaces, m.usbInterface(ctx, iface))
+			}
+		}
+
+		devices = append(devices, device)
+	}
+
+	slices.SortFunc(devices, func(a, b USBDevice) int {
+		return cmp.Or(cmp.Compare(a.Bus, b.Bus), cmp.Compare(a.Device, b.Device))
+	})
+
+	return devices, nil
+}
+
+// PCIDevices lists the PCI devices, sorted by slot. Systems without PCI have none.
+func (m *Manager) PCIDevices(ctx context.Context) ([]PCIDevice, error) {
+	slots, err := m.list(ctx, pciDevicesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := m.database(ctx, PCIIDsFile)
+	devices := []PCIDevice{}
+
+	for _, slot := range slots {
+		dir := pciDevicesDir + slot + "/"
+
+		vendor, ok := m.hex(ctx, dir+"vendor")
+		if !ok {
+			continue
+		}
+
+		product, _ := m.hex(ctx, dir+"device")
+		subVendor, _ := m.hex(ctx, dir+"subsystem_vendor")
+		subProduct, _ := m.hex(ctx, dir+"subsystem_device")
+		class, _ := m.hex(ctx, dir+"class")
+
+		devices = append(devices, PCIDevice{
+			Slot:             slot,
+			Vendor:           uint16(vendor),
+			Product:          uint16(product),
+			SubsystemVendor:  uint16(subVendor),
+			SubsystemProduct: uint16(subProduct),
+			VendorName:       ids.Vendor(uint16(vendor)),
+			ProductName:      ids.Product(uint16(vendor), uint16(product)),
+			Class:            int(class),
+		})
+	}
+
+	slices.SortFunc(devices, func(a, b PCIDevice) int { return strings.Compare(a.Slot, b.Slot) })
+
+	return devices, nil
+}
+
+// HasClass reports whether the device or one of its interfaces has a class, e.g.
+// USBClassMassStorage.
+func (d *USBDevice) HasClass(class int) bool {
+	return d.Class == class || slices.ContainsFunc(d.Interfaces, func(i USBInterface) bool {
+		return i.Class == class
+	})
+}
+
+// BaseClass returns the base class of the device, e.g. PCIClassNetwork.
+func (d *PCIDevice) BaseClass() int {
+	return d.Class >> pciBaseClassShift
+}
+
+// usbDevice reads the attributes of the USB device name.
+func (m *Manager) usbDevice(ctx context.Context, name string, ids *IDs) (USBDevice, bool) {
+	dir := usbDevicesDir + name + "/"
+
+	vendor, ok := m.hex(ctx, dir+"idVendor")
+	if !ok {
+		return USBDevice{}, false
+	}
+
+	product, _ := m.hex(ctx, dir+"idProduct")
+	class, _ := m.hex(ctx, dir+"bDeviceClass")
+	bus, _ := strconv.Atoi(m.attribute(ctx, dir+"busnum"))
+	devnum, _ := strconv.Atoi(m.attribute(ctx, dir+"devnum"))
+
+	device := USBDevice{
+		Path:        name,
+		Bus:         bus,
+		Device:      devnum,
+		Vendor:      uint16(vendor),
+		Product:     uint16(product),
+		VendorName:  ids.Vendor(uint16(vendor)),
+		ProductName: ids.Product(uint16(vendor), uint16(product)),
+		Serial:      m.attribute(ctx, dir+"serial"),
+		Speed:       m.attribute(ctx, dir+"speed"),
+		Interfaces:  []USBInterface{},
+		Class:       int(class),
+	}
+
+	if device.VendorName == "" {
+		device.VendorName = m.attribute(ctx, dir+"manufacturer")
+	}
+
+	if device.ProductName == "" {
+		device.ProductName = m.attribute(ctx, dir+"product")
+	}
+
+	return device, true
+}
+
+// usbInterface reads the class of the USB interface name.
+func (m *Manager) usbInterface(ctx context.Context, name string) USBInterface {
+	dir := usbDevicesDir + name + "/"
+
+	class, _ := m.hex(ctx, dir+"bInterfaceClass")
+	subClass, _ := m.hex(ctx, dir+"bInterfaceSubClass")
+	protocol, _ := m.hex(ctx, dir+"bInterfaceProtocol")
+
+	return USBInterface{Path: name, Class: int(class), SubClass: int(subClass), Protocol: int(protocol)}
+}
+
+// database returns the ID database at path, parsing it on first use. A database that cannot
+// be read resolves no names.
+func (m *Manager) database(ctx context.Context, path string) *IDs {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if ids, ok := m.ids[path]; ok {
+		return ids
+	}
+
+	res, err := m.file.Read(ctx, path, false)
+	if err != nil {
+		empty := ParseIDs("")
+		if errdefs.IsNotFound(err) {
+			m.ids[path] = empty
+		}
+
+		return empty
+	}
+
+	m.ids[path] = ParseIDs(res.Data)
+
+	return m.ids[path]
+}
+
+// list lists the entries of a sysfs directory in name order. A missing directory has none.
+func (m *Manager) list(ctx context.Context, dir string) ([]string, error) {
+	list, err := m.file.List(ctx, dir)
+	if errdefs.IsNotFound(err) {
+		return []string{}, nil
+	}
+
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to list %s", dir)
+	}
+
+	names := make([]string, 0, len(list.Entries))
+	for _, entry := range list.Entries {
+		names = append(names, entry.Name)
+	}
+
+	slices.Sort(names)
+
+	return names, nil
+}
+
+// attribute reads a text attribute, returning "" when it cannot be read.
+func (m *Manager) attribute(ctx context.Context, path string) string {
+	res, err := m.file.Read(ctx, path, false)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(res.Data)
+}
+
+// hex reads a hexadecimal attribute such as "0x8086" or "1d6b".
+func (m *Manager) hex(ctx context.Context, path string) (uint64, bool) {
+	value, err := strconv.ParseUint(strings.TrimPrefix(m.attribute(ctx, path), "0x"), 16, 32)
+
+	return value, err == nil
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package hardware_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/honeybbq/goubus/v2/internal/base/hardware"
+	"github.com/honeybbq/goubus/v2/internal/testutil"
+)
+
+const usbIDs = `# List of USB ID's
+1d6b  Linux Foundation
+	0002  2.0 root hub
+2c7c  Quectel Wireless Solutions Co., Ltd.
+	0125  EC25 LTE modem
+C 08  Mass Storage
+	06  SCSI
+`
+
+func TestHardwareManager(t *testing.T) {
+	ctx := context.Background()
+
+	newMock := func() (*testutil.MockTransport, func(path, data string), func(dir string, names ...string)) {
+		mock := testutil.NewMockTransport()
+		read := func(path, data string) {
+			mock.AddResponseForArgs("file", "read", map[string]any{"path": path}, map[string]any{"data": data})
+		}
+		list := func(dir string, names ...string) {
+			entries := make([]map[string]any, len(names))
+			for i, name := range names {
+				entries[i] = map[string]any{"name": name, "type": "symlink"}
+			}
+
+			mock.AddResponseForArgs("file", "list", map[string]any{"path": dir}, map[string]any{"entries": entries})
+		}
+
+		return mock, read, list
+	}
+
+	t.Run("USBDevices", func(t *testing.T) {
+		mock, read, list := newMock()
+		list("/sys/bus/usb/devices/", "1-1:1.4", "usb1", "1-0:1.0", "1-1", "1-1:1.0", "2-1")
+
+		read(hardware.USBIDsFile, usbIDs)
+		read("/sys/bus/usb/devices/usb1/idVendor", "1d6b\n")
+		read("/sys/bus/usb/devices/usb1/idProduct", "0002\n")
+		read("/sys/bus/usb/devices/usb1/bDeviceClass", "09\n")
+		read("/sys/bus/usb/devices/usb1/busnum", "1\n")
+		read("/sys/bus/usb/devices/usb1/devnum", "1\n")
+		read("/sys/bus/usb/devices/1-1/idVendor", "2c7c\n")
+		read("/sys/bus/usb/devices/1-1/idProduct", "0125\n")
+		read("/sys/bus/usb/devices/1-1/bDeviceClass", "ef\n")
+		read("/sys/bus/usb/devices/1-1/busnum", "1\n")
+		read("/sys/bus/usb/devices/1-1/devnum", "3\n")
+		read("/sys/bus/usb/devices/1-1/speed", "480\n")
+		read("/sys/bus/usb/devices/1-1:1.0/bInterfaceClass", "ff\n")
+		read("/sys/bus/usb/devices/1-1:1.4/bInterfaceClass", "ff\n")
+		read("/sys/bus/usb/devices/1-1:1.4/bInterfaceProtocol", "50\n")
+		read("/sys/bus/usb/devices/2-1/idVendor", "0781\n")
+		read("/sys/bus/usb/devices/2-1/idProduct", "5581\n")
+		read("/sys/bus/usb/devices/2-1/busnum", "2\n")
+		read("/sys/bus/usb/devices/2-1/devnum", "2\n")
+		read("/sys/bus/usb/devices/2-1/manufacturer", " SanDisk\n")
+		read("/sys/bus/usb/devices/2-1/product", "Ultra\n")
+
+		devices, err := hardware.New(mock).USBDevices(ctx)
+		if err != nil {
+			t.Fatalf("USBDevices failed: %v", err)
+		}
+
+		if len(devices) != 3 || devices[0].ProductName != "2.0 root hub" || devices[1].Path != "1-1" {
+			t.Fatalf("unexpected USB devices: %+v", devices)
+		}
+
+		modem := devices[1]
+		if modem.VendorName != "Quectel Wireless Solutions Co., Ltd." || modem.ProductName != "EC25 LTE modem" ||
+			len(modem.Interfaces) != 2 || modem.Interfaces[1].Protocol != 0x50 ||
+			!modem.HasClass(hardware.USBClassVendorSpecific) || modem.HasClass(hardware.USBClassMassStorage) {
+			t.Errorf("unexpected modem: %+v", modem)
+		}
+
+		// Names the ID database lacks fall back to the device strings.
+		if devices[2].VendorName != "SanDisk" || devices[2].ProductName != "Ultra" || devices[2].Vendor != 0x0781 {
+			t.Errorf("unexpected storage device: %+v", devices[2])
+		}
+	})
+
+	t.Run("PCIDevices", func(t *testing.T) {
+		mock, read, list := newMock()
+		list("/sys/bus/pci/devices/", "0000:01:00.0", "0000:00:00.0")
+
+		read("/sys/bus/pci/devices/0000:00:00.0/vendor", "0x8086\n")
+		read("/sys/bus/pci/devices/0000:00:00.0/device", "0x1237\n")
+		read("/sys/bus/pci/devices/0000:00:00.0/class", "0x060000\n")
+		read("/sys/bus/pci/devices/0000:01:00.0/vendor", "0x14c3\n")
+		read("/sys/bus/pci/devices/0000:01:00.0/device", "0x7915\n")
+		read("/sys/bus/pci/devices/0000:01:00.0/class", "0x028000\n")
+		read("/sys/bus/pci/devices/0000:01:00.0/subsystem_vendor", "0x14c3\n")
+
+		devices, err := hardware.New(mock).PCIDevices(ctx)
+		if err != nil {
+			t.Fatalf("PCIDevices failed: %v", err)
+		}
+
+		if len(devices) != 2 || devices[0].BaseClass() != hardware.PCIClassBridge ||
+			devices[1].BaseClass() != hardware.PCIClassNetwork || devices[1].SubsystemVendor != 0x14c3 ||
+			devices[1].VendorName != "" {
+			t.Errorf("unexpected PCI devices: %+v", devices)
+		}
+	})
+
+	t.Run("ParseIDs", func(t *testing.T) {
+		ids := hardware.ParseIDs(usbIDs)
+
+		if ids.Vendor(0x1d6b) != "Linux Foundation" || ids.Product(0x2c7c, 0x0125) != "EC25 LTE modem" ||
+			ids.Vendor(0x0008) != "" || ids.Product(0x1d6b, 0x0003) != "" {
+			t.Error("unexpected ID lookups")
+		}
+	})
+}
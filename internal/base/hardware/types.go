@@ -0,0 +1,78 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package hardware
+
+// USB device and interface class codes assigned by the USB-IF.
+const (
+	// USBClassPerInterface marks devices whose interfaces declare their classes.
+	USBClassPerInterface = 0x00
+	USBClassAudio        = 0x01
+	// USBClassCommunications is the CDC class of modems and USB network adapters.
+	USBClassCommunications = 0x02
+	USBClassHID            = 0x03
+	USBClassPrinter        = 0x07
+	USBClassMassStorage    = 0x08
+	USBClassHub            = 0x09
+	USBClassCDCData        = 0x0a
+	USBClassVideo          = 0x0e
+	// USBClassWireless is the class of Bluetooth adapters and RNDIS devices.
+	USBClassWireless = 0xe0
+	// USBClassVendorSpecific is the class of most Wi-Fi dongles and LTE modems.
+	USBClassVendorSpecific = 0xff
+)
+
+// PCI class codes of the devices routers commonly carry, the upper byte of the class.
+const (
+	PCIClassStorage = 0x01
+	// PCIClassNetwork covers Ethernet and Wi-Fi controllers.
+	PCIClassNetwork  = 0x02
+	PCIClassDisplay  = 0x03
+	PCIClassBridge   = 0x06
+	PCIClassSerial   = 0x0c
+	PCIClassWireless = 0x0d
+)
+
+// USBDevice is a USB device attached to the system, read from /sys/bus/usb/devices.
+type USBDevice struct {
+	// Path is the sysfs name of the device, e.g. "1-1.2", or "usb1" for a root hub.
+	Path    string `json:"path"`
+	Bus     int    `json:"bus"`
+	Device  int    `json:"device"`
+	Vendor  uint16 `json:"vendor_id"`
+	Product uint16 `json:"product_id"`
+	// VendorName and ProductName come from usb.ids when it is installed, otherwise from the
+	// strings the device reports.
+	VendorName  string `json:"vendor_name,omitempty"`
+	ProductName string `json:"product_name,omitempty"`
+	Serial      string `json:"serial,omitempty"`
+	// Speed is the link speed in Mbit/s, e.g. "480".
+	Speed      string         `json:"speed"`
+	Interfaces []USBInterface `json:"interfaces"`
+	Class      int            `json:"class"`
+}
+
+// USBInterface is an interface of a USB device.
+type USBInterface struct {
+	// Path is the sysfs name of the interface, e.g. "1-1.2:1.0".
+	Path     string `json:"path"`
+	Class    int    `json:"class"`
+	SubClass int    `json:"subclass"`
+	Protocol int    `json:"protocol"`
+}
+
+// PCIDevice is a PCI device, read from /sys/bus/pci/devices.
+type PCIDevice struct {
+	// Slot is the PCI address, e.g. "0000:01:00.0".
+	Slot    string `json:"slot"`
+	Vendor  uint16 `json:"vendor_id"`
+	Product uint16 `json:"product_id"`
+	// SubsystemVendor and SubsystemProduct identify the board the chip is built into.
+	SubsystemVendor  uint16 `json:"subsystem_vendor_id"`
+	SubsystemProduct uint16 `json:"subsystem_product_id"`
+	// VendorName and ProductName come from pci.ids when it is installed.
+	VendorName  string `json:"vendor_name,omitempty"`
+	ProductName string `json:"product_name,omitempty"`
+	// Class is the 24 bit class code: class, subclass and programming interface.
+	Class int `json:"class"`
+}
@@ -5,18 +5,53 @@ package hostapd
 
 import (
 	"context"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/file"
 )
 
+const (
+	// vhtMCSNotSupported marks a spatial stream as unsupported in a VHT MCS map.
+	vhtMCSNotSupported = 3
+	// extCapBSSTransitionByte and extCapBSSTransitionBit locate the BSS Transition bit (bit 19)
+	// in the Extended Capabilities element.
+	extCapBSSTransitionByte = 2
+	extCapBSSTransitionBit  = 3
+)
+
+// IEEE 802.11 reason codes commonly sent when removing a station.
+const (
+	ReasonUnspecified   = 1
+	ReasonInactivity    = 4
+	ReasonAPBusy        = 5
+	ReasonBSSTransition = 12
+)
+
+// DFSEventRadarDetected is the notification hostapd sends when radar is detected on its channel.
+const DFSEventRadarDetected = "radar-detected"
+
+// chanWidthMHz maps the hostapd chan_width values carried by radar notifications
+// (20 MHz no-HT, 20, 40, 80, 80+80, 160) to MHz.
+var chanWidthMHz = []int{20, 20, 40, 80, 160, 160}
+
 // Manager provides an interface for managing hostapd (WiFi AP).
 type Manager struct {
 	caller goubus.Transport
+	file   *file.Manager
 }
 
 // New creates a new base hostapd Manager.
 func New(t goubus.Transport) *Manager {
-	return &Manager{caller: t}
+	return &Manager{caller: t, file: file.New(t)}
+}
+
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"hostapd", "hostapd.*", "file"}
 }
 
 // Reload reloads hostapd configuration.
@@ -65,6 +100,26 @@ func (c *APContext) GetClients(ctx context.Context) (map[string]any, error) {
 	return *res, nil
 }
 
+// Clients retrieves the connected clients as typed entries, sorted by MAC address.
+func (c *APContext) Clients(ctx context.Context) ([]Client, error) {
+	res, err := goubus.Call[clientsResponse](ctx, c.manager.caller, c.name, "get_clients", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	clients := make([]Client, 0, len(res.Clients))
+	for mac, client := range res.Clients {
+		client.MAC = mac
+		clients = append(clients, client)
+	}
+
+	slices.SortFunc(clients, func(a, b Client) int {
+		return strings.Compare(a.MAC, b.MAC)
+	})
+
+	return clients, nil
+}
+
 // GetStatus retrieves the status of the AP.
 func (c *APContext) GetStatus(ctx context.Context) (map[string]any, error) {
 	res, err := goubus.Call[map[string]any](ctx, c.manager.caller, c.name, "get_status", nil)
@@ -75,6 +130,11 @@ func (c *APContext) GetStatus(ctx context.Context) (map[string]any, error) {
 	return *res, nil
 }
 
+// Status retrieves the typed status of the AP, including its DFS state.
+func (c *APContext) Status(ctx context.Context) (*Status, error) {
+	return goubus.Call[Status](ctx, c.manager.caller, c.name, "get_status", nil)
+}
+
 // DelClient removes a connected client.
 func (c *APContext) DelClient(ctx context.Context, addr string, reason int, deauth bool, banTime int) error {
 	params := map[string]any{
@@ -88,6 +148,41 @@ func (c *APContext) DelClient(ctx context.Context, addr string, reason int, deau
 	return err
 }
 
+// Kick removes a station from the AP, optionally banning it for a while so that it associates
+// with another BSS, as band steering does.
+func (c *APContext) Kick(ctx context.Context, req DelClientRequest) error {
+	if req.Addr == "" {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "station address is required")
+	}
+
+	if req.Reason == 0 {
+		req.Reason = ReasonUnspecified
+	}
+
+	_, err := c.manager.caller.Call(ctx, c.name, "del_client", req)
+
+	return err
+}
+
+// WPSStart starts the WPS push-button method on the AP.
+func (c *APContext) WPSStart(ctx context.Context) error {
+	_, err := c.manager.caller.Call(ctx, c.name, "wps_start", nil)
+
+	return err
+}
+
+// WPSCancel cancels a pending WPS push-button or PIN session.
+func (c *APContext) WPSCancel(ctx context.Context) error {
+	_, err := c.manager.caller.Call(ctx, c.name, "wps_cancel", nil)
+
+	return err
+}
+
+// WPSStatus retrieves the WPS push-button state of the AP.
+func (c *APContext) WPSStatus(ctx context.Context) (*WPSStatus, error) {
+	return goubus.Call[WPSStatus](ctx, c.manager.caller, c.name, "wps_status", nil)
+}
+
 // SwitchChan switches the channel of the AP.
 func (c *APContext) SwitchChan(ctx context.Context, freq, bandwidth int) error {
 	params := map[string]any{
@@ -98,3 +193,161 @@ func (c *APContext) SwitchChan(ctx context.Context, freq, bandwidth int) error {
 
 	return err
 }
+
+// SwitchChannel moves the AP to a new channel with a channel switch announcement (CSA),
+// letting associated clients follow without the disconnect of a wifi reload.
+func (c *APContext) SwitchChannel(ctx context.Context, req SwitchChanRequest) error {
+	if req.Freq <= 0 {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "target frequency is required")
+	}
+
+	_, err := c.manager.caller.Call(ctx, c.name, "switch_chan", req)
+
+	return err
+}
+
+// WatchDFS delivers the radar notifications of the AP.
+// Every event carries a DFSEvent payload that can be decoded with ParseDFSEvent.
+func (c *APContext) WatchDFS(ctx context.Context) (*goubus.Subscription, error) {
+	sub, err := goubus.Subscribe(ctx, c.manager.caller, c.name)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to subscribe to %s", c.name)
+	}
+
+	return goubus.FilterSubscription(ctx, sub, isDFSEvent), nil
+}
+
+// WatchDFS delivers the radar notifications of several APs on one subscription.
+// The APs are hostapd ubus objects such as "hostapd.phy1-ap0".
+func (m *Manager) WatchDFS(ctx context.Context, aps ...string) (*goubus.Subscription, error) {
+	if len(aps) == 0 {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "at least one AP is required")
+	}
+
+	subs := make([]*goubus.Subscription, 0, len(aps))
+
+	for _, ap := range aps {
+		sub, err := m.AP(ap).WatchDFS(ctx)
+		if err != nil {
+			for _, s := range subs {
+				_ = s.Close()
+			}
+
+			return nil, err
+		}
+
+		subs = append(subs, sub)
+	}
+
+	if len(subs) == 1 {
+		return subs[0], nil
+	}
+
+	return goubus.MergeSubscriptions(ctx, subs...), nil
+}
+
+// DFSState reports the CAC state of the channel in use by each of the given APs.
+// APs sharing a radio report the same channel, so only the first of them is listed.
+func (m *Manager) DFSState(ctx context.Context, aps ...string) ([]ChannelCAC, error) {
+	states := []ChannelCAC{}
+	seen := map[string]bool{}
+
+	for _, ap := range aps {
+		status, err := m.AP(ap).Status(ctx)
+		if err != nil {
+			return nil, errdefs.Wrapf(err, "failed to get status of %s", ap)
+		}
+
+		key := status.Phy + "/" + strconv.Itoa(status.Freq)
+		if status.Phy != "" && seen[key] {
+			continue
+		}
+
+		seen[key] = true
+
+		states = append(states, ChannelCAC{
+			Interface:      ap,
+			Phy:            status.Phy,
+			Channel:        status.Channel,
+			Freq:           status.Freq,
+			CACSeconds:     status.DFS.CACSeconds,
+			CACSecondsLeft: status.DFS.CACSecondsLeft,
+			CACActive:      status.DFS.CACActive,
+		})
+	}
+
+	return states, nil
+}
+
+// ParseDFSEvent decodes a radar notification. It returns false for other notifications.
+func ParseDFSEvent(ev goubus.Event) (DFSEvent, bool) {
+	if !isDFSEvent(ev) {
+		return DFSEvent{}, false
+	}
+
+	var dfs DFSEvent
+
+	err := ev.Unmarshal(&dfs)
+	if err != nil {
+		return DFSEvent{}, false
+	}
+
+	dfs.Interface = ev.Object
+	dfs.Type = ev.Type
+
+	return dfs, true
+}
+
+func isDFSEvent(ev goubus.Event) bool {
+	return ev.Type == DFSEventRadarDetected
+}
+
+// WidthMHz converts the hostapd channel width of the event to MHz.
+// 80+80 MHz channels report 160; unknown widths report 0.
+func (e *DFSEvent) WidthMHz() int {
+	if e.Width < 0 || e.Width >= len(chanWidthMHz) {
+		return 0
+	}
+
+	return chanWidthMHz[e.Width]
+}
+
+// Features derives the station feature set from the flags and capability elements hostapd reports.
+func (c *Client) Features() Features {
+	features := Features{
+		HT:            c.HT,
+		VHT:           c.VHT,
+		HE:            c.HE,
+		EHT:           c.EHT,
+		WMM:           c.WMM,
+		MFP:           c.MFP,
+		RRM:           slices.ContainsFunc(c.RRM, func(b int) bool { return b != 0 }),
+		BSSTransition: hasExtCap(c.ExtendedCapabilities, extCapBSSTransitionByte, extCapBSSTransitionBit),
+		MaxRxRate:     int(c.Rate.Rx),
+		MaxTxRate:     int(c.Rate.Tx),
+	}
+
+	if vht := c.Capabilities.VHT; vht != nil {
+		features.MUMIMO = vht.MUBeamformee
+		features.SUBeamformee = vht.SUBeamformee
+		features.MaxSpatialStreams = max(supportedStreams(vht.MCSMap.Rx), supportedStreams(vht.MCSMap.Tx))
+	}
+
+	return features
+}
+
+func supportedStreams(mcsMap map[string]int) int {
+	streams := 0
+
+	for _, mcs := range mcsMap {
+		if mcs != vhtMCSNotSupported {
+			streams++
+		}
+	}
+
+	return streams
+}
+
+func hasExtCap(extCaps []int, index, bit int) bool {
+	return len(extCaps) > index && extCaps[index]&(1<<bit) != 0
+}
@@ -5,8 +5,12 @@ package hostapd_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
 	"github.com/honeybbq/goubus/v2/internal/base/hostapd"
 	"github.com/honeybbq/goubus/v2/internal/testutil"
 )
@@ -23,6 +27,123 @@ func TestHostapdManager(t *testing.T) {
 	t.Run("AP", func(t *testing.T) {
 		testHostapdAP(t, ctx, mock, mgr)
 	})
+
+	t.Run("Roaming", func(t *testing.T) {
+		testHostapdNeighbors(t, ctx, mock, mgr)
+		testHostapdBSSTransition(t, ctx, mock, mgr)
+	})
+
+	t.Run("DFS", func(t *testing.T) {
+		testHostapdWatchDFS(t, ctx, mock, mgr)
+		testHostapdDFSState(t, ctx, mock, mgr)
+	})
+
+	t.Run("WPSSessions", func(t *testing.T) {
+		testHostapdWPSSessions(t, ctx)
+	})
+}
+
+// wpsSequence answers wps_status calls with statuses in turn, repeating the last one.
+type wpsSequence struct {
+	*testutil.MockTransport
+	statuses []map[string]any
+}
+
+func (s *wpsSequence) Call(ctx context.Context, service, method string, data any) (goubus.Result, error) {
+	if method == "wps_status" && len(s.statuses) > 0 {
+		s.AddResponse(service, method, s.statuses[0])
+		s.statuses = s.statuses[1:]
+	}
+
+	return s.MockTransport.Call(ctx, service, method, data)
+}
+
+func testHostapdWPSSessions(t *testing.T, ctx context.Context) {
+	t.Helper()
+
+	newAP := func(statuses ...map[string]any) (*testutil.MockTransport, *hostapd.APContext) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("hostapd.wlan0", "wps_start", map[string]any{})
+		mock.AddResponse("hostapd.wlan0", "wps_cancel", map[string]any{})
+		mock.AddResponse("file", "exec", map[string]any{"code": 0, "stdout": "OK\n"})
+
+		return mock, hostapd.New(&wpsSequence{MockTransport: mock, statuses: statuses}).AP("hostapd.wlan0")
+	}
+
+	t.Run("PushButton", func(t *testing.T) {
+		_, ap := newAP(map[string]any{
+			"pbc_status": "Disabled", "last_wps_result": "Success", "peer_address": "00:11:22:33:44:55",
+		})
+
+		status, err := ap.PushButton(ctx)
+		if err != nil {
+			t.Fatalf("PushButton failed: %v", err)
+		}
+
+		if status.PeerAddress != "00:11:22:33:44:55" {
+			t.Errorf("unexpected WPS status: %+v", status)
+		}
+
+		_, ap = newAP(map[string]any{"pbc_status": "Timed-out", "last_wps_result": "None"})
+
+		_, err = ap.PushButton(ctx)
+		if !errdefs.IsTimeout(err) {
+			t.Errorf("expected a walk time timeout, got %v", err)
+		}
+	})
+
+	t.Run("PushButton_Canceled", func(t *testing.T) {
+		mock, ap := newAP(map[string]any{"pbc_status": "Active", "last_wps_result": "None"})
+
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		_, err := ap.PushButton(ctx)
+		if !errdefs.IsTimeout(err) {
+			t.Errorf("expected the wait to time out, got %v", err)
+		}
+
+		if call := mock.GetLastCall(); call.Method != "wps_cancel" {
+			t.Errorf("expected the session to be cancelled, last call %+v", call)
+		}
+	})
+
+	t.Run("PIN", func(t *testing.T) {
+		mock, ap := newAP(
+			map[string]any{"pbc_status": "Disabled", "last_wps_result": "Success", "peer_address": "00:11:22:33:44:55"},
+			map[string]any{"pbc_status": "Disabled", "last_wps_result": "Success", "peer_address": "66:77:88:99:aa:bb"},
+		)
+
+		status, err := ap.PIN(ctx, "12345670", time.Minute)
+		if err != nil {
+			t.Fatalf("PIN failed: %v", err)
+		}
+
+		if status.PeerAddress != "66:77:88:99:aa:bb" {
+			t.Errorf("the previous result was taken for the session: %+v", status)
+		}
+
+		for _, call := range mock.Calls {
+			if call.Method != "exec" {
+				continue
+			}
+
+			params := fmt.Sprint(call.Data.(map[string]any)["params"])
+			if params != "[-i wlan0 wps_pin any 12345670 60]" {
+				t.Errorf("unexpected hostapd_cli arguments: %s", params)
+			}
+		}
+	})
+
+	t.Run("ValidateWPSPIN", func(t *testing.T) {
+		for pin, valid := range map[string]bool{
+			"1234": true, "12345670": true, "12345678": false, "+123": false, "123456": false, "": false,
+		} {
+			if err := hostapd.ValidateWPSPIN(pin); (err == nil) != valid {
+				t.Errorf("ValidateWPSPIN(%q) = %v", pin, err)
+			}
+		}
+	})
 }
 
 func testHostapdGeneral(t *testing.T, ctx context.Context, mock *testutil.MockTransport, mgr *hostapd.Manager) {
@@ -71,9 +192,13 @@ func testHostapdGeneral(t *testing.T, ctx context.Context, mock *testutil.MockTr
 func testHostapdAP(t *testing.T, ctx context.Context, mock *testutil.MockTransport, mgr *hostapd.Manager) {
 	t.Helper()
 	testHostapdGetClients(t, ctx, mock, mgr)
+	testHostapdClients(t, ctx, mock, mgr)
 	testHostapdGetStatus(t, ctx, mock, mgr)
 	testHostapdDelClient(t, ctx, mock, mgr)
+	testHostapdKick(t, ctx, mock, mgr)
+	testHostapdWPS(t, ctx, mock, mgr)
 	testHostapdSwitchChan(t, ctx, mock, mgr)
+	testHostapdSwitchChannel(t, ctx, mock, mgr)
 }
 
 func testHostapdGetClients(t *testing.T, ctx context.Context, mock *testutil.MockTransport, mgr *hostapd.Manager) {
@@ -102,6 +227,59 @@ func testHostapdGetClients(t *testing.T, ctx context.Context, mock *testutil.Moc
 	})
 }
 
+func testHostapdClients(t *testing.T, ctx context.Context, mock *testutil.MockTransport, mgr *hostapd.Manager) {
+	t.Helper()
+	t.Run("Clients", func(t *testing.T) {
+		mock.AddResponse("hostapd.wlan0", "get_clients", map[string]any{
+			"freq": 5180,
+			"clients": map[string]any{
+				"bb:bb:bb:bb:bb:bb": map[string]any{"authorized": true, "wmm": true, "ht": true},
+				"aa:aa:aa:aa:aa:aa": map[string]any{
+					"authorized":            true,
+					"vht":                   true,
+					"he":                    true,
+					"rrm":                   []int{0x73, 0, 0, 0, 0},
+					"extended_capabilities": []int{0x04, 0x00, 0x08, 0x00},
+					"rate":                  map[string]any{"rx": 864000, "tx": 1201000},
+					"capabilities": map[string]any{
+						"vht": map[string]any{
+							"su_beamformee": true,
+							"mu_beamformee": true,
+							"mcs_map": map[string]any{
+								"rx": map[string]int{"1ss": 2, "2ss": 2, "3ss": 3, "4ss": 3},
+								"tx": map[string]int{"1ss": 2, "2ss": 2, "3ss": 3, "4ss": 3},
+							},
+						},
+					},
+				},
+			},
+		})
+
+		clients, err := mgr.AP("hostapd.wlan0").Clients(ctx)
+		if err != nil {
+			t.Fatalf("Clients failed: %v", err)
+		}
+
+		if len(clients) != 2 || clients[0].MAC != "aa:aa:aa:aa:aa:aa" {
+			t.Fatalf("unexpected clients: %+v", clients)
+		}
+
+		features := clients[0].Features()
+		if !features.HE || !features.MUMIMO || !features.RRM || !features.BSSTransition {
+			t.Errorf("unexpected features: %+v", features)
+		}
+
+		if features.MaxSpatialStreams != 2 || features.MaxTxRate != 1201000 {
+			t.Errorf("unexpected streams/rate: %+v", features)
+		}
+
+		legacy := clients[1].Features()
+		if !legacy.HT || legacy.VHT || legacy.MUMIMO || legacy.BSSTransition {
+			t.Errorf("unexpected legacy features: %+v", legacy)
+		}
+	})
+}
+
 func testHostapdGetStatus(t *testing.T, ctx context.Context, mock *testutil.MockTransport, mgr *hostapd.Manager) {
 	t.Helper()
 	t.Run("GetStatus", func(t *testing.T) {
@@ -144,6 +322,67 @@ func testHostapdDelClient(t *testing.T, ctx context.Context, mock *testutil.Mock
 	})
 }
 
+func testHostapdKick(t *testing.T, ctx context.Context, mock *testutil.MockTransport, mgr *hostapd.Manager) {
+	t.Helper()
+	t.Run("Kick", func(t *testing.T) {
+		mock.AddResponse("hostapd.wlan0", "del_client", map[string]any{})
+
+		err := mgr.AP("hostapd.wlan0").Kick(ctx, hostapd.DelClientRequest{})
+		if !errdefs.IsInvalidParameter(err) {
+			t.Errorf("expected a missing address to be rejected, got %v", err)
+		}
+
+		err = mgr.AP("hostapd.wlan0").Kick(ctx, hostapd.DelClientRequest{
+			Addr:    "00:11:22:33:44:55",
+			BanTime: 30000,
+		})
+		if err != nil {
+			t.Fatalf("Kick failed: %v", err)
+		}
+
+		req, ok := mock.GetLastCall().Data.(hostapd.DelClientRequest)
+		if !ok || req.Reason != hostapd.ReasonUnspecified || req.BanTime != 30000 || req.Deauth {
+			t.Errorf("unexpected request: %+v", mock.GetLastCall().Data)
+		}
+	})
+}
+
+func testHostapdWPS(t *testing.T, ctx context.Context, mock *testutil.MockTransport, mgr *hostapd.Manager) {
+	t.Helper()
+	t.Run("WPS", func(t *testing.T) {
+		mock.AddResponse("hostapd.wlan0", "wps_start", map[string]any{})
+		mock.AddResponse("hostapd.wlan0", "wps_cancel", map[string]any{})
+		mock.AddResponse("hostapd.wlan0", "wps_status", map[string]any{
+			"pbc_status": "Active", "last_wps_result": "None",
+		})
+
+		ap := mgr.AP("hostapd.wlan0")
+
+		err := ap.WPSStart(ctx)
+		if err != nil {
+			t.Fatalf("WPSStart failed: %v", err)
+		}
+
+		status, err := ap.WPSStatus(ctx)
+		if err != nil {
+			t.Fatalf("WPSStatus failed: %v", err)
+		}
+
+		if status.PBCStatus != "Active" || status.LastResult != "None" {
+			t.Errorf("unexpected WPS status: %+v", status)
+		}
+
+		err = ap.WPSCancel(ctx)
+		if err != nil {
+			t.Fatalf("WPSCancel failed: %v", err)
+		}
+
+		if call := mock.GetLastCall(); call.Method != "wps_cancel" {
+			t.Errorf("unexpected call: %+v", call)
+		}
+	})
+}
+
 func testHostapdSwitchChan(t *testing.T, ctx context.Context, mock *testutil.MockTransport, mgr *hostapd.Manager) {
 	t.Helper()
 	t.Run("SwitchChan", func(t *testing.T) {
@@ -166,3 +405,163 @@ func testHostapdSwitchChan(t *testing.T, ctx context.Context, mock *testutil.Moc
 		}
 	})
 }
+
+func testHostapdSwitchChannel(t *testing.T, ctx context.Context, mock *testutil.MockTransport, mgr *hostapd.Manager) {
+	t.Helper()
+	t.Run("SwitchChannel", func(t *testing.T) {
+		mock.AddResponse("hostapd.wlan1", "switch_chan", map[string]any{})
+
+		req := hostapd.SwitchChanRequest{Freq: 5745, CenterFreq1: 5775, Bandwidth: 80, BcnCount: 10, VHT: true}
+
+		err := mgr.AP("hostapd.wlan1").SwitchChannel(ctx, req)
+		if err != nil {
+			t.Fatalf("SwitchChannel failed: %v", err)
+		}
+
+		call := mock.GetLastCall()
+		if call.Service != "hostapd.wlan1" || call.Data != req {
+			t.Errorf("unexpected call: %+v", call)
+		}
+
+		err = mgr.AP("hostapd.wlan1").SwitchChannel(ctx, hostapd.SwitchChanRequest{})
+		if !errdefs.IsInvalidParameter(err) {
+			t.Errorf("expected invalid parameter, got %v", err)
+		}
+	})
+}
+
+func testHostapdWatchDFS(t *testing.T, ctx context.Context, mock *testutil.MockTransport, mgr *hostapd.Manager) {
+	t.Helper()
+	t.Run("WatchDFS", func(t *testing.T) {
+		sub, err := mgr.WatchDFS(ctx, "hostapd.phy1-ap0", "hostapd.phy1-ap1")
+		if err != nil {
+			t.Fatalf("WatchDFS failed: %v", err)
+		}
+
+		defer func() {
+			_ = sub.Close()
+		}()
+
+		mock.Emit("hostapd.phy1-ap0", "probe", map[string]any{"address": "00:11:22:33:44:55"})
+		mock.Emit("hostapd.phy1-ap1", hostapd.DFSEventRadarDetected, map[string]any{
+			"frequency": 5500, "width": 3, "cf1": 5530, "cf2": 0,
+		})
+
+		select {
+		case ev := <-sub.Events():
+			radar, ok := hostapd.ParseDFSEvent(ev)
+			if !ok {
+				t.Fatalf("unexpected event: %+v", ev)
+			}
+
+			if radar.Interface != "hostapd.phy1-ap1" || radar.Frequency != 5500 || radar.CenterFreq1 != 5530 ||
+				radar.WidthMHz() != 80 {
+				t.Errorf("unexpected radar event: %+v", radar)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for radar event")
+		}
+
+		_, err = mgr.WatchDFS(ctx)
+		if !errdefs.IsInvalidParameter(err) {
+			t.Errorf("expected invalid parameter, got %v", err)
+		}
+	})
+}
+
+func testHostapdDFSState(t *testing.T, ctx context.Context, mock *testutil.MockTransport, mgr *hostapd.Manager) {
+	t.Helper()
+	t.Run("DFSState", func(t *testing.T) {
+		status := map[string]any{
+			"phy": "phy1", "freq": 5500, "channel": 100,
+			"dfs": map[string]any{"cac_active": true, "cac_seconds": 60, "cac_seconds_left": 42},
+		}
+		mock.AddResponse("hostapd.phy1-ap0", "get_status", status)
+		mock.AddResponse("hostapd.phy1-ap1", "get_status", status)
+
+		states, err := mgr.DFSState(ctx, "hostapd.phy1-ap0", "hostapd.phy1-ap1")
+		if err != nil {
+			t.Fatalf("DFSState failed: %v", err)
+		}
+
+		if len(states) != 1 {
+			t.Fatalf("expected 1 channel, got %+v", states)
+		}
+
+		if !states[0].CACActive || states[0].CACSecondsLeft != 42 || states[0].Channel != 100 ||
+			states[0].Interface != "hostapd.phy1-ap0" {
+			t.Errorf("unexpected CAC state: %+v", states[0])
+		}
+	})
+}
+
+func testHostapdNeighbors(t *testing.T, ctx context.Context, mock *testutil.MockTransport, mgr *hostapd.Manager) {
+	t.Helper()
+	t.Run("SyncNeighbors", func(t *testing.T) {
+		for i, ap := range []string{"hostapd.ap0", "hostapd.ap1", "hostapd.ap2"} {
+			bssid := fmt.Sprintf("02:00:00:00:00:%02d", i)
+			mock.AddResponse(ap, "rrm_nr_get_own", map[string]any{"value": []any{bssid, "Home", fmt.Sprintf("%02d00", i)}})
+			mock.AddResponse(ap, "rrm_nr_set", map[string]any{})
+		}
+
+		aps := []*hostapd.APContext{mgr.AP("hostapd.ap0"), mgr.AP("hostapd.ap1"), mgr.AP("hostapd.ap2")}
+
+		err := hostapd.SyncNeighbors(ctx, aps...)
+		if err != nil {
+			t.Fatalf("SyncNeighbors failed: %v", err)
+		}
+
+		call := mock.GetLastCall()
+
+		want := "[[02:00:00:00:00:00 Home 0000] [02:00:00:00:00:01 Home 0100]]"
+
+		params, _ := call.Data.(map[string]any)
+		if call.Service != "hostapd.ap2" || fmt.Sprint(params["list"]) != want {
+			t.Errorf("unexpected call: %+v", call)
+		}
+	})
+
+	t.Run("NeighborReports", func(t *testing.T) {
+		mock.AddResponse("hostapd.wlan0", "rrm_nr_list", map[string]any{
+			"list": []any{[]any{"02:00:00:00:00:01", "Home", "0100"}},
+		})
+
+		reports, err := mgr.AP("hostapd.wlan0").NeighborReports(ctx)
+		if err != nil {
+			t.Fatalf("NeighborReports failed: %v", err)
+		}
+
+		if len(reports) != 1 || reports[0].BSSID != "02:00:00:00:00:01" || reports[0].Report != "0100" {
+			t.Errorf("unexpected reports: %+v", reports)
+		}
+
+		err = mgr.AP("hostapd.wlan0").SetNeighborReports(ctx, []hostapd.NeighborReport{{BSSID: "x", Report: "zz"}})
+		if !errdefs.IsInvalidParameter(err) {
+			t.Errorf("expected an invalid report to be rejected, got %v", err)
+		}
+	})
+}
+
+func testHostapdBSSTransition(t *testing.T, ctx context.Context, mock *testutil.MockTransport, mgr *hostapd.Manager) {
+	t.Helper()
+	t.Run("BSSTransition", func(t *testing.T) {
+		mock.AddResponse("hostapd.wlan0", "bss_transition_request", map[string]any{})
+
+		req := hostapd.BSSTransitionRequest{
+			Addr:                   "00:11:22:33:44:55",
+			Neighbors:              []string{"0100"},
+			DisassociationImminent: true,
+			DisassociationTimer:    100,
+		}
+
+		err := mgr.AP("hostapd.wlan0").BSSTransition(ctx, req)
+		if err != nil {
+			t.Fatalf("BSSTransition failed: %v", err)
+		}
+
+		sent, ok := mock.GetLastCall().Data.(hostapd.BSSTransitionRequest)
+		if !ok || sent.Addr != req.Addr || len(sent.Neighbors) != 1 {
+			t.Errorf("unexpected request: %+v", mock.GetLastCall().Data)
+		}
+	})
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package hostapd
+
+import (
+	"context"
+	"encoding/hex"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+// neighborTupleLength is the length of the [bssid, ssid, report] tuples of the rrm_nr methods.
+const neighborTupleLength = 3
+
+type ownNeighborResponse struct {
+	Value []string `json:"value"`
+}
+
+type neighborListResponse struct {
+	List [][]string `json:"list"`
+}
+
+// OwnNeighborReport retrieves the neighbor report entry of the AP itself, which other APs
+// advertise to steer stations towards it. It requires the neighbor report feature (SetBSSManagement).
+func (c *APContext) OwnNeighborReport(ctx context.Context) (*NeighborReport, error) {
+	res, err := goubus.Call[ownNeighborResponse](ctx, c.manager.caller, c.name, "rrm_nr_get_own", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := neighborFromTuple(res.Value)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "own neighbor report of %s", c.name)
+	}
+
+	return &report, nil
+}
+
+// NeighborReports retrieves the neighbor report entries the AP advertises.
+func (c *APContext) NeighborReports(ctx context.Context) ([]NeighborReport, error) {
+	res, err := goubus.Call[neighborListResponse](ctx, c.manager.caller, c.name, "rrm_nr_list", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]NeighborReport, 0, len(res.List))
+
+	for _, tuple := range res.List {
+		report, err := neighborFromTuple(tuple)
+		if err != nil {
+			return nil, errdefs.Wrapf(err, "neighbor reports of %s", c.name)
+		}
+
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+// SetNeighborReports replaces the neighbor report entries the AP advertises.
+func (c *APContext) SetNeighborReports(ctx context.Context, reports []NeighborReport) error {
+	list := make([][]string, 0, len(reports))
+
+	for _, report := range reports {
+		_, err := hex.DecodeString(report.Report)
+		if err != nil || report.Report == "" || report.BSSID == "" {
+			return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid neighbor report for %q", report.BSSID)
+		}
+
+		list = append(list, []string{report.BSSID, report.SSID, report.Report})
+	}
+
+	_, err := c.manager.caller.Call(ctx, c.name, "rrm_nr_set", map[string]any{"list": list})
+
+	return err
+}
+
+// SetBSSManagement enables or disables the 802.11k and 802.11v features of the AP.
+func (c *APContext) SetBSSManagement(ctx context.Context, features BSSManagement) error {
+	_, err := c.manager.caller.Call(ctx, c.name, "bss_mgmt_enable", features)
+
+	return err
+}
+
+// BSSTransition asks a station to move to one of the candidate APs with an 802.11v BSS
+// transition management request. Stations without BSS transition support ignore it;
+// see Features.BSSTransition.
+func (c *APContext) BSSTransition(ctx context.Context, req BSSTransitionRequest) error {
+	if req.Addr == "" {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "station address is required")
+	}
+
+	_, err := c.manager.caller.Call(ctx, c.name, "bss_transition_request", req)
+
+	return err
+}
+
+// SyncNeighbors makes every AP advertise the own neighbor reports of all other APs, so stations
+// learn their roaming candidates. The APs may belong to different devices.
+func SyncNeighbors(ctx context.Context, aps ...*APContext) error {
+	own := make([]NeighborReport, len(aps))
+
+	for i, ap := range aps {
+		report, err := ap.OwnNeighborReport(ctx)
+		if err != nil {
+			return err
+		}
+
+		own[i] = *report
+	}
+
+	for i, ap := range aps {
+		others := make([]NeighborReport, 0, len(aps)-1)
+
+		for j, report := range own {
+			if j != i && report.BSSID != own[i].BSSID {
+				others = append(others, report)
+			}
+		}
+
+		err := ap.SetNeighborReports(ctx, others)
+		if err != nil {
+			return errdefs.Wrapf(err, "failed to set neighbor reports of %s", ap.name)
+		}
+	}
+
+	return nil
+}
+
+func neighborFromTuple(tuple []string) (NeighborReport, error) {
+	if len(tuple) != neighborTupleLength {
+		return NeighborReport{}, errdefs.Wrapf(errdefs.ErrInvalidResponse, "expected [bssid, ssid, report], got %q", tuple)
+	}
+
+	return NeighborReport{BSSID: tuple[0], SSID: tuple[1], Report: tuple[2]}, nil
+}
@@ -0,0 +1,213 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package hostapd
+
+// Client represents a station associated with a hostapd BSS, as reported by get_clients.
+type Client struct {
+	Capabilities         ClientCapabilities `json:"capabilities"`
+	MAC                  string             `json:"mac"`
+	Signature            string             `json:"signature"`
+	RRM                  []int              `json:"rrm"`
+	ExtendedCapabilities []int              `json:"extended_capabilities"`
+	Bytes                ClientCounters     `json:"bytes"`
+	Packets              ClientCounters     `json:"packets"`
+	Rate                 ClientCounters     `json:"rate"`
+	Airtime              ClientCounters     `json:"airtime"`
+	AID                  int                `json:"aid"`
+	Signal               int                `json:"signal"`
+	Auth                 bool               `json:"auth"`
+	Assoc                bool               `json:"assoc"`
+	Authorized           bool               `json:"authorized"`
+	Preauth              bool               `json:"preauth"`
+	WDS                  bool               `json:"wds"`
+	WMM                  bool               `json:"wmm"`
+	HT                   bool               `json:"ht"`
+	VHT                  bool               `json:"vht"`
+	HE                   bool               `json:"he"`
+	EHT                  bool               `json:"eht"`
+	WPS                  bool               `json:"wps"`
+	MFP                  bool               `json:"mfp"`
+}
+
+// ClientCounters holds a receive/transmit counter pair. Rates are reported in kbit/s, airtime in microseconds.
+type ClientCounters struct {
+	Rx int64 `json:"rx"`
+	Tx int64 `json:"tx"`
+}
+
+// ClientCapabilities holds the capability elements hostapd decodes from the association request.
+// Current hostapd releases only decode the VHT element.
+type ClientCapabilities struct {
+	VHT *VHTCapabilities `json:"vht,omitempty"`
+}
+
+// VHTCapabilities represents the decoded VHT capabilities of a station.
+type VHTCapabilities struct {
+	MCSMap       VHTMCSMap `json:"mcs_map"`
+	SUBeamformee bool      `json:"su_beamformee"`
+	MUBeamformee bool      `json:"mu_beamformee"`
+}
+
+// VHTMCSMap maps spatial stream labels ("1ss" ... "8ss") to the supported MCS range.
+// Values are 0 (MCS 0-7), 1 (MCS 0-8), 2 (MCS 0-9) or 3 (not supported).
+type VHTMCSMap struct {
+	Rx map[string]int `json:"rx"`
+	Tx map[string]int `json:"tx"`
+}
+
+// Features summarises what a client station can do, for capacity planning.
+type Features struct {
+	// MaxSpatialStreams is the highest number of spatial streams the station supports or was seen using.
+	MaxSpatialStreams int `json:"max_spatial_streams"`
+	// MaxBandwidth is the widest channel width in MHz the station was seen using.
+	MaxBandwidth int `json:"max_bandwidth"`
+	// MaxRxRate and MaxTxRate are the highest current PHY rates in kbit/s.
+	MaxRxRate int `json:"max_rx_rate"`
+	MaxTxRate int `json:"max_tx_rate"`
+	// HT, VHT, HE and EHT report 802.11n, 802.11ac, 802.11ax and 802.11be support.
+	HT  bool `json:"ht"`
+	VHT bool `json:"vht"`
+	HE  bool `json:"he"`
+	EHT bool `json:"eht"`
+	// MUMIMO reports VHT MU beamformee support, required for downlink MU-MIMO.
+	MUMIMO bool `json:"mu_mimo"`
+	// SUBeamformee reports VHT SU beamformee support.
+	SUBeamformee bool `json:"su_beamformee"`
+	// Supports160MHz reports whether the station was seen on a 160 MHz (or wider) channel.
+	Supports160MHz bool `json:"supports_160mhz"`
+	// WMM and MFP report QoS and 802.11w management frame protection.
+	WMM bool `json:"wmm"`
+	MFP bool `json:"mfp"`
+	// RRM reports 802.11k radio resource management support.
+	RRM bool `json:"rrm"`
+	// BSSTransition reports 802.11v BSS transition management support.
+	BSSTransition bool `json:"bss_transition"`
+}
+
+type clientsResponse struct {
+	Clients map[string]Client `json:"clients"`
+	Freq    int               `json:"freq"`
+}
+
+// Status represents the typed get_status response of a hostapd BSS.
+type Status struct {
+	Driver         string    `json:"driver"`
+	Status         string    `json:"status"`
+	BSSID          string    `json:"bssid"`
+	SSID           string    `json:"ssid"`
+	Phy            string    `json:"phy"`
+	DFS            DFSStatus `json:"dfs"`
+	Airtime        Airtime   `json:"airtime"`
+	Freq           int       `json:"freq"`
+	Channel        int       `json:"channel"`
+	OpClass        int       `json:"op_class"`
+	BeaconInterval int       `json:"beacon_interval"`
+	BSSColor       int       `json:"bss_color"`
+}
+
+// Airtime reports the channel use measured by the radio of a BSS: the total and busy channel time
+// in milliseconds, and the busy share of the channel scaled to 0-255.
+type Airtime struct {
+	Time        int64 `json:"time"`
+	TimeBusy    int64 `json:"time_busy"`
+	Utilization int   `json:"utilization"`
+}
+
+// DFSStatus reports the channel availability check (CAC) state of a radio.
+type DFSStatus struct {
+	CACSeconds     int  `json:"cac_seconds"`
+	CACSecondsLeft int  `json:"cac_seconds_left"`
+	CACActive      bool `json:"cac_active"`
+}
+
+// SwitchChanRequest represents the parameters of a channel switch announced with CSA.
+// Only Freq is required; hostapd derives the remaining parameters from the current configuration.
+type SwitchChanRequest struct {
+	Freq             int  `json:"freq"`
+	BcnCount         int  `json:"bcn_count,omitempty"`
+	CenterFreq1      int  `json:"center_freq1,omitempty"`
+	CenterFreq2      int  `json:"center_freq2,omitempty"`
+	Bandwidth        int  `json:"bandwidth,omitempty"`
+	SecChannelOffset int  `json:"sec_channel_offset,omitempty"`
+	HT               bool `json:"ht,omitempty"`
+	VHT              bool `json:"vht,omitempty"`
+	HE               bool `json:"he,omitempty"`
+	BlockTx          bool `json:"block_tx,omitempty"`
+	Force            bool `json:"csa_force,omitempty"`
+}
+
+// DFSEvent is a radar detection reported by hostapd through a ubus notification.
+type DFSEvent struct {
+	// Interface is the hostapd ubus object that sent the notification, e.g. "hostapd.phy1-ap0".
+	Interface string `json:"interface"`
+	// Type is the notification type, e.g. "radar-detected".
+	Type string `json:"type"`
+	// Frequency is the operating frequency in MHz on which radar was detected.
+	Frequency   int `json:"frequency"`
+	Width       int `json:"width"`
+	CenterFreq1 int `json:"cf1"`
+	CenterFreq2 int `json:"cf2"`
+}
+
+// ChannelCAC reports the channel availability check (CAC) state of the channel used by a radio.
+type ChannelCAC struct {
+	Interface      string `json:"interface"`
+	Phy            string `json:"phy"`
+	Channel        int    `json:"channel"`
+	Freq           int    `json:"freq"`
+	CACSeconds     int    `json:"cac_seconds"`
+	CACSecondsLeft int    `json:"cac_seconds_left"`
+	CACActive      bool   `json:"cac_active"`
+}
+
+// DelClientRequest represents the parameters for removing a station from a BSS.
+type DelClientRequest struct {
+	Addr string `json:"addr"`
+	// Reason is the IEEE 802.11 reason code sent to the station, e.g. ReasonAPBusy.
+	Reason int `json:"reason,omitempty"`
+	// BanTime rejects the station's association attempts for this many milliseconds.
+	BanTime int `json:"ban_time,omitempty"`
+	// Deauth sends a deauthentication instead of a disassociation.
+	Deauth bool `json:"deauth,omitempty"`
+}
+
+// WPSStatus reports the state of the WPS push-button method on a BSS.
+type WPSStatus struct {
+	// PBCStatus is "Active", "Disabled", "Timed-out" or "Overlap".
+	PBCStatus string `json:"pbc_status"`
+	// LastResult is "Success", "Failed" or "None".
+	LastResult  string `json:"last_wps_result"`
+	PeerAddress string `json:"peer_address"`
+}
+
+// NeighborReport is an 802.11k neighbor report entry. Report is the hex-encoded neighbor report
+// element hostapd advertises for the BSS, as returned by OwnNeighborReport.
+type NeighborReport struct {
+	BSSID  string `json:"bssid"`
+	SSID   string `json:"ssid"`
+	Report string `json:"report"`
+}
+
+// BSSManagement selects the 802.11k and 802.11v features a BSS advertises.
+type BSSManagement struct {
+	NeighborReport  bool `json:"neighbor_report"`
+	BeaconReport    bool `json:"beacon_report"`
+	LinkMeasurement bool `json:"link_measurement"`
+	BSSTransition   bool `json:"bss_transition"`
+}
+
+// BSSTransitionRequest represents an 802.11v BSS transition management request to a station.
+type BSSTransitionRequest struct {
+	Addr string `json:"addr"`
+	// Neighbors are the hex-encoded neighbor report elements of the candidate APs, most preferred first.
+	Neighbors []string `json:"neighbors,omitempty"`
+	// DisassociationTimer and ValidityPeriod are counted in beacon intervals.
+	DisassociationTimer int `json:"disassociation_timer,omitempty"`
+	ValidityPeriod      int `json:"validity_period,omitempty"`
+	DialogToken         int `json:"dialog_token,omitempty"`
+	// DisassociationImminent announces that the AP disassociates the station when the timer expires.
+	DisassociationImminent bool `json:"disassociation_imminent,omitempty"`
+	// Abridged lowers the preference of APs missing from Neighbors.
+	Abridged bool `json:"abridged,omitempty"`
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package hostapd
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+// WPS push-button states reported in WPSStatus.PBCStatus.
+const (
+	WPSActive   = "Active"
+	WPSDisabled = "Disabled"
+	WPSTimedOut = "Timed-out"
+	WPSOverlap  = "Overlap"
+)
+
+// WPS results reported in WPSStatus.LastResult.
+const (
+	WPSResultNone    = "None"
+	WPSResultSuccess = "Success"
+	WPSResultFailed  = "Failed"
+)
+
+const (
+	// DefaultWPSPINTimeout is how long PIN accepts the PIN when no timeout is given.
+	DefaultWPSPINTimeout = 2 * time.Minute
+
+	// wpsPollInterval is how often WaitWPS reads the WPS status.
+	wpsPollInterval = time.Second
+
+	hostapdCLI     = "/usr/sbin/hostapd_cli"
+	wpsShortPINLen = 4
+	wpsPINLen      = 8
+	wpsPINWeight   = 3
+	decimalBase    = 10
+)
+
+// PushButton presses the virtual WPS button of the AP and waits until the push-button session
+// ends, which hostapd does after a credential exchange or the 120 s walk time. It returns the
+// final status and an error unless the exchange succeeded: one matching errdefs.ErrTimeout when
+// no device joined and errdefs.ErrInvalidCommand when several pressed their button. If ctx ends
+// first, the session is cancelled.
+func (c *APContext) PushButton(ctx context.Context) (*WPSStatus, error) {
+	err := c.WPSStart(ctx)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to start WPS on %s", c.name)
+	}
+
+	// The session is active until it ends, so any result that follows belongs to it.
+	return c.waitOrCancel(ctx, nil)
+}
+
+// PIN lets a device join with its WPS PIN, a 4-digit PIN or an 8-digit PIN with a valid
+// checksum, for timeout or DefaultWPSPINTimeout, and waits for the result like PushButton.
+// hostapd has no ubus method for PINs, so it runs hostapd_cli through rpcd's file.exec, which
+// needs the full wpad or hostapd package and exec permission for hostapd_cli.
+func (c *APContext) PIN(ctx context.Context, pin string, timeout time.Duration) (*WPSStatus, error) {
+	err := ValidateWPSPIN(pin)
+	if err != nil {
+		return nil, err
+	}
+
+	if timeout <= 0 {
+		timeout = DefaultWPSPINTimeout
+	}
+
+	since, err := c.WPSStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ifname := strings.TrimPrefix(c.name, "hostapd.")
+	seconds := strconv.Itoa(int(timeout.Round(time.Second).Seconds()))
+
+	res, err := c.manager.file.Exec(ctx, hostapdCLI, []string{"-i", ifname, "wps_pin", "any", pin, seconds}, nil)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to run hostapd_cli")
+	}
+
+	if res.Code != 0 || strings.HasPrefix(strings.TrimSpace(res.Stdout), "FAIL") {
+		return nil, errdefs.Wrapf(errdefs.ErrUnknown, "hostapd_cli wps_pin failed with code %d: %s",
+			res.Code, strings.TrimSpace(res.Stdout+res.Stderr))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return c.waitOrCancel(ctx, since)
+}
+
+// WaitWPS polls the WPS status until the session started after since ends, and returns the
+// final status with the error PushButton describes. A PIN session leaves the push-button state
+// alone, so pass the status read before starting it; a nil since accepts any result once no
+// push-button session is active. Bound the wait with ctx.
+func (c *APContext) WaitWPS(ctx context.Context, since *WPSStatus) (*WPSStatus, error) {
+	ticker := time.NewTicker(wpsPollInterval)
+	defer ticker.Stop()
+
+	for {
+		status, err := c.WPSStatus(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if status.finished(since) {
+			return status, status.Err()
+		}
+
+		select {
+		case <-ctx.Done():
+			return status, errdefs.Wrapf(errdefs.FromTransport(ctx.Err()), "wait for WPS on %s", c.name)
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitOrCancel waits for the session and cancels it when ctx ends first.
+func (c *APContext) waitOrCancel(ctx context.Context, since *WPSStatus) (*WPSStatus, error) {
+	status, err := c.WaitWPS(ctx, since)
+	if err != nil && ctx.Err() != nil {
+		_ = c.WPSCancel(context.WithoutCancel(ctx))
+	}
+
+	return status, err
+}
+
+// Err returns nil for a successful exchange and an error describing the other outcomes.
+func (s *WPSStatus) Err() error {
+	switch {
+	case s.PBCStatus == WPSOverlap:
+		return errdefs.Wrapf(errdefs.ErrInvalidCommand, "WPS session overlap: several devices pressed their button")
+	case s.PBCStatus == WPSTimedOut:
+		return errdefs.Wrapf(errdefs.ErrTimeout, "no device joined through WPS")
+	case s.LastResult == WPSResultSuccess:
+		return nil
+	default:
+		return errdefs.Wrapf(errdefs.ErrUnknown, "WPS exchange with %q ended with %q", s.PeerAddress, s.LastResult)
+	}
+}
+
+// finished reports whether the session started after since has ended.
+func (s *WPSStatus) finished(since *WPSStatus) bool {
+	switch {
+	case s.PBCStatus == WPSActive:
+		return false
+	case s.PBCStatus == WPSTimedOut || s.PBCStatus == WPSOverlap:
+		return true
+	case s.LastResult == WPSResultNone || s.LastResult == "":
+		return false
+	case since == nil:
+		return true
+	default:
+		return s.LastResult != since.LastResult || s.PeerAddress != since.PeerAddress
+	}
+}
+
+// ValidateWPSPIN checks that pin is a 4-digit PIN or an 8-digit PIN with a valid checksum.
+func ValidateWPSPIN(pin string) error {
+	if strings.Trim(pin, "0123456789") != "" || (len(pin) != wpsShortPINLen && len(pin) != wpsPINLen) {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "WPS PIN must have 4 or 8 digits")
+	}
+
+	if len(pin) == wpsShortPINLen {
+		return nil
+	}
+
+	sum := 0
+
+	for i, digit := range pin {
+		weight := 1
+		if i%2 == 0 {
+			weight = wpsPINWeight
+		}
+
+		sum += weight * int(digit-'0')
+	}
+
+	if sum%decimalBase != 0 {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "WPS PIN %s has an invalid checksum", pin)
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+const followBuffer = 64
+
+// Follower delivers the log entries written after Follow was called, like "logread -f".
+type Follower struct {
+	sub     *goubus.Subscription
+	entries chan Data
+	stop    chan struct{}
+	once    sync.Once
+}
+
+// Follow subscribes to the notifications logd sends for every new entry and delivers the ones
+// accepted by filter. It needs a transport with subscription support, such as the unix socket.
+func (m *Manager) Follow(ctx context.Context, filter FollowFilter) (*Follower, error) {
+	sub, err := goubus.Subscribe(ctx, m.caller, "log")
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to subscribe to log")
+	}
+
+	follower := &Follower{
+		sub:     sub,
+		entries: make(chan Data, followBuffer),
+		stop:    make(chan struct{}),
+	}
+
+	go follower.run(filter)
+
+	return follower, nil
+}
+
+// Entries returns the channel of log entries. It is closed when the follower ends.
+func (f *Follower) Entries() <-chan Data {
+	return f.entries
+}
+
+// Err returns the error that ended the follower, if any.
+func (f *Follower) Err() error {
+	return f.sub.Err()
+}
+
+// Close stops following the log.
+func (f *Follower) Close() error {
+	f.once.Do(func() { close(f.stop) })
+
+	return f.sub.Close()
+}
+
+func (f *Follower) run(filter FollowFilter) {
+	defer close(f.entries)
+
+	for ev := range f.sub.Events() {
+		entry, ok := ParseEntry(ev)
+		if !ok || !filter.Match(entry) {
+			continue
+		}
+
+		select {
+		case f.entries <- entry:
+		case <-f.stop:
+			return
+		}
+	}
+}
+
+// Match reports whether the filter accepts entry.
+func (ff FollowFilter) Match(entry Data) bool {
+	if len(ff.Severities) > 0 && !slices.Contains(ff.Severities, entry.Severity()) {
+		return false
+	}
+
+	return len(ff.Facilities) == 0 || slices.Contains(ff.Facilities, entry.Facility())
+}
+
+// ParseEntry decodes a log notification. It returns false for notifications without a message.
+func ParseEntry(ev goubus.Event) (Data, bool) {
+	var notification struct {
+		Msg string `json:"msg"`
+		Data
+	}
+
+	err := ev.Unmarshal(&notification)
+	if err != nil || notification.Msg == "" {
+		return Data{}, false
+	}
+
+	notification.Text = notification.Msg
+
+	return notification.Data, true
+}
@@ -5,18 +5,27 @@ package log
 
 import (
 	"context"
+	"strings"
 
 	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/file"
 )
 
 // Manager provides methods to interact with the system log.
 type Manager struct {
 	caller goubus.Transport
+	file   *file.Manager
 }
 
 // New creates a new base log Manager.
 func New(t goubus.Transport) *Manager {
-	return &Manager{caller: t}
+	return &Manager{caller: t, file: file.New(t)}
+}
+
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"log"}
 }
 
 // Read retrieves log entries.
@@ -30,7 +39,7 @@ func (m *Manager) Read(ctx context.Context, lines int, stream bool, oneshot bool
 	return goubus.Call[Log](ctx, m.caller, "log", "read", params)
 }
 
-// Write sends a log entry.
+// Write sends a log entry. logd records it at the info severity.
 func (m *Manager) Write(ctx context.Context, event string) error {
 	params := map[string]any{
 		"event": event,
@@ -39,3 +48,28 @@ func (m *Manager) Write(ctx context.Context, event string) error {
 
 	return err
 }
+
+// WritePriority sends a log entry with the given severity. logd's write method only records
+// info entries, so other severities are written with logger(1) through file exec and
+// the session needs exec permission for /usr/bin/logger.
+func (m *Manager) WritePriority(ctx context.Context, event string, priority Severity) error {
+	if priority < SeverityEmerg || priority > SeverityDebug {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid severity %d", priority)
+	}
+
+	if priority == SeverityInfo {
+		return m.Write(ctx, event)
+	}
+
+	res, err := m.file.Exec(ctx, "/usr/bin/logger", []string{"-p", "user." + priority.String(), "--", event}, nil)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to write %s log entry", priority)
+	}
+
+	if res.Code != 0 {
+		return errdefs.Wrapf(errdefs.ErrUnknown, "logger exited with code %d: %s",
+			res.Code, strings.TrimSpace(res.Stderr))
+	}
+
+	return nil
+}
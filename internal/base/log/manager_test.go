@@ -5,8 +5,10 @@ package log_test
 
 import (
 	"context"
+	"slices"
 	"testing"
 
+	"github.com/honeybbq/goubus/v2/errdefs"
 	logpkg "github.com/honeybbq/goubus/v2/internal/base/log"
 	"github.com/honeybbq/goubus/v2/internal/testutil"
 )
@@ -39,4 +41,64 @@ func TestLogManager(t *testing.T) {
 			t.Errorf("unexpected log data: %+v", log)
 		}
 	})
+	t.Run("Follow_Filtered", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mgr := logpkg.New(mock)
+
+		follower, err := mgr.Follow(ctx, logpkg.FollowFilter{
+			Severities: logpkg.SeverityAtLeast(logpkg.SeverityWarning),
+			Facilities: []logpkg.Facility{logpkg.FacilityDaemon},
+		})
+		if err != nil {
+			t.Fatalf("Follow failed: %v", err)
+		}
+
+		defer func() { _ = follower.Close() }()
+
+		// daemon.info, kern.err and daemon.err, encoded as facility<<3 | severity.
+		for _, priority := range []int{30, 3, 27} {
+			mock.Emit("log", "message", map[string]any{"msg": "dnsmasq: entry", "priority": priority, "id": priority})
+		}
+
+		entry := <-follower.Entries()
+		if entry.ID != 27 || entry.Text != "dnsmasq: entry" || entry.Severity() != logpkg.SeverityErr {
+			t.Errorf("unexpected entry: %+v", entry)
+		}
+
+		_ = follower.Close()
+
+		for range follower.Entries() {
+			t.Error("unexpected entry after Close")
+		}
+	})
+	t.Run("WritePriority", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("log", "write", map[string]any{})
+		mock.AddResponse("file", "exec", map[string]any{"code": 0})
+
+		mgr := logpkg.New(mock)
+
+		err := mgr.WritePriority(ctx, "audit: info", logpkg.SeverityInfo)
+		if err != nil || mock.GetLastCall().Method != "write" {
+			t.Fatalf("expected log write for info, got %v, %+v", err, mock.GetLastCall())
+		}
+
+		err = mgr.WritePriority(ctx, "-audit: warn", logpkg.SeverityWarning)
+		if err != nil {
+			t.Fatalf("WritePriority failed: %v", err)
+		}
+
+		params, _ := mock.GetLastCall().Data.(map[string]any)
+		args, _ := params["params"].([]string)
+
+		want := []string{"-p", "user.warning", "--", "-audit: warn"}
+		if params["command"] != "/usr/bin/logger" || !slices.Equal(args, want) {
+			t.Errorf("unexpected logger call: %+v", params)
+		}
+
+		err = mgr.WritePriority(ctx, "x", logpkg.Severity(9))
+		if !errdefs.IsInvalidParameter(err) {
+			t.Errorf("expected invalid parameter, got %v", err)
+		}
+	})
 }
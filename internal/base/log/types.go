@@ -10,6 +10,95 @@ type Log struct {
 
 // Data represents a single log entry.
 type Data struct {
-	Text string `json:"text"`
-	Time int    `json:"time"`
+	Text     string `json:"text"`
+	Time     int    `json:"time"`
+	ID       int    `json:"id,omitempty"`
+	Priority int    `json:"priority,omitempty"`
+	Source   int    `json:"source,omitempty"`
+}
+
+// Severity returns the syslog severity encoded in the priority.
+func (d Data) Severity() Severity {
+	return Severity(d.Priority & severityMask)
+}
+
+// Facility returns the syslog facility encoded in the priority.
+func (d Data) Facility() Facility {
+	return Facility(d.Priority >> facilityShift)
+}
+
+const (
+	severityMask  = 0x07
+	facilityShift = 3
+)
+
+// Severity is a syslog severity; lower values are more severe.
+type Severity int
+
+const (
+	SeverityEmerg Severity = iota
+	SeverityAlert
+	SeverityCrit
+	SeverityErr
+	SeverityWarning
+	SeverityNotice
+	SeverityInfo
+	SeverityDebug
+)
+
+var severityNames = [...]string{"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug"}
+
+// String returns the syslog name of the severity, e.g. "warning".
+func (s Severity) String() string {
+	if s < SeverityEmerg || s > SeverityDebug {
+		return "unknown"
+	}
+
+	return severityNames[s]
+}
+
+// Facility is a syslog facility.
+type Facility int
+
+const (
+	FacilityKern Facility = iota
+	FacilityUser
+	FacilityMail
+	FacilityDaemon
+	FacilityAuth
+	FacilitySyslog
+	FacilityLPR
+	FacilityNews
+	FacilityUUCP
+	FacilityCron
+	FacilityAuthPriv
+	FacilityFTP
+)
+
+// FacilityLocal0 to FacilityLocal7 are reserved for local use.
+const (
+	FacilityLocal0 Facility = iota + 16
+	FacilityLocal1
+	FacilityLocal2
+	FacilityLocal3
+	FacilityLocal4
+	FacilityLocal5
+	FacilityLocal6
+	FacilityLocal7
+)
+
+// FollowFilter selects the entries delivered by Follow. Empty lists match everything.
+type FollowFilter struct {
+	Severities []Severity
+	Facilities []Facility
+}
+
+// SeverityAtLeast returns the severities at least as severe as s, for use in a FollowFilter.
+func SeverityAtLeast(s Severity) []Severity {
+	severities := make([]Severity, 0, s+1)
+	for level := SeverityEmerg; level <= s; level++ {
+		severities = append(severities, level)
+	}
+
+	return severities
 }
@@ -26,6 +26,11 @@ func New(t goubus.Transport, d Dialect) *Manager {
 	return &Manager{caller: t, dialect: d}
 }
 
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"luci", "luci-rpc", "file"}
+}
+
 // GetVersion retrieves the LuCI version information from the device.
 func (m *Manager) GetVersion(ctx context.Context) (*Version, error) {
 	return goubus.Call[Version](ctx, m.caller, "luci", "getVersion", nil)
@@ -130,27 +135,22 @@ func (m *Manager) GetFeatures(ctx context.Context) (map[string]goubus.Bool, erro
 }
 
 // GetSwconfigFeatures retrieves features for a swconfig switch.
-func (m *Manager) GetSwconfigFeatures(ctx context.Context, switchName string) (map[string]any, error) {
+func (m *Manager) GetSwconfigFeatures(ctx context.Context, switchName string) (*SwconfigFeatures, error) {
 	params := map[string]any{"switch": switchName}
 
-	res, err := goubus.Call[map[string]any](ctx, m.caller, "luci", "getSwconfigFeatures", params)
-	if err != nil {
-		return nil, err
-	}
-
-	return *res, nil
+	return goubus.Call[SwconfigFeatures](ctx, m.caller, "luci", "getSwconfigFeatures", params)
 }
 
 // GetSwconfigPortState retrieves the port state for a swconfig switch.
-func (m *Manager) GetSwconfigPortState(ctx context.Context, switchName string) (map[string]any, error) {
+func (m *Manager) GetSwconfigPortState(ctx context.Context, switchName string) ([]SwconfigPort, error) {
 	params := map[string]any{"switch": switchName}
 
-	res, err := goubus.Call[map[string]any](ctx, m.caller, "luci", "getSwconfigPortState", params)
+	res, err := goubus.Call[swconfigPortState](ctx, m.caller, "luci", "getSwconfigPortState", params)
 	if err != nil {
 		return nil, err
 	}
 
-	return *res, nil
+	return res.Result, nil
 }
 
 // SetPassword sets the password for a system user.
@@ -271,9 +271,15 @@ func (m *Manager) GetHostHints(ctx context.Context) (map[string]HostHint, error)
 	return *res, nil
 }
 
-// GetDUIDHints retrieves DUID hint information.
-func (m *Manager) GetDUIDHints(ctx context.Context) (map[string]any, error) {
-	res, err := goubus.Call[map[string]any](ctx, m.caller, "luci-rpc", "getDUIDHints", nil)
+// LazyHostHints retrieves host hint information, decoding each host only when it is accessed.
+// It is intended for large networks where materializing every hint up front is costly.
+func (m *Manager) LazyHostHints(ctx context.Context) (*goubus.LazyMap[HostHint], error) {
+	return goubus.Call[goubus.LazyMap[HostHint]](ctx, m.caller, "luci-rpc", "getHostHints", nil)
+}
+
+// GetDUIDHints retrieves the DHCPv6 clients odhcpd knows, keyed by DUID.
+func (m *Manager) GetDUIDHints(ctx context.Context) (map[string]DUIDHint, error) {
+	res, err := goubus.Call[map[string]DUIDHint](ctx, m.caller, "luci-rpc", "getDUIDHints", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -5,8 +5,11 @@ package luci_test
 
 import (
 	"context"
+	"slices"
+	"strings"
 	"testing"
 
+	"github.com/honeybbq/goubus/v2/errdefs"
 	"github.com/honeybbq/goubus/v2/internal/base/luci"
 	"github.com/honeybbq/goubus/v2/internal/testutil"
 )
@@ -26,6 +29,8 @@ func TestLuciManager(t *testing.T) {
 	testLuciGetInitList(t, ctx, mock)
 	testLuciGetTimezones(t, ctx, mock)
 	testLuciGetHostHints(t, ctx, mock)
+	testLuciSwconfig(t, ctx, mock)
+	testLuciWol(t, ctx, mock)
 }
 
 func testLuciGetVersion(t *testing.T, ctx context.Context, mock *testutil.MockTransport) {
@@ -157,4 +162,102 @@ func testLuciGetHostHints(t *testing.T, ctx context.Context, mock *testutil.Mock
 			t.Error("unexpected host hints")
 		}
 	})
+
+	t.Run("LuciRPC_LazyHints", func(t *testing.T) {
+		mgr := luci.New(mock, mockLuciDialect{method: "getUnixtime"})
+
+		hints, err := mgr.LazyHostHints(ctx)
+		if err != nil {
+			t.Fatalf("LazyHostHints failed: %v", err)
+		}
+
+		hint, err := hints.Get("00:11:22:33:44:55")
+		if err != nil || hint.Name != "test-host" || hints.Len() != 1 {
+			t.Errorf("unexpected host hint: %+v (%v)", hint, err)
+		}
+	})
+}
+
+func testLuciSwconfig(t *testing.T, ctx context.Context, mock *testutil.MockTransport) {
+	t.Helper()
+	t.Run("Swconfig", func(t *testing.T) {
+		mock.AddResponse("luci", "getSwconfigFeatures", map[string]any{
+			"switch_title": "mt7530", "num_vlans": "4095", "min_vid": 1,
+			"vid_option": "vid", "learning_option": "enable_learning",
+		})
+		mock.AddResponse("luci", "getSwconfigPortState", map[string]any{"result": []any{
+			map[string]any{"port": 0, "link": true, "speed": 1000, "duplex": true},
+			map[string]any{"port": 1, "link": false},
+		}})
+		mock.AddResponse("luci-rpc", "getDUIDHints", map[string]any{
+			"000100012b3c4d5e001122334455": map[string]any{"hostname": "laptop", "ip6addr": "fd00::5"},
+		})
+
+		mgr := luci.New(mock, mockLuciDialect{method: "getUnixtime"})
+
+		features, err := mgr.GetSwconfigFeatures(ctx, "switch0")
+		if err != nil {
+			t.Fatalf("GetSwconfigFeatures failed: %v", err)
+		}
+
+		if vlans, _ := features.NumVLANs.Int64(); vlans != 4095 || features.MinVID != "1" || features.MirrorOption != "" {
+			t.Errorf("unexpected features: %+v", features)
+		}
+
+		ports, err := mgr.GetSwconfigPortState(ctx, "switch0")
+		if err != nil {
+			t.Fatalf("GetSwconfigPortState failed: %v", err)
+		}
+
+		if len(ports) != 2 || !bool(ports[0].Link) || ports[0].Speed != 1000 || bool(ports[1].Link) {
+			t.Errorf("unexpected ports: %+v", ports)
+		}
+
+		duids, err := mgr.GetDUIDHints(ctx)
+		if err != nil {
+			t.Fatalf("GetDUIDHints failed: %v", err)
+		}
+
+		if hint := duids["000100012b3c4d5e001122334455"]; hint.Hostname != "laptop" || hint.IP6Addr != "fd00::5" {
+			t.Errorf("unexpected DUID hints: %+v", duids)
+		}
+	})
+}
+
+func testLuciWol(t *testing.T, ctx context.Context, mock *testutil.MockTransport) {
+	t.Helper()
+	t.Run("Wol", func(t *testing.T) {
+		mgr := luci.New(mock, mockLuciDialect{method: "getUnixtime"})
+		mock.AddResponse("file", "exec", map[string]any{"code": 0})
+
+		err := mgr.Wol(ctx, "AA-BB-CC-DD-EE-01", luci.WakeOptions{Interface: "br-lan", Broadcast: true})
+		if err != nil {
+			t.Fatalf("Wol failed: %v", err)
+		}
+
+		params, _ := mock.GetLastCall().Data.(map[string]any)
+		if params["command"] != "/usr/bin/etherwake" ||
+			!slices.Equal(params["params"].([]string), []string{"-i", "br-lan", "-b", "aa:bb:cc:dd:ee:01"}) {
+			t.Errorf("unexpected etherwake call: %+v", params)
+		}
+
+		mock.AddResponse("file", "exec", map[string]any{"code": 1, "stderr": "etherwake: bad interface\n"})
+
+		err = mgr.Wol(ctx, "aa:bb:cc:dd:ee:01", luci.WakeOptions{Interface: "eth9"})
+		if err == nil || !strings.Contains(err.Error(), "bad interface") {
+			t.Errorf("expected the etherwake failure to be reported, got %v", err)
+		}
+
+		for _, mac := range []string{"aa:bb:cc", "01:00:5e:00:00:01"} {
+			err = mgr.Wol(ctx, mac, luci.WakeOptions{})
+			if !errdefs.IsInvalidParameter(err) {
+				t.Errorf("expected %s to be rejected, got %v", mac, err)
+			}
+		}
+
+		packet, err := luci.MagicPacket("aa:bb:cc:dd:ee:01")
+		if err != nil || len(packet) != 102 || packet[5] != 0xff || packet[6] != 0xaa || packet[101] != 0x01 {
+			t.Errorf("unexpected magic packet: %x, %v", packet, err)
+		}
+	})
 }
@@ -4,6 +4,8 @@
 package luci
 
 import (
+	"encoding/json"
+
 	"github.com/honeybbq/goubus/v2"
 	"github.com/honeybbq/goubus/v2/internal/base/dhcp"
 	"github.com/honeybbq/goubus/v2/internal/base/network"
@@ -96,9 +98,36 @@ type HostHint struct {
 
 // BoardJSON represents board hardware information.
 type BoardJSON struct {
-	WLAN    map[string]BoardWLAN `json:"wlan"`
-	Network BoardNetwork         `json:"network"`
-	Model   BoardModel           `json:"model"`
+	WLAN    map[string]BoardWLAN   `json:"wlan"`
+	Switch  map[string]BoardSwitch `json:"switch,omitempty"`
+	Network BoardNetwork           `json:"network"`
+	Model   BoardModel             `json:"model"`
+}
+
+// BoardSwitch represents the default configuration of a swconfig switch.
+type BoardSwitch struct {
+	Ports  []BoardSwitchPort `json:"ports"`
+	Roles  []BoardSwitchRole `json:"roles"`
+	Enable goubus.Bool       `json:"enable"`
+	Reset  goubus.Bool       `json:"reset"`
+}
+
+// BoardSwitchPort represents a switch port, which is either assigned a role or wired to the CPU
+// through Device.
+type BoardSwitchPort struct {
+	Role      string      `json:"role,omitempty"`
+	Device    string      `json:"device,omitempty"`
+	Num       int         `json:"num"`
+	Index     int         `json:"index,omitempty"`
+	NeedTag   goubus.Bool `json:"need_tag,omitempty"`
+	WantUntag goubus.Bool `json:"want_untag,omitempty"`
+}
+
+// BoardSwitchRole represents the ports of a switch role, e.g. "1 2 3 4 6t" for "lan".
+type BoardSwitchRole struct {
+	Role   string `json:"role"`
+	Ports  string `json:"ports"`
+	Device string `json:"device"`
 }
 
 // BoardModel represents board model information.
@@ -145,6 +174,45 @@ type BoardWLANBand struct {
 	HE             goubus.Bool `json:"he,omitempty"`
 }
 
+// DUIDHint represents a DHCPv6 client known by its DUID.
+type DUIDHint struct {
+	Hostname string `json:"hostname,omitempty"`
+	MACAddr  string `json:"macaddr,omitempty"`
+	IP6Addr  string `json:"ip6addr,omitempty"`
+}
+
+// SwconfigFeatures represents the features of a swconfig switch, as parsed from its help
+// output. The option fields name the switch attributes implementing a feature and are
+// empty when the switch lacks it.
+type SwconfigFeatures struct {
+	Title          string `json:"switch_title,omitempty"`
+	VIDOption      string `json:"vid_option,omitempty"`
+	VLANOption     string `json:"vlan_option,omitempty"`
+	VLAN4KOption   string `json:"vlan4k_option,omitempty"`
+	LearningOption string `json:"learning_option,omitempty"`
+	MirrorOption   string `json:"mirror_option,omitempty"`
+	JumboOption    string `json:"jumbo_option,omitempty"`
+	// NumVLANs and MinVID are reported as numbers or numeric strings.
+	NumVLANs json.Number `json:"num_vlans,omitempty"`
+	MinVID   json.Number `json:"min_vid,omitempty"`
+}
+
+// SwconfigPort represents the link state of a swconfig switch port.
+type SwconfigPort struct {
+	Port   int         `json:"port"`
+	Speed  int         `json:"speed,omitempty"`
+	Link   goubus.Bool `json:"link"`
+	Duplex goubus.Bool `json:"duplex,omitempty"`
+	Auto   goubus.Bool `json:"auto,omitempty"`
+	RxFlow goubus.Bool `json:"rxflow,omitempty"`
+	TxFlow goubus.Bool `json:"txflow,omitempty"`
+}
+
+// swconfigPortState is the response of luci getSwconfigPortState.
+type swconfigPortState struct {
+	Result []SwconfigPort `json:"result"`
+}
+
 // DHCPLeases is a re-export or alias for dhcp.Leases.
 type DHCPLeases = dhcp.Leases
 
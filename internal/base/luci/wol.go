@@ -0,0 +1,92 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package luci
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/file"
+)
+
+const (
+	etherwakeBinary = "/usr/bin/etherwake"
+	// magicPacketRepeats is how often the target MAC address follows the sync stream.
+	magicPacketRepeats = 16
+	macAddressLength   = 6
+)
+
+// WakeOptions configures Wol.
+type WakeOptions struct {
+	// Interface is the device to send the packet on, e.g. "br-lan". Empty uses the default
+	// of the sender.
+	Interface string
+	// Broadcast sends the packet to the broadcast address instead of the target MAC.
+	Broadcast bool
+}
+
+// Wol wakes the host with the given MAC address by running etherwake via file.exec, like
+// luci-app-wol; that needs the etherwake package and exec permission for /usr/bin/etherwake.
+func (m *Manager) Wol(ctx context.Context, mac string, opts WakeOptions) error {
+	hw, err := parseWakeMAC(mac)
+	if err != nil {
+		return err
+	}
+
+	return m.etherwake(ctx, hw, opts)
+}
+
+func (m *Manager) etherwake(ctx context.Context, hw net.HardwareAddr, opts WakeOptions) error {
+	var args []string
+	if opts.Interface != "" {
+		args = append(args, "-i", opts.Interface)
+	}
+
+	if opts.Broadcast {
+		args = append(args, "-b")
+	}
+
+	args = append(args, hw.String())
+
+	res, err := file.New(m.caller).Exec(ctx, etherwakeBinary, args, nil)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to run etherwake")
+	}
+
+	if res.Code != 0 {
+		return errdefs.Wrapf(errdefs.ErrUnknown, "etherwake exited with code %d: %s",
+			res.Code, strings.TrimSpace(res.Stderr))
+	}
+
+	return nil
+}
+
+// MagicPacket returns the Wake-on-LAN payload for a MAC address: six 0xff bytes followed by
+// the address repeated 16 times, e.g. to send it over UDP port 9 from the client side.
+func MagicPacket(mac string) ([]byte, error) {
+	hw, err := parseWakeMAC(mac)
+	if err != nil {
+		return nil, err
+	}
+
+	packet := bytes.Repeat([]byte{0xff}, macAddressLength)
+
+	return append(packet, bytes.Repeat(hw, magicPacketRepeats)...), nil
+}
+
+func parseWakeMAC(mac string) (net.HardwareAddr, error) {
+	hw, err := net.ParseMAC(mac)
+	if err != nil || len(hw) != macAddressLength {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid MAC address %q", mac)
+	}
+
+	if hw[0]&1 != 0 {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "%s is a multicast address", mac)
+	}
+
+	return hw, nil
+}
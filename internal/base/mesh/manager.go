@@ -0,0 +1,228 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package mesh
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/file"
+	"github.com/honeybbq/goubus/v2/internal/base/wireless"
+)
+
+const (
+	iwBinary     = "/usr/sbin/iw"
+	batctlBinary = "/usr/sbin/batctl"
+
+	// DefaultMeshInterface is the batman-adv soft interface OpenWrt creates by default.
+	DefaultMeshInterface = "bat0"
+
+	meshMode = "mesh"
+)
+
+// Manager reports the state of 802.11s mesh interfaces and of batman-adv. Peer links come from
+// iwinfo; paths and the batman-adv tables have no ubus interface and are read by running iw and
+// batctl through rpcd's file.exec, which needs exec permission for them.
+type Manager struct {
+	caller   goubus.Transport
+	wireless *wireless.Manager
+	file     *file.Manager
+}
+
+// New creates a new base mesh Manager.
+func New(t goubus.Transport) *Manager {
+	return &Manager{
+		caller:   t,
+		wireless: wireless.New(t),
+		file:     file.New(t),
+	}
+}
+
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"network.wireless", "iwinfo", "file"}
+}
+
+// Interfaces lists the configured 802.11s mesh points, sorted by interface name.
+func (m *Manager) Interfaces(ctx context.Context) ([]Interface, error) {
+	status, err := goubus.Call[wirelessStatusResponse](ctx, m.caller, "network.wireless", "status", nil)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read the wireless status")
+	}
+
+	ifaces := []Interface{}
+
+	for radio, rs := range status.Radio {
+		for _, iface := range rs.Interfaces {
+			if iface.Config.Mode != meshMode {
+				continue
+			}
+
+			ifaces = append(ifaces, Interface{
+				Section: iface.Section,
+				Radio:   radio,
+				Ifname:  iface.Ifname,
+				MeshID:  iface.Config.MeshID,
+				Up:      rs.Up,
+			})
+		}
+	}
+
+	slices.SortFunc(ifaces, func(a, b Interface) int {
+		return strings.Compare(a.Ifname+"/"+a.Section, b.Ifname+"/"+b.Section)
+	})
+
+	return ifaces, nil
+}
+
+// Peers returns the mesh peer links of a mesh interface such as "phy0-mesh0".
+func (m *Manager) Peers(ctx context.Context, ifname string) ([]Peer, error) {
+	assocs, err := m.wireless.AssocList(ctx, ifname)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read the peers of %s", ifname)
+	}
+
+	peers := []Peer{}
+
+	for _, assoc := range assocs {
+		if assoc.MeshPlink == "" {
+			continue
+		}
+
+		peers = append(peers, Peer{
+			MAC:                assoc.Mac,
+			PLinkState:         assoc.MeshPlink,
+			LocalPS:            assoc.MeshLocalPS,
+			PeerPS:             assoc.MeshPeerPS,
+			NonPeerPS:          assoc.MeshNonPeerPS,
+			LLID:               assoc.MeshLLID,
+			PLID:               assoc.MeshPLID,
+			Signal:             assoc.Signal,
+			Inactive:           assoc.Inactive,
+			RxRate:             assoc.Rx.Rate,
+			TxRate:             assoc.Tx.Rate,
+			ExpectedThroughput: assoc.ExpectedThroughput,
+		})
+	}
+
+	return peers, nil
+}
+
+// Paths returns the HWMP path table of a mesh interface by running "iw dev <ifname> mpath dump".
+func (m *Manager) Paths(ctx context.Context, ifname string) ([]Path, error) {
+	out, err := m.exec(ctx, iwBinary, "dev", ifname, "mpath", "dump")
+	if err != nil {
+		return nil, err
+	}
+
+	return ParsePaths(out), nil
+}
+
+// Originators returns the batman-adv originator table of the soft interface meshif, e.g.
+// DefaultMeshInterface. It needs batctl 2021.0 or newer for its JSON output.
+func (m *Manager) Originators(ctx context.Context, meshif string) ([]Originator, error) {
+	return batctl[Originator](ctx, m, meshif, "originators_json")
+}
+
+// Neighbors returns the batman-adv neighbor table of the soft interface meshif.
+func (m *Manager) Neighbors(ctx context.Context, meshif string) ([]Neighbor, error) {
+	return batctl[Neighbor](ctx, m, meshif, "neighbors_json")
+}
+
+// batctl runs a JSON table command of batctl on meshif and decodes its output.
+func batctl[T any](ctx context.Context, m *Manager, meshif, command string) ([]T, error) {
+	if meshif == "" {
+		meshif = DefaultMeshInterface
+	}
+
+	out, err := m.exec(ctx, batctlBinary, "meshif", meshif, command)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []T{}
+
+	err = json.Unmarshal([]byte(out), &entries)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to decode batctl %s output", command)
+	}
+
+	return entries, nil
+}
+
+// exec runs a command on the device and returns its output, failing on a non-zero exit code.
+func (m *Manager) exec(ctx context.Context, command string, args ...string) (string, error) {
+	res, err := m.file.Exec(ctx, command, args, nil)
+	if err != nil {
+		return "", errdefs.Wrapf(err, "failed to run %s", command)
+	}
+
+	if res.Code != 0 {
+		return "", errdefs.Wrapf(errdefs.ErrUnknown, "%s exited with code %d: %s",
+			command, res.Code, strings.TrimSpace(res.Stderr))
+	}
+
+	return res.Stdout, nil
+}
+
+// ParsePaths parses the output of "iw dev <ifname> mpath dump". Columns are matched by their
+// header, so the output of iw versions without the hop count also parses.
+func ParsePaths(out string) []Path {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	paths := []Path{}
+
+	if len(lines) == 0 || !strings.HasPrefix(lines[0], "DEST ADDR") {
+		return paths
+	}
+
+	header := strings.NewReplacer("DEST ADDR", "DEST_ADDR", "NEXT HOP", "NEXT_HOP").Replace(lines[0])
+	columns := strings.Fields(header)
+
+	for _, line := range lines[1:] {
+		fields := strings.Fields(line)
+		if len(fields) < len(columns) {
+			continue
+		}
+
+		var path Path
+		for i, column := range columns {
+			path.set(column, fields[i])
+		}
+
+		paths = append(paths, path)
+	}
+
+	return paths
+}
+
+// set assigns the value of a column of the mpath dump.
+func (p *Path) set(column, value string) {
+	number, _ := strconv.ParseInt(value, 10, 64)
+
+	switch column {
+	case "DEST_ADDR":
+		p.Destination = value
+	case "NEXT_HOP":
+		p.NextHop = value
+	case "IFACE":
+		p.Ifname = value
+	case "SN":
+		p.SN = number
+	case "METRIC":
+		p.Metric = int(number)
+	case "QLEN":
+		p.QLen = int(number)
+	case "EXPTIME":
+		p.ExpTime = int(number)
+	case "FLAGS":
+		p.Flags = value
+	case "HOP_COUNT":
+		p.HopCount = int(number)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package mesh_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/mesh"
+	"github.com/honeybbq/goubus/v2/internal/testutil"
+)
+
+const mpathDump = "DEST ADDR         NEXT HOP          IFACE\tSN\tMETRIC\tQLEN\tEXPTIME\t\tDTIM\tDRET\tFLAGS\t" +
+	"HOP_COUNT\tPATH_CHANGE\n" + `02:00:00:00:00:02 02:00:00:00:00:02 phy0-mesh0	12	171	0	4720	100	0	0x15	1	2
+02:00:00:00:00:03 02:00:00:00:00:02 phy0-mesh0	8	342	0	3010	100	0	0x15	2	1
+`
+
+func TestMeshManager(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Interfaces", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("network.wireless", "status", map[string]any{"radio": map[string]any{
+			"radio0": map[string]any{"up": true, "interfaces": []map[string]any{
+				{"section": "default_radio0", "ifname": "phy0-ap0", "config": map[string]any{"mode": "ap"}},
+				{"section": "mesh0", "ifname": "phy0-mesh0", "config": map[string]any{"mode": "mesh", "mesh_id": "backhaul"}},
+			}},
+		}})
+
+		ifaces, err := mesh.New(mock).Interfaces(ctx)
+		if err != nil {
+			t.Fatalf("Interfaces failed: %v", err)
+		}
+
+		if len(ifaces) != 1 || ifaces[0].MeshID != "backhaul" || ifaces[0].Radio != "radio0" || !ifaces[0].Up {
+			t.Errorf("unexpected interfaces: %+v", ifaces)
+		}
+	})
+
+	t.Run("Peers", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("iwinfo", "assoclist", map[string]any{"results": []map[string]any{
+			{"mac": "02:00:00:00:00:02", "signal": -48, "mesh plink": "ESTAB", "mesh llid": 1021, "thr": 250000},
+			{"mac": "02:00:00:00:00:09", "signal": -60},
+		}})
+
+		peers, err := mesh.New(mock).Peers(ctx, "phy0-mesh0")
+		if err != nil {
+			t.Fatalf("Peers failed: %v", err)
+		}
+
+		if len(peers) != 1 || peers[0].PLinkState != "ESTAB" || peers[0].LLID != 1021 ||
+			peers[0].ExpectedThroughput != 250000 {
+			t.Errorf("unexpected peers: %+v", peers)
+		}
+	})
+
+	t.Run("Paths", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("file", "exec", map[string]any{"code": 0, "stdout": mpathDump})
+
+		paths, err := mesh.New(mock).Paths(ctx, "phy0-mesh0")
+		if err != nil {
+			t.Fatalf("Paths failed: %v", err)
+		}
+
+		if len(paths) != 2 || paths[1].NextHop != "02:00:00:00:00:02" || paths[1].Metric != 342 ||
+			paths[1].HopCount != 2 || paths[0].Flags != "0x15" || paths[0].SN != 12 {
+			t.Errorf("unexpected paths: %+v", paths)
+		}
+	})
+
+	t.Run("Batman", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("file", "exec", map[string]any{"code": 0, "stdout": `[
+			{"hard_ifindex": 12, "hard_ifname": "phy0-mesh0", "orig_address": "02:00:00:00:00:03",
+			 "neigh_address": "02:00:00:00:00:02", "last_seen_msecs": 420, "tq": 230, "best": true}
+		]`})
+
+		mgr := mesh.New(mock)
+
+		originators, err := mgr.Originators(ctx, "")
+		if err != nil {
+			t.Fatalf("Originators failed: %v", err)
+		}
+
+		if len(originators) != 1 || originators[0].TQ != 230 || !originators[0].Best {
+			t.Errorf("unexpected originators: %+v", originators)
+		}
+
+		params, ok := mock.GetLastCall().Data.(map[string]any)["params"].([]string)
+		if !ok || len(params) != 3 || params[1] != mesh.DefaultMeshInterface {
+			t.Errorf("unexpected batctl call: %+v", mock.GetLastCall())
+		}
+
+		mock.AddResponse("file", "exec", map[string]any{"code": 1, "stderr": "Error - batman-adv module has not been loaded"})
+
+		_, err = mgr.Neighbors(ctx, "bat0")
+		if !errdefs.IsUnknown(err) {
+			t.Errorf("expected a failed batctl run to fail, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package mesh
+
+// Interface is an 802.11s mesh point configured on a radio.
+type Interface struct {
+	// Section is the wifi-iface section of the interface.
+	Section string `json:"section"`
+	Radio   string `json:"radio"`
+	Ifname  string `json:"ifname"`
+	MeshID  string `json:"mesh_id"`
+	// Up reports whether the radio of the interface is up.
+	Up bool `json:"up"`
+}
+
+// Peer is a mesh peer link of an interface as reported by iwinfo.
+type Peer struct {
+	MAC string `json:"mac"`
+	// PLinkState is the peer link state, "ESTAB" for an established link.
+	PLinkState string `json:"plink_state"`
+	// LocalPS, PeerPS and NonPeerPS are the mesh power save modes of the link.
+	LocalPS   string `json:"local_ps"`
+	PeerPS    string `json:"peer_ps"`
+	NonPeerPS string `json:"non_peer_ps"`
+	LLID      int    `json:"llid"`
+	PLID      int    `json:"plid"`
+	Signal    int    `json:"signal"`
+	// Inactive is the time since the last frame of the peer in milliseconds.
+	Inactive int `json:"inactive"`
+	// RxRate and TxRate are the current rates in kbit/s.
+	RxRate int `json:"rx_rate"`
+	TxRate int `json:"tx_rate"`
+	// ExpectedThroughput is the throughput estimated by the rate control algorithm in kbit/s.
+	ExpectedThroughput int `json:"expected_throughput"`
+}
+
+// Path is an entry of the HWMP path table of a mesh interface, as listed by "iw mpath dump".
+type Path struct {
+	Destination string `json:"destination"`
+	NextHop     string `json:"next_hop"`
+	Ifname      string `json:"ifname"`
+	// Flags is the hexadecimal path flag mask, e.g. "0x15".
+	Flags string `json:"flags"`
+	// SN is the sequence number of the destination.
+	SN     int64 `json:"sn"`
+	Metric int   `json:"metric"`
+	QLen   int   `json:"qlen"`
+	// ExpTime is the time until the path expires in milliseconds.
+	ExpTime int `json:"exptime"`
+	// HopCount is zero when iw is too old to report it.
+	HopCount int `json:"hop_count"`
+}
+
+// Originator is an entry of the batman-adv originator table.
+type Originator struct {
+	// Address is the originator, NextHop the neighbor it is reached through on HardIf.
+	Address string `json:"orig_address"`
+	NextHop string `json:"neigh_address"`
+	HardIf  string `json:"hard_ifname"`
+	// LastSeen is the time since the last originator message in milliseconds.
+	LastSeen int `json:"last_seen_msecs"`
+	// TQ is the BATMAN IV transmit quality from 0 to 255.
+	TQ int `json:"tq,omitempty"`
+	// Throughput is the BATMAN V throughput estimate in 100 kbit/s.
+	Throughput int `json:"throughput,omitempty"`
+	// Best marks the route used for the originator.
+	Best bool `json:"best"`
+}
+
+// Neighbor is an entry of the batman-adv neighbor table.
+type Neighbor struct {
+	Address string `json:"neigh_address"`
+	HardIf  string `json:"hard_ifname"`
+	// LastSeen is the time since the last message of the neighbor in milliseconds.
+	LastSeen int `json:"last_seen_msecs"`
+	// Throughput is the BATMAN V throughput estimate in 100 kbit/s.
+	Throughput int `json:"throughput,omitempty"`
+}
+
+// wirelessStatusResponse is the part of the network.wireless status listing mesh interfaces.
+type wirelessStatusResponse struct {
+	Radio map[string]radioStatus `json:"radio"`
+}
+
+type radioStatus struct {
+	Interfaces []radioInterface `json:"interfaces"`
+	Up         bool             `json:"up"`
+}
+
+type radioInterface struct {
+	Section string `json:"section"`
+	Ifname  string `json:"ifname"`
+	Config  struct {
+		Mode   string `json:"mode"`
+		MeshID string `json:"mesh_id"`
+	} `json:"config"`
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package modem
+
+import (
+	"context"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/file"
+	"github.com/honeybbq/goubus/v2/internal/base/uci"
+)
+
+const uciPackage = "network"
+
+// Manager reports the state of LTE and other WWAN modems and controls their data connection.
+// A modem is addressed by the netifd interface configured for it, e.g. "wwan" with proto qmi or
+// modemmanager. Neither protocol has a ubus interface for the modem itself, so the status is
+// read by running uqmi or mmcli through rpcd's file.exec, which needs exec permission for them.
+type Manager struct {
+	caller goubus.Transport
+	file   *file.Manager
+	uci    *uci.Manager
+}
+
+// New creates a new base modem Manager.
+func New(t goubus.Transport) *Manager {
+	return &Manager{caller: t, file: file.New(t), uci: uci.New(t, nil)}
+}
+
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"uci", "file", "network.interface"}
+}
+
+// Status reads the registration, operator, signal and data connection state of the modem of
+// the interface iface.
+func (m *Manager) Status(ctx context.Context, iface string) (*Status, error) {
+	backend, device, err := m.modem(ctx, iface)
+	if err != nil {
+		return nil, err
+	}
+
+	var status *Status
+
+	if backend == BackendQMI {
+		status, err = m.qmiStatus(ctx, device)
+	} else {
+		status, err = m.mmStatus(ctx, device)
+	}
+
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read the modem status of %s", iface)
+	}
+
+	status.Interface, status.Backend, status.Device = iface, backend, device
+
+	return status, nil
+}
+
+// SIM reads the SIM card and modem identifiers of the modem of the interface iface.
+func (m *Manager) SIM(ctx context.Context, iface string) (*SIM, error) {
+	backend, device, err := m.modem(ctx, iface)
+	if err != nil {
+		return nil, err
+	}
+
+	var sim *SIM
+
+	if backend == BackendQMI {
+		sim, err = m.qmiSIM(ctx, device)
+	} else {
+		sim, err = m.mmSIM(ctx, device)
+	}
+
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read the SIM of %s", iface)
+	}
+
+	return sim, nil
+}
+
+// Connect brings the interface iface up, letting its netifd protocol establish the data
+// connection with the APN and credentials it is configured with.
+func (m *Manager) Connect(ctx context.Context, iface string) error {
+	_, _, err := m.modem(ctx, iface)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.caller.Call(ctx, "network.interface."+iface, "up", nil)
+
+	return err
+}
+
+// Disconnect takes the interface iface down, which ends its data connection.
+func (m *Manager) Disconnect(ctx context.Context, iface string) error {
+	_, _, err := m.modem(ctx, iface)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.caller.Call(ctx, "network.interface."+iface, "down", nil)
+
+	return err
+}
+
+// modem returns the backend and device of the modem interface iface.
+func (m *Manager) modem(ctx context.Context, iface string) (string, string, error) {
+	section, err := m.uci.Package(uciPackage).Section(iface).Get(ctx)
+	if err != nil {
+		return "", "", errdefs.Wrapf(err, "interface %s", iface)
+	}
+
+	proto := section.GetString("proto")
+	if proto != BackendQMI && proto != BackendModemManager {
+		return "", "", errdefs.Wrapf(errdefs.ErrNotSupported,
+			"interface %s uses protocol %q, not %s or %s", iface, proto, BackendQMI, BackendModemManager)
+	}
+
+	device := section.GetString("device")
+	if device == "" {
+		return "", "", errdefs.Wrapf(errdefs.ErrInvalidParameter, "interface %s has no modem device", iface)
+	}
+
+	return proto, device, nil
+}
+
+// run runs a command on the device and returns its output, failing on a non-zero exit code.
+func (m *Manager) run(ctx context.Context, command string, args ...string) (string, error) {
+	res, err := m.file.Exec(ctx, command, args, nil)
+	if err != nil {
+		return "", errdefs.Wrapf(err, "failed to run %s", command)
+	}
+
+	if res.Code != 0 {
+		return "", errdefs.Wrapf(errdefs.ErrUnknown, "%s exited with code %d: %s",
+			command, res.Code, strings.TrimSpace(res.Stderr))
+	}
+
+	return res.Stdout, nil
+}
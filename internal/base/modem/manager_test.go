@@ -0,0 +1,134 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package modem_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/modem"
+	"github.com/honeybbq/goubus/v2/internal/testutil"
+)
+
+func TestModemManager(t *testing.T) {
+	ctx := context.Background()
+
+	newMock := func(proto, device string) (*testutil.MockTransport, func(stdout string, params ...string)) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("uci", "get", map[string]any{"values": map[string]any{
+			".type": "interface", ".name": "wwan", "proto": proto, "device": device, "apn": "cmnet",
+		}})
+
+		exec := func(stdout string, params ...string) {
+			command := "/sbin/uqmi"
+			if proto == modem.BackendModemManager {
+				command = "/usr/bin/mmcli"
+			}
+
+			mock.AddResponseForArgs("file", "exec", map[string]any{"command": command, "params": params},
+				map[string]any{"code": 0, "stdout": stdout})
+		}
+
+		return mock, exec
+	}
+
+	t.Run("QMI", func(t *testing.T) {
+		mock, exec := newMock("qmi", "/dev/cdc-wdm0")
+		uqmi := func(option, stdout string) { exec(stdout, "-s", "-d", "/dev/cdc-wdm0", option) }
+
+		uqmi("--get-serving-system",
+			`{"registration":"registered","plmn_mcc":460,"plmn_mnc":0,"plmn_description":"CMCC","roaming":false}`)
+		uqmi("--get-signal-info", `{"type":"lte","rssi":-67,"rsrq":-9,"rsrp":-95,"snr":12.4}`)
+		uqmi("--get-data-status", "\"connected\"\n")
+		uqmi("--get-iccid", `"89860012345678901234"`)
+		uqmi("--get-imsi", `"460001234567890"`)
+		uqmi("--get-imei", `"861234567890123"`)
+
+		mgr := modem.New(mock)
+
+		status, err := mgr.Status(ctx, "wwan")
+		if err != nil {
+			t.Fatalf("Status failed: %v", err)
+		}
+
+		if status.Registration != modem.RegistrationRegistered || status.Operator.MNC != "00" ||
+			status.Operator.Name != "CMCC" || status.Technology != "lte" || !status.Connected ||
+			status.Signal.RSRP != -95 || status.Signal.Quality != 74 || status.Backend != modem.BackendQMI {
+			t.Errorf("unexpected status: %+v", status)
+		}
+
+		// A modem without the PIN status still reports its SIM.
+		sim, err := mgr.SIM(ctx, "wwan")
+		if err != nil {
+			t.Fatalf("SIM failed: %v", err)
+		}
+
+		if sim.ICCID != "89860012345678901234" || sim.IMEI != "861234567890123" || sim.PINStatus != "" {
+			t.Errorf("unexpected SIM: %+v", sim)
+		}
+	})
+
+	t.Run("ModemManager", func(t *testing.T) {
+		device := "/sys/devices/platform/soc/11200000.usb/usb1/1-1"
+		mock, exec := newMock("modemmanager", device)
+
+		exec(`{"modem": {"generic": {"state": "connected", "signal-quality": {"value": "71", "recent": "yes"},
+			"access-technologies": ["lte"], "sim": "/org/freedesktop/ModemManager1/SIM/0",
+			"equipment-identifier": "861234567890123"},
+			"3gpp": {"imei": "--", "operator-code": "46001", "operator-name": "CHN-UNICOM",
+			"registration-state": "roaming"}}}`, "--modem="+device, "--output-json")
+		exec(`{"sim": {"properties": {"iccid": "89860112345678901234", "imsi": "460011234567890",
+			"operator-code": "46001", "operator-name": "--"}}}`,
+			"--sim=/org/freedesktop/ModemManager1/SIM/0", "--output-json")
+
+		mgr := modem.New(mock)
+
+		status, err := mgr.Status(ctx, "wwan")
+		if err != nil {
+			t.Fatalf("Status failed: %v", err)
+		}
+
+		if status.Registration != modem.RegistrationRegistered || !status.Roaming || status.Operator.MCC != "460" ||
+			status.Operator.MNC != "01" || status.Signal.Quality != 71 || !status.Connected {
+			t.Errorf("unexpected status: %+v", status)
+		}
+
+		sim, err := mgr.SIM(ctx, "wwan")
+		if err != nil {
+			t.Fatalf("SIM failed: %v", err)
+		}
+
+		if sim.IMSI != "460011234567890" || sim.IMEI != "861234567890123" || sim.Operator.Name != "" {
+			t.Errorf("unexpected SIM: %+v", sim)
+		}
+	})
+
+	t.Run("Connect", func(t *testing.T) {
+		mock, _ := newMock("qmi", "/dev/cdc-wdm0")
+		mock.AddResponse("network.interface.wwan", "down", map[string]any{})
+
+		err := modem.New(mock).Disconnect(ctx, "wwan")
+		if err != nil {
+			t.Fatalf("Disconnect failed: %v", err)
+		}
+
+		if call := mock.GetLastCall(); call.Service != "network.interface.wwan" || call.Method != "down" {
+			t.Errorf("unexpected call: %+v", call)
+		}
+
+		mock, _ = newMock("dhcp", "")
+
+		err = modem.New(mock).Connect(ctx, "wwan")
+		if !errdefs.IsNotSupported(err) {
+			t.Errorf("expected a non-modem interface to be rejected, got %v", err)
+		}
+	})
+
+	t.Run("SignalQuality", func(t *testing.T) {
+		if modem.SignalQuality(-120) != 0 || modem.SignalQuality(-40) != 100 || modem.SignalQuality(0) != 0 {
+			t.Error("unexpected signal quality bounds")
+		}
+	})
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package modem
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+const (
+	mmcliBinary = "/usr/bin/mmcli"
+
+	// mmcliUnknown is the value mmcli prints for unknown properties.
+	mmcliUnknown = "--"
+	// mccLength is the length of the mobile country code an operator code starts with.
+	mccLength = 3
+)
+
+// mmStatus reads the status of the ModemManager modem device, such as the sysfs path the
+// modemmanager protocol is configured with.
+func (m *Manager) mmStatus(ctx context.Context, device string) (*Status, error) {
+	modem, err := mmcli[mmcliModem](ctx, m, "--modem="+device)
+	if err != nil {
+		return nil, err
+	}
+
+	generic, gpp := modem.Modem.Generic, modem.Modem.ThreeGPP
+	quality, _ := strconv.Atoi(generic.SignalQuality.Value)
+
+	status := &Status{
+		Registration: mmRegistration(gpp.RegistrationState),
+		Operator:     mmOperator(gpp.OperatorName, gpp.OperatorCode),
+		Signal:       Signal{Quality: quality},
+		Connected:    generic.State == "connected",
+		Roaming:      strings.HasPrefix(gpp.RegistrationState, "roaming"),
+	}
+
+	if len(generic.AccessTechnologies) > 0 {
+		status.Technology = strings.ToLower(generic.AccessTechnologies[0])
+	}
+
+	return status, nil
+}
+
+// mmSIM reads the SIM of the ModemManager modem device.
+func (m *Manager) mmSIM(ctx context.Context, device string) (*SIM, error) {
+	modem, err := mmcli[mmcliModem](ctx, m, "--modem="+device)
+	if err != nil {
+		return nil, err
+	}
+
+	path := modem.Modem.Generic.SIM
+	if path == "" || path == mmcliUnknown {
+		return nil, errdefs.Wrapf(errdefs.ErrNotFound, "modem %s has no SIM", device)
+	}
+
+	res, err := mmcli[mmcliSIM](ctx, m, "--sim="+path)
+	if err != nil {
+		return nil, err
+	}
+
+	props := res.SIM.Properties
+
+	imei := modem.Modem.ThreeGPP.IMEI
+	if imei == "" || imei == mmcliUnknown {
+		imei = modem.Modem.Generic.IMEI
+	}
+
+	return &SIM{
+		ICCID:    known(props.ICCID),
+		IMSI:     known(props.IMSI),
+		IMEI:     known(imei),
+		Operator: mmOperator(props.OperatorName, props.OperatorCode),
+	}, nil
+}
+
+// mmcli runs an mmcli query and decodes its JSON output.
+func mmcli[T any](ctx context.Context, m *Manager, args ...string) (*T, error) {
+	out, err := m.run(ctx, mmcliBinary, append(args, "--output-json")...)
+	if err != nil {
+		return nil, err
+	}
+
+	var result T
+
+	err = json.Unmarshal([]byte(out), &result)
+	if err != nil {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidResponse, "invalid mmcli output: %v", err)
+	}
+
+	return &result, nil
+}
+
+// mmRegistration maps a ModemManager 3GPP registration state to a Registration constant.
+func mmRegistration(state string) string {
+	switch {
+	case strings.HasPrefix(state, "home"), strings.HasPrefix(state, "roaming"):
+		return RegistrationRegistered
+	case state == "searching":
+		return RegistrationSearching
+	case state == "denied":
+		return RegistrationDenied
+	case state == "idle":
+		return RegistrationNotRegistered
+	default:
+		return RegistrationUnknown
+	}
+}
+
+// mmOperator splits an operator code such as "46000" into the MCC and MNC.
+func mmOperator(name, code string) Operator {
+	code = known(code)
+	if len(code) <= mccLength {
+		return Operator{Name: known(name)}
+	}
+
+	return Operator{Name: known(name), MCC: code[:mccLength], MNC: code[mccLength:]}
+}
+
+// known returns value, or "" for the placeholder of an unknown mmcli value.
+func known(value string) string {
+	if value == mmcliUnknown {
+		return ""
+	}
+
+	return value
+}
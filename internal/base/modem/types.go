@@ -0,0 +1,134 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package modem
+
+// Backends a modem interface is driven by, named after the netifd protocols.
+const (
+	// BackendQMI drives QMI modems with uqmi, e.g. a /dev/cdc-wdm0 control device.
+	BackendQMI = "qmi"
+	// BackendModemManager reads the modem from ModemManager with mmcli.
+	BackendModemManager = "modemmanager"
+)
+
+// Registration states of a modem in the mobile network.
+const (
+	RegistrationRegistered    = "registered"
+	RegistrationSearching     = "searching"
+	RegistrationDenied        = "denied"
+	RegistrationNotRegistered = "not_registered"
+	RegistrationUnknown       = "unknown"
+)
+
+// Status is the state of the modem behind a netifd interface.
+type Status struct {
+	// Interface is the netifd interface, Device the control device or ModemManager device
+	// it is configured with.
+	Interface string `json:"interface"`
+	Backend   string `json:"backend"`
+	Device    string `json:"device"`
+	// Registration is one of the Registration constants.
+	Registration string   `json:"registration"`
+	Operator     Operator `json:"operator"`
+	// Technology is the radio access technology in lower case, e.g. "lte" or "umts".
+	Technology string `json:"technology"`
+	Signal     Signal `json:"signal"`
+	// Connected reports whether the modem has an active data connection.
+	Connected bool `json:"connected"`
+	Roaming   bool `json:"roaming"`
+}
+
+// Operator is the mobile network the modem is registered to.
+type Operator struct {
+	Name string `json:"name"`
+	MCC  string `json:"mcc"`
+	MNC  string `json:"mnc"`
+}
+
+// Signal holds the signal measurements of the modem. Measurements the technology or the
+// backend does not report are zero.
+type Signal struct {
+	// Quality is the signal quality in percent.
+	Quality int `json:"quality"`
+	// RSSI, RSRP and RSCP are in dBm, RSRQ, SNR and ECIO in dB.
+	RSSI float64 `json:"rssi,omitempty"`
+	RSRP float64 `json:"rsrp,omitempty"`
+	RSRQ float64 `json:"rsrq,omitempty"`
+	SNR  float64 `json:"snr,omitempty"`
+	RSCP float64 `json:"rscp,omitempty"`
+	ECIO float64 `json:"ecio,omitempty"`
+}
+
+// SIM describes the SIM card of the modem.
+type SIM struct {
+	ICCID string `json:"iccid"`
+	IMSI  string `json:"imsi"`
+	// IMEI identifies the modem rather than the SIM.
+	IMEI string `json:"imei"`
+	// PINStatus is the state of the PIN, e.g. "disabled", "enabled" or "blocked"; it is
+	// empty when the backend does not report it.
+	PINStatus  string `json:"pin_status,omitempty"`
+	PINRetries int    `json:"pin_retries,omitempty"`
+	// Operator is the home network of the SIM.
+	Operator Operator `json:"operator"`
+}
+
+// uqmiSignal is the output of uqmi --get-signal-info.
+type uqmiSignal struct {
+	Type string  `json:"type"`
+	RSSI float64 `json:"rssi"`
+	RSRP float64 `json:"rsrp"`
+	RSRQ float64 `json:"rsrq"`
+	SNR  float64 `json:"snr"`
+	RSCP float64 `json:"rscp"`
+	ECIO float64 `json:"ecio"`
+}
+
+// uqmiServingSystem is the output of uqmi --get-serving-system.
+type uqmiServingSystem struct {
+	Registration string `json:"registration"`
+	Description  string `json:"plmn_description"`
+	MCC          int    `json:"plmn_mcc"`
+	MNC          int    `json:"plmn_mnc"`
+	Roaming      bool   `json:"roaming"`
+}
+
+// uqmiPINStatus is the output of uqmi --get-pin-status.
+type uqmiPINStatus struct {
+	Status string `json:"pin1_status"`
+	Tries  int    `json:"pin1_verify_tries"`
+}
+
+// mmcliModem is the output of mmcli --modem=<device> --output-json. mmcli reports all values
+// as strings, "--" for those that are unknown.
+type mmcliModem struct {
+	Modem struct {
+		Generic struct {
+			State         string `json:"state"`
+			SignalQuality struct {
+				Value string `json:"value"`
+			} `json:"signal-quality"`
+			AccessTechnologies []string `json:"access-technologies"`
+			SIM                string   `json:"sim"`
+			IMEI               string   `json:"equipment-identifier"`
+		} `json:"generic"`
+		ThreeGPP struct {
+			IMEI              string `json:"imei"`
+			OperatorCode      string `json:"operator-code"`
+			OperatorName      string `json:"operator-name"`
+			RegistrationState string `json:"registration-state"`
+		} `json:"3gpp"`
+	} `json:"modem"`
+}
+
+// mmcliSIM is the output of mmcli --sim=<path> --output-json.
+type mmcliSIM struct {
+	SIM struct {
+		Properties struct {
+			ICCID        string `json:"iccid"`
+			IMSI         string `json:"imsi"`
+			OperatorCode string `json:"operator-code"`
+			OperatorName string `json:"operator-name"`
+		} `json:"properties"`
+	} `json:"sim"`
+}
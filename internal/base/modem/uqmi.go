@@ -0,0 +1,143 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package modem
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+const (
+	uqmiBinary = "/sbin/uqmi"
+
+	// rssiMin and rssiMax bound the RSSI in dBm that maps to a signal quality of 0 to 100
+	// percent, as ModemManager computes it.
+	rssiMin = -113
+	rssiMax = -51
+	percent = 100
+)
+
+// qmiStatus reads the status of the QMI modem with the control device device.
+func (m *Manager) qmiStatus(ctx context.Context, device string) (*Status, error) {
+	serving, err := uqmi[uqmiServingSystem](ctx, m, device, "--get-serving-system")
+	if err != nil {
+		return nil, err
+	}
+
+	status := &Status{
+		Registration: qmiRegistration(serving.Registration),
+		Roaming:      serving.Roaming,
+	}
+
+	if status.Registration == RegistrationRegistered {
+		status.Operator = Operator{
+			Name: serving.Description,
+			MCC:  fmt.Sprintf("%03d", serving.MCC),
+			MNC:  fmt.Sprintf("%02d", serving.MNC),
+		}
+	}
+
+	signal, err := uqmi[uqmiSignal](ctx, m, device, "--get-signal-info")
+	if err != nil {
+		return nil, err
+	}
+
+	status.Technology = signal.Type
+	status.Signal = Signal{
+		Quality: SignalQuality(signal.RSSI),
+		RSSI:    signal.RSSI,
+		RSRP:    signal.RSRP,
+		RSRQ:    signal.RSRQ,
+		SNR:     signal.SNR,
+		RSCP:    signal.RSCP,
+		ECIO:    signal.ECIO,
+	}
+
+	data, err := uqmi[string](ctx, m, device, "--get-data-status")
+	if err != nil {
+		return nil, err
+	}
+
+	status.Connected = data == "connected"
+
+	return status, nil
+}
+
+// qmiSIM reads the SIM of the QMI modem with the control device device.
+func (m *Manager) qmiSIM(ctx context.Context, device string) (*SIM, error) {
+	sim := &SIM{}
+
+	for _, id := range []struct {
+		option string
+		value  *string
+	}{
+		{"--get-iccid", &sim.ICCID},
+		{"--get-imsi", &sim.IMSI},
+		{"--get-imei", &sim.IMEI},
+	} {
+		value, err := uqmi[string](ctx, m, device, id.option)
+		if err != nil {
+			return nil, err
+		}
+
+		*id.value = value
+	}
+
+	// Modems that only manage the SIM through the UIM service have no PIN status.
+	pin, err := uqmi[uqmiPINStatus](ctx, m, device, "--get-pin-status")
+	if err == nil {
+		sim.PINStatus, sim.PINRetries = pin.Status, pin.Tries
+	}
+
+	return sim, nil
+}
+
+// uqmi runs a uqmi query on device and decodes its JSON output.
+func uqmi[T any](ctx context.Context, m *Manager, device, option string) (T, error) {
+	var result T
+
+	out, err := m.run(ctx, uqmiBinary, "-s", "-d", device, option)
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal([]byte(strings.TrimSpace(out)), &result)
+	if err != nil {
+		return result, errdefs.Wrapf(errdefs.ErrInvalidResponse, "invalid uqmi %s output %q", option, out)
+	}
+
+	return result, nil
+}
+
+// qmiRegistration maps a uqmi registration state to a Registration constant.
+func qmiRegistration(state string) string {
+	switch state {
+	case "registered":
+		return RegistrationRegistered
+	case "searching":
+		return RegistrationSearching
+	case "registering_denied":
+		return RegistrationDenied
+	case "not_registered":
+		return RegistrationNotRegistered
+	default:
+		return RegistrationUnknown
+	}
+}
+
+// SignalQuality maps an RSSI in dBm to a signal quality in percent. An RSSI of zero, which
+// marks a missing measurement, has no quality.
+func SignalQuality(rssi float64) int {
+	if rssi == 0 {
+		return 0
+	}
+
+	rssi = min(max(rssi, rssiMin), rssiMax)
+
+	return int((rssi - rssiMin) * percent / (rssiMax - rssiMin))
+}
@@ -0,0 +1,322 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package network
+
+import (
+	"context"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/uci"
+)
+
+const (
+	uciDeviceType     = "device"
+	uciBridgeVLANType = "bridge-vlan"
+
+	// DefaultApplyTimeout is the rollback timeout ApplyChanges uses when none is given.
+	DefaultApplyTimeout = uci.DefaultApplyTimeout
+)
+
+// DeviceConfigs retrieves the device sections configured in /etc/config/network, in configuration order.
+func (m *Manager) DeviceConfigs(ctx context.Context) ([]DeviceConfig, error) {
+	sections, err := m.sectionsOfTypes(ctx, uciDeviceType)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read devices")
+	}
+
+	devices := make([]DeviceConfig, 0, len(sections))
+	for _, section := range sections {
+		devices = append(devices, DeviceConfigFromSection(section))
+	}
+
+	return devices, nil
+}
+
+// SaveDeviceConfig validates device, adds it as a device section, or updates the section named
+// by device.Section, and commits the network package.
+func (m *Manager) SaveDeviceConfig(ctx context.Context, device DeviceConfig) error {
+	err := device.Validate()
+	if err != nil {
+		return err
+	}
+
+	return m.saveSection(ctx, uciDeviceType, device.Section, device.SectionValues())
+}
+
+// BridgeVLANs retrieves the bridge-vlan sections configured in /etc/config/network, in configuration order.
+func (m *Manager) BridgeVLANs(ctx context.Context) ([]BridgeVLAN, error) {
+	sections, err := m.sectionsOfTypes(ctx, uciBridgeVLANType)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read bridge VLANs")
+	}
+
+	vlans := make([]BridgeVLAN, 0, len(sections))
+	for _, section := range sections {
+		vlans = append(vlans, BridgeVLANFromSection(section))
+	}
+
+	return vlans, nil
+}
+
+// SaveBridgeVLAN validates vlan, adds it as a bridge-vlan section, or updates the section named
+// by vlan.Section, and commits the network package.
+func (m *Manager) SaveBridgeVLAN(ctx context.Context, vlan BridgeVLAN) error {
+	err := vlan.Validate()
+	if err != nil {
+		return err
+	}
+
+	return m.saveSection(ctx, uciBridgeVLANType, vlan.Section, vlan.SectionValues())
+}
+
+// AddBridgePort adds port to the ports of the bridge device section and activates the change
+// with ApplyChanges. It does nothing if the port is already a member.
+func (m *Manager) AddBridgePort(ctx context.Context, bridge, port string, timeout time.Duration) error {
+	err := validateDeviceName(port)
+	if err != nil {
+		return err
+	}
+
+	devices, err := m.DeviceConfigs(ctx)
+	if err != nil {
+		return err
+	}
+
+	index := slices.IndexFunc(devices, func(d DeviceConfig) bool { return d.Name == bridge && d.Type == "bridge" })
+	if index < 0 {
+		return errdefs.Wrapf(errdefs.ErrNotFound, "bridge device %s is not configured", bridge)
+	}
+
+	device := devices[index]
+	if slices.Contains(device.Ports, port) {
+		return nil
+	}
+
+	device.Ports = append(device.Ports, port)
+
+	err = m.stageSection(ctx, uciDeviceType, device.Section, device.SectionValues())
+	if err != nil {
+		return err
+	}
+
+	return m.ApplyChanges(ctx, timeout)
+}
+
+// SetVLANPort adds port to the VLAN of the bridge, or replaces its tagging if it is already a
+// member, and activates the change with ApplyChanges.
+func (m *Manager) SetVLANPort(
+	ctx context.Context, bridge string, vlan int, port BridgeVLANPort, timeout time.Duration,
+) error {
+	err := validateDeviceName(port.Name)
+	if err != nil {
+		return err
+	}
+
+	vlans, err := m.BridgeVLANs(ctx)
+	if err != nil {
+		return err
+	}
+
+	index := slices.IndexFunc(vlans, func(v BridgeVLAN) bool { return v.Device == bridge && v.VLAN == vlan })
+	if index < 0 {
+		return errdefs.Wrapf(errdefs.ErrNotFound, "VLAN %d is not configured on %s", vlan, bridge)
+	}
+
+	section := vlans[index]
+
+	member := slices.IndexFunc(section.Ports, func(p BridgeVLANPort) bool { return p.Name == port.Name })
+	if member >= 0 {
+		section.Ports[member] = port
+	} else {
+		section.Ports = append(section.Ports, port)
+	}
+
+	err = m.stageSection(ctx, uciBridgeVLANType, section.Section, section.SectionValues())
+	if err != nil {
+		return err
+	}
+
+	return m.ApplyChanges(ctx, timeout)
+}
+
+// ApplyChanges commits the staged network changes with rollback: rpcd reloads the network and
+// reverts the changes after timeout unless they are confirmed. ApplyChanges waits a third of
+// the timeout, checks that the device still answers and confirms. If it does not, for example
+// because the change cut off the management port, rpcd restores the previous configuration.
+func (m *Manager) ApplyChanges(ctx context.Context, timeout time.Duration) error {
+	return m.uci.ApplyWithConfirm(ctx, timeout, func(ctx context.Context) error {
+		_, err := m.DumpInterfaces(ctx)
+
+		return err
+	})
+}
+
+// DeviceConfigFromSection converts a UCI device section into a DeviceConfig.
+func DeviceConfigFromSection(section *uci.Section) DeviceConfig {
+	return DeviceConfig{
+		Section: section.Name,
+		Name:    section.GetString("name"),
+		Type:    section.GetString("type"),
+		MACAddr: section.GetString("macaddr"),
+		Ports:   section.Get("ports"),
+		MTU:     section.GetInt("mtu"),
+	}
+}
+
+// SectionValues converts the device into UCI option values.
+func (d *DeviceConfig) SectionValues() uci.SectionValues {
+	values := uci.NewSectionValues()
+	values.SetScalar("name", d.Name)
+	values.SetScalar("type", d.Type)
+	values.SetScalar("macaddr", d.MACAddr)
+	setInt(&values, "mtu", d.MTU)
+
+	if len(d.Ports) > 0 {
+		values.SetList("ports", d.Ports...)
+	} else {
+		values.Set("ports")
+	}
+
+	return values
+}
+
+// Validate checks the device name, MAC address and ports.
+func (d *DeviceConfig) Validate() error {
+	err := validateDeviceName(d.Name)
+	if err != nil {
+		return err
+	}
+
+	if d.MACAddr != "" {
+		_, err = net.ParseMAC(d.MACAddr)
+		if err != nil {
+			return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid MAC address %q", d.MACAddr)
+		}
+	}
+
+	if len(d.Ports) > 0 && d.Type != "bridge" {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "only bridge devices have ports, %s is %q", d.Name, d.Type)
+	}
+
+	for _, port := range d.Ports {
+		err = validateDeviceName(port)
+		if err != nil {
+			return err
+		}
+	}
+
+	return validateMTU(d.MTU)
+}
+
+// validateMTU accepts a valid MTU or 0 for the default.
+func validateMTU(mtu int) error {
+	if mtu != 0 && (mtu < minMTU || mtu > maxMTU) {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid MTU %d", mtu)
+	}
+
+	return nil
+}
+
+// BridgeVLANFromSection converts a UCI bridge-vlan section into a BridgeVLAN. Malformed port
+// entries are kept as untagged ports of that name.
+func BridgeVLANFromSection(section *uci.Section) BridgeVLAN {
+	vlan := BridgeVLAN{
+		Section: section.Name,
+		Device:  section.GetString("device"),
+		VLAN:    section.GetInt("vlan"),
+	}
+
+	for _, value := range section.Get("ports") {
+		for _, entry := range strings.Fields(value) {
+			port, _ := ParseBridgeVLANPort(entry)
+			vlan.Ports = append(vlan.Ports, port)
+		}
+	}
+
+	return vlan
+}
+
+// SectionValues converts the VLAN into UCI option values.
+func (v *BridgeVLAN) SectionValues() uci.SectionValues {
+	ports := make([]string, 0, len(v.Ports))
+	for _, port := range v.Ports {
+		ports = append(ports, port.String())
+	}
+
+	values := uci.NewSectionValues()
+	values.SetScalar("device", v.Device)
+	setInt(&values, "vlan", v.VLAN)
+
+	if len(ports) > 0 {
+		values.SetList("ports", ports...)
+	} else {
+		values.Set("ports")
+	}
+
+	return values
+}
+
+// Validate checks the bridge, VLAN ID and ports. A port may be the PVID of one VLAN only,
+// which is not checked here.
+func (v *BridgeVLAN) Validate() error {
+	err := validateDeviceName(v.Device)
+	if err != nil {
+		return err
+	}
+
+	if v.VLAN < 1 || v.VLAN > maxVLANID {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid VLAN ID %d", v.VLAN)
+	}
+
+	seen := make(map[string]bool, len(v.Ports))
+
+	for _, port := range v.Ports {
+		err = validateDeviceName(port.Name)
+		if err != nil {
+			return err
+		}
+
+		if seen[port.Name] {
+			return errdefs.Wrapf(errdefs.ErrInvalidParameter, "port %s is listed twice in VLAN %d", port.Name, v.VLAN)
+		}
+
+		seen[port.Name] = true
+	}
+
+	return nil
+}
+
+// ParseBridgeVLANPort parses a bridge-vlan port entry such as "lan1", "lan1:t" or "lan1:u*".
+func ParseBridgeVLANPort(entry string) (BridgeVLANPort, error) {
+	name, flags, _ := strings.Cut(entry, ":")
+	port := BridgeVLANPort{Name: name, PVID: strings.HasSuffix(flags, "*")}
+
+	switch strings.TrimSuffix(flags, "*") {
+	case "", "u":
+	case "t":
+		port.Tagged = true
+	default:
+		return port, errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid bridge VLAN port %q", entry)
+	}
+
+	return port, nil
+}
+
+// String formats the port as a bridge-vlan port entry.
+func (p BridgeVLANPort) String() string {
+	flags := "u"
+	if p.Tagged {
+		flags = "t"
+	}
+
+	if p.PVID {
+		flags += "*"
+	}
+
+	return p.Name + ":" + flags
+}
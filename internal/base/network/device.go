@@ -0,0 +1,178 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package network
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+const (
+	// maxDeviceNameLength is IFNAMSIZ without the terminating NUL.
+	maxDeviceNameLength = 15
+	minMTU              = 68
+	maxMTU              = 65535
+	maxVLANID           = 4094
+	macAddressLength    = 6
+)
+
+// VLAN protocols of a VLANRequest.
+const (
+	VLANProtocol8021Q  = "802.1Q"
+	VLANProtocol8021AD = "802.1ad"
+)
+
+// VLANRequest describes a VLAN device to create on top of a parent device.
+type VLANRequest struct {
+	// Parent is the device carrying the tagged traffic, e.g. "eth0".
+	Parent string
+	// Name defaults to "<parent>.<id>".
+	Name string
+	// Protocol defaults to VLANProtocol8021Q.
+	Protocol string
+	ID       int
+}
+
+// Up brings a device up by clearing its deferred state in netifd.
+func (dc *DeviceContext) Up(ctx context.Context, name string) error {
+	return dc.setDeferred(ctx, name, false)
+}
+
+// Down takes a device down by deferring it in netifd, which keeps it down until Up is called.
+func (dc *DeviceContext) Down(ctx context.Context, name string) error {
+	return dc.setDeferred(ctx, name, true)
+}
+
+// setDeferred sends defer explicitly; DeviceSetStateRequest omits it when false.
+func (dc *DeviceContext) setDeferred(ctx context.Context, name string, deferred bool) error {
+	_, err := dc.manager.caller.Call(ctx, "network.device", "set_state", map[string]any{
+		"name":  name,
+		"defer": deferred,
+	})
+
+	return err
+}
+
+// The methods below change the kernel device with ip(8) through file exec, so the session
+// needs exec permission for /sbin/ip. The changes are not written to UCI and are lost when
+// netifd reconfigures the device.
+
+// SetMTU sets the MTU of a device.
+func (dc *DeviceContext) SetMTU(ctx context.Context, name string, mtu int) error {
+	if mtu < minMTU || mtu > maxMTU {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid MTU %d", mtu)
+	}
+
+	return dc.ip(ctx, name, "link", "set", "dev", name, "mtu", strconv.Itoa(mtu))
+}
+
+// SetMAC sets the MAC address of a device.
+func (dc *DeviceContext) SetMAC(ctx context.Context, name, mac string) error {
+	hw, err := net.ParseMAC(mac)
+	if err != nil || len(hw) != macAddressLength {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid MAC address %q", mac)
+	}
+
+	return dc.ip(ctx, name, "link", "set", "dev", name, "address", hw.String())
+}
+
+// AddVLAN creates a VLAN device and returns its name.
+func (dc *DeviceContext) AddVLAN(ctx context.Context, req VLANRequest) (string, error) {
+	if req.ID < 1 || req.ID > maxVLANID {
+		return "", errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid VLAN ID %d", req.ID)
+	}
+
+	if req.Protocol == "" {
+		req.Protocol = VLANProtocol8021Q
+	}
+
+	if req.Protocol != VLANProtocol8021Q && req.Protocol != VLANProtocol8021AD {
+		return "", errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid VLAN protocol %q", req.Protocol)
+	}
+
+	if req.Name == "" {
+		req.Name = req.Parent + "." + strconv.Itoa(req.ID)
+	}
+
+	err := validateDeviceName(req.Parent)
+	if err != nil {
+		return "", err
+	}
+
+	err = dc.ip(ctx, req.Name, "link", "add", "link", req.Parent, "name", req.Name,
+		"type", "vlan", "protocol", req.Protocol, "id", strconv.Itoa(req.ID))
+	if err != nil {
+		return "", err
+	}
+
+	return req.Name, nil
+}
+
+// AddBridge creates a bridge device and attaches the given ports to it.
+func (dc *DeviceContext) AddBridge(ctx context.Context, name string, ports ...string) error {
+	for _, port := range ports {
+		err := validateDeviceName(port)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := dc.ip(ctx, name, "link", "add", "name", name, "type", "bridge")
+	if err != nil {
+		return err
+	}
+
+	for _, port := range ports {
+		err = dc.ip(ctx, port, "link", "set", "dev", port, "master", name)
+		if err != nil {
+			return errdefs.Wrapf(err, "failed to attach %s to bridge %s", port, name)
+		}
+	}
+
+	return nil
+}
+
+// Delete deletes a VLAN, bridge or other virtual device.
+func (dc *DeviceContext) Delete(ctx context.Context, name string) error {
+	return dc.ip(ctx, name, "link", "delete", "dev", name)
+}
+
+// ip runs ip(8) with args after validating the name of the device it changes.
+func (dc *DeviceContext) ip(ctx context.Context, name string, args ...string) error {
+	err := validateDeviceName(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = dc.manager.runIP(ctx, args...)
+
+	return err
+}
+
+// runIP runs ip(8) with args and returns its standard output.
+func (m *Manager) runIP(ctx context.Context, args ...string) (string, error) {
+	res, err := m.file.Exec(ctx, "/sbin/ip", args, nil)
+	if err != nil {
+		return "", errdefs.Wrapf(err, "failed to run ip %s", args[0])
+	}
+
+	if res.Code != 0 {
+		return "", errdefs.Wrapf(errdefs.ErrUnknown, "ip %s %s exited with code %d: %s",
+			args[0], args[1], res.Code, strings.TrimSpace(res.Stderr))
+	}
+
+	return res.Stdout, nil
+}
+
+func validateDeviceName(name string) error {
+	if name == "" || len(name) > maxDeviceNameLength || strings.ContainsAny(name, "/: \t\n") {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid device name %q", name)
+	}
+
+	return nil
+}
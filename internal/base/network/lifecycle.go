@@ -0,0 +1,82 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package network
+
+import (
+	"context"
+	"time"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+const (
+	// protoActionKill is the proto-shell notification that signals the protocol's running command.
+	protoActionKill = 2
+	// signalRelease is SIGUSR2, which makes udhcpc and odhcp6c release their lease.
+	signalRelease = 12
+	// interfacePollInterval is how often WaitUp reads the interface status.
+	interfacePollInterval = time.Second
+)
+
+// Release makes the DHCP client of the interface release its lease by having netifd send it
+// SIGUSR2. The client stays running without an address until Renew. Only the dhcp and dhcpv6
+// protocols support it.
+func (ic *InterfaceContext) Release(ctx context.Context) error {
+	status, err := ic.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	if status.Proto != "dhcp" && status.Proto != "dhcpv6" {
+		return errdefs.Wrapf(errdefs.ErrNotSupported, "interface %s uses protocol %q, which cannot release a lease",
+			ic.name, status.Proto)
+	}
+
+	_, err = ic.manager.caller.Call(ctx, "network.interface."+ic.name, "notify_proto", map[string]any{
+		"action": protoActionKill,
+		"signal": signalRelease,
+	})
+
+	return err
+}
+
+// WaitUp polls the interface status until the interface is up and returns that status.
+// Bound the wait with ctx.
+func (ic *InterfaceContext) WaitUp(ctx context.Context) (*InterfaceDetails, error) {
+	ticker := time.NewTicker(interfacePollInterval)
+	defer ticker.Stop()
+
+	for {
+		status, err := ic.Status(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if status.Up {
+			return status, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, errdefs.Wrapf(errdefs.FromTransport(ctx.Err()), "wait for interface %s to come up", ic.name)
+		case <-ticker.C:
+		}
+	}
+}
+
+// Reconnect takes the interface down, brings it up again and waits until it is up, e.g. to
+// force a new PPPoE session or DHCP lease on the WAN. Bound the wait with ctx.
+func (ic *InterfaceContext) Reconnect(ctx context.Context) (*InterfaceDetails, error) {
+	err := ic.Down(ctx)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to take interface %s down", ic.name)
+	}
+
+	err = ic.Up(ctx)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to bring interface %s up", ic.name)
+	}
+
+	return ic.WaitUp(ctx)
+}
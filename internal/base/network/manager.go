@@ -8,6 +8,8 @@ import (
 
 	"github.com/honeybbq/goubus/v2"
 	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/file"
+	"github.com/honeybbq/goubus/v2/internal/base/uci"
 )
 
 // Dialect defines the differences in Network ubus calls.
@@ -17,11 +19,18 @@ type Dialect any
 type Manager struct {
 	caller  goubus.Transport
 	dialect Dialect
+	file    *file.Manager
+	uci     *uci.Manager
 }
 
 // New creates a new base network Manager.
 func New(t goubus.Transport, d Dialect) *Manager {
-	return &Manager{caller: t, dialect: d}
+	return &Manager{caller: t, dialect: d, file: file.New(t), uci: uci.New(t, nil)}
+}
+
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"network", "network.interface", "network.device", "network.wireless", "file", "uci"}
 }
 
 // Restart restarts the network service.
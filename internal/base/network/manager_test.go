@@ -5,8 +5,13 @@ package network_test
 
 import (
 	"context"
+	"encoding/json"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/honeybbq/goubus/v2/errdefs"
 	"github.com/honeybbq/goubus/v2/internal/base/network"
 	"github.com/honeybbq/goubus/v2/internal/testutil"
 )
@@ -88,3 +93,465 @@ func TestNetworkManagerDeviceStatus(t *testing.T) {
 		t.Errorf("unexpected device data: %+v", devices)
 	}
 }
+
+func TestNetworkManagerDeviceControl(t *testing.T) {
+	ctx := context.Background()
+	mock := testutil.NewMockTransport()
+	mock.AddResponse("network.device", "set_state", map[string]any{})
+	mock.AddResponse("file", "exec", map[string]any{"code": 0})
+
+	devices := network.New(mock, mockNetworkDialect{}).Devices()
+
+	err := devices.Up(ctx, "eth1")
+	if err != nil {
+		t.Fatalf("Up failed: %v", err)
+	}
+
+	params, _ := mock.GetLastCall().Data.(map[string]any)
+	if params["name"] != "eth1" || params["defer"] != false {
+		t.Errorf("unexpected set_state params: %+v", params)
+	}
+
+	err = devices.SetMTU(ctx, "eth1", 1492)
+	if err != nil || execArgs(mock) != "link set dev eth1 mtu 1492" {
+		t.Errorf("SetMTU: %v, args %q", err, execArgs(mock))
+	}
+
+	err = devices.SetMAC(ctx, "eth1", "AA-BB-CC-DD-EE-FF")
+	if err != nil || execArgs(mock) != "link set dev eth1 address aa:bb:cc:dd:ee:ff" {
+		t.Errorf("SetMAC: %v, args %q", err, execArgs(mock))
+	}
+
+	name, err := devices.AddVLAN(ctx, network.VLANRequest{Parent: "eth1", ID: 20})
+	if err != nil || name != "eth1.20" ||
+		execArgs(mock) != "link add link eth1 name eth1.20 type vlan protocol 802.1Q id 20" {
+		t.Errorf("AddVLAN: %s, %v, args %q", name, err, execArgs(mock))
+	}
+
+	err = devices.AddBridge(ctx, "br-iot", "eth1.20")
+	if err != nil || execArgs(mock) != "link set dev eth1.20 master br-iot" {
+		t.Errorf("AddBridge: %v, args %q", err, execArgs(mock))
+	}
+
+	for _, err := range []error{
+		devices.SetMTU(ctx, "eth1", 20),
+		devices.SetMAC(ctx, "eth1", "not-a-mac"),
+		devices.Delete(ctx, "eth1; reboot"),
+		devices.AddBridge(ctx, "br-lan", "much-too-long-device-name"),
+	} {
+		if !errdefs.IsInvalidParameter(err) {
+			t.Errorf("expected an invalid parameter error, got %v", err)
+		}
+	}
+
+	_, err = devices.AddVLAN(ctx, network.VLANRequest{Parent: "eth1", ID: 4095})
+	if !errdefs.IsInvalidParameter(err) {
+		t.Errorf("expected an invalid VLAN ID error, got %v", err)
+	}
+
+	mock.AddResponse("file", "exec", map[string]any{"code": 2, "stderr": "Cannot find device \"eth9\"\n"})
+
+	err = devices.Delete(ctx, "eth9")
+	if !errdefs.IsUnknown(err) {
+		t.Errorf("expected the ip failure to be reported, got %v", err)
+	}
+}
+
+func execArgs(mock *testutil.MockTransport) string {
+	params, _ := mock.GetLastCall().Data.(map[string]any)
+	args, _ := params["params"].([]string)
+
+	return strings.Join(args, " ")
+}
+
+func TestNetworkManagerInterfaceLifecycle(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Release", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("network.interface.wan", "status", map[string]any{"proto": "dhcp", "up": true})
+		mock.AddResponse("network.interface.wan", "notify_proto", map[string]any{})
+		mock.AddResponse("network.interface.lan", "status", map[string]any{"proto": "static", "up": true})
+
+		mgr := network.New(mock, mockNetworkDialect{})
+
+		err := mgr.Interface("wan").Release(ctx)
+		if err != nil {
+			t.Fatalf("Release failed: %v", err)
+		}
+
+		params, _ := mock.GetLastCall().Data.(map[string]any)
+		if params["action"] != 2 || params["signal"] != 12 {
+			t.Errorf("unexpected notify_proto params: %+v", params)
+		}
+
+		err = mgr.Interface("lan").Release(ctx)
+		if !errdefs.IsNotSupported(err) {
+			t.Errorf("expected static interfaces to be unsupported, got %v", err)
+		}
+	})
+
+	t.Run("Reconnect", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("network.interface.wan", "down", map[string]any{})
+		mock.AddResponse("network.interface.wan", "up", map[string]any{})
+		mock.AddResponse("network.interface.wan", "status", map[string]any{"up": true, "l3_device": "pppoe-wan"})
+
+		status, err := network.New(mock, mockNetworkDialect{}).Interface("wan").Reconnect(ctx)
+		if err != nil {
+			t.Fatalf("Reconnect failed: %v", err)
+		}
+
+		if status.L3Device != "pppoe-wan" {
+			t.Errorf("unexpected status: %+v", status)
+		}
+
+		calls := mock.Calls
+		if len(calls) != 3 || calls[0].Method != "down" || calls[1].Method != "up" {
+			t.Errorf("unexpected calls: %+v", calls)
+		}
+	})
+
+	t.Run("WaitUp_Cancelled", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("network.interface.wan", "status", map[string]any{"up": false})
+
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		_, err := network.New(mock, mockNetworkDialect{}).Interface("wan").WaitUp(ctx)
+		if !errdefs.IsTimeout(err) {
+			t.Errorf("expected a timeout, got %v", err)
+		}
+	})
+}
+
+func TestNetworkManagerRoutes(t *testing.T) {
+	ctx := context.Background()
+	mock := testutil.NewMockTransport()
+	mock.AddResponse("uci", "get", map[string]any{
+		"values": map[string]any{
+			"lan": map[string]any{".type": "interface", ".name": "lan", ".index": 0, "proto": "static"},
+			"vpn": map[string]any{
+				".type": "route", ".name": "vpn", ".index": 1,
+				"interface": "lan", "target": "10.8.0.0", "netmask": "255.255.255.0", "gateway": "192.168.1.2",
+			},
+			"cfg02": map[string]any{
+				".type": "route6", ".name": "cfg02", ".index": 2,
+				"interface": "lan", "target": "fd00:8::/64", "metric": "10", "disabled": "1",
+			},
+			"cfg03": map[string]any{
+				".type": "rule", ".name": "cfg03", ".index": 3, "src": "192.168.2.0/24", "lookup": "100",
+			},
+		},
+	})
+	mock.AddResponse("network.interface", "dump", map[string]any{
+		"interface": []map[string]any{{
+			"interface": "lan",
+			"route": []map[string]any{
+				{"target": "10.8.0.0", "mask": 24, "nexthop": "192.168.1.2"},
+				{"target": "0.0.0.0", "mask": 0, "nexthop": "192.168.1.1"},
+			},
+		}},
+	})
+
+	mgr := network.New(mock, mockNetworkDialect{})
+
+	t.Run("List", func(t *testing.T) {
+		routes, err := mgr.Routes(ctx)
+		if err != nil || len(routes) != 2 {
+			t.Fatalf("Routes: %+v, %v", routes, err)
+		}
+
+		if routes[0].Section != "vpn" || routes[0].IPv6 || !routes[1].IPv6 || routes[1].Metric != 10 || !routes[1].Disabled {
+			t.Errorf("unexpected routes: %+v", routes)
+		}
+
+		rules, err := mgr.Rules(ctx)
+		if err != nil || len(rules) != 1 || rules[0].Src != "192.168.2.0/24" || rules[0].Lookup != "100" {
+			t.Errorf("Rules: %+v, %v", rules, err)
+		}
+	})
+
+	t.Run("RouteTable", func(t *testing.T) {
+		table, err := mgr.RouteTable(ctx)
+		if err != nil || len(table) != 3 {
+			t.Fatalf("RouteTable: %+v, %v", table, err)
+		}
+
+		if !table[0].Active() || table[0].Config.Section != "vpn" || table[1].Active() {
+			t.Errorf("unexpected configured entries: %+v", table[:2])
+		}
+
+		if table[2].Config != nil || table[2].Live.Nexthop != "192.168.1.1" {
+			t.Errorf("unexpected protocol route: %+v", table[2])
+		}
+	})
+
+	t.Run("Save", func(t *testing.T) {
+		testSaveRoute(t, ctx, mock, mgr)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		mock.AddResponseForArgs("uci", "get", map[string]any{"config": "network", "section": "lan"},
+			map[string]any{"values": map[string]any{".type": "interface", ".name": "lan"}})
+
+		err := mgr.DeleteRoute(ctx, "lan")
+		if !errdefs.IsNotFound(err) {
+			t.Errorf("expected deleting an interface as a route to fail, got %v", err)
+		}
+	})
+}
+
+func testSaveRoute(t *testing.T, ctx context.Context, mock *testutil.MockTransport, mgr *network.Manager) {
+	t.Helper()
+
+	mock.AddResponse("uci", "add", map[string]any{})
+	mock.AddResponse("uci", "commit", map[string]any{})
+
+	err := mgr.SaveRoute(ctx, network.StaticRoute{Interface: "wan", Target: "10.9.0.0/16", Gateway: "100.64.0.1"})
+	if err != nil {
+		t.Fatalf("SaveRoute failed: %v", err)
+	}
+
+	err = mgr.SaveRule(ctx, network.RoutingRule{IPv6: true, Src: "fd00:1::/64", Lookup: "vpn", Priority: 100})
+	if err != nil {
+		t.Fatalf("SaveRule failed: %v", err)
+	}
+
+	for _, route := range []network.StaticRoute{
+		{Target: "10.9.0.0/16"},
+		{Interface: "wan", Target: "10.9.0.1/16"},
+		{Interface: "wan", Target: "10.9.0.0", Netmask: "255.0.255.0"},
+		{Interface: "wan", Target: "10.9.0.0/16", Gateway: "fe80::1"},
+		{Interface: "wan", Target: "fd00::/8"},
+		{Interface: "wan", Target: "10.9.0.0/16", Type: "bogus"},
+	} {
+		err := mgr.SaveRoute(ctx, route)
+		if !errdefs.IsInvalidParameter(err) {
+			t.Errorf("expected %+v to be invalid, got %v", route, err)
+		}
+	}
+
+	for _, rule := range []network.RoutingRule{
+		{Src: "10.0.0.0/8"},
+		{Lookup: "100", Action: "blackhole"},
+		{Dest: "fd00::/8", Lookup: "100"},
+	} {
+		err := mgr.SaveRule(ctx, rule)
+		if !errdefs.IsInvalidParameter(err) {
+			t.Errorf("expected %+v to be invalid, got %v", rule, err)
+		}
+	}
+}
+
+func TestNetworkManagerBridgeVLANs(t *testing.T) {
+	ctx := context.Background()
+	mock := testutil.NewMockTransport()
+	mock.AddResponse("uci", "get", map[string]any{
+		"values": map[string]any{
+			"cfg01": map[string]any{
+				".type": "device", ".name": "cfg01", ".index": 0,
+				"name": "br-lan", "type": "bridge", "ports": []string{"lan1", "lan2"},
+			},
+			"cfg02": map[string]any{
+				".type": "bridge-vlan", ".name": "cfg02", ".index": 1,
+				"device": "br-lan", "vlan": "1", "ports": []string{"lan1:u*", "lan2:t"},
+			},
+		},
+	})
+	mock.AddResponse("uci", "set", map[string]any{})
+	mock.AddResponse("uci", "apply", map[string]any{})
+	mock.AddResponse("uci", "confirm", map[string]any{})
+	mock.AddResponse("network.interface", "dump", map[string]any{"interface": []any{}})
+
+	mgr := network.New(mock, mockNetworkDialect{})
+
+	t.Run("List", func(t *testing.T) {
+		devices, err := mgr.DeviceConfigs(ctx)
+		if err != nil || len(devices) != 1 || devices[0].Name != "br-lan" || len(devices[0].Ports) != 2 {
+			t.Fatalf("DeviceConfigs: %+v, %v", devices, err)
+		}
+
+		vlans, err := mgr.BridgeVLANs(ctx)
+		if err != nil || len(vlans) != 1 || vlans[0].VLAN != 1 {
+			t.Fatalf("BridgeVLANs: %+v, %v", vlans, err)
+		}
+
+		want := []network.BridgeVLANPort{{Name: "lan1", PVID: true}, {Name: "lan2", Tagged: true}}
+		if !slices.Equal(vlans[0].Ports, want) {
+			t.Errorf("unexpected ports: %+v", vlans[0].Ports)
+		}
+	})
+
+	t.Run("AddBridgePort", func(t *testing.T) {
+		err := mgr.AddBridgePort(ctx, "br-lan", "lan3", time.Millisecond)
+		if err != nil {
+			t.Fatalf("AddBridgePort failed: %v", err)
+		}
+
+		ports, _ := uciSetValues(t, mock, "set")["ports"].([]any)
+		if !slices.Equal(ports, []any{"lan1", "lan2", "lan3"}) {
+			t.Errorf("unexpected ports: %+v", uciSetValues(t, mock, "set"))
+		}
+
+		if call := mock.GetLastCall(); call.Service != "uci" || call.Method != "confirm" {
+			t.Errorf("expected the change to be confirmed, last call %+v", call)
+		}
+
+		err = mgr.AddBridgePort(ctx, "br-wan", "lan3", time.Millisecond)
+		if !errdefs.IsNotFound(err) {
+			t.Errorf("expected a missing bridge to fail, got %v", err)
+		}
+	})
+
+	t.Run("SetVLANPort", func(t *testing.T) {
+		err := mgr.SetVLANPort(ctx, "br-lan", 1, network.BridgeVLANPort{Name: "lan2"}, time.Millisecond)
+		if err != nil {
+			t.Fatalf("SetVLANPort failed: %v", err)
+		}
+
+		ports, _ := uciSetValues(t, mock, "set")["ports"].([]any)
+		if !slices.Equal(ports, []any{"lan1:u*", "lan2:u"}) {
+			t.Errorf("unexpected ports: %+v", uciSetValues(t, mock, "set"))
+		}
+	})
+
+	t.Run("Unconfirmed", func(t *testing.T) {
+		mock.AddResponse("network.interface", "dump", errdefs.Wrapf(errdefs.ErrTimeout, "no answer"))
+
+		err := mgr.ApplyChanges(ctx, time.Millisecond)
+		if !errdefs.IsTimeout(err) {
+			t.Errorf("expected the check to fail, got %v", err)
+		}
+
+		if call := mock.GetLastCall(); call.Method == "confirm" {
+			t.Error("changes were confirmed although the device did not answer")
+		}
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		for _, err := range []error{
+			mgr.SaveDeviceConfig(ctx, network.DeviceConfig{Name: "eth0", Ports: []string{"lan1"}}),
+			mgr.SaveDeviceConfig(ctx, network.DeviceConfig{Name: "br-lan", Type: "bridge", MACAddr: "nope"}),
+			mgr.SaveBridgeVLAN(ctx, network.BridgeVLAN{Device: "br-lan", VLAN: 4095}),
+			mgr.SaveBridgeVLAN(ctx, network.BridgeVLAN{
+				Device: "br-lan", VLAN: 2, Ports: []network.BridgeVLANPort{{Name: "lan1"}, {Name: "lan1", Tagged: true}},
+			}),
+		} {
+			if !errdefs.IsInvalidParameter(err) {
+				t.Errorf("expected an invalid parameter error, got %v", err)
+			}
+		}
+
+		_, err := network.ParseBridgeVLANPort("lan1:x")
+		if !errdefs.IsInvalidParameter(err) {
+			t.Errorf("expected an invalid port flag error, got %v", err)
+		}
+	})
+}
+
+// uciSetValues returns the values of the last uci call with the given method.
+func uciSetValues(t *testing.T, mock *testutil.MockTransport, method string) map[string]any {
+	t.Helper()
+
+	for i := len(mock.Calls) - 1; i >= 0; i-- {
+		if mock.Calls[i].Service != "uci" || mock.Calls[i].Method != method {
+			continue
+		}
+
+		data, err := json.Marshal(mock.Calls[i].Data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var req struct {
+			Values map[string]any `json:"values"`
+		}
+
+		err = json.Unmarshal(data, &req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return req.Values
+	}
+
+	return nil
+}
+
+func TestNetworkManagerNeighbors(t *testing.T) {
+	ctx := context.Background()
+	mock := testutil.NewMockTransport()
+	mock.AddResponse("file", "exec", map[string]any{
+		"code": 0,
+		"stdout": "192.168.1.20 dev br-lan lladdr AA:BB:CC:DD:EE:02 STALE\n" +
+			"192.168.1.3 dev br-lan lladdr aa:bb:cc:dd:ee:01 REACHABLE\n" +
+			"fe80::1 dev eth1 lladdr 00:11:22:33:44:55 router REACHABLE\n" +
+			"192.168.1.9 dev br-lan  FAILED\n",
+	})
+	mock.AddResponse("network.interface", "dump", map[string]any{
+		"interface": []any{
+			map[string]any{
+				"interface": "lan", "l3_device": "br-lan",
+				"neighbors": []any{
+					map[string]any{"address": "192.168.1.3", "mac": "aa:bb:cc:dd:ee:01", "state": 2},
+					map[string]any{"address": "192.168.1.4", "mac": "AA:BB:CC:DD:EE:04", "state": 128},
+				},
+			},
+		},
+	})
+	mock.AddResponse("luci-rpc", "getHostHints", map[string]any{
+		"AA:BB:CC:DD:EE:01": map[string]any{"name": "laptop", "ipaddrs": []string{"192.168.1.3"}},
+		"AA:BB:CC:DD:EE:05": map[string]any{"name": "printer", "ipaddrs": []string{"192.168.1.50"}},
+	})
+
+	mgr := network.New(mock, mockNetworkDialect{})
+
+	t.Run("Merged", func(t *testing.T) {
+		entries, err := mgr.Neighbors(ctx)
+		if err != nil {
+			t.Fatalf("Neighbors failed: %v", err)
+		}
+
+		want := []network.NeighborEntry{
+			{IP: "192.168.1.3", MAC: "aa:bb:cc:dd:ee:01", Device: "br-lan", State: "REACHABLE", Hostname: "laptop"},
+			{IP: "192.168.1.4", MAC: "aa:bb:cc:dd:ee:04", Device: "br-lan", State: "PERMANENT"},
+			{IP: "192.168.1.9", Device: "br-lan", State: "FAILED"},
+			{IP: "192.168.1.20", MAC: "aa:bb:cc:dd:ee:02", Device: "br-lan", State: "STALE"},
+			{IP: "192.168.1.50", MAC: "aa:bb:cc:dd:ee:05", Hostname: "printer"},
+			{IP: "fe80::1", MAC: "00:11:22:33:44:55", Device: "eth1", State: "REACHABLE", Router: true},
+		}
+		if !slices.Equal(entries, want) {
+			t.Errorf("unexpected neighbors:\n got %+v\nwant %+v", entries, want)
+		}
+	})
+
+	t.Run("Static", func(t *testing.T) {
+		err := mgr.AddStaticNeighbor(ctx, "192.168.1.60", "AA-BB-CC-DD-EE-06", "br-lan")
+		if err != nil {
+			t.Fatalf("AddStaticNeighbor failed: %v", err)
+		}
+
+		if got := execArgs(mock); got != "neigh replace 192.168.1.60 lladdr aa:bb:cc:dd:ee:06 nud permanent dev br-lan" {
+			t.Errorf("unexpected ip arguments: %s", got)
+		}
+
+		err = mgr.DeleteNeighbor(ctx, "192.168.1.60", "br-lan")
+		if err != nil || execArgs(mock) != "neigh delete 192.168.1.60 dev br-lan" {
+			t.Errorf("DeleteNeighbor: %v, %s", err, execArgs(mock))
+		}
+
+		err = mgr.AddStaticNeighbor(ctx, "192.168.1", "AA-BB-CC-DD-EE-06", "br-lan")
+		if !errdefs.IsInvalidParameter(err) {
+			t.Errorf("expected an invalid address error, got %v", err)
+		}
+	})
+
+	t.Run("Unavailable", func(t *testing.T) {
+		entries, err := network.New(testutil.NewMockTransport(), mockNetworkDialect{}).Neighbors(ctx)
+		if !errdefs.IsNotFound(err) || entries != nil {
+			t.Errorf("expected an error when no source answers, got %v, %v", entries, err)
+		}
+	})
+}
@@ -0,0 +1,228 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package network
+
+import (
+	"cmp"
+	"context"
+	"net"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+// nudStates maps the NUD state bits reported by netifd to the names ip(8) prints.
+var nudStates = []struct {
+	bit  int
+	name string
+}{
+	{0x01, "INCOMPLETE"},
+	{0x02, "REACHABLE"},
+	{0x04, "STALE"},
+	{0x08, "DELAY"},
+	{0x10, "PROBE"},
+	{0x20, "FAILED"},
+	{0x40, "NOARP"},
+	{0x80, "PERMANENT"},
+}
+
+type hostHint struct {
+	Name     string   `json:"name"`
+	IPAddrs  []string `json:"ipaddrs"`
+	IP6Addrs []string `json:"ip6addrs"`
+}
+
+// Neighbors returns the IPv4 and IPv6 neighbor table, sorted by address. It merges the kernel
+// table read with `ip neigh`, the neighbors netifd reports per interface and the LuCI host
+// hints, which add hostnames and hosts missing from the other sources. A source that is not
+// available, e.g. because the session may not exec /sbin/ip, is skipped; Neighbors only
+// fails if all of them fail.
+func (m *Manager) Neighbors(ctx context.Context) ([]NeighborEntry, error) {
+	table := make(map[neighborKey]*NeighborEntry)
+
+	ipErr := m.addKernelNeighbors(ctx, table)
+	ifaceErr := m.addInterfaceNeighbors(ctx, table)
+
+	hints, hintErr := goubus.Call[map[string]hostHint](ctx, m.caller, "luci-rpc", "getHostHints", nil)
+	if ipErr != nil && ifaceErr != nil && hintErr != nil {
+		return nil, errdefs.Wrapf(ipErr, "failed to read neighbors")
+	}
+
+	if hintErr == nil {
+		addHostHints(table, *hints)
+	}
+
+	entries := make([]NeighborEntry, 0, len(table))
+	for _, entry := range table {
+		entries = append(entries, *entry)
+	}
+
+	slices.SortFunc(entries, func(a, b NeighborEntry) int {
+		return cmp.Or(compareAddr(a.IP, b.IP), strings.Compare(a.Device, b.Device))
+	})
+
+	return entries, nil
+}
+
+// AddStaticNeighbor adds a permanent neighbor entry that maps ip to mac on device, replacing
+// an existing entry. Like the DeviceContext methods it runs ip(8) and is not persisted.
+func (m *Manager) AddStaticNeighbor(ctx context.Context, ip, mac, device string) error {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid neighbor address %q", ip)
+	}
+
+	hw, err := net.ParseMAC(mac)
+	if err != nil || len(hw) != macAddressLength {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid MAC address %q", mac)
+	}
+
+	return m.Devices().ip(ctx, device, "neigh", "replace", addr.String(), "lladdr", hw.String(),
+		"nud", "permanent", "dev", device)
+}
+
+// DeleteNeighbor deletes the neighbor entry of ip on device.
+func (m *Manager) DeleteNeighbor(ctx context.Context, ip, device string) error {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid neighbor address %q", ip)
+	}
+
+	return m.Devices().ip(ctx, device, "neigh", "delete", addr.String(), "dev", device)
+}
+
+type neighborKey struct {
+	ip     string
+	device string
+}
+
+func (m *Manager) addKernelNeighbors(ctx context.Context, table map[neighborKey]*NeighborEntry) error {
+	out, err := m.runIP(ctx, "neigh", "show")
+	if err != nil {
+		return err
+	}
+
+	for line := range strings.Lines(out) {
+		entry, ok := parseNeighborLine(line)
+		if ok {
+			table[neighborKey{entry.IP, entry.Device}] = &entry
+		}
+	}
+
+	return nil
+}
+
+func (m *Manager) addInterfaceNeighbors(ctx context.Context, table map[neighborKey]*NeighborEntry) error {
+	ifaces, err := m.DumpInterfaces(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, iface := range ifaces {
+		device := cmp.Or(iface.L3Device, iface.Device)
+
+		for _, neighbor := range iface.Neighbors {
+			key := neighborKey{neighbor.Address, device}
+			if _, ok := table[key]; ok {
+				continue
+			}
+
+			table[key] = &NeighborEntry{
+				IP:     neighbor.Address,
+				MAC:    strings.ToLower(neighbor.MAC),
+				Device: device,
+				State:  nudState(neighbor.State),
+				Router: bool(neighbor.Router),
+			}
+		}
+	}
+
+	return nil
+}
+
+// addHostHints sets the hostnames of known neighbors and adds the hinted addresses that no
+// other source reported.
+func addHostHints(table map[neighborKey]*NeighborEntry, hints map[string]hostHint) {
+	known := make(map[string]bool, len(table))
+
+	for _, entry := range table {
+		known[entry.IP] = true
+
+		hint, ok := hints[strings.ToUpper(entry.MAC)]
+		if ok && entry.MAC != "" {
+			entry.Hostname = hint.Name
+		}
+	}
+
+	for mac, hint := range hints {
+		for _, ip := range slices.Concat(hint.IPAddrs, hint.IP6Addrs) {
+			if known[ip] {
+				continue
+			}
+
+			table[neighborKey{ip: ip}] = &NeighborEntry{IP: ip, MAC: strings.ToLower(mac), Hostname: hint.Name}
+		}
+	}
+}
+
+// parseNeighborLine parses a line of `ip neigh show` such as
+// "192.168.1.10 dev br-lan lladdr 00:11:22:33:44:55 router REACHABLE".
+func parseNeighborLine(line string) (NeighborEntry, bool) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return NeighborEntry{}, false
+	}
+
+	entry := NeighborEntry{IP: fields[0]}
+
+	for i := 1; i < len(fields); i++ {
+		switch field := fields[i]; {
+		case (field == "dev" || field == "lladdr") && i+1 < len(fields):
+			i++
+			if field == "dev" {
+				entry.Device = fields[i]
+			} else {
+				entry.MAC = strings.ToLower(fields[i])
+			}
+		case field == "router":
+			entry.Router = true
+		case field == strings.ToUpper(field):
+			entry.State = field
+		}
+	}
+
+	return entry, entry.Device != ""
+}
+
+// nudState returns the name of the first NUD state bit set in state.
+func nudState(state int) string {
+	for _, nud := range nudStates {
+		if state&nud.bit != 0 {
+			return nud.name
+		}
+	}
+
+	return ""
+}
+
+// compareAddr orders IPv4 before IPv6 addresses and both numerically; unparsable addresses
+// sort last by text.
+func compareAddr(a, b string) int {
+	addrA, errA := netip.ParseAddr(a)
+	addrB, errB := netip.ParseAddr(b)
+
+	switch {
+	case errA != nil && errB != nil:
+		return strings.Compare(a, b)
+	case errA != nil:
+		return 1
+	case errB != nil:
+		return -1
+	}
+
+	return addrA.Compare(addrB)
+}
@@ -0,0 +1,477 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package network
+
+import (
+	"context"
+	"encoding/binary"
+	"math/bits"
+	"net/netip"
+	"slices"
+	"strconv"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/uci"
+)
+
+const (
+	uciPackage    = "network"
+	uciRouteType  = "route"
+	uciRoute6Type = "route6"
+	uciRuleType   = "rule"
+	uciRule6Type  = "rule6"
+)
+
+var (
+	routeTypes = []string{
+		"unicast", "local", "broadcast", "multicast", "unreachable", "prohibit", "blackhole", "anycast", "throw",
+	}
+	ruleActions = []string{"prohibit", "unreachable", "blackhole", "throw"}
+)
+
+// Routes retrieves the static IPv4 and IPv6 routes configured in /etc/config/network, in configuration order.
+func (m *Manager) Routes(ctx context.Context) ([]StaticRoute, error) {
+	sections, err := m.sectionsOfTypes(ctx, uciRouteType, uciRoute6Type)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read routes")
+	}
+
+	routes := make([]StaticRoute, 0, len(sections))
+	for _, section := range sections {
+		routes = append(routes, StaticRouteFromSection(section))
+	}
+
+	return routes, nil
+}
+
+// SaveRoute validates route, adds it as a route or route6 section, or updates the section named
+// by route.Section, and commits the network package. netifd applies the route on reload.
+func (m *Manager) SaveRoute(ctx context.Context, route StaticRoute) error {
+	err := route.Validate()
+	if err != nil {
+		return err
+	}
+
+	sectionType := uciRouteType
+	if route.IPv6 {
+		sectionType = uciRoute6Type
+	}
+
+	return m.saveSection(ctx, sectionType, route.Section, route.SectionValues())
+}
+
+// DeleteRoute deletes the route section and commits the network package.
+func (m *Manager) DeleteRoute(ctx context.Context, section string) error {
+	return m.deleteSection(ctx, section, uciRouteType, uciRoute6Type)
+}
+
+// Rules retrieves the IPv4 and IPv6 policy routing rules configured in /etc/config/network, in configuration order.
+func (m *Manager) Rules(ctx context.Context) ([]RoutingRule, error) {
+	sections, err := m.sectionsOfTypes(ctx, uciRuleType, uciRule6Type)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read rules")
+	}
+
+	rules := make([]RoutingRule, 0, len(sections))
+	for _, section := range sections {
+		rules = append(rules, RoutingRuleFromSection(section))
+	}
+
+	return rules, nil
+}
+
+// SaveRule validates rule, adds it as a rule or rule6 section, or updates the section named by
+// rule.Section, and commits the network package.
+func (m *Manager) SaveRule(ctx context.Context, rule RoutingRule) error {
+	err := rule.Validate()
+	if err != nil {
+		return err
+	}
+
+	sectionType := uciRuleType
+	if rule.IPv6 {
+		sectionType = uciRule6Type
+	}
+
+	return m.saveSection(ctx, sectionType, rule.Section, rule.SectionValues())
+}
+
+// DeleteRule deletes the rule section and commits the network package.
+func (m *Manager) DeleteRule(ctx context.Context, section string) error {
+	return m.deleteSection(ctx, section, uciRuleType, uciRule6Type)
+}
+
+// RouteTable correlates the configured routes with the routes in the interface status. Configured
+// routes come first, in configuration order, followed by the routes that protocols installed.
+func (m *Manager) RouteTable(ctx context.Context) ([]RouteEntry, error) {
+	routes, err := m.Routes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ifaces, err := m.DumpInterfaces(ctx)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read interface routes")
+	}
+
+	live := make(map[string][]*Route, len(ifaces))
+
+	for i := range ifaces {
+		for j := range ifaces[i].Route {
+			live[ifaces[i].Interface] = append(live[ifaces[i].Interface], &ifaces[i].Route[j])
+		}
+	}
+
+	entries := make([]RouteEntry, 0, len(routes))
+
+	for i := range routes {
+		entry := RouteEntry{Config: &routes[i], Interface: routes[i].Interface}
+		candidates := live[entry.Interface]
+
+		index := slices.IndexFunc(candidates, routes[i].Matches)
+		if index >= 0 {
+			entry.Live = candidates[index]
+			live[entry.Interface] = slices.Delete(candidates, index, index+1)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	for _, iface := range ifaces {
+		for _, route := range live[iface.Interface] {
+			entries = append(entries, RouteEntry{Live: route, Interface: iface.Interface})
+		}
+	}
+
+	return entries, nil
+}
+
+func (m *Manager) sectionsOfTypes(ctx context.Context, types ...string) ([]*uci.Section, error) {
+	sections, err := m.uci.Package(uciPackage).GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []*uci.Section
+
+	for _, section := range uci.SortedSections(sections) {
+		if slices.Contains(types, section.Type) {
+			matched = append(matched, section)
+		}
+	}
+
+	return matched, nil
+}
+
+func (m *Manager) saveSection(ctx context.Context, sectionType, name string, values uci.SectionValues) error {
+	err := m.stageSection(ctx, sectionType, name, values)
+	if err != nil {
+		return err
+	}
+
+	return m.uci.Package(uciPackage).Commit(ctx)
+}
+
+// stageSection adds a section, or updates the named one, without committing the change.
+func (m *Manager) stageSection(ctx context.Context, sectionType, name string, values uci.SectionValues) error {
+	pkg := m.uci.Package(uciPackage)
+
+	var err error
+	if name == "" {
+		err = pkg.Add(ctx, sectionType, "", values)
+	} else {
+		err = pkg.Section(name).SetValues(ctx, values)
+	}
+
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to save %s section", sectionType)
+	}
+
+	return nil
+}
+
+// deleteSection deletes a section after checking that it has one of the given types.
+func (m *Manager) deleteSection(ctx context.Context, name string, types ...string) error {
+	pkg := m.uci.Package(uciPackage)
+
+	section, err := pkg.Section(name).Get(ctx)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to read section %s", name)
+	}
+
+	if !slices.Contains(types, section.Type) {
+		return errdefs.Wrapf(errdefs.ErrNotFound, "section %s is a %s, not a %s", name, section.Type, types[0])
+	}
+
+	err = pkg.Section(name).Delete(ctx)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to delete section %s", name)
+	}
+
+	return pkg.Commit(ctx)
+}
+
+// StaticRouteFromSection converts a UCI route or route6 section into a StaticRoute.
+func StaticRouteFromSection(section *uci.Section) StaticRoute {
+	return StaticRoute{
+		Section:   section.Name,
+		Interface: section.GetString("interface"),
+		Target:    section.GetString("target"),
+		Netmask:   section.GetString("netmask"),
+		Gateway:   section.GetString("gateway"),
+		Source:    section.GetString("source"),
+		Type:      section.GetString("type"),
+		Table:     section.GetString("table"),
+		Metric:    section.GetInt("metric"),
+		MTU:       section.GetInt("mtu"),
+		IPv6:      section.Type == uciRoute6Type,
+		OnLink:    section.GetBool("onlink"),
+		Disabled:  section.GetBool("disabled"),
+	}
+}
+
+// SectionValues converts the route into UCI option values.
+func (r *StaticRoute) SectionValues() uci.SectionValues {
+	values := uci.NewSectionValues()
+	values.SetScalar("interface", r.Interface)
+	values.SetScalar("target", r.Target)
+	values.SetScalar("netmask", r.Netmask)
+	values.SetScalar("gateway", r.Gateway)
+	values.SetScalar("source", r.Source)
+	values.SetScalar("type", r.Type)
+	values.SetScalar("table", r.Table)
+	setInt(&values, "metric", r.Metric)
+	setInt(&values, "mtu", r.MTU)
+	setFlag(&values, "onlink", r.OnLink)
+	setFlag(&values, "disabled", r.Disabled)
+
+	return values
+}
+
+// Prefix returns the destination prefix of the route from Target and Netmask.
+func (r *StaticRoute) Prefix() (netip.Prefix, error) {
+	if r.Netmask == "" {
+		return parsePrefix(r.Target)
+	}
+
+	addr, err := netip.ParseAddr(r.Target)
+	if err != nil || !addr.Is4() {
+		return netip.Prefix{}, errdefs.Wrapf(errdefs.ErrInvalidParameter,
+			"netmask needs an IPv4 target without prefix length, got %q", r.Target)
+	}
+
+	mask, err := netip.ParseAddr(r.Netmask)
+	if err != nil || !mask.Is4() {
+		return netip.Prefix{}, errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid netmask %q", r.Netmask)
+	}
+
+	bits, ok := maskBits(mask)
+	if !ok {
+		return netip.Prefix{}, errdefs.Wrapf(errdefs.ErrInvalidParameter, "non-contiguous netmask %q", r.Netmask)
+	}
+
+	return netip.PrefixFrom(addr, bits), nil
+}
+
+// Validate checks the route the way netifd reads it.
+func (r *StaticRoute) Validate() error {
+	if r.Interface == "" {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "route needs an interface")
+	}
+
+	prefix, err := r.Prefix()
+	if err != nil {
+		return err
+	}
+
+	if prefix.Masked() != prefix {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "route target %s has host bits set", prefix)
+	}
+
+	for _, check := range []error{
+		checkFamily("target", prefix.Addr(), r.IPv6),
+		checkAddr("gateway", r.Gateway, r.IPv6),
+		checkPrefix("source", r.Source, r.IPv6),
+		checkChoice("route type", r.Type, routeTypes),
+	} {
+		if check != nil {
+			return check
+		}
+	}
+
+	if r.Metric < 0 || r.MTU < 0 {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "negative metric or MTU")
+	}
+
+	return nil
+}
+
+// Matches reports whether live is the route netifd installed for r.
+func (r *StaticRoute) Matches(live *Route) bool {
+	prefix, err := r.Prefix()
+	if err != nil {
+		return false
+	}
+
+	addr, err := netip.ParseAddr(live.Target)
+	if err != nil || netip.PrefixFrom(addr, live.Mask) != prefix {
+		return false
+	}
+
+	return r.Gateway == "" || r.Gateway == live.Nexthop
+}
+
+// RoutingRuleFromSection converts a UCI rule or rule6 section into a RoutingRule.
+func RoutingRuleFromSection(section *uci.Section) RoutingRule {
+	return RoutingRule{
+		Section:  section.Name,
+		In:       section.GetString("in"),
+		Out:      section.GetString("out"),
+		Src:      section.GetString("src"),
+		Dest:     section.GetString("dest"),
+		Mark:     section.GetString("mark"),
+		Lookup:   section.GetString("lookup"),
+		Action:   section.GetString("action"),
+		Priority: section.GetInt("priority"),
+		Goto:     section.GetInt("goto"),
+		IPv6:     section.Type == uciRule6Type,
+		Invert:   section.GetBool("invert"),
+		Disabled: section.GetBool("disabled"),
+	}
+}
+
+// SectionValues converts the rule into UCI option values.
+func (r *RoutingRule) SectionValues() uci.SectionValues {
+	values := uci.NewSectionValues()
+	values.SetScalar("in", r.In)
+	values.SetScalar("out", r.Out)
+	values.SetScalar("src", r.Src)
+	values.SetScalar("dest", r.Dest)
+	values.SetScalar("mark", r.Mark)
+	values.SetScalar("lookup", r.Lookup)
+	values.SetScalar("action", r.Action)
+	setInt(&values, "priority", r.Priority)
+	setInt(&values, "goto", r.Goto)
+	setFlag(&values, "invert", r.Invert)
+	setFlag(&values, "disabled", r.Disabled)
+
+	return values
+}
+
+// Validate checks that the rule has exactly one of a lookup table, an action or a goto target.
+func (r *RoutingRule) Validate() error {
+	targets := 0
+
+	for _, set := range []bool{r.Lookup != "", r.Action != "", r.Goto > 0} {
+		if set {
+			targets++
+		}
+	}
+
+	if targets != 1 {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "rule needs exactly one of lookup, action or goto")
+	}
+
+	for _, check := range []error{
+		checkPrefix("source", r.Src, r.IPv6),
+		checkPrefix("destination", r.Dest, r.IPv6),
+		checkChoice("rule action", r.Action, ruleActions),
+	} {
+		if check != nil {
+			return check
+		}
+	}
+
+	if r.Priority < 0 || r.Goto < 0 {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "negative priority or goto")
+	}
+
+	return nil
+}
+
+// parsePrefix parses a CIDR prefix or a single address as a host prefix.
+func parsePrefix(value string) (netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(value)
+	if err == nil {
+		return prefix, nil
+	}
+
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return netip.Prefix{}, errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid address or prefix %q", value)
+	}
+
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// maskBits returns the prefix length of a contiguous IPv4 netmask.
+func maskBits(mask netip.Addr) (int, bool) {
+	octets := mask.As4()
+	value := binary.BigEndian.Uint32(octets[:])
+	ones := bits.LeadingZeros32(^value)
+
+	return ones, value<<ones == 0
+}
+
+func checkFamily(name string, addr netip.Addr, ipv6 bool) error {
+	if addr.Unmap().Is6() != ipv6 {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "%s %s does not match the address family", name, addr)
+	}
+
+	return nil
+}
+
+func checkAddr(name, value string, ipv6 bool) error {
+	if value == "" {
+		return nil
+	}
+
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid %s %q", name, value)
+	}
+
+	return checkFamily(name, addr, ipv6)
+}
+
+func checkPrefix(name, value string, ipv6 bool) error {
+	if value == "" {
+		return nil
+	}
+
+	prefix, err := parsePrefix(value)
+	if err != nil {
+		return errdefs.Wrapf(err, "invalid %s", name)
+	}
+
+	return checkFamily(name, prefix.Addr(), ipv6)
+}
+
+func checkChoice(name, value string, choices []string) error {
+	if value != "" && !slices.Contains(choices, value) {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid %s %q", name, value)
+	}
+
+	return nil
+}
+
+func setInt(values *uci.SectionValues, option string, value int) {
+	if value == 0 {
+		values.Set(option)
+
+		return
+	}
+
+	values.Set(option, strconv.Itoa(value))
+}
+
+func setFlag(values *uci.SectionValues, option string, value bool) {
+	if !value {
+		values.Set(option)
+
+		return
+	}
+
+	values.SetBool(option, true)
+}
@@ -176,3 +176,104 @@ type WirelessNotifyRequest struct {
 	Vlan      string         `json:"vlan,omitempty"`
 	Command   int            `json:"command"`
 }
+
+// StaticRoute is a "route" or "route6" section of /etc/config/network.
+type StaticRoute struct {
+	// Section is the UCI section name; it is empty for routes that were not saved yet.
+	Section   string `json:"section,omitempty"`
+	Interface string `json:"interface"`
+	// Target is an address or a CIDR prefix, e.g. "10.8.0.0/24".
+	Target string `json:"target"`
+	// Netmask is the IPv4 netmask of Target when it is not given in CIDR notation.
+	Netmask string `json:"netmask,omitempty"`
+	Gateway string `json:"gateway,omitempty"`
+	Source  string `json:"source,omitempty"`
+	// Type is the route type, e.g. "unicast", "blackhole" or "unreachable"; empty means unicast.
+	Type     string `json:"type,omitempty"`
+	Table    string `json:"table,omitempty"`
+	Metric   int    `json:"metric,omitempty"`
+	MTU      int    `json:"mtu,omitempty"`
+	IPv6     bool   `json:"ipv6"`
+	OnLink   bool   `json:"onlink,omitempty"`
+	Disabled bool   `json:"disabled,omitempty"`
+}
+
+// RoutingRule is a "rule" or "rule6" policy routing section of /etc/config/network.
+type RoutingRule struct {
+	// Section is the UCI section name; it is empty for rules that were not saved yet.
+	Section string `json:"section,omitempty"`
+	// In and Out are the logical interfaces the traffic enters or leaves through.
+	In   string `json:"in,omitempty"`
+	Out  string `json:"out,omitempty"`
+	Src  string `json:"src,omitempty"`
+	Dest string `json:"dest,omitempty"`
+	// Mark matches the firewall mark, optionally with a mask, e.g. "0x1/0xff".
+	Mark string `json:"mark,omitempty"`
+	// Lookup is the routing table to use, by name or number.
+	Lookup string `json:"lookup,omitempty"`
+	// Action is "prohibit", "unreachable", "blackhole" or "throw" instead of a lookup.
+	Action   string `json:"action,omitempty"`
+	Priority int    `json:"priority,omitempty"`
+	Goto     int    `json:"goto,omitempty"`
+	IPv6     bool   `json:"ipv6"`
+	Invert   bool   `json:"invert,omitempty"`
+	Disabled bool   `json:"disabled,omitempty"`
+}
+
+// RouteEntry correlates a configured route with the route netifd installed for it.
+type RouteEntry struct {
+	// Config is the configured route; it is nil for routes added by a protocol, e.g. a DHCP default route.
+	Config *StaticRoute `json:"config,omitempty"`
+	// Live is the route from the interface status; it is nil for configured routes that are not installed.
+	Live      *Route `json:"live,omitempty"`
+	Interface string `json:"interface"`
+}
+
+// Active reports whether the route is installed.
+func (e *RouteEntry) Active() bool {
+	return e.Live != nil
+}
+
+// DeviceConfig is a "device" section of /etc/config/network, e.g. the br-lan bridge of a DSA switch.
+type DeviceConfig struct {
+	// Section is the UCI section name; it is empty for devices that were not saved yet.
+	Section string `json:"section,omitempty"`
+	Name    string `json:"name"`
+	// Type is the device type, e.g. "bridge" or "8021q"; empty means a plain ethernet device.
+	Type    string `json:"type,omitempty"`
+	MACAddr string `json:"macaddr,omitempty"`
+	// Ports are the bridge member devices.
+	Ports []string `json:"ports,omitempty"`
+	MTU   int      `json:"mtu,omitempty"`
+}
+
+// BridgeVLAN is a "bridge-vlan" section of /etc/config/network, which assigns bridge ports to a VLAN.
+type BridgeVLAN struct {
+	// Section is the UCI section name; it is empty for VLANs that were not saved yet.
+	Section string `json:"section,omitempty"`
+	// Device is the bridge, e.g. "br-lan".
+	Device string           `json:"device"`
+	Ports  []BridgeVLANPort `json:"ports,omitempty"`
+	VLAN   int              `json:"vlan"`
+}
+
+// BridgeVLANPort is a member of a bridge VLAN, written "lan1", "lan1:t" or "lan1:u*" in UCI.
+type BridgeVLANPort struct {
+	Name string `json:"name"`
+	// Tagged sends the VLAN tagged on the port; untagged ports carry it without a tag.
+	Tagged bool `json:"tagged"`
+	// PVID makes the VLAN the port's native VLAN for untagged ingress traffic.
+	PVID bool `json:"pvid"`
+}
+
+// NeighborEntry is an entry of the merged neighbor table returned by Neighbors.
+type NeighborEntry struct {
+	IP     string
+	MAC    string
+	Device string
+	// State is the kernel NUD state, e.g. "REACHABLE", "STALE" or "PERMANENT". It is empty for
+	// hosts that are only known from the LuCI host hints.
+	State    string
+	Hostname string
+	Router   bool
+}
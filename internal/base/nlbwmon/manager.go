@@ -0,0 +1,211 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package nlbwmon
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/file"
+	"github.com/honeybbq/goubus/v2/internal/base/rc"
+)
+
+const (
+	nlbwBinary = "/usr/sbin/nlbw"
+	initScript = "nlbwmon"
+)
+
+var groupColumns = []string{GroupFamily, GroupProtocol, GroupPort, GroupMAC, GroupIP, GroupLayer7}
+
+// Manager wraps nlbwmon, the netlink bandwidth monitor. nlbwmon does not register a ubus
+// object, so the manager runs its nlbw client via file.exec, like LuCI does; the session
+// needs exec permission for /usr/sbin/nlbw in its rpcd ACL.
+type Manager struct {
+	file *file.Manager
+	rc   *rc.Manager
+}
+
+// New creates a new base nlbwmon Manager.
+func New(t goubus.Transport) *Manager {
+	return &Manager{
+		file: file.New(t),
+		rc:   rc.New(t),
+	}
+}
+
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"file", "rc"}
+}
+
+// Dump retrieves the accounting data selected by q.
+func (m *Manager) Dump(ctx context.Context, q Query) ([]Record, error) {
+	for _, column := range q.GroupBy {
+		if !slices.Contains(groupColumns, column) {
+			return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "unknown nlbwmon column %q", column)
+		}
+	}
+
+	args := queryArgs(FormatJSON, q.Period)
+	if len(q.GroupBy) > 0 {
+		args = append(args, "-g", strings.Join(q.GroupBy, ","))
+	}
+
+	out, err := m.run(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseDump(out)
+}
+
+// Clients retrieves the traffic per client MAC and IP address of a period, largest first.
+func (m *Manager) Clients(ctx context.Context, period string) ([]Record, error) {
+	records, err := m.Dump(ctx, Query{Period: period, GroupBy: []string{GroupMAC, GroupIP}})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortStableFunc(records, func(a, b Record) int {
+		return cmp.Or(cmp.Compare(b.TotalBytes(), a.TotalBytes()), strings.Compare(a.MAC, b.MAC))
+	})
+
+	return records, nil
+}
+
+// Export returns the raw accounting data of a period in FormatCSV or FormatJSON, e.g. to
+// offer it for download.
+func (m *Manager) Export(ctx context.Context, period, format string) (string, error) {
+	if format != FormatCSV && format != FormatJSON {
+		return "", errdefs.Wrapf(errdefs.ErrInvalidParameter, "unknown export format %q", format)
+	}
+
+	return m.run(ctx, queryArgs(format, period)...)
+}
+
+// Periods lists the start dates of the stored accounting periods, oldest first.
+func (m *Manager) Periods(ctx context.Context) ([]string, error) {
+	out, err := m.run(ctx, "-c", "list")
+	if err != nil {
+		return nil, err
+	}
+
+	periods := strings.Fields(out)
+	slices.Sort(periods)
+
+	return periods, nil
+}
+
+// Commit makes nlbwmon write its in-memory counters to the database.
+func (m *Manager) Commit(ctx context.Context) error {
+	_, err := m.run(ctx, "-c", "commit")
+
+	return err
+}
+
+// Restart commits the counters and restarts nlbwmon, e.g. to apply a changed accounting period.
+func (m *Manager) Restart(ctx context.Context) error {
+	err := m.Commit(ctx)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to commit before restart")
+	}
+
+	return m.rc.Init(ctx, initScript, "restart")
+}
+
+func queryArgs(format, period string) []string {
+	args := []string{"-c", format}
+	if period != "" {
+		args = append(args, "-t", period)
+	}
+
+	return args
+}
+
+func (m *Manager) run(ctx context.Context, args ...string) (string, error) {
+	res, err := m.file.Exec(ctx, nlbwBinary, args, nil)
+	if err != nil {
+		return "", errdefs.Wrapf(err, "failed to run nlbw %s", args[1])
+	}
+
+	if res.Code != 0 {
+		return "", errdefs.Wrapf(errdefs.ErrUnknown, "nlbw %s exited with code %d: %s",
+			args[1], res.Code, strings.TrimSpace(res.Stderr))
+	}
+
+	return res.Stdout, nil
+}
+
+// ParseDump decodes the output of "nlbw -c json".
+func ParseDump(data string) ([]Record, error) {
+	var d dump
+
+	// Byte counters may exceed the integer precision of float64.
+	dec := json.NewDecoder(strings.NewReader(data))
+	dec.UseNumber()
+
+	err := dec.Decode(&d)
+	if err != nil {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidResponse, "failed to decode nlbw output: %v", err)
+	}
+
+	records := make([]Record, 0, len(d.Data))
+
+	for _, row := range d.Data {
+		var record Record
+
+		for i, column := range d.Columns {
+			if i < len(row) {
+				record.set(column, row[i])
+			}
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func (r *Record) set(column string, value any) {
+	number, _ := value.(json.Number)
+	integer, _ := number.Int64()
+	text, _ := value.(string)
+
+	switch column {
+	case GroupFamily:
+		r.Family = int(integer)
+	case GroupProtocol:
+		r.Protocol = text
+	case GroupPort:
+		r.Port = int(integer)
+	case GroupMAC:
+		r.MAC = text
+	case GroupIP:
+		r.IP = text
+	case GroupLayer7:
+		r.Layer7 = text
+	default:
+		r.setCounter(column, integer)
+	}
+}
+
+func (r *Record) setCounter(column string, value int64) {
+	switch column {
+	case "conns":
+		r.Conns = value
+	case "rx_bytes":
+		r.RxBytes = value
+	case "rx_pkts":
+		r.RxPackets = value
+	case "tx_bytes":
+		r.TxBytes = value
+	case "tx_pkts":
+		r.TxPackets = value
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package nlbwmon_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/nlbwmon"
+	"github.com/honeybbq/goubus/v2/internal/testutil"
+)
+
+func nlbw(args ...string) map[string]any {
+	return map[string]any{"command": "/usr/sbin/nlbw", "params": args}
+}
+
+func TestNlbwmonManager(t *testing.T) {
+	ctx := context.Background()
+	mock := testutil.NewMockTransport()
+	mgr := nlbwmon.New(mock)
+
+	t.Run("Clients", func(t *testing.T) {
+		mock.AddResponseForArgs("file", "exec", nlbw("-c", "json", "-t", "2026-10-01", "-g", "mac,ip"), map[string]any{
+			"code": 0,
+			"stdout": `{"columns":["mac","ip","conns","rx_bytes","rx_pkts","tx_bytes","tx_pkts"],"data":[` +
+				`["aa:bb:cc:dd:ee:01","192.168.1.10",12,1000,10,500,5],` +
+				`["aa:bb:cc:dd:ee:02","192.168.1.20",40,9007199254740993,900,100,2]]}`,
+		})
+
+		clients, err := mgr.Clients(ctx, "2026-10-01")
+		if err != nil {
+			t.Fatalf("Clients failed: %v", err)
+		}
+
+		want := []nlbwmon.Record{
+			{MAC: "aa:bb:cc:dd:ee:02", IP: "192.168.1.20", Conns: 40, RxBytes: 9007199254740993, RxPackets: 900,
+				TxBytes: 100, TxPackets: 2},
+			{MAC: "aa:bb:cc:dd:ee:01", IP: "192.168.1.10", Conns: 12, RxBytes: 1000, RxPackets: 10,
+				TxBytes: 500, TxPackets: 5},
+		}
+		if !slices.Equal(clients, want) {
+			t.Errorf("unexpected clients: %+v", clients)
+		}
+	})
+
+	t.Run("Dump", func(t *testing.T) {
+		mock.AddResponseForArgs("file", "exec", nlbw("-c", "json"), map[string]any{
+			"code": 0,
+			"stdout": `{"columns":["family","proto","port","mac","ip","conns","rx_bytes","rx_pkts","tx_bytes",` +
+				`"tx_pkts","layer7"],"data":[[6,"tcp",443,"aa:bb:cc:dd:ee:01","fd00::10",3,100,2,50,1,null]]}`,
+		})
+
+		records, err := mgr.Dump(ctx, nlbwmon.Query{})
+		if err != nil || len(records) != 1 {
+			t.Fatalf("Dump: %+v, %v", records, err)
+		}
+
+		if r := records[0]; r.Family != 6 || r.Protocol != "tcp" || r.Port != 443 || r.Layer7 != "" || r.TotalBytes() != 150 {
+			t.Errorf("unexpected record: %+v", r)
+		}
+
+		_, err = mgr.Dump(ctx, nlbwmon.Query{GroupBy: []string{"host"}})
+		if !errdefs.IsInvalidParameter(err) {
+			t.Errorf("expected an unknown column error, got %v", err)
+		}
+	})
+
+	t.Run("Periods", func(t *testing.T) {
+		mock.AddResponseForArgs("file", "exec", nlbw("-c", "list"), map[string]any{
+			"code": 0, "stdout": "2026-10-01\n2026-08-01\n2026-09-01\n",
+		})
+
+		periods, err := mgr.Periods(ctx)
+		if err != nil || !slices.Equal(periods, []string{"2026-08-01", "2026-09-01", "2026-10-01"}) {
+			t.Errorf("Periods: %v, %v", periods, err)
+		}
+	})
+
+	t.Run("Restart", func(t *testing.T) {
+		mock.AddResponseForArgs("file", "exec", nlbw("-c", "commit"), map[string]any{"code": 0})
+		mock.AddResponse("rc", "init", map[string]any{})
+
+		err := mgr.Restart(ctx)
+		if err != nil {
+			t.Fatalf("Restart failed: %v", err)
+		}
+
+		if call := mock.GetLastCall(); call.Service != "rc" || call.Method != "init" {
+			t.Errorf("expected an rc init call, got %+v", call)
+		}
+	})
+
+	t.Run("ExecError", func(t *testing.T) {
+		mock.AddResponseForArgs("file", "exec", nlbw("-c", "csv"), map[string]any{
+			"code": 1, "stderr": "Unable to connect to nlbwmon socket",
+		})
+
+		_, err := mgr.Export(ctx, "", nlbwmon.FormatCSV)
+		if !errdefs.IsUnknown(err) {
+			t.Errorf("expected an exec error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package nlbwmon
+
+// Columns of a Query.GroupBy.
+const (
+	GroupFamily   = "family"
+	GroupProtocol = "proto"
+	GroupPort     = "port"
+	GroupMAC      = "mac"
+	GroupIP       = "ip"
+	GroupLayer7   = "layer7"
+)
+
+// Export formats of nlbw.
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+// Query selects the accounting data returned by Dump.
+type Query struct {
+	// Period is the start date of an accounting period as returned by Periods, e.g.
+	// "2026-10-01". Empty selects the current period.
+	Period string
+	// GroupBy lists the columns to aggregate by. Empty keeps nlbw's default grouping by
+	// family, protocol, port, MAC, IP and layer 7 protocol.
+	GroupBy []string
+}
+
+// Record is a row of nlbwmon accounting data. Columns that are not part of the grouping of
+// the query are zero.
+type Record struct {
+	// Family is 4 or 6.
+	Family   int
+	Protocol string
+	Port     int
+	MAC      string
+	IP       string
+	Layer7   string
+	// Conns is the number of connections seen.
+	Conns int64
+	// RxBytes and RxPackets were received by the host, i.e. downloaded.
+	RxBytes   int64
+	RxPackets int64
+	// TxBytes and TxPackets were sent by the host, i.e. uploaded.
+	TxBytes   int64
+	TxPackets int64
+}
+
+// TotalBytes returns the bytes of both directions.
+func (r *Record) TotalBytes() int64 {
+	return r.RxBytes + r.TxBytes
+}
+
+// dump is the output of "nlbw -c json".
+type dump struct {
+	Columns []string `json:"columns"`
+	Data    [][]any  `json:"data"`
+}
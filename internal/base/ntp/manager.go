@@ -0,0 +1,185 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package ntp
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/file"
+	"github.com/honeybbq/goubus/v2/internal/base/luci"
+	"github.com/honeybbq/goubus/v2/internal/base/rc"
+	"github.com/honeybbq/goubus/v2/internal/base/system"
+	"github.com/honeybbq/goubus/v2/internal/base/uci"
+)
+
+const (
+	uciPackage = "system"
+	uciSection = "ntp"
+	initScript = "sysntpd"
+	rtcPath    = "/sys/class/rtc/rtc0/since_epoch"
+)
+
+// Manager reads and controls time synchronization through sysntpd, the busybox ntpd service
+// of OpenWrt. busybox ntpd does not publish its peers over ubus, so the status is limited to
+// the clocks, the configuration and whether the service runs.
+type Manager struct {
+	luci   *luci.Manager
+	system *system.Manager
+	uci    *uci.Manager
+	file   *file.Manager
+	rc     *rc.Manager
+}
+
+// New creates a new base NTP Manager. d selects the luci method that reads the system clock.
+func New(t goubus.Transport, d luci.Dialect) *Manager {
+	return &Manager{
+		luci:   luci.New(t, d),
+		system: system.New(t),
+		uci:    uci.New(t, nil),
+		file:   file.New(t),
+		rc:     rc.New(t),
+	}
+}
+
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"luci", "system", "uci", "file", "rc"}
+}
+
+// Status reads the clocks, the NTP configuration and the state of sysntpd.
+func (m *Manager) Status(ctx context.Context) (*Status, error) {
+	now, err := m.luci.GetTime(ctx)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read system time")
+	}
+
+	info, err := m.system.Info(ctx)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read system info")
+	}
+
+	cfg, err := m.Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	services, err := m.rc.List(ctx, initScript, false)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read %s state", initScript)
+	}
+
+	status := &Status{
+		SystemTime: now,
+		LocalTime:  time.Unix(info.LocalTime, 0).UTC(),
+		Config:     *cfg,
+		Running:    bool(services[initScript].Running),
+	}
+
+	// Devices without an RTC or sessions without read access leave RTC zero.
+	rtc, err := m.file.Read(ctx, rtcPath, false)
+	if err == nil {
+		seconds, parseErr := strconv.ParseInt(strings.TrimSpace(rtc.Data), 10, 64)
+		if parseErr == nil {
+			status.RTC = time.Unix(seconds, 0)
+			status.RTCDrift = now.Sub(status.RTC)
+		}
+	}
+
+	return status, nil
+}
+
+// Config retrieves the NTP configuration.
+func (m *Manager) Config(ctx context.Context) (*Config, error) {
+	section, err := m.uci.Package(uciPackage).Section(uciSection).Get(ctx)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read NTP config")
+	}
+
+	cfg := ConfigFromSection(section)
+
+	return &cfg, nil
+}
+
+// SetConfig stages the configuration, commits the system package and restarts sysntpd to apply it.
+func (m *Manager) SetConfig(ctx context.Context, cfg Config) error {
+	pkg := m.uci.Package(uciPackage)
+
+	err := pkg.Section(uciSection).SetValues(ctx, cfg.SectionValues())
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to set NTP config")
+	}
+
+	err = pkg.Commit(ctx)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to commit NTP config")
+	}
+
+	return m.Resync(ctx)
+}
+
+// SetServers replaces the NTP servers and applies them.
+func (m *Manager) SetServers(ctx context.Context, servers ...string) error {
+	cfg, err := m.Config(ctx)
+	if err != nil {
+		return err
+	}
+
+	cfg.Servers = servers
+
+	return m.SetConfig(ctx, *cfg)
+}
+
+// Resync restarts sysntpd, which makes busybox ntpd query its servers and step the clock right away.
+func (m *Manager) Resync(ctx context.Context) error {
+	err := m.rc.Init(ctx, initScript, "restart")
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to restart %s", initScript)
+	}
+
+	return nil
+}
+
+// SetClock sets the system clock through luci setLocaltime. A running NTP client corrects it
+// again on its next poll.
+func (m *Manager) SetClock(ctx context.Context, t time.Time) error {
+	return m.luci.SetLocaltime(ctx, t)
+}
+
+// ConfigFromSection converts a UCI timeserver section into a Config. Like sysntpd, it treats
+// missing enabled and use_dhcp options as enabled.
+func ConfigFromSection(section *uci.Section) Config {
+	return Config{
+		Servers:      section.Get("server"),
+		Interface:    section.GetString("interface"),
+		Enabled:      boolDefaultTrue(section, "enabled"),
+		EnableServer: section.GetBool("enable_server"),
+		UseDHCP:      boolDefaultTrue(section, "use_dhcp"),
+	}
+}
+
+func boolDefaultTrue(section *uci.Section, option string) bool {
+	return section.GetString(option) == "" || section.GetBool(option)
+}
+
+// SectionValues converts the Config into UCI option values.
+func (c *Config) SectionValues() uci.SectionValues {
+	values := uci.NewSectionValues()
+	values.SetBool("enabled", c.Enabled)
+	values.SetBool("enable_server", c.EnableServer)
+	values.SetBool("use_dhcp", c.UseDHCP)
+	values.SetScalar("interface", c.Interface)
+
+	if len(c.Servers) > 0 {
+		values.SetList("server", c.Servers...)
+	} else {
+		values.Set("server")
+	}
+
+	return values
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package ntp_test
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/honeybbq/goubus/v2/internal/base/ntp"
+	"github.com/honeybbq/goubus/v2/internal/testutil"
+)
+
+type mockLuciDialect struct{}
+
+func (mockLuciDialect) GetTimeMethod() string { return "getUnixtime" }
+
+func TestNTPManager(t *testing.T) {
+	ctx := context.Background()
+	mock := testutil.NewMockTransport()
+	mock.AddResponse("luci", "getUnixtime", map[string]any{"result": 1760000100})
+	mock.AddResponse("system", "info", map[string]any{"localtime": 1760028900})
+	mock.AddResponse("rc", "list", map[string]any{"sysntpd": map[string]any{"running": true, "enabled": true}})
+	mock.AddResponse("file", "read", map[string]any{"data": "1760000000\n"})
+	mock.AddResponse("uci", "get", map[string]any{
+		"values": map[string]any{
+			".type": "timeserver", ".name": "ntp",
+			"server": []string{"0.openwrt.pool.ntp.org", "1.openwrt.pool.ntp.org"},
+		},
+	})
+
+	mgr := ntp.New(mock, mockLuciDialect{})
+
+	t.Run("Status", func(t *testing.T) {
+		status, err := mgr.Status(ctx)
+		if err != nil {
+			t.Fatalf("Status failed: %v", err)
+		}
+
+		if !status.Running || status.RTCDrift != 100*time.Second || status.LocalTime.Hour() != 16 {
+			t.Errorf("unexpected status: %+v", status)
+		}
+
+		if !status.Config.Enabled || !status.Config.UseDHCP || status.Config.EnableServer {
+			t.Errorf("expected the sysntpd defaults, got %+v", status.Config)
+		}
+	})
+
+	t.Run("NoRTC", func(t *testing.T) {
+		mock.AddResponse("file", "read", map[string]any{"data": ""})
+
+		status, err := mgr.Status(ctx)
+		if err != nil || !status.RTC.IsZero() || status.RTCDrift != 0 {
+			t.Errorf("expected no RTC, got %+v, %v", status, err)
+		}
+	})
+
+	t.Run("SetServers", func(t *testing.T) {
+		mock.AddResponse("uci", "set", map[string]any{})
+		mock.AddResponse("uci", "commit", map[string]any{})
+		mock.AddResponse("rc", "init", map[string]any{})
+
+		err := mgr.SetServers(ctx, "time.cloudflare.com")
+		if err != nil {
+			t.Fatalf("SetServers failed: %v", err)
+		}
+
+		var req struct {
+			Values struct {
+				Server  []string `json:"server"`
+				Enabled string   `json:"enabled"`
+			} `json:"values"`
+		}
+
+		for _, call := range mock.Calls {
+			if call.Method == "set" {
+				data, _ := json.Marshal(call.Data)
+				_ = json.Unmarshal(data, &req)
+			}
+		}
+
+		if !slices.Equal(req.Values.Server, []string{"time.cloudflare.com"}) || req.Values.Enabled != "1" {
+			t.Errorf("unexpected values: %+v", req.Values)
+		}
+
+		if call := mock.GetLastCall(); call.Service != "rc" || call.Method != "init" {
+			t.Errorf("expected sysntpd to be restarted, got %+v", call)
+		}
+	})
+
+	t.Run("SetClock", func(t *testing.T) {
+		mock.AddResponse("luci", "setLocaltime", map[string]any{})
+
+		err := mgr.SetClock(ctx, time.Unix(1760000000, 0))
+		if err != nil {
+			t.Fatalf("SetClock failed: %v", err)
+		}
+
+		params, _ := mock.GetLastCall().Data.(map[string]any)
+		if params["localtime"] != int64(1760000000) {
+			t.Errorf("unexpected params: %+v", params)
+		}
+	})
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package ntp
+
+import "time"
+
+// Config represents the NTP section ("config timeserver 'ntp'") in /etc/config/system.
+type Config struct {
+	Servers []string `json:"server"`
+	// Interface limits the NTP server to one logical interface.
+	Interface string `json:"interface"`
+	// Enabled runs the NTP client.
+	Enabled bool `json:"enabled"`
+	// EnableServer also serves time to the network.
+	EnableServer bool `json:"enable_server"`
+	// UseDHCP adds the NTP servers announced by DHCP.
+	UseDHCP bool `json:"use_dhcp"`
+}
+
+// Status is the time synchronization state of the device.
+type Status struct {
+	// SystemTime is the system clock.
+	SystemTime time.Time
+	// LocalTime is the wall clock time in the configured time zone, as reported by system
+	// info. It is expressed in UTC, so its fields read as the local date and time.
+	LocalTime time.Time
+	// RTC is the hardware clock. It is zero if the device has no readable RTC.
+	RTC time.Time
+	// RTCDrift is SystemTime minus RTC, or zero without an RTC.
+	RTCDrift time.Duration
+	Config   Config
+	// Running reports whether sysntpd is running.
+	Running bool
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package odhcpd
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/dhcp"
+)
+
+// Manager reads the leases of odhcpd, which registers itself as the "dhcp" ubus object.
+type Manager struct {
+	caller goubus.Transport
+	dhcp   *dhcp.Manager
+}
+
+// New creates a new base odhcpd Manager.
+func New(t goubus.Transport) *Manager {
+	return &Manager{caller: t, dhcp: dhcp.New(t, nil)}
+}
+
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"dhcp"}
+}
+
+// IPv6Leases retrieves the DHCPv6 leases of all interfaces, ordered by interface.
+func (m *Manager) IPv6Leases(ctx context.Context) ([]IPv6Lease, error) {
+	res, err := goubus.Call[dhcp.DeviceLeases[IPv6Lease]](ctx, m.caller, "dhcp", "ipv6leases", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Flatten(func(lease *IPv6Lease, iface string) { lease.Interface = iface }), nil
+}
+
+// IPv4Leases retrieves the DHCPv4 leases of all interfaces served by odhcpd, ordered by interface.
+func (m *Manager) IPv4Leases(ctx context.Context) ([]IPv4Lease, error) {
+	res, err := goubus.Call[dhcp.DeviceLeases[IPv4Lease]](ctx, m.caller, "dhcp", "ipv4leases", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Flatten(func(lease *IPv4Lease, iface string) { lease.Interface = iface }), nil
+}
+
+// HostLeases retrieves the DHCPv6 leases and pairs each with the UCI host section reserving it.
+func (m *Manager) HostLeases(ctx context.Context) ([]HostLease, error) {
+	leases, err := m.IPv6Leases(ctx)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read DHCPv6 leases")
+	}
+
+	hosts, err := m.dhcp.StaticLeases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	paired := make([]HostLease, len(leases))
+	for i, lease := range leases {
+		paired[i] = HostLease{Lease: lease, Host: MatchHost(lease, hosts)}
+	}
+
+	return paired, nil
+}
+
+// MatchHost returns the host section odhcpd uses for lease: the first host whose duid option
+// equals the DUID of the lease, optionally qualified with the IAID in hex as "DUID%IAID".
+func MatchHost(lease IPv6Lease, hosts []dhcp.StaticLease) *dhcp.StaticLease {
+	for i := range hosts {
+		duid, iaid, qualified := strings.Cut(hosts[i].DUID, "%")
+		if duid == "" || !strings.EqualFold(duid, lease.DUID) {
+			continue
+		}
+
+		if qualified {
+			id, err := strconv.ParseUint(iaid, 16, 32)
+			if err != nil || uint32(id) != lease.IAID {
+				continue
+			}
+		}
+
+		return &hosts[i]
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package odhcpd_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/honeybbq/goubus/v2/internal/base/odhcpd"
+	"github.com/honeybbq/goubus/v2/internal/testutil"
+)
+
+func TestOdhcpdManager(t *testing.T) {
+	ctx := context.Background()
+
+	mock := testutil.NewMockTransport()
+	mock.AddResponse("dhcp", "ipv6leases", map[string]any{
+		"device": map[string]any{
+			"br-lan": map[string]any{
+				"leases": []map[string]any{
+					{
+						"duid": "00010001AABBCCDD", "iaid": 11, "hostname": "nas", "accept-reconf": 0,
+						"assigned": 4660, "flags": []string{"bound"}, "valid": 3500,
+						"ipv6-addr": []map[string]any{
+							{"address": "fd00::1234", "preferred-lifetime": 1800, "valid-lifetime": 3600},
+						},
+					},
+					{
+						"duid": "00030001112233445566", "iaid": 1, "valid": 7000,
+						"ipv6-prefix": []map[string]any{
+							{"address": "fd00:0:0:10::", "prefix-length": 60, "preferred-lifetime": 3600, "valid-lifetime": 7200},
+						},
+					},
+				},
+			},
+		},
+	})
+	mock.AddResponse("uci", "get", map[string]any{
+		"values": map[string]any{
+			"nas": map[string]any{".type": "host", ".name": "nas", ".index": 0, "duid": "00010001aabbccdd%b"},
+			"tv":  map[string]any{".type": "host", ".name": "tv", ".index": 1, "duid": "00030001112233445566%2"},
+		},
+	})
+
+	mgr := odhcpd.New(mock)
+
+	t.Run("IPv6Leases", func(t *testing.T) {
+		leases, err := mgr.IPv6Leases(ctx)
+		if err != nil {
+			t.Fatalf("IPv6Leases failed: %v", err)
+		}
+
+		if len(leases) != 2 || leases[0].Interface != "br-lan" || leases[0].Addresses[0].ValidLifetime != 3600 {
+			t.Fatalf("unexpected leases: %+v", leases)
+		}
+
+		prefix := leases[1].Prefixes
+		if len(prefix) != 1 || prefix[0].PrefixLength != 60 {
+			t.Errorf("unexpected delegated prefix: %+v", prefix)
+		}
+	})
+
+	t.Run("HostLeases", func(t *testing.T) {
+		paired, err := mgr.HostLeases(ctx)
+		if err != nil {
+			t.Fatalf("HostLeases failed: %v", err)
+		}
+
+		if paired[0].Host == nil || paired[0].Host.Section != "nas" {
+			t.Errorf("expected the nas lease to match its host: %+v", paired[0])
+		}
+
+		if paired[1].Host != nil {
+			t.Errorf("expected the IAID mismatch to leave the lease unmatched: %+v", paired[1].Host)
+		}
+	})
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package odhcpd
+
+import (
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/internal/base/dhcp"
+)
+
+// IPv6Lease is a DHCPv6 binding (identity association) handed out by odhcpd.
+type IPv6Lease struct {
+	// Interface is the logical device the lease was handed out on, e.g. "br-lan".
+	Interface string        `json:"interface"`
+	DUID      string        `json:"duid"`
+	Hostname  string        `json:"hostname"`
+	Flags     []string      `json:"flags"`
+	Addresses []IPv6Address `json:"ipv6-addr"`
+	Prefixes  []IPv6Address `json:"ipv6-prefix"`
+	IAID      uint32        `json:"iaid"`
+	// Assigned is the host part odhcpd assigned to the client.
+	Assigned uint32 `json:"assigned"`
+	// Valid is the remaining lifetime of the binding in seconds.
+	Valid        int         `json:"valid"`
+	AcceptReconf goubus.Bool `json:"accept-reconf"`
+}
+
+// IPv6Address is an address (IA_NA) or delegated prefix (IA_PD) of a lease.
+type IPv6Address struct {
+	Address string `json:"address"`
+	// PrefixLength is only set for delegated prefixes.
+	PrefixLength      int `json:"prefix-length,omitempty"`
+	PreferredLifetime int `json:"preferred-lifetime"`
+	ValidLifetime     int `json:"valid-lifetime"`
+}
+
+// IPv4Lease is a DHCPv4 lease handed out by odhcpd.
+type IPv4Lease struct {
+	Interface    string      `json:"interface"`
+	MAC          string      `json:"mac"`
+	Hostname     string      `json:"hostname"`
+	Address      string      `json:"address"`
+	Flags        []string    `json:"flags"`
+	Valid        int         `json:"valid"`
+	AcceptReconf goubus.Bool `json:"accept-reconf"`
+}
+
+// HostLease pairs a DHCPv6 lease with the UCI host section that reserves it, if any.
+type HostLease struct {
+	// Host is nil for dynamic leases.
+	Host  *dhcp.StaticLease `json:"host,omitempty"`
+	Lease IPv6Lease         `json:"lease"`
+}
@@ -0,0 +1,397 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package opkg
+
+import (
+	"cmp"
+	"context"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/file"
+)
+
+const (
+	opkgBinary       = "/bin/opkg"
+	listFieldSep     = " - "
+	listFields       = 3
+	upgradableFields = 3
+	installedFields  = 2
+	errorsHeader     = "Collected errors:"
+)
+
+var (
+	installingPattern = regexp.MustCompile(`^Installing (\S+) \((\S+)\) to `)
+	upgradingPattern  = regexp.MustCompile(`^Upgrading (\S+) on \S+ from (\S+) to (\S+)\.\.\.`)
+	removingPattern   = regexp.MustCompile(`^Removing package (\S+) from `)
+)
+
+// Manager wraps the opkg command line tool via file.exec.
+// The session needs exec permission for /bin/opkg in its rpcd ACL.
+type Manager struct {
+	caller goubus.Transport
+	file   *file.Manager
+}
+
+// New creates a new base opkg Manager.
+func New(t goubus.Transport) *Manager {
+	return &Manager{
+		caller: t,
+		file:   file.New(t),
+	}
+}
+
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"rpc-sys", "file"}
+}
+
+// Update refreshes the package lists from the configured feeds.
+func (m *Manager) Update(ctx context.Context) (*Result, error) {
+	return m.run(ctx, "update")
+}
+
+// List retrieves all packages available in the feeds.
+func (m *Manager) List(ctx context.Context) ([]Package, error) {
+	res, err := m.run(ctx, "list")
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseList(res.Stdout), nil
+}
+
+// ListInstalled retrieves the installed packages.
+// It prefers rpcd-mod-rpcsys packagelist and falls back to "opkg list-installed".
+func (m *Manager) ListInstalled(ctx context.Context) ([]Package, error) {
+	params := map[string]any{"all": true}
+
+	list, err := goubus.Call[packageListResponse](ctx, m.caller, "rpc-sys", "packagelist", params)
+	if err == nil {
+		packages := make([]Package, 0, len(list.Packages))
+		for name, version := range list.Packages {
+			packages = append(packages, Package{Name: name, Version: version})
+		}
+
+		slices.SortFunc(packages, func(a, b Package) int {
+			return cmp.Compare(a.Name, b.Name)
+		})
+
+		return packages, nil
+	}
+
+	if !errdefs.IsNotSupported(err) && !errdefs.IsNotFound(err) && !errdefs.IsMethodNotFound(err) &&
+		!errdefs.IsPermissionDenied(err) {
+		return nil, err
+	}
+
+	res, err := m.run(ctx, "list-installed")
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseList(res.Stdout), nil
+}
+
+// ListUpgradable retrieves the installed packages that have a newer version in the feeds.
+func (m *Manager) ListUpgradable(ctx context.Context) ([]Upgrade, error) {
+	res, err := m.run(ctx, "list-upgradable")
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseListUpgradable(res.Stdout), nil
+}
+
+// Info retrieves the control fields of a package.
+func (m *Manager) Info(ctx context.Context, name string) (*Info, error) {
+	err := validatePackage(name)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := m.run(ctx, "info", name)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := ParseInfo(res.Stdout)
+	if len(infos) == 0 {
+		return nil, errdefs.Wrapf(errdefs.ErrNotFound, "package %s", name)
+	}
+
+	return &infos[0], nil
+}
+
+// Install installs the given packages one at a time, reporting progress after each package.
+// It stops at the first failure and returns the combined result so far.
+func (m *Manager) Install(ctx context.Context, packages []string, opts *Options) (*Result, error) {
+	args := []string{"install"}
+
+	if opts != nil && opts.ForceReinstall {
+		args = append(args, "--force-reinstall")
+	}
+
+	if opts != nil && opts.ForceDepends {
+		args = append(args, "--force-depends")
+	}
+
+	return m.each(ctx, args, packages, opts)
+}
+
+// Remove removes the given packages one at a time, reporting progress after each package.
+// It stops at the first failure and returns the combined result so far.
+func (m *Manager) Remove(ctx context.Context, packages []string, opts *Options) (*Result, error) {
+	args := []string{"remove"}
+
+	if opts != nil && opts.ForceDepends {
+		args = append(args, "--force-depends")
+	}
+
+	if opts != nil && opts.AutoRemove {
+		args = append(args, "--autoremove")
+	}
+
+	return m.each(ctx, args, packages, opts)
+}
+
+func (m *Manager) each(ctx context.Context, args, packages []string, opts *Options) (*Result, error) {
+	if len(packages) == 0 {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "no packages given")
+	}
+
+	for _, name := range packages {
+		err := validatePackage(name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	total := &Result{}
+
+	for i, name := range packages {
+		res, err := m.run(ctx, append(slices.Clone(args), name)...)
+		if res != nil {
+			total.merge(res)
+		}
+
+		if opts != nil && opts.Progress != nil {
+			opts.Progress(Progress{Package: name, Index: i + 1, Total: len(packages), Result: res})
+		}
+
+		if err != nil {
+			return total, errdefs.Wrapf(err, "failed to %s %s", args[0], name)
+		}
+	}
+
+	return total, nil
+}
+
+// validatePackage rejects names opkg would parse as options, such as
+// "--force-removal-of-essential-packages". Names, package files and URLs never start with "-".
+func validatePackage(name string) error {
+	if name == "" || strings.HasPrefix(name, "-") {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid package name %q", name)
+	}
+
+	return nil
+}
+
+func (m *Manager) run(ctx context.Context, args ...string) (*Result, error) {
+	out, err := m.file.Exec(ctx, opkgBinary, args, nil)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to run opkg %s", args[0])
+	}
+
+	res := ParseResult(out.Stdout, out.Stderr)
+	res.Code = out.Code
+
+	if out.Code != 0 {
+		detail := strings.Join(res.Errors, "; ")
+		if detail == "" {
+			detail = strings.TrimSpace(out.Stderr)
+		}
+
+		return res, errdefs.Wrapf(errdefs.ErrUnknown, "opkg %s exited with code %d: %s", args[0], out.Code, detail)
+	}
+
+	return res, nil
+}
+
+func (r *Result) merge(other *Result) {
+	r.Stdout += other.Stdout
+	r.Stderr += other.Stderr
+	r.Installed = append(r.Installed, other.Installed...)
+	r.Upgraded = append(r.Upgraded, other.Upgraded...)
+	r.Removed = append(r.Removed, other.Removed...)
+	r.Errors = append(r.Errors, other.Errors...)
+	r.Code = other.Code
+}
+
+// ParseResult extracts the installed, upgraded and removed packages and the collected errors
+// from the output of an opkg command.
+func ParseResult(stdout, stderr string) *Result {
+	res := &Result{Stdout: stdout, Stderr: stderr}
+
+	for line := range strings.SplitSeq(stdout, "\n") {
+		res.parseActionLine(strings.TrimSpace(line))
+	}
+
+	res.Errors = parseCollectedErrors(stderr)
+
+	return res
+}
+
+func (r *Result) parseActionLine(line string) {
+	if match := installingPattern.FindStringSubmatch(line); match != nil {
+		r.Installed = append(r.Installed, Package{Name: match[1], Version: match[2]})
+
+		return
+	}
+
+	if match := upgradingPattern.FindStringSubmatch(line); match != nil {
+		r.Upgraded = append(r.Upgraded, Upgrade{Name: match[1], InstalledVersion: match[2], AvailableVersion: match[3]})
+
+		return
+	}
+
+	if match := removingPattern.FindStringSubmatch(line); match != nil {
+		r.Removed = append(r.Removed, match[1])
+	}
+}
+
+func parseCollectedErrors(stderr string) []string {
+	var errs []string
+
+	collecting := false
+
+	for line := range strings.SplitSeq(stderr, "\n") {
+		line = strings.TrimSpace(line)
+
+		switch {
+		case line == errorsHeader:
+			collecting = true
+		case collecting && strings.HasPrefix(line, "* "):
+			errs = append(errs, strings.TrimPrefix(line, "* "))
+		case collecting && line != "" && len(errs) > 0:
+			errs[len(errs)-1] += " " + line
+		}
+	}
+
+	return errs
+}
+
+// ParseList parses the output of "opkg list" or "opkg list-installed".
+// Each line has the form "name - version[ - description]"; indented lines continue the previous description.
+func ParseList(output string) []Package {
+	packages := []Package{}
+
+	for line := range strings.SplitSeq(output, "\n") {
+		if strings.HasPrefix(line, " ") && len(packages) > 0 {
+			last := &packages[len(packages)-1]
+			last.Description = strings.TrimSpace(last.Description + " " + strings.TrimSpace(line))
+
+			continue
+		}
+
+		fields := strings.SplitN(strings.TrimSpace(line), listFieldSep, listFields)
+		if len(fields) < installedFields {
+			continue
+		}
+
+		pkg := Package{Name: fields[0], Version: fields[1]}
+		if len(fields) == listFields {
+			pkg.Description = fields[2]
+		}
+
+		packages = append(packages, pkg)
+	}
+
+	return packages
+}
+
+// ParseListUpgradable parses the output of "opkg list-upgradable" ("name - installed - available").
+func ParseListUpgradable(output string) []Upgrade {
+	upgrades := []Upgrade{}
+
+	for line := range strings.SplitSeq(output, "\n") {
+		fields := strings.SplitN(strings.TrimSpace(line), listFieldSep, upgradableFields)
+		if len(fields) < upgradableFields {
+			continue
+		}
+
+		upgrades = append(upgrades, Upgrade{Name: fields[0], InstalledVersion: fields[1], AvailableVersion: fields[2]})
+	}
+
+	return upgrades
+}
+
+// ParseInfo parses the output of "opkg info", which may contain several blank line separated records.
+func ParseInfo(output string) []Info {
+	infos := []Info{}
+
+	for record := range strings.SplitSeq(strings.ReplaceAll(output, "\r\n", "\n"), "\n\n") {
+		fields := parseControlFields(record)
+		if fields["Package"] == "" {
+			continue
+		}
+
+		infos = append(infos, infoFromFields(fields))
+	}
+
+	return infos
+}
+
+func parseControlFields(record string) map[string]string {
+	fields := map[string]string{}
+	last := ""
+
+	for line := range strings.SplitSeq(record, "\n") {
+		if strings.HasPrefix(line, " ") && last != "" {
+			fields[last] += "\n" + strings.TrimSpace(line)
+
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+
+		last = strings.TrimSpace(key)
+		fields[last] = strings.TrimSpace(value)
+	}
+
+	return fields
+}
+
+func infoFromFields(fields map[string]string) Info {
+	info := Info{
+		Fields:       fields,
+		Package:      fields["Package"],
+		Version:      fields["Version"],
+		Status:       fields["Status"],
+		Section:      fields["Section"],
+		Architecture: fields["Architecture"],
+		Maintainer:   fields["Maintainer"],
+		Filename:     fields["Filename"],
+		Description:  fields["Description"],
+		Installed:    strings.HasSuffix(fields["Status"], " installed"),
+	}
+
+	info.Size, _ = strconv.ParseInt(fields["Size"], 10, 64)
+	info.InstalledTime, _ = strconv.ParseInt(fields["Installed-Time"], 10, 64)
+
+	for dep := range strings.SplitSeq(fields["Depends"], ",") {
+		if dep = strings.TrimSpace(dep); dep != "" {
+			info.Depends = append(info.Depends, dep)
+		}
+	}
+
+	return info
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package opkg_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/opkg"
+	"github.com/honeybbq/goubus/v2/internal/testutil"
+)
+
+const testInfoOutput = `Package: luci-app-upnp
+Version: 25.001.12345~abcdef
+Depends: libc, luci-base, miniupnpd
+Status: install user installed
+Section: luci
+Architecture: all
+Size: 12345
+Description: LuCI support for UPnP
+ IGD and NAT-PMP.
+Installed-Time: 1737109342
+
+`
+
+func TestOpkgManager(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ListInstalled_RpcSys", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("rpc-sys", "packagelist", map[string]any{
+			"packages": map[string]string{"dnsmasq": "2.91-r2", "busybox": "1.37.0-r6"},
+		})
+
+		packages, err := opkg.New(mock).ListInstalled(ctx)
+		if err != nil {
+			t.Fatalf("ListInstalled failed: %v", err)
+		}
+
+		if len(packages) != 2 || packages[0].Name != "busybox" || packages[1].Version != "2.91-r2" {
+			t.Errorf("unexpected packages: %+v", packages)
+		}
+	})
+
+	t.Run("ListInstalled_ExecFallback", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("file", "exec", map[string]any{
+			"code":   0,
+			"stdout": "base-files - 1679~9e9b05130c\nbusybox - 1.37.0-r6\n",
+		})
+
+		packages, err := opkg.New(mock).ListInstalled(ctx)
+		if err != nil {
+			t.Fatalf("ListInstalled failed: %v", err)
+		}
+
+		if len(packages) != 2 || packages[0].Version != "1679~9e9b05130c" {
+			t.Errorf("unexpected packages: %+v", packages)
+		}
+	})
+
+	t.Run("Install_Progress", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("file", "exec", map[string]any{
+			"code":   0,
+			"stdout": "Installing tcpdump (4.99.5-r1) to root...\nConfiguring tcpdump.\n",
+		})
+
+		var steps []opkg.Progress
+
+		res, err := opkg.New(mock).Install(ctx, []string{"tcpdump", "iperf3"}, &opkg.Options{
+			Progress: func(p opkg.Progress) { steps = append(steps, p) },
+		})
+		if err != nil {
+			t.Fatalf("Install failed: %v", err)
+		}
+
+		if len(steps) != 2 || steps[1].Package != "iperf3" || steps[1].Index != 2 || steps[1].Total != 2 {
+			t.Errorf("unexpected progress: %+v", steps)
+		}
+
+		if len(res.Installed) != 2 || res.Installed[0].Version != "4.99.5-r1" {
+			t.Errorf("unexpected result: %+v", res)
+		}
+
+		call := mock.GetLastCall()
+
+		params, ok := call.Data.(map[string]any)
+		if !ok || params["command"] != "/bin/opkg" {
+			t.Fatalf("unexpected exec call: %+v", call.Data)
+		}
+
+		args, ok := params["params"].([]string)
+		if !ok || len(args) != 2 || args[0] != "install" || args[1] != "iperf3" {
+			t.Errorf("unexpected args: %v", params["params"])
+		}
+	})
+
+	t.Run("Install_Failure", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("file", "exec", map[string]any{
+			"code":   255,
+			"stderr": "Unknown package 'nope'.\nCollected errors:\n * opkg_install_cmd: Cannot install package nope.\n",
+		})
+
+		res, err := opkg.New(mock).Install(ctx, []string{"nope"}, nil)
+		if err == nil {
+			t.Fatal("expected error")
+		}
+
+		if len(res.Errors) != 1 || res.Errors[0] != "opkg_install_cmd: Cannot install package nope." || res.Code != 255 {
+			t.Errorf("unexpected result: %+v", res)
+		}
+	})
+
+	t.Run("Install_OptionName", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+
+		for _, packages := range [][]string{{"tcpdump", "--force-removal-of-essential-packages"}, {"-fopkg"}, {""}} {
+			_, err := opkg.New(mock).Remove(ctx, packages, nil)
+			if !errdefs.IsInvalidParameter(err) {
+				t.Errorf("expected %q to be rejected, got %v", packages, err)
+			}
+		}
+
+		_, err := opkg.New(mock).Info(ctx, "--force-overwrite")
+		if !errdefs.IsInvalidParameter(err) {
+			t.Errorf("expected an option to be rejected, got %v", err)
+		}
+
+		if len(mock.Calls) != 0 {
+			t.Errorf("expected opkg not to run, got %+v", mock.Calls)
+		}
+	})
+
+	t.Run("ParseInfo", func(t *testing.T) {
+		infos := opkg.ParseInfo(testInfoOutput)
+		if len(infos) != 1 {
+			t.Fatalf("expected 1 record, got %d", len(infos))
+		}
+
+		info := infos[0]
+		if !info.Installed || info.Size != 12345 || len(info.Depends) != 3 || info.InstalledTime != 1737109342 {
+			t.Errorf("unexpected info: %+v", info)
+		}
+
+		if info.Description != "LuCI support for UPnP\nIGD and NAT-PMP." {
+			t.Errorf("unexpected description: %q", info.Description)
+		}
+	})
+
+	t.Run("ParseListUpgradable", func(t *testing.T) {
+		upgrades := opkg.ParseListUpgradable("dnsmasq - 2.90-r1 - 2.91-r2\n")
+		if len(upgrades) != 1 || upgrades[0].AvailableVersion != "2.91-r2" {
+			t.Errorf("unexpected upgrades: %+v", upgrades)
+		}
+	})
+}
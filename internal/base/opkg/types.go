@@ -0,0 +1,71 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package opkg
+
+// Package represents a package entry of an opkg listing.
+type Package struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Upgrade represents an installed package for which a newer version is available.
+type Upgrade struct {
+	Name             string `json:"name"`
+	InstalledVersion string `json:"installed_version"`
+	AvailableVersion string `json:"available_version"`
+}
+
+// Info represents the control fields reported by "opkg info".
+type Info struct {
+	Fields        map[string]string `json:"fields"`
+	Package       string            `json:"package"`
+	Version       string            `json:"version"`
+	Status        string            `json:"status"`
+	Section       string            `json:"section"`
+	Architecture  string            `json:"architecture"`
+	Maintainer    string            `json:"maintainer"`
+	Filename      string            `json:"filename"`
+	Description   string            `json:"description"`
+	Depends       []string          `json:"depends"`
+	Size          int64             `json:"size"`
+	InstalledTime int64             `json:"installed_time"`
+	Installed     bool              `json:"installed"`
+}
+
+// Result represents the parsed output of an opkg command that changes the package set.
+type Result struct {
+	Stdout    string    `json:"stdout"`
+	Stderr    string    `json:"stderr"`
+	Installed []Package `json:"installed"`
+	Upgraded  []Upgrade `json:"upgraded"`
+	Removed   []string  `json:"removed"`
+	// Errors holds the entries of the "Collected errors" section.
+	Errors []string `json:"errors"`
+	Code   int      `json:"code"`
+}
+
+// Options controls Install and Remove.
+type Options struct {
+	// Progress, if set, is called after each package has been processed.
+	Progress func(Progress)
+	// ForceReinstall reinstalls packages that are already installed (install only).
+	ForceReinstall bool
+	// ForceDepends ignores failed dependencies.
+	ForceDepends bool
+	// AutoRemove also removes dependencies that are no longer needed (remove only).
+	AutoRemove bool
+}
+
+// Progress reports the state of a multi-package install or remove.
+type Progress struct {
+	Result  *Result
+	Package string
+	Index   int
+	Total   int
+}
+
+type packageListResponse struct {
+	Packages map[string]string `json:"packages"`
+}
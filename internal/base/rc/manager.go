@@ -5,18 +5,57 @@ package rc
 
 import (
 	"context"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/file"
+	"github.com/honeybbq/goubus/v2/internal/base/service"
 )
 
-// Manager provides methods to interact with init scripts.
+const (
+	initDir = "/etc/init.d/"
+	rcDir   = "/etc/rc.d/"
+)
+
+// Init script actions.
+const (
+	ActionStart   = "start"
+	ActionStop    = "stop"
+	ActionRestart = "restart"
+	ActionReload  = "reload"
+	ActionEnable  = "enable"
+	ActionDisable = "disable"
+)
+
+var (
+	actions = []string{ActionStart, ActionStop, ActionRestart, ActionReload, ActionEnable, ActionDisable}
+
+	// scriptName matches the name of an init script.
+	scriptName = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]*$`)
+	// rcLink matches the start and stop links of /etc/rc.d, e.g. "S50cron".
+	rcLink = regexp.MustCompile(`^([SK])(\d+)(.+)$`)
+)
+
+// Manager provides methods to interact with init scripts. It uses the rc object of rpcd and
+// falls back to /etc/init.d and /etc/rc.d through the file object on firmwares without it.
 type Manager struct {
-	caller goubus.Transport
+	caller  goubus.Transport
+	file    *file.Manager
+	service *service.Manager
 }
 
 // New creates a new base RC Manager.
 func New(t goubus.Transport) *Manager {
-	return &Manager{caller: t}
+	return &Manager{caller: t, file: file.New(t), service: service.New(t)}
+}
+
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"rc", "file", "service"}
 }
 
 // List retrieves a list of available init scripts.
@@ -31,20 +70,158 @@ func (m *Manager) List(ctx context.Context, name string, skipRunningCheck bool)
 	}
 
 	resp, err := goubus.Call[map[string]ListInfo](ctx, m.caller, "rc", "list", params)
-	if err != nil {
+	if err == nil {
+		return *resp, nil
+	}
+
+	if !fallback(err) {
 		return nil, err
 	}
 
-	return *resp, nil
+	return m.listScripts(ctx, name, skipRunningCheck)
 }
 
-// Init performs an init script action.
+// Init performs an init script action, one of the Action constants.
 func (m *Manager) Init(ctx context.Context, name, action string) error {
+	if !scriptName.MatchString(name) {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid init script name %q", name)
+	}
+
+	if !slices.Contains(actions, action) {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid init script action %q", action)
+	}
+
 	req := InitRequest{
 		Name:   name,
 		Action: action,
 	}
+
 	_, err := m.caller.Call(ctx, "rc", "init", req)
+	if err == nil || !fallback(err) {
+		return err
+	}
+
+	res, err := m.file.Exec(ctx, initDir+name, []string{action}, nil)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to run %s %s", name, action)
+	}
+
+	if res.Code != 0 {
+		return errdefs.Wrapf(errdefs.ErrUnknown, "%s %s exited with code %d: %s",
+			name, action, res.Code, strings.TrimSpace(res.Stderr))
+	}
+
+	return nil
+}
+
+// Status combines the init script state of name with the instances procd runs for it.
+func (m *Manager) Status(ctx context.Context, name string) (*Status, error) {
+	scripts, err := m.List(ctx, name, false)
+	if err != nil {
+		return nil, err
+	}
+
+	script, ok := scripts[name]
+	if !ok {
+		return nil, errdefs.Wrapf(errdefs.ErrNotFound, "init script %s not found", name)
+	}
+
+	status := &Status{Name: name, ListInfo: script, Instances: map[string]service.Instance{}}
+
+	services, err := m.service.List(ctx, name, false)
+	if err != nil && !errdefs.IsNotFound(err) && !errdefs.IsNotSupported(err) {
+		return nil, errdefs.Wrapf(err, "failed to read the procd state of %s", name)
+	}
+
+	if info, ok := services[name]; ok && info.Instances != nil {
+		status.Instances = info.Instances
+	}
 
-	return err
+	return status, nil
+}
+
+// fallback reports whether err means the rc object is missing or not accessible. The transports
+// report a missing object as a CapabilityError, which matches ErrNotSupported.
+func fallback(err error) bool {
+	return errdefs.IsNotSupported(err) || errdefs.IsNotFound(err) || errdefs.IsMethodNotFound(err) ||
+		errdefs.IsPermissionDenied(err)
+}
+
+// listScripts lists the init scripts of /etc/init.d, taking the enabled state and priorities
+// from the links of /etc/rc.d and the running state from procd.
+func (m *Manager) listScripts(ctx context.Context, name string, skipRunningCheck bool) (map[string]ListInfo, error) {
+	scripts, err := m.file.List(ctx, initDir)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to list %s", initDir)
+	}
+
+	list := make(map[string]ListInfo)
+
+	for _, entry := range scripts.Entries {
+		if name == "" || entry.Name == name {
+			list[entry.Name] = ListInfo{}
+		}
+	}
+
+	links, err := m.file.List(ctx, rcDir)
+	if err != nil && !errdefs.IsNotFound(err) {
+		return nil, errdefs.Wrapf(err, "failed to list %s", rcDir)
+	}
+
+	if links != nil {
+		applyLinks(list, links.Entries)
+	}
+
+	if skipRunningCheck {
+		return list, nil
+	}
+
+	err = m.applyRunning(ctx, list)
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// applyRunning marks the scripts whose procd service has a running instance as running.
+func (m *Manager) applyRunning(ctx context.Context, list map[string]ListInfo) error {
+	services, err := m.service.List(ctx, "", false)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to read the procd services")
+	}
+
+	for script, info := range list {
+		for _, instance := range services[script].Instances {
+			info.Running = info.Running || instance.Running
+		}
+
+		list[script] = info
+	}
+
+	return nil
+}
+
+// applyLinks sets the enabled state and priorities of the scripts from the /etc/rc.d links.
+func applyLinks(list map[string]ListInfo, links []file.ListData) {
+	for _, link := range links {
+		match := rcLink.FindStringSubmatch(link.Name)
+		if match == nil {
+			continue
+		}
+
+		info, ok := list[match[3]]
+		if !ok {
+			continue
+		}
+
+		priority, _ := strconv.Atoi(match[2])
+		if match[1] == "S" {
+			info.Start, info.Enabled = priority, true
+		} else {
+			info.Stop = priority
+		}
+
+		list[match[3]] = info
+	}
 }
@@ -7,6 +7,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/honeybbq/goubus/v2/errdefs"
 	"github.com/honeybbq/goubus/v2/internal/base/rc"
 	"github.com/honeybbq/goubus/v2/internal/testutil"
 )
@@ -34,4 +35,73 @@ func TestRCManager(t *testing.T) {
 			t.Errorf("unexpected rc data: %+v", scripts)
 		}
 	})
+	t.Run("Init", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("rc", "init", map[string]any{})
+
+		mgr := rc.New(mock)
+
+		err := mgr.Init(ctx, "cron", rc.ActionRestart)
+		if err != nil {
+			t.Fatalf("Init failed: %v", err)
+		}
+
+		for _, call := range [][2]string{{"../sbin/reboot", rc.ActionStart}, {"cron", "boot; reboot"}} {
+			err = mgr.Init(ctx, call[0], call[1])
+			if !errdefs.IsInvalidParameter(err) {
+				t.Errorf("expected %q to be rejected, got %v", call, err)
+			}
+		}
+	})
+
+	t.Run("Fallback", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("rc", "list", errdefs.ErrMethodNotFound)
+		mock.AddResponse("rc", "init", errdefs.ErrPermissionDenied)
+		mock.AddResponseForArgs("file", "list", map[string]any{"path": "/etc/init.d/"}, map[string]any{
+			"entries": []map[string]any{{"name": "cron"}, {"name": "dnsmasq"}, {"name": "boot"}},
+		})
+		mock.AddResponseForArgs("file", "list", map[string]any{"path": "/etc/rc.d/"}, map[string]any{
+			"entries": []map[string]any{{"name": "S10boot"}, {"name": "S19dnsmasq"}, {"name": "K85dnsmasq"}},
+		})
+		mock.AddResponse("service", "list", map[string]any{
+			"dnsmasq": map[string]any{"instances": map[string]any{
+				"cfg01411c": map[string]any{"running": true, "pid": 1862, "command": []string{"/usr/sbin/dnsmasq"}},
+			}},
+			"cron": map[string]any{"instances": map[string]any{}},
+		})
+		mock.AddResponse("file", "exec", map[string]any{"code": 0})
+
+		mgr := rc.New(mock)
+
+		scripts, err := mgr.List(ctx, "", false)
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+
+		dnsmasq := scripts["dnsmasq"]
+		if len(scripts) != 3 || !bool(dnsmasq.Enabled) || !bool(dnsmasq.Running) || dnsmasq.Start != 19 ||
+			dnsmasq.Stop != 85 || bool(scripts["cron"].Enabled) || bool(scripts["cron"].Running) {
+			t.Errorf("unexpected scripts: %+v", scripts)
+		}
+
+		status, err := mgr.Status(ctx, "dnsmasq")
+		if err != nil {
+			t.Fatalf("Status failed: %v", err)
+		}
+
+		if !bool(status.Running) || status.Instances["cfg01411c"].Pid != 1862 {
+			t.Errorf("unexpected status: %+v", status)
+		}
+
+		err = mgr.Init(ctx, "cron", rc.ActionEnable)
+		if err != nil {
+			t.Fatalf("Init failed: %v", err)
+		}
+
+		call := mock.GetLastCall()
+		if data, ok := call.Data.(map[string]any); !ok || data["command"] != "/etc/init.d/cron" {
+			t.Errorf("expected the init script to be run, got %+v", call)
+		}
+	})
 }
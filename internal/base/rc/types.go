@@ -3,12 +3,18 @@
 
 package rc
 
-import "github.com/honeybbq/goubus/v2"
+import (
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/internal/base/service"
+)
 
 // ListInfo represents init script status.
 type ListInfo struct {
 	Running goubus.Bool `json:"running"`
 	Enabled goubus.Bool `json:"enabled"`
+	// Start and Stop are the boot and shutdown priorities; zero when the script has none.
+	Start int `json:"start,omitempty"`
+	Stop  int `json:"stop,omitempty"`
 }
 
 // InitRequest represents parameters for init script action.
@@ -16,3 +22,10 @@ type InitRequest struct {
 	Name   string `json:"name"`
 	Action string `json:"action"`
 }
+
+// Status is the state of an init script together with the procd instances of its service.
+type Status struct {
+	Instances map[string]service.Instance `json:"instances"`
+	Name      string                      `json:"name"`
+	ListInfo
+}
@@ -19,6 +19,11 @@ func New(t goubus.Transport) *Manager {
 	return &Manager{caller: t}
 }
 
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"rpc-sys"}
+}
+
 // PackageList retrieves the list of installed packages.
 func (m *Manager) PackageList(ctx context.Context, all bool) (map[string]any, error) {
 	params := map[string]any{"all": all}
@@ -5,8 +5,19 @@ package service
 
 import (
 	"context"
+	"slices"
 
 	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+// Signals for Signal. Only the signals with the same number on every architecture OpenWrt
+// runs on are listed; SIGUSR1 for example is 10 on ARM and x86 but 16 on MIPS.
+const (
+	SignalHUP  = 1
+	SignalINT  = 2
+	SignalKILL = 9
+	SignalTERM = 15
 )
 
 // Manager provides an interface for managing system services.
@@ -19,6 +30,11 @@ func New(t goubus.Transport) *Manager {
 	return &Manager{caller: t}
 }
 
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"service"}
+}
+
 // List retrieves the status of all services.
 func (m *Manager) List(ctx context.Context, name string, verbose bool) (map[string]Info, error) {
 	params := make(map[string]any)
@@ -38,37 +54,47 @@ func (m *Manager) List(ctx context.Context, name string, verbose bool) (map[stri
 	return *res, nil
 }
 
-// Delete removes a service instance.
+// Delete removes a service instance, stopping it. An empty instance removes the whole service.
 func (m *Manager) Delete(ctx context.Context, name, instance string) error {
-	params := map[string]any{
-		"name":     name,
-		"instance": instance,
+	params := map[string]any{"name": name}
+	if instance != "" {
+		params["instance"] = instance
 	}
+
 	_, err := m.caller.Call(ctx, "service", "delete", params)
 
 	return err
 }
 
-// Signal sends a Unix signal to a service instance.
+// Signal sends a Unix signal, such as SignalHUP, to a service instance. An empty instance
+// signals all instances of the service.
 func (m *Manager) Signal(ctx context.Context, name, instance string, signal int) error {
+	if signal <= 0 {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid signal %d", signal)
+	}
+
 	params := map[string]any{
-		"name":     name,
-		"instance": instance,
-		"signal":   signal,
+		"name":   name,
+		"signal": signal,
+	}
+	if instance != "" {
+		params["instance"] = instance
 	}
+
 	_, err := m.caller.Call(ctx, "service", "signal", params)
 
 	return err
 }
 
-// Set configures a service.
+// Set registers a service, replacing its instances with those of req, which are usually
+// InstanceConfig values. procd starts new instances and restarts changed ones.
 func (m *Manager) Set(ctx context.Context, req SetRequest) error {
 	_, err := m.caller.Call(ctx, "service", "set", req)
 
 	return err
 }
 
-// Add adds a service.
+// Add registers a service like Set, but keeps the instances req does not mention.
 func (m *Manager) Add(ctx context.Context, req SetRequest) error {
 	_, err := m.caller.Call(ctx, "service", "add", req)
 
@@ -91,6 +117,65 @@ func (m *Manager) UpdateComplete(ctx context.Context, name string) error {
 	return err
 }
 
+// Update runs fn between UpdateStart and UpdateComplete, so that procd stops the instances of
+// the service that fn does not register again with Add. UpdateComplete runs even when fn fails.
+func (m *Manager) Update(ctx context.Context, name string, fn func() error) error {
+	err := m.UpdateStart(ctx, name)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to start the update of %s", name)
+	}
+
+	fnErr := fn()
+
+	err = m.UpdateComplete(ctx, name)
+	if fnErr != nil {
+		return fnErr
+	}
+
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to complete the update of %s", name)
+	}
+
+	return nil
+}
+
+// Watch delivers the instance notifications of procd, such as an instance being respawned,
+// for the services named, or for all services when none is named. Every event carries an
+// InstanceEvent payload that can be decoded with ParseInstanceEvent.
+func (m *Manager) Watch(ctx context.Context, services ...string) (*goubus.Subscription, error) {
+	sub, err := goubus.Subscribe(ctx, m.caller, "service")
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to subscribe to service")
+	}
+
+	return goubus.FilterSubscription(ctx, sub, func(ev goubus.Event) bool {
+		event, ok := ParseInstanceEvent(ev)
+
+		return ok && (len(services) == 0 || slices.Contains(services, event.Service))
+	}), nil
+}
+
+// ParseInstanceEvent decodes a procd instance notification. It returns false for other
+// notifications.
+func ParseInstanceEvent(ev goubus.Event) (InstanceEvent, bool) {
+	switch ev.Type {
+	case InstanceEventStart, InstanceEventStop, InstanceEventRespawn, InstanceEventCrash:
+	default:
+		return InstanceEvent{}, false
+	}
+
+	var event InstanceEvent
+
+	err := ev.Unmarshal(&event)
+	if err != nil {
+		return InstanceEvent{}, false
+	}
+
+	event.Type = ev.Type
+
+	return event, true
+}
+
 // Event sends an event to the service.
 func (m *Manager) Event(ctx context.Context, req EventRequest) error {
 	_, err := m.caller.Call(ctx, "service", "event", req)
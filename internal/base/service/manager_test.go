@@ -5,8 +5,12 @@ package service_test
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 
+	"github.com/honeybbq/goubus/v2/errdefs"
 	"github.com/honeybbq/goubus/v2/internal/base/service"
 	"github.com/honeybbq/goubus/v2/internal/testutil"
 )
@@ -38,4 +42,99 @@ func TestServiceManager(t *testing.T) {
 			t.Errorf("unexpected service data: %+v", services)
 		}
 	})
+	t.Run("Set_InstanceConfig", func(t *testing.T) {
+		mock.AddResponse("service", "set", map[string]any{})
+
+		err := service.New(mock).Set(ctx, service.SetRequest{
+			Name: "agent",
+			Instances: map[string]any{"main": service.InstanceConfig{
+				Command: []string{"/usr/bin/agent", "-f"},
+				Env:     map[string]string{"LOG": "debug"},
+				Respawn: &service.Respawn{Threshold: 3600, Timeout: 5, Retry: 0},
+				Jail:    &service.Jail{Name: "agent", ProcFS: true},
+			}},
+		})
+		if err != nil {
+			t.Fatalf("Set failed: %v", err)
+		}
+
+		data, _ := json.Marshal(mock.GetLastCall().Data)
+
+		want := `"instances":{"main":{"env":{"LOG":"debug"},"jail":{"name":"agent","procfs":true},` +
+			`"command":["/usr/bin/agent","-f"],"respawn":["3600","5","0"]}}`
+		if !strings.Contains(string(data), want) {
+			t.Errorf("unexpected set request: %s", data)
+		}
+	})
+
+	t.Run("Signal", func(t *testing.T) {
+		mock.AddResponse("service", "signal", map[string]any{})
+		mgr := service.New(mock)
+
+		err := mgr.Signal(ctx, "agent", "", service.SignalHUP)
+		if err != nil {
+			t.Fatalf("Signal failed: %v", err)
+		}
+
+		params, _ := mock.GetLastCall().Data.(map[string]any)
+		if _, ok := params["instance"]; ok || params["signal"] != service.SignalHUP {
+			t.Errorf("unexpected signal request: %+v", params)
+		}
+
+		err = mgr.Signal(ctx, "agent", "main", 0)
+		if !errdefs.IsInvalidParameter(err) {
+			t.Errorf("expected an invalid signal to be rejected, got %v", err)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		mock.AddResponse("service", "update_start", map[string]any{})
+		mock.AddResponse("service", "update_complete", map[string]any{})
+		mock.AddResponse("service", "add", map[string]any{})
+		mgr := service.New(mock)
+		mock.Calls = nil
+
+		errFailed := errors.New("failed")
+
+		err := mgr.Update(ctx, "agent", func() error {
+			err := mgr.Add(ctx, service.SetRequest{Name: "agent"})
+			if err != nil {
+				return err
+			}
+
+			return errFailed
+		})
+		if !errors.Is(err, errFailed) {
+			t.Errorf("expected the error of fn, got %v", err)
+		}
+
+		methods := []string{}
+		for _, call := range mock.Calls {
+			methods = append(methods, call.Method)
+		}
+
+		if strings.Join(methods, ",") != "update_start,add,update_complete" {
+			t.Errorf("unexpected calls: %v", methods)
+		}
+	})
+	t.Run("Watch", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+
+		sub, err := service.New(mock).Watch(ctx, "dnsmasq")
+		if err != nil {
+			t.Fatalf("Watch failed: %v", err)
+		}
+
+		defer func() { _ = sub.Close() }()
+
+		mock.Emit("service", service.InstanceEventStart, map[string]any{"service": "uhttpd", "instance": "main"})
+		mock.Emit("service", "config.change", map[string]any{"package": "dhcp"})
+		mock.Emit("service", service.InstanceEventCrash, map[string]any{"service": "dnsmasq", "instance": "cfg01411c"})
+
+		event, ok := service.ParseInstanceEvent(<-sub.Events())
+		if !ok || event.Type != service.InstanceEventCrash || event.Service != "dnsmasq" ||
+			event.Instance != "cfg01411c" {
+			t.Errorf("unexpected instance event: %+v", event)
+		}
+	})
 }
@@ -4,6 +4,9 @@
 package service
 
 import (
+	"encoding/json"
+	"strconv"
+
 	"github.com/honeybbq/goubus/v2"
 )
 
@@ -12,23 +15,73 @@ type Info struct {
 	Instances map[string]Instance `json:"instances"`
 }
 
-// Instance represents a service instance.
+// Instance represents a service instance. The fields beyond the command and state are only
+// reported in verbose listings or when the instance configures them. Seccomp and Capabilities
+// are the paths of the seccomp policy and capability set files, Bundle is the OCI bundle
+// directory of a container instance.
 type Instance struct {
-	Command []string    `json:"command"`
-	Pid     int         `json:"pid"`
-	Running goubus.Bool `json:"running"`
+	Env          map[string]string `json:"env,omitempty"`
+	Respawn      *Respawn          `json:"respawn,omitempty"`
+	Jail         *Jail             `json:"jail,omitempty"`
+	Limits       *Limits           `json:"limits,omitempty"`
+	User         string            `json:"user,omitempty"`
+	Group        string            `json:"group,omitempty"`
+	Seccomp      string            `json:"seccomp,omitempty"`
+	Capabilities string            `json:"capabilities,omitempty"`
+	Bundle       string            `json:"bundle,omitempty"`
+	Command      []string          `json:"command"`
+	Pid          int               `json:"pid"`
+	ExitCode     int               `json:"exit_code,omitempty"`
+	TermTimeout  int               `json:"term_timeout,omitempty"`
+	Running      goubus.Bool       `json:"running"`
+	NoNewPrivs   goubus.Bool       `json:"no_new_privs,omitempty"`
+}
+
+// Instance event types procd sends on the service object.
+const (
+	// InstanceEventStart reports that an instance was started or respawned.
+	InstanceEventStart = "instance.start"
+	// InstanceEventStop reports that an instance exited. A respawn or crash event follows when
+	// it was not stopped on purpose.
+	InstanceEventStop = "instance.stop"
+	// InstanceEventRespawn reports that an instance exited unexpectedly and will be respawned.
+	InstanceEventRespawn = "instance.respawn"
+	// InstanceEventCrash reports that an instance crashed more often than its respawn retries
+	// allow and was given up.
+	InstanceEventCrash = "instance.fail"
+)
+
+// InstanceEvent is an instance state change reported by procd through a ubus notification.
+type InstanceEvent struct {
+	// Type is the notification type, one of the InstanceEvent constants.
+	Type     string `json:"type"`
+	Service  string `json:"service"`
+	Instance string `json:"instance"`
 }
 
-// Respawn holds respawn configuration.
+// Respawn holds respawn configuration: an instance that exits within Threshold seconds of its
+// start is restarted after Timeout seconds, at most Retry times in a row (0 retries forever).
 type Respawn struct {
 	Threshold int `json:"threshold"`
 	Timeout   int `json:"timeout"`
 	Retry     int `json:"retry"`
 }
 
-// Jail holds sandboxing configuration.
+// Jail holds sandboxing configuration for ujail. Mount maps paths of the host to mount into
+// the jail to "1" for writable or "0" for read-only mounts.
 type Jail struct {
-	Name string `json:"name"`
+	Mount     map[string]string `json:"mount,omitempty"`
+	Name      string            `json:"name,omitempty"`
+	Hostname  string            `json:"hostname,omitempty"`
+	ProcFS    goubus.Bool       `json:"procfs,omitempty"`
+	SysFS     goubus.Bool       `json:"sysfs,omitempty"`
+	Ubus      goubus.Bool       `json:"ubus,omitempty"`
+	Log       goubus.Bool       `json:"log,omitempty"`
+	ReadOnly  goubus.Bool       `json:"ronly,omitempty"`
+	NetNS     goubus.Bool       `json:"netns,omitempty"`
+	UserNS    goubus.Bool       `json:"userns,omitempty"`
+	CgroupsNS goubus.Bool       `json:"cgroupsns,omitempty"`
+	Console   goubus.Bool       `json:"console,omitempty"`
 }
 
 // Limits represents resource limits.
@@ -36,6 +89,48 @@ type Limits struct {
 	NoFile string `json:"nofile,omitempty"`
 }
 
+// InstanceConfig describes a procd instance to register with Set or Add.
+type InstanceConfig struct {
+	Env          map[string]string `json:"env,omitempty"`
+	Data         map[string]any    `json:"data,omitempty"`
+	Limits       map[string]string `json:"limits,omitempty"`
+	Respawn      *Respawn          `json:"-"`
+	Jail         *Jail             `json:"jail,omitempty"`
+	User         string            `json:"user,omitempty"`
+	Group        string            `json:"group,omitempty"`
+	PidFile      string            `json:"pidfile,omitempty"`
+	Seccomp      string            `json:"seccomp,omitempty"`
+	Capabilities string            `json:"capabilities,omitempty"`
+	Command      []string          `json:"command"`
+	File         []string          `json:"file,omitempty"`
+	Netdev       []string          `json:"netdev,omitempty"`
+	Watch        []string          `json:"watch,omitempty"`
+	TermTimeout  int               `json:"term_timeout,omitempty"`
+	ReloadSignal int               `json:"reload_signal,omitempty"`
+	Stdout       bool              `json:"stdout,omitempty"`
+	Stderr       bool              `json:"stderr,omitempty"`
+	NoNewPrivs   bool              `json:"no_new_privs,omitempty"`
+}
+
+// MarshalJSON encodes the instance the way procd.sh does, with the respawn parameters as an
+// array of strings.
+func (c InstanceConfig) MarshalJSON() ([]byte, error) {
+	type config InstanceConfig
+
+	var respawn []string
+	if c.Respawn != nil {
+		respawn = []string{
+			strconv.Itoa(c.Respawn.Threshold), strconv.Itoa(c.Respawn.Timeout), strconv.Itoa(c.Respawn.Retry),
+		}
+	}
+
+	return json.Marshal(struct {
+		config
+
+		Respawn []string `json:"respawn,omitempty"`
+	}{config(c), respawn})
+}
+
 // SetRequest represents parameters for setting up a service.
 type SetRequest struct {
 	Instances map[string]any `json:"instances,omitempty"`
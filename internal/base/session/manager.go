@@ -5,9 +5,12 @@ package session
 
 import (
 	"context"
+	"maps"
+	"slices"
 	"time"
 
 	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
 )
 
 // Manager provides an interface for managing ubus sessions.
@@ -20,6 +23,11 @@ func New(t goubus.Transport) *Manager {
 	return &Manager{caller: t}
 }
 
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"session"}
+}
+
 // Create creates a new session.
 func (m *Manager) Create(ctx context.Context, timeout int) (*Data, error) {
 	params := map[string]any{"timeout": timeout}
@@ -44,20 +52,57 @@ func (m *Manager) List(ctx context.Context) (map[string]any, error) {
 	return *res, nil
 }
 
-// Grant grants access to a session.
-func (m *Manager) Grant(ctx context.Context, req GrantRequest) error {
-	_, err := m.caller.Call(ctx, "session", "grant", req)
+// Grant grants the session access to methods of object in scope, e.g. scope "ubus" with
+// object "network.interface.wan" and method "status", or scope "uci" with object "network" and
+// method "read". Object and methods may be wildcard patterns; without methods "*" is granted.
+func (m *Manager) Grant(ctx context.Context, session, scope, object string, methods ...string) error {
+	_, err := m.caller.Call(ctx, "session", "grant", newGrantRequest(session, scope, object, methods))
 
 	return err
 }
 
-// Revoke revokes access from a session.
-func (m *Manager) Revoke(ctx context.Context, req GrantRequest) error {
-	_, err := m.caller.Call(ctx, "session", "revoke", req)
+// Revoke revokes grants made with Grant. rpcd matches the object and method patterns literally,
+// so revoke exactly what was granted; without methods the "*" grant is revoked.
+func (m *Manager) Revoke(ctx context.Context, session, scope, object string, methods ...string) error {
+	_, err := m.caller.Call(ctx, "session", "revoke", newGrantRequest(session, scope, object, methods))
 
 	return err
 }
 
+// CreateRestricted creates a session that can only use the permissions in acls, e.g. a token
+// handed to a sub-service that only reads the interface status. The session is destroyed again
+// if a grant fails.
+func (m *Manager) CreateRestricted(ctx context.Context, timeout int, acls ACLs) (*Data, error) {
+	data, err := m.Create(ctx, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.grantAll(ctx, data.UbusRPCSession, ScopeUbus, acls.Ubus)
+	if err == nil {
+		err = m.grantAll(ctx, data.UbusRPCSession, ScopeUCI, acls.Uci)
+	}
+
+	if err != nil {
+		_ = m.Destroy(ctx, data.UbusRPCSession)
+
+		return nil, errdefs.Wrapf(err, "failed to grant session permissions")
+	}
+
+	return data, nil
+}
+
+func (m *Manager) grantAll(ctx context.Context, session, scope string, grants map[string][]string) error {
+	for _, object := range slices.Sorted(maps.Keys(grants)) {
+		err := m.Grant(ctx, session, scope, object, grants[object]...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Access checks access for a session.
 func (m *Manager) Access(ctx context.Context, req AccessRequest) (bool, error) {
 	res, err := goubus.Call[map[string]bool](ctx, m.caller, "session", "access", req)
@@ -105,6 +150,33 @@ func (m *Manager) Unset(ctx context.Context, session string, keys []string) erro
 	return err
 }
 
+// SetData stores value under key in the session data.
+func (m *Manager) SetData(ctx context.Context, session, key string, value any) error {
+	return m.Set(ctx, session, map[string]any{key: value})
+}
+
+// GetData returns the value stored under key in the session data.
+func (m *Manager) GetData(ctx context.Context, session, key string) (any, error) {
+	req := map[string]any{
+		"ubus_rpc_session": session,
+		"keys":             []string{key},
+	}
+
+	res, err := goubus.Call[struct {
+		Values map[string]any `json:"values"`
+	}](ctx, m.caller, "session", "get", req)
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := res.Values[key]
+	if !ok {
+		return nil, errdefs.Wrapf(errdefs.ErrNotFound, "session data %q", key)
+	}
+
+	return value, nil
+}
+
 // Destroy destroys a session.
 func (m *Manager) Destroy(ctx context.Context, session string) error {
 	req := map[string]any{"ubus_rpc_session": session}
@@ -124,3 +196,17 @@ func (m *Manager) Login(ctx context.Context, req LoginRequest) (*Data, error) {
 
 	return sessionData, nil
 }
+
+// newGrantRequest pairs object with each method, defaulting to "*".
+func newGrantRequest(session, scope, object string, methods []string) GrantRequest {
+	if len(methods) == 0 {
+		methods = []string{"*"}
+	}
+
+	objects := make([][]string, 0, len(methods))
+	for _, method := range methods {
+		objects = append(objects, []string{object, method})
+	}
+
+	return GrantRequest{Session: session, Scope: scope, Objects: objects}
+}
@@ -5,8 +5,11 @@ package session_test
 
 import (
 	"context"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/honeybbq/goubus/v2/errdefs"
 	"github.com/honeybbq/goubus/v2/internal/base/session"
 	"github.com/honeybbq/goubus/v2/internal/testutil"
 )
@@ -32,4 +35,76 @@ func TestSessionManager(t *testing.T) {
 			t.Errorf("unexpected session data: %+v", sess)
 		}
 	})
+	t.Run("Grant", func(t *testing.T) {
+		mock.AddResponse("session", "grant", map[string]any{})
+
+		mgr := session.New(mock)
+
+		err := mgr.Grant(ctx, "sid", session.ScopeUbus, "network.interface.wan", "status", "dump")
+		if err != nil {
+			t.Fatalf("Grant failed: %v", err)
+		}
+
+		req, ok := mock.GetLastCall().Data.(session.GrantRequest)
+		if !ok || req.Session != "sid" || req.Scope != "ubus" ||
+			!reflect.DeepEqual(req.Objects, [][]string{{"network.interface.wan", "status"}, {"network.interface.wan", "dump"}}) {
+			t.Errorf("unexpected grant request: %+v", mock.GetLastCall().Data)
+		}
+	})
+
+	t.Run("CreateRestricted", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("session", "create", map[string]any{"ubus_rpc_session": "sid", "timeout": 60})
+		mock.AddResponse("session", "grant", map[string]any{})
+		mock.AddResponse("session", "destroy", map[string]any{})
+
+		mgr := session.New(mock)
+
+		data, err := mgr.CreateRestricted(ctx, 60, session.ACLs{
+			Ubus: map[string][]string{"system": {"board"}, "network.interface.*": {"status"}},
+			Uci:  map[string][]string{"network": {"read"}},
+		})
+		if err != nil || data.UbusRPCSession != "sid" {
+			t.Fatalf("CreateRestricted failed: %v", err)
+		}
+
+		var grants []string
+		for _, call := range mock.Calls {
+			if req, ok := call.Data.(session.GrantRequest); ok {
+				grants = append(grants, req.Scope+" "+strings.Join(req.Objects[0], "."))
+			}
+		}
+
+		want := []string{"ubus network.interface.*.status", "ubus system.board", "uci network.read"}
+		if !reflect.DeepEqual(grants, want) {
+			t.Errorf("unexpected grants: %v", grants)
+		}
+
+		mock.AddResponse("session", "grant", errdefs.ErrPermissionDenied)
+
+		_, err = mgr.CreateRestricted(ctx, 60, session.ACLs{Ubus: map[string][]string{"system": nil}})
+		if !errdefs.IsPermissionDenied(err) {
+			t.Errorf("expected permission denied, got %v", err)
+		}
+
+		if mock.GetLastCall().Method != "destroy" {
+			t.Errorf("expected the session to be destroyed, last call was %s", mock.GetLastCall().Method)
+		}
+	})
+
+	t.Run("GetData", func(t *testing.T) {
+		mock.AddResponse("session", "get", map[string]any{"values": map[string]any{"owner": "backup"}})
+
+		mgr := session.New(mock)
+
+		value, err := mgr.GetData(ctx, "sid", "owner")
+		if err != nil || value != "backup" {
+			t.Errorf("unexpected session data %v: %v", value, err)
+		}
+
+		_, err = mgr.GetData(ctx, "sid", "missing")
+		if !errdefs.IsNotFound(err) {
+			t.Errorf("expected not found, got %v", err)
+		}
+	})
 }
@@ -5,6 +5,12 @@ package session
 
 import "time"
 
+// ACL scopes of Grant, Revoke and AccessRequest.
+const (
+	ScopeUbus = "ubus"
+	ScopeUCI  = "uci"
+)
+
 // Data represents session information.
 type Data struct {
 	ExpireTime     time.Time `json:"-"`
@@ -12,17 +18,19 @@ type Data struct {
 	Timeout        int       `json:"timeout"`
 }
 
-// ACLs represents access control lists.
+// ACLs represents access control lists: ubus objects with their methods, and UCI configs with
+// the "read" or "write" access.
 type ACLs struct {
 	Ubus map[string][]string `json:"ubus"`
 	Uci  map[string][]string `json:"uci"`
 }
 
-// GrantRequest represents parameters for granting session access.
+// GrantRequest represents parameters for granting session access. Each entry of Objects is an
+// [object, method] pair.
 type GrantRequest struct {
-	Session string   `json:"ubus_rpc_session"`
-	Scope   string   `json:"scope"`
-	Objects []string `json:"objects"`
+	Session string     `json:"ubus_rpc_session"`
+	Scope   string     `json:"scope"`
+	Objects [][]string `json:"objects"`
 }
 
 // AccessRequest represents parameters for checking session access.
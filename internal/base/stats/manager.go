@@ -0,0 +1,247 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package stats
+
+import (
+	"context"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/luci"
+	"github.com/honeybbq/goubus/v2/internal/base/network"
+)
+
+// DefaultInterval is the poll interval when Options.Interval is not set.
+const DefaultInterval = time.Second
+
+// Manager reads device traffic counters and polls them into rate samples.
+type Manager struct {
+	network *network.Manager
+	luci    *luci.Manager
+}
+
+// New creates a new base stats Manager.
+func New(t goubus.Transport) *Manager {
+	return &Manager{network: network.New(t, nil), luci: luci.New(t, nil)}
+}
+
+// RequiredObjects lists the ubus objects the manager calls with the default source.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"network.device"}
+}
+
+// Read returns the current counters of the devices selected by opts.
+func (m *Manager) Read(ctx context.Context, opts Options) (map[string]Counters, error) {
+	if opts.Source == SourceLuCI {
+		return m.readLuCI(ctx, opts.Devices)
+	}
+
+	devices, err := m.network.Devices().Status(ctx, "")
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read device statistics")
+	}
+
+	counters := make(map[string]Counters, len(devices))
+
+	for name, device := range devices {
+		if len(opts.Devices) > 0 && !slices.Contains(opts.Devices, name) {
+			continue
+		}
+
+		s := device.Statistics
+		counters[name] = Counters{
+			RxBytes:   s.RxBytes,
+			TxBytes:   s.TxBytes,
+			RxPackets: s.RxPackets,
+			TxPackets: s.TxPackets,
+			RxErrors:  s.RxErrors,
+			TxErrors:  s.TxErrors,
+		}
+	}
+
+	return counters, nil
+}
+
+func (m *Manager) readLuCI(ctx context.Context, devices []string) (map[string]Counters, error) {
+	counters := make(map[string]Counters, len(devices))
+
+	for _, name := range devices {
+		s, err := m.luci.GetRealtimeStats(ctx, "interface", name)
+		if err != nil {
+			return nil, errdefs.Wrapf(err, "failed to read statistics of %s", name)
+		}
+
+		counters[name] = Counters{
+			RxBytes:   s.RxBytes,
+			TxBytes:   s.TxBytes,
+			RxPackets: s.RxPackets,
+			TxPackets: s.TxPackets,
+		}
+	}
+
+	return counters, nil
+}
+
+// Poller delivers a Sample per poll interval.
+type Poller struct {
+	manager *Manager
+	opts    Options
+	samples chan Sample
+	stop    chan struct{}
+	err     error
+	once    sync.Once
+	mu      sync.Mutex
+}
+
+// Poll reads the counters selected by opts every interval and delivers the rates between
+// consecutive reads, starting one interval after the call. It ends when ctx is done, Close is
+// called or a read fails; Err reports the failure.
+func (m *Manager) Poll(ctx context.Context, opts Options) (*Poller, error) {
+	if opts.Source != "" && opts.Source != SourceDevice && opts.Source != SourceLuCI {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "unknown statistics source %q", opts.Source)
+	}
+
+	if opts.Source == SourceLuCI && len(opts.Devices) == 0 {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "the luci source needs a device list")
+	}
+
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultInterval
+	}
+
+	poller := &Poller{
+		manager: m,
+		opts:    opts,
+		samples: make(chan Sample),
+		stop:    make(chan struct{}),
+	}
+
+	go poller.run(ctx)
+
+	return poller, nil
+}
+
+// Samples returns the channel of samples. It is closed when the poller ends.
+func (p *Poller) Samples() <-chan Sample {
+	return p.samples
+}
+
+// Err returns the error that ended the poller, if any.
+func (p *Poller) Err() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.err
+}
+
+// Close stops polling.
+func (p *Poller) Close() error {
+	p.once.Do(func() { close(p.stop) })
+
+	return nil
+}
+
+func (p *Poller) run(ctx context.Context) {
+	defer close(p.samples)
+
+	prev, err := p.manager.Read(ctx, p.opts)
+	last := time.Now()
+
+	ticker := time.NewTicker(p.opts.Interval)
+	defer ticker.Stop()
+
+	for err == nil {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		}
+
+		var cur map[string]Counters
+
+		cur, err = p.manager.Read(ctx, p.opts)
+		if err != nil {
+			break
+		}
+
+		now := time.Now()
+		sample := Sample{Time: now, Elapsed: now.Sub(last), Rates: Rates(prev, cur, now.Sub(last))}
+		prev, last = cur, now
+
+		select {
+		case p.samples <- sample:
+		case <-ctx.Done():
+			return
+		case <-p.stop:
+			return
+		}
+	}
+
+	if ctx.Err() == nil {
+		p.mu.Lock()
+		p.err = err
+		p.mu.Unlock()
+	}
+}
+
+// Rates computes the rates of the devices present in both prev and cur over elapsed.
+func Rates(prev, cur map[string]Counters, elapsed time.Duration) []Rate {
+	rates := make([]Rate, 0, len(cur))
+
+	for name, counters := range cur {
+		old, ok := prev[name]
+		if !ok {
+			continue
+		}
+
+		delta := Delta(old, counters)
+		rate := Rate{Device: name, Counters: counters, Delta: delta}
+
+		if seconds := elapsed.Seconds(); seconds > 0 {
+			rate.RxRate = float64(delta.RxBytes) / seconds
+			rate.TxRate = float64(delta.TxBytes) / seconds
+			rate.RxPacketRate = float64(delta.RxPackets) / seconds
+			rate.TxPacketRate = float64(delta.TxPackets) / seconds
+		}
+
+		rates = append(rates, rate)
+	}
+
+	slices.SortFunc(rates, func(a, b Rate) int { return strings.Compare(a.Device, b.Device) })
+
+	return rates
+}
+
+// Delta returns the traffic between two reads of the same device.
+func Delta(prev, cur Counters) Counters {
+	return Counters{
+		RxBytes:   CounterDelta(prev.RxBytes, cur.RxBytes),
+		TxBytes:   CounterDelta(prev.TxBytes, cur.TxBytes),
+		RxPackets: CounterDelta(prev.RxPackets, cur.RxPackets),
+		TxPackets: CounterDelta(prev.TxPackets, cur.TxPackets),
+		RxErrors:  CounterDelta(prev.RxErrors, cur.RxErrors),
+		TxErrors:  CounterDelta(prev.TxErrors, cur.TxErrors),
+	}
+}
+
+// CounterDelta returns the increase of a counter between two readings. A counter that went
+// backwards and fit in 32 bits wrapped, as the kernel counters of 32-bit targets do; a larger one
+// was reset, e.g. because the device was recreated, and the new value is the traffic since the reset.
+func CounterDelta(prev, cur int64) int64 {
+	switch {
+	case cur >= prev:
+		return cur - prev
+	case prev <= math.MaxUint32:
+		return math.MaxUint32 - prev + cur + 1
+	default:
+		return cur
+	}
+}
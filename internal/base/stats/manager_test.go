@@ -0,0 +1,130 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package stats_test
+
+import (
+	"context"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/stats"
+	"github.com/honeybbq/goubus/v2/internal/testutil"
+)
+
+// countingTransport answers network.device status with counters that grow by 1000 bytes and
+// 10 packets per call.
+type countingTransport struct {
+	*testutil.MockTransport
+
+	calls int64
+	mu    sync.Mutex
+}
+
+func (c *countingTransport) Call(ctx context.Context, service, method string, data any) (goubus.Result, error) {
+	if service != "network.device" {
+		return c.MockTransport.Call(ctx, service, method, data)
+	}
+
+	c.mu.Lock()
+	c.calls++
+	n := c.calls
+	c.mu.Unlock()
+
+	return &testutil.MockResult{Data: map[string]any{
+		"br-lan": map[string]any{"statistics": map[string]any{"rx_bytes": n * 1000, "rx_packets": n * 10}},
+		"eth0":   map[string]any{"statistics": map[string]any{"tx_bytes": n * 500}},
+	}}, nil
+}
+
+func TestStatsPoller(t *testing.T) {
+	ctx := context.Background()
+	mgr := stats.New(&countingTransport{MockTransport: testutil.NewMockTransport()})
+
+	poller, err := mgr.Poll(ctx, stats.Options{Devices: []string{"br-lan"}, Interval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+
+	for range 2 {
+		sample := <-poller.Samples()
+		if len(sample.Rates) != 1 || sample.Elapsed <= 0 {
+			t.Fatalf("unexpected sample: %+v", sample)
+		}
+
+		rate := sample.Rates[0]
+		if rate.Device != "br-lan" || rate.Delta != (stats.Counters{RxBytes: 1000, RxPackets: 10}) || rate.RxRate <= 0 {
+			t.Errorf("unexpected rate: %+v", rate)
+		}
+	}
+
+	_ = poller.Close()
+
+	for range poller.Samples() {
+	}
+
+	if poller.Err() != nil {
+		t.Errorf("unexpected error after Close: %v", poller.Err())
+	}
+
+	_, err = mgr.Poll(ctx, stats.Options{Source: stats.SourceLuCI})
+	if !errdefs.IsInvalidParameter(err) {
+		t.Errorf("expected the luci source to need devices, got %v", err)
+	}
+}
+
+func TestStatsPollerError(t *testing.T) {
+	mock := testutil.NewMockTransport()
+	mock.AddResponse("luci", "getRealtimeStats", map[string]any{"rx_bytes": 100})
+
+	poller, err := stats.New(mock).Poll(context.Background(), stats.Options{
+		Source: stats.SourceLuCI, Devices: []string{"wan"}, Interval: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+
+	<-poller.Samples()
+	mock.AddResponse("luci", "getRealtimeStats", errdefs.Wrapf(errdefs.ErrPermissionDenied, "denied"))
+
+	for range poller.Samples() {
+	}
+
+	if !errdefs.IsPermissionDenied(poller.Err()) {
+		t.Errorf("expected the read error, got %v", poller.Err())
+	}
+}
+
+func TestStatsDelta(t *testing.T) {
+	tests := []struct {
+		name      string
+		prev, cur int64
+		want      int64
+	}{
+		{"Increase", 100, 250, 150},
+		{"Wrap32", math.MaxUint32 - 9, 5, 15},
+		{"Reset", 1 << 40, 300, 300},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delta := stats.Delta(stats.Counters{RxBytes: tt.prev}, stats.Counters{RxBytes: tt.cur})
+			if delta.RxBytes != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, delta.RxBytes)
+			}
+		})
+	}
+
+	rates := stats.Rates(
+		map[string]stats.Counters{"eth0": {TxBytes: 1000}, "gone": {}},
+		map[string]stats.Counters{"eth0": {TxBytes: 3000}, "new": {}},
+		2*time.Second,
+	)
+	if len(rates) != 1 || rates[0].TxRate != 1000 {
+		t.Errorf("unexpected rates: %+v", rates)
+	}
+}
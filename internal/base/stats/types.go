@@ -0,0 +1,59 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package stats
+
+import "time"
+
+// Sources of a Poller.
+const (
+	// SourceDevice reads all devices with one network.device status call.
+	SourceDevice = "device"
+	// SourceLuCI reads each device with luci getRealtimeStats, e.g. when the session may not
+	// call network.device.
+	SourceLuCI = "luci"
+)
+
+// Options configures a Poller.
+type Options struct {
+	// Devices limits the poller to these devices; SourceLuCI needs them. Empty polls all devices.
+	Devices []string
+	// Source defaults to SourceDevice.
+	Source string
+	// Interval defaults to DefaultInterval.
+	Interval time.Duration
+}
+
+// Counters are the traffic counters of a device.
+type Counters struct {
+	RxBytes   int64
+	TxBytes   int64
+	RxPackets int64
+	TxPackets int64
+	RxErrors  int64
+	TxErrors  int64
+}
+
+// Rate is the traffic of a device between two samples.
+type Rate struct {
+	Device string
+	// Counters are the totals at the time of the sample.
+	Counters Counters
+	// Delta is the traffic since the previous sample.
+	Delta Counters
+	// RxRate and TxRate are in bytes per second.
+	RxRate float64
+	TxRate float64
+	// RxPacketRate and TxPacketRate are in packets per second.
+	RxPacketRate float64
+	TxPacketRate float64
+}
+
+// Sample is the traffic of all polled devices between two polls.
+type Sample struct {
+	Time time.Time
+	// Elapsed is the time since the previous poll.
+	Elapsed time.Duration
+	// Rates holds the devices seen in both polls, sorted by device name.
+	Rates []Rate
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package system
+
+import (
+	"context"
+	"time"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/hostapd"
+	"github.com/honeybbq/goubus/v2/internal/base/network"
+	"github.com/honeybbq/goubus/v2/internal/base/stats"
+)
+
+const (
+	defaultIdleInterval = 30 * time.Second
+	defaultWANInterface = "wan"
+)
+
+// trafficSample is a reading of the WAN byte counters. The zero sample means no reading.
+type trafficSample struct {
+	at      time.Time
+	rxBytes int64
+	txBytes int64
+}
+
+// RebootWhenIdle samples the associated stations and the WAN traffic every policy interval
+// and reboots once both are at or below the policy thresholds, or once the deadline passes.
+// The first traffic rate is known after one interval, so the reboot never happens right away.
+// With a deadline, a failed sample counts as busy, so the reboot still happens at the deadline.
+// It returns when the reboot was requested, or with an error if ctx is done or, without a
+// deadline, if sampling fails.
+func (m *Manager) RebootWhenIdle(ctx context.Context, policy IdlePolicy) error {
+	policy = policy.withDefaults()
+
+	prev, err := m.sampleWAN(ctx, policy.WANInterface)
+	if err != nil && policy.Deadline.IsZero() {
+		return err
+	}
+
+	for {
+		expired, err := waitInterval(ctx, policy)
+		if err != nil {
+			return err
+		}
+
+		if expired {
+			return m.Reboot(ctx)
+		}
+
+		idle, cur, err := m.idle(ctx, policy, prev)
+		if err != nil && policy.Deadline.IsZero() {
+			return err
+		}
+
+		if idle {
+			return m.Reboot(ctx)
+		}
+
+		prev = cur
+	}
+}
+
+// withDefaults fills in the interval and WAN interface when they are unset.
+func (p IdlePolicy) withDefaults() IdlePolicy {
+	if p.Interval <= 0 {
+		p.Interval = defaultIdleInterval
+	}
+
+	if p.WANInterface == "" {
+		p.WANInterface = defaultWANInterface
+	}
+
+	return p
+}
+
+// waitInterval waits for the next sample and reports whether the deadline passed instead.
+func waitInterval(ctx context.Context, policy IdlePolicy) (bool, error) {
+	wait := policy.Interval
+	expired := false
+
+	if !policy.Deadline.IsZero() {
+		left := time.Until(policy.Deadline)
+		if left <= wait {
+			wait = max(left, 0)
+			expired = true
+		}
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false, errdefs.Wrapf(errdefs.FromTransport(ctx.Err()), "wait for the device to become idle")
+	case <-timer.C:
+		return expired, nil
+	}
+}
+
+// idle takes a sample and compares it with the policy thresholds.
+func (m *Manager) idle(ctx context.Context, policy IdlePolicy, prev trafficSample) (bool, trafficSample, error) {
+	cur, err := m.sampleWAN(ctx, policy.WANInterface)
+	if err != nil {
+		return false, cur, err
+	}
+
+	if policy.MaxWANRate > 0 {
+		if prev.at.IsZero() {
+			return false, cur, nil
+		}
+
+		elapsed := cur.at.Sub(prev.at).Seconds()
+		bytes := stats.CounterDelta(prev.rxBytes, cur.rxBytes) + stats.CounterDelta(prev.txBytes, cur.txBytes)
+
+		if elapsed > 0 && float64(bytes)/elapsed > float64(policy.MaxWANRate) {
+			return false, cur, nil
+		}
+	}
+
+	stations, err := m.countStations(ctx, policy.APs)
+	if err != nil {
+		return false, cur, err
+	}
+
+	return stations <= policy.MaxStations, cur, nil
+}
+
+// sampleWAN reads the byte counters of the device behind a logical interface.
+func (m *Manager) sampleWAN(ctx context.Context, iface string) (trafficSample, error) {
+	networks := network.New(m.caller, nil)
+
+	status, err := networks.Interface(iface).Status(ctx)
+	if err != nil {
+		return trafficSample{}, errdefs.Wrapf(err, "failed to get status of %s", iface)
+	}
+
+	device := status.L3Device
+	if device == "" {
+		device = status.Device
+	}
+
+	devices, err := networks.Devices().Status(ctx, device)
+	if err != nil {
+		return trafficSample{}, errdefs.Wrapf(err, "failed to get statistics of %s", device)
+	}
+
+	counters := devices[device].Statistics
+
+	return trafficSample{at: time.Now(), rxBytes: counters.RxBytes, txBytes: counters.TxBytes}, nil
+}
+
+// countStations sums the clients of the given APs, or of all hostapd objects when none are given.
+func (m *Manager) countStations(ctx context.Context, aps []string) (int, error) {
+	if len(aps) == 0 {
+		objects, err := goubus.Objects(ctx, m.caller, "hostapd.*")
+		if err != nil {
+			return 0, errdefs.Wrapf(err, "failed to list access points")
+		}
+
+		for _, object := range objects {
+			aps = append(aps, object.Path)
+		}
+	}
+
+	ap := hostapd.New(m.caller)
+	total := 0
+
+	for _, name := range aps {
+		clients, err := ap.AP(name).Clients(ctx)
+		if err != nil {
+			return 0, errdefs.Wrapf(err, "failed to get clients of %s", name)
+		}
+
+		total += len(clients)
+	}
+
+	return total, nil
+}
@@ -0,0 +1,298 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package system
+
+import (
+	"context"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+const (
+	modulesFile    = "/proc/modules"
+	modprobeBinary = "/sbin/modprobe"
+	rmmodBinary    = "/sbin/rmmod"
+
+	sysctlDir      = "/proc/sys/"
+	sysctlConfDir  = "/etc/sysctl.d/"
+	sysctlConfMode = 0o644
+
+	// moduleFields is the number of fields of a /proc/modules line.
+	moduleFields = 6
+)
+
+var (
+	// moduleName matches kernel module names; modprobe treats "-" and "_" alike.
+	moduleName = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_-]*$`)
+	// moduleParameter matches a module parameter assignment such as "debug=1".
+	moduleParameter = regexp.MustCompile(`^[A-Za-z0-9_.]+=\S*$`)
+	// sysctlKey matches a sysctl key in dotted form, e.g. "net.ipv4.ip_forward", or in slash
+	// form, e.g. "net/ipv4/conf/eth0.1/rp_filter", for keys with dots in a component.
+	sysctlKey = regexp.MustCompile(`^[A-Za-z0-9_]+([./][A-Za-z0-9_.@-]+)+$`)
+	// sysctlConfName matches the name of a file in /etc/sysctl.d without its .conf suffix.
+	sysctlConfName = regexp.MustCompile(`^[A-Za-z0-9_-][A-Za-z0-9_.-]*$`)
+)
+
+// Modules lists the loaded kernel modules of /proc/modules in load order, most recent first.
+func (m *Manager) Modules(ctx context.Context) ([]KernelModule, error) {
+	var table strings.Builder
+
+	_, err := m.file.Download(ctx, modulesFile, &table, 0)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read %s", modulesFile)
+	}
+
+	return ParseModules(table.String()), nil
+}
+
+// LoadModule loads a kernel module and its dependencies with modprobe, passing params such as
+// "debug=1" as module parameters.
+func (m *Manager) LoadModule(ctx context.Context, name string, params ...string) error {
+	if !moduleName.MatchString(name) {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid module name %q", name)
+	}
+
+	for _, param := range params {
+		if !moduleParameter.MatchString(param) {
+			return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid module parameter %q", param)
+		}
+	}
+
+	_, err := m.run(ctx, modprobeBinary, append([]string{name}, params...)...)
+
+	return err
+}
+
+// UnloadModule unloads a kernel module with rmmod. It fails with ErrNotFound when the module is
+// not loaded and with ErrInvalidParameter when it is in use.
+func (m *Manager) UnloadModule(ctx context.Context, name string) error {
+	if !moduleName.MatchString(name) {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid module name %q", name)
+	}
+
+	modules, err := m.Modules(ctx)
+	if err != nil {
+		return err
+	}
+
+	canonical := strings.ReplaceAll(name, "-", "_")
+
+	i := slices.IndexFunc(modules, func(mod KernelModule) bool { return mod.Name == canonical })
+	if i < 0 {
+		return errdefs.Wrapf(errdefs.ErrNotFound, "module %s is not loaded", name)
+	}
+
+	if modules[i].RefCount > 0 {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "module %s is in use by %d references %v",
+			name, modules[i].RefCount, modules[i].UsedBy)
+	}
+
+	_, err = m.run(ctx, rmmodBinary, canonical)
+
+	return err
+}
+
+// Sysctl reads the current value of a kernel parameter such as "net.ipv4.ip_forward".
+func (m *Manager) Sysctl(ctx context.Context, key string) (string, error) {
+	path, err := sysctlPath(key)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := m.file.Read(ctx, path, false)
+	if err != nil {
+		return "", errdefs.Wrapf(err, "failed to read sysctl %s", key)
+	}
+
+	return strings.TrimSpace(res.Data), nil
+}
+
+// SetSysctl sets a kernel parameter until the next reboot. Use PersistSysctl to keep it.
+func (m *Manager) SetSysctl(ctx context.Context, key, value string) error {
+	path, err := sysctlPath(key)
+	if err != nil {
+		return err
+	}
+
+	if strings.Contains(value, "\n") {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "sysctl value must be a single line")
+	}
+
+	err = m.file.Write(ctx, path, value, false, 0, false)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to set sysctl %s", key)
+	}
+
+	return nil
+}
+
+// SysctlConf reads the parameters of /etc/sysctl.d/<name>.conf. A missing file has none.
+func (m *Manager) SysctlConf(ctx context.Context, name string) (map[string]string, error) {
+	if !sysctlConfName.MatchString(name) {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid sysctl file name %q", name)
+	}
+
+	res, err := m.file.Read(ctx, sysctlConfDir+name+".conf", false)
+	if errdefs.IsNotFound(err) {
+		return map[string]string{}, nil
+	}
+
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read %s.conf", name)
+	}
+
+	return ParseSysctlConf(res.Data), nil
+}
+
+// PersistSysctl merges values into /etc/sysctl.d/<name>.conf, which the sysctl service applies
+// at boot; an empty value removes a parameter. The file is edited line by line: parameters
+// already in it are changed in place, new ones are appended and comments and other lines are
+// kept. It does not change the running kernel, see SetSysctl.
+func (m *Manager) PersistSysctl(ctx context.Context, name string, values map[string]string) error {
+	if !sysctlConfName.MatchString(name) {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid sysctl file name %q", name)
+	}
+
+	for key, value := range values {
+		_, err := sysctlPath(key)
+		if err != nil {
+			return err
+		}
+
+		if strings.ContainsAny(value, "\r\n") {
+			return errdefs.Wrapf(errdefs.ErrInvalidParameter, "value of %s must be a single line", key)
+		}
+	}
+
+	path := sysctlConfDir + name + ".conf"
+
+	res, err := m.file.Read(ctx, path, false)
+	if err != nil && !errdefs.IsNotFound(err) && !errdefs.IsNoData(err) {
+		return errdefs.Wrapf(err, "failed to read %s.conf", name)
+	}
+
+	var data string
+	if res != nil {
+		data = res.Data
+	}
+
+	return m.file.Replace(ctx, path, UpdateSysctlConf(data, values), sysctlConfMode, false)
+}
+
+// sysctlPath returns the /proc/sys path of a sysctl key.
+func sysctlPath(key string) (string, error) {
+	if !sysctlKey.MatchString(key) || strings.Contains(key, "..") {
+		return "", errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid sysctl key %q", key)
+	}
+
+	if strings.Contains(key, "/") {
+		return sysctlDir + key, nil
+	}
+
+	return sysctlDir + strings.ReplaceAll(key, ".", "/"), nil
+}
+
+// ParseModules parses /proc/modules.
+func ParseModules(data string) []KernelModule {
+	modules := []KernelModule{}
+
+	for line := range strings.Lines(data) {
+		fields := strings.Fields(line)
+		if len(fields) < moduleFields {
+			continue
+		}
+
+		size, _ := strconv.ParseUint(fields[1], 10, 64)
+		refs, _ := strconv.Atoi(fields[2])
+
+		usedBy := []string{}
+
+		for dep := range strings.SplitSeq(fields[3], ",") {
+			if dep != "" && dep != "-" {
+				usedBy = append(usedBy, dep)
+			}
+		}
+
+		modules = append(modules, KernelModule{
+			Name: fields[0], Size: size, RefCount: refs, UsedBy: usedBy, State: fields[4],
+		})
+	}
+
+	return modules
+}
+
+// ParseSysctlConf parses the "key = value" lines of a sysctl.conf file, skipping comments.
+func ParseSysctlConf(data string) map[string]string {
+	conf := make(map[string]string)
+
+	for line := range strings.Lines(data) {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if ok {
+			conf[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+
+	return conf
+}
+
+// UpdateSysctlConf applies values to the content of a sysctl.conf file line by line. The first
+// line of a parameter is rewritten with its new value and later lines of it are dropped; an empty
+// value drops every line of the parameter. Parameters not in the file are appended sorted by key,
+// and comments and other lines are kept as they are.
+func UpdateSysctlConf(data string, values map[string]string) string {
+	var b strings.Builder
+
+	written := make(map[string]bool, len(values))
+
+	for line := range strings.Lines(data) {
+		key, _, ok := strings.Cut(strings.TrimSpace(line), "=")
+		key = strings.TrimPrefix(strings.TrimSpace(key), "-")
+
+		value, changed := values[key]
+		if !ok || !changed {
+			b.WriteString(line)
+
+			if !strings.HasSuffix(line, "\n") {
+				b.WriteString("\n")
+			}
+
+			continue
+		}
+
+		if value != "" && !written[key] {
+			b.WriteString(key + "=" + value + "\n")
+		}
+
+		written[key] = true
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		if values[key] != "" && !written[key] {
+			b.WriteString(key + "=" + values[key] + "\n")
+		}
+	}
+
+	return b.String()
+}
+
+// FormatSysctlConf formats parameters as sysctl.conf lines, sorted by key.
+func FormatSysctlConf(conf map[string]string) string {
+	var b strings.Builder
+
+	for _, key := range slices.Sorted(maps.Keys(conf)) {
+		b.WriteString(key + "=" + conf[key] + "\n")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,306 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package system
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/uci"
+)
+
+const (
+	uciPackage        = "system"
+	uciLEDType        = "led"
+	uciButtonType     = "button"
+	ledSysfsDir       = "/sys/class/leds/"
+	identifyBlinkTime = 100
+)
+
+// activeTrigger matches the selected entry of an LED trigger file, e.g. "none [timer] netdev".
+var activeTrigger = regexp.MustCompile(`\[([^\]]+)\]`)
+
+// LEDs retrieves the led sections in configuration order.
+func (m *Manager) LEDs(ctx context.Context) ([]LEDConfig, error) {
+	sections, err := m.sectionsOfType(ctx, uciLEDType)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read LEDs")
+	}
+
+	leds := make([]LEDConfig, 0, len(sections))
+	for _, section := range sections {
+		leds = append(leds, LEDConfigFromSection(section))
+	}
+
+	return leds, nil
+}
+
+// SaveLED validates led, adds it as an led section, or updates the section named by
+// led.Section, and commits the system package. The LED changes when the led service restarts.
+func (m *Manager) SaveLED(ctx context.Context, led LEDConfig) error {
+	err := led.Validate()
+	if err != nil {
+		return err
+	}
+
+	return m.saveSection(ctx, uciLEDType, led.Section, led.SectionValues())
+}
+
+// Buttons retrieves the button sections in configuration order.
+func (m *Manager) Buttons(ctx context.Context) ([]ButtonConfig, error) {
+	sections, err := m.sectionsOfType(ctx, uciButtonType)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read buttons")
+	}
+
+	buttons := make([]ButtonConfig, 0, len(sections))
+	for _, section := range sections {
+		buttons = append(buttons, ButtonConfigFromSection(section))
+	}
+
+	return buttons, nil
+}
+
+// SaveButton validates button, adds it as a button section, or updates the section named by
+// button.Section, and commits the system package.
+func (m *Manager) SaveButton(ctx context.Context, button ButtonConfig) error {
+	err := button.Validate()
+	if err != nil {
+		return err
+	}
+
+	return m.saveSection(ctx, uciButtonType, button.Section, button.SectionValues())
+}
+
+// Identify blinks the LED with the given sysfs name fast for duration, e.g. to find a device
+// in a rack, and then restores its trigger. It writes /sys/class/leds directly, so the UCI
+// configuration is untouched. It blocks until duration passes or ctx is done; the trigger is
+// restored in both cases.
+func (m *Manager) Identify(ctx context.Context, sysfs string, duration time.Duration) error {
+	if sysfs == "" || strings.ContainsAny(sysfs, "/\x00") || strings.Contains(sysfs, "..") {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid LED name %q", sysfs)
+	}
+
+	dir := ledSysfsDir + sysfs + "/"
+
+	triggers, err := m.file.Read(ctx, dir+"trigger", false)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to read trigger of LED %s", sysfs)
+	}
+
+	brightness, err := m.file.Read(ctx, dir+"brightness", false)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to read brightness of LED %s", sysfs)
+	}
+
+	err = m.blink(ctx, dir)
+	if err == nil {
+		timer := time.NewTimer(duration)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			err = errdefs.FromTransport(ctx.Err())
+		case <-timer.C:
+		}
+	}
+
+	restoreErr := m.restoreLED(context.WithoutCancel(ctx), dir, triggers.Data, brightness.Data)
+	if restoreErr != nil {
+		return errdefs.Wrapf(restoreErr, "failed to restore LED %s", sysfs)
+	}
+
+	return err
+}
+
+func (m *Manager) blink(ctx context.Context, dir string) error {
+	delay := strconv.Itoa(identifyBlinkTime)
+
+	for _, write := range [][2]string{
+		{"trigger", string(LEDTriggerTimer)},
+		{"delay_on", delay},
+		{"delay_off", delay},
+	} {
+		err := m.file.Write(ctx, dir+write[0], write[1], false, 0, false)
+		if err != nil {
+			return errdefs.Wrapf(err, "failed to set LED %s", write[0])
+		}
+	}
+
+	return nil
+}
+
+// restoreLED selects the previously active trigger, or restores the brightness of an LED
+// that had none.
+func (m *Manager) restoreLED(ctx context.Context, dir, triggers, brightness string) error {
+	trigger := string(LEDTriggerNone)
+	if match := activeTrigger.FindStringSubmatch(triggers); match != nil {
+		trigger = match[1]
+	}
+
+	err := m.file.Write(ctx, dir+"trigger", trigger, false, 0, false)
+	if err != nil || trigger != string(LEDTriggerNone) {
+		return err
+	}
+
+	return m.file.Write(ctx, dir+"brightness", strings.TrimSpace(brightness), false, 0, false)
+}
+
+func (m *Manager) sectionsOfType(ctx context.Context, sectionType string) ([]*uci.Section, error) {
+	sections, err := m.uci.Package(uciPackage).GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var matching []*uci.Section
+
+	for _, section := range uci.SortedSections(sections) {
+		if section.Type == sectionType {
+			matching = append(matching, section)
+		}
+	}
+
+	return matching, nil
+}
+
+func (m *Manager) saveSection(ctx context.Context, sectionType, name string, values uci.SectionValues) error {
+	pkg := m.uci.Package(uciPackage)
+
+	var err error
+	if name == "" {
+		err = pkg.Add(ctx, sectionType, "", values)
+	} else {
+		err = pkg.Section(name).SetValues(ctx, values)
+	}
+
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to save %s section", sectionType)
+	}
+
+	return pkg.Commit(ctx)
+}
+
+// LEDConfigFromSection converts a UCI led section into an LEDConfig.
+func LEDConfigFromSection(section *uci.Section) LEDConfig {
+	var mode []string
+	for _, value := range section.Get("mode") {
+		mode = append(mode, strings.Fields(value)...)
+	}
+
+	return LEDConfig{
+		Section:  section.Name,
+		Name:     section.GetString("name"),
+		Sysfs:    section.GetString("sysfs"),
+		Trigger:  LEDTrigger(section.GetString("trigger")),
+		Dev:      section.GetString("dev"),
+		Mode:     mode,
+		DelayOn:  section.GetInt("delayon"),
+		DelayOff: section.GetInt("delayoff"),
+		Default:  section.GetBool("default"),
+	}
+}
+
+// SectionValues converts the LED into UCI option values. Options of other triggers are cleared.
+func (l *LEDConfig) SectionValues() uci.SectionValues {
+	values := uci.NewSectionValues()
+	values.SetScalar("name", l.Name)
+	values.SetScalar("sysfs", l.Sysfs)
+	values.SetScalar("trigger", string(l.Trigger))
+	values.SetBool("default", l.Default)
+	values.Set("dev")
+	values.Set("mode")
+	values.Set("delayon")
+	values.Set("delayoff")
+
+	switch l.Trigger {
+	case LEDTriggerNetdev:
+		values.SetScalar("dev", l.Dev)
+		values.SetScalar("mode", strings.Join(l.Mode, " "))
+	case LEDTriggerTimer:
+		values.SetScalar("delayon", strconv.Itoa(l.DelayOn))
+		values.SetScalar("delayoff", strconv.Itoa(l.DelayOff))
+	}
+
+	return values
+}
+
+// Validate checks the options required by the trigger.
+func (l *LEDConfig) Validate() error {
+	if l.Sysfs == "" || l.Trigger == "" {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "LED needs a sysfs name and a trigger")
+	}
+
+	switch l.Trigger {
+	case LEDTriggerNetdev:
+		if l.Dev == "" {
+			return errdefs.Wrapf(errdefs.ErrInvalidParameter, "netdev trigger of LED %s needs a device", l.Sysfs)
+		}
+
+		for _, mode := range l.Mode {
+			if !slices.Contains([]string{"link", "tx", "rx"}, mode) {
+				return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid netdev mode %q", mode)
+			}
+		}
+	case LEDTriggerTimer:
+		if l.DelayOn <= 0 || l.DelayOff <= 0 {
+			return errdefs.Wrapf(errdefs.ErrInvalidParameter, "timer trigger of LED %s needs delays", l.Sysfs)
+		}
+	}
+
+	return nil
+}
+
+// ButtonConfigFromSection converts a UCI button section into a ButtonConfig.
+func ButtonConfigFromSection(section *uci.Section) ButtonConfig {
+	return ButtonConfig{
+		Section: section.Name,
+		Button:  section.GetString("button"),
+		Action:  section.GetString("action"),
+		Handler: section.GetString("handler"),
+		Min:     section.GetInt("min"),
+		Max:     section.GetInt("max"),
+	}
+}
+
+// SectionValues converts the button into UCI option values.
+func (b *ButtonConfig) SectionValues() uci.SectionValues {
+	values := uci.NewSectionValues()
+	values.SetScalar("button", b.Button)
+	values.SetScalar("action", b.Action)
+	values.SetScalar("handler", b.Handler)
+	values.Set("min")
+	values.Set("max")
+
+	if b.Min > 0 {
+		values.SetScalar("min", strconv.Itoa(b.Min))
+	}
+
+	if b.Max > 0 {
+		values.SetScalar("max", strconv.Itoa(b.Max))
+	}
+
+	return values
+}
+
+// Validate checks the button name, action, handler and hold bounds.
+func (b *ButtonConfig) Validate() error {
+	if b.Button == "" || b.Handler == "" {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "button needs a name and a handler")
+	}
+
+	if b.Action != "pressed" && b.Action != "released" {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid button action %q", b.Action)
+	}
+
+	if b.Min < 0 || b.Max < 0 || (b.Max > 0 && b.Min > b.Max) {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid hold bounds %d-%d", b.Min, b.Max)
+	}
+
+	return nil
+}
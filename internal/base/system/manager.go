@@ -5,18 +5,40 @@ package system
 
 import (
 	"context"
+	"strings"
 
 	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/file"
+	"github.com/honeybbq/goubus/v2/internal/base/uci"
 )
 
+const poweroffBinary = "/sbin/poweroff"
+
 // Manager provides methods to interact with system-wide information.
 type Manager struct {
-	caller goubus.Transport
+	caller  goubus.Transport
+	file    *file.Manager
+	uci     *uci.Manager
+	confirm ConfirmFunc
+	// sensorPaths lists the board sensors Sensors reads.
+	sensorPaths []SensorPath
 }
 
 // New creates a new base system Manager.
 func New(t goubus.Transport) *Manager {
-	return &Manager{caller: t}
+	return &Manager{caller: t, file: file.New(t), uci: uci.New(t, nil)}
+}
+
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"system", "file", "uci", "block"}
+}
+
+// SetConfirm installs a callback that must approve reboots, power-offs, factory resets and
+// upgrades before they run. A nil callback approves every action.
+func (m *Manager) SetConfirm(confirm ConfirmFunc) {
+	m.confirm = confirm
 }
 
 // Info retrieves runtime system information.
@@ -31,7 +53,45 @@ func (m *Manager) Board(ctx context.Context) (*BoardInfo, error) {
 
 // Reboot reboots the system.
 func (m *Manager) Reboot(ctx context.Context) error {
-	_, err := m.caller.Call(ctx, "system", "reboot", nil)
+	err := m.approve(ctx, ActionReboot)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.caller.Call(ctx, "system", "reboot", nil)
+
+	return err
+}
+
+// PowerOff halts the system. procd has no ubus method for it, so it runs /sbin/poweroff,
+// which needs exec permission in the rpcd ACL.
+func (m *Manager) PowerOff(ctx context.Context) error {
+	err := m.approve(ctx, ActionPowerOff)
+	if err != nil {
+		return err
+	}
+
+	res, err := m.file.Exec(ctx, poweroffBinary, nil, nil)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to power off")
+	}
+
+	if res.Code != 0 {
+		return errdefs.Wrapf(errdefs.ErrUnknown, "poweroff exited with code %d: %s", res.Code, strings.TrimSpace(res.Stderr))
+	}
+
+	return nil
+}
+
+// FactoryReset erases the overlay and reboots into the default configuration (firstboot),
+// using rpcd-mod-rpcsys.
+func (m *Manager) FactoryReset(ctx context.Context) error {
+	err := m.approve(ctx, ActionFactoryReset)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.caller.Call(ctx, "rpc-sys", "factory", nil)
 
 	return err
 }
@@ -43,6 +103,17 @@ func (m *Manager) Watchdog(ctx context.Context, req WatchdogRequest) error {
 	return err
 }
 
+// WatchdogStatus retrieves the current watchdog settings.
+func (m *Manager) WatchdogStatus(ctx context.Context) (*WatchdogStatus, error) {
+	return goubus.Call[WatchdogStatus](ctx, m.caller, "system", "watchdog", WatchdogRequest{})
+}
+
+// SetWatchdog changes the watchdog settings and returns the resulting state.
+// Zero values leave the corresponding setting unchanged.
+func (m *Manager) SetWatchdog(ctx context.Context, req WatchdogRequest) (*WatchdogStatus, error) {
+	return goubus.Call[WatchdogStatus](ctx, m.caller, "system", "watchdog", req)
+}
+
 // Signal sends a signal to a process.
 func (m *Manager) Signal(ctx context.Context, pid, signum int) error {
 	req := SignalRequest{Pid: pid, Signum: signum}
@@ -65,7 +136,25 @@ func (m *Manager) ValidateFirmwareImage(ctx context.Context, path string) (map[s
 
 // Sysupgrade performs a system upgrade.
 func (m *Manager) Sysupgrade(ctx context.Context, req SysupgradeRequest) error {
-	_, err := m.caller.Call(ctx, "system", "sysupgrade", req)
+	err := m.approve(ctx, ActionSysupgrade)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.caller.Call(ctx, "system", "sysupgrade", req)
 
 	return err
 }
+
+func (m *Manager) approve(ctx context.Context, action Action) error {
+	if m.confirm == nil {
+		return nil
+	}
+
+	err := m.confirm(ctx, action)
+	if err != nil {
+		return errdefs.Wrapf(err, "%s not confirmed", action)
+	}
+
+	return nil
+}
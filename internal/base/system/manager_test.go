@@ -5,8 +5,14 @@ package system_test
 
 import (
 	"context"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/honeybbq/goubus/v2/errdefs"
 	"github.com/honeybbq/goubus/v2/internal/base/system"
 	"github.com/honeybbq/goubus/v2/internal/testutil"
 )
@@ -44,6 +50,46 @@ func TestSystemManager(t *testing.T) {
 	t.Run("Firmware", func(t *testing.T) {
 		testSystemFirmware(t, ctx, mock, mgr)
 	})
+
+	t.Run("PowerControl", func(t *testing.T) {
+		testSystemPowerControl(t, ctx)
+	})
+
+	t.Run("RebootWhenIdle", func(t *testing.T) {
+		testSystemRebootWhenIdle(t, ctx)
+	})
+
+	t.Run("LEDs", func(t *testing.T) {
+		testSystemLEDs(t, ctx)
+	})
+
+	t.Run("Identify", func(t *testing.T) {
+		testSystemIdentify(t, ctx)
+	})
+
+	t.Run("Sensors", func(t *testing.T) {
+		testSystemSensors(t, ctx)
+	})
+
+	t.Run("CPUStats", func(t *testing.T) {
+		testSystemCPUStats(t, ctx)
+	})
+
+	t.Run("Processes", func(t *testing.T) {
+		testSystemProcesses(t, ctx)
+	})
+
+	t.Run("Storage", func(t *testing.T) {
+		testSystemStorage(t, ctx)
+	})
+
+	t.Run("KernelModules", func(t *testing.T) {
+		testSystemKernelModules(t, ctx)
+	})
+
+	t.Run("Sysctl", func(t *testing.T) {
+		testSystemSysctl(t, ctx)
+	})
 }
 
 func testSystemBoard(t *testing.T, ctx context.Context, mock *testutil.MockTransport, mgr *system.Manager) {
@@ -119,3 +165,569 @@ func testSystemFirmware(t *testing.T, ctx context.Context, mock *testutil.MockTr
 		}
 	})
 }
+
+func testSystemPowerControl(t *testing.T, ctx context.Context) {
+	t.Helper()
+	t.Run("WatchdogStatus", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("system", "watchdog", map[string]any{
+			"status": "running", "timeout": 30, "frequency": 5, "magicclose": false,
+		})
+
+		status, err := system.New(mock).SetWatchdog(ctx, system.WatchdogRequest{Timeout: 30})
+		if err != nil {
+			t.Fatalf("SetWatchdog failed: %v", err)
+		}
+
+		if status.Status != "running" || status.Timeout != 30 || status.Frequency != 5 {
+			t.Errorf("unexpected watchdog status: %+v", status)
+		}
+	})
+
+	t.Run("Confirm", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("rpc-sys", "factory", map[string]any{})
+		mock.AddResponse("file", "exec", map[string]any{"code": 0})
+
+		mgr := system.New(mock)
+
+		var asked []system.Action
+
+		mgr.SetConfirm(func(_ context.Context, action system.Action) error {
+			asked = append(asked, action)
+			if action == system.ActionFactoryReset {
+				return errdefs.ErrPermissionDenied
+			}
+
+			return nil
+		})
+
+		err := mgr.FactoryReset(ctx)
+		if !errdefs.IsPermissionDenied(err) || len(mock.Calls) != 0 {
+			t.Errorf("expected the factory reset to be refused, got %v (%d calls)", err, len(mock.Calls))
+		}
+
+		err = mgr.PowerOff(ctx)
+		if err != nil {
+			t.Fatalf("PowerOff failed: %v", err)
+		}
+
+		if call := mock.GetLastCall(); call.Service != "file" || call.Method != "exec" {
+			t.Errorf("unexpected call: %+v", call)
+		}
+
+		if len(asked) != 2 || asked[1] != system.ActionPowerOff {
+			t.Errorf("unexpected confirmations: %v", asked)
+		}
+	})
+}
+
+func newIdleMock(stations int) *testutil.MockTransport {
+	mock := testutil.NewMockTransport()
+	mock.AddResponse("network.interface.wan", "status", map[string]any{"l3_device": "eth1"})
+	mock.AddResponse("network.device", "status", map[string]any{
+		"statistics": map[string]any{"rx_bytes": 1000, "tx_bytes": 500},
+	})
+	mock.AddResponse("system", "reboot", map[string]any{})
+
+	clients := map[string]any{}
+	for i := range stations {
+		clients[fmt.Sprintf("00:00:00:00:00:%02x", i)] = map[string]any{"authorized": true}
+	}
+
+	mock.AddResponse("hostapd.phy0-ap0", "get_clients", map[string]any{"clients": clients})
+
+	return mock
+}
+
+func testSystemRebootWhenIdle(t *testing.T, ctx context.Context) {
+	t.Helper()
+	t.Run("Idle", func(t *testing.T) {
+		mock := newIdleMock(1)
+
+		err := system.New(mock).RebootWhenIdle(ctx, system.IdlePolicy{
+			APs: []string{"hostapd.phy0-ap0"}, MaxStations: 1, MaxWANRate: 100, Interval: time.Millisecond,
+		})
+		if err != nil {
+			t.Fatalf("RebootWhenIdle failed: %v", err)
+		}
+
+		if call := mock.GetLastCall(); call.Service != "system" || call.Method != "reboot" {
+			t.Errorf("expected a reboot, got %+v", call)
+		}
+	})
+
+	t.Run("Deadline", func(t *testing.T) {
+		mock := newIdleMock(3)
+		start := time.Now()
+
+		err := system.New(mock).RebootWhenIdle(ctx, system.IdlePolicy{
+			APs: []string{"hostapd.phy0-ap0"}, Interval: 5 * time.Millisecond, Deadline: start.Add(30 * time.Millisecond),
+		})
+		if err != nil {
+			t.Fatalf("RebootWhenIdle failed: %v", err)
+		}
+
+		if call := mock.GetLastCall(); call.Method != "reboot" || time.Since(start) < 30*time.Millisecond {
+			t.Errorf("expected a reboot at the deadline, got %+v", call)
+		}
+	})
+
+	t.Run("SamplingFails", func(t *testing.T) {
+		mock := newIdleMock(0)
+		mock.AddResponse("network.device", "status", errdefs.ErrPermissionDenied)
+		start := time.Now()
+
+		err := system.New(mock).RebootWhenIdle(ctx, system.IdlePolicy{
+			APs: []string{"hostapd.phy0-ap0"}, Interval: 5 * time.Millisecond, Deadline: start.Add(30 * time.Millisecond),
+		})
+		if err != nil {
+			t.Fatalf("RebootWhenIdle failed: %v", err)
+		}
+
+		if call := mock.GetLastCall(); call.Method != "reboot" || time.Since(start) < 30*time.Millisecond {
+			t.Errorf("expected a reboot at the deadline, got %+v", call)
+		}
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		mock := newIdleMock(3)
+
+		ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+
+		err := system.New(mock).RebootWhenIdle(ctx, system.IdlePolicy{
+			APs: []string{"hostapd.phy0-ap0"}, Interval: 5 * time.Millisecond,
+		})
+		if err == nil {
+			t.Fatal("expected RebootWhenIdle to fail when the context ends")
+		}
+
+		if call := mock.GetLastCall(); call.Method == "reboot" {
+			t.Error("expected no reboot")
+		}
+	})
+}
+
+func testSystemLEDs(t *testing.T, ctx context.Context) {
+	t.Helper()
+
+	mock := testutil.NewMockTransport()
+	mock.AddResponse("uci", "get", map[string]any{
+		"values": map[string]any{
+			"led_wan": map[string]any{
+				".type": "led", ".name": "led_wan", ".index": 1,
+				"name": "WAN", "sysfs": "green:wan", "trigger": "netdev", "dev": "wan", "mode": "link tx rx",
+			},
+			"cfg02": map[string]any{
+				".type": "button", ".name": "cfg02", ".index": 2,
+				"button": "reset", "action": "released", "handler": "firstboot -y && reboot", "min": "5", "max": "30",
+			},
+			"system": map[string]any{".type": "system", ".name": "system", ".index": 0},
+		},
+	})
+	mock.AddResponse("uci", "add", map[string]any{"section": "cfg03"})
+	mock.AddResponse("uci", "commit", map[string]any{})
+
+	mgr := system.New(mock)
+
+	leds, err := mgr.LEDs(ctx)
+	if err != nil || len(leds) != 1 || leds[0].Trigger != system.LEDTriggerNetdev || len(leds[0].Mode) != 3 {
+		t.Fatalf("LEDs: %+v, %v", leds, err)
+	}
+
+	buttons, err := mgr.Buttons(ctx)
+	if err != nil || len(buttons) != 1 || buttons[0].Min != 5 || buttons[0].Max != 30 {
+		t.Fatalf("Buttons: %+v, %v", buttons, err)
+	}
+
+	err = mgr.SaveLED(ctx, system.LEDConfig{Sysfs: "blue:status", Trigger: system.LEDTriggerHeartbeat})
+	if err != nil {
+		t.Fatalf("SaveLED failed: %v", err)
+	}
+
+	for _, led := range []system.LEDConfig{
+		{Sysfs: "green:wan", Trigger: system.LEDTriggerNetdev},
+		{Sysfs: "green:wan", Trigger: system.LEDTriggerNetdev, Dev: "wan", Mode: []string{"blink"}},
+		{Sysfs: "green:wan", Trigger: system.LEDTriggerTimer, DelayOn: 500},
+	} {
+		err = mgr.SaveLED(ctx, led)
+		if !errdefs.IsInvalidParameter(err) {
+			t.Errorf("expected %+v to be rejected, got %v", led, err)
+		}
+	}
+
+	err = mgr.SaveButton(ctx, system.ButtonConfig{Button: "wps", Action: "held", Handler: "wps-toggle"})
+	if !errdefs.IsInvalidParameter(err) {
+		t.Errorf("expected an invalid action error, got %v", err)
+	}
+}
+
+func testSystemIdentify(t *testing.T, ctx context.Context) {
+	t.Helper()
+
+	mock := testutil.NewMockTransport()
+	mock.AddResponseForArgs("file", "read", map[string]any{"path": "/sys/class/leds/green:status/trigger"},
+		map[string]any{"data": "none timer [heartbeat] netdev\n"})
+	mock.AddResponseForArgs("file", "read", map[string]any{"path": "/sys/class/leds/green:status/brightness"},
+		map[string]any{"data": "1\n"})
+	mock.AddResponse("file", "write", map[string]any{})
+
+	mgr := system.New(mock)
+
+	err := mgr.Identify(ctx, "green:status", time.Millisecond)
+	if err != nil {
+		t.Fatalf("Identify failed: %v", err)
+	}
+
+	var writes []string
+
+	for _, call := range mock.Calls {
+		if call.Method == "write" {
+			params, _ := call.Data.(map[string]any)
+			writes = append(writes, fmt.Sprintf("%s=%s", params["path"], params["data"]))
+		}
+	}
+
+	want := []string{
+		"/sys/class/leds/green:status/trigger=timer",
+		"/sys/class/leds/green:status/delay_on=100",
+		"/sys/class/leds/green:status/delay_off=100",
+		"/sys/class/leds/green:status/trigger=heartbeat",
+	}
+	if !slices.Equal(writes, want) {
+		t.Errorf("unexpected writes: %v", writes)
+	}
+
+	err = mgr.Identify(ctx, "../../kernel", time.Millisecond)
+	if !errdefs.IsInvalidParameter(err) {
+		t.Errorf("expected an invalid LED name error, got %v", err)
+	}
+}
+
+func testSystemSensors(t *testing.T, ctx context.Context) {
+	t.Helper()
+
+	mock := testutil.NewMockTransport()
+	list := func(dir string, names ...string) {
+		entries := make([]map[string]any, len(names))
+		for i, name := range names {
+			entries[i] = map[string]any{"name": name, "type": "directory"}
+		}
+
+		mock.AddResponseForArgs("file", "list", map[string]any{"path": dir}, map[string]any{"entries": entries})
+	}
+	read := func(path, data string) {
+		mock.AddResponseForArgs("file", "read", map[string]any{"path": path}, map[string]any{"data": data + "\n"})
+	}
+
+	list("/sys/class/thermal/", "cooling_device0", "thermal_zone1", "thermal_zone0")
+	read("/sys/class/thermal/thermal_zone0/temp", "52300")
+	read("/sys/class/thermal/thermal_zone0/type", "cpu-thermal")
+	list("/sys/class/hwmon/", "hwmon0")
+	list("/sys/class/hwmon/hwmon0/", "name", "temp1_input", "temp1_label", "in0_input", "fan1_input", "fan2_input")
+	read("/sys/class/hwmon/hwmon0/name", "nct6775")
+	read("/sys/class/hwmon/hwmon0/temp1_input", "41000")
+	read("/sys/class/hwmon/hwmon0/temp1_label", "SYSTIN")
+	read("/sys/class/hwmon/hwmon0/in0_input", "1104")
+	read("/sys/class/hwmon/hwmon0/fan1_input", "1250")
+
+	mgr := system.New(mock)
+	mgr.SetSensorPaths([]system.SensorPath{
+		{Name: "CPU", Path: "/sys/class/thermal/thermal_zone0/temp", Kind: system.SensorTemperature, Divisor: 1000},
+	})
+
+	sensors, err := mgr.Sensors(ctx)
+	if err != nil {
+		t.Fatalf("Sensors failed: %v", err)
+	}
+
+	got := make([]string, len(sensors))
+	for i, sensor := range sensors {
+		got[i] = fmt.Sprintf("%s %s %g", sensor.Name, sensor.Kind, sensor.Value)
+	}
+
+	// thermal_zone1 and fan2 cannot be read and the board entry replaces thermal_zone0.
+	want := []string{
+		"CPU temperature 52.3",
+		"nct6775 SYSTIN temperature 41",
+		"nct6775 in0 voltage 1.104",
+		"nct6775 fan1 fan 1250",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("unexpected sensors: %q", got)
+	}
+}
+
+func testSystemCPUStats(t *testing.T, ctx context.Context) {
+	t.Helper()
+
+	mock := testutil.NewMockTransport()
+	// /proc/stat reports size 0 and is copied to a temporary file before it is read.
+	stat := func(user, idle int) {
+		data := fmt.Sprintf("cpu  %d 0 100 %d 0 0 0 0 0 0\n"+
+			"cpu0 %d 0 50 %d 0 0 0 0 0 0\ncpu1 %d 0 50 %d 0 0 0 0 0 0\n"+
+			"intr 1234 0 0\nctxt 987654\nbtime 1760000000\nprocesses 4321\n"+
+			"procs_running 2\nprocs_blocked 0\n", user, idle, user/2, idle/2, user/2, idle/2)
+		mock.AddResponse("file", "stat", map[string]any{"type": "file", "size": len(data)})
+		mock.AddResponse("file", "read", map[string]any{"data": base64.StdEncoding.EncodeToString([]byte(data))})
+	}
+	mock.AddResponseForArgs("file", "stat", map[string]any{"path": "/proc/stat"},
+		map[string]any{"type": "file", "size": 0})
+	mock.AddResponse("file", "exec", map[string]any{"code": 0})
+	mock.AddResponse("file", "remove", map[string]any{})
+	stat(400, 1500)
+	mock.AddResponseForArgs("file", "read", map[string]any{"path": "/proc/loadavg"},
+		map[string]any{"data": "0.52 0.34 0.20 2/87 4321\n"})
+
+	mgr := system.New(mock)
+
+	prev, err := mgr.CPUStats(ctx)
+	if err != nil {
+		t.Fatalf("CPUStats failed: %v", err)
+	}
+
+	if len(prev.CPUs) != 2 || prev.ContextSwitches != 987654 || prev.Forks != 4321 || prev.Running != 2 ||
+		prev.BootTime != 1760000000 || prev.Load.Load1 != 0.52 || prev.Load.Tasks != 87 {
+		t.Errorf("unexpected CPU stats: %+v", prev)
+	}
+
+	stat(700, 1700)
+
+	cur, err := mgr.CPUStats(ctx)
+	if err != nil {
+		t.Fatalf("CPUStats failed: %v", err)
+	}
+
+	// 300 of 500 ticks were spent busy.
+	usage := cur.Usage(prev)
+	if usage.Total != 60 || len(usage.CPUs) != 2 || usage.CPUs[0] != 60 {
+		t.Errorf("unexpected CPU usage: %+v", usage)
+	}
+
+	_, err = system.ParseProcStat("intr 1234\n")
+	if !errdefs.IsInvalidResponse(err) {
+		t.Errorf("expected an invalid response error, got %v", err)
+	}
+}
+
+func testSystemProcesses(t *testing.T, ctx context.Context) {
+	t.Helper()
+
+	mock := testutil.NewMockTransport()
+	mock.AddResponseForArgs("file", "list", map[string]any{"path": "/proc/"}, map[string]any{"entries": []map[string]any{
+		{"name": "self", "type": "symlink"}, {"name": "1", "type": "directory"},
+		{"name": "812", "type": "directory"}, {"name": "90", "type": "directory"},
+		{"name": "meminfo", "type": "file"},
+	}})
+	mock.AddResponseForArgs("file", "read", map[string]any{"path": "/proc/1/stat"}, map[string]any{
+		"data": "1 (procd) S 0 1 1 0 -1 4194560 1234 5678 0 0 120 80 0 0 20 0 1 0 18 1769472 256 " +
+			"18446744073709551615 1 1 0 0 0 0 0 4096 0 0 0 0 17 0 0 0 0 0 0\n",
+	})
+	mock.AddResponseForArgs("file", "read", map[string]any{"path": "/proc/812/stat"}, map[string]any{
+		"data": "812 (my (odd) proc) R 1 812 812 0 -1 4194560 10 0 0 0 300 100 0 0 20 0 3 0 2100 4718592 512 " +
+			"18446744073709551615 1 1 0 0 0 0 0 0 0 0 0 0 17 1 0 0 0 0 0\n",
+	})
+
+	procs, err := system.New(mock).Processes(ctx)
+	if err != nil {
+		t.Fatalf("Processes failed: %v", err)
+	}
+
+	// Process 90 exited before its stat was read.
+	if len(procs) != 2 || procs[0].Name != "procd" || procs[0].RSS != 256*4096 || procs[0].VSZ != 1769472 {
+		t.Fatalf("unexpected processes: %+v", procs)
+	}
+
+	if p := procs[1]; p.Name != "my (odd) proc" || p.State != "R" || p.PPID != 1 || p.Threads != 3 ||
+		p.UTime != 300 || p.STime != 100 {
+		t.Errorf("unexpected process: %+v", p)
+	}
+
+	prevCPU := &system.CPUStats{Total: system.CPUTimes{Idle: 1000}}
+	cpu := &system.CPUStats{Total: system.CPUTimes{User: 100, Idle: 1900}}
+	prev := []system.Process{{PID: 812, UTime: 200, STime: 100}}
+
+	usage := system.ProcessCPU(prev, procs, prevCPU, cpu)
+	if usage[812] != 10 || usage[1] != 20 {
+		t.Errorf("unexpected process CPU usage: %v", usage)
+	}
+}
+
+func testSystemStorage(t *testing.T, ctx context.Context) {
+	t.Helper()
+
+	mock := testutil.NewMockTransport()
+	// /proc/mounts reports size 0 and is copied to a temporary file before it is read.
+	table := "/dev/root /rom squashfs ro,relatime 0 0\n" +
+		"proc /proc proc rw,nosuid,nodev,noexec,noatime 0 0\n" +
+		"/dev/ubi0_1 /overlay ubifs rw,noatime 0 0\n" +
+		"overlayfs:/overlay / overlay rw,noatime,lowerdir=/,upperdir=/overlay/upper 0 0\n" +
+		"/dev/sda1 /mnt/my\\040disk ext4 rw,relatime 0 0\n"
+	mock.AddResponseForArgs("file", "stat", map[string]any{"path": "/proc/mounts"},
+		map[string]any{"type": "file", "size": 0})
+	mock.AddResponse("file", "stat", map[string]any{"type": "file", "size": len(table)})
+	mock.AddResponse("file", "read", map[string]any{"data": base64.StdEncoding.EncodeToString([]byte(table))})
+	mock.AddResponse("file", "remove", map[string]any{})
+	mock.AddResponse("file", "exec", map[string]any{"code": 0, "stdout": "" +
+		"Filesystem           1024-blocks    Used Available Capacity Mounted on\n" +
+		"/dev/root                  4352      4352         0 100% /rom\n" +
+		"/dev/ubi0_1               56988      1024     53032   2% /overlay\n" +
+		"overlayfs:/overlay        56988      1024     53032   2% /\n" +
+		"/dev/sda1              15000000   3000000  11200000  21% /mnt/my disk\n",
+	})
+
+	mgr := system.New(mock)
+
+	mounts, err := mgr.Mounts(ctx)
+	if err != nil {
+		t.Fatalf("Mounts failed: %v", err)
+	}
+
+	if len(mounts) != 5 || !mounts[0].ReadOnly || mounts[1].Size != 0 || mounts[3].Size != 56988*1024 {
+		t.Fatalf("unexpected mounts: %+v", mounts)
+	}
+
+	if usb := mounts[4]; usb.MountPoint != "/mnt/my disk" || usb.FSType != "ext4" || usb.UsedPercent() != 20 {
+		t.Errorf("unexpected USB mount: %+v", usb)
+	}
+
+	mock.AddResponse("block", "info", &errdefs.CapabilityError{Object: "block"})
+	mock.AddResponse("file", "exec", map[string]any{"code": 0, "stdout": "" +
+		"/dev/ubiblock0_0: UUID=\"1c8a6e2e\" VERSION=\"4.0\" MOUNT=\"/rom\" TYPE=\"squashfs\"\n" +
+		"/dev/sda1: UUID=\"8d2b-41f0\" LABEL=\"USB DISK\" VERSION=\"1.0\" TYPE=\"ext4\"\n",
+	})
+
+	devices, err := mgr.BlockDevices(ctx)
+	if err != nil {
+		t.Fatalf("BlockDevices failed: %v", err)
+	}
+
+	if len(devices) != 2 || devices[1].Device != "/dev/sda1" || devices[1].Label != "USB DISK" ||
+		devices[1].Mount != "" || devices[0].Type != "squashfs" {
+		t.Errorf("unexpected block devices from block info: %+v", devices)
+	}
+
+	mock.AddResponse("block", "info", map[string]any{"devices": []map[string]any{
+		{"device": "/dev/sda1", "uuid": "8d2b-41f0", "type": "ext4", "mount": "/mnt/sda1"},
+	}})
+
+	devices, err = mgr.BlockDevices(ctx)
+	if err != nil || len(devices) != 1 || devices[0].Mount != "/mnt/sda1" {
+		t.Errorf("unexpected block devices from ubus: %+v, %v", devices, err)
+	}
+}
+
+func testSystemKernelModules(t *testing.T, ctx context.Context) {
+	t.Helper()
+
+	mock := testutil.NewMockTransport()
+	// /proc/modules reports size 0 and usually spans more than one page, so it is copied to a
+	// temporary file before it is read.
+	table := "mt7915e 233472 0 - Live 0x0000000000000000\n" +
+		"mt76_connac_lib 81920 1 mt7915e, Live 0x0000000000000000\n" +
+		strings.Repeat("nf_filler 16384 0 - Live 0x0000000000000000\n", 120) +
+		"usb_storage 61440 0 - Live 0x0000000000000000\n"
+	mock.AddResponseForArgs("file", "stat", map[string]any{"path": "/proc/modules"},
+		map[string]any{"type": "file", "size": 0})
+	mock.AddResponse("file", "stat", map[string]any{"type": "file", "size": len(table)})
+	mock.AddResponse("file", "read", map[string]any{"data": base64.StdEncoding.EncodeToString([]byte(table))})
+	mock.AddResponse("file", "remove", map[string]any{})
+	mock.AddResponse("file", "exec", map[string]any{"code": 0})
+
+	mgr := system.New(mock)
+
+	modules, err := mgr.Modules(ctx)
+	if err != nil {
+		t.Fatalf("Modules failed: %v", err)
+	}
+
+	if len(modules) != 123 || modules[1].RefCount != 1 || !slices.Equal(modules[1].UsedBy, []string{"mt7915e"}) ||
+		modules[0].Size != 233472 || modules[0].State != "Live" {
+		t.Errorf("unexpected modules: %+v", modules)
+	}
+
+	err = mgr.UnloadModule(ctx, "usb-storage")
+	if err != nil {
+		t.Fatalf("UnloadModule failed: %v", err)
+	}
+
+	params, ok := mock.GetLastCall().Data.(map[string]any)["params"].([]string)
+	if !ok || !slices.Equal(params, []string{"usb_storage"}) {
+		t.Errorf("unexpected rmmod call: %+v", mock.GetLastCall())
+	}
+
+	err = mgr.UnloadModule(ctx, "mt76_connac_lib")
+	if !errdefs.IsInvalidParameter(err) {
+		t.Errorf("expected a module in use to be kept, got %v", err)
+	}
+
+	err = mgr.UnloadModule(ctx, "ath9k")
+	if !errdefs.IsNotFound(err) {
+		t.Errorf("expected a module that is not loaded to fail, got %v", err)
+	}
+
+	err = mgr.LoadModule(ctx, "mt7915e", "wed_enable=Y")
+	if err != nil {
+		t.Fatalf("LoadModule failed: %v", err)
+	}
+
+	for _, call := range [][]string{{"-r", "mt7915e"}, {"mt7915e", "wed_enable=Y; reboot"}} {
+		err = mgr.LoadModule(ctx, call[0], call[1:]...)
+		if !errdefs.IsInvalidParameter(err) {
+			t.Errorf("expected %q to be rejected, got %v", call, err)
+		}
+	}
+}
+
+func testSystemSysctl(t *testing.T, ctx context.Context) {
+	t.Helper()
+
+	mock := testutil.NewMockTransport()
+	mock.AddResponseForArgs("file", "read", map[string]any{"path": "/proc/sys/net/ipv4/ip_forward"},
+		map[string]any{"data": "1\n"})
+	mock.AddResponseForArgs("file", "read", map[string]any{"path": "/etc/sysctl.d/90-tuning.conf"},
+		map[string]any{"data": "# tuning\nnet.core.rmem_max = 4194304\nnet.ipv4.tcp_ecn=1\n"})
+	mock.AddResponse("file", "write", map[string]any{})
+	mock.AddResponse("file", "exec", map[string]any{"code": 0})
+
+	mgr := system.New(mock)
+
+	value, err := mgr.Sysctl(ctx, "net.ipv4.ip_forward")
+	if err != nil || value != "1" {
+		t.Fatalf("unexpected sysctl: %q, %v", value, err)
+	}
+
+	err = mgr.SetSysctl(ctx, "net/ipv4/conf/eth0.1/rp_filter", "0")
+	if err != nil {
+		t.Fatalf("SetSysctl failed: %v", err)
+	}
+
+	if path := mock.GetLastCall().Data.(map[string]any)["path"]; path != "/proc/sys/net/ipv4/conf/eth0.1/rp_filter" {
+		t.Errorf("unexpected sysctl path: %v", path)
+	}
+
+	for _, key := range []string{"net/../../etc/passwd", "ip_forward", "net.ipv4.ip_forward\n"} {
+		err = mgr.SetSysctl(ctx, key, "1")
+		if !errdefs.IsInvalidParameter(err) {
+			t.Errorf("expected key %q to be rejected, got %v", key, err)
+		}
+	}
+
+	err = mgr.PersistSysctl(ctx, "90-tuning", map[string]string{"net.ipv4.tcp_ecn": "", "vm.swappiness": "10"})
+	if err != nil {
+		t.Fatalf("PersistSysctl failed: %v", err)
+	}
+
+	var written string
+
+	for _, call := range mock.Calls {
+		if call.Method == "write" {
+			written, _ = call.Data.(map[string]any)["data"].(string)
+		}
+	}
+
+	if written != "# tuning\nnet.core.rmem_max = 4194304\nvm.swappiness=10\n" {
+		t.Errorf("unexpected sysctl.d file: %q", written)
+	}
+}
@@ -0,0 +1,286 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package system
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+const (
+	procDir = "/proc/"
+
+	// pageSize is the memory page size of the targets OpenWrt supports, used for the RSS.
+	pageSize = 4096
+	percent  = 100
+
+	// Fields of /proc/<pid>/stat after the command name, counted from the state as 0.
+	procState    = 0
+	procPPID     = 1
+	procUTime    = 11
+	procSTime    = 12
+	procThreads  = 17
+	procVSize    = 20
+	procRSS      = 21
+	procMinStats = 22
+
+	// cpuCounters is the number of counters of a cpu line CPUTimes holds.
+	cpuCounters = 8
+	// loadavgFields is the number of fields of /proc/loadavg ParseLoadAvg reads.
+	loadavgFields = 4
+)
+
+// CPUStats reads the CPU time counters of /proc/stat and the load average of /proc/loadavg.
+// Utilization needs two readings, see CPUStats.Usage. /proc/stat outgrows a single file.read
+// on machines with many CPUs or interrupts, so it is copied in full, which needs exec rights
+// for dd.
+func (m *Manager) CPUStats(ctx context.Context) (*CPUStats, error) {
+	var stat strings.Builder
+
+	_, err := m.file.Download(ctx, procDir+"stat", &stat, 0)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read /proc/stat")
+	}
+
+	stats, err := ParseProcStat(stat.String())
+	if err != nil {
+		return nil, err
+	}
+
+	loadavg, err := m.file.Read(ctx, procDir+"loadavg", false)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read /proc/loadavg")
+	}
+
+	stats.Load, err = ParseLoadAvg(loadavg.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// Processes reads /proc/<pid>/stat of every process, sorted by PID. It reads one file per
+// process, so it costs as many calls as processes run; processes that exit meanwhile are left
+// out. CPU usage needs two readings, see ProcessCPU.
+func (m *Manager) Processes(ctx context.Context) ([]Process, error) {
+	list, err := m.file.List(ctx, procDir)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to list /proc")
+	}
+
+	procs := []Process{}
+
+	for _, entry := range list.Entries {
+		if strings.Trim(entry.Name, "0123456789") != "" {
+			continue
+		}
+
+		stat, err := m.file.Read(ctx, procDir+entry.Name+"/stat", false)
+		if errdefs.IsNotFound(err) {
+			continue
+		}
+
+		if err != nil {
+			return nil, errdefs.Wrapf(err, "failed to read the stat of process %s", entry.Name)
+		}
+
+		proc, err := ParseProcessStat(stat.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		procs = append(procs, proc)
+	}
+
+	slices.SortFunc(procs, func(a, b Process) int { return a.PID - b.PID })
+
+	return procs, nil
+}
+
+// Usage returns the CPU utilization since prev, an earlier reading.
+func (s *CPUStats) Usage(prev *CPUStats) CPUUsage {
+	usage := CPUUsage{Total: s.Total.Usage(prev.Total), CPUs: make([]float64, len(s.CPUs))}
+
+	for i, cpu := range s.CPUs {
+		if i < len(prev.CPUs) {
+			usage.CPUs[i] = cpu.Usage(prev.CPUs[i])
+		}
+	}
+
+	return usage
+}
+
+// Usage returns the busy share of the time elapsed since prev in percent.
+func (t CPUTimes) Usage(prev CPUTimes) float64 {
+	total := t.Sum() - prev.Sum()
+	if total == 0 || t.Sum() < prev.Sum() {
+		return 0
+	}
+
+	idle := t.Idle + t.IOWait - prev.Idle - prev.IOWait
+
+	return float64(total-idle) / float64(total) * percent
+}
+
+// Sum returns the total time.
+func (t CPUTimes) Sum() uint64 {
+	// Guest time is already part of the user time.
+	return t.User + t.Nice + t.System + t.Idle + t.IOWait + t.IRQ + t.SoftIRQ + t.Steal
+}
+
+// ProcessCPU returns the CPU usage of the processes of cur since prev in percent of the total
+// capacity of all CPUs, keyed by PID; cpu and prevCPU are the CPUStats read with them.
+// Processes that were not running at prev are measured from their start.
+func ProcessCPU(prev, cur []Process, prevCPU, cpu *CPUStats) map[int]float64 {
+	elapsed := cpu.Total.Sum() - prevCPU.Total.Sum()
+	usage := make(map[int]float64, len(cur))
+
+	before := make(map[int]uint64, len(prev))
+	for _, p := range prev {
+		before[p.PID] = p.CPUTime()
+	}
+
+	for _, p := range cur {
+		if elapsed == 0 || cpu.Total.Sum() < prevCPU.Total.Sum() {
+			usage[p.PID] = 0
+
+			continue
+		}
+
+		used := p.CPUTime()
+		if start, ok := before[p.PID]; ok && start <= used {
+			used -= start
+		}
+
+		usage[p.PID] = min(float64(used)/float64(elapsed)*percent, percent)
+	}
+
+	return usage
+}
+
+// CPUTime returns the user and system time of the process in clock ticks.
+func (p *Process) CPUTime() uint64 {
+	return p.UTime + p.STime
+}
+
+// ParseProcStat parses the cpu lines and counters of /proc/stat.
+func ParseProcStat(data string) (*CPUStats, error) {
+	stats := &CPUStats{}
+
+	for line := range strings.Lines(data) {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		switch {
+		case fields[0] == "cpu":
+			stats.Total = parseCPUTimes(fields[1:])
+		case strings.HasPrefix(fields[0], "cpu"):
+			stats.CPUs = append(stats.CPUs, parseCPUTimes(fields[1:]))
+		default:
+			stats.set(fields[0], fields[1])
+		}
+	}
+
+	if stats.Total.Sum() == 0 {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidResponse, "no cpu line in /proc/stat")
+	}
+
+	return stats, nil
+}
+
+// set assigns a counter line of /proc/stat.
+func (s *CPUStats) set(name, value string) {
+	number, _ := strconv.ParseUint(value, 10, 64)
+
+	switch name {
+	case "ctxt":
+		s.ContextSwitches = number
+	case "processes":
+		s.Forks = number
+	case "procs_running":
+		s.Running = int(number)
+	case "procs_blocked":
+		s.Blocked = int(number)
+	case "btime":
+		s.BootTime = int64(number)
+	}
+}
+
+// parseCPUTimes parses the counters of a cpu line; older kernels report fewer of them.
+func parseCPUTimes(fields []string) CPUTimes {
+	values := make([]uint64, len(fields))
+	for i, field := range fields {
+		values[i], _ = strconv.ParseUint(field, 10, 64)
+	}
+
+	values = append(values, make([]uint64, max(0, cpuCounters-len(values)))...)
+
+	return CPUTimes{
+		User: values[0], Nice: values[1], System: values[2], Idle: values[3],
+		IOWait: values[4], IRQ: values[5], SoftIRQ: values[6], Steal: values[7],
+	}
+}
+
+// ParseLoadAvg parses /proc/loadavg.
+func ParseLoadAvg(data string) (LoadAverage, error) {
+	fields := strings.Fields(data)
+	if len(fields) < loadavgFields {
+		return LoadAverage{}, errdefs.Wrapf(errdefs.ErrInvalidResponse, "invalid /proc/loadavg %q", data)
+	}
+
+	var load LoadAverage
+
+	load.Load1, _ = strconv.ParseFloat(fields[0], 64)
+	load.Load5, _ = strconv.ParseFloat(fields[1], 64)
+	load.Load15, _ = strconv.ParseFloat(fields[2], 64)
+
+	running, total, _ := strings.Cut(fields[3], "/")
+	load.RunningTasks, _ = strconv.Atoi(running)
+	load.Tasks, _ = strconv.Atoi(total)
+
+	return load, nil
+}
+
+// ParseProcessStat parses /proc/<pid>/stat. The command name is enclosed in parentheses and
+// may contain spaces and parentheses itself.
+func ParseProcessStat(data string) (Process, error) {
+	open := strings.IndexByte(data, '(')
+	closing := strings.LastIndexByte(data, ')')
+
+	if open < 0 || closing < open {
+		return Process{}, errdefs.Wrapf(errdefs.ErrInvalidResponse, "invalid process stat %q", data)
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(data[:open]))
+	fields := strings.Fields(data[closing+1:])
+
+	if err != nil || len(fields) < procMinStats {
+		return Process{}, errdefs.Wrapf(errdefs.ErrInvalidResponse, "invalid process stat %q", data)
+	}
+
+	number := func(i int) uint64 {
+		value, _ := strconv.ParseUint(fields[i], 10, 64)
+
+		return value
+	}
+
+	return Process{
+		PID:     pid,
+		PPID:    int(number(procPPID)),
+		Name:    data[open+1 : closing],
+		State:   fields[procState],
+		Threads: int(number(procThreads)),
+		VSZ:     number(procVSize),
+		RSS:     number(procRSS) * pageSize,
+		UTime:   number(procUTime),
+		STime:   number(procSTime),
+	}, nil
+}
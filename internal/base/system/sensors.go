@@ -0,0 +1,228 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package system
+
+import (
+	"context"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+const (
+	thermalDir = "/sys/class/thermal/"
+	hwmonDir   = "/sys/class/hwmon/"
+
+	// milli converts the millidegrees and millivolts of sysfs.
+	milli = 1000
+)
+
+// hwmonInput matches the hwmon attributes Sensors reads, e.g. "temp1_input".
+var hwmonInput = regexp.MustCompile(`^(temp|in|fan)(\d+)_input$`)
+
+// hwmonKinds maps the hwmon attribute prefixes to kinds and divisors.
+var hwmonKinds = map[string]struct {
+	kind    SensorKind
+	divisor float64
+}{
+	"temp": {SensorTemperature, milli},
+	"in":   {SensorVoltage, milli},
+	"fan":  {SensorFan, 1},
+}
+
+// SetSensorPaths installs the sensors of the board. Sensors reads them in addition to the
+// discovered ones and names a discovered sensor on the same path after its board entry.
+func (m *Manager) SetSensorPaths(paths []SensorPath) {
+	m.sensorPaths = slices.Clone(paths)
+}
+
+// Sensors reads the board sensors set with SetSensorPaths, the thermal zones and the
+// temperature, voltage and fan inputs of the hwmon chips through the file object, which needs
+// read access to /sys/class/thermal and /sys/class/hwmon. Sensors that cannot be read, such as
+// those of a powered-down radio, are left out.
+func (m *Manager) Sensors(ctx context.Context) ([]Sensor, error) {
+	sensors := []Sensor{}
+	mapped := make(map[string]bool, len(m.sensorPaths))
+
+	for _, p := range m.sensorPaths {
+		value, err := m.readSensor(ctx, p.Path)
+		if err != nil {
+			continue
+		}
+
+		divisor := p.Divisor
+		if divisor == 0 {
+			divisor = 1
+		}
+
+		mapped[p.Path] = true
+		sensors = append(sensors, Sensor{Name: p.Name, Kind: p.Kind, Path: p.Path, Value: value / divisor})
+	}
+
+	thermal, err := m.thermalZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hwmon, err := m.hwmonSensors(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sensor := range append(thermal, hwmon...) {
+		if !mapped[sensor.Path] {
+			sensors = append(sensors, sensor)
+		}
+	}
+
+	return sensors, nil
+}
+
+// thermalZones reads the temperature of each thermal zone.
+func (m *Manager) thermalZones(ctx context.Context) ([]Sensor, error) {
+	zones, err := m.sysfsEntries(ctx, thermalDir, "thermal_zone")
+	if err != nil {
+		return nil, err
+	}
+
+	sensors := []Sensor{}
+
+	for _, zone := range zones {
+		dir := thermalDir + zone + "/"
+
+		value, err := m.readSensor(ctx, dir+"temp")
+		if err != nil {
+			continue
+		}
+
+		name := m.readAttribute(ctx, dir+"type")
+		if name == "" {
+			name = zone
+		}
+
+		sensors = append(sensors, Sensor{
+			Name: name, Source: zone, Kind: SensorTemperature, Path: dir + "temp", Value: value / milli,
+		})
+	}
+
+	return sensors, nil
+}
+
+// hwmonSensors reads the inputs of each hwmon chip.
+func (m *Manager) hwmonSensors(ctx context.Context) ([]Sensor, error) {
+	chips, err := m.sysfsEntries(ctx, hwmonDir, "hwmon")
+	if err != nil {
+		return nil, err
+	}
+
+	sensors := []Sensor{}
+
+	for _, chip := range chips {
+		dir := hwmonDir + chip + "/"
+
+		list, err := m.file.List(ctx, dir)
+		if err != nil {
+			continue
+		}
+
+		chipName := m.readAttribute(ctx, dir+"name")
+		if chipName == "" {
+			chipName = chip
+		}
+
+		for _, entry := range list.Entries {
+			sensor, ok := m.hwmonInput(ctx, dir, entry.Name)
+			if ok {
+				sensor.Name = chipName + " " + sensor.Name
+				sensor.Source = chip
+				sensors = append(sensors, sensor)
+			}
+		}
+	}
+
+	return sensors, nil
+}
+
+// hwmonInput reads an input attribute of the hwmon chip in dir.
+func (m *Manager) hwmonInput(ctx context.Context, dir, attribute string) (Sensor, bool) {
+	match := hwmonInput.FindStringSubmatch(attribute)
+	if match == nil {
+		return Sensor{}, false
+	}
+
+	value, err := m.readSensor(ctx, dir+attribute)
+	if err != nil {
+		return Sensor{}, false
+	}
+
+	prefix := match[1] + match[2]
+
+	label := m.readAttribute(ctx, dir+prefix+"_label")
+	if label == "" {
+		label = prefix
+	}
+
+	kind := hwmonKinds[match[1]]
+
+	return Sensor{Name: label, Kind: kind.kind, Path: dir + attribute, Value: value / kind.divisor}, true
+}
+
+// sysfsEntries lists the entries of dir starting with prefix in natural order. A missing
+// directory has no entries.
+func (m *Manager) sysfsEntries(ctx context.Context, dir, prefix string) ([]string, error) {
+	list, err := m.file.List(ctx, dir)
+	if errdefs.IsNotFound(err) {
+		return []string{}, nil
+	}
+
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to list %s", dir)
+	}
+
+	var names []string
+
+	for _, entry := range list.Entries {
+		if strings.HasPrefix(entry.Name, prefix) {
+			names = append(names, entry.Name)
+		}
+	}
+
+	slices.SortFunc(names, func(a, b string) int {
+		ia, _ := strconv.Atoi(strings.TrimPrefix(a, prefix))
+		ib, _ := strconv.Atoi(strings.TrimPrefix(b, prefix))
+
+		return ia - ib
+	})
+
+	return names, nil
+}
+
+// readSensor reads the numeric value of a sysfs attribute.
+func (m *Manager) readSensor(ctx context.Context, file string) (float64, error) {
+	res, err := m.file.Read(ctx, file, false)
+	if err != nil {
+		return 0, err
+	}
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(res.Data), 64)
+	if err != nil {
+		return 0, errdefs.Wrapf(errdefs.ErrInvalidResponse, "%s is not a number: %q", path.Base(file), res.Data)
+	}
+
+	return value, nil
+}
+
+// readAttribute reads a text attribute, returning "" when it cannot be read.
+func (m *Manager) readAttribute(ctx context.Context, file string) string {
+	res, err := m.file.Read(ctx, file, false)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(res.Data)
+}
@@ -0,0 +1,236 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package system
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+const (
+	mountsFile  = "/proc/mounts"
+	dfBinary    = "/bin/df"
+	blockBinary = "/sbin/block"
+
+	// kibibyte is the block size df reports in with -k.
+	kibibyte = 1024
+
+	// mountFields and dfFields are the minimum fields of a /proc/mounts and a df -P line.
+	mountFields = 4
+	dfFields    = 6
+	// octalEscape is the length of an escape such as "\040".
+	octalEscape = 4
+)
+
+// blockAttribute matches the KEY="value" attributes of a block info line.
+var blockAttribute = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// Mounts lists the mounted filesystems of /proc/mounts in mount order, with the usage "df -Pk"
+// reports for them. df runs through rpcd's file.exec, which needs exec permission for it, and
+// so does the dd that copies /proc/mounts, which outgrows a single file.read.
+// Pseudo filesystems such as proc and filesystems hidden by a later mount on the same mount
+// point report no usage.
+func (m *Manager) Mounts(ctx context.Context) ([]Mount, error) {
+	var table strings.Builder
+
+	_, err := m.file.Download(ctx, mountsFile, &table, 0)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read %s", mountsFile)
+	}
+
+	mounts := ParseMounts(table.String())
+
+	out, err := m.run(ctx, dfBinary, "-Pk")
+	if err != nil {
+		return nil, err
+	}
+
+	usage := ParseDF(out)
+
+	// The last mount on a mount point hides the earlier ones, and df reports the visible one.
+	for i := len(mounts) - 1; i >= 0; i-- {
+		du, ok := usage[mounts[i].MountPoint]
+		if !ok {
+			continue
+		}
+
+		mounts[i].Size, mounts[i].Used, mounts[i].Available = du.Size, du.Used, du.Available
+		delete(usage, mounts[i].MountPoint)
+	}
+
+	return mounts, nil
+}
+
+// BlockDevices lists the block devices with a filesystem, such as USB storage, as detected by
+// fstools. It prefers the info method of the block ubus object and falls back to running
+// "block info" on releases whose blockd does not provide it.
+func (m *Manager) BlockDevices(ctx context.Context) ([]BlockDevice, error) {
+	res, err := goubus.Call[blockInfoResponse](ctx, m.caller, "block", "info", nil)
+	if err == nil {
+		return res.Devices, nil
+	}
+
+	if !errdefs.IsNotSupported(err) && !errdefs.IsNotFound(err) && !errdefs.IsMethodNotFound(err) &&
+		!errdefs.IsPermissionDenied(err) {
+		return nil, err
+	}
+
+	out, err := m.run(ctx, blockBinary, "info")
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseBlockInfo(out), nil
+}
+
+// run runs a command on the device and returns its output, failing on a non-zero exit code.
+func (m *Manager) run(ctx context.Context, command string, args ...string) (string, error) {
+	res, err := m.file.Exec(ctx, command, args, nil)
+	if err != nil {
+		return "", errdefs.Wrapf(err, "failed to run %s", command)
+	}
+
+	if res.Code != 0 {
+		return "", errdefs.Wrapf(errdefs.ErrUnknown, "%s exited with code %d: %s",
+			command, res.Code, strings.TrimSpace(res.Stderr))
+	}
+
+	return res.Stdout, nil
+}
+
+// UsedPercent returns the used share of the filesystem in percent.
+func (m *Mount) UsedPercent() float64 {
+	if m.Size == 0 {
+		return 0
+	}
+
+	return float64(m.Used) / float64(m.Size) * percent
+}
+
+// ParseMounts parses /proc/mounts, whose fields escape spaces and other special characters as
+// octal sequences such as "\040".
+func ParseMounts(data string) []Mount {
+	mounts := []Mount{}
+
+	for line := range strings.Lines(data) {
+		fields := strings.Fields(line)
+		if len(fields) < mountFields {
+			continue
+		}
+
+		options := strings.Split(fields[3], ",")
+
+		mounts = append(mounts, Mount{
+			Device:     unescapeMount(fields[0]),
+			MountPoint: unescapeMount(fields[1]),
+			FSType:     fields[2],
+			Options:    options,
+			ReadOnly:   slices.Contains(options, "ro"),
+		})
+	}
+
+	return mounts
+}
+
+// unescapeMount decodes the octal escapes of a /proc/mounts field.
+func unescapeMount(field string) string {
+	if !strings.Contains(field, `\`) {
+		return field
+	}
+
+	var b strings.Builder
+
+	for i := 0; i < len(field); i++ {
+		if field[i] == '\\' && i+octalEscape <= len(field) {
+			c, err := strconv.ParseUint(field[i+1:i+octalEscape], 8, 8)
+			if err == nil {
+				b.WriteByte(byte(c))
+
+				i += octalEscape - 1
+
+				continue
+			}
+		}
+
+		b.WriteByte(field[i])
+	}
+
+	return b.String()
+}
+
+// ParseDF parses the output of "df -Pk" into the usage of each mount point in bytes.
+func ParseDF(out string) map[string]Mount {
+	usage := make(map[string]Mount)
+
+	for line := range strings.Lines(out) {
+		fields := strings.Fields(line)
+		if len(fields) < dfFields || fields[0] == "Filesystem" {
+			continue
+		}
+
+		size, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		used, _ := strconv.ParseUint(fields[2], 10, 64)
+		available, _ := strconv.ParseUint(fields[3], 10, 64)
+		mountPoint := strings.Join(fields[5:], " ")
+
+		usage[mountPoint] = Mount{
+			Device:     fields[0],
+			MountPoint: mountPoint,
+			Size:       size * kibibyte,
+			Used:       used * kibibyte,
+			Available:  available * kibibyte,
+		}
+	}
+
+	return usage
+}
+
+// ParseBlockInfo parses the output of "block info", one device per line in the form
+// `/dev/sda1: UUID="..." LABEL="..." VERSION="1.0" MOUNT="/mnt/sda1" TYPE="ext4"`.
+func ParseBlockInfo(out string) []BlockDevice {
+	devices := []BlockDevice{}
+
+	for line := range strings.Lines(out) {
+		device, attributes, ok := strings.Cut(strings.TrimSpace(line), ": ")
+		if !ok {
+			continue
+		}
+
+		dev := BlockDevice{Device: device}
+
+		for _, match := range blockAttribute.FindAllStringSubmatch(attributes, -1) {
+			dev.set(match[1], match[2])
+		}
+
+		devices = append(devices, dev)
+	}
+
+	return devices
+}
+
+// set assigns an attribute of a block info line.
+func (d *BlockDevice) set(key, value string) {
+	switch key {
+	case "UUID":
+		d.UUID = value
+	case "LABEL":
+		d.Label = value
+	case "VERSION":
+		d.Version = value
+	case "MOUNT":
+		d.Mount = value
+	case "TYPE":
+		d.Type = value
+	}
+}
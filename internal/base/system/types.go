@@ -4,6 +4,9 @@
 package system
 
 import (
+	"context"
+	"time"
+
 	"github.com/honeybbq/goubus/v2"
 )
 
@@ -66,6 +69,14 @@ type WatchdogRequest struct {
 	Stop       goubus.Bool `json:"stop,omitempty"`
 }
 
+// WatchdogStatus reports the state of the procd hardware watchdog.
+type WatchdogStatus struct {
+	Status     string `json:"status"`
+	Timeout    int    `json:"timeout"`
+	Frequency  int    `json:"frequency"`
+	MagicClose bool   `json:"magicclose"`
+}
+
 // SignalRequest represents parameters for sending a signal.
 type SignalRequest struct {
 	Pid    int `json:"pid"`
@@ -86,3 +97,223 @@ type SysupgradeRequest struct {
 	Command string         `json:"command,omitempty"`
 	Force   goubus.Bool    `json:"force,omitempty"`
 }
+
+// Action identifies a destructive system action submitted to a ConfirmFunc.
+type Action string
+
+// Destructive system actions.
+const (
+	ActionReboot       Action = "reboot"
+	ActionPowerOff     Action = "poweroff"
+	ActionFactoryReset Action = "factory_reset"
+	ActionSysupgrade   Action = "sysupgrade"
+)
+
+// ConfirmFunc approves a destructive action before it runs. Returning an error aborts the action.
+type ConfirmFunc func(ctx context.Context, action Action) error
+
+// IdlePolicy decides when RebootWhenIdle considers the device idle.
+type IdlePolicy struct {
+	// Deadline is when the reboot happens even if the device is still busy; zero waits indefinitely.
+	Deadline time.Time
+	// WANInterface is the logical interface whose traffic is measured, "wan" by default.
+	WANInterface string
+	// APs are the hostapd objects whose stations are counted, e.g. "hostapd.phy0-ap0";
+	// empty counts the stations of every hostapd object the transport can list.
+	APs []string
+	// MaxStations is the highest number of associated stations that still counts as idle.
+	MaxStations int
+	// MaxWANRate is the highest combined receive and transmit rate on the WAN, in bytes per second,
+	// that still counts as idle. Zero disables the traffic check.
+	MaxWANRate int64
+	// Interval is the time between two samples, 30 seconds by default.
+	Interval time.Duration
+}
+
+// LEDTrigger is the kernel trigger that drives an LED.
+type LEDTrigger string
+
+// LED triggers configurable in an led section. Devices may offer more, e.g. "phy0tpt" or
+// "usbport"; see the trigger file of the LED in /sys/class/leds.
+const (
+	LEDTriggerNone      LEDTrigger = "none"
+	LEDTriggerDefaultOn LEDTrigger = "default-on"
+	LEDTriggerTimer     LEDTrigger = "timer"
+	LEDTriggerHeartbeat LEDTrigger = "heartbeat"
+	LEDTriggerNetdev    LEDTrigger = "netdev"
+)
+
+// LEDConfig represents an "led" section in /etc/config/system.
+type LEDConfig struct {
+	Section string `json:".name"`
+	Name    string `json:"name"`
+	// Sysfs is the LED name in /sys/class/leds, e.g. "green:status".
+	Sysfs   string     `json:"sysfs"`
+	Trigger LEDTrigger `json:"trigger"`
+	// Dev is the network device of the netdev trigger.
+	Dev string `json:"dev"`
+	// Mode lists the netdev events that light the LED: "link", "tx" and "rx".
+	Mode []string `json:"mode"`
+	// DelayOn and DelayOff are the timer trigger periods in milliseconds.
+	DelayOn  int `json:"delayon"`
+	DelayOff int `json:"delayoff"`
+	// Default is the state of the LED with trigger none.
+	Default bool `json:"default"`
+}
+
+// ButtonConfig represents a "button" section in /etc/config/system, which runs a handler
+// when a button is pressed or released.
+type ButtonConfig struct {
+	Section string `json:".name"`
+	// Button is the button name of the hotplug event, e.g. "reset" or "wps".
+	Button string `json:"button"`
+	// Action is "pressed" or "released".
+	Action string `json:"action"`
+	// Handler is the shell command to run.
+	Handler string `json:"handler"`
+	// Min and Max bound how long, in seconds, a button must have been held for a released
+	// action to run. Zero leaves a bound unset.
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+// SensorKind is the quantity a sensor measures.
+type SensorKind string
+
+// Sensor kinds with the unit of their values.
+const (
+	// SensorTemperature values are in degrees Celsius.
+	SensorTemperature SensorKind = "temperature"
+	// SensorVoltage values are in volts.
+	SensorVoltage SensorKind = "voltage"
+	// SensorFan values are in revolutions per minute.
+	SensorFan SensorKind = "fan"
+)
+
+// Sensor is a reading of a hardware sensor.
+type Sensor struct {
+	// Name is the thermal zone type, the hwmon chip name and input label, or the board name of
+	// the sensor, e.g. "cpu-thermal" or "coretemp Core 0".
+	Name string `json:"name"`
+	// Source is the thermal zone or hwmon chip, e.g. "thermal_zone0"; it is empty for board
+	// sensors.
+	Source string     `json:"source,omitempty"`
+	Kind   SensorKind `json:"kind"`
+	// Path is the sysfs attribute the value was read from.
+	Path  string  `json:"path"`
+	Value float64 `json:"value"`
+}
+
+// SensorPath describes a sensor of a board whose sysfs attribute is known.
+type SensorPath struct {
+	Name string     `json:"name"`
+	Path string     `json:"path"`
+	Kind SensorKind `json:"kind"`
+	// Divisor converts the raw value into the unit of Kind, e.g. 1000 for millidegrees; zero
+	// means 1.
+	Divisor float64 `json:"divisor,omitempty"`
+}
+
+// CPUStats holds the CPU time counters of /proc/stat and the load average.
+type CPUStats struct {
+	// CPUs holds the counters of each CPU, Total their sum.
+	CPUs  []CPUTimes  `json:"cpus"`
+	Total CPUTimes    `json:"total"`
+	Load  LoadAverage `json:"load"`
+	// BootTime is the boot time in seconds since the Unix epoch.
+	BootTime        int64  `json:"btime"`
+	ContextSwitches uint64 `json:"ctxt"`
+	// Forks counts the processes and threads created since boot.
+	Forks   uint64 `json:"processes"`
+	Running int    `json:"procs_running"`
+	Blocked int    `json:"procs_blocked"`
+}
+
+// CPUTimes holds the time a CPU spent in each state in clock ticks, usually 1/100 s.
+type CPUTimes struct {
+	User    uint64 `json:"user"`
+	Nice    uint64 `json:"nice"`
+	System  uint64 `json:"system"`
+	Idle    uint64 `json:"idle"`
+	IOWait  uint64 `json:"iowait"`
+	IRQ     uint64 `json:"irq"`
+	SoftIRQ uint64 `json:"softirq"`
+	Steal   uint64 `json:"steal"`
+}
+
+// CPUUsage is the busy share of the CPUs between two CPUStats readings in percent.
+type CPUUsage struct {
+	CPUs  []float64 `json:"cpus"`
+	Total float64   `json:"total"`
+}
+
+// LoadAverage holds the 1, 5 and 15 minute load averages of /proc/loadavg.
+type LoadAverage struct {
+	Load1  float64 `json:"load1"`
+	Load5  float64 `json:"load5"`
+	Load15 float64 `json:"load15"`
+	// RunningTasks are runnable, Tasks exist.
+	RunningTasks int `json:"running_tasks"`
+	Tasks        int `json:"tasks"`
+}
+
+// Process is an entry of the process table read from /proc/<pid>/stat.
+type Process struct {
+	Name string `json:"name"`
+	// State is the process state, e.g. "R" running or "S" sleeping.
+	State string `json:"state"`
+	// VSZ is the virtual and RSS the resident memory size in bytes.
+	VSZ uint64 `json:"vsz"`
+	RSS uint64 `json:"rss"`
+	// UTime and STime are the user and system CPU time in clock ticks.
+	UTime   uint64 `json:"utime"`
+	STime   uint64 `json:"stime"`
+	PID     int    `json:"pid"`
+	PPID    int    `json:"ppid"`
+	Threads int    `json:"threads"`
+}
+
+// Mount is a mounted filesystem of /proc/mounts with its usage.
+type Mount struct {
+	Device     string `json:"device"`
+	MountPoint string `json:"mount_point"`
+	FSType     string `json:"fstype"`
+	// Options are the mount options, e.g. "rw" and "noatime".
+	Options []string `json:"options"`
+	// Size, Used and Available are in bytes and zero for filesystems df does not report.
+	Size      uint64 `json:"size"`
+	Used      uint64 `json:"used"`
+	Available uint64 `json:"available"`
+	ReadOnly  bool   `json:"read_only"`
+}
+
+// BlockDevice is a block device with a filesystem as detected by fstools' block info.
+type BlockDevice struct {
+	// Device is the device node, e.g. "/dev/sda1".
+	Device  string `json:"device"`
+	UUID    string `json:"uuid"`
+	Label   string `json:"label"`
+	Version string `json:"version"`
+	// Type is the filesystem type, e.g. "ext4" or "vfat".
+	Type string `json:"type"`
+	// Mount is the mount point, empty when the device is not mounted.
+	Mount string `json:"mount"`
+}
+
+// blockInfoResponse is the result of the block info method.
+type blockInfoResponse struct {
+	Devices []BlockDevice `json:"devices"`
+}
+
+// KernelModule is a loaded kernel module of /proc/modules.
+type KernelModule struct {
+	Name string `json:"name"`
+	// State is "Live", "Loading" or "Unloading".
+	State string `json:"state"`
+	// UsedBy lists the modules depending on the module.
+	UsedBy []string `json:"used_by"`
+	// Size is the memory size of the module in bytes.
+	Size uint64 `json:"size"`
+	// RefCount counts the users of the module; a module in use cannot be unloaded.
+	RefCount int `json:"ref_count"`
+}
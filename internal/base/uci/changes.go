@@ -0,0 +1,238 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package uci
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+// Changes are staged UCI changes keyed by package, in the order they were staged.
+type Changes map[string][]Change
+
+// UnmarshalJSON decodes the changes of uci.changes: a table of packages, or a plain list when
+// the call asked for a single package, which is then keyed by "" until the caller names it.
+func (c *Changes) UnmarshalJSON(data []byte) error {
+	var list [][]string
+
+	err := json.Unmarshal(data, &list)
+	if err == nil {
+		*c = Changes{"": parseChanges("", list)}
+
+		return nil
+	}
+
+	var table map[string][][]string
+
+	err = json.Unmarshal(data, &table)
+	if err != nil {
+		return errdefs.Wrapf(errdefs.ErrInvalidResponse, "decode uci changes: %v", err)
+	}
+
+	*c = make(Changes, len(table))
+	for pkg, entries := range table {
+		(*c)[pkg] = parseChanges(pkg, entries)
+	}
+
+	return nil
+}
+
+// Packages returns the names of the packages with changes in sorted order.
+func (c Changes) Packages() []string {
+	return slices.Sorted(maps.Keys(c))
+}
+
+// All returns the changes of all packages, ordered by package.
+func (c Changes) All() []Change {
+	var all []Change
+	for _, pkg := range c.Packages() {
+		all = append(all, c[pkg]...)
+	}
+
+	return all
+}
+
+// String lists the changes like "uci changes", one per line.
+func (c Changes) String() string {
+	lines := make([]string, 0, len(c))
+	for _, change := range c.All() {
+		lines = append(lines, change.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// String formats the change like "uci changes", e.g. "network.lan.ipaddr='192.168.2.1'" or
+// "-network.wan".
+func (c Change) String() string {
+	prefix, op := "", "="
+
+	switch c.Kind {
+	case ChangeRemove:
+		prefix = "-"
+	case ChangeRename:
+		prefix = "@"
+	case ChangeAdd:
+		prefix = "+"
+	case ChangeOrder:
+		prefix = "^"
+	case ChangeListAdd:
+		op = "+="
+	case ChangeListDel:
+		op = "-="
+	case ChangeSet:
+	}
+
+	path := prefix + c.Package + "." + c.Section
+	if c.Option != "" {
+		path += "." + c.Option
+	}
+
+	if c.Kind == ChangeRemove {
+		return path
+	}
+
+	return path + op + quoteValue(c.Value)
+}
+
+// ParseChanges parses the output of "uci changes", e.g. read over SSH or from a saved review,
+// into changes keyed by package. It accepts what Changes.String returns.
+func ParseChanges(output string) (Changes, error) {
+	changes := make(Changes)
+
+	for line := range strings.Lines(output) {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		change, err := parseChangeLine(line)
+		if err != nil {
+			return nil, err
+		}
+
+		changes[change.Package] = append(changes[change.Package], change)
+	}
+
+	return changes, nil
+}
+
+// changePrefixes maps the line prefixes of "uci changes" to their change kinds.
+var changePrefixes = map[byte]ChangeKind{'-': ChangeRemove, '@': ChangeRename, '+': ChangeAdd, '^': ChangeOrder}
+
+// parseChangeLine parses one line of "uci changes".
+func parseChangeLine(line string) (Change, error) {
+	change := Change{Kind: ChangeSet}
+
+	kind, ok := changePrefixes[line[0]]
+	if ok {
+		change.Kind = kind
+		line = line[1:]
+	}
+
+	path, value, hasValue := strings.Cut(line, "=")
+	if hasValue == (change.Kind == ChangeRemove) {
+		return Change{}, errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid uci change %q", line)
+	}
+
+	if change.Kind == ChangeSet {
+		change.Kind, path = listChangeKind(path)
+	}
+
+	err := change.setPath(path)
+	if err != nil {
+		return Change{}, err
+	}
+
+	change.Value = unquoteValue(value)
+
+	return change, nil
+}
+
+// listChangeKind detects the "+=" and "-=" operators of list changes, whose "+" or "-" ends
+// the path before the "=".
+func listChangeKind(path string) (ChangeKind, string) {
+	switch {
+	case strings.HasSuffix(path, "+"):
+		return ChangeListAdd, strings.TrimSuffix(path, "+")
+	case strings.HasSuffix(path, "-"):
+		return ChangeListDel, strings.TrimSuffix(path, "-")
+	default:
+		return ChangeSet, path
+	}
+}
+
+// setPath sets the package, section and option of a "package.section[.option]" path.
+func (c *Change) setPath(path string) error {
+	parts := strings.Split(path, ".")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid uci change path %q", path)
+	}
+
+	c.Package, c.Section = parts[0], parts[1]
+	if len(parts) == 3 {
+		c.Option = parts[2]
+	}
+
+	return nil
+}
+
+// unquoteValue removes the shell quoting "uci changes" prints around values.
+func unquoteValue(value string) string {
+	if len(value) < 2 || value[0] != '\'' || value[len(value)-1] != '\'' {
+		return value
+	}
+
+	return strings.ReplaceAll(value[1:len(value)-1], `'\''`, "'")
+}
+
+// parseChanges converts the [kind, section, option, value] lists of uci.changes. Three element
+// lists carry the option of a removal and the value of any other change.
+func parseChanges(pkg string, entries [][]string) []Change {
+	const (
+		sectionOnly = 2
+		withValue   = 3
+	)
+
+	changes := make([]Change, 0, len(entries))
+
+	for _, entry := range entries {
+		if len(entry) < sectionOnly {
+			continue
+		}
+
+		change := Change{Kind: ChangeKind(entry[0]), Package: pkg, Section: entry[1]}
+
+		switch {
+		case len(entry) > withValue:
+			change.Option, change.Value = entry[2], entry[3]
+		case len(entry) == withValue && change.Kind == ChangeRemove:
+			change.Option = entry[2]
+		case len(entry) == withValue:
+			change.Value = entry[2]
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+// named assigns the changes of a single package listing to pkg.
+func (c Changes) named(pkg string) Changes {
+	changes, ok := c[""]
+	if !ok {
+		return c
+	}
+
+	for i := range changes {
+		changes[i].Package = pkg
+	}
+
+	return Changes{pkg: changes}
+}
@@ -0,0 +1,289 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package uci
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+// extraTag marks the field that receives the options no other field takes.
+const extraTag = ",extra"
+
+// As decodes the section into the struct v points to. Fields are matched to options by their
+// `uci:"option"` tag and untagged fields are skipped. The tags ".name", ".type" and ".anonymous"
+// receive the section metadata, and a map[string][]string field tagged `uci:",extra"` receives
+// the options no field takes. String, bool, integer and []string fields and pointers to them are
+// supported; a pointer stays nil when the option is unset. Decode list options into []string
+// fields, since a string field receives the entries joined with spaces.
+func (s *Section) As(v any) error {
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Pointer || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "cannot decode section into %T", v)
+	}
+
+	return s.decode(target.Elem())
+}
+
+// GetAllAs decodes the sections of sectionType into the slice out points to, in configuration
+// order. The slice elements may be structs or pointers to structs, see Section.As.
+func (pc *PackageContext) GetAllAs(ctx context.Context, sectionType string, out any) error {
+	target := reflect.ValueOf(out)
+	if target.Kind() != reflect.Pointer || target.IsNil() || target.Elem().Kind() != reflect.Slice {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "cannot decode sections into %T", out)
+	}
+
+	sections, err := pc.Query(sectionType).All(ctx)
+	if err != nil {
+		return err
+	}
+
+	sliceType := target.Elem().Type()
+	elemType := sliceType.Elem()
+	byPointer := elemType.Kind() == reflect.Pointer
+
+	if byPointer {
+		elemType = elemType.Elem()
+	}
+
+	result := reflect.MakeSlice(sliceType, 0, len(sections))
+
+	for _, section := range sections {
+		item := reflect.New(elemType)
+
+		err = section.As(item.Interface())
+		if err != nil {
+			return errdefs.Wrapf(err, "decode %s.%s", pc.name, section.Name)
+		}
+
+		if !byPointer {
+			item = item.Elem()
+		}
+
+		result = reflect.Append(result, item)
+	}
+
+	target.Elem().Set(result)
+
+	return nil
+}
+
+// SectionValuesFromStruct encodes the tagged fields of the struct v, or the struct v points to,
+// into option values; it is the reverse of Section.As. []string fields are written as lists, even
+// with a single entry, so list options keep their kind. Nil pointers and empty strings and lists
+// are left out, which makes SetValues leave those options untouched. Metadata fields are ignored
+// and the options of the extra field are written as they are.
+func SectionValuesFromStruct(v any) (SectionValues, error) {
+	source := reflect.Indirect(reflect.ValueOf(v))
+	if source.Kind() != reflect.Struct {
+		return SectionValues{}, errdefs.Wrapf(errdefs.ErrInvalidParameter, "cannot encode %T as a section", v)
+	}
+
+	values := NewSectionValues()
+
+	for i := range source.NumField() {
+		field := source.Type().Field(i)
+
+		tag, ok := field.Tag.Lookup("uci")
+		if !ok || tag == "-" || !field.IsExported() || strings.HasPrefix(tag, ".") {
+			continue
+		}
+
+		if tag == extraTag {
+			extra, _ := source.Field(i).Interface().(map[string][]string)
+			for option, entries := range extra {
+				values.Set(option, entries...)
+			}
+
+			continue
+		}
+
+		err := encodeField(&values, tag, source.Field(i))
+		if err != nil {
+			return SectionValues{}, errdefs.Wrapf(err, "option %s", tag)
+		}
+	}
+
+	return values, nil
+}
+
+// decode sets the tagged fields of target.
+func (s *Section) decode(target reflect.Value) error {
+	taken := make(map[string]bool)
+	extra := reflect.Value{}
+
+	for i := range target.NumField() {
+		field := target.Type().Field(i)
+
+		tag, ok := field.Tag.Lookup("uci")
+		if !ok || tag == "-" || !field.IsExported() {
+			continue
+		}
+
+		if tag == extraTag {
+			extra = target.Field(i)
+
+			continue
+		}
+
+		taken[tag] = true
+
+		values, ok := s.lookup(tag)
+		if !ok {
+			continue
+		}
+
+		err := setField(target.Field(i), values)
+		if err != nil {
+			return errdefs.Wrapf(err, "option %s", tag)
+		}
+	}
+
+	return s.decodeExtra(extra, taken)
+}
+
+// decodeExtra stores the options that were not taken in the extra field, if there is one.
+func (s *Section) decodeExtra(extra reflect.Value, taken map[string]bool) error {
+	if !extra.IsValid() {
+		return nil
+	}
+
+	if extra.Type() != reflect.TypeFor[map[string][]string]() {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "extra field must be a map[string][]string")
+	}
+
+	options := make(map[string][]string)
+
+	for option, values := range s.Values.All() {
+		if !taken[option] {
+			options[option] = values
+		}
+	}
+
+	extra.Set(reflect.ValueOf(options))
+
+	return nil
+}
+
+// lookup returns the values of an option or metadata field.
+func (s *Section) lookup(name string) ([]string, bool) {
+	switch name {
+	case ".name":
+		return []string{s.Name}, true
+	case ".type":
+		return []string{s.Type}, true
+	case ".anonymous":
+		return []string{strconv.FormatBool(bool(s.Metadata.Anonymous))}, true
+	}
+
+	values := s.Values.Get(name)
+
+	return values, len(values) > 0
+}
+
+// setField converts the values of an option into the type of field.
+func setField(field reflect.Value, values []string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(strings.Join(values, " "))
+	case reflect.Bool:
+		return setBool(field, values[0])
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(values[0], 10, field.Type().Bits())
+		if err != nil {
+			return errdefs.Wrapf(errdefs.ErrInvalidResponse, "%q is not an integer", values[0])
+		}
+
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(values[0], 10, field.Type().Bits())
+		if err != nil {
+			return errdefs.Wrapf(errdefs.ErrInvalidResponse, "%q is not an unsigned integer", values[0])
+		}
+
+		field.SetUint(n)
+	case reflect.Slice:
+		return setStrings(field, values)
+	case reflect.Pointer:
+		value := reflect.New(field.Type().Elem())
+
+		err := setField(value.Elem(), values)
+		if err != nil {
+			return err
+		}
+
+		field.Set(value)
+	default:
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "unsupported field type %s", field.Type())
+	}
+
+	return nil
+}
+
+// encodeField stores the value of field as option.
+func encodeField(values *SectionValues, option string, field reflect.Value) error {
+	switch field.Kind() {
+	case reflect.String:
+		if field.String() != "" {
+			values.Set(option, field.String())
+		}
+	case reflect.Bool:
+		values.SetBool(option, field.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		values.Set(option, strconv.FormatInt(field.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		values.Set(option, strconv.FormatUint(field.Uint(), 10))
+	case reflect.Slice:
+		return encodeStrings(values, option, field)
+	case reflect.Pointer:
+		if !field.IsNil() {
+			return encodeField(values, option, field.Elem())
+		}
+	default:
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "unsupported field type %s", field.Type())
+	}
+
+	return nil
+}
+
+// setStrings stores the entries of a list option in a []string field.
+func setStrings(field reflect.Value, values []string) error {
+	if field.Type().Elem().Kind() != reflect.String {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "unsupported field type %s", field.Type())
+	}
+
+	field.Set(reflect.ValueOf(values).Convert(field.Type()))
+
+	return nil
+}
+
+// encodeStrings stores a []string field as a list option.
+func encodeStrings(values *SectionValues, option string, field reflect.Value) error {
+	if field.Type().Elem().Kind() != reflect.String {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "unsupported field type %s", field.Type())
+	}
+
+	entries, _ := field.Convert(reflect.TypeFor[[]string]()).Interface().([]string)
+	if len(entries) > 0 {
+		values.SetList(option, entries...)
+	}
+
+	return nil
+}
+
+// setBool parses a boolean in any of the BoolStyle spellings.
+func setBool(field reflect.Value, value string) error {
+	style, ok := DetectBoolStyle(value)
+	if !ok {
+		return errdefs.Wrapf(errdefs.ErrInvalidResponse, "%q is not a boolean", value)
+	}
+
+	field.SetBool(strings.EqualFold(value, style.True))
+
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package uci
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+// Effective is the value an option takes and whether it was set explicitly or falls back to the
+// default of its consumer.
+type Effective[T any] struct {
+	Value    T
+	Explicit bool
+}
+
+// EffectiveValue returns the value of an optional field: *value when it is set, def otherwise.
+func EffectiveValue[T any](value *T, def T) Effective[T] {
+	if value == nil {
+		return Effective[T]{Value: def}
+	}
+
+	return Effective[T]{Value: *value, Explicit: true}
+}
+
+// ApplyDefaults sets the nil pointer fields of the struct v points to from their
+// `default:"..."` tags, parsed like option values. Call it after EffectiveValues when both are
+// needed, since it hides which options were unset.
+func ApplyDefaults(v any) error {
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Pointer || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "cannot apply defaults to %T", v)
+	}
+
+	target = target.Elem()
+
+	for i := range target.NumField() {
+		field := target.Type().Field(i)
+
+		def, ok := field.Tag.Lookup("default")
+		if !ok || !field.IsExported() || field.Type.Kind() != reflect.Pointer || !target.Field(i).IsNil() {
+			continue
+		}
+
+		err := setField(target.Field(i), []string{def})
+		if err != nil {
+			return errdefs.Wrapf(err, "default of %s", field.Name)
+		}
+	}
+
+	return nil
+}
+
+// EffectiveValues reports the effective value of each option of the struct v, or the struct v
+// points to, that is held in a pointer field with a `uci:"option"` tag, keyed by option. Nil
+// fields report the value of their `default:"..."` tag, or "" without one, so a UI can tell
+// "unset (default 12h)" from "explicitly 12h". List entries are joined with spaces.
+func EffectiveValues(v any) (map[string]Effective[string], error) {
+	source := reflect.Indirect(reflect.ValueOf(v))
+	if source.Kind() != reflect.Struct {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "cannot read the values of %T", v)
+	}
+
+	effective := make(map[string]Effective[string])
+
+	for i := range source.NumField() {
+		field := source.Type().Field(i)
+
+		option, ok := field.Tag.Lookup("uci")
+		if !ok || !field.IsExported() || field.Type.Kind() != reflect.Pointer || !isOptionTag(option) {
+			continue
+		}
+
+		if source.Field(i).IsNil() {
+			effective[option] = Effective[string]{Value: field.Tag.Get("default")}
+
+			continue
+		}
+
+		values := NewSectionValues()
+
+		err := encodeField(&values, option, source.Field(i))
+		if err != nil {
+			return nil, errdefs.Wrapf(err, "option %s", option)
+		}
+
+		effective[option] = Effective[string]{Value: strings.Join(values.Get(option), " "), Explicit: true}
+	}
+
+	return effective, nil
+}
+
+// String returns the value, or "unset (default value)" when it is not explicit.
+func (e Effective[T]) String() string {
+	value := fmt.Sprint(e.Value)
+	if e.Explicit {
+		return value
+	}
+
+	return "unset (default " + value + ")"
+}
+
+// isOptionTag reports whether a uci tag names an option rather than metadata or the extra field.
+func isOptionTag(tag string) bool {
+	return tag != "-" && tag != extraTag && !strings.HasPrefix(tag, ".")
+}
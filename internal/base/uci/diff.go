@@ -0,0 +1,193 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package uci
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+// DiffKind is the kind of a difference between two configurations.
+type DiffKind string
+
+// Kinds of configuration differences.
+const (
+	DiffAdded   DiffKind = "added"
+	DiffRemoved DiffKind = "removed"
+	DiffChanged DiffKind = "changed"
+)
+
+// OptionDiff is a difference in one option of a section.
+type OptionDiff struct {
+	Kind   DiffKind `json:"kind"`
+	Option string   `json:"option"`
+	// Old holds the values on the left side; it is empty for added options.
+	Old []string `json:"old,omitempty"`
+	// New holds the values on the right side; it is empty for removed options.
+	New []string `json:"new,omitempty"`
+}
+
+// SectionDiff is a difference in one section.
+type SectionDiff struct {
+	Kind DiffKind `json:"kind"`
+	// Section is the section name, or its "@type[index]" reference if it is anonymous.
+	Section string `json:"section"`
+	Type    string `json:"type"`
+	// Options lists the option differences by option name; for added and removed sections it
+	// lists every option.
+	Options []OptionDiff `json:"options,omitempty"`
+}
+
+// Diff compares two packages, as returned by GetAll, and returns the differences that turn a
+// into b. Removed and changed sections follow the order of a, added sections the order of b.
+// Anonymous sections are matched by their "@type[index]" reference, since their generated names
+// differ between devices, and a section whose type changed is reported as removed and added.
+func Diff(a, b map[string]*Section) []SectionDiff {
+	left, right := sectionsByRef(a), sectionsByRef(b)
+
+	var diffs []SectionDiff
+
+	for _, ref := range orderedRefs(a) {
+		old, current := left[ref], right[ref]
+		if current == nil || current.Type != old.Type {
+			diffs = append(diffs, wholeSection(DiffRemoved, ref, old))
+
+			continue
+		}
+
+		options := diffOptions(old.Values, current.Values)
+		if len(options) > 0 {
+			diffs = append(diffs, SectionDiff{Kind: DiffChanged, Section: ref, Type: old.Type, Options: options})
+		}
+	}
+
+	for _, ref := range orderedRefs(b) {
+		old, current := left[ref], right[ref]
+		if old == nil || current.Type != old.Type {
+			diffs = append(diffs, wholeSection(DiffAdded, ref, current))
+		}
+	}
+
+	return diffs
+}
+
+// SectionsByRef keys sections by name, or by their "@type[index]" reference if they are
+// anonymous, e.g. to compare the sections of ParseConfig with Diff.
+func SectionsByRef(sections []*Section) map[string]*Section {
+	byRef := make(map[string]*Section, len(sections))
+	for i, ref := range sectionRefs(sections) {
+		byRef[ref] = sections[i]
+	}
+
+	return byRef
+}
+
+// DiffAgainst compares the packages of the device with those of other, e.g. a reference device,
+// and returns the differences that turn this device's configuration into other's, keyed by
+// package. Without names, every package listed by Configs on this device is compared. Packages
+// without differences are left out.
+func (m *Manager) DiffAgainst(
+	ctx context.Context, other *Manager, names ...string,
+) (map[string][]SectionDiff, error) {
+	if len(names) == 0 {
+		var err error
+
+		names, err = m.Configs(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	local, err := m.GetPackages(ctx, names...)
+	if err != nil {
+		return nil, err
+	}
+
+	remote, err := other.GetPackages(ctx, names...)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read the packages to compare against")
+	}
+
+	diffs := make(map[string][]SectionDiff)
+
+	for name, sections := range local {
+		diff := Diff(sections, remote[name])
+		if len(diff) > 0 {
+			diffs[name] = diff
+		}
+	}
+
+	return diffs, nil
+}
+
+// wholeSection describes an added or removed section with all its options.
+func wholeSection(kind DiffKind, ref string, section *Section) SectionDiff {
+	options := diffOptions(SectionValues{}, section.Values)
+	if kind == DiffRemoved {
+		options = diffOptions(section.Values, SectionValues{})
+	}
+
+	return SectionDiff{Kind: kind, Section: ref, Type: section.Type, Options: options}
+}
+
+// diffOptions compares the options of two sections by option name.
+func diffOptions(a, b SectionValues) []OptionDiff {
+	options := maps.Clone(a.values)
+	if options == nil {
+		options = make(map[string]sectionValue, len(b.values))
+	}
+
+	maps.Copy(options, b.values)
+
+	var diffs []OptionDiff
+
+	for _, name := range slices.Sorted(maps.Keys(options)) {
+		old, current := a.Get(name), b.Get(name)
+		_, inA := a.values[name]
+		_, inB := b.values[name]
+
+		switch {
+		case !inA:
+			diffs = append(diffs, OptionDiff{Kind: DiffAdded, Option: name, New: current})
+		case !inB:
+			diffs = append(diffs, OptionDiff{Kind: DiffRemoved, Option: name, Old: old})
+		case !slices.Equal(old, current):
+			diffs = append(diffs, OptionDiff{Kind: DiffChanged, Option: name, Old: old, New: current})
+		}
+	}
+
+	return diffs
+}
+
+// sectionsByRef keys the sections of a package by their references.
+func sectionsByRef(sections map[string]*Section) map[string]*Section {
+	return SectionsByRef(SortedSections(sections))
+}
+
+// orderedRefs returns the references of the sections of a package in configuration order.
+func orderedRefs(sections map[string]*Section) []string {
+	return sectionRefs(SortedSections(sections))
+}
+
+// sectionRefs returns the name of each section, or its "@type[index]" reference if it is
+// anonymous.
+func sectionRefs(sections []*Section) []string {
+	refs := make([]string, len(sections))
+	counts := make(map[string]int)
+
+	for i, section := range sections {
+		if section.Name == "" || bool(section.Metadata.Anonymous) {
+			refs[i] = TypedRef(section.Type, counts[section.Type])
+		} else {
+			refs[i] = section.Name
+		}
+
+		counts[section.Type]++
+	}
+
+	return refs
+}
@@ -0,0 +1,267 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package uci
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+// Export writes the package to w in the UCI file format, like "uci export" does.
+func (m *Manager) Export(ctx context.Context, pkg string, w io.Writer) error {
+	sections, err := m.Package(pkg).GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	return WriteConfig(w, pkg, SortedSections(sections))
+}
+
+// Import reads a package in the UCI file format from r and stages it as a full replacement of
+// pkg: the existing sections are deleted and the imported ones added in order. The changes take
+// effect once the package is committed or applied. A "package" line naming another package is
+// rejected. If staging fails part way, revert the package to discard the partial import.
+func (m *Manager) Import(ctx context.Context, pkg string, r io.Reader) error {
+	name, sections, err := ParseConfig(r)
+	if err != nil {
+		return err
+	}
+
+	if name != "" && name != pkg {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "cannot import package %s into %s", name, pkg)
+	}
+
+	existing, err := m.Package(pkg).GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx := m.Transaction()
+	for _, section := range SortedSections(existing) {
+		tx.Delete(pkg, section.Name)
+	}
+
+	for _, section := range sections {
+		tx.Add(pkg, section.Type, section.Name, section.Values)
+	}
+
+	return tx.Stage(ctx)
+}
+
+// WriteConfig writes sections in the UCI file format, preceded by a "package" line unless pkg is
+// empty. Anonymous sections are written without a name and options in sorted order, so equal
+// configurations render identically.
+func WriteConfig(w io.Writer, pkg string, sections []*Section) error {
+	var b strings.Builder
+
+	if pkg != "" {
+		b.WriteString("package " + pkg + "\n")
+	}
+
+	for _, section := range sections {
+		b.WriteString("\nconfig " + section.Type)
+
+		if section.Name != "" && !bool(section.Metadata.Anonymous) {
+			b.WriteString(" " + quoteValue(section.Name))
+		}
+
+		b.WriteString("\n")
+		writeOptions(&b, section.Values)
+	}
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+// writeOptions writes the option and list lines of a section.
+func writeOptions(b *strings.Builder, values SectionValues) {
+	for _, option := range slices.Sorted(maps.Keys(values.values)) {
+		value := values.values[option]
+		if value.kind == sectionValueKindScalar && len(value.values) <= 1 {
+			b.WriteString("\toption " + option + " " + quoteValue(strings.Join(value.values, "")) + "\n")
+
+			continue
+		}
+
+		for _, item := range value.values {
+			b.WriteString("\tlist " + option + " " + quoteValue(item) + "\n")
+		}
+	}
+}
+
+// ParseConfig parses a package in the UCI file format. It returns the name given by a "package"
+// line, if any, and the sections in order; sections without a name are marked anonymous.
+// Comments and blank lines are skipped, and values may be quoted like in a shell.
+func ParseConfig(r io.Reader) (string, []*Section, error) {
+	var (
+		pkg      string
+		sections []*Section
+	)
+
+	scanner := bufio.NewScanner(r)
+
+	for line := 1; scanner.Scan(); line++ {
+		words, err := splitConfigLine(scanner.Text())
+		if err != nil {
+			return "", nil, errdefs.Wrapf(err, "line %d", line)
+		}
+
+		if len(words) == 0 {
+			continue
+		}
+
+		if words[0] == "package" && len(words) == 2 {
+			pkg = words[1]
+
+			continue
+		}
+
+		sections, err = parseConfigStatement(sections, words)
+		if err != nil {
+			return "", nil, errdefs.Wrapf(err, "line %d", line)
+		}
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return pkg, sections, nil
+}
+
+// parseConfigStatement applies a config, option or list statement to the sections parsed so far.
+func parseConfigStatement(sections []*Section, words []string) ([]*Section, error) {
+	const (
+		typeOnly  = 2
+		statement = 3
+	)
+
+	if words[0] == "config" && (len(words) == typeOnly || len(words) == statement) {
+		index := len(sections)
+		section := &Section{Type: words[1], Values: NewSectionValues(), Metadata: Metadata{Index: &index}}
+
+		if len(words) == statement {
+			section.Name = words[2]
+		}
+
+		section.Metadata.Type, section.Metadata.Name = section.Type, section.Name
+		section.Metadata.Anonymous = section.Name == ""
+
+		return append(sections, section), nil
+	}
+
+	if len(words) != statement || len(sections) == 0 {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "unexpected %q", strings.Join(words, " "))
+	}
+
+	values := &sections[len(sections)-1].Values
+
+	switch words[0] {
+	case "option":
+		values.Set(words[1], words[2])
+	case "list":
+		values.SetList(words[1], append(values.Get(words[1]), words[2])...)
+	default:
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "unknown statement %q", words[0])
+	}
+
+	return sections, nil
+}
+
+// splitConfigLine splits a line into words, removing quotes and escapes and stopping at a
+// comment.
+func splitConfigLine(line string) ([]string, error) {
+	var words []string
+
+	for {
+		line = strings.TrimLeft(line, " \t")
+		if line == "" || line[0] == '#' {
+			return words, nil
+		}
+
+		word, rest, err := cutConfigWord(line)
+		if err != nil {
+			return nil, err
+		}
+
+		words, line = append(words, word), rest
+	}
+}
+
+// cutConfigWord returns the word at the start of line and the rest of the line. A word is a run
+// of unquoted characters and quoted strings.
+func cutConfigWord(line string) (string, string, error) {
+	var word strings.Builder
+
+	for line != "" && line[0] != ' ' && line[0] != '\t' {
+		var (
+			part string
+			err  error
+		)
+
+		part, line, err = cutConfigPart(line)
+		if err != nil {
+			return "", "", err
+		}
+
+		word.WriteString(part)
+	}
+
+	return word.String(), line, nil
+}
+
+// cutConfigPart returns the quoted string, escaped character or plain character at the start of
+// line. Single quotes are literal; double quotes allow backslash escapes.
+func cutConfigPart(line string) (string, string, error) {
+	switch line[0] {
+	case '\'':
+		value, rest, ok := strings.Cut(line[1:], "'")
+		if !ok {
+			return "", "", errdefs.Wrapf(errdefs.ErrInvalidParameter, "unterminated quote")
+		}
+
+		return value, rest, nil
+	case '"':
+		return cutDoubleQuoted(line[1:])
+	case '\\':
+		if len(line) < 2 {
+			return "", "", errdefs.Wrapf(errdefs.ErrInvalidParameter, "trailing backslash")
+		}
+
+		return line[1:2], line[2:], nil
+	default:
+		return line[:1], line[1:], nil
+	}
+}
+
+// cutDoubleQuoted returns the value of a double quoted string whose opening quote was removed.
+func cutDoubleQuoted(line string) (string, string, error) {
+	var value strings.Builder
+
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '"':
+			return value.String(), line[i+1:], nil
+		case line[i] == '\\' && i+1 < len(line):
+			i++
+		}
+
+		value.WriteByte(line[i])
+	}
+
+	return "", "", errdefs.Wrapf(errdefs.ErrInvalidParameter, "unterminated quote")
+}
+
+// quoteValue quotes a value for the UCI file format and the "uci changes" output.
+func quoteValue(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
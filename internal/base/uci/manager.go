@@ -4,23 +4,40 @@
 package uci
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
+	"math"
 	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/honeybbq/goubus/v2"
 	"github.com/honeybbq/goubus/v2/errdefs"
 )
 
+const (
+	// maxConcurrentPackageReads bounds the number of packages GetPackages reads at once.
+	maxConcurrentPackageReads = 4
+
+	// DefaultApplyTimeout is the rollback timeout of ApplyWithConfirm when none is given.
+	DefaultApplyTimeout = 30 * time.Second
+	// applySettleDivisor sets how much of the rollback timeout ApplyWithConfirm waits for the
+	// services to reload before probing the device.
+	applySettleDivisor = 3
+)
+
 // Dialect defines the differences in UCI ubus calls.
 type Dialect any
 
 // Manager is the entry point for all UCI-related operations.
 type Manager struct {
-	caller  goubus.Transport
-	dialect Dialect
+	caller   goubus.Transport
+	dialect  Dialect
+	schemas  map[schemaKey]Schema
+	schemaMu sync.RWMutex
 }
 
 // New creates a new base UCI Manager.
@@ -28,6 +45,11 @@ func New(t goubus.Transport, d Dialect) *Manager {
 	return &Manager{caller: t, dialect: d}
 }
 
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"uci"}
+}
+
 // Package selects a specific UCI configuration file (package) for operations.
 func (m *Manager) Package(name string) *PackageContext {
 	return &PackageContext{
@@ -46,11 +68,78 @@ func (m *Manager) Configs(ctx context.Context) ([]string, error) {
 	return resp.Configs, nil
 }
 
+// GetPackages retrieves several packages concurrently, keyed by package name.
+// Without names, every package listed by Configs is retrieved. The first failure aborts the call.
+func (m *Manager) GetPackages(ctx context.Context, names ...string) (map[string]map[string]*Section, error) {
+	if len(names) == 0 {
+		configs, err := m.Configs(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		names = configs
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		group    sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+
+	packages := make(map[string]map[string]*Section, len(names))
+	limit := make(chan struct{}, maxConcurrentPackageReads)
+
+	for _, name := range slices.Compact(slices.Sorted(slices.Values(names))) {
+		group.Go(func() {
+			limit <- struct{}{}
+			defer func() { <-limit }()
+
+			sections, err := m.Package(name).GetAll(ctx)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				if firstErr == nil {
+					firstErr = errdefs.Wrapf(err, "failed to read package %s", name)
+				}
+
+				cancel()
+
+				return
+			}
+
+			packages[name] = sections
+		})
+	}
+
+	group.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return packages, nil
+}
+
 // State retrieves runtime state information.
 func (m *Manager) State(ctx context.Context, req StateRequest) (*GetResponse, error) {
 	return m.getRaw(ctx, "state", GetRequest(req))
 }
 
+// Changes lists the staged changes of all packages.
+func (m *Manager) Changes(ctx context.Context) (Changes, error) {
+	resp, err := goubus.Call[ChangesResponse](ctx, m.caller, "uci", "changes", nil)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to list uci changes")
+	}
+
+	return resp.Changes, nil
+}
+
 // Apply activates staged changes.
 func (m *Manager) Apply(ctx context.Context, rollback bool, timeout int) error {
 	req := ApplyRequest{
@@ -86,6 +175,50 @@ func (m *Manager) Rollback(ctx context.Context) error {
 	return nil
 }
 
+// ApplyWithConfirm applies the staged changes with rollback: rpcd reloads the affected services
+// and restores the previous configuration after timeout unless the changes are confirmed.
+// ApplyWithConfirm waits a third of the timeout, runs probe to check that the device is still
+// reachable and confirms. If the probe fails, for example because the change cut off the
+// management interface, the changes are left to roll back and the probe error is returned.
+// A nil probe lists the UCI configs.
+func (m *Manager) ApplyWithConfirm(
+	ctx context.Context, timeout time.Duration, probe func(ctx context.Context) error,
+) error {
+	if timeout <= 0 {
+		timeout = DefaultApplyTimeout
+	}
+
+	if probe == nil {
+		probe = func(ctx context.Context) error {
+			_, err := m.Configs(ctx)
+
+			return err
+		}
+	}
+
+	err := m.Apply(ctx, true, int(math.Ceil(timeout.Seconds())))
+	if err != nil {
+		return err
+	}
+
+	timer := time.NewTimer(timeout / applySettleDivisor)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return errdefs.Wrapf(errdefs.FromTransport(ctx.Err()),
+			"wait for uci changes to settle; they roll back after %s", timeout)
+	case <-timer.C:
+	}
+
+	err = probe(ctx)
+	if err != nil {
+		return errdefs.Wrapf(err, "device did not answer after applying uci changes; they roll back after %s", timeout)
+	}
+
+	return m.Confirm(ctx)
+}
+
 // ReloadConfig reloads the system configuration services.
 func (m *Manager) ReloadConfig(ctx context.Context) error {
 	_, err := m.caller.Call(ctx, "uci", "reload_config", nil)
@@ -129,49 +262,53 @@ func (pc *PackageContext) GetAll(ctx context.Context) (map[string]*Section, erro
 	return sections, nil
 }
 
-// State retrieves all runtime state sections from the package.
-func (pc *PackageContext) State(ctx context.Context) (map[string]*Section, error) {
+// GetAllLazy retrieves all sections of the package but only converts a section when it is accessed,
+// which keeps large packages cheap to hold when only a few sections are needed.
+func (pc *PackageContext) GetAllLazy(ctx context.Context) (*LazySections, error) {
 	req := GetRequest{
 		RequestGeneric: RequestGeneric{Config: pc.name},
 	}
 
-	raw, err := pc.manager.getAllRaw(ctx, "state", req)
+	res, err := goubus.Call[LazySections](ctx, pc.manager.caller, "uci", "get", req)
 	if err != nil {
 		return nil, err
 	}
 
-	sections := make(map[string]*Section, len(raw))
-	for name, data := range raw {
-		sections[name] = newSectionFromRaw(name, data)
-	}
-
-	return sections, nil
+	return res, nil
 }
 
-// SectionsOfType returns the names of all sections in the package that match the given type.
-func (pc *PackageContext) SectionsOfType(ctx context.Context, sectionType string) ([]string, error) {
+// State retrieves all runtime state sections from the package.
+func (pc *PackageContext) State(ctx context.Context) (map[string]*Section, error) {
 	req := GetRequest{
 		RequestGeneric: RequestGeneric{Config: pc.name},
 	}
 
-	allSections, err := pc.manager.getAllRaw(ctx, "get", req)
+	raw, err := pc.manager.getAllRaw(ctx, "state", req)
 	if err != nil {
 		return nil, err
 	}
 
-	var sectionNames []string
-
-	for sectionName, options := range allSections {
-		if t, ok := options[".type"].(string); ok && t == sectionType {
-			sectionNames = append(sectionNames, sectionName)
-		}
+	sections := make(map[string]*Section, len(raw))
+	for name, data := range raw {
+		sections[name] = newSectionFromRaw(name, data)
 	}
 
-	return sectionNames, nil
+	return sections, nil
+}
+
+// SectionsOfType returns the names of all sections in the package that match the given type,
+// in configuration order. Use Query to filter by option values as well.
+func (pc *PackageContext) SectionsOfType(ctx context.Context, sectionType string) ([]string, error) {
+	return pc.Query(sectionType).Names(ctx)
 }
 
 // Add creates a new section of sectionType with the given name and initial values.
 func (pc *PackageContext) Add(ctx context.Context, sectionType, name string, values SectionValues) error {
+	err := pc.manager.validate(pc.name, sectionType, cmp.Or(name, sectionType), values)
+	if err != nil {
+		return err
+	}
+
 	req := Request{
 		RequestGeneric: RequestGeneric{
 			Config: pc.name,
@@ -183,7 +320,7 @@ func (pc *PackageContext) Add(ctx context.Context, sectionType, name string, val
 		req.Values = values.toUbusValues()
 	}
 
-	_, err := pc.manager.caller.Call(ctx, "uci", "add", req)
+	_, err = pc.manager.caller.Call(ctx, "uci", "add", req)
 
 	return err
 }
@@ -208,7 +345,14 @@ func (pc *PackageContext) Revert(ctx context.Context) error {
 func (pc *PackageContext) Changes(ctx context.Context) (*ChangesResponse, error) {
 	req := ChangesRequest{Config: pc.name}
 
-	return goubus.Call[ChangesResponse](ctx, pc.manager.caller, "uci", "changes", req)
+	resp, err := goubus.Call[ChangesResponse](ctx, pc.manager.caller, "uci", "changes", req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Changes = resp.Changes.named(pc.name)
+
+	return resp, nil
 }
 
 // Order rearranges the sections in the package.
@@ -222,6 +366,55 @@ func (pc *PackageContext) Order(ctx context.Context, sections []string) error {
 	return err
 }
 
+// SectionOrder returns the names of the sections in the package in configuration order, which
+// decides for example which firewall rule matches first.
+func (pc *PackageContext) SectionOrder(ctx context.Context) ([]string, error) {
+	sections, err := pc.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(sections))
+	for _, section := range SortedSections(sections) {
+		names = append(names, section.Name)
+	}
+
+	return names, nil
+}
+
+// MoveBefore moves section directly in front of anchor, e.g. to let a firewall rule match before
+// a broader one: Package("firewall").MoveBefore(ctx, "allow_mgmt", "reject_wan").
+func (pc *PackageContext) MoveBefore(ctx context.Context, section, anchor string) error {
+	return pc.move(ctx, section, anchor, 0)
+}
+
+// MoveAfter moves section directly behind anchor.
+func (pc *PackageContext) MoveAfter(ctx context.Context, section, anchor string) error {
+	return pc.move(ctx, section, anchor, 1)
+}
+
+// move places section at the position of anchor plus offset and stages the new order.
+func (pc *PackageContext) move(ctx context.Context, section, anchor string, offset int) error {
+	order, err := pc.SectionOrder(ctx)
+	if err != nil {
+		return err
+	}
+
+	from := slices.Index(order, section)
+	if from < 0 || !slices.Contains(order, anchor) {
+		return errdefs.Wrapf(errdefs.ErrNotFound, "sections %s and %s must exist in %s", section, anchor, pc.name)
+	}
+
+	if section == anchor {
+		return nil
+	}
+
+	order = slices.Delete(order, from, from+1)
+	to := slices.Index(order, anchor) + offset
+
+	return pc.Order(ctx, slices.Insert(order, to, section))
+}
+
 // Sections returns the names of all sections currently defined in the package.
 func (pc *PackageContext) Sections(ctx context.Context) ([]string, error) {
 	req := GetRequest{
@@ -251,6 +444,11 @@ type SectionContext struct {
 	name string
 }
 
+// resolve returns the name of the section, looking up "@type[index]" references.
+func (sc *SectionContext) resolve(ctx context.Context) (string, error) {
+	return sc.pc.Resolve(ctx, sc.name)
+}
+
 // Option selects a specific option within the section.
 func (sc *SectionContext) Option(name string) *OptionContext {
 	return &OptionContext{
@@ -261,10 +459,15 @@ func (sc *SectionContext) Option(name string) *OptionContext {
 
 // Get retrieves the section's type and all its current values.
 func (sc *SectionContext) Get(ctx context.Context) (*Section, error) {
+	section, err := sc.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	req := GetRequest{
 		RequestGeneric: RequestGeneric{
 			Config:  sc.pc.name,
-			Section: sc.name,
+			Section: section,
 		},
 	}
 
@@ -273,15 +476,20 @@ func (sc *SectionContext) Get(ctx context.Context) (*Section, error) {
 		return nil, err
 	}
 
-	return newSectionFromRaw(sc.name, resp.Values), nil
+	return newSectionFromRaw(section, resp.Values), nil
 }
 
 // State retrieves the runtime state of the section.
 func (sc *SectionContext) State(ctx context.Context) (*Section, error) {
+	section, err := sc.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	req := GetRequest{
 		RequestGeneric: RequestGeneric{
 			Config:  sc.pc.name,
-			Section: sc.name,
+			Section: section,
 		},
 	}
 
@@ -290,45 +498,66 @@ func (sc *SectionContext) State(ctx context.Context) (*Section, error) {
 		return nil, err
 	}
 
-	return newSectionFromRaw(sc.name, resp.Values), nil
+	return newSectionFromRaw(section, resp.Values), nil
 }
 
-// SetValues updates multiple options in the section simultaneously.
+// SetValues updates multiple options in the section simultaneously, after checking them against
+// the schema set for the section type, if any.
 func (sc *SectionContext) SetValues(ctx context.Context, values SectionValues) error {
+	section, err := sc.resolve(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = sc.validate(ctx, section, values)
+	if err != nil {
+		return err
+	}
+
 	req := Request{
 		RequestGeneric: RequestGeneric{
 			Config:  sc.pc.name,
-			Section: sc.name,
+			Section: section,
 		},
 	}
 	if values.Len() > 0 {
 		req.Values = values.toUbusValues()
 	}
 
-	_, err := sc.pc.manager.caller.Call(ctx, "uci", "set", req)
+	_, err = sc.pc.manager.caller.Call(ctx, "uci", "set", req)
 
 	return err
 }
 
 // Delete removes the section from the package.
 func (sc *SectionContext) Delete(ctx context.Context) error {
+	section, err := sc.resolve(ctx)
+	if err != nil {
+		return err
+	}
+
 	req := RequestGeneric{
 		Config:  sc.pc.name,
-		Section: sc.name,
+		Section: section,
 	}
-	_, err := sc.pc.manager.caller.Call(ctx, "uci", "delete", req)
+	_, err = sc.pc.manager.caller.Call(ctx, "uci", "delete", req)
 
 	return err
 }
 
 // Rename changes the name of the section.
 func (sc *SectionContext) Rename(ctx context.Context, newName string) error {
+	section, err := sc.resolve(ctx)
+	if err != nil {
+		return err
+	}
+
 	req := RenameRequest{
 		Config:  sc.pc.name,
-		Section: sc.name,
+		Section: section,
 		Name:    newName,
 	}
-	_, err := sc.pc.manager.caller.Call(ctx, "uci", "rename", req)
+	_, err = sc.pc.manager.caller.Call(ctx, "uci", "rename", req)
 
 	return err
 }
@@ -341,10 +570,15 @@ type OptionContext struct {
 
 // Get retrieves the current value of the option.
 func (oc *OptionContext) Get(ctx context.Context) (string, error) {
+	section, err := oc.sc.resolve(ctx)
+	if err != nil {
+		return "", err
+	}
+
 	req := GetRequest{
 		RequestGeneric: RequestGeneric{
 			Config:  oc.sc.pc.name,
-			Section: oc.sc.name,
+			Section: section,
 			Option:  oc.name,
 		},
 	}
@@ -352,7 +586,7 @@ func (oc *OptionContext) Get(ctx context.Context) (string, error) {
 	resp, err := oc.sc.pc.manager.getRaw(ctx, "get", req)
 	if err != nil {
 		if errdefs.IsNotFound(err) {
-			return "", errdefs.Wrapf(err, "option '%s' not found in section '%s'", oc.name, oc.sc.name)
+			return "", errdefs.Wrapf(err, "option '%s' not found in section '%s'", oc.name, section)
 		}
 
 		return "", err
@@ -363,10 +597,15 @@ func (oc *OptionContext) Get(ctx context.Context) (string, error) {
 
 // State retrieves the runtime state of the option.
 func (oc *OptionContext) State(ctx context.Context) (string, error) {
+	section, err := oc.sc.resolve(ctx)
+	if err != nil {
+		return "", err
+	}
+
 	req := GetRequest{
 		RequestGeneric: RequestGeneric{
 			Config:  oc.sc.pc.name,
-			Section: oc.sc.name,
+			Section: section,
 			Option:  oc.name,
 		},
 	}
@@ -374,7 +613,7 @@ func (oc *OptionContext) State(ctx context.Context) (string, error) {
 	resp, err := oc.sc.pc.manager.getRaw(ctx, "state", req)
 	if err != nil {
 		if errdefs.IsNotFound(err) {
-			return "", errdefs.Wrapf(err, "option '%s' not found in section '%s'", oc.name, oc.sc.name)
+			return "", errdefs.Wrapf(err, "option '%s' not found in section '%s'", oc.name, section)
 		}
 
 		return "", err
@@ -393,67 +632,58 @@ func (oc *OptionContext) Set(ctx context.Context, value string) error {
 
 // Delete removes the option from the section.
 func (oc *OptionContext) Delete(ctx context.Context) error {
+	section, err := oc.sc.resolve(ctx)
+	if err != nil {
+		return err
+	}
+
 	req := RequestGeneric{
 		Config:  oc.sc.pc.name,
-		Section: oc.sc.name,
+		Section: section,
 		Option:  oc.name,
 	}
-	_, err := oc.sc.pc.manager.caller.Call(ctx, "uci", "delete", req)
+	_, err = oc.sc.pc.manager.caller.Call(ctx, "uci", "delete", req)
 
 	return err
 }
 
-// AddToList appends a value to a list option.
+// List retrieves the entries of a list option; a scalar option is returned as a single entry.
+func (oc *OptionContext) List(ctx context.Context) ([]string, error) {
+	section, err := oc.sc.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return oc.list(ctx, section)
+}
+
+// AddToList appends a value to a list option unless the list already contains it.
 func (oc *OptionContext) AddToList(ctx context.Context, value string) error {
-	config, section, option := oc.sc.pc.name, oc.sc.name, oc.name
-	getRequest := GetRequest{
-		RequestGeneric: RequestGeneric{
-			Config:  config,
-			Section: section,
-			Option:  option,
-		},
+	section, err := oc.sc.resolve(ctx)
+	if err != nil {
+		return err
 	}
 
-	getResponse, err := oc.sc.pc.manager.getRaw(ctx, "get", getRequest)
+	currentList, err := oc.list(ctx, section)
 	if err != nil && !errors.Is(err, errdefs.ErrNotFound) {
 		return errdefs.Wrapf(err, "could not get list to add to")
 	}
 
-	currentList := []string{}
-
-	if getResponse != nil && getResponse.Value != "" {
-		for item := range strings.SplitSeq(getResponse.Value, " ") {
-			if item != "" {
-				currentList = append(currentList, item)
-			}
-		}
-	}
-
 	if slices.Contains(currentList, value) {
 		return nil
 	}
 
-	currentList = append(currentList, value)
-	setRequest := Request{
-		RequestGeneric: RequestGeneric{
-			Config:  config,
-			Section: section,
-		},
-		Values: map[string]any{option: currentList},
-	}
-	_, err = oc.sc.pc.manager.caller.Call(ctx, "uci", "set", setRequest)
-
-	return err
+	return oc.setList(ctx, section, append(currentList, value))
 }
 
-// DeleteFromList removes a value from a list option.
+// DeleteFromList removes a value from a list option, deleting the option with its last entry.
 func (oc *OptionContext) DeleteFromList(ctx context.Context, value string) error {
-	config, section, option := oc.sc.pc.name, oc.sc.name, oc.name
-	getRequest := GetRequest{
-		RequestGeneric: RequestGeneric{Config: config, Section: section, Option: option},
+	section, err := oc.sc.resolve(ctx)
+	if err != nil {
+		return err
 	}
 
-	getResponse, err := oc.sc.pc.manager.getRaw(ctx, "get", getRequest)
+	currentList, err := oc.list(ctx, section)
 	if err != nil {
 		if errors.Is(err, errdefs.ErrNotFound) {
 			return nil
@@ -462,49 +692,60 @@ func (oc *OptionContext) DeleteFromList(ctx context.Context, value string) error
 		return errdefs.Wrapf(err, "could not get list to delete from")
 	}
 
-	if getResponse.Value == "" {
+	newList := slices.DeleteFunc(slices.Clone(currentList), func(item string) bool { return item == value })
+	if len(newList) == len(currentList) {
 		return nil
 	}
 
-	newList := []string{}
-	found := false
+	if len(newList) == 0 {
+		delRequest := RequestGeneric{Config: oc.sc.pc.name, Section: section, Option: oc.name}
+		_, err = oc.sc.pc.manager.caller.Call(ctx, "uci", "delete", delRequest)
 
-	for item := range strings.SplitSeq(getResponse.Value, " ") {
-		if item == value {
-			found = true
+		return err
+	}
 
-			continue
-		}
+	return oc.setList(ctx, section, newList)
+}
 
-		if item != "" {
-			newList = append(newList, item)
-		}
+// list reads the entries of the option. Lists arrive as arrays; older rpcd versions join them
+// with spaces, which is split as a fallback.
+func (oc *OptionContext) list(ctx context.Context, section string) ([]string, error) {
+	getRequest := GetRequest{
+		RequestGeneric: RequestGeneric{Config: oc.sc.pc.name, Section: section, Option: oc.name},
 	}
 
-	if !found {
-		return nil
+	getResponse, err := oc.sc.pc.manager.getRaw(ctx, "get", getRequest)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(newList) == 0 {
-		delRequest := RequestGeneric{Config: config, Section: section, Option: option}
-		_, err = oc.sc.pc.manager.caller.Call(ctx, "uci", "delete", delRequest)
-
-		return err
+	if getResponse.List != nil {
+		return getResponse.List, nil
 	}
 
+	return strings.Fields(getResponse.Value), nil
+}
+
+// setList writes the option as a list, which rpcd only does for arrays.
+func (oc *OptionContext) setList(ctx context.Context, section string, list []string) error {
 	setRequest := Request{
-		RequestGeneric: RequestGeneric{Config: config, Section: section},
-		Values:         map[string]any{option: newList},
+		RequestGeneric: RequestGeneric{Config: oc.sc.pc.name, Section: section},
+		Values:         map[string]any{oc.name: list},
 	}
-	_, err = oc.sc.pc.manager.caller.Call(ctx, "uci", "set", setRequest)
+	_, err := oc.sc.pc.manager.caller.Call(ctx, "uci", "set", setRequest)
 
 	return err
 }
 
 // Rename changes the name of the option.
 func (oc *OptionContext) Rename(ctx context.Context, newName string) error {
-	req := RenameRequest{Config: oc.sc.pc.name, Section: oc.sc.name, Option: oc.name, Name: newName}
-	_, err := oc.sc.pc.manager.caller.Call(ctx, "uci", "rename", req)
+	section, err := oc.sc.resolve(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := RenameRequest{Config: oc.sc.pc.name, Section: section, Option: oc.name, Name: newName}
+	_, err = oc.sc.pc.manager.caller.Call(ctx, "uci", "rename", req)
 
 	return err
 }
@@ -545,6 +786,35 @@ func (m *Manager) getRaw(ctx context.Context, method string, req GetRequest) (*G
 	return ubusData, nil
 }
 
+// UnmarshalJSON decodes a uci.get reply, whose value is an array for list options.
+func (r *GetResponse) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Values map[string]any  `json:"values"`
+		Value  json.RawMessage `json:"value"`
+	}
+
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+
+	*r = GetResponse{Values: raw.Values}
+	if len(raw.Value) == 0 {
+		return nil
+	}
+
+	err = json.Unmarshal(raw.Value, &r.List)
+	if err == nil {
+		r.Value = strings.Join(r.List, " ")
+
+		return nil
+	}
+
+	r.List = nil
+
+	return json.Unmarshal(raw.Value, &r.Value)
+}
+
 func (m *Manager) getAllRaw(ctx context.Context, method string, req GetRequest) (map[string]map[string]any, error) {
 	resp, err := m.getRaw(ctx, method, req)
 	if err != nil {
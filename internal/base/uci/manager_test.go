@@ -5,8 +5,13 @@ package uci_test
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
 	"github.com/honeybbq/goubus/v2/internal/base/uci"
 	"github.com/honeybbq/goubus/v2/internal/testutil"
 )
@@ -23,8 +28,11 @@ func TestUciManager(t *testing.T) {
 	testUciConfigs(t, ctx, mock, mgr)
 	testUciApplyConfirmRollback(t, ctx, mock, mgr)
 	testUciPackageOperations(t, ctx, mock, mgr)
+	testUciGetPackages(t, ctx, mock, mgr)
 	testUciSectionOperations(t, ctx, mock, mgr)
 	testUciOptionOperations(t, ctx, mock, mgr)
+	testUciTransaction(t, ctx, mock, mgr)
+	testUciApplyWithConfirm(t, ctx, mock, mgr)
 }
 
 func testUciConfigs(t *testing.T, ctx context.Context, mock *testutil.MockTransport, mgr *uci.Manager) {
@@ -76,6 +84,150 @@ func testUciPackageOperations(t *testing.T, ctx context.Context, mock *testutil.
 		testUciPackageGetAll(t, ctx, mock, pkg)
 		testUciPackageAdd(t, ctx, mock, pkg)
 		testUciPackageCommitRevert(t, ctx, mock, pkg)
+		testUciPackageMove(t, ctx, mock)
+		testUciPackageQuery(t, ctx, mock)
+		testUciPackageAnonymous(t, ctx, mock)
+	})
+}
+
+func testUciPackageQuery(t *testing.T, ctx context.Context, mock *testutil.MockTransport) {
+	t.Helper()
+	t.Run("Query", func(t *testing.T) {
+		mock.AddResponse("uci", "get", map[string]any{
+			"values": map[string]any{
+				"cfg0c92bd": map[string]any{".type": "rule", ".index": 4, "src": "wan", "proto": "icmp"},
+				"cfg0a92bd": map[string]any{".type": "rule", ".index": 2, "src": "wan", "proto": "udp"},
+			},
+		})
+
+		pkg := uci.New(mock, mockUciDialect{}).Package("firewall")
+		query := pkg.Query("rule").Where("src", "wan")
+
+		names, err := query.Where("proto", "udp").Names(ctx)
+		if err != nil {
+			t.Fatalf("Names failed: %v", err)
+		}
+
+		req, _ := mock.GetLastCall().Data.(uci.GetRequest)
+		if req.Type != "rule" || req.Match["src"] != "wan" || req.Match["proto"] != "udp" {
+			t.Errorf("expected the filter to be sent to rpcd, got %+v", req)
+		}
+
+		if strings.Join(names, ",") != "cfg0a92bd,cfg0c92bd" {
+			t.Errorf("expected configuration order, got %v", names)
+		}
+
+		last, err := pkg.Resolve(ctx, "@rule[-1]")
+		if err != nil || last != "cfg0c92bd" {
+			t.Errorf("unexpected @rule[-1]: %q (%v)", last, err)
+		}
+
+		req, _ = mock.GetLastCall().Data.(uci.GetRequest)
+		if req.Type != "rule" || len(req.Match) != 0 {
+			t.Errorf("expected Resolve to query by type only, got %+v", req)
+		}
+
+		name, err := pkg.Resolve(ctx, "lan")
+		if err != nil || name != "lan" {
+			t.Errorf("expected a plain name to pass through, got %q (%v)", name, err)
+		}
+
+		_, err = pkg.Resolve(ctx, "@rule[2]")
+		if !errdefs.IsNotFound(err) {
+			t.Errorf("expected not found, got %v", err)
+		}
+	})
+}
+
+func testUciPackageAnonymous(t *testing.T, ctx context.Context, mock *testutil.MockTransport) {
+	t.Helper()
+	t.Run("Anonymous", func(t *testing.T) {
+		mock.AddResponse("uci", "get", map[string]any{
+			"values": map[string]any{
+				"cfg0a92bd": map[string]any{".type": "host", ".index": 1, ".anonymous": true, "name": "nas"},
+				"printer":   map[string]any{".type": "host", ".index": 0, ".anonymous": false, "name": "printer"},
+				"cfg0c92bd": map[string]any{".type": "host", ".index": 2, ".anonymous": true, "name": "tv"},
+			},
+		})
+		mock.AddResponse("uci", "delete", map[string]any{})
+
+		pkg := uci.New(mock, mockUciDialect{}).Package("dhcp")
+		isTV := func(s *uci.Section) bool {
+			name, _ := s.Values.First("name")
+
+			return name == "tv"
+		}
+
+		refs, err := pkg.Refs(ctx, "host")
+		if err != nil {
+			t.Fatalf("Refs failed: %v", err)
+		}
+
+		if len(refs) != 3 || refs[1].Ref != "@host[1]" || refs[1].Section.Name != "cfg0a92bd" || refs[0].Anonymous() {
+			t.Errorf("unexpected refs: %+v", refs)
+		}
+
+		ref, err := pkg.FindAnonymous(ctx, "host", isTV)
+		if err != nil || ref.Ref != uci.TypedRef("host", 2) {
+			t.Errorf("unexpected anonymous section: %+v (%v)", ref, err)
+		}
+
+		_, err = pkg.FindAnonymous(ctx, "host", func(s *uci.Section) bool { return s.Name == "printer" })
+		if !errdefs.IsNotFound(err) {
+			t.Errorf("expected not found, got %v", err)
+		}
+
+		err = pkg.Section(ref.Ref).Option("name").Delete(ctx)
+		if err != nil {
+			t.Fatalf("Delete failed: %v", err)
+		}
+
+		req, _ := mock.GetLastCall().Data.(uci.RequestGeneric)
+		if req.Section != "cfg0c92bd" || req.Option != "name" {
+			t.Errorf("expected the reference to be resolved, got %+v", req)
+		}
+	})
+}
+
+func testUciPackageMove(t *testing.T, ctx context.Context, mock *testutil.MockTransport) {
+	t.Helper()
+	t.Run("Move", func(t *testing.T) {
+		mock.AddResponse("uci", "get", map[string]any{
+			"values": map[string]any{
+				"reject_wan": map[string]any{".type": "rule", ".index": 2},
+				"defaults":   map[string]any{".type": "defaults", ".index": 0},
+				"allow_ping": map[string]any{".type": "rule", ".index": 1},
+				"allow_mgmt": map[string]any{".type": "rule", ".index": 3},
+			},
+		})
+		mock.AddResponse("uci", "order", map[string]any{})
+
+		pkg := uci.New(mock, mockUciDialect{}).Package("firewall")
+
+		err := pkg.MoveBefore(ctx, "allow_mgmt", "allow_ping")
+		if err != nil {
+			t.Fatalf("MoveBefore failed: %v", err)
+		}
+
+		req, _ := mock.GetLastCall().Data.(uci.OrderRequest)
+		if strings.Join(req.Sections, ",") != "defaults,allow_mgmt,allow_ping,reject_wan" {
+			t.Errorf("unexpected order %v", req.Sections)
+		}
+
+		err = pkg.MoveAfter(ctx, "defaults", "reject_wan")
+		if err != nil {
+			t.Fatalf("MoveAfter failed: %v", err)
+		}
+
+		req, _ = mock.GetLastCall().Data.(uci.OrderRequest)
+		if strings.Join(req.Sections, ",") != "allow_ping,reject_wan,defaults,allow_mgmt" {
+			t.Errorf("unexpected order %v", req.Sections)
+		}
+
+		err = pkg.MoveAfter(ctx, "missing", "reject_wan")
+		if !errdefs.IsNotFound(err) {
+			t.Errorf("expected not found, got %v", err)
+		}
 	})
 }
 
@@ -97,6 +249,64 @@ func testUciPackageGetAll(t *testing.T, ctx context.Context, mock *testutil.Mock
 			t.Errorf("unexpected sections: %v", sections)
 		}
 	})
+
+	t.Run("GetAllLazy", func(t *testing.T) {
+		mock.AddResponse("uci", "get", map[string]any{
+			"values": map[string]any{
+				"s2": map[string]any{".type": "t2", "list1": []any{"a", "b"}},
+				"s1": map[string]any{".type": "t1", "opt1": "v1"},
+			},
+		})
+
+		sections, err := pkg.GetAllLazy(ctx)
+		if err != nil {
+			t.Fatalf("GetAllLazy failed: %v", err)
+		}
+
+		if names := sections.Names(); len(names) != 2 || names[0] != "s1" {
+			t.Fatalf("unexpected section names: %v", names)
+		}
+
+		section, err := sections.Get("s2")
+		if err != nil || section.Type != "t2" {
+			t.Fatalf("unexpected section: %+v (%v)", section, err)
+		}
+
+		if list := section.Values.Get("list1"); len(list) != 2 {
+			t.Errorf("unexpected list: %v", list)
+		}
+
+		_, err = sections.Get("missing")
+		if !errdefs.IsNotFound(err) {
+			t.Errorf("expected not found, got %v", err)
+		}
+	})
+}
+
+func testUciGetPackages(t *testing.T, ctx context.Context, mock *testutil.MockTransport, mgr *uci.Manager) {
+	t.Helper()
+	t.Run("GetPackages", func(t *testing.T) {
+		mock.AddResponse("uci", "get", map[string]any{
+			"values": map[string]any{
+				"s1": map[string]any{".type": "t1", "opt1": "v1"},
+			},
+		})
+
+		calls := len(mock.Calls)
+
+		packages, err := mgr.GetPackages(ctx, "network", "wireless", "network")
+		if err != nil {
+			t.Fatalf("GetPackages failed: %v", err)
+		}
+
+		if len(packages) != 2 || packages["wireless"]["s1"].Type != "t1" || packages["network"]["s1"] == nil {
+			t.Errorf("unexpected packages: %v", packages)
+		}
+
+		if got := len(mock.Calls) - calls; got != 2 {
+			t.Errorf("expected 2 calls, got %d", got)
+		}
+	})
 }
 
 func testUciPackageAdd(t *testing.T, ctx context.Context, mock *testutil.MockTransport, pkg *uci.PackageContext) {
@@ -255,6 +465,18 @@ func testUciOptionAddToList(t *testing.T, ctx context.Context, mock *testutil.Mo
 		if len(list) != 3 || list[2] != "item3" {
 			t.Errorf("unexpected list: %v", list)
 		}
+
+		mock.AddResponse("uci", "get", map[string]any{"value": []any{"6,10.0.0.1 10.0.0.2", "item3"}})
+
+		err = opt.AddToList(ctx, "item3")
+		if err != nil || mock.GetLastCall().Method == methodSet {
+			t.Errorf("expected an existing entry to be skipped, got %v", err)
+		}
+
+		entries, err := opt.List(ctx)
+		if err != nil || len(entries) != 2 || entries[0] != "6,10.0.0.1 10.0.0.2" {
+			t.Errorf("expected entries with spaces to survive, got %q (%v)", entries, err)
+		}
 	})
 }
 
@@ -310,3 +532,639 @@ func readStringList(t *testing.T, value any) []string {
 
 	return list
 }
+
+func TestPreserveBoolStyles(t *testing.T) {
+	original := &uci.Section{Name: "main", Values: uci.NewSectionValues()}
+	original.Values.Set("enabled", "on")
+	original.Values.Set("disabled", "false")
+	original.Values.Set("port", "1")
+
+	values := uci.NewSectionValues()
+	values.SetBool("enabled", false)
+	values.SetBool("disabled", true)
+	values.SetBool("new", true)
+	values.Set("port", "0")
+
+	values.PreserveBoolStyles(original)
+
+	for option, want := range map[string]string{"enabled": "off", "disabled": "true", "new": "1", "port": "0"} {
+		if got, _ := values.First(option); got != want {
+			t.Errorf("option %s = %q, want %q", option, got, want)
+		}
+	}
+
+	if !original.GetBool("enabled") || original.GetBool("disabled") {
+		t.Errorf("unexpected GetBool results")
+	}
+}
+
+func testUciTransaction(t *testing.T, ctx context.Context, mock *testutil.MockTransport, mgr *uci.Manager) {
+	t.Helper()
+	t.Run("Transaction", func(t *testing.T) {
+		for _, method := range []string{methodSet, "add", "delete", "commit", "revert", "apply", "confirm"} {
+			mock.AddResponse("uci", method, map[string]any{})
+		}
+
+		mock.AddResponse("uci", "configs", map[string]any{"configs": []string{"network"}})
+		mock.AddResponse("uci", "changes", map[string]any{"changes": map[string]any{
+			"network":  [][]string{{"set", "lan", "ipaddr", "192.168.2.1"}, {"add", "cfg0a1b2c", "route"}},
+			"firewall": [][]string{{"remove", "wan"}},
+			"system":   [][]string{{"set", "system", "hostname", "other"}},
+		}})
+
+		values := uci.NewSectionValues()
+		values.Set("ipaddr", "192.168.2.1")
+
+		tx := mgr.Transaction().
+			Set("network", "lan", values).
+			Add("network", "route", "", uci.NewSectionValues()).
+			Delete("firewall", "wan").
+			Delete("network", "lan", "dns", "gateway")
+
+		mock.Calls = nil
+
+		changes, err := tx.Changes(ctx)
+		if err != nil {
+			t.Fatalf("Changes failed: %v", err)
+		}
+
+		if len(mock.Calls) != 6 || mock.Calls[4].Method != "delete" {
+			t.Errorf("expected 5 staged operations and a changes call, got %+v", mock.Calls)
+		}
+
+		got := changes.String()
+		if len(changes) != 2 || !strings.Contains(got, "-firewall.wan") ||
+			!strings.Contains(got, "+network.cfg0a1b2c='route'") {
+			t.Errorf("expected the network and firewall changes, got:\n%s", got)
+		}
+
+		mock.Calls = nil
+
+		err = tx.Apply(ctx, true, 30*time.Millisecond)
+		if err != nil {
+			t.Fatalf("Apply failed: %v", err)
+		}
+
+		methods := make([]string, 0, len(mock.Calls))
+		for _, call := range mock.Calls {
+			methods = append(methods, call.Method)
+		}
+
+		if strings.Join(methods, ",") != "apply,configs,confirm" || len(tx.Operations()) != 0 {
+			t.Errorf("expected apply, check and confirm, got %v", methods)
+		}
+
+		mock.AddResponse("uci", "configs", errdefs.ErrTimeout)
+
+		err = mgr.Transaction().Set("network", "lan", values).Apply(ctx, true, 30*time.Millisecond)
+		if !errdefs.IsTimeout(err) || mock.GetLastCall().Method != "configs" {
+			t.Errorf("expected an unconfirmed apply, got %v", err)
+		}
+
+		mock.Calls = nil
+
+		err = mgr.Transaction().Set("network", "lan", values).Set("dhcp", "lan", values).Commit(ctx)
+		if err != nil || len(mock.Calls) != 4 || mock.Calls[3].Method != "commit" {
+			t.Errorf("expected two sets and two commits, got %v (%+v)", err, mock.Calls)
+		}
+	})
+	t.Run("Changes_Package", func(t *testing.T) {
+		mock.AddResponse("uci", "changes", map[string]any{
+			"changes": [][]string{{"list-add", "lan", "dns", "1.1.1.1"}, {"remove", "lan", "gateway"}},
+		})
+
+		res, err := mgr.Package("network").Changes(ctx)
+		if err != nil {
+			t.Fatalf("Changes failed: %v", err)
+		}
+
+		if got := res.Changes.String(); got != "network.lan.dns+='1.1.1.1'\n-network.lan.gateway" {
+			t.Errorf("unexpected changes %q", got)
+		}
+	})
+}
+
+func TestParseChanges(t *testing.T) {
+	output := "network.lan.ipaddr='192.168.2.1'\n" +
+		"+network.cfg0a1b2c='route'\n" +
+		"network.lan.dns+='1.1.1.1'\n" +
+		"network.lan.dns-='8.8.8.8'\n" +
+		"-network.wan\n" +
+		"-network.lan.gateway\n" +
+		"@network.guest='iot'\n" +
+		"^network.lan='2'\n" +
+		"system.@system[0].hostname='it'\\''s'\n"
+
+	changes, err := uci.ParseChanges(output)
+	if err != nil {
+		t.Fatalf("ParseChanges failed: %v", err)
+	}
+
+	network := changes["network"]
+	if len(network) != 8 || network[2].Kind != uci.ChangeListAdd || network[5].Option != "gateway" ||
+		network[7].Kind != uci.ChangeOrder || network[7].Value != "2" {
+		t.Errorf("unexpected network changes: %+v", network)
+	}
+
+	if got := changes["system"][0]; got.Section != "@system[0]" || got.Value != "it's" {
+		t.Errorf("unexpected system change: %+v", got)
+	}
+
+	if changes.String()+"\n" != output {
+		t.Errorf("expected String to reproduce the output, got:\n%s", changes.String())
+	}
+
+	for _, invalid := range []string{"network", "-network.lan=x", "network.lan.a.b='x'"} {
+		_, err = uci.ParseChanges(invalid)
+		if !errdefs.IsInvalidParameter(err) {
+			t.Errorf("expected %q to be rejected, got %v", invalid, err)
+		}
+	}
+}
+
+func TestConfigFormat(t *testing.T) {
+	input := `package firewall
+
+# defaults
+config defaults
+	option input 'ACCEPT'
+
+config rule 'allow_ping'
+	option name "Allow-\"Ping\""
+	option family ''
+	list proto icmp
+	list proto 'it'\''s' # trailing comment
+`
+
+	pkg, sections, err := uci.ParseConfig(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseConfig failed: %v", err)
+	}
+
+	if pkg != "firewall" || len(sections) != 2 || !bool(sections[0].Metadata.Anonymous) {
+		t.Fatalf("unexpected package %q or sections %+v", pkg, sections)
+	}
+
+	rule := sections[1]
+	if name, _ := rule.Values.First("name"); rule.Name != "allow_ping" || name != `Allow-"Ping"` {
+		t.Errorf("unexpected rule: %+v", rule)
+	}
+
+	if proto := rule.Values.Get("proto"); len(proto) != 2 || proto[1] != "it's" {
+		t.Errorf("unexpected list: %v", proto)
+	}
+
+	var out strings.Builder
+
+	err = uci.WriteConfig(&out, pkg, sections)
+	if err != nil {
+		t.Fatalf("WriteConfig failed: %v", err)
+	}
+
+	want := "package firewall\n\nconfig defaults\n\toption input 'ACCEPT'\n\n" +
+		"config rule 'allow_ping'\n\toption family ''\n\toption name 'Allow-\"Ping\"'\n" +
+		"\tlist proto 'icmp'\n\tlist proto 'it'\\''s'\n"
+	if out.String() != want {
+		t.Errorf("unexpected output:\n%s", out.String())
+	}
+
+	for _, invalid := range []string{"option name 'x'", "config rule 'a", "config", "rule x y"} {
+		_, _, err = uci.ParseConfig(strings.NewReader(invalid))
+		if !errdefs.IsInvalidParameter(err) {
+			t.Errorf("expected %q to be rejected, got %v", invalid, err)
+		}
+	}
+}
+
+func parseSections(t *testing.T, config string) map[string]*uci.Section {
+	t.Helper()
+
+	_, sections, err := uci.ParseConfig(strings.NewReader(config))
+	if err != nil {
+		t.Fatalf("ParseConfig failed: %v", err)
+	}
+
+	return uci.SectionsByRef(sections)
+}
+
+func TestDiff(t *testing.T) {
+	golden := parseSections(t, `
+config host
+	option name 'nas'
+	list dns '1.1.1.1'
+config zone 'lan'
+	option input 'ACCEPT'
+config zone 'guest'
+`)
+	device := parseSections(t, `
+config host
+	option name 'nas'
+	list dns '1.1.1.1'
+	list dns '8.8.8.8'
+config rule 'lan'
+config zone 'wan'
+	option input 'REJECT'
+`)
+
+	diffs := uci.Diff(golden, device)
+
+	var got []string
+	for _, diff := range diffs {
+		got = append(got, string(diff.Kind)+" "+diff.Section)
+	}
+
+	want := "changed @host[0],removed lan,removed guest,added lan,added wan"
+	if strings.Join(got, ",") != want {
+		t.Fatalf("unexpected diff %v", got)
+	}
+
+	if dns := diffs[0].Options; len(dns) != 1 || dns[0].Kind != uci.DiffChanged || len(dns[0].New) != 2 {
+		t.Errorf("unexpected option diff %+v", dns)
+	}
+
+	if wan := diffs[4]; wan.Type != "zone" || len(wan.Options) != 1 || wan.Options[0].New[0] != "REJECT" {
+		t.Errorf("unexpected added section %+v", wan)
+	}
+
+	if len(uci.Diff(device, device)) != 0 {
+		t.Error("expected no differences between equal packages")
+	}
+}
+
+func TestDiffAgainst(t *testing.T) {
+	ctx := context.Background()
+	local, remote := testutil.NewMockTransport(), testutil.NewMockTransport()
+	local.AddResponse("uci", "get", map[string]any{
+		"values": map[string]any{"cfg01": map[string]any{".type": "system", ".anonymous": true, "hostname": "a"}},
+	})
+	remote.AddResponse("uci", "get", map[string]any{
+		"values": map[string]any{"cfg7f": map[string]any{".type": "system", ".anonymous": true, "hostname": "b"}},
+	})
+
+	diffs, err := uci.New(local, mockUciDialect{}).DiffAgainst(ctx, uci.New(remote, mockUciDialect{}), "system")
+	if err != nil {
+		t.Fatalf("DiffAgainst failed: %v", err)
+	}
+
+	system := diffs["system"]
+	if len(system) != 1 || system[0].Section != "@system[0]" || system[0].Options[0].New[0] != "b" {
+		t.Errorf("unexpected diff %+v", diffs)
+	}
+}
+
+type zoneModel struct {
+	input string
+	mtu   string
+}
+
+func (z zoneModel) SectionValues() uci.SectionValues {
+	values := uci.NewSectionValues()
+	values.Set("input", z.input)
+	values.Set("mtu_fix", z.mtu)
+
+	return values
+}
+
+func TestSchema(t *testing.T) {
+	ctx := context.Background()
+	mock := testutil.NewMockTransport()
+	mock.AddResponse("uci", "get", map[string]any{"values": map[string]any{".type": "zone", "name": "lan"}})
+	mock.AddResponse("uci", methodSet, map[string]any{})
+
+	mgr := uci.New(mock, mockUciDialect{})
+	mgr.SetSchema("firewall", "zone", uci.Schema{
+		"input":   {Enum: []string{"ACCEPT", "REJECT", "DROP"}},
+		"mtu_fix": {Bool: true},
+		"mtu":     {Integer: true, Min: 576, Max: 9000},
+	})
+
+	err := mgr.Validate("firewall", "zone", zoneModel{input: "allow", mtu: "maybe"})
+
+	var invalid *uci.ValidationError
+	if !errors.As(err, &invalid) || len(invalid.Violations) != 2 || !errdefs.IsInvalidParameter(err) {
+		t.Fatalf("expected both violations, got %v", err)
+	}
+
+	values := uci.NewSectionValues()
+	values.Set("mtu", "100000")
+
+	err = mgr.Package("firewall").Section("lan").SetValues(ctx, values)
+	if !errdefs.IsInvalidParameter(err) || mock.GetLastCall().Method == methodSet {
+		t.Errorf("expected the write to be rejected client-side, got %v", err)
+	}
+
+	err = mgr.Package("firewall").Add(ctx, "zone", "wan", values)
+	if !errdefs.IsInvalidParameter(err) {
+		t.Errorf("expected the add to be rejected, got %v", err)
+	}
+
+	err = mgr.Package("firewall").Section("lan").Option("mtu").Set(ctx, "1500")
+	if err != nil || mock.GetLastCall().Method != methodSet {
+		t.Errorf("expected a valid write to be sent, got %v", err)
+	}
+
+	mgr.SetSchema("firewall", "zone", nil)
+
+	err = mgr.Validate("firewall", "zone", zoneModel{input: "allow"})
+	if err != nil {
+		t.Errorf("expected no validation without a schema, got %v", err)
+	}
+}
+
+type poolConfig struct {
+	Extra     map[string][]string `uci:",extra"`
+	Name      string              `uci:".name"`
+	Interface string              `uci:"interface"`
+	DHCPv6    string              `uci:"dhcpv6"`
+	Options   []string            `uci:"dhcp_option"`
+	Start     int                 `uci:"start"`
+	Limit     uint16              `uci:"limit"`
+	Ignore    bool                `uci:"ignore"`
+	Anonymous bool                `uci:".anonymous"`
+	Skipped   string
+}
+
+func TestSectionAs(t *testing.T) {
+	ctx := context.Background()
+	mock := testutil.NewMockTransport()
+	mock.AddResponse("uci", "get", map[string]any{
+		"values": map[string]any{
+			"guest": map[string]any{
+				".type": "dhcp", ".index": 1, "interface": "guest", "start": "100", "limit": "50",
+				"ignore": "off", "dhcp_option": []any{"3,10.0.0.1", "6,10.0.0.1"}, "leasetime": "1h",
+			},
+			"lan": map[string]any{".type": "dhcp", ".index": 0, "interface": "lan", "ignore": "1"},
+		},
+	})
+
+	var pools []poolConfig
+
+	err := uci.New(mock, mockUciDialect{}).Package("dhcp").GetAllAs(ctx, "dhcp", &pools)
+	if err != nil {
+		t.Fatalf("GetAllAs failed: %v", err)
+	}
+
+	if len(pools) != 2 || pools[0].Name != "lan" || !pools[0].Ignore || pools[0].Extra == nil {
+		t.Fatalf("unexpected pools: %+v", pools)
+	}
+
+	guest := pools[1]
+	if guest.Start != 100 || guest.Limit != 50 || guest.Ignore || len(guest.Options) != 2 || guest.Anonymous {
+		t.Errorf("unexpected pool: %+v", guest)
+	}
+
+	if len(guest.Extra) != 1 || guest.Extra["leasetime"][0] != "1h" {
+		t.Errorf("expected unknown options in Extra, got %v", guest.Extra)
+	}
+
+	values, err := uci.SectionValuesFromStruct(&guest)
+	if err != nil {
+		t.Fatalf("SectionValuesFromStruct failed: %v", err)
+	}
+
+	single := uci.SectionValuesFromAny(map[string]any{"dhcp_option": []any{"3,10.0.0.1"}})
+	encoded, _ := values.MarshalJSON()
+	if !strings.Contains(string(encoded), `"dhcp_option":["3,10.0.0.1","6,10.0.0.1"]`) ||
+		!strings.Contains(string(encoded), `"leasetime":"1h"`) || strings.Contains(string(encoded), "anonymous") {
+		t.Errorf("unexpected encoding %s", encoded)
+	}
+
+	guest.Options = single.Get("dhcp_option")
+	values, _ = uci.SectionValuesFromStruct(guest)
+
+	encoded, _ = values.MarshalJSON()
+	if !strings.Contains(string(encoded), `"dhcp_option":["3,10.0.0.1"]`) {
+		t.Errorf("expected a single entry to stay a list, got %s", encoded)
+	}
+
+	var pointers []*poolConfig
+
+	err = uci.New(mock, mockUciDialect{}).Package("dhcp").GetAllAs(ctx, "dhcp", &pointers)
+	if err != nil || len(pointers) != 2 || pointers[1].Interface != "guest" {
+		t.Errorf("unexpected pointers: %v (%v)", pointers, err)
+	}
+
+	section := &uci.Section{Values: uci.NewSectionValues()}
+	section.Values.Set("start", "many")
+
+	err = section.As(&poolConfig{})
+	if !errdefs.IsInvalidResponse(err) {
+		t.Errorf("expected an invalid value to be rejected, got %v", err)
+	}
+
+	err = section.As(poolConfig{})
+	if !errdefs.IsInvalidParameter(err) {
+		t.Errorf("expected a non-pointer target to be rejected, got %v", err)
+	}
+}
+
+func TestTransactionLists(t *testing.T) {
+	ctx := context.Background()
+	mock := testutil.NewMockTransport()
+	mock.AddResponse("uci", "get", map[string]any{"value": []any{"lan", "guest net"}})
+	mock.AddResponse("uci", methodSet, map[string]any{})
+
+	tx := uci.New(mock, mockUciDialect{}).Transaction().
+		AddList("firewall", "zone1", "network", "lan", "iot").
+		DelList("firewall", "zone1", "network", "guest net")
+
+	err := tx.Stage(ctx)
+	if err != nil {
+		t.Fatalf("Stage failed: %v", err)
+	}
+
+	var sets [][]string
+
+	for _, call := range mock.Calls {
+		req, ok := call.Data.(uci.Request)
+		if ok {
+			sets = append(sets, readStringList(t, req.Values["network"]))
+		}
+	}
+
+	if len(sets) != 2 || strings.Join(sets[0], ",") != "lan,guest net,iot" || strings.Join(sets[1], ",") != "lan" {
+		t.Errorf("unexpected list writes %q", sets)
+	}
+
+	if ops := tx.Operations(); len(ops) != 2 || ops[1].Kind != uci.OperationDelList {
+		t.Errorf("unexpected operations %+v", ops)
+	}
+}
+
+type leaseConfig struct {
+	LeaseTime *string `uci:"leasetime" default:"12h"`
+	Start     *int    `uci:"start"     default:"100"`
+	Force     *bool   `uci:"force"     default:"0"`
+	Name      string  `uci:".name"`
+}
+
+func TestDefaults(t *testing.T) {
+	section := &uci.Section{Name: "lan", Values: uci.NewSectionValues()}
+	section.Values.Set("leasetime", "12h")
+
+	var cfg leaseConfig
+
+	err := section.As(&cfg)
+	if err != nil || cfg.LeaseTime == nil || cfg.Start != nil {
+		t.Fatalf("expected only the set option to be decoded, got %+v (%v)", cfg, err)
+	}
+
+	effective, err := uci.EffectiveValues(cfg)
+	if err != nil {
+		t.Fatalf("EffectiveValues failed: %v", err)
+	}
+
+	if got := effective["leasetime"]; !got.Explicit || got.String() != "12h" {
+		t.Errorf("expected an explicit lease time, got %v", got)
+	}
+
+	if got := effective["start"]; got.Explicit || got.String() != "unset (default 100)" {
+		t.Errorf("expected a default start, got %v", got)
+	}
+
+	if start := uci.EffectiveValue(cfg.Start, 100); start.Explicit || start.Value != 100 {
+		t.Errorf("unexpected effective value %+v", start)
+	}
+
+	err = uci.ApplyDefaults(&cfg)
+	if err != nil || *cfg.Start != 100 || *cfg.Force || *cfg.LeaseTime != "12h" {
+		t.Errorf("unexpected defaults %+v (%v)", cfg, err)
+	}
+
+	values, _ := uci.SectionValuesFromStruct(cfg)
+	if start, _ := values.First("start"); start != "100" || values.Len() != 3 {
+		t.Errorf("unexpected encoding %v", values.All())
+	}
+}
+
+func waitForChange(t *testing.T, sub *goubus.Subscription) uci.PackageChange {
+	t.Helper()
+
+	select {
+	case ev := <-sub.Events():
+		change, ok := uci.ParsePackageChange(ev)
+		if !ok {
+			t.Fatalf("unexpected event %+v", ev)
+		}
+
+		return change
+	case <-time.After(time.Second):
+		t.Fatalf("no change delivered: %v", sub.Err())
+	}
+
+	return uci.PackageChange{}
+}
+
+func TestWatch(t *testing.T) {
+	ctx := context.Background()
+	mock := testutil.NewMockTransport()
+	mock.AddResponse("uci", "get", map[string]any{
+		"values": map[string]any{"lan": map[string]any{".type": "interface", "proto": "static"}},
+	})
+
+	sub, err := uci.New(mock, mockUciDialect{}).Watch(ctx, "network", uci.WithWatchInterval(time.Hour))
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	defer sub.Close()
+
+	mock.AddResponse("uci", "get", map[string]any{
+		"values": map[string]any{"lan": map[string]any{".type": "interface", "proto": "dhcp"}},
+	})
+	mock.EmitEvent("config.change", map[string]any{"config": "wireless"})
+	mock.EmitEvent("config.change", map[string]any{"config": "network"})
+
+	change := waitForChange(t, sub)
+	if change.Package != "network" || len(change.Diffs) != 1 || change.Diffs[0].Options[0].New[0] != "dhcp" {
+		t.Errorf("unexpected change %+v", change)
+	}
+}
+
+func TestWatch_Polling(t *testing.T) {
+	ctx := context.Background()
+	mock := testutil.NewMockTransport()
+	mock.AddResponse("uci", "get", map[string]any{"values": map[string]any{}})
+
+	sub, err := uci.New(mock, mockUciDialect{}).Watch(ctx, "system", uci.WithWatchInterval(time.Millisecond))
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	defer sub.Close()
+
+	mock.AddResponse("uci", "get", map[string]any{
+		"values": map[string]any{"cfg01": map[string]any{".type": "system", ".anonymous": true, "hostname": "x"}},
+	})
+
+	change := waitForChange(t, sub)
+	if len(change.Diffs) != 1 || change.Diffs[0].Kind != uci.DiffAdded || change.Diffs[0].Section != "@system[0]" {
+		t.Errorf("unexpected change %+v", change)
+	}
+}
+
+func TestImport(t *testing.T) {
+	ctx := context.Background()
+	mock := testutil.NewMockTransport()
+	mock.AddResponse("uci", "get", map[string]any{
+		"values": map[string]any{
+			"wan": map[string]any{".type": "interface", ".index": 1},
+			"lan": map[string]any{".type": "interface", ".index": 0},
+		},
+	})
+	mock.AddResponse("uci", "delete", map[string]any{})
+	mock.AddResponse("uci", "add", map[string]any{})
+
+	mgr := uci.New(mock, mockUciDialect{})
+
+	err := mgr.Import(ctx, "network", strings.NewReader("config interface 'lan'\n\toption proto 'static'\n"))
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	var calls []string
+	for _, call := range mock.Calls[1:] {
+		calls = append(calls, call.Method)
+	}
+
+	if strings.Join(calls, ",") != "delete,delete,add" {
+		t.Errorf("unexpected calls %v", calls)
+	}
+
+	err = mgr.Import(ctx, "network", strings.NewReader("package firewall\n"))
+	if !errdefs.IsInvalidParameter(err) {
+		t.Errorf("expected another package to be rejected, got %v", err)
+	}
+}
+
+func testUciApplyWithConfirm(t *testing.T, ctx context.Context, mock *testutil.MockTransport, mgr *uci.Manager) {
+	t.Helper()
+	t.Run("ApplyWithConfirm", func(t *testing.T) {
+		mock.AddResponse("uci", "apply", map[string]any{})
+		mock.AddResponse("uci", "confirm", map[string]any{})
+
+		probes := 0
+		mock.Calls = nil
+
+		err := mgr.ApplyWithConfirm(ctx, 15*time.Millisecond, func(context.Context) error {
+			probes++
+
+			return nil
+		})
+		if err != nil || probes != 1 {
+			t.Fatalf("ApplyWithConfirm failed: %v (%d probes)", err, probes)
+		}
+
+		apply, _ := mock.Calls[0].Data.(uci.ApplyRequest)
+		if !bool(apply.Rollback) || apply.Timeout != 1 || mock.GetLastCall().Method != "confirm" {
+			t.Errorf("expected a rollback apply of 1s and a confirm, got %+v", mock.Calls)
+		}
+
+		mock.Calls = nil
+
+		unreachable := func(context.Context) error { return errdefs.ErrConnectionFailed }
+
+		err = mgr.ApplyWithConfirm(ctx, time.Millisecond, unreachable)
+		if !errdefs.IsConnectionFailed(err) || mock.GetLastCall().Method != "apply" {
+			t.Errorf("expected the changes to be left to roll back, got %v (%+v)", err, mock.Calls)
+		}
+	})
+}
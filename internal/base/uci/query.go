@@ -0,0 +1,187 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package uci
+
+import (
+	"context"
+	"maps"
+	"strconv"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+// SectionQuery selects sections of a package by type and option values. The filtering happens
+// in rpcd, so only the matching sections are transferred.
+type SectionQuery struct {
+	pc          *PackageContext
+	match       map[string]string
+	sectionType string
+}
+
+// Query starts a query for the sections of sectionType; an empty type selects every section.
+func (pc *PackageContext) Query(sectionType string) *SectionQuery {
+	return &SectionQuery{pc: pc, sectionType: sectionType}
+}
+
+// Where restricts the query to sections whose option has value; a list option matches if any
+// of its values does. Conditions on several options must all hold.
+func (q *SectionQuery) Where(option, value string) *SectionQuery {
+	match := maps.Clone(q.match)
+	if match == nil {
+		match = make(map[string]string)
+	}
+
+	match[option] = value
+
+	return &SectionQuery{pc: q.pc, sectionType: q.sectionType, match: match}
+}
+
+// All returns the matching sections in configuration order.
+func (q *SectionQuery) All(ctx context.Context) ([]*Section, error) {
+	req := GetRequest{
+		RequestGeneric: RequestGeneric{Config: q.pc.name, Type: q.sectionType, Match: q.match},
+	}
+
+	raw, err := q.pc.manager.getAllRaw(ctx, "get", req)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := make(map[string]*Section, len(raw))
+	for name, data := range raw {
+		sections[name] = newSectionFromRaw(name, data)
+	}
+
+	return SortedSections(sections), nil
+}
+
+// Names returns the names of the matching sections in configuration order.
+func (q *SectionQuery) Names(ctx context.Context) ([]string, error) {
+	sections, err := q.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(sections))
+	for i, section := range sections {
+		names[i] = section.Name
+	}
+
+	return names, nil
+}
+
+// First returns the first matching section, or an error matching errdefs.ErrNotFound.
+func (q *SectionQuery) First(ctx context.Context) (*Section, error) {
+	return q.Index(ctx, 0)
+}
+
+// Index returns the matching section at index like "@type[index]" addresses it; negative indexes
+// count from the end.
+func (q *SectionQuery) Index(ctx context.Context, index int) (*Section, error) {
+	sections, err := q.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if index < 0 {
+		index += len(sections)
+	}
+
+	if index < 0 || index >= len(sections) {
+		return nil, errdefs.Wrapf(errdefs.ErrNotFound, "no %s section %d in %s", q.sectionType, index, q.pc.name)
+	}
+
+	return sections[index], nil
+}
+
+// Resolve returns the name of the section ref refers to. A reference of the form "@type[index]",
+// such as "@rule[0]" or "@zone[-1]", is looked up among the sections of that type; any other
+// reference is a section name and returned unchanged.
+func (pc *PackageContext) Resolve(ctx context.Context, ref string) (string, error) {
+	sectionType, index, ok := parseSectionRef(ref)
+	if !ok {
+		return ref, nil
+	}
+
+	section, err := pc.Query(sectionType).Index(ctx, index)
+	if err != nil {
+		return "", errdefs.Wrapf(err, "resolve %s.%s", pc.name, ref)
+	}
+
+	return section.Name, nil
+}
+
+// parseSectionRef splits an "@type[index]" reference.
+func parseSectionRef(ref string) (string, int, bool) {
+	rest, ok := strings.CutPrefix(ref, "@")
+	if !ok {
+		return "", 0, false
+	}
+
+	sectionType, indexPart, ok := strings.Cut(rest, "[")
+	if !ok || sectionType == "" {
+		return "", 0, false
+	}
+
+	index, err := strconv.Atoi(strings.TrimSuffix(indexPart, "]"))
+	if err != nil || !strings.HasSuffix(indexPart, "]") {
+		return "", 0, false
+	}
+
+	return sectionType, index, true
+}
+
+// SectionRef is a section together with its "@type[index]" reference, which addresses anonymous
+// sections by position.
+type SectionRef struct {
+	Section *Section
+	Ref     string
+}
+
+// Anonymous reports whether the section has no name of its own.
+func (r SectionRef) Anonymous() bool {
+	return bool(r.Section.Metadata.Anonymous)
+}
+
+// TypedRef returns the "@type[index]" reference of the section of sectionType at index.
+func TypedRef(sectionType string, index int) string {
+	return "@" + sectionType + "[" + strconv.Itoa(index) + "]"
+}
+
+// Refs returns the sections of sectionType in configuration order with their references. The
+// references stay valid until sections of that type are added, deleted or reordered.
+func (pc *PackageContext) Refs(ctx context.Context, sectionType string) ([]SectionRef, error) {
+	sections, err := pc.Query(sectionType).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]SectionRef, len(sections))
+	for i, section := range sections {
+		refs[i] = SectionRef{Section: section, Ref: TypedRef(sectionType, i)}
+	}
+
+	return refs, nil
+}
+
+// FindAnonymous returns the first anonymous section of sectionType that match accepts, or an
+// error matching errdefs.ErrNotFound. A nil match accepts any anonymous section.
+func (pc *PackageContext) FindAnonymous(
+	ctx context.Context, sectionType string, match func(*Section) bool,
+) (SectionRef, error) {
+	refs, err := pc.Refs(ctx, sectionType)
+	if err != nil {
+		return SectionRef{}, err
+	}
+
+	for _, ref := range refs {
+		if ref.Anonymous() && (match == nil || match(ref.Section)) {
+			return ref, nil
+		}
+	}
+
+	return SectionRef{}, errdefs.Wrapf(errdefs.ErrNotFound, "no matching anonymous %s section in %s",
+		sectionType, pc.name)
+}
@@ -0,0 +1,252 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package uci
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+// OperationKind is the kind of an operation queued in a Transaction.
+type OperationKind string
+
+// Kinds of transaction operations.
+const (
+	OperationSet     OperationKind = "set"
+	OperationAdd     OperationKind = "add"
+	OperationDelete  OperationKind = "delete"
+	OperationAddList OperationKind = "add_list"
+	OperationDelList OperationKind = "del_list"
+)
+
+// Operation is a UCI change queued in a Transaction.
+type Operation struct {
+	Values  SectionValues
+	Kind    OperationKind
+	Package string
+	Section string
+	// Type is the section type of an add.
+	Type string
+	// Option is the option a delete or list operation changes; it is empty when the whole
+	// section is deleted.
+	Option string
+	// List holds the entries a list operation adds or removes.
+	List []string
+}
+
+// Transaction collects UCI changes across packages and stages, previews, commits, reverts or
+// applies them together. Operations are queued locally and staged on the device by Stage, which
+// Changes, Commit and Apply call first. A Transaction is not safe for concurrent use.
+type Transaction struct {
+	manager  *Manager
+	pending  []Operation
+	staged   []Operation
+	packages []string
+}
+
+// Transaction starts an empty transaction.
+func (m *Manager) Transaction() *Transaction {
+	return &Transaction{manager: m}
+}
+
+// Set queues setting values on a section.
+func (tx *Transaction) Set(pkg, section string, values SectionValues) *Transaction {
+	return tx.queue(Operation{Kind: OperationSet, Package: pkg, Section: section, Values: values})
+}
+
+// Add queues adding a section of sectionType; an empty name adds an anonymous section.
+func (tx *Transaction) Add(pkg, sectionType, name string, values SectionValues) *Transaction {
+	return tx.queue(Operation{Kind: OperationAdd, Package: pkg, Section: name, Type: sectionType, Values: values})
+}
+
+// Delete queues deleting options of a section, or the whole section without options.
+func (tx *Transaction) Delete(pkg, section string, options ...string) *Transaction {
+	if len(options) == 0 {
+		return tx.queue(Operation{Kind: OperationDelete, Package: pkg, Section: section})
+	}
+
+	for _, option := range options {
+		tx.queue(Operation{Kind: OperationDelete, Package: pkg, Section: section, Option: option})
+	}
+
+	return tx
+}
+
+// AddList queues appending entries to a list option, like "uci add_list". Entries the list
+// already contains are not added again.
+func (tx *Transaction) AddList(pkg, section, option string, entries ...string) *Transaction {
+	return tx.queue(Operation{Kind: OperationAddList, Package: pkg, Section: section, Option: option, List: entries})
+}
+
+// DelList queues removing entries from a list option, like "uci del_list".
+func (tx *Transaction) DelList(pkg, section, option string, entries ...string) *Transaction {
+	return tx.queue(Operation{Kind: OperationDelList, Package: pkg, Section: section, Option: option, List: entries})
+}
+
+// Operations returns the staged and pending operations in order.
+func (tx *Transaction) Operations() []Operation {
+	return slices.Concat(tx.staged, tx.pending)
+}
+
+// Staged returns the operations Stage sent to the device, in order.
+func (tx *Transaction) Staged() []Operation {
+	return slices.Clone(tx.staged)
+}
+
+// Packages returns the packages the transaction touches.
+func (tx *Transaction) Packages() []string {
+	return slices.Clone(tx.packages)
+}
+
+// Stage sends the pending operations to the device, where they are staged in the session until
+// committed or reverted. It stops at the first failure; the failed and later operations stay
+// pending.
+func (tx *Transaction) Stage(ctx context.Context) error {
+	for len(tx.pending) > 0 {
+		op := tx.pending[0]
+
+		err := tx.manager.stage(ctx, op)
+		if err != nil {
+			return errdefs.Wrapf(err, "failed to stage %s of %s.%s", op.Kind, op.Package, op.Section)
+		}
+
+		tx.staged = append(tx.staged, op)
+		tx.pending = tx.pending[1:]
+	}
+
+	return nil
+}
+
+// Changes stages the pending operations and returns the staged changes of the packages the
+// transaction touches, including changes staged earlier in the same session.
+func (tx *Transaction) Changes(ctx context.Context) (Changes, error) {
+	err := tx.Stage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	all, err := tx.manager.Changes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(Changes, len(tx.packages))
+	for _, pkg := range tx.packages {
+		if len(all[pkg]) > 0 {
+			changes[pkg] = all[pkg]
+		}
+	}
+
+	return changes, nil
+}
+
+// Commit stages the pending operations and commits the touched packages without reloading
+// services.
+func (tx *Transaction) Commit(ctx context.Context) error {
+	err := tx.Stage(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, pkg := range tx.packages {
+		err = tx.manager.Package(pkg).Commit(ctx)
+		if err != nil {
+			return errdefs.Wrapf(err, "failed to commit %s", pkg)
+		}
+	}
+
+	tx.reset()
+
+	return nil
+}
+
+// Revert discards the pending operations and reverts the staged changes of the touched packages.
+func (tx *Transaction) Revert(ctx context.Context) error {
+	for _, pkg := range tx.packages {
+		err := tx.manager.Package(pkg).Revert(ctx)
+		if err != nil {
+			return errdefs.Wrapf(err, "failed to revert %s", pkg)
+		}
+	}
+
+	tx.reset()
+
+	return nil
+}
+
+// Apply stages the pending operations and applies all changes staged in the session, reloading
+// the affected services. With rollback, rpcd restores the previous configuration after timeout
+// unless the changes are confirmed, see Manager.ApplyWithConfirm.
+func (tx *Transaction) Apply(ctx context.Context, rollback bool, timeout time.Duration) error {
+	err := tx.Stage(ctx)
+	if err != nil {
+		return err
+	}
+
+	if rollback {
+		err = tx.manager.ApplyWithConfirm(ctx, timeout, nil)
+	} else {
+		err = tx.manager.Apply(ctx, false, 0)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	tx.reset()
+
+	return nil
+}
+
+func (tx *Transaction) queue(op Operation) *Transaction {
+	tx.pending = append(tx.pending, op)
+	if !slices.Contains(tx.packages, op.Package) {
+		tx.packages = append(tx.packages, op.Package)
+	}
+
+	return tx
+}
+
+func (tx *Transaction) reset() {
+	tx.pending, tx.staged, tx.packages = nil, nil, nil
+}
+
+// stage sends one operation to the device.
+func (m *Manager) stage(ctx context.Context, op Operation) error {
+	section := m.Package(op.Package).Section(op.Section)
+
+	switch op.Kind {
+	case OperationSet:
+		return section.SetValues(ctx, op.Values)
+	case OperationAdd:
+		return m.Package(op.Package).Add(ctx, op.Type, op.Section, op.Values)
+	case OperationDelete:
+		if op.Option != "" {
+			return section.Option(op.Option).Delete(ctx)
+		}
+
+		return section.Delete(ctx)
+	case OperationAddList:
+		return eachEntry(ctx, op.List, section.Option(op.Option).AddToList)
+	case OperationDelList:
+		return eachEntry(ctx, op.List, section.Option(op.Option).DeleteFromList)
+	default:
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "unknown operation %q", op.Kind)
+	}
+}
+
+// eachEntry applies a list change to each entry in turn.
+func eachEntry(ctx context.Context, entries []string, change func(context.Context, string) error) error {
+	for _, entry := range entries {
+		err := change(ctx, entry)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -7,12 +7,13 @@ import "github.com/honeybbq/goubus/v2"
 
 // RequestGeneric represents the basic UCI request structure.
 type RequestGeneric struct {
-	Config  string `json:"config"`
-	Section string `json:"section,omitempty"`
-	Option  string `json:"option,omitempty"`
-	Type    string `json:"type,omitempty"`
-	Match   string `json:"match,omitempty"`
-	Name    string `json:"name,omitempty"`
+	// Match restricts a get of a whole package to the sections whose options have these values.
+	Match   map[string]string `json:"match,omitempty"`
+	Config  string            `json:"config"`
+	Section string            `json:"section,omitempty"`
+	Option  string            `json:"option,omitempty"`
+	Type    string            `json:"type,omitempty"`
+	Name    string            `json:"name,omitempty"`
 }
 
 // Request represents a UCI request with values.
@@ -30,6 +31,8 @@ type GetRequest struct {
 type GetResponse struct {
 	Values map[string]any `json:"values"`
 	Value  string         `json:"value"`
+	// List holds the entries of a list option, whose Value joins them with spaces.
+	List []string `json:"-"`
 }
 
 // ConfigsResponse holds the response from a uci.configs call.
@@ -69,7 +72,33 @@ type ChangesRequest struct {
 
 // ChangesResponse holds the response from a uci.changes call.
 type ChangesResponse struct {
-	Changes map[string]any `json:"changes"`
+	Changes Changes `json:"changes"`
+}
+
+// ChangeKind is the kind of a staged UCI change, as listed by uci.changes.
+type ChangeKind string
+
+// Kinds of staged UCI changes.
+const (
+	ChangeSet     ChangeKind = "set"
+	ChangeAdd     ChangeKind = "add"
+	ChangeRemove  ChangeKind = "remove"
+	ChangeRename  ChangeKind = "rename"
+	ChangeOrder   ChangeKind = "order"
+	ChangeListAdd ChangeKind = "list-add"
+	ChangeListDel ChangeKind = "list-del"
+)
+
+// Change is a staged UCI change.
+type Change struct {
+	Kind    ChangeKind
+	Package string
+	Section string
+	// Option is empty for changes of a whole section.
+	Option string
+	// Value is the new value, the type of an added section, the new name of a rename or the
+	// position of an order change; it is empty for removals.
+	Value string
 }
 
 // RevertRequest represents a UCI revert request.
@@ -0,0 +1,201 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package uci
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+// OptionRule constrains the values of an option. Each value of a list option is checked on its
+// own; the zero rule allows any value.
+type OptionRule struct {
+	// Enum lists the allowed values.
+	Enum []string
+	// Min and Max bound the value when Integer is set.
+	Min, Max int
+	// Integer requires a decimal integer.
+	Integer bool
+	// Bool requires a boolean in one of the BoolStyle spellings.
+	Bool bool
+}
+
+// Schema maps the options of a section type to their rules. Options without a rule are not
+// checked.
+type Schema map[string]OptionRule
+
+// Violation is a value that breaks the rule of its option.
+type Violation struct {
+	// Section is the section name, or the section type when a model is validated.
+	Section string
+	Option  string
+	Value   string
+	Reason  string
+}
+
+// ValidationError lists every violation found in a write. It matches errdefs.ErrInvalidParameter.
+type ValidationError struct {
+	Violations []Violation
+}
+
+// Error lists the violations.
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = fmt.Sprintf("%s.%s: %q %s", v.Section, v.Option, v.Value, v.Reason)
+	}
+
+	return "invalid uci values: " + strings.Join(messages, "; ")
+}
+
+// Unwrap returns errdefs.ErrInvalidParameter.
+func (e *ValidationError) Unwrap() error {
+	return errdefs.ErrInvalidParameter
+}
+
+// Validate checks values of section against the schema and returns a *ValidationError listing
+// all violations, or nil.
+func (s Schema) Validate(section string, values SectionValues) error {
+	var violations []Violation
+
+	for _, option := range slices.Sorted(maps.Keys(values.values)) {
+		rule, ok := s[option]
+		if !ok {
+			continue
+		}
+
+		for _, value := range values.values[option].values {
+			reason := rule.check(value)
+			if reason != "" {
+				violations = append(violations, Violation{Section: section, Option: option, Value: value, Reason: reason})
+			}
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Violations: violations}
+}
+
+// check returns why value breaks the rule, or "" if it does not.
+func (r OptionRule) check(value string) string {
+	if len(r.Enum) > 0 && !slices.Contains(r.Enum, value) {
+		return "is not one of " + strings.Join(r.Enum, ", ")
+	}
+
+	if r.Bool {
+		_, ok := DetectBoolStyle(value)
+		if !ok {
+			return "is not a boolean"
+		}
+	}
+
+	if !r.Integer {
+		return ""
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return "is not an integer"
+	}
+
+	if n < r.Min || n > r.Max {
+		return fmt.Sprintf("is not between %d and %d", r.Min, r.Max)
+	}
+
+	return ""
+}
+
+// ConfigModel is a typed section model that converts itself into UCI option values.
+type ConfigModel interface {
+	SectionValues() SectionValues
+}
+
+type schemaKey struct {
+	pkg         string
+	sectionType string
+}
+
+// SetSchema enforces schema on the sections of sectionType in pkg: Add and SetValues, and so
+// Set and transactions, reject values that break it before sending anything to the device. A
+// nil schema stops enforcing.
+func (m *Manager) SetSchema(pkg, sectionType string, schema Schema) {
+	m.schemaMu.Lock()
+	defer m.schemaMu.Unlock()
+
+	key := schemaKey{pkg: pkg, sectionType: sectionType}
+	if schema == nil {
+		delete(m.schemas, key)
+
+		return
+	}
+
+	if m.schemas == nil {
+		m.schemas = make(map[schemaKey]Schema)
+	}
+
+	m.schemas[key] = schema
+}
+
+// Validate checks the values of v against the schema set for sectionType in pkg and returns a
+// *ValidationError listing all violations. It returns nil if no schema is set.
+func (m *Manager) Validate(pkg, sectionType string, v ConfigModel) error {
+	return m.validate(pkg, sectionType, sectionType, v.SectionValues())
+}
+
+// validate checks values against the schema set for sectionType in pkg, if any.
+func (m *Manager) validate(pkg, sectionType, section string, values SectionValues) error {
+	schema, ok := m.schema(pkg, sectionType)
+	if !ok {
+		return nil
+	}
+
+	return schema.Validate(section, values)
+}
+
+func (m *Manager) schema(pkg, sectionType string) (Schema, bool) {
+	m.schemaMu.RLock()
+	defer m.schemaMu.RUnlock()
+
+	schema, ok := m.schemas[schemaKey{pkg: pkg, sectionType: sectionType}]
+
+	return schema, ok
+}
+
+// hasSchemas reports whether any schema is set for pkg.
+func (m *Manager) hasSchemas(pkg string) bool {
+	m.schemaMu.RLock()
+	defer m.schemaMu.RUnlock()
+
+	for key := range m.schemas {
+		if key.pkg == pkg {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validate checks values about to be set on a section, looking up its type only when a
+// schema is set for the package.
+func (sc *SectionContext) validate(ctx context.Context, section string, values SectionValues) error {
+	if !sc.pc.manager.hasSchemas(sc.pc.name) {
+		return nil
+	}
+
+	current, err := sc.pc.Section(section).Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	return sc.pc.manager.validate(sc.pc.name, current.Type, section, values)
+}
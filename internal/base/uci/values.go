@@ -4,8 +4,10 @@
 package uci
 
 import (
+	"cmp"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -99,6 +101,78 @@ func (sv *SectionValues) SetScalar(option, value string) {
 	sv.Set(option, value)
 }
 
+// BoolStyle is the pair of words a UCI option uses to spell true and false.
+type BoolStyle struct {
+	True  string
+	False string
+}
+
+// The boolean spellings understood by UCI consumers. BoolStyleNumeric is the UCI convention.
+var (
+	BoolStyleNumeric   = BoolStyle{True: "1", False: "0"}
+	BoolStyleTrueFalse = BoolStyle{True: "true", False: "false"}
+	BoolStyleYesNo     = BoolStyle{True: "yes", False: "no"}
+	BoolStyleOnOff     = BoolStyle{True: "on", False: "off"}
+	BoolStyleEnabled   = BoolStyle{True: "enabled", False: "disabled"}
+)
+
+var boolStyles = []BoolStyle{BoolStyleNumeric, BoolStyleTrueFalse, BoolStyleYesNo, BoolStyleOnOff, BoolStyleEnabled}
+
+// Format spells value in the style.
+func (bs BoolStyle) Format(value bool) string {
+	if value {
+		return bs.True
+	}
+
+	return bs.False
+}
+
+// DetectBoolStyle reports the style a boolean option value is spelled in.
+// It returns false for values that are not a known boolean spelling.
+func DetectBoolStyle(value string) (BoolStyle, bool) {
+	for _, style := range boolStyles {
+		if strings.EqualFold(value, style.True) || strings.EqualFold(value, style.False) {
+			return style, true
+		}
+	}
+
+	return BoolStyle{}, false
+}
+
+// SetBool stores a boolean option using the UCI "1"/"0" convention.
+func (sv *SectionValues) SetBool(option string, value bool) {
+	sv.SetBoolStyle(option, value, BoolStyleNumeric)
+}
+
+// SetBoolStyle stores a boolean option spelled in the given style.
+func (sv *SectionValues) SetBoolStyle(option string, value bool, style BoolStyle) {
+	sv.Set(option, style.Format(value))
+}
+
+// PreserveBoolStyles respells the "1"/"0" booleans written by SetBool in the style the original
+// section used for the same option, so that writing a model back keeps the device's representation.
+func (sv *SectionValues) PreserveBoolStyles(original *Section) {
+	if original == nil {
+		return
+	}
+
+	for option, value := range sv.values {
+		if value.kind != sectionValueKindScalar || len(value.values) != 1 {
+			continue
+		}
+
+		current := value.values[0]
+		if current != BoolStyleNumeric.True && current != BoolStyleNumeric.False {
+			continue
+		}
+
+		style, ok := DetectBoolStyle(original.GetString(option))
+		if ok && style != BoolStyleNumeric {
+			sv.SetBoolStyle(option, current == BoolStyleNumeric.True, style)
+		}
+	}
+}
+
 // Append adds values to an option without overwriting existing ones.
 func (sv *SectionValues) Append(option string, values ...string) {
 	sv.ensure()
@@ -284,6 +358,84 @@ func (s *Section) GetFirst(option string) (string, bool) {
 	return s.Values.First(option)
 }
 
+// GetString returns the first value of an option, or an empty string when it is unset.
+func (s *Section) GetString(option string) string {
+	value, _ := s.GetFirst(option)
+
+	return value
+}
+
+// GetBool interprets an option as a UCI boolean ("1", "true", "yes", "on", "enabled").
+func (s *Section) GetBool(option string) bool {
+	style, ok := DetectBoolStyle(s.GetString(option))
+
+	return ok && strings.EqualFold(s.GetString(option), style.True)
+}
+
+// GetInt interprets an option as an integer, returning 0 when it is unset or malformed.
+func (s *Section) GetInt(option string) int {
+	value, err := strconv.Atoi(s.GetString(option))
+	if err != nil {
+		return 0
+	}
+
+	return value
+}
+
+// SortedSections returns the sections ordered by their position in the package.
+func SortedSections(sections map[string]*Section) []*Section {
+	ordered := make([]*Section, 0, len(sections))
+	for _, section := range sections {
+		ordered = append(ordered, section)
+	}
+
+	slices.SortStableFunc(ordered, func(a, b *Section) int {
+		return cmp.Or(cmp.Compare(a.index(), b.index()), strings.Compare(a.Name, b.Name))
+	})
+
+	return ordered
+}
+
+func (s *Section) index() int {
+	if s.Metadata.Index == nil {
+		return 0
+	}
+
+	return *s.Metadata.Index
+}
+
+// LazySections holds the raw sections of a package and converts each of them on access.
+type LazySections struct {
+	Values goubus.LazyMap[map[string]any] `json:"values"`
+}
+
+// Len returns the number of sections.
+func (ls *LazySections) Len() int {
+	return ls.Values.Len()
+}
+
+// Names returns the section names in sorted order.
+func (ls *LazySections) Names() []string {
+	return ls.Values.Keys()
+}
+
+// Get converts the named section. It returns ErrNotFound if the package has no such section.
+func (ls *LazySections) Get(name string) (*Section, error) {
+	raw, err := ls.Values.Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSectionFromRaw(name, *raw), nil
+}
+
+// Range converts the sections one at a time in name order and calls fn for each of them.
+func (ls *LazySections) Range(fn func(section *Section) error) error {
+	return ls.Values.Range(func(name string, raw *map[string]any) error {
+		return fn(newSectionFromRaw(name, *raw))
+	})
+}
+
 func newSectionFromRaw(name string, raw map[string]any) *Section {
 	values := NewSectionValues()
 	for key, rawValue := range raw {
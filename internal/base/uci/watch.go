@@ -0,0 +1,179 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package uci
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+const (
+	// DefaultWatchInterval is how often Watch reads a package to catch changes that raise no
+	// event.
+	DefaultWatchInterval = 30 * time.Second
+
+	// ChangeEvent is the type of the events Watch delivers.
+	ChangeEvent = "uci.change"
+
+	// configChangeEvent is the ubus event procd and rpcd raise when a package is committed.
+	configChangeEvent = "config.change"
+)
+
+// PackageChange is a change of a package reported by Watch.
+type PackageChange struct {
+	Package string `json:"config"`
+	// Diffs lists the differences since the previous notification, see Diff.
+	Diffs []SectionDiff `json:"diffs"`
+}
+
+// WatchOption defines a functional option for Watch.
+type WatchOption func(*watchConfig)
+
+type watchConfig struct {
+	interval time.Duration
+}
+
+// WithWatchInterval sets how often Watch reads the package; a non-positive interval keeps
+// DefaultWatchInterval.
+func WithWatchInterval(interval time.Duration) WatchOption {
+	return func(c *watchConfig) {
+		if interval > 0 {
+			c.interval = interval
+		}
+	}
+}
+
+// Watch notifies when pkg changes, e.g. because LuCI or the uci CLI committed a modification.
+// Where the transport delivers ubus events, the "config.change" event of a commit triggers an
+// immediate check; the package is also read every interval, which is the only source of changes
+// on transports without events. Every event has the type ChangeEvent and a PackageChange payload
+// that can be decoded with ParsePackageChange. A failed read ends the subscription.
+func (m *Manager) Watch(ctx context.Context, pkg string, opts ...WatchOption) (*goubus.Subscription, error) {
+	cfg := watchConfig{interval: DefaultWatchInterval}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	pc := m.Package(pkg)
+
+	snapshot, err := pc.GetAll(ctx)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read %s", pkg)
+	}
+
+	// Without events, changes are only found by reading the package.
+	commits, err := goubus.Listen(ctx, m.caller, configChangeEvent)
+	if err != nil {
+		commits = nil
+	}
+
+	w := &watcher{pc: pc, snapshot: snapshot, commits: commits, interval: cfg.interval}
+
+	return goubus.NewSubscription(ctx, w.run), nil
+}
+
+// ParsePackageChange decodes an event delivered by Watch. It returns false for other events.
+func ParsePackageChange(ev goubus.Event) (PackageChange, bool) {
+	if ev.Type != ChangeEvent {
+		return PackageChange{}, false
+	}
+
+	var change PackageChange
+
+	err := ev.Unmarshal(&change)
+	if err != nil {
+		return PackageChange{}, false
+	}
+
+	return change, true
+}
+
+// watcher compares a package with the snapshot taken at the previous notification.
+type watcher struct {
+	pc       *PackageContext
+	snapshot map[string]*Section
+	commits  *goubus.Subscription
+	interval time.Duration
+}
+
+func (w *watcher) run(ctx context.Context, emit func(goubus.Event) bool) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	var commits <-chan goubus.Event
+
+	if w.commits != nil {
+		defer func() { _ = w.commits.Close() }()
+
+		commits = w.commits.Events()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-commits:
+			if !ok {
+				commits = nil
+
+				continue
+			}
+
+			if ev.Data["config"] != w.pc.name {
+				continue
+			}
+		case <-ticker.C:
+		}
+
+		err := w.check(ctx, emit)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// check reads the package and emits its differences from the snapshot.
+func (w *watcher) check(ctx context.Context, emit func(goubus.Event) bool) error {
+	current, err := w.pc.GetAll(ctx)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to read %s", w.pc.name)
+	}
+
+	diffs := Diff(w.snapshot, current)
+	if len(diffs) == 0 {
+		return nil
+	}
+
+	w.snapshot = current
+
+	data, err := changeData(PackageChange{Package: w.pc.name, Diffs: diffs})
+	if err != nil {
+		return err
+	}
+
+	emit(goubus.Event{Type: ChangeEvent, Object: w.pc.name, Data: data})
+
+	return nil
+}
+
+// changeData converts a change into an event payload.
+func changeData(change PackageChange) (map[string]any, error) {
+	raw, err := json.Marshal(change)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to encode change of %s", change.Package)
+	}
+
+	var data map[string]any
+
+	err = json.Unmarshal(raw, &data)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to encode change of %s", change.Package)
+	}
+
+	return data, nil
+}
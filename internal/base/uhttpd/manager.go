@@ -0,0 +1,307 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package uhttpd
+
+import (
+	"context"
+	"crypto/tls"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/file"
+	"github.com/honeybbq/goubus/v2/internal/base/rc"
+	"github.com/honeybbq/goubus/v2/internal/base/uci"
+)
+
+const (
+	uciPackage      = "uhttpd"
+	uciServerType   = "uhttpd"
+	uciCertType     = "cert"
+	initScript      = "uhttpd"
+	defaultCertPath = "/etc/uhttpd.crt"
+	defaultKeyPath  = "/etc/uhttpd.key"
+	certMode        = 0o644
+	keyMode         = 0o600
+	tmpSuffix       = ".tmp"
+	backupSuffix    = ".bak"
+)
+
+// Manager provides methods to configure the uhttpd web server and its TLS certificate.
+type Manager struct {
+	uci  *uci.Manager
+	file *file.Manager
+	rc   *rc.Manager
+}
+
+// New creates a new base uhttpd Manager.
+func New(t goubus.Transport) *Manager {
+	return &Manager{
+		uci:  uci.New(t, nil),
+		file: file.New(t),
+		rc:   rc.New(t),
+	}
+}
+
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"uci", "file", "rc"}
+}
+
+// Configs retrieves all uhttpd server instances in configuration order.
+func (m *Manager) Configs(ctx context.Context) ([]Config, error) {
+	sections, err := m.uci.Package(uciPackage).GetAll(ctx)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read uhttpd config")
+	}
+
+	var configs []Config
+
+	for _, section := range uci.SortedSections(sections) {
+		if section.Type != uciServerType {
+			continue
+		}
+
+		configs = append(configs, ConfigFromSection(section))
+	}
+
+	return configs, nil
+}
+
+// Config retrieves a single uhttpd server instance by section name.
+func (m *Manager) Config(ctx context.Context, name string) (*Config, error) {
+	section, err := m.uci.Package(uciPackage).Section(name).Get(ctx)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read uhttpd section %s", name)
+	}
+
+	cfg := ConfigFromSection(section)
+
+	return &cfg, nil
+}
+
+// SetConfig stages the configuration of the named uhttpd section and commits the package.
+// The server has to be restarted for the changes to take effect.
+func (m *Manager) SetConfig(ctx context.Context, cfg Config) error {
+	if cfg.Name == "" {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "uhttpd section name is required")
+	}
+
+	pkg := m.uci.Package(uciPackage)
+
+	err := pkg.Section(cfg.Name).SetValues(ctx, cfg.SectionValues())
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to set uhttpd config")
+	}
+
+	return pkg.Commit(ctx)
+}
+
+// CertDefaults retrieves the self-signed certificate generation parameters.
+func (m *Manager) CertDefaults(ctx context.Context) ([]CertDefaults, error) {
+	sections, err := m.uci.Package(uciPackage).GetAll(ctx)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read uhttpd config")
+	}
+
+	var defaults []CertDefaults
+
+	for _, section := range uci.SortedSections(sections) {
+		if section.Type != uciCertType {
+			continue
+		}
+
+		defaults = append(defaults, CertDefaultsFromSection(section))
+	}
+
+	return defaults, nil
+}
+
+// InstallCertificate uploads a PEM encoded certificate chain and private key to the paths
+// configured for the named server section and restarts uhttpd.
+// The pair is validated locally first. Both files are uploaded to temporary paths and moved
+// into place only once both uploads succeeded. The old key is kept as a backup until the new
+// certificate is in place and restored if that move fails, so a failure leaves the old pair intact.
+func (m *Manager) InstallCertificate(ctx context.Context, name, certPEM, keyPEM string) error {
+	_, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+	if err != nil {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid certificate or key: %v", err)
+	}
+
+	cfg, err := m.Config(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	certPath, keyPath := cfg.Cert, cfg.Key
+	if certPath == "" {
+		certPath = defaultCertPath
+	}
+
+	if keyPath == "" {
+		keyPath = defaultKeyPath
+	}
+
+	keyTmp, certTmp := keyPath+tmpSuffix, certPath+tmpSuffix
+
+	err = m.file.Write(ctx, keyTmp, keyPEM, false, keyMode, false)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to upload private key")
+	}
+
+	err = m.file.Write(ctx, certTmp, certPEM, false, certMode, false)
+	if err == nil {
+		err = m.replacePair(ctx, keyTmp, keyPath, certTmp, certPath)
+	}
+
+	if err != nil {
+		_ = m.file.Remove(ctx, keyTmp)
+		_ = m.file.Remove(ctx, certTmp)
+
+		return errdefs.Wrapf(err, "failed to install certificate")
+	}
+
+	return m.Restart(ctx)
+}
+
+// replacePair moves the uploaded key and certificate into place, key first. A copy of the old key
+// is restored when the certificate cannot be moved, so uhttpd never pairs the new key with the
+// old certificate.
+func (m *Manager) replacePair(ctx context.Context, keyTmp, keyPath, certTmp, certPath string) error {
+	backup := keyPath + backupSuffix
+
+	_, err := m.file.Stat(ctx, keyPath)
+	if err != nil && !errdefs.IsNotFound(err) {
+		return errdefs.Wrapf(err, "failed to stat %s", keyPath)
+	}
+
+	hadKey := err == nil
+	if hadKey {
+		err = m.run(ctx, "/bin/cp", "-p", keyPath, backup)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = m.move(ctx, keyTmp, keyPath)
+	if err == nil {
+		err = m.move(ctx, certTmp, certPath)
+	}
+
+	if err != nil {
+		if hadKey {
+			_ = m.move(ctx, backup, keyPath)
+		} else {
+			_ = m.file.Remove(ctx, keyPath)
+		}
+
+		return err
+	}
+
+	if hadKey {
+		_ = m.file.Remove(ctx, backup)
+	}
+
+	return nil
+}
+
+// move renames a file on the device.
+func (m *Manager) move(ctx context.Context, from, to string) error {
+	return m.run(ctx, "/bin/mv", "-f", from, to)
+}
+
+// run runs a command on the device and fails unless it exits with code 0.
+func (m *Manager) run(ctx context.Context, command string, args ...string) error {
+	res, err := m.file.Exec(ctx, command, args, nil)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to run %s %s", command, strings.Join(args, " "))
+	}
+
+	if res.Code != 0 {
+		return errdefs.Wrapf(errdefs.ErrUnknown, "%s exited with code %d: %s",
+			path.Base(command), res.Code, strings.TrimSpace(res.Stderr))
+	}
+
+	return nil
+}
+
+// Restart restarts the uhttpd init script.
+func (m *Manager) Restart(ctx context.Context) error {
+	err := m.rc.Init(ctx, initScript, "restart")
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to restart %s", initScript)
+	}
+
+	return nil
+}
+
+// ConfigFromSection converts a UCI section into a Config.
+func ConfigFromSection(section *uci.Section) Config {
+	return Config{
+		Name:           section.Name,
+		Home:           section.GetString("home"),
+		Cert:           section.GetString("cert"),
+		Key:            section.GetString("key"),
+		CGIPrefix:      section.GetString("cgi_prefix"),
+		LuaPrefix:      section.Get("lua_prefix"),
+		UbusPrefix:     section.GetString("ubus_prefix"),
+		ListenHTTP:     section.Get("listen_http"),
+		ListenHTTPS:    section.Get("listen_https"),
+		MaxRequests:    section.GetInt("max_requests"),
+		MaxConnections: section.GetInt("max_connections"),
+		ScriptTimeout:  section.GetInt("script_timeout"),
+		NetworkTimeout: section.GetInt("network_timeout"),
+		HTTPKeepalive:  section.GetInt("http_keepalive"),
+		TCPKeepalive:   section.GetInt("tcp_keepalive"),
+		RedirectHTTPS:  section.GetBool("redirect_https"),
+		RFC1918Filter:  section.GetBool("rfc1918_filter"),
+	}
+}
+
+// SectionValues converts the Config into UCI option values.
+func (c *Config) SectionValues() uci.SectionValues {
+	values := uci.NewSectionValues()
+	values.SetList("listen_http", c.ListenHTTP...)
+	values.SetList("listen_https", c.ListenHTTPS...)
+	values.SetScalar("home", c.Home)
+	values.SetScalar("cert", c.Cert)
+	values.SetScalar("key", c.Key)
+	values.SetScalar("cgi_prefix", c.CGIPrefix)
+	values.SetList("lua_prefix", c.LuaPrefix...)
+	values.SetScalar("ubus_prefix", c.UbusPrefix)
+	values.SetBool("redirect_https", c.RedirectHTTPS)
+	values.SetBool("rfc1918_filter", c.RFC1918Filter)
+
+	for option, value := range map[string]int{
+		"max_requests":    c.MaxRequests,
+		"max_connections": c.MaxConnections,
+		"script_timeout":  c.ScriptTimeout,
+		"network_timeout": c.NetworkTimeout,
+		"http_keepalive":  c.HTTPKeepalive,
+		"tcp_keepalive":   c.TCPKeepalive,
+	} {
+		if value > 0 {
+			values.Set(option, strconv.Itoa(value))
+		}
+	}
+
+	return values
+}
+
+// CertDefaultsFromSection converts a UCI section into CertDefaults.
+func CertDefaultsFromSection(section *uci.Section) CertDefaults {
+	return CertDefaults{
+		Name:       section.Name,
+		KeyType:    section.GetString("key_type"),
+		ECCurve:    section.GetString("ec_curve"),
+		Country:    section.GetString("country"),
+		State:      section.GetString("state"),
+		Location:   section.GetString("location"),
+		CommonName: section.GetString("commonname"),
+		Days:       section.GetInt("days"),
+		Bits:       section.GetInt("bits"),
+	}
+}
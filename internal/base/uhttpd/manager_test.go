@@ -0,0 +1,216 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package uhttpd_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/rc"
+	"github.com/honeybbq/goubus/v2/internal/base/uhttpd"
+	"github.com/honeybbq/goubus/v2/internal/testutil"
+)
+
+func TestUhttpdManager(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Config", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("uci", "get", map[string]any{
+			"values": map[string]any{
+				".type":          "uhttpd",
+				".name":          "main",
+				"listen_http":    []string{"0.0.0.0:80", "[::]:80"},
+				"listen_https":   []string{"0.0.0.0:443", "[::]:443"},
+				"redirect_https": "on",
+				"cert":           "/etc/uhttpd.crt",
+				"max_requests":   "3",
+				"lua_prefix":     []string{"/cgi-bin/luci=/usr/lib/lua/luci/sgi/uhttpd.lua", "/app=/www/app.lua"},
+			},
+		})
+
+		cfg, err := uhttpd.New(mock).Config(ctx, "main")
+		if err != nil {
+			t.Fatalf("Config failed: %v", err)
+		}
+
+		if len(cfg.ListenHTTPS) != 2 || !cfg.RedirectHTTPS || cfg.MaxRequests != 3 || cfg.Cert != "/etc/uhttpd.crt" {
+			t.Errorf("unexpected config: %+v", cfg)
+		}
+
+		if values := cfg.SectionValues(); len(cfg.LuaPrefix) != 2 || !slices.Equal(values.Get("lua_prefix"), cfg.LuaPrefix) {
+			t.Errorf("expected lua_prefix to stay a list, got %v", cfg.LuaPrefix)
+		}
+	})
+
+	t.Run("InstallCertificate", func(t *testing.T) {
+		certPEM, keyPEM := newTestCertificate(t)
+
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("uci", "get", map[string]any{
+			"values": map[string]any{".type": "uhttpd", ".name": "main", "key": "/etc/ssl/router.key"},
+		})
+		mock.AddResponse("file", "write", map[string]any{})
+		mock.AddResponse("file", "stat", map[string]any{"type": "file", "size": 241})
+		mock.AddResponse("file", "exec", map[string]any{"code": 0})
+		mock.AddResponse("file", "remove", map[string]any{})
+		mock.AddResponse("rc", "init", map[string]any{})
+
+		err := uhttpd.New(mock).InstallCertificate(ctx, "main", certPEM, keyPEM)
+		if err != nil {
+			t.Fatalf("InstallCertificate failed: %v", err)
+		}
+
+		keyWrite, ok := mock.Calls[1].Data.(map[string]any)
+		if !ok || keyWrite["path"] != "/etc/ssl/router.key.tmp" || keyWrite["mode"] != 0o600 {
+			t.Errorf("unexpected key write: %+v", mock.Calls[1])
+		}
+
+		certWrite, ok := mock.Calls[2].Data.(map[string]any)
+		if !ok || certWrite["path"] != "/etc/uhttpd.crt.tmp" {
+			t.Errorf("unexpected certificate write: %+v", mock.Calls[2])
+		}
+
+		want := [][]string{
+			{"-p", "/etc/ssl/router.key", "/etc/ssl/router.key.bak"},
+			{"-f", "/etc/ssl/router.key.tmp", "/etc/ssl/router.key"},
+			{"-f", "/etc/uhttpd.crt.tmp", "/etc/uhttpd.crt"},
+		}
+		if got := execParams(mock); !slices.EqualFunc(got, want, slices.Equal) {
+			t.Errorf("expected the key to be backed up and both files moved after the uploads, got %v", got)
+		}
+
+		req, ok := mock.GetLastCall().Data.(rc.InitRequest)
+		if !ok || req.Name != "uhttpd" || req.Action != "restart" {
+			t.Errorf("unexpected last call: %+v", mock.GetLastCall())
+		}
+	})
+
+	t.Run("InstallCertificate_UploadFailure", func(t *testing.T) {
+		certPEM, keyPEM := newTestCertificate(t)
+
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("uci", "get", map[string]any{"values": map[string]any{".type": "uhttpd", ".name": "main"}})
+		mock.AddResponse("file", "write", map[string]any{})
+		mock.AddResponseForArgs("file", "write",
+			map[string]any{"path": "/etc/uhttpd.crt.tmp", "data": certPEM, "mode": 0o644}, errdefs.ErrPermissionDenied)
+		mock.AddResponse("file", "remove", map[string]any{})
+
+		err := uhttpd.New(mock).InstallCertificate(ctx, "main", certPEM, keyPEM)
+		if !errdefs.IsPermissionDenied(err) {
+			t.Fatalf("expected permission denied, got %v", err)
+		}
+
+		for _, call := range mock.Calls {
+			if call.Method == "exec" || call.Service == "rc" {
+				t.Errorf("expected the old pair to stay in place, got %+v", call)
+			}
+		}
+
+		if call := mock.GetLastCall(); call.Method != "remove" {
+			t.Errorf("expected the temporary files to be removed, got %+v", call)
+		}
+	})
+
+	t.Run("InstallCertificate_MoveFailure", func(t *testing.T) {
+		certPEM, keyPEM := newTestCertificate(t)
+
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("uci", "get", map[string]any{"values": map[string]any{".type": "uhttpd", ".name": "main"}})
+		mock.AddResponse("file", "write", map[string]any{})
+		mock.AddResponse("file", "stat", map[string]any{"type": "file", "size": 241})
+		mock.AddResponse("file", "exec", map[string]any{"code": 0})
+		mock.AddResponseForArgs("file", "exec",
+			map[string]any{"command": "/bin/mv", "params": []string{"-f", "/etc/uhttpd.crt.tmp", "/etc/uhttpd.crt"}},
+			map[string]any{"code": 1, "stderr": "mv: can't rename: Read-only file system"})
+		mock.AddResponse("file", "remove", map[string]any{})
+
+		err := uhttpd.New(mock).InstallCertificate(ctx, "main", certPEM, keyPEM)
+		if !errdefs.IsUnknown(err) {
+			t.Fatalf("expected the failed move to be reported, got %v", err)
+		}
+
+		got := execParams(mock)
+		if restore := []string{"-f", "/etc/uhttpd.key.bak", "/etc/uhttpd.key"}; !slices.Equal(got[len(got)-1], restore) {
+			t.Errorf("expected the old key to be restored, got %v", got)
+		}
+
+		for _, call := range mock.Calls {
+			if call.Service == "rc" {
+				t.Errorf("expected uhttpd not to be restarted, got %+v", call)
+			}
+		}
+	})
+
+	t.Run("InstallCertificate_Invalid", func(t *testing.T) {
+		certPEM, _ := newTestCertificate(t)
+		_, otherKeyPEM := newTestCertificate(t)
+
+		mock := testutil.NewMockTransport()
+
+		err := uhttpd.New(mock).InstallCertificate(ctx, "main", certPEM, otherKeyPEM)
+		if !errdefs.IsInvalidParameter(err) {
+			t.Fatalf("expected invalid parameter, got %v", err)
+		}
+
+		if len(mock.Calls) != 0 {
+			t.Errorf("expected no calls, got %d", len(mock.Calls))
+		}
+	})
+}
+
+// execParams returns the parameters of the file.exec calls.
+func execParams(mock *testutil.MockTransport) [][]string {
+	var params [][]string
+
+	for _, call := range mock.Calls {
+		if call.Method == "exec" {
+			args, _ := call.Data.(map[string]any)["params"].([]string)
+			params = append(params, args)
+		}
+	}
+
+	return params
+}
+
+func newTestCertificate(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "OpenWrt"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return string(certPEM), string(keyPEM)
+}
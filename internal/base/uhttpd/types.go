@@ -0,0 +1,39 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package uhttpd
+
+// Config represents a "uhttpd" server section (usually "main") in /etc/config/uhttpd.
+type Config struct {
+	Name           string   `json:"name"`
+	Home           string   `json:"home"`
+	Cert           string   `json:"cert"`
+	Key            string   `json:"key"`
+	CGIPrefix      string   `json:"cgi_prefix"`
+	LuaPrefix      []string `json:"lua_prefix"`
+	UbusPrefix     string   `json:"ubus_prefix"`
+	ListenHTTP     []string `json:"listen_http"`
+	ListenHTTPS    []string `json:"listen_https"`
+	MaxRequests    int      `json:"max_requests"`
+	MaxConnections int      `json:"max_connections"`
+	ScriptTimeout  int      `json:"script_timeout"`
+	NetworkTimeout int      `json:"network_timeout"`
+	HTTPKeepalive  int      `json:"http_keepalive"`
+	TCPKeepalive   int      `json:"tcp_keepalive"`
+	RedirectHTTPS  bool     `json:"redirect_https"`
+	RFC1918Filter  bool     `json:"rfc1918_filter"`
+}
+
+// CertDefaults represents a "cert" section holding the parameters px5g uses to generate
+// the self-signed certificate when none exists.
+type CertDefaults struct {
+	Name       string `json:"name"`
+	KeyType    string `json:"key_type"`
+	ECCurve    string `json:"ec_curve"`
+	Country    string `json:"country"`
+	State      string `json:"state"`
+	Location   string `json:"location"`
+	CommonName string `json:"commonname"`
+	Days       int    `json:"days"`
+	Bits       int    `json:"bits"`
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package umdns
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/honeybbq/goubus/v2"
+)
+
+// Manager provides methods to browse and announce mDNS services through umdns.
+type Manager struct {
+	caller goubus.Transport
+}
+
+// New creates a new base umdns Manager.
+func New(t goubus.Transport) *Manager {
+	return &Manager{caller: t}
+}
+
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"umdns"}
+}
+
+// Update sends mDNS queries for all known services. umdns answers browse calls from its cache,
+// so call Update and wait a moment before browsing for fresh results.
+func (m *Manager) Update(ctx context.Context) error {
+	_, err := m.caller.Call(ctx, "umdns", "update", nil)
+
+	return err
+}
+
+// Browse returns the discovered service instances sorted by type and instance.
+// An empty serviceType returns every service, otherwise only instances of that type, e.g. "_http._tcp".
+func (m *Manager) Browse(ctx context.Context, serviceType string) ([]Service, error) {
+	params := map[string]any{
+		"array":   true,
+		"address": true,
+	}
+	if serviceType != "" {
+		params["service"] = serviceType
+	}
+
+	res, err := goubus.Call[map[string]map[string]serviceEntry](ctx, m.caller, "umdns", "browse", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var services []Service
+
+	for typ, instances := range *res {
+		for instance, entry := range instances {
+			services = append(services, Service{
+				Type:      typ,
+				Instance:  instance,
+				Host:      entry.Host,
+				Interface: entry.Interface,
+				TXT:       entry.TXT,
+				IPv4:      entry.IPv4,
+				IPv6:      entry.IPv6,
+				Port:      entry.Port,
+			})
+		}
+	}
+
+	slices.SortFunc(services, func(a, b Service) int {
+		return cmp.Or(cmp.Compare(a.Type, b.Type), cmp.Compare(a.Instance, b.Instance))
+	})
+
+	return services, nil
+}
+
+// Hosts returns the discovered hosts and their addresses sorted by name.
+func (m *Manager) Hosts(ctx context.Context) ([]Host, error) {
+	res, err := goubus.Call[map[string]hostEntry](ctx, m.caller, "umdns", "hosts", map[string]any{"array": true})
+	if err != nil {
+		return nil, err
+	}
+
+	hosts := make([]Host, 0, len(*res))
+	for name, entry := range *res {
+		hosts = append(hosts, Host{Name: name, IPv4: entry.IPv4, IPv6: entry.IPv6})
+	}
+
+	slices.SortFunc(hosts, func(a, b Host) int { return cmp.Compare(a.Name, b.Name) })
+
+	return hosts, nil
+}
+
+// Announcements returns the services the device announces itself, as configured in /etc/umdns
+// and by procd service instances with mdns data, sorted by name.
+func (m *Manager) Announcements(ctx context.Context) ([]Announcement, error) {
+	res, err := goubus.Call[map[string]announcementEntry](ctx, m.caller, "umdns", "announcements", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	announcements := make([]Announcement, 0, len(*res))
+	for name, entry := range *res {
+		announcements = append(announcements, Announcement{
+			Name:    name,
+			Service: entry.Service,
+			TXT:     entry.TXT,
+			Port:    entry.Port,
+		})
+	}
+
+	slices.SortFunc(announcements, func(a, b Announcement) int { return cmp.Compare(a.Name, b.Name) })
+
+	return announcements, nil
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package umdns_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/honeybbq/goubus/v2/internal/base/umdns"
+	"github.com/honeybbq/goubus/v2/internal/testutil"
+)
+
+func TestUmdnsManager(t *testing.T) {
+	ctx := context.Background()
+	mock := testutil.NewMockTransport()
+	mgr := umdns.New(mock)
+
+	t.Run("Browse", func(t *testing.T) {
+		testBrowse(t, ctx, mock, mgr)
+	})
+
+	t.Run("Hosts", func(t *testing.T) {
+		mock.AddResponse("umdns", "hosts", map[string]any{
+			"printer.local": map[string]any{"ipv4": "192.168.1.50"},
+			"nas.local":     map[string]any{"ipv4": []string{"192.168.1.20"}, "ipv6": []string{"fd00::20"}},
+		})
+
+		hosts, err := mgr.Hosts(ctx)
+		if err != nil {
+			t.Fatalf("Hosts failed: %v", err)
+		}
+
+		if len(hosts) != 2 || hosts[0].Name != "nas.local" || hosts[0].IPv6[0] != "fd00::20" ||
+			!slices.Equal(hosts[1].IPv4, []string{"192.168.1.50"}) {
+			t.Errorf("unexpected hosts: %+v", hosts)
+		}
+	})
+
+	t.Run("Announcements", func(t *testing.T) {
+		mock.AddResponse("umdns", "announcements", map[string]any{
+			"_ssh._tcp.local": map[string]any{"service": "_ssh._tcp.local", "port": 22, "txt": []string{"daemon=dropbear"}},
+		})
+
+		announcements, err := mgr.Announcements(ctx)
+		if err != nil {
+			t.Fatalf("Announcements failed: %v", err)
+		}
+
+		if len(announcements) != 1 || announcements[0].Port != 22 || announcements[0].TXT[0] != "daemon=dropbear" {
+			t.Errorf("unexpected announcements: %+v", announcements)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		mock.AddResponse("umdns", "update", map[string]any{})
+
+		err := mgr.Update(ctx)
+		if err != nil {
+			t.Fatalf("Update failed: %v", err)
+		}
+
+		if call := mock.GetLastCall(); call.Service != "umdns" || call.Method != "update" {
+			t.Errorf("unexpected call: %+v", call)
+		}
+	})
+}
+
+func testBrowse(t *testing.T, ctx context.Context, mock *testutil.MockTransport, mgr *umdns.Manager) {
+	t.Helper()
+
+	mock.AddResponse("umdns", "browse", map[string]any{
+		"_ssh._tcp": map[string]any{
+			"nas": map[string]any{
+				"port": 22, "host": "nas.local", "iface": "br-lan",
+				"ipv4": []string{"192.168.1.20"}, "txt": []string{},
+			},
+		},
+		"_http._tcp": map[string]any{
+			"printer": map[string]any{
+				"port": 80, "host": "printer.local", "iface": "br-lan",
+				"ipv4": []string{"192.168.1.50"}, "txt": []string{"path=/", "ty=Office"},
+			},
+		},
+	})
+
+	services, err := mgr.Browse(ctx, "")
+	if err != nil {
+		t.Fatalf("Browse failed: %v", err)
+	}
+
+	if len(services) != 2 || services[0].Type != "_http._tcp" || services[0].Instance != "printer" {
+		t.Fatalf("unexpected services: %+v", services)
+	}
+
+	printer := services[0]
+	if printer.Port != 80 || printer.Interface != "br-lan" || !slices.Equal(printer.TXT, []string{"path=/", "ty=Office"}) {
+		t.Errorf("unexpected printer service: %+v", printer)
+	}
+
+	mock.AddResponse("umdns", "browse", map[string]any{})
+
+	services, err = mgr.Browse(ctx, "_ipp._tcp")
+	if err != nil || len(services) != 0 {
+		t.Fatalf("expected no services, got %+v (%v)", services, err)
+	}
+
+	params, ok := mock.GetLastCall().Data.(map[string]any)
+	if !ok || params["service"] != "_ipp._tcp" || params["array"] != true {
+		t.Errorf("unexpected browse params: %+v", mock.GetLastCall().Data)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package umdns
+
+import (
+	"encoding/json"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+// Service is a service instance discovered on the LAN.
+type Service struct {
+	// Type is the service type without the domain, e.g. "_ssh._tcp".
+	Type string
+	// Instance is the instance name, usually the host label, e.g. "nas".
+	Instance string
+	// Host is the target host of the service, e.g. "nas.local".
+	Host string
+	// Interface is the interface the service was seen on.
+	Interface string
+	TXT       []string
+	IPv4      []string
+	IPv6      []string
+	Port      int
+}
+
+// Host is a host discovered on the LAN.
+type Host struct {
+	// Name is the host name, e.g. "nas.local".
+	Name string
+	IPv4 []string
+	IPv6 []string
+}
+
+// Announcement is a service umdns announces for the device itself.
+type Announcement struct {
+	// Name is the announced record, e.g. "_ssh._tcp.local".
+	Name    string
+	Service string
+	TXT     []string
+	Port    int
+}
+
+type serviceEntry struct {
+	Host      string     `json:"host"`
+	Interface string     `json:"iface"`
+	TXT       stringList `json:"txt"`
+	IPv4      stringList `json:"ipv4"`
+	IPv6      stringList `json:"ipv6"`
+	Port      int        `json:"port"`
+}
+
+type hostEntry struct {
+	IPv4 stringList `json:"ipv4"`
+	IPv6 stringList `json:"ipv6"`
+}
+
+type announcementEntry struct {
+	Service string     `json:"service"`
+	TXT     stringList `json:"txt"`
+	Port    int        `json:"port"`
+}
+
+// stringList decodes a string or an array of strings; umdns only returns arrays when asked to.
+type stringList []string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (l *stringList) UnmarshalJSON(data []byte) error {
+	var list []string
+
+	err := json.Unmarshal(data, &list)
+	if err == nil {
+		*l = list
+
+		return nil
+	}
+
+	var single string
+
+	err = json.Unmarshal(data, &single)
+	if err != nil {
+		return errdefs.Wrapf(errdefs.ErrInvalidResponse, "expected a string or string array: %v", err)
+	}
+
+	if single != "" {
+		*l = stringList{single}
+	}
+
+	return nil
+}
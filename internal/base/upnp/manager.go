@@ -0,0 +1,294 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package upnp
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/file"
+	"github.com/honeybbq/goubus/v2/internal/base/rc"
+	"github.com/honeybbq/goubus/v2/internal/base/uci"
+)
+
+const (
+	uciPackage       = "upnpd"
+	uciConfigSection = "config"
+	uciPermRuleType  = "perm_rule"
+	initScript       = "miniupnpd"
+	defaultLeaseFile = "/var/run/miniupnpd.leases"
+	leaseFileFields  = 6
+	dateBinary       = "/bin/date"
+)
+
+// Manager provides methods to configure miniupnpd and inspect its active port mappings.
+type Manager struct {
+	caller goubus.Transport
+	uci    *uci.Manager
+	file   *file.Manager
+	rc     *rc.Manager
+}
+
+// New creates a new base UPnP Manager.
+func New(t goubus.Transport) *Manager {
+	return &Manager{
+		caller: t,
+		uci:    uci.New(t, nil),
+		file:   file.New(t),
+		rc:     rc.New(t),
+	}
+}
+
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"luci.upnp", "uci", "file", "rc"}
+}
+
+// Config retrieves the main miniupnpd configuration section.
+func (m *Manager) Config(ctx context.Context) (*Config, error) {
+	section, err := m.uci.Package(uciPackage).Section(uciConfigSection).Get(ctx)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read upnpd config")
+	}
+
+	cfg := ConfigFromSection(section)
+
+	return &cfg, nil
+}
+
+// SetConfig stages the given configuration and commits the upnpd package.
+func (m *Manager) SetConfig(ctx context.Context, cfg Config) error {
+	pkg := m.uci.Package(uciPackage)
+
+	err := pkg.Section(uciConfigSection).SetValues(ctx, cfg.SectionValues())
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to set upnpd config")
+	}
+
+	return pkg.Commit(ctx)
+}
+
+// PermRules retrieves all permission rules in configuration order.
+func (m *Manager) PermRules(ctx context.Context) ([]PermRule, error) {
+	sections, err := m.uci.Package(uciPackage).GetAll(ctx)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to read upnpd rules")
+	}
+
+	var rules []PermRule
+
+	for _, section := range uci.SortedSections(sections) {
+		if section.Type != uciPermRuleType {
+			continue
+		}
+
+		rules = append(rules, PermRuleFromSection(section))
+	}
+
+	return rules, nil
+}
+
+// SetEnabled toggles the service in UCI and starts or stops the miniupnpd init script accordingly.
+func (m *Manager) SetEnabled(ctx context.Context, enabled bool) error {
+	pkg := m.uci.Package(uciPackage)
+	values := uci.NewSectionValues()
+	values.SetBool("enabled", enabled)
+
+	err := pkg.Section(uciConfigSection).SetValues(ctx, values)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to set upnpd enabled")
+	}
+
+	err = pkg.Commit(ctx)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to commit upnpd")
+	}
+
+	actions := []string{"enable", "restart"}
+	if !enabled {
+		actions = []string{"stop", "disable"}
+	}
+
+	for _, action := range actions {
+		err = m.rc.Init(ctx, initScript, action)
+		if err != nil {
+			return errdefs.Wrapf(err, "failed to %s %s", action, initScript)
+		}
+	}
+
+	return nil
+}
+
+// Leases retrieves the active port mappings.
+// It prefers the luci.upnp rpcd object and falls back to parsing the miniupnpd lease file.
+func (m *Manager) Leases(ctx context.Context) ([]Lease, error) {
+	res, err := goubus.Call[statusResponse](ctx, m.caller, "luci.upnp", "get_status", nil)
+	if err == nil {
+		return res.Rules, nil
+	}
+
+	if !errdefs.IsNotSupported(err) && !errdefs.IsNotFound(err) && !errdefs.IsMethodNotFound(err) &&
+		!errdefs.IsPermissionDenied(err) {
+		return nil, err
+	}
+
+	return m.leasesFromFile(ctx)
+}
+
+func (m *Manager) leasesFromFile(ctx context.Context) ([]Lease, error) {
+	path := defaultLeaseFile
+
+	cfg, err := m.Config(ctx)
+	if err == nil && cfg.LeaseFile != "" {
+		path = cfg.LeaseFile
+	}
+
+	content, err := m.file.Read(ctx, path, false)
+	if err != nil {
+		if errdefs.IsNotFound(err) || errdefs.IsNoData(err) {
+			return []Lease{}, nil
+		}
+
+		return nil, err
+	}
+
+	// The device clock is only needed when a mapping expires.
+	var now time.Time
+
+	expiring := func(l Lease) bool { return l.Expires != 0 }
+	if slices.ContainsFunc(ParseLeaseFile(content.Data, now), expiring) {
+		now, err = m.deviceTime(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ParseLeaseFile(content.Data, now), nil
+}
+
+// deviceTime reads the clock of the device, which the timestamps of the lease file refer to.
+func (m *Manager) deviceTime(ctx context.Context) (time.Time, error) {
+	res, err := m.file.Exec(ctx, dateBinary, []string{"+%s"}, nil)
+	if err != nil {
+		return time.Time{}, errdefs.Wrapf(err, "failed to read the device time")
+	}
+
+	sec, err := strconv.ParseInt(strings.TrimSpace(res.Stdout), 10, 64)
+	if err != nil || res.Code != 0 {
+		return time.Time{}, errdefs.Wrapf(errdefs.ErrInvalidResponse, "unexpected date output %q", res.Stdout)
+	}
+
+	return time.Unix(sec, 0), nil
+}
+
+// ParseLeaseFile parses the content of a miniupnpd lease file.
+// Each line has the form PROTO:EXTPORT:INTADDR:INTPORT:TIMESTAMP:DESCRIPTION; malformed lines are skipped.
+// The absolute TIMESTAMP is converted to the seconds remaining at now, the device time, as
+// luci.upnp reports them; mappings that expired by now are skipped.
+func ParseLeaseFile(content string, now time.Time) []Lease {
+	leases := []Lease{}
+
+	for line := range strings.SplitSeq(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		fields := strings.SplitN(line, ":", leaseFileFields)
+		if len(fields) < leaseFileFields {
+			continue
+		}
+
+		extPort, errExt := strconv.Atoi(fields[1])
+		intPort, errInt := strconv.Atoi(fields[3])
+
+		if errExt != nil || errInt != nil {
+			continue
+		}
+
+		timestamp, _ := strconv.ParseInt(fields[4], 10, 64)
+
+		var expires int64
+		if timestamp > 0 {
+			expires = timestamp - now.Unix()
+			if expires <= 0 {
+				continue
+			}
+		}
+
+		leases = append(leases, Lease{
+			Protocol:    strings.ToUpper(fields[0]),
+			ExtPort:     extPort,
+			IntAddr:     fields[2],
+			IntPort:     intPort,
+			Expires:     expires,
+			Description: fields[5],
+		})
+	}
+
+	return leases
+}
+
+// ConfigFromSection converts a UCI section into a Config.
+func ConfigFromSection(section *uci.Section) Config {
+	return Config{
+		Enabled:         section.GetBool("enabled"),
+		EnableUPnP:      section.GetBool("enable_upnp"),
+		EnableNATPMP:    section.GetBool("enable_natpmp"),
+		SecureMode:      section.GetBool("secure_mode"),
+		LogOutput:       section.GetBool("log_output"),
+		ExternalIface:   section.GetString("external_iface"),
+		InternalIface:   section.GetString("internal_iface"),
+		LeaseFile:       section.GetString("upnp_lease_file"),
+		PresentationURL: section.GetString("presentation_url"),
+		Download:        section.GetInt("download"),
+		Upload:          section.GetInt("upload"),
+		Port:            section.GetInt("port"),
+	}
+}
+
+// SectionValues converts the Config into UCI option values.
+func (c *Config) SectionValues() uci.SectionValues {
+	values := uci.NewSectionValues()
+	values.SetBool("enabled", c.Enabled)
+	values.SetBool("enable_upnp", c.EnableUPnP)
+	values.SetBool("enable_natpmp", c.EnableNATPMP)
+	values.SetBool("secure_mode", c.SecureMode)
+	values.SetBool("log_output", c.LogOutput)
+	values.SetScalar("external_iface", c.ExternalIface)
+	values.SetScalar("internal_iface", c.InternalIface)
+	values.SetScalar("upnp_lease_file", c.LeaseFile)
+	values.SetScalar("presentation_url", c.PresentationURL)
+
+	if c.Download > 0 {
+		values.Set("download", strconv.Itoa(c.Download))
+	}
+
+	if c.Upload > 0 {
+		values.Set("upload", strconv.Itoa(c.Upload))
+	}
+
+	if c.Port > 0 {
+		values.Set("port", strconv.Itoa(c.Port))
+	}
+
+	return values
+}
+
+// PermRuleFromSection converts a UCI section into a PermRule.
+func PermRuleFromSection(section *uci.Section) PermRule {
+	return PermRule{
+		Name:     section.Name,
+		Action:   section.GetString("action"),
+		ExtPorts: section.GetString("ext_ports"),
+		IntAddr:  section.GetString("int_addr"),
+		IntPorts: section.GetString("int_ports"),
+		Comment:  section.GetString("comment"),
+	}
+}
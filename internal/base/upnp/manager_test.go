@@ -0,0 +1,122 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package upnp_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/honeybbq/goubus/v2/internal/base/rc"
+	"github.com/honeybbq/goubus/v2/internal/base/upnp"
+	"github.com/honeybbq/goubus/v2/internal/testutil"
+)
+
+func TestUPnPManager(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Config", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("uci", "get", map[string]any{
+			"values": map[string]any{
+				".type":          "upnpd",
+				".name":          "config",
+				"enabled":        "1",
+				"enable_natpmp":  "1",
+				"enable_upnp":    "1",
+				"secure_mode":    "1",
+				"download":       "1024",
+				"upload":         "512",
+				"internal_iface": "lan",
+			},
+		})
+
+		mgr := upnp.New(mock)
+
+		cfg, err := mgr.Config(ctx)
+		if err != nil {
+			t.Fatalf("Config failed: %v", err)
+		}
+
+		if !cfg.Enabled || !cfg.EnableNATPMP || cfg.Download != 1024 || cfg.InternalIface != "lan" {
+			t.Errorf("unexpected config: %+v", cfg)
+		}
+	})
+
+	t.Run("Leases_LuciRPC", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("luci.upnp", "get_status", map[string]any{
+			"rules": []map[string]any{
+				{"proto": "UDP", "extport": 3074, "intaddr": "192.168.1.20", "intport": 3074, "descr": "Xbox", "expires": 3600},
+			},
+		})
+
+		mgr := upnp.New(mock)
+
+		leases, err := mgr.Leases(ctx)
+		if err != nil {
+			t.Fatalf("Leases failed: %v", err)
+		}
+
+		if len(leases) != 1 || leases[0].ExtPort != 3074 || leases[0].Description != "Xbox" || leases[0].Expires != 3600 {
+			t.Errorf("unexpected leases: %+v", leases)
+		}
+	})
+
+	t.Run("Leases_FileFallback", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("file", "read", map[string]any{
+			"data": "TCP:8080:192.168.1.10:80:1737109342:web:server\nbroken-line\n" +
+				"UDP:3074:192.168.1.20:3074:0:Xbox\nUDP:5000:192.168.1.30:5000:1737105000:expired\n",
+		})
+		mock.AddResponse("file", "exec", map[string]any{"code": 0, "stdout": "1737105742\n"})
+
+		mgr := upnp.New(mock)
+
+		leases, err := mgr.Leases(ctx)
+		if err != nil {
+			t.Fatalf("Leases failed: %v", err)
+		}
+
+		if len(leases) != 2 {
+			t.Fatalf("expected 2 leases, got %+v", leases)
+		}
+
+		// Like luci.upnp, the lease file reports the seconds remaining at the device time.
+		if leases[0].Description != "web:server" || leases[0].IntPort != 80 || leases[0].Expires != 3600 {
+			t.Errorf("unexpected lease: %+v", leases[0])
+		}
+
+		if leases[1].Description != "Xbox" || leases[1].Expires != 0 {
+			t.Errorf("expected a permanent lease, got %+v", leases[1])
+		}
+
+		call := mock.Calls[len(mock.Calls)-2]
+
+		params, ok := call.Data.(map[string]any)
+		if !ok || params["path"] != "/var/run/miniupnpd.leases" {
+			t.Errorf("unexpected lease file read: %+v", call.Data)
+		}
+	})
+
+	t.Run("SetEnabled", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("uci", "set", map[string]any{})
+		mock.AddResponse("uci", "commit", map[string]any{})
+		mock.AddResponse("rc", "init", map[string]any{})
+
+		mgr := upnp.New(mock)
+
+		err := mgr.SetEnabled(ctx, false)
+		if err != nil {
+			t.Fatalf("SetEnabled failed: %v", err)
+		}
+
+		call := mock.GetLastCall()
+
+		req, ok := call.Data.(rc.InitRequest)
+		if !ok || req.Name != "miniupnpd" || req.Action != "disable" {
+			t.Errorf("unexpected last call: %+v", call)
+		}
+	})
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package upnp
+
+// Config represents the main miniupnpd section ("config upnpd 'config'") in /etc/config/upnpd.
+type Config struct {
+	ExternalIface   string `json:"external_iface"`
+	InternalIface   string `json:"internal_iface"`
+	LeaseFile       string `json:"upnp_lease_file"`
+	PresentationURL string `json:"presentation_url"`
+	Download        int    `json:"download"`
+	Upload          int    `json:"upload"`
+	Port            int    `json:"port"`
+	Enabled         bool   `json:"enabled"`
+	EnableUPnP      bool   `json:"enable_upnp"`
+	EnableNATPMP    bool   `json:"enable_natpmp"`
+	SecureMode      bool   `json:"secure_mode"`
+	LogOutput       bool   `json:"log_output"`
+}
+
+// PermRule represents a "perm_rule" section controlling which clients may open which ports.
+type PermRule struct {
+	Name     string `json:"name"`
+	Action   string `json:"action"`
+	ExtPorts string `json:"ext_ports"`
+	IntAddr  string `json:"int_addr"`
+	IntPorts string `json:"int_ports"`
+	Comment  string `json:"comment"`
+}
+
+// Lease represents an active UPnP IGD or NAT-PMP port mapping.
+type Lease struct {
+	Protocol    string `json:"proto"`
+	IntAddr     string `json:"intaddr"`
+	Description string `json:"descr"`
+	HostHint    string `json:"host_hint,omitempty"`
+	ExtPort     int    `json:"extport"`
+	IntPort     int    `json:"intport"`
+	// Expires is the number of seconds until the mapping expires, or 0 if it does not expire.
+	Expires int64 `json:"expires"`
+}
+
+type statusResponse struct {
+	Rules []Lease `json:"rules"`
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package wireless
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strconv"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/uci"
+)
+
+// Weights of the channel score, which starts at maxScore.
+const (
+	maxScore = 100
+	// bssPenalty is subtracted for each network overlapping the channel, strongBSSPenalty once
+	// more for each one received above strongSignal.
+	bssPenalty       = 10
+	strongBSSPenalty = 5
+	strongSignal     = -70
+	// dfsPenalty accounts for the channel availability check a DFS channel needs.
+	dfsPenalty = 5
+
+	// band24 is the iwinfo band of 2.4 GHz channels, whose 20 MHz channels lie 5 MHz apart.
+	band24      = 2
+	bandwidth20 = 20
+	// signedByte converts the noise floor some drivers report as an unsigned byte.
+	signedByte = 256
+)
+
+type surveyResponse struct {
+	Results []SurveyEntry `json:"results"`
+}
+
+// ChannelSurvey retrieves the typed channel survey of the radio of the interface. Drivers
+// without survey support answer with an empty list.
+func (m *Manager) ChannelSurvey(ctx context.Context, device string) ([]SurveyEntry, error) {
+	params := map[string]any{"device": device}
+
+	res, err := goubus.Call[surveyResponse](ctx, m.caller, "iwinfo", "survey", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Results, nil
+}
+
+// PlanChannels scores the channels the radio of the interface may use with opts from its
+// frequency list, channel survey and a scan for neighbouring networks, best channel first.
+// The scan takes a few seconds and may briefly leave the operating channel.
+func (m *Manager) PlanChannels(ctx context.Context, device string, opts ChannelPlanOptions) ([]ChannelScore, error) {
+	freqs, err := m.Frequencies(ctx, device)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to get frequency list for %s", device)
+	}
+
+	survey, err := m.ChannelSurvey(ctx, device)
+	if err != nil && !errdefs.IsNotFound(err) && !errdefs.IsNotSupported(err) {
+		return nil, errdefs.Wrapf(err, "failed to get channel survey for %s", device)
+	}
+
+	networks, err := m.Scan(ctx, device)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to scan on %s", device)
+	}
+
+	return ScoreChannels(freqs, survey, networks, opts), nil
+}
+
+// ApplyBestChannel plans the channels of the interface, sets the best one on its radio, a
+// wifi-device section, and applies the change like Apply. It returns the chosen channel.
+func (m *Manager) ApplyBestChannel(
+	ctx context.Context, device, radio string, opts ChannelPlanOptions,
+) (*ChannelScore, error) {
+	scores, err := m.PlanChannels(ctx, device, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(scores) == 0 {
+		return nil, errdefs.Wrapf(errdefs.ErrNotFound, "no channel of %s allows the requested operation", device)
+	}
+
+	best := scores[0]
+
+	err = m.SetChannel(ctx, radio, best.Channel)
+	if err != nil {
+		return nil, err
+	}
+
+	return &best, m.Apply(ctx)
+}
+
+// SetChannel stages a fixed channel for the radio, a wifi-device section. Call Apply to
+// activate it.
+func (m *Manager) SetChannel(ctx context.Context, radio string, channel int) error {
+	if channel <= 0 {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid channel %d", channel)
+	}
+
+	values := uci.NewSectionValues()
+	values.Set("channel", strconv.Itoa(channel))
+
+	return m.setRadio(ctx, radio, values)
+}
+
+// ScoreChannels scores the frequencies that allow the operation opts describes, best channel
+// first. A channel starts at 100 and loses its busy time in percent, 10 points for each
+// network overlapping it and 5 more for each one received stronger than -70 dBm. DFS channels,
+// which are only considered with opts.AllowDFS, lose another 5 points for their availability
+// check.
+func ScoreChannels(
+	freqs []Frequency, survey []SurveyEntry, networks []ScanResult, opts ChannelPlanOptions,
+) []ChannelScore {
+	byMHz := make(map[int]SurveyEntry, len(survey))
+	for _, entry := range survey {
+		byMHz[entry.MHz] = entry
+	}
+
+	scores := make([]ChannelScore, 0, len(freqs))
+
+	for _, freq := range freqs {
+		if freq.Restricted || (freq.IsDFS() && !opts.AllowDFS) || !freq.AllowsBandwidth(opts.Bandwidth) {
+			continue
+		}
+
+		score := ChannelScore{Frequency: freq, DFS: freq.IsDFS()}
+
+		if entry, ok := byMHz[freq.MHz]; ok && entry.ActiveTime > 0 {
+			score.Surveyed = true
+			score.Occupancy = entry.Occupancy()
+			score.Noise = entry.NoiseDBm()
+		}
+
+		score.rate(networks, opts.Bandwidth)
+		scores = append(scores, score)
+	}
+
+	slices.SortStableFunc(scores, func(a, b ChannelScore) int { return cmp.Compare(b.Score, a.Score) })
+
+	return scores
+}
+
+// rate counts the networks overlapping the channel and computes the score.
+func (s *ChannelScore) rate(networks []ScanResult, bandwidth int) {
+	width := max(bandwidth, bandwidth20)
+	if s.Band == band24 {
+		width = bandwidth20
+	}
+
+	penalty := 0
+
+	for _, network := range networks {
+		if network.MHz == 0 || (network.Band != 0 && network.Band != s.Band) {
+			continue
+		}
+
+		if diff := network.MHz - s.MHz; diff > -width && diff < width {
+			s.Networks++
+
+			penalty += bssPenalty
+			if network.Signal > strongSignal {
+				penalty += strongBSSPenalty
+			}
+		}
+	}
+
+	if s.DFS {
+		penalty += dfsPenalty
+	}
+
+	s.Score = float64(maxScore-penalty) - s.Occupancy*maxScore
+}
+
+// Occupancy returns the fraction of the active time the channel was busy with other
+// transmissions, which excludes the own transmit time.
+func (e *SurveyEntry) Occupancy() float64 {
+	if e.ActiveTime <= 0 {
+		return 0
+	}
+
+	busy := max(e.BusyTime-e.TxTime, 0)
+
+	return min(float64(busy)/float64(e.ActiveTime), 1)
+}
+
+// NoiseDBm returns the noise floor in dBm, correcting drivers that report it as an unsigned byte.
+func (e *SurveyEntry) NoiseDBm() int {
+	if e.Noise > 0 {
+		return e.Noise - signedByte
+	}
+
+	return e.Noise
+}
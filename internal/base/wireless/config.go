@@ -0,0 +1,327 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package wireless
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/hostapd"
+	"github.com/honeybbq/goubus/v2/internal/base/uci"
+)
+
+const (
+	uciPackage     = "wireless"
+	uciDeviceType  = "wifi-device"
+	uciIfaceType   = "wifi-iface"
+	maxSSIDLength  = 32
+	minKeyLength   = 8
+	maxKeyLength   = 63
+	hexKeyLength   = 64
+	defaultKeyMode = "psk2"
+
+	// readyPollInterval is how often Apply checks whether the radios are back up.
+	readyPollInterval = time.Second
+	// reloadPollInterval is how often Apply checks whether netifd started reconfiguring the
+	// radios; it is short so that a quick restart is not missed.
+	reloadPollInterval = 100 * time.Millisecond
+	// reloadStartTimeout bounds the wait for netifd to start reconfiguring the radios. A reload
+	// that changes nothing leaves them up.
+	reloadStartTimeout = 5 * time.Second
+
+	// DefaultApplyTimeout bounds the wait of Apply when ctx has no deadline.
+	DefaultApplyTimeout = 2 * time.Minute
+)
+
+// SetSSID stages a new SSID for target, which is a wifi-iface section or a wifi-device section
+// whose interfaces all get the SSID. Call Apply to activate it.
+func (m *Manager) SetSSID(ctx context.Context, target, ssid string) error {
+	if ssid == "" || len(ssid) > maxSSIDLength {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid SSID %q", ssid)
+	}
+
+	values := uci.NewSectionValues()
+	values.Set("ssid", ssid)
+
+	return m.setInterfaces(ctx, target, func(*uci.Section) uci.SectionValues { return values })
+}
+
+// SetKey stages a new WPA key for target, which is a wifi-iface section or a wifi-device section
+// whose interfaces all get the key. Open interfaces switch to WPA2-PSK. Call Apply to activate it.
+func (m *Manager) SetKey(ctx context.Context, target, key string) error {
+	_, hexErr := hex.DecodeString(key)
+	if (len(key) < minKeyLength || len(key) > maxKeyLength) && (len(key) != hexKeyLength || hexErr != nil) {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter,
+			"the key must have %d to %d characters or %d hex digits", minKeyLength, maxKeyLength, hexKeyLength)
+	}
+
+	return m.setInterfaces(ctx, target, func(iface *uci.Section) uci.SectionValues {
+		values := uci.NewSectionValues()
+		values.Set("key", key)
+
+		encryption := iface.GetString("encryption")
+		if encryption == "" || encryption == "none" || encryption == "owe" {
+			values.Set("encryption", defaultKeyMode)
+		}
+
+		return values
+	})
+}
+
+// SetWPS stages the WPS options of target, which is a wifi-iface section or a wifi-device section
+// whose interfaces all get them. hostapd offers WPS only on WPA2-PSK interfaces, including
+// WPA2/WPA3 mixed mode, and only with the full wpad or hostapd package. Call Apply to activate
+// the options; start sessions with the hostapd PushButton and PIN methods.
+func (m *Manager) SetWPS(ctx context.Context, target string, cfg WPSConfig) error {
+	ifaces, err := m.interfaces(ctx, target)
+	if err != nil {
+		return err
+	}
+
+	for _, iface := range ifaces {
+		encryption := iface.GetString("encryption")
+		if cfg.PushButton && !strings.HasPrefix(encryption, "psk") && !strings.HasPrefix(encryption, "sae-mixed") {
+			return errdefs.Wrapf(errdefs.ErrNotSupported, "WPS requires WPA2-PSK, %s uses %q", iface.Name, encryption)
+		}
+	}
+
+	return m.setInterfaces(ctx, target, func(*uci.Section) uci.SectionValues { return cfg.SectionValues() })
+}
+
+// SectionValues converts the WPS options into wifi-iface option values.
+func (c *WPSConfig) SectionValues() uci.SectionValues {
+	values := uci.NewSectionValues()
+	values.SetBool("wps_pushbutton", c.PushButton)
+	values.SetScalar("wps_device_name", c.DeviceName)
+	values.SetScalar("wps_manufacturer", c.Manufacturer)
+
+	return values
+}
+
+// EnableRadio stages enabling the radio, a wifi-device section. Call Apply to activate it.
+func (m *Manager) EnableRadio(ctx context.Context, radio string) error {
+	return m.setRadioDisabled(ctx, radio, false)
+}
+
+// DisableRadio stages disabling the radio, a wifi-device section. Call Apply to activate it.
+func (m *Manager) DisableRadio(ctx context.Context, radio string) error {
+	return m.setRadioDisabled(ctx, radio, true)
+}
+
+// Apply commits the wireless package, reloads the network like "wifi reload" and waits until
+// every enabled radio is up and hostapd answers for each of its access points. As netifd
+// reconfigures the radios asynchronously, it first waits up to a few seconds for a radio to go
+// down or pending, so that the status from before the reload is not mistaken for the result.
+// The wait ends with ctx, or after DefaultApplyTimeout if ctx has no deadline; on a DFS
+// channel hostapd answers while the channel availability check still runs.
+func (m *Manager) Apply(ctx context.Context) error {
+	err := m.uci.Package(uciPackage).Commit(ctx)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to commit wireless")
+	}
+
+	_, err = m.caller.Call(ctx, "network", "reload", nil)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to reload the network")
+	}
+
+	return m.waitReady(ctx)
+}
+
+func (m *Manager) setRadioDisabled(ctx context.Context, radio string, disabled bool) error {
+	values := uci.NewSectionValues()
+	values.SetBool("disabled", disabled)
+
+	return m.setRadio(ctx, radio, values)
+}
+
+// setRadio stages values on the radio, checking that it is a wifi-device section.
+func (m *Manager) setRadio(ctx context.Context, radio string, values uci.SectionValues) error {
+	section, err := m.uci.Package(uciPackage).Section(radio).Get(ctx)
+	if err != nil {
+		return errdefs.Wrapf(err, "radio %s", radio)
+	}
+
+	if section.Type != uciDeviceType {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "%s is a %s section, not a radio", radio, section.Type)
+	}
+
+	return m.uci.Package(uciPackage).Section(section.Name).SetValues(ctx, values)
+}
+
+// setInterfaces stages the values change returns for each interface target selects.
+func (m *Manager) setInterfaces(
+	ctx context.Context, target string, change func(*uci.Section) uci.SectionValues,
+) error {
+	ifaces, err := m.interfaces(ctx, target)
+	if err != nil {
+		return err
+	}
+
+	for _, iface := range ifaces {
+		err = m.uci.Package(uciPackage).Section(iface.Name).SetValues(ctx, change(iface))
+		if err != nil {
+			return errdefs.Wrapf(err, "failed to set %s", iface.Name)
+		}
+	}
+
+	return nil
+}
+
+// interfaces returns the wifi-iface sections target selects: the section itself, or every
+// interface of a wifi-device section.
+func (m *Manager) interfaces(ctx context.Context, target string) ([]*uci.Section, error) {
+	pkg := m.uci.Package(uciPackage)
+
+	section, err := pkg.Section(target).Get(ctx)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "wireless section %s", target)
+	}
+
+	switch section.Type {
+	case uciIfaceType:
+		return []*uci.Section{section}, nil
+	case uciDeviceType:
+		ifaces, err := pkg.Query(uciIfaceType).Where("device", section.Name).All(ctx)
+		if err != nil {
+			return nil, errdefs.Wrapf(err, "failed to read the interfaces of %s", section.Name)
+		}
+
+		if len(ifaces) == 0 {
+			return nil, errdefs.Wrapf(errdefs.ErrNotFound, "radio %s has no interfaces", section.Name)
+		}
+
+		return ifaces, nil
+	default:
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "%s is a %s section, not a radio or interface",
+			target, section.Type)
+	}
+}
+
+// waitReady waits for the reload to start, then polls the radio status until nothing is pending.
+func (m *Manager) waitReady(ctx context.Context) error {
+	_, ok := ctx.Deadline()
+	if !ok {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, DefaultApplyTimeout)
+		defer cancel()
+	}
+
+	err := m.waitReload(ctx)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(readyPollInterval)
+	defer ticker.Stop()
+
+	for {
+		pending, err := m.pending(ctx)
+		if err != nil {
+			return err
+		}
+
+		if pending == "" {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return errdefs.Wrapf(errdefs.FromTransport(ctx.Err()), "wait for %s to come up", pending)
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitReload polls the radio status until an enabled radio is down or pending, which shows that
+// netifd picked up the reload, for at most reloadStartTimeout.
+func (m *Manager) waitReload(ctx context.Context) error {
+	timer := time.NewTimer(reloadStartTimeout)
+	defer timer.Stop()
+
+	ticker := time.NewTicker(reloadPollInterval)
+	defer ticker.Stop()
+
+	for {
+		status, err := goubus.Call[radioStatusResponse](ctx, m.caller, "network.wireless", "status", nil)
+		if err != nil {
+			return errdefs.Wrapf(err, "failed to read the wireless status")
+		}
+
+		if status.reloading() {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return errdefs.Wrapf(errdefs.FromTransport(ctx.Err()), "wait for the wireless reload to start")
+		case <-timer.C:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// reloading reports whether an enabled radio is down or pending.
+func (r *radioStatusResponse) reloading() bool {
+	for _, radio := range r.Radio {
+		if !radio.Disabled && (!radio.Up || radio.Pending) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pending returns the first radio or access point that is not up yet, or "" when all are.
+func (m *Manager) pending(ctx context.Context) (string, error) {
+	status, err := goubus.Call[radioStatusResponse](ctx, m.caller, "network.wireless", "status", nil)
+	if err != nil {
+		return "", errdefs.Wrapf(err, "failed to read the wireless status")
+	}
+
+	aps := hostapd.New(m.caller)
+
+	for name, radio := range status.Radio {
+		if radio.Disabled {
+			continue
+		}
+
+		if !radio.Up || radio.Pending {
+			return "radio " + name, nil
+		}
+
+		pending, err := pendingAP(ctx, aps, radio)
+		if pending != "" || err != nil {
+			return pending, err
+		}
+	}
+
+	return "", nil
+}
+
+// pendingAP returns the first access point of radio hostapd does not answer for.
+func pendingAP(ctx context.Context, aps *hostapd.Manager, radio radioStatus) (string, error) {
+	for _, iface := range radio.Interfaces {
+		if iface.Ifname == "" || (iface.Config.Mode != "" && iface.Config.Mode != "ap") {
+			continue
+		}
+
+		_, err := aps.AP("hostapd." + iface.Ifname).GetStatus(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return "", errdefs.FromTransport(ctx.Err())
+			}
+
+			return "hostapd on " + iface.Ifname, nil
+		}
+	}
+
+	return "", nil
+}
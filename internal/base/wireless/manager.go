@@ -5,18 +5,42 @@ package wireless
 
 import (
 	"context"
+	"slices"
+	"strings"
 
 	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/hostapd"
+	"github.com/honeybbq/goubus/v2/internal/base/uci"
 )
 
-// Manager provides methods to interact with 'iwinfo'.
+// DFS range of the 5 GHz band (channels 52-144), in MHz.
+const (
+	dfsRangeStart = 5250
+	dfsRangeEnd   = 5730
+)
+
+// Channel widths in MHz checked against the iwinfo frequency flags.
+const (
+	bandwidth40  = 40
+	bandwidth80  = 80
+	bandwidth160 = 160
+)
+
+// Manager provides methods to interact with 'iwinfo' and to change the wireless configuration.
 type Manager struct {
 	caller goubus.Transport
+	uci    *uci.Manager
 }
 
 // New creates a new base wireless Manager.
 func New(t goubus.Transport) *Manager {
-	return &Manager{caller: t}
+	return &Manager{caller: t, uci: uci.New(t, nil)}
+}
+
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"iwinfo", "uci", "network", "network.wireless"}
 }
 
 type devicesResponse struct {
@@ -56,6 +80,10 @@ func (m *Manager) Scan(ctx context.Context, device string) ([]ScanResult, error)
 	return res.Results, nil
 }
 
+type frequencyListResponse struct {
+	Results []Frequency `json:"results"`
+}
+
 type assocListResponse struct {
 	Results []Assoc `json:"results"`
 }
@@ -72,6 +100,58 @@ func (m *Manager) AssocList(ctx context.Context, device string) ([]Assoc, error)
 	return res.Results, nil
 }
 
+// Stations retrieves the associated stations of an interface together with their capabilities.
+// Features, airtime and RRM capabilities come from the hostapd.<device> object when available;
+// features are completed with the rates, spatial streams and channel widths observed by iwinfo.
+func (m *Manager) Stations(ctx context.Context, device string) ([]Station, error) {
+	assocs, err := m.AssocList(ctx, device)
+	if err != nil {
+		return nil, err
+	}
+
+	clients, err := hostapd.New(m.caller).AP("hostapd." + device).Clients(ctx)
+	if err != nil && !errdefs.IsNotSupported(err) && !errdefs.IsNotFound(err) && !errdefs.IsPermissionDenied(err) {
+		return nil, errdefs.Wrapf(err, "failed to get hostapd clients for %s", device)
+	}
+
+	byMAC := make(map[string]hostapd.Client, len(clients))
+	for _, client := range clients {
+		byMAC[strings.ToUpper(client.MAC)] = client
+	}
+
+	stations := make([]Station, 0, len(assocs))
+	for _, assoc := range assocs {
+		client := byMAC[strings.ToUpper(assoc.Mac)]
+
+		stations = append(stations, Station{
+			Assoc:    assoc,
+			Features: mergeObservedFeatures(client.Features(), assoc),
+			RRM:      client.RRM,
+			Airtime:  client.Airtime,
+		})
+	}
+
+	return stations, nil
+}
+
+// mergeObservedFeatures completes advertised features with what iwinfo observed on the current rates.
+func mergeObservedFeatures(features hostapd.Features, assoc Assoc) hostapd.Features {
+	for _, rate := range []AssocRate{assoc.Rx, assoc.Tx} {
+		features.HT = features.HT || bool(rate.IsHt)
+		features.VHT = features.VHT || bool(rate.IsVht)
+		features.HE = features.HE || bool(rate.IsHe)
+		features.EHT = features.EHT || bool(rate.IsEht)
+		features.MaxSpatialStreams = max(features.MaxSpatialStreams, rate.Nss)
+		features.MaxBandwidth = max(features.MaxBandwidth, rate.Mhz)
+	}
+
+	features.MaxRxRate = max(features.MaxRxRate, assoc.Rx.Rate)
+	features.MaxTxRate = max(features.MaxTxRate, assoc.Tx.Rate)
+	features.Supports160MHz = features.MaxBandwidth >= bandwidth160
+
+	return features
+}
+
 // FreqList retrieves the list of available frequencies for the interface.
 func (m *Manager) FreqList(ctx context.Context, device string) ([]any, error) {
 	params := map[string]any{"device": device}
@@ -84,6 +164,94 @@ func (m *Manager) FreqList(ctx context.Context, device string) ([]any, error) {
 	return (*res)["results"], nil
 }
 
+// Frequencies retrieves the typed list of frequencies supported by the interface.
+func (m *Manager) Frequencies(ctx context.Context, device string) ([]Frequency, error) {
+	params := map[string]any{"device": device}
+
+	res, err := goubus.Call[frequencyListResponse](ctx, m.caller, "iwinfo", "freqlist", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Results, nil
+}
+
+// SwitchChannel moves the AP on the given interface to a new channel with a channel switch
+// announcement. The target is validated against the interface frequency list and the
+// current DFS state before hostapd is asked to switch.
+func (m *Manager) SwitchChannel(ctx context.Context, device string, req ChannelSwitch) error {
+	freqs, err := m.Frequencies(ctx, device)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to get frequency list for %s", device)
+	}
+
+	ap := hostapd.New(m.caller).AP("hostapd." + device)
+
+	status, err := ap.Status(ctx)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to get hostapd status for %s", device)
+	}
+
+	err = ValidateChannelSwitch(freqs, status, req)
+	if err != nil {
+		return err
+	}
+
+	return ap.SwitchChannel(ctx, req.SwitchChanRequest)
+}
+
+// ValidateChannelSwitch checks a channel switch against the frequency list and DFS state of a radio.
+func ValidateChannelSwitch(freqs []Frequency, status *hostapd.Status, req ChannelSwitch) error {
+	if status != nil && status.DFS.CACActive {
+		return errdefs.Wrapf(errdefs.ErrInvalidCommand,
+			"channel availability check in progress (%ds left)", status.DFS.CACSecondsLeft)
+	}
+
+	idx := slices.IndexFunc(freqs, func(f Frequency) bool { return f.MHz == req.Freq })
+	if idx < 0 {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "frequency %d MHz is not supported by the radio", req.Freq)
+	}
+
+	target := freqs[idx]
+	if target.Restricted {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "channel %d is restricted", target.Channel)
+	}
+
+	if !req.AllowDFS && target.IsDFS() {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "channel %d requires DFS", target.Channel)
+	}
+
+	if !target.AllowsBandwidth(req.Bandwidth) {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter,
+			"channel %d does not allow %d MHz operation", target.Channel, req.Bandwidth)
+	}
+
+	if req.HE && slices.Contains(target.Flags, "no_he") {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "channel %d does not allow HE operation", target.Channel)
+	}
+
+	return nil
+}
+
+// IsDFS reports whether the frequency lies in the 5 GHz range that requires radar detection.
+func (f *Frequency) IsDFS() bool {
+	return f.MHz > dfsRangeStart && f.MHz < dfsRangeEnd
+}
+
+// AllowsBandwidth reports whether the regulatory flags permit the given channel width in MHz.
+func (f *Frequency) AllowsBandwidth(mhz int) bool {
+	switch mhz {
+	case bandwidth40:
+		return !slices.Contains(f.Flags, "no_ht40+") || !slices.Contains(f.Flags, "no_ht40-")
+	case bandwidth80:
+		return !slices.Contains(f.Flags, "no_80mhz")
+	case bandwidth160:
+		return !slices.Contains(f.Flags, "no_160mhz")
+	default:
+		return true
+	}
+}
+
 // TxPowerList retrieves the list of available transmit power settings.
 func (m *Manager) TxPowerList(ctx context.Context, device string) ([]any, error) {
 	params := map[string]any{"device": device}
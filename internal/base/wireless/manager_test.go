@@ -5,8 +5,15 @@ package wireless_test
 
 import (
 	"context"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/hostapd"
+	"github.com/honeybbq/goubus/v2/internal/base/uci"
 	"github.com/honeybbq/goubus/v2/internal/base/wireless"
 	"github.com/honeybbq/goubus/v2/internal/testutil"
 )
@@ -32,6 +39,18 @@ func TestWirelessManager(t *testing.T) {
 		testWirelessAssocList(t, ctx, mock, mgr)
 	})
 
+	t.Run("Stations", func(t *testing.T) {
+		testWirelessStations(t, ctx, mgr)
+	})
+
+	t.Run("RateTracker", func(t *testing.T) {
+		testWirelessRateTracker(t)
+	})
+
+	t.Run("SwitchChannel", func(t *testing.T) {
+		testWirelessSwitchChannel(t, ctx)
+	})
+
 	t.Run("Lists", func(t *testing.T) {
 		testWirelessLists(t, ctx, mock, mgr)
 	})
@@ -39,6 +58,26 @@ func TestWirelessManager(t *testing.T) {
 	t.Run("PhyName", func(t *testing.T) {
 		testWirelessPhyName(t, ctx, mock, mgr)
 	})
+
+	t.Run("Config", func(t *testing.T) {
+		testWirelessConfig(t, ctx)
+	})
+
+	t.Run("Apply", func(t *testing.T) {
+		testWirelessApply(t, ctx)
+	})
+
+	t.Run("Channels", func(t *testing.T) {
+		testWirelessChannels(t, ctx)
+	})
+
+	t.Run("Scanner", func(t *testing.T) {
+		testWirelessScanner(t, ctx)
+	})
+
+	t.Run("WatchStations", func(t *testing.T) {
+		testWirelessWatchStations(t, ctx)
+	})
 }
 
 func testWirelessDevices(t *testing.T, ctx context.Context, mock *testutil.MockTransport, mgr *wireless.Manager) {
@@ -98,9 +137,14 @@ func testWirelessAssocList(t *testing.T, ctx context.Context, mock *testutil.Moc
 	mock.AddResponse("iwinfo", "assoclist", map[string]any{
 		"results": []map[string]any{
 			{
-				"mac":    "00:11:22:33:44:55",
-				"signal": -50,
-				"rx":     map[string]any{"rate": 100000},
+				"mac":       "00:11:22:33:44:55",
+				"signal":    -50,
+				"thr":       202384,
+				"preamble":  "short",
+				"wme":       true,
+				"mesh llid": 0,
+				"rx":        map[string]any{"rate": 100000, "packets": 510, "drop_misc": 2},
+				"tx":        map[string]any{"rate": 72200, "ht": true, "mcs": 15, "40mhz": false, "short_gi": true, "retries": 43},
 			},
 		},
 	})
@@ -111,7 +155,20 @@ func testWirelessAssocList(t *testing.T, ctx context.Context, mock *testutil.Moc
 	}
 
 	if len(clients) != 1 || clients[0].Mac != "00:11:22:33:44:55" {
-		t.Errorf("unexpected client data: %+v", clients[0])
+		t.Fatalf("unexpected client data: %+v", clients)
+	}
+
+	client := clients[0]
+	if client.ExpectedThroughput != 202384 || client.Preamble != "short" || !client.WME {
+		t.Errorf("unexpected station fields: %+v", client)
+	}
+
+	if client.Rx.Packets != 510 || client.Rx.DropMisc != 2 || client.Tx.Retries != 43 || !client.Tx.ShortGI {
+		t.Errorf("unexpected rate counters: rx %+v, tx %+v", client.Rx, client.Tx)
+	}
+
+	if key := client.Tx.Key(); key != (wireless.RateKey{Mode: wireless.ModeHT, MCS: 7, NSS: 2}) {
+		t.Errorf("unexpected HT rate key: %v", key)
 	}
 }
 
@@ -156,3 +213,592 @@ func testWirelessPhyName(t *testing.T, ctx context.Context, mock *testutil.MockT
 		t.Errorf("expected phy0, got %s", phy)
 	}
 }
+
+func testWirelessStations(t *testing.T, ctx context.Context, mgr *wireless.Manager) {
+	t.Helper()
+
+	t.Run("WithHostapd", func(t *testing.T) {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("iwinfo", "assoclist", map[string]any{
+			"results": []map[string]any{
+				{
+					"mac": "AA:AA:AA:AA:AA:AA",
+					"rx":  map[string]any{"rate": 1201000, "nss": 2, "he": true, "mhz": 160},
+					"tx":  map[string]any{"rate": 960000, "nss": 2, "he": true, "mhz": 80},
+				},
+			},
+		})
+		mock.AddResponse("hostapd.phy1-ap0", "get_clients", map[string]any{
+			"clients": map[string]any{
+				"aa:aa:aa:aa:aa:aa": map[string]any{
+					"wmm":          true,
+					"vht":          true,
+					"rrm":          []int{115, 0, 0, 0, 0},
+					"airtime":      map[string]any{"rx": 1200, "tx": 3400},
+					"capabilities": map[string]any{"vht": map[string]any{"mu_beamformee": true}},
+				},
+			},
+		})
+
+		stations, err := wireless.New(mock).Stations(ctx, "phy1-ap0")
+		if err != nil {
+			t.Fatalf("Stations failed: %v", err)
+		}
+
+		if len(stations) != 1 {
+			t.Fatalf("expected 1 station, got %d", len(stations))
+		}
+
+		features := stations[0].Features
+		if !features.HE || !features.VHT || !features.MUMIMO || !features.WMM || !features.Supports160MHz {
+			t.Errorf("unexpected features: %+v", features)
+		}
+
+		if features.MaxSpatialStreams != 2 || features.MaxRxRate != 1201000 {
+			t.Errorf("unexpected streams/rate: %+v", features)
+		}
+
+		if len(stations[0].RRM) != 5 || stations[0].Airtime.Tx != 3400 {
+			t.Errorf("unexpected hostapd fields: rrm %v, airtime %+v", stations[0].RRM, stations[0].Airtime)
+		}
+	})
+
+	t.Run("WithoutHostapd", func(t *testing.T) {
+		stations, err := mgr.Stations(ctx, "wlan0")
+		if err != nil {
+			t.Fatalf("Stations failed: %v", err)
+		}
+
+		for _, station := range stations {
+			if station.Features.MUMIMO {
+				t.Errorf("unexpected MU-MIMO without hostapd data: %+v", station)
+			}
+		}
+	})
+}
+
+func testWirelessSwitchChannel(t *testing.T, ctx context.Context) {
+	t.Helper()
+
+	newMock := func(cacActive bool) *testutil.MockTransport {
+		mock := testutil.NewMockTransport()
+		mock.AddResponse("iwinfo", "freqlist", map[string]any{
+			"results": []map[string]any{
+				{"channel": 36, "mhz": 5180, "flags": []string{}, "active": true},
+				{"channel": 52, "mhz": 5260, "flags": []string{}},
+				{"channel": 149, "mhz": 5745, "flags": []string{"no_160mhz"}},
+			},
+		})
+		mock.AddResponse("hostapd.phy1-ap0", "get_status", map[string]any{
+			"freq": 5180,
+			"dfs":  map[string]any{"cac_active": cacActive, "cac_seconds_left": 42},
+		})
+		mock.AddResponse("hostapd.phy1-ap0", "switch_chan", map[string]any{})
+
+		return mock
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		mock := newMock(false)
+		req := wireless.ChannelSwitch{}
+		req.Freq = 5745
+		req.Bandwidth = 80
+		req.BcnCount = 5
+
+		err := wireless.New(mock).SwitchChannel(ctx, "phy1-ap0", req)
+		if err != nil {
+			t.Fatalf("SwitchChannel failed: %v", err)
+		}
+
+		call := mock.GetLastCall()
+
+		sent, ok := call.Data.(hostapd.SwitchChanRequest)
+		if !ok || call.Method != "switch_chan" || sent.Freq != 5745 || sent.BcnCount != 5 {
+			t.Errorf("unexpected last call: %+v", call)
+		}
+	})
+
+	rejected := []struct {
+		name      string
+		freq      int
+		bandwidth int
+		cacActive bool
+	}{
+		{name: "UnknownFrequency", freq: 5500},
+		{name: "DFS", freq: 5260},
+		{name: "Bandwidth", freq: 5745, bandwidth: 160},
+		{name: "CACActive", freq: 5180, cacActive: true},
+	}
+
+	for _, tc := range rejected {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := newMock(tc.cacActive)
+			req := wireless.ChannelSwitch{}
+			req.Freq = tc.freq
+			req.Bandwidth = tc.bandwidth
+
+			err := wireless.New(mock).SwitchChannel(ctx, "phy1-ap0", req)
+			if err == nil {
+				t.Fatal("expected validation error")
+			}
+
+			if mock.GetLastCall().Method == "switch_chan" {
+				t.Error("switch_chan must not be called for a rejected target")
+			}
+		})
+	}
+}
+
+func testWirelessRateTracker(t *testing.T) {
+	t.Helper()
+
+	he := func(mcs, packets int64) wireless.AssocRate {
+		return wireless.AssocRate{IsHe: true, Mcs: int(mcs), Nss: 2, Mhz: 80, Packets: packets}
+	}
+
+	tracker := wireless.NewRateTracker()
+	tracker.Observe([]wireless.Assoc{{Mac: "aa:aa:aa:aa:aa:aa", Rx: he(11, 100), Tx: he(9, 50)}})
+	tracker.Observe([]wireless.Assoc{{Mac: "AA:AA:AA:AA:AA:AA", Rx: he(11, 400), Tx: he(7, 80)}})
+	tracker.Observe([]wireless.Assoc{{Mac: "AA:AA:AA:AA:AA:AA", Rx: he(9, 500), Tx: he(7, 20)}})
+
+	breakdown, ok := tracker.Breakdown("aa:aa:aa:aa:aa:aa")
+	if !ok {
+		t.Fatal("expected a breakdown for the station")
+	}
+
+	mcs11 := wireless.RateKey{Mode: wireless.ModeHE, MCS: 11, NSS: 2, MHz: 80}
+	if usage := breakdown.Rx[mcs11]; usage.Samples != 2 || usage.Packets != 300 {
+		t.Errorf("unexpected MCS11 usage: %+v", usage)
+	}
+
+	mcs7 := wireless.RateKey{Mode: wireless.ModeHE, MCS: 7, NSS: 2, MHz: 80}
+	if usage := breakdown.Tx[mcs7]; usage.Samples != 2 || usage.Packets != 30 {
+		t.Errorf("unexpected MCS7 usage after counter reset: %+v", usage)
+	}
+
+	if mcs11.String() != "HE-MCS11 2SS 80MHz" {
+		t.Errorf("unexpected key string: %s", mcs11)
+	}
+
+	tracker.Observe(nil)
+
+	if _, ok := tracker.Breakdown("aa:aa:aa:aa:aa:aa"); ok {
+		t.Error("expected the departed station to be forgotten")
+	}
+}
+
+func addWirelessSection(mock *testutil.MockTransport, name string, values map[string]any) {
+	mock.AddResponseForArgs("uci", "get",
+		uci.GetRequest{RequestGeneric: uci.RequestGeneric{Config: "wireless", Section: name}},
+		map[string]any{"values": values})
+}
+
+func testWirelessConfig(t *testing.T, ctx context.Context) {
+	t.Helper()
+
+	mock := testutil.NewMockTransport()
+	mgr := wireless.New(mock)
+	radio := map[string]any{".type": "wifi-device", ".name": "radio0", "band": "5g"}
+	addWirelessSection(mock, "radio0", radio)
+	addWirelessSection(mock, "default_radio0", map[string]any{
+		".type": "wifi-iface", ".name": "default_radio0", "device": "radio0", "encryption": "none",
+	})
+	mock.AddResponseForArgs("uci", "get",
+		uci.GetRequest{RequestGeneric: uci.RequestGeneric{
+			Config: "wireless", Type: "wifi-iface", Match: map[string]string{"device": "radio0"},
+		}},
+		map[string]any{"values": map[string]any{
+			"default_radio0": map[string]any{".type": "wifi-iface", ".name": "default_radio0", ".index": 1},
+			"guest_radio0":   map[string]any{".type": "wifi-iface", ".name": "guest_radio0", ".index": 2},
+		}})
+	mock.AddResponse("uci", "set", map[string]any{})
+
+	sets := func() []uci.Request {
+		var requests []uci.Request
+
+		for _, call := range mock.Calls {
+			if req, ok := call.Data.(uci.Request); ok && call.Method == "set" {
+				requests = append(requests, req)
+			}
+		}
+
+		mock.Calls = nil
+
+		return requests
+	}
+
+	err := mgr.SetSSID(ctx, "radio0", "HomeNet")
+	if err != nil {
+		t.Fatalf("SetSSID failed: %v", err)
+	}
+
+	requests := sets()
+	if len(requests) != 2 || requests[0].Section != "default_radio0" || requests[1].Values["ssid"] != "HomeNet" {
+		t.Errorf("unexpected SSID changes: %+v", requests)
+	}
+
+	err = mgr.SetKey(ctx, "default_radio0", "correct horse")
+	if err != nil {
+		t.Fatalf("SetKey failed: %v", err)
+	}
+
+	requests = sets()
+	if len(requests) != 1 || requests[0].Values["key"] != "correct horse" || requests[0].Values["encryption"] != "psk2" {
+		t.Errorf("unexpected key change: %+v", requests)
+	}
+
+	err = mgr.DisableRadio(ctx, "radio0")
+	if err != nil {
+		t.Fatalf("DisableRadio failed: %v", err)
+	}
+
+	requests = sets()
+	if len(requests) != 1 || requests[0].Section != "radio0" || requests[0].Values["disabled"] != "1" {
+		t.Errorf("unexpected radio change: %+v", requests)
+	}
+
+	err = mgr.SetWPS(ctx, "default_radio0", wireless.WPSConfig{PushButton: true})
+	if !errdefs.IsNotSupported(err) {
+		t.Errorf("expected WPS on an open interface to fail, got %v", err)
+	}
+
+	addWirelessSection(mock, "secure", map[string]any{
+		".type": "wifi-iface", ".name": "secure", "device": "radio0", "encryption": "psk2+ccmp",
+	})
+
+	err = mgr.SetWPS(ctx, "secure", wireless.WPSConfig{PushButton: true, DeviceName: "OpenWrt AP"})
+	if err != nil {
+		t.Fatalf("SetWPS failed: %v", err)
+	}
+
+	requests = sets()
+	if len(requests) != 1 || requests[0].Values["wps_pushbutton"] != "1" ||
+		requests[0].Values["wps_device_name"] != "OpenWrt AP" {
+		t.Errorf("unexpected WPS change: %+v", requests)
+	}
+
+	for _, err := range []error{
+		mgr.SetKey(ctx, "radio0", "short"),
+		mgr.SetSSID(ctx, "radio0", ""),
+		mgr.EnableRadio(ctx, "default_radio0"),
+	} {
+		if !errdefs.IsInvalidParameter(err) {
+			t.Errorf("expected an invalid parameter error, got %v", err)
+		}
+	}
+}
+
+// statusSequence returns the network.wireless statuses in turn, repeating the last one.
+type statusSequence struct {
+	*testutil.MockTransport
+
+	statuses []map[string]any
+}
+
+func (s *statusSequence) Call(ctx context.Context, service, method string, data any) (goubus.Result, error) {
+	if service == "network.wireless" && method == "status" {
+		s.AddResponse(service, method, map[string]any{"radio": s.statuses[0]})
+		if len(s.statuses) > 1 {
+			s.statuses = s.statuses[1:]
+		}
+	}
+
+	return s.MockTransport.Call(ctx, service, method, data)
+}
+
+func testWirelessApply(t *testing.T, ctx context.Context) {
+	t.Helper()
+
+	ready := map[string]any{
+		"radio0": map[string]any{
+			"up": true, "pending": false, "disabled": false,
+			"interfaces": []map[string]any{
+				{"section": "default_radio0", "ifname": "phy0-ap0", "config": map[string]any{"mode": "ap"}},
+				{"section": "mesh", "ifname": "phy0-mesh0", "config": map[string]any{"mode": "mesh"}},
+			},
+		},
+		"radio1": map[string]any{"up": false, "disabled": true},
+	}
+	pending := map[string]any{"radio0": map[string]any{"up": true, "pending": true}}
+
+	// The first status still shows the radios as they were before the reload.
+	mock := &statusSequence{MockTransport: testutil.NewMockTransport(), statuses: []map[string]any{ready, pending, ready}}
+	mock.AddResponse("uci", "commit", map[string]any{})
+	mock.AddResponse("network", "reload", map[string]any{})
+	mock.AddResponse("hostapd.phy0-ap0", "get_status", map[string]any{"status": "ENABLED"})
+
+	err := wireless.New(mock).Apply(ctx)
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	methods := make([]string, len(mock.Calls))
+	for i, call := range mock.Calls {
+		methods[i] = call.Service + "." + call.Method
+	}
+
+	want := []string{
+		"uci.commit", "network.reload", "network.wireless.status", "network.wireless.status",
+		"network.wireless.status", "hostapd.phy0-ap0.get_status",
+	}
+	if !slices.Equal(methods, want) {
+		t.Errorf("unexpected calls: %v", methods)
+	}
+
+	mock.statuses = []map[string]any{pending}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+
+	err = wireless.New(mock).Apply(ctx)
+	if !errdefs.IsTimeout(err) && !errdefs.IsCanceled(err) {
+		t.Errorf("expected the wait to time out, got %v", err)
+	}
+}
+
+func testWirelessChannels(t *testing.T, ctx context.Context) {
+	t.Helper()
+
+	mock := testutil.NewMockTransport()
+	mock.AddResponse("iwinfo", "freqlist", map[string]any{
+		"results": []map[string]any{
+			{"band": 5, "channel": 36, "mhz": 5180, "flags": []string{}, "active": true},
+			{"band": 5, "channel": 52, "mhz": 5260, "flags": []string{}},
+			{"band": 5, "channel": 100, "mhz": 5500, "flags": []string{}},
+			{"band": 5, "channel": 149, "mhz": 5745, "flags": []string{"no_80mhz"}},
+			{"band": 5, "channel": 157, "mhz": 5785, "flags": []string{}},
+		},
+	})
+	mock.AddResponse("iwinfo", "survey", map[string]any{
+		"results": []map[string]any{
+			{"mhz": 5180, "noise": 164, "active_time": 1000, "busy_time": 600, "tx_time": 100},
+			{"mhz": 5785, "noise": -95, "active_time": 1000, "busy_time": 100, "tx_time": 0},
+		},
+	})
+	mock.AddResponse("iwinfo", "scan", map[string]any{
+		"results": []map[string]any{
+			{"ssid": "Neighbour", "band": 5, "channel": 40, "mhz": 5200, "signal": -60},
+			{"ssid": "Far", "band": 5, "channel": 161, "mhz": 5805, "signal": -85},
+		},
+	})
+
+	mgr := wireless.New(mock)
+
+	scores, err := mgr.PlanChannels(ctx, "phy1-ap0", wireless.ChannelPlanOptions{Bandwidth: 80})
+	if err != nil {
+		t.Fatalf("PlanChannels failed: %v", err)
+	}
+
+	channels := make([]int, len(scores))
+	for i, score := range scores {
+		channels[i] = score.Channel
+	}
+
+	// 149 does not allow 80 MHz and 52 and 100 need DFS; 157 shares its block with a weak
+	// network and is 10% busy, 36 shares it with a strong one and is 50% busy.
+	if !slices.Equal(channels, []int{157, 36}) {
+		t.Fatalf("unexpected channel order: %v", channels)
+	}
+
+	if scores[0].Score != 80 || scores[1].Score != 35 || scores[1].Noise != -92 || scores[1].Networks != 1 {
+		t.Errorf("unexpected scores: %+v", scores)
+	}
+
+	scores, err = mgr.PlanChannels(ctx, "phy1-ap0", wireless.ChannelPlanOptions{AllowDFS: true})
+	if err != nil {
+		t.Fatalf("PlanChannels failed: %v", err)
+	}
+
+	if len(scores) != 5 || scores[0].Channel != 149 || !scores[1].DFS || scores[1].Score != 95 {
+		t.Errorf("unexpected DFS scores: %+v", scores)
+	}
+
+	addWirelessSection(mock, "radio1", map[string]any{".type": "wifi-device", ".name": "radio1"})
+	mock.AddResponse("uci", "set", map[string]any{})
+
+	err = mgr.SetChannel(ctx, "radio1", 157)
+	if err != nil {
+		t.Fatalf("SetChannel failed: %v", err)
+	}
+
+	req, ok := mock.GetLastCall().Data.(uci.Request)
+	if !ok || req.Section != "radio1" || req.Values["channel"] != "157" {
+		t.Errorf("unexpected channel change: %+v", mock.GetLastCall())
+	}
+}
+
+func testWirelessScanner(t *testing.T, ctx context.Context) {
+	t.Helper()
+
+	scan := func(results ...map[string]any) map[string]any { return map[string]any{"results": results} }
+	home := func(signal int) map[string]any {
+		return map[string]any{"ssid": "Home", "bssid": "aa:bb:cc:00:00:01", "channel": 6, "mhz": 2437, "signal": signal}
+	}
+	rogue := map[string]any{"ssid": "Home", "bssid": "aa:bb:cc:00:00:02", "channel": 1, "mhz": 2412, "signal": -70}
+
+	mock := testutil.NewMockTransport()
+	mock.AddResponse("iwinfo", "scan", scan(home(-50), rogue))
+
+	scanner, err := wireless.New(mock).StartScanner(ctx, []string{"phy0-ap0"},
+		wireless.WithScanInterval(5*time.Millisecond), wireless.WithBSSExpiry(50*time.Millisecond))
+	if err != nil {
+		t.Fatalf("StartScanner failed: %v", err)
+	}
+	defer scanner.Close()
+
+	next := func(want string) wireless.BSS {
+		t.Helper()
+
+		select {
+		case ev := <-scanner.Events():
+			bss, ok := wireless.ParseBSSEvent(ev)
+			if !ok || ev.Type != want {
+				t.Fatalf("expected a %s event, got %+v", want, ev)
+			}
+
+			return bss
+		case <-time.After(time.Second):
+			t.Fatalf("no %s event: %v", want, scanner.Err())
+		}
+
+		return wireless.BSS{}
+	}
+
+	added := []string{next(wireless.BSSAddedEvent).BSSID, next(wireless.BSSAddedEvent).BSSID}
+	slices.Sort(added)
+
+	if !slices.Equal(added, []string{"aa:bb:cc:00:00:01", "aa:bb:cc:00:00:02"}) {
+		t.Fatalf("unexpected added networks: %v", added)
+	}
+
+	// A small change is recorded without an event, a large one is reported.
+	mock.AddResponse("iwinfo", "scan", scan(home(-52), rogue))
+	time.Sleep(20 * time.Millisecond)
+	mock.AddResponse("iwinfo", "scan", scan(home(-40)))
+
+	updated := next(wireless.BSSUpdatedEvent)
+	if updated.Signal != -40 || updated.Device != "phy0-ap0" || len(updated.History) < 3 {
+		t.Errorf("unexpected update: %+v", updated)
+	}
+
+	if removed := next(wireless.BSSRemovedEvent); removed.BSSID != "aa:bb:cc:00:00:02" {
+		t.Errorf("unexpected removal: %+v", removed)
+	}
+
+	bsses := scanner.BSSes()
+	if len(bsses) != 1 || bsses[0].SSID != "Home" || !bsses[0].FirstSeen.Before(bsses[0].LastSeen) {
+		t.Errorf("unexpected table: %+v", bsses)
+	}
+
+	// Without iwinfo, scanning fails for good instead of being retried.
+	mock.AddResponse("iwinfo", "scan", &errdefs.CapabilityError{Object: "iwinfo"})
+
+	select {
+	case <-scanner.Done():
+		if !errdefs.IsNotSupported(scanner.Err()) {
+			t.Errorf("expected the missing iwinfo object to be reported, got %v", scanner.Err())
+		}
+	case <-time.After(time.Second):
+		t.Error("expected the scanner to stop without iwinfo")
+	}
+}
+
+func testWirelessWatchStations(t *testing.T, ctx context.Context) {
+	t.Helper()
+
+	const phone, laptop = "AA:BB:CC:DD:EE:01", "AA:BB:CC:DD:EE:02"
+
+	mock := testutil.NewMockTransport()
+	associated := func(stations ...map[string]any) {
+		mock.AddResponse("iwinfo", "assoclist", map[string]any{"results": stations})
+	}
+	associated(map[string]any{"mac": strings.ToLower(phone), "signal": -50})
+
+	// Polls only follow the hostapd notifications, which keeps the test deterministic.
+	sub, err := wireless.New(mock).WatchStations(ctx, []string{"phy0-ap0"},
+		wireless.WithStationInterval(time.Hour), wireless.WithLeaveDelay(30*time.Millisecond))
+	if err != nil {
+		t.Fatalf("WatchStations failed: %v", err)
+	}
+	defer sub.Close()
+
+	next := func() (string, wireless.StationEvent) {
+		t.Helper()
+
+		select {
+		case ev := <-sub.Events():
+			station, ok := wireless.ParseStationEvent(ev)
+			if !ok {
+				t.Fatalf("unexpected event: %+v", ev)
+			}
+
+			return ev.Type, station
+		case <-time.After(time.Second):
+			t.Fatalf("no station event: %v", sub.Err())
+		}
+
+		return "", wireless.StationEvent{}
+	}
+
+	if typ, station := next(); typ != wireless.StationJoinEvent || station.MAC != phone || station.Since.IsZero() {
+		t.Fatalf("expected the phone to join, got %s %+v", typ, station)
+	}
+
+	associated(
+		map[string]any{"mac": phone, "signal": -75},
+		map[string]any{"mac": laptop, "signal": -60},
+	)
+	mock.Emit("hostapd.phy0-ap0", "assoc", map[string]any{"address": laptop})
+
+	events := map[string]string{}
+
+	for range 2 {
+		typ, station := next()
+		events[station.MAC] = typ
+	}
+
+	if events[phone] != wireless.StationSignalEvent || events[laptop] != wireless.StationJoinEvent {
+		t.Fatalf("unexpected events: %v", events)
+	}
+
+	// A brief absence is not reported.
+	associated(map[string]any{"mac": laptop, "signal": -60})
+	mock.Emit("hostapd.phy0-ap0", "disassoc", map[string]any{"address": phone})
+	time.Sleep(10 * time.Millisecond)
+	associated(map[string]any{"mac": phone, "signal": -75}, map[string]any{"mac": laptop, "signal": -60})
+	mock.Emit("hostapd.phy0-ap0", "assoc", map[string]any{"address": phone})
+	time.Sleep(10 * time.Millisecond)
+
+	associated(map[string]any{"mac": laptop, "signal": -60})
+	mock.Emit("hostapd.phy0-ap0", "disassoc", map[string]any{"address": phone})
+	time.Sleep(40 * time.Millisecond)
+	mock.Emit("hostapd.phy0-ap0", "deauth", map[string]any{"address": phone})
+
+	if typ, station := next(); typ != wireless.StationLeaveEvent || station.MAC != phone {
+		t.Errorf("expected the phone to leave, got %s %+v", typ, station)
+	}
+
+	// A transient failure is retried at the next poll.
+	mock.AddResponse("iwinfo", "assoclist", errdefs.Wrapf(errdefs.ErrTimeout, "request timed out"))
+	mock.Emit("hostapd.phy0-ap0", "assoc", map[string]any{"address": phone})
+	time.Sleep(10 * time.Millisecond)
+
+	select {
+	case <-sub.Done():
+		t.Fatalf("expected a transient failure to be retried, got %v", sub.Err())
+	default:
+	}
+
+	// Without iwinfo, the watcher ends instead of polling forever.
+	mock.AddResponse("iwinfo", "assoclist", &errdefs.CapabilityError{Object: "iwinfo"})
+	mock.Emit("hostapd.phy0-ap0", "assoc", map[string]any{"address": phone})
+
+	select {
+	case <-sub.Done():
+		if !errdefs.IsNotSupported(sub.Err()) {
+			t.Errorf("expected the missing iwinfo object to be reported, got %v", sub.Err())
+		}
+	case <-time.After(time.Second):
+		t.Error("expected the watcher to stop without iwinfo")
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package wireless
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+)
+
+// PHY modes of a RateKey.
+const (
+	ModeLegacy = "legacy"
+	ModeHT     = "ht"
+	ModeVHT    = "vht"
+	ModeHE     = "he"
+	ModeEHT    = "eht"
+)
+
+// htStreamsPerMCSGroup is the number of HT MCS indexes per spatial stream count; HT encodes
+// the stream count in the MCS index instead of reporting it.
+const htStreamsPerMCSGroup = 8
+
+// RateKey identifies a PHY rate by mode, MCS index, spatial streams and channel width.
+type RateKey struct {
+	Mode string
+	MCS  int
+	NSS  int
+	MHz  int
+}
+
+// String formats the key as e.g. "HE-MCS11 2SS 80MHz".
+func (k RateKey) String() string {
+	if k.Mode == ModeLegacy {
+		return fmt.Sprintf("legacy %dMHz", k.MHz)
+	}
+
+	return fmt.Sprintf("%s-MCS%d %dSS %dMHz", strings.ToUpper(k.Mode), k.MCS, k.NSS, k.MHz)
+}
+
+// Key returns the rate key of r. HT MCS indexes are normalized to the per-stream index 0-7.
+func (r AssocRate) Key() RateKey {
+	key := RateKey{Mode: ModeLegacy, NSS: 1, MHz: r.Mhz}
+
+	switch {
+	case bool(r.IsEht):
+		key.Mode, key.MCS, key.NSS = ModeEHT, r.Mcs, r.Nss
+	case bool(r.IsHe):
+		key.Mode, key.MCS, key.NSS = ModeHE, r.Mcs, r.Nss
+	case bool(r.IsVht):
+		key.Mode, key.MCS, key.NSS = ModeVHT, r.Mcs, r.Nss
+	case bool(r.IsHt):
+		key.Mode = ModeHT
+		key.MCS = r.Mcs % htStreamsPerMCSGroup
+		key.NSS = r.Mcs/htStreamsPerMCSGroup + 1
+	}
+
+	return key
+}
+
+// RateUsage counts how often a rate was observed and the packets attributed to it.
+type RateUsage struct {
+	Packets int64
+	Samples int
+}
+
+// RateBreakdown is the rate usage of one station per direction.
+type RateBreakdown struct {
+	Rx map[RateKey]RateUsage
+	Tx map[RateKey]RateUsage
+}
+
+// RateTracker builds per-station rate breakdowns from successive assoclist samples. The
+// packets a station exchanged since the previous sample are attributed to the rate reported
+// in the current one, so the breakdown sharpens as the sampling interval shrinks.
+type RateTracker struct {
+	stations map[string]*rateState
+	mu       sync.Mutex
+}
+
+type rateState struct {
+	breakdown RateBreakdown
+	rxPackets int64
+	txPackets int64
+}
+
+// NewRateTracker creates an empty tracker.
+func NewRateTracker() *RateTracker {
+	return &RateTracker{stations: make(map[string]*rateState)}
+}
+
+// Observe records one assoclist sample. Stations missing from the sample are forgotten.
+func (t *RateTracker) Observe(assocs []Assoc) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	seen := make(map[string]*rateState, len(assocs))
+
+	for _, assoc := range assocs {
+		mac := strings.ToUpper(assoc.Mac)
+
+		state, ok := t.stations[mac]
+		if !ok {
+			state = &rateState{breakdown: RateBreakdown{
+				Rx: make(map[RateKey]RateUsage),
+				Tx: make(map[RateKey]RateUsage),
+			}}
+		}
+
+		addUsage(state.breakdown.Rx, assoc.Rx, packetDelta(assoc.Rx.Packets, state.rxPackets, ok))
+		addUsage(state.breakdown.Tx, assoc.Tx, packetDelta(assoc.Tx.Packets, state.txPackets, ok))
+		state.rxPackets, state.txPackets = assoc.Rx.Packets, assoc.Tx.Packets
+		seen[mac] = state
+	}
+
+	t.stations = seen
+}
+
+// Breakdown returns a copy of the breakdown of the station with the given MAC address.
+func (t *RateTracker) Breakdown(mac string) (RateBreakdown, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, ok := t.stations[strings.ToUpper(mac)]
+	if !ok {
+		return RateBreakdown{}, false
+	}
+
+	return RateBreakdown{Rx: maps.Clone(state.breakdown.Rx), Tx: maps.Clone(state.breakdown.Tx)}, true
+}
+
+func addUsage(usage map[RateKey]RateUsage, rate AssocRate, packets int64) {
+	key := rate.Key()
+	entry := usage[key]
+	entry.Samples++
+	entry.Packets += packets
+	usage[key] = entry
+}
+
+// packetDelta returns the packets since the previous sample. The first sample of a station
+// and counter resets, e.g. after a reassociation, contribute no packets.
+func packetDelta(current, previous int64, known bool) int64 {
+	if !known || current < previous {
+		return 0
+	}
+
+	return current - previous
+}
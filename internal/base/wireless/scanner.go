@@ -0,0 +1,324 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package wireless
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+const (
+	// DefaultScanInterval is how often a Scanner scans each interface. Every scan leaves the
+	// operating channel for a while, so short intervals hurt the throughput of clients.
+	DefaultScanInterval = 2 * time.Minute
+	// DefaultRSSIHistory is how many signal samples a Scanner keeps for each BSS.
+	DefaultRSSIHistory = 30
+	// DefaultSignalChange is the signal change in dB that makes a Scanner report a BSS again.
+	DefaultSignalChange = 6
+	// defaultExpiryScans is how many intervals a BSS may go unseen before it is removed.
+	defaultExpiryScans = 3
+)
+
+// Types of the events a Scanner delivers; the payload is a BSS that can be decoded with
+// ParseBSSEvent.
+const (
+	BSSAddedEvent   = "wireless.bss.add"
+	BSSUpdatedEvent = "wireless.bss.update"
+	BSSRemovedEvent = "wireless.bss.remove"
+)
+
+// ScannerOption defines a functional option for StartScanner.
+type ScannerOption func(*scannerConfig)
+
+type scannerConfig struct {
+	interval     time.Duration
+	expiry       time.Duration
+	history      int
+	signalChange int
+}
+
+// WithScanInterval sets how often each interface is scanned; a non-positive interval keeps
+// DefaultScanInterval.
+func WithScanInterval(interval time.Duration) ScannerOption {
+	return func(c *scannerConfig) {
+		if interval > 0 {
+			c.interval = interval
+		}
+	}
+}
+
+// WithBSSExpiry sets how long a BSS may go unseen before it is removed; it defaults to three
+// scan intervals.
+func WithBSSExpiry(expiry time.Duration) ScannerOption {
+	return func(c *scannerConfig) {
+		if expiry > 0 {
+			c.expiry = expiry
+		}
+	}
+}
+
+// WithRSSIHistory sets how many signal samples are kept for each BSS.
+func WithRSSIHistory(samples int) ScannerOption {
+	return func(c *scannerConfig) {
+		if samples > 0 {
+			c.history = samples
+		}
+	}
+}
+
+// WithSignalChange sets the signal change in dB that makes the scanner report a BSS again.
+func WithSignalChange(db int) ScannerOption {
+	return func(c *scannerConfig) {
+		if db > 0 {
+			c.signalChange = db
+		}
+	}
+}
+
+// Scanner scans interfaces periodically and keeps a table of the networks around. It delivers
+// a BSSAddedEvent for each new BSS, a BSSUpdatedEvent when the SSID or channel of a BSS
+// changes or its signal moved by more than the signal change since it was last reported, and
+// a BSSRemovedEvent when a BSS went unseen for the expiry time.
+type Scanner struct {
+	*goubus.Subscription
+
+	manager *Manager
+	devices []string
+	cfg     scannerConfig
+
+	mu    sync.Mutex
+	table map[string]*BSS
+	// reported holds the signal of each BSS in its last event.
+	reported map[string]int
+}
+
+// StartScanner starts scanning devices, which are scanned one after another, until ctx ends
+// or the scanner is closed. A scan that fails is retried in the next interval; one that is
+// refused ends the scanner with an error reported by Err.
+func (m *Manager) StartScanner(ctx context.Context, devices []string, opts ...ScannerOption) (*Scanner, error) {
+	if len(devices) == 0 {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "no interface to scan")
+	}
+
+	cfg := scannerConfig{interval: DefaultScanInterval, history: DefaultRSSIHistory, signalChange: DefaultSignalChange}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if cfg.expiry == 0 {
+		cfg.expiry = defaultExpiryScans * cfg.interval
+	}
+
+	s := &Scanner{
+		manager:  m,
+		devices:  slices.Clone(devices),
+		cfg:      cfg,
+		table:    make(map[string]*BSS),
+		reported: make(map[string]int),
+	}
+	s.Subscription = goubus.NewSubscription(ctx, s.run)
+
+	return s, nil
+}
+
+// BSSes returns the current table, strongest signal first.
+func (s *Scanner) BSSes() []BSS {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bsses := make([]BSS, 0, len(s.table))
+	for _, bss := range s.table {
+		entry := *bss
+		entry.History = slices.Clone(bss.History)
+		bsses = append(bsses, entry)
+	}
+
+	slices.SortFunc(bsses, func(a, b BSS) int {
+		return cmp.Or(cmp.Compare(b.Signal, a.Signal), strings.Compare(a.BSSID, b.BSSID))
+	})
+
+	return bsses
+}
+
+// ParseBSSEvent decodes an event delivered by a Scanner. It returns false for other events.
+func ParseBSSEvent(ev goubus.Event) (BSS, bool) {
+	if ev.Type != BSSAddedEvent && ev.Type != BSSUpdatedEvent && ev.Type != BSSRemovedEvent {
+		return BSS{}, false
+	}
+
+	var bss BSS
+
+	err := ev.Unmarshal(&bss)
+	if err != nil {
+		return BSS{}, false
+	}
+
+	return bss, true
+}
+
+func (s *Scanner) run(ctx context.Context, emit func(goubus.Event) bool) error {
+	ticker := time.NewTicker(s.cfg.interval)
+	defer ticker.Stop()
+
+	for {
+		for _, device := range s.devices {
+			err := s.scan(ctx, device, emit)
+			if err != nil {
+				return err
+			}
+		}
+
+		err := s.expire(time.Now(), emit)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// scan scans device and merges the results into the table.
+func (s *Scanner) scan(ctx context.Context, device string, emit func(goubus.Event) bool) error {
+	results, err := s.manager.Scan(ctx, device)
+	if errdefs.IsPermissionDenied(err) || errdefs.IsNotFound(err) || errdefs.IsNotSupported(err) ||
+		errdefs.IsInvalidParameter(err) {
+		return errdefs.Wrapf(err, "failed to scan on %s", device)
+	}
+
+	if err != nil {
+		// The radio is busy or the scan was aborted; the next interval tries again.
+		return nil
+	}
+
+	now := time.Now()
+
+	for _, result := range results {
+		if result.BSSID == "" {
+			continue
+		}
+
+		event := s.merge(device, result, now)
+		if event == "" {
+			continue
+		}
+
+		err = s.emit(emit, event, strings.ToUpper(result.BSSID))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// merge records result in the table and returns the event to deliver, if any.
+func (s *Scanner) merge(device string, result ScanResult, now time.Time) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := strings.ToUpper(result.BSSID)
+	sample := RSSISample{Time: now, Signal: result.Signal}
+
+	bss, ok := s.table[key]
+	if !ok {
+		s.table[key] = &BSS{
+			ScanResult: result, Device: device, FirstSeen: now, LastSeen: now, History: []RSSISample{sample},
+		}
+
+		return BSSAddedEvent
+	}
+
+	changed := bss.SSID != result.SSID || bss.Channel != result.Channel || bss.MHz != result.MHz ||
+		abs(result.Signal-s.reported[key]) >= s.cfg.signalChange
+
+	bss.ScanResult = result
+	bss.Device = device
+	bss.LastSeen = now
+
+	bss.History = append(bss.History, sample)
+	if len(bss.History) > s.cfg.history {
+		bss.History = slices.Delete(bss.History, 0, len(bss.History)-s.cfg.history)
+	}
+
+	if changed {
+		return BSSUpdatedEvent
+	}
+
+	return ""
+}
+
+// expire removes the networks that went unseen for the expiry time.
+func (s *Scanner) expire(now time.Time, emit func(goubus.Event) bool) error {
+	s.mu.Lock()
+
+	var removed []BSS
+
+	for key, bss := range s.table {
+		if now.Sub(bss.LastSeen) >= s.cfg.expiry {
+			removed = append(removed, *bss)
+			delete(s.table, key)
+			delete(s.reported, key)
+		}
+	}
+
+	s.mu.Unlock()
+
+	for _, bss := range removed {
+		err := emitBSS(emit, BSSRemovedEvent, bss)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// emit delivers event for the BSS with the key and remembers the reported signal.
+func (s *Scanner) emit(emit func(goubus.Event) bool, event, key string) error {
+	s.mu.Lock()
+	bss := *s.table[key]
+	bss.History = slices.Clone(bss.History)
+	s.reported[key] = bss.Signal
+	s.mu.Unlock()
+
+	return emitBSS(emit, event, bss)
+}
+
+func emitBSS(emit func(goubus.Event) bool, event string, bss BSS) error {
+	raw, err := json.Marshal(bss)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to encode BSS %s", bss.BSSID)
+	}
+
+	var data map[string]any
+
+	err = json.Unmarshal(raw, &data)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to encode BSS %s", bss.BSSID)
+	}
+
+	emit(goubus.Event{Type: event, Object: bss.Device, Data: data})
+
+	return nil
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
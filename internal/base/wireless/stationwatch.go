@@ -0,0 +1,314 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package wireless
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+const (
+	// DefaultStationInterval is how often WatchStations reads the association lists.
+	DefaultStationInterval = 10 * time.Second
+	// DefaultLeaveDelay is how long a station must stay away before WatchStations reports it left.
+	DefaultLeaveDelay = time.Minute
+	// DefaultSignalThreshold is the signal change in dB WatchStations reports.
+	DefaultSignalThreshold = 10
+)
+
+// Types of the events WatchStations delivers; the payload is a StationEvent that can be decoded
+// with ParseStationEvent.
+const (
+	StationJoinEvent   = "wireless.station.join"
+	StationLeaveEvent  = "wireless.station.leave"
+	StationSignalEvent = "wireless.station.signal"
+)
+
+// StationWatchOption defines a functional option for WatchStations.
+type StationWatchOption func(*stationWatchConfig)
+
+type stationWatchConfig struct {
+	interval   time.Duration
+	leaveDelay time.Duration
+	threshold  int
+}
+
+// WithStationInterval sets how often the association lists are read; a non-positive interval
+// keeps DefaultStationInterval.
+func WithStationInterval(interval time.Duration) StationWatchOption {
+	return func(c *stationWatchConfig) {
+		if interval > 0 {
+			c.interval = interval
+		}
+	}
+}
+
+// WithLeaveDelay sets how long a station must stay away before it is reported to have left; a
+// negative delay keeps DefaultLeaveDelay, zero reports it at the first read that misses it.
+func WithLeaveDelay(delay time.Duration) StationWatchOption {
+	return func(c *stationWatchConfig) {
+		if delay >= 0 {
+			c.leaveDelay = delay
+		}
+	}
+}
+
+// WithSignalThreshold sets the signal change in dB that is reported.
+func WithSignalThreshold(db int) StationWatchOption {
+	return func(c *stationWatchConfig) {
+		if db > 0 {
+			c.threshold = db
+		}
+	}
+}
+
+// WatchStations tracks the stations associated with devices and notifies when one joins,
+// leaves or its signal moves by the threshold since it was last reported. A station that
+// roams between the devices stays joined, and one that disappears is only reported to have
+// left once it stayed away for the leave delay, so brief disconnects raise no events.
+// The association lists are read every interval and, where the transport delivers the
+// notifications of the hostapd objects, right after a station associates or disassociates.
+// A read that is refused ends the subscription; other failed reads are retried.
+func (m *Manager) WatchStations(
+	ctx context.Context, devices []string, opts ...StationWatchOption,
+) (*goubus.Subscription, error) {
+	if len(devices) == 0 {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "no interface to watch")
+	}
+
+	cfg := stationWatchConfig{
+		interval: DefaultStationInterval, leaveDelay: DefaultLeaveDelay, threshold: DefaultSignalThreshold,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	w := &stationWatcher{
+		manager: m,
+		devices: slices.Clone(devices),
+		cfg:     cfg,
+		present: make(map[string]*trackedStation),
+	}
+
+	// Without notifications, changes are only found by reading the lists.
+	for _, device := range devices {
+		sub, err := goubus.Subscribe(ctx, m.caller, "hostapd."+device)
+		if err == nil {
+			w.notifications = append(w.notifications, sub)
+		}
+	}
+
+	return goubus.NewSubscription(ctx, w.run), nil
+}
+
+// ParseStationEvent decodes an event delivered by WatchStations. It returns false for other
+// events.
+func ParseStationEvent(ev goubus.Event) (StationEvent, bool) {
+	if ev.Type != StationJoinEvent && ev.Type != StationLeaveEvent && ev.Type != StationSignalEvent {
+		return StationEvent{}, false
+	}
+
+	var station StationEvent
+
+	err := ev.Unmarshal(&station)
+	if err != nil {
+		return StationEvent{}, false
+	}
+
+	return station, true
+}
+
+// stationWatcher compares the association lists with the stations seen before.
+type stationWatcher struct {
+	manager       *Manager
+	devices       []string
+	cfg           stationWatchConfig
+	notifications []*goubus.Subscription
+	present       map[string]*trackedStation
+}
+
+type trackedStation struct {
+	// missingSince is when the station was first missed, zero while it is associated.
+	missingSince time.Time
+	event        StationEvent
+	// reported is the signal of the last event.
+	reported int
+}
+
+func (w *stationWatcher) run(ctx context.Context, emit func(goubus.Event) bool) error {
+	ticker := time.NewTicker(w.cfg.interval)
+	defer ticker.Stop()
+
+	var notifications <-chan goubus.Event
+
+	if len(w.notifications) > 0 {
+		merged := goubus.MergeSubscriptions(ctx, w.notifications...)
+		defer func() { _ = merged.Close() }()
+
+		notifications = merged.Events()
+	}
+
+	for {
+		err := w.check(ctx, emit)
+		if err != nil {
+			return err
+		}
+
+		if !wait(ctx, ticker, &notifications) {
+			return nil
+		}
+	}
+}
+
+// wait blocks until the next interval or an association notification and returns false when
+// ctx ends.
+func wait(ctx context.Context, ticker *time.Ticker, notifications *<-chan goubus.Event) bool {
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case ev, ok := <-*notifications:
+			if !ok {
+				*notifications = nil
+
+				continue
+			}
+
+			if ev.Type != "assoc" && ev.Type != "disassoc" && ev.Type != "deauth" {
+				continue
+			}
+
+			return true
+		case <-ticker.C:
+			return true
+		}
+	}
+}
+
+// check reads the association lists and emits the changes.
+func (w *stationWatcher) check(ctx context.Context, emit func(goubus.Event) bool) error {
+	seen, complete, err := w.read(ctx)
+	if err != nil || !complete {
+		return err
+	}
+
+	now := time.Now()
+
+	for mac, event := range seen {
+		typ := w.see(mac, event, now)
+		if typ != "" {
+			err = emitStation(emit, typ, w.present[mac].event)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return w.leave(seen, now, emit)
+}
+
+// leave emits the stations that stayed away for the leave delay.
+func (w *stationWatcher) leave(seen map[string]StationEvent, now time.Time, emit func(goubus.Event) bool) error {
+	for mac, station := range w.present {
+		if _, ok := seen[mac]; ok {
+			continue
+		}
+
+		if station.missingSince.IsZero() {
+			station.missingSince = now
+		}
+
+		if now.Sub(station.missingSince) >= w.cfg.leaveDelay {
+			delete(w.present, mac)
+
+			err := emitStation(emit, StationLeaveEvent, station.event)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// read returns the stations associated with the devices by MAC. complete is false when a list
+// could not be read, since every station on it would seem to leave.
+func (w *stationWatcher) read(ctx context.Context) (map[string]StationEvent, bool, error) {
+	seen := make(map[string]StationEvent)
+
+	for _, device := range w.devices {
+		assocs, err := w.manager.AssocList(ctx, device)
+		if errdefs.IsPermissionDenied(err) || errdefs.IsNotSupported(err) || errdefs.IsMethodNotFound(err) ||
+			errdefs.IsInvalidParameter(err) {
+			// Retrying cannot help when iwinfo is missing or the device is unknown.
+			return nil, false, errdefs.Wrapf(err, "failed to read the stations of %s", device)
+		}
+
+		if errdefs.IsNotFound(err) {
+			// The interface is down, so nothing is associated with it.
+			continue
+		}
+
+		if err != nil {
+			// Transient failures are retried at the next poll.
+			return nil, false, nil
+		}
+
+		for _, assoc := range assocs {
+			mac := strings.ToUpper(assoc.Mac)
+			if current, ok := seen[mac]; !ok || assoc.Signal > current.Signal {
+				seen[mac] = StationEvent{MAC: mac, Device: device, Signal: assoc.Signal}
+			}
+		}
+	}
+
+	return seen, true, nil
+}
+
+// see records a station found associated and returns the event to deliver, if any.
+func (w *stationWatcher) see(mac string, event StationEvent, now time.Time) string {
+	station, ok := w.present[mac]
+	if !ok {
+		event.Since = now
+		w.present[mac] = &trackedStation{event: event, reported: event.Signal}
+
+		return StationJoinEvent
+	}
+
+	station.missingSince = time.Time{}
+	station.event.Device = event.Device
+	station.event.Signal = event.Signal
+
+	if abs(event.Signal-station.reported) < w.cfg.threshold {
+		return ""
+	}
+
+	station.reported = event.Signal
+
+	return StationSignalEvent
+}
+
+func emitStation(emit func(goubus.Event) bool, typ string, station StationEvent) error {
+	raw, err := json.Marshal(station)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to encode station %s", station.MAC)
+	}
+
+	var data map[string]any
+
+	err = json.Unmarshal(raw, &data)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to encode station %s", station.MAC)
+	}
+
+	emit(goubus.Event{Type: typ, Object: station.Device, Data: data})
+
+	return nil
+}
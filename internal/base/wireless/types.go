@@ -3,7 +3,12 @@
 
 package wireless
 
-import "github.com/honeybbq/goubus/v2"
+import (
+	"time"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/internal/base/hostapd"
+)
 
 // Info represents wireless interface information.
 type Info struct {
@@ -22,32 +27,180 @@ type Encryption struct {
 type ScanResult struct {
 	SSID    string `json:"ssid"`
 	BSSID   string `json:"bssid"`
+	Band    int    `json:"band"`
 	Channel int    `json:"channel"`
+	MHz     int    `json:"mhz"`
 	Signal  int    `json:"signal"`
 }
 
-// Assoc represents an associated wireless station.
+// Assoc represents an associated wireless station as reported by rpcd-mod-iwinfo.
 type Assoc struct {
 	Mac           string    `json:"mac"`
+	Preamble      string    `json:"preamble"`
+	MeshPlink     string    `json:"mesh plink"`
+	MeshLocalPS   string    `json:"mesh local PS"`
+	MeshPeerPS    string    `json:"mesh peer PS"`
+	MeshNonPeerPS string    `json:"mesh non-peer PS"`
+	Rx            AssocRate `json:"rx"`
+	Tx            AssocRate `json:"tx"`
 	Signal        int       `json:"signal"`
 	SignalAvg     int       `json:"signal_avg"`
 	Noise         int       `json:"noise"`
 	Inactive      int       `json:"inactive"`
 	ConnectedTime int       `json:"connected_time"`
-	Authorized    bool      `json:"authorized"`
-	Authenticated bool      `json:"authenticated"`
-	Rx            AssocRate `json:"rx"`
-	Tx            AssocRate `json:"tx"`
+	// ExpectedThroughput is the throughput estimated by the rate control algorithm in kbit/s.
+	ExpectedThroughput int  `json:"thr"`
+	MeshLLID           int  `json:"mesh llid"`
+	MeshPLID           int  `json:"mesh plid"`
+	Authorized         bool `json:"authorized"`
+	Authenticated      bool `json:"authenticated"`
+	WME                bool `json:"wme"`
+	MFP                bool `json:"mfp"`
+	TDLS               bool `json:"tdls"`
 }
 
-// AssocRate represents wireless association rate information.
+// AssocRate represents wireless association rate information. Rates are in kbit/s; the
+// MCS, guard interval and DCM fields are only reported for the matching PHY mode.
 type AssocRate struct {
-	Rate  int         `json:"rate"`
-	Mcs   int         `json:"mcs"`
-	Nss   int         `json:"nss"`
-	IsHt  goubus.Bool `json:"ht"`
-	IsVht goubus.Bool `json:"vht"`
-	IsHe  goubus.Bool `json:"he"`
-	IsEht goubus.Bool `json:"eht"`
-	Mhz   int         `json:"mhz"`
+	Packets  int64       `json:"packets"`
+	Bytes    int64       `json:"bytes"`
+	DropMisc int64       `json:"drop_misc"`
+	Failed   int64       `json:"failed"`
+	Retries  int64       `json:"retries"`
+	Rate     int         `json:"rate"`
+	Mcs      int         `json:"mcs"`
+	Nss      int         `json:"nss"`
+	Mhz      int         `json:"mhz"`
+	HeGI     int         `json:"he_gi"`
+	HeDCM    int         `json:"he_dcm"`
+	EhtGI    int         `json:"eht_gi"`
+	IsHt     goubus.Bool `json:"ht"`
+	IsVht    goubus.Bool `json:"vht"`
+	IsHe     goubus.Bool `json:"he"`
+	IsEht    goubus.Bool `json:"eht"`
+	Is40Mhz  goubus.Bool `json:"40mhz"`
+	ShortGI  goubus.Bool `json:"short_gi"`
+}
+
+// Station combines the iwinfo association data of a client with the features it advertised to hostapd.
+type Station struct {
+	// RRM holds the radio resource management (802.11k) capability bytes advertised by the client.
+	RRM      []int            `json:"rrm,omitempty"`
+	Features hostapd.Features `json:"features"`
+	Assoc
+	// Airtime is the receive/transmit airtime reported by hostapd in microseconds.
+	Airtime hostapd.ClientCounters `json:"airtime"`
+}
+
+// Frequency represents an entry of the iwinfo frequency list.
+type Frequency struct {
+	Flags      []string `json:"flags"`
+	Band       int      `json:"band"`
+	Channel    int      `json:"channel"`
+	MHz        int      `json:"mhz"`
+	Restricted bool     `json:"restricted"`
+	Active     bool     `json:"active"`
+}
+
+// ChannelSwitch describes a validated channel switch announced to clients via CSA.
+type ChannelSwitch struct {
+	hostapd.SwitchChanRequest
+
+	// AllowDFS permits targets inside the 5 GHz DFS range. Moving to such a channel may
+	// require a channel availability check that silences the radio for a minute or more.
+	AllowDFS bool `json:"-"`
+}
+
+type radioStatusResponse struct {
+	Radio map[string]radioStatus `json:"radio"`
+}
+
+// radioStatus is the part of the network.wireless status of a radio Apply waits on.
+type radioStatus struct {
+	Interfaces []radioInterface `json:"interfaces"`
+	Up         goubus.Bool      `json:"up"`
+	Pending    goubus.Bool      `json:"pending"`
+	Disabled   goubus.Bool      `json:"disabled"`
+}
+
+type radioInterface struct {
+	Ifname string `json:"ifname"`
+	Config struct {
+		Mode string `json:"mode"`
+	} `json:"config"`
+}
+
+// WPSConfig holds the WPS options of a wifi-iface section.
+type WPSConfig struct {
+	// DeviceName and Manufacturer describe the AP to enrolling devices; empty values keep the
+	// current options.
+	DeviceName   string `json:"wps_device_name,omitempty"`
+	Manufacturer string `json:"wps_manufacturer,omitempty"`
+	// PushButton enables the push-button method.
+	PushButton bool `json:"wps_pushbutton"`
+}
+
+// SurveyEntry represents an entry of the iwinfo channel survey. Times are in milliseconds
+// since the radio came up; busy time includes the own receive and transmit time.
+type SurveyEntry struct {
+	MHz        int   `json:"mhz"`
+	Noise      int   `json:"noise"`
+	ActiveTime int64 `json:"active_time"`
+	BusyTime   int64 `json:"busy_time"`
+	RxTime     int64 `json:"rx_time"`
+	TxTime     int64 `json:"tx_time"`
+}
+
+// ChannelPlanOptions selects the channels PlanChannels considers.
+type ChannelPlanOptions struct {
+	// Bandwidth is the intended channel width in MHz; channels whose flags forbid it are left
+	// out and networks within it count as overlapping. Zero means 20 MHz.
+	Bandwidth int `json:"bandwidth,omitempty"`
+	// AllowDFS includes channels in the 5 GHz DFS range.
+	AllowDFS bool `json:"allow_dfs,omitempty"`
+}
+
+// ChannelScore rates a channel for a radio; a higher Score is better.
+type ChannelScore struct {
+	Frequency
+
+	Score float64 `json:"score"`
+	// Occupancy is the fraction of time the channel was busy with other transmissions.
+	Occupancy float64 `json:"occupancy"`
+	// Noise is the noise floor in dBm.
+	Noise int `json:"noise,omitempty"`
+	// Networks counts the scanned networks overlapping the channel.
+	Networks int  `json:"networks"`
+	DFS      bool `json:"dfs"`
+	// Surveyed reports whether the survey covered the channel; Occupancy and Noise are zero
+	// otherwise.
+	Surveyed bool `json:"surveyed"`
+}
+
+// BSS is a network in the table of a Scanner.
+type BSS struct {
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+	// Device is the interface whose scan last found the network.
+	Device string `json:"device"`
+	// History holds the latest signal samples, oldest first.
+	History []RSSISample `json:"history"`
+	ScanResult
+}
+
+// RSSISample is a signal strength in dBm measured by a scan.
+type RSSISample struct {
+	Time   time.Time `json:"time"`
+	Signal int       `json:"signal"`
+}
+
+// StationEvent is the payload of the events WatchStations delivers.
+type StationEvent struct {
+	// Since is when the station joined.
+	Since time.Time `json:"since"`
+	MAC   string    `json:"mac"`
+	// Device is the interface the station is associated with, the one it was last seen on for
+	// a StationLeaveEvent.
+	Device string `json:"device"`
+	Signal int    `json:"signal"`
 }
@@ -19,6 +19,11 @@ func New(t goubus.Transport) *Manager {
 	return &Manager{caller: t}
 }
 
+// RequiredObjects lists the ubus objects the manager calls.
+func (m *Manager) RequiredObjects() []string {
+	return []string{"wpa_supplicant"}
+}
+
 // IfaceStatus retrieves the status of a wireless interface.
 func (m *Manager) IfaceStatus(ctx context.Context, name string) (map[string]any, error) {
 	params := map[string]any{"name": name}
@@ -5,6 +5,7 @@ package wpa_supplicant_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/honeybbq/goubus/v2/internal/base/wpa_supplicant"
@@ -44,6 +45,8 @@ func testWpaSTA(t *testing.T, ctx context.Context, mock *testutil.MockTransport,
 	testWpaSTAWPSStart(t, ctx, mock, mgr)
 	testWpaSTAWPSCancel(t, ctx, mock, mgr)
 	testWpaSTAControl(t, ctx, mock, mgr)
+	testWpaSTAStatus(t, ctx, mock, mgr)
+	testWpaSTANetworks(t, ctx, mock, mgr)
 }
 
 func testWpaSTAReload(t *testing.T, ctx context.Context, mock *testutil.MockTransport, mgr *wpa_supplicant.Manager) {
@@ -129,3 +132,104 @@ func testWpaSTAControl(t *testing.T, ctx context.Context, mock *testutil.MockTra
 		}
 	})
 }
+
+func addControlReply(mock *testutil.MockTransport, command, reply string) {
+	mock.AddResponseForArgs("wpa_supplicant.wlan0", "control", map[string]any{"command": command},
+		map[string]any{"result": reply})
+}
+
+func testWpaSTAStatus(t *testing.T, ctx context.Context, mock *testutil.MockTransport, mgr *wpa_supplicant.Manager) {
+	t.Helper()
+	t.Run("Status", func(t *testing.T) {
+		addControlReply(mock, "STATUS", "bssid=aa:bb:cc:dd:ee:ff\nfreq=5180\nssid=Upstream\nid=0\n"+
+			"mode=station\nkey_mgmt=WPA2-PSK\nwpa_state=COMPLETED\nip_address=192.168.8.20\n")
+
+		status, err := mgr.STA("wpa_supplicant.wlan0").Status(ctx)
+		if err != nil {
+			t.Fatalf("Status failed: %v", err)
+		}
+
+		if !status.Connected() || status.SSID != "Upstream" || status.Freq != 5180 || status.NetworkID != 0 {
+			t.Errorf("unexpected status: %+v", status)
+		}
+
+		if status.Fields["mode"] != "station" {
+			t.Errorf("expected untyped fields to be kept, got %v", status.Fields)
+		}
+	})
+
+	t.Run("ScanResults", func(t *testing.T) {
+		addControlReply(mock, "SCAN", "FAIL-BUSY\n")
+		addControlReply(mock, "SCAN_RESULTS", "bssid / frequency / signal level / flags / ssid\n"+
+			"aa:bb:cc:dd:ee:ff\t5180\t-52\t[WPA2-PSK-CCMP][ESS]\tUpstream\n"+
+			"11:22:33:44:55:66\t2412\t-80\t[ESS]\t\n")
+
+		sta := mgr.STA("wpa_supplicant.wlan0")
+
+		err := sta.Scan(ctx)
+		if err == nil {
+			t.Error("expected a busy scan to fail")
+		}
+
+		results, err := sta.ScanResults(ctx)
+		if err != nil {
+			t.Fatalf("ScanResults failed: %v", err)
+		}
+
+		if len(results) != 2 || results[0].Signal != -52 || results[0].SSID != "Upstream" || results[1].SSID != "" {
+			t.Errorf("unexpected scan results: %+v", results)
+		}
+	})
+}
+
+func testWpaSTANetworks(t *testing.T, ctx context.Context, mock *testutil.MockTransport, mgr *wpa_supplicant.Manager) {
+	t.Helper()
+	t.Run("AddNetwork", func(t *testing.T) {
+		mock.AddResponse("wpa_supplicant.wlan0", "control", map[string]any{"result": "OK"})
+		addControlReply(mock, "ADD_NETWORK", "3\n")
+
+		sta := mgr.STA("wpa_supplicant.wlan0")
+
+		_, err := sta.AddNetwork(ctx, wpa_supplicant.NetworkConfig{SSID: "Hotel", PSK: "short"})
+		if err == nil {
+			t.Fatal("expected a short passphrase to be rejected")
+		}
+
+		start := len(mock.Calls)
+
+		id, err := sta.AddNetwork(ctx, wpa_supplicant.NetworkConfig{SSID: "Hotel", PSK: "secret123", Hidden: true})
+		if err != nil {
+			t.Fatalf("AddNetwork failed: %v", err)
+		}
+
+		var commands []string
+
+		for _, call := range mock.Calls[start:] {
+			params, _ := call.Data.(map[string]any)
+			command, _ := params["command"].(string)
+			commands = append(commands, command)
+		}
+
+		want := []string{
+			"ADD_NETWORK", "SET_NETWORK 3 ssid 486f74656c", "SET_NETWORK 3 key_mgmt WPA-PSK",
+			`SET_NETWORK 3 psk "secret123"`, "SET_NETWORK 3 scan_ssid 1",
+		}
+		if id != 3 || strings.Join(commands, "|") != strings.Join(want, "|") {
+			t.Errorf("unexpected commands for network %d: %q", id, commands)
+		}
+	})
+
+	t.Run("Networks", func(t *testing.T) {
+		addControlReply(mock, "LIST_NETWORKS", "network id / ssid / bssid / flags\n"+
+			"0\tUpstream\tany\t[CURRENT]\n3\tHotel\tany\t[DISABLED]\n")
+
+		networks, err := mgr.STA("wpa_supplicant.wlan0").Networks(ctx)
+		if err != nil {
+			t.Fatalf("Networks failed: %v", err)
+		}
+
+		if len(networks) != 2 || !networks[0].Current() || !networks[1].Disabled() || networks[1].ID != 3 {
+			t.Errorf("unexpected networks: %+v", networks)
+		}
+	})
+}
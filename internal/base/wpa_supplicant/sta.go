@@ -0,0 +1,289 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package wpa_supplicant
+
+import (
+	"context"
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+const (
+	minPassphraseLength = 8
+	maxPassphraseLength = 63
+	rawPSKLength        = 64
+)
+
+// Status retrieves the connection state of the interface.
+func (c *STAContext) Status(ctx context.Context) (*STAStatus, error) {
+	out, err := c.command(ctx, "STATUS")
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]string{}
+
+	for line := range strings.SplitSeq(out, "\n") {
+		key, value, ok := strings.Cut(line, "=")
+		if ok {
+			fields[key] = value
+		}
+	}
+
+	status := &STAStatus{
+		Fields:         fields,
+		State:          fields["wpa_state"],
+		SSID:           fields["ssid"],
+		BSSID:          fields["bssid"],
+		KeyMgmt:        fields["key_mgmt"],
+		PairwiseCipher: fields["pairwise_cipher"],
+		IPAddress:      fields["ip_address"],
+		Address:        fields["address"],
+		Freq:           atoi(fields["freq"], 0),
+		NetworkID:      atoi(fields["id"], -1),
+	}
+
+	return status, nil
+}
+
+// Scan starts a scan. The results are available from ScanResults once it completes,
+// which usually takes a few seconds.
+func (c *STAContext) Scan(ctx context.Context) error {
+	_, err := c.command(ctx, "SCAN")
+
+	return err
+}
+
+// ScanResults retrieves the BSSs found by the last scan.
+func (c *STAContext) ScanResults(ctx context.Context) ([]ScanResult, error) {
+	out, err := c.command(ctx, "SCAN_RESULTS")
+	if err != nil {
+		return nil, err
+	}
+
+	results := []ScanResult{}
+
+	// bssid / frequency / signal level / flags / ssid
+	for _, cols := range tableRows(out, 4) {
+		result := ScanResult{
+			BSSID:  cols[0],
+			Freq:   atoi(cols[1], 0),
+			Signal: atoi(cols[2], 0),
+			Flags:  cols[3],
+		}
+
+		if len(cols) > 4 {
+			result.SSID = cols[4]
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// Networks lists the configured networks.
+func (c *STAContext) Networks(ctx context.Context) ([]Network, error) {
+	out, err := c.command(ctx, "LIST_NETWORKS")
+	if err != nil {
+		return nil, err
+	}
+
+	networks := []Network{}
+
+	// network id / ssid / bssid / flags
+	for _, cols := range tableRows(out, 3) {
+		network := Network{ID: atoi(cols[0], -1), SSID: cols[1], BSSID: cols[2]}
+		if len(cols) > 3 {
+			network.Flags = cols[3]
+		}
+
+		networks = append(networks, network)
+	}
+
+	return networks, nil
+}
+
+// AddNetwork adds a disabled network and returns its ID; SelectNetwork connects to it.
+// Runtime networks are lost when the interface is reloaded, as OpenWrt generates the
+// wpa_supplicant configuration from the wireless UCI package.
+func (c *STAContext) AddNetwork(ctx context.Context, cfg NetworkConfig) (int, error) {
+	settings, err := networkSettings(cfg)
+	if err != nil {
+		return 0, err
+	}
+
+	out, err := c.command(ctx, "ADD_NETWORK")
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := strconv.Atoi(out)
+	if err != nil {
+		return 0, errdefs.Wrapf(errdefs.ErrInvalidResponse, "unexpected ADD_NETWORK reply %q", out)
+	}
+
+	for _, setting := range settings {
+		_, err = c.command(ctx, "SET_NETWORK "+strconv.Itoa(id)+" "+setting)
+		if err != nil {
+			_ = c.RemoveNetwork(ctx, id)
+
+			return 0, errdefs.Wrapf(err, "failed to configure network %d", id)
+		}
+	}
+
+	return id, nil
+}
+
+// SelectNetwork connects to a network and disables all others.
+func (c *STAContext) SelectNetwork(ctx context.Context, id int) error {
+	_, err := c.command(ctx, "SELECT_NETWORK "+strconv.Itoa(id))
+
+	return err
+}
+
+// RemoveNetwork removes a network, disconnecting if it is in use.
+func (c *STAContext) RemoveNetwork(ctx context.Context, id int) error {
+	_, err := c.command(ctx, "REMOVE_NETWORK "+strconv.Itoa(id))
+
+	return err
+}
+
+// Reassociate reconnects to the current network, also after Disconnect.
+func (c *STAContext) Reassociate(ctx context.Context) error {
+	_, err := c.command(ctx, "REASSOCIATE")
+
+	return err
+}
+
+// Disconnect disconnects and stays disconnected until Reassociate or SelectNetwork.
+func (c *STAContext) Disconnect(ctx context.Context) error {
+	_, err := c.command(ctx, "DISCONNECT")
+
+	return err
+}
+
+// command runs a control command and fails on FAIL and UNKNOWN COMMAND replies.
+func (c *STAContext) command(ctx context.Context, command string) (string, error) {
+	verb, _, _ := strings.Cut(command, " ")
+
+	out, err := c.Control(ctx, command)
+	if err != nil {
+		return "", errdefs.Wrapf(err, "%s on %s", verb, c.name)
+	}
+
+	out = strings.TrimSpace(out)
+
+	switch {
+	case strings.HasPrefix(out, "FAIL"):
+		return "", errdefs.Wrapf(errdefs.ErrUnknown, "%s on %s: %s", verb, c.name, out)
+	case out == "UNKNOWN COMMAND":
+		return "", errdefs.Wrapf(errdefs.ErrNotSupported, "%s on %s", verb, c.name)
+	}
+
+	return out, nil
+}
+
+// networkSettings converts cfg to SET_NETWORK arguments. The SSID is hex encoded, so any
+// byte sequence is accepted.
+func networkSettings(cfg NetworkConfig) ([]string, error) {
+	if cfg.SSID == "" {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "ssid is required")
+	}
+
+	psk, err := pskSetting(cfg.PSK)
+	if err != nil {
+		return nil, err
+	}
+
+	keyMgmt := cfg.KeyMgmt
+	if keyMgmt == "" {
+		keyMgmt = "WPA-PSK"
+		if psk == "" {
+			keyMgmt = "NONE"
+		}
+	}
+
+	settings := []string{"ssid " + hex.EncodeToString([]byte(cfg.SSID)), "key_mgmt " + keyMgmt}
+
+	if psk != "" {
+		settings = append(settings, psk)
+	}
+
+	if cfg.BSSID != "" {
+		settings = append(settings, "bssid "+cfg.BSSID)
+	}
+
+	if cfg.Priority != 0 {
+		settings = append(settings, "priority "+strconv.Itoa(cfg.Priority))
+	}
+
+	if cfg.Hidden {
+		settings = append(settings, "scan_ssid 1")
+	}
+
+	return settings, nil
+}
+
+// pskSetting returns the psk argument for a passphrase or raw key, or "" for an open network.
+func pskSetting(psk string) (string, error) {
+	if psk == "" {
+		return "", nil
+	}
+
+	if len(psk) == rawPSKLength && isHex(psk) {
+		return "psk " + psk, nil
+	}
+
+	if len(psk) < minPassphraseLength || len(psk) > maxPassphraseLength || !isPrintableASCII(psk) {
+		return "", errdefs.Wrapf(errdefs.ErrInvalidParameter,
+			"passphrase must be %d to %d printable ASCII characters", minPassphraseLength, maxPassphraseLength)
+	}
+
+	return `psk "` + psk + `"`, nil
+}
+
+// tableRows splits the tab-separated rows of a control reply, skipping the header line and
+// rows with fewer than minCols columns.
+func tableRows(out string, minCols int) [][]string {
+	var rows [][]string
+
+	lines := strings.Split(out, "\n")
+	for _, line := range lines[min(1, len(lines)):] {
+		cols := strings.Split(line, "\t")
+		if len(cols) >= minCols {
+			rows = append(rows, cols)
+		}
+	}
+
+	return rows
+}
+
+func atoi(s string, fallback int) int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return fallback
+	}
+
+	return n
+}
+
+func isPrintableASCII(s string) bool {
+	for i := range len(s) {
+		if s[i] < ' ' || s[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isHex(s string) bool {
+	_, err := hex.DecodeString(s)
+
+	return err == nil
+}
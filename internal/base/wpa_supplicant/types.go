@@ -0,0 +1,79 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package wpa_supplicant
+
+import "strings"
+
+// Connection states reported as wpa_state.
+const (
+	StateDisconnected     = "DISCONNECTED"
+	StateInactive         = "INACTIVE"
+	StateScanning         = "SCANNING"
+	StateAssociating      = "ASSOCIATING"
+	StateAssociated       = "ASSOCIATED"
+	StateFourWayHandshake = "4WAY_HANDSHAKE"
+	StateCompleted        = "COMPLETED"
+)
+
+// STAStatus is the connection state of a STA interface, as reported by the STATUS command.
+type STAStatus struct {
+	// Fields holds every reported key, including those without a typed field.
+	Fields         map[string]string `json:"fields"`
+	State          string            `json:"wpa_state"`
+	SSID           string            `json:"ssid"`
+	BSSID          string            `json:"bssid"`
+	KeyMgmt        string            `json:"key_mgmt"`
+	PairwiseCipher string            `json:"pairwise_cipher"`
+	IPAddress      string            `json:"ip_address"`
+	Address        string            `json:"address"`
+	Freq           int               `json:"freq"`
+	// NetworkID is the ID of the selected network, or -1 when none is selected.
+	NetworkID int `json:"id"`
+}
+
+// Connected reports whether the interface completed authentication with an AP.
+func (s *STAStatus) Connected() bool {
+	return s.State == StateCompleted
+}
+
+// ScanResult is a BSS found by the last scan. Signal is in dBm.
+type ScanResult struct {
+	BSSID  string `json:"bssid"`
+	Flags  string `json:"flags"`
+	SSID   string `json:"ssid"`
+	Freq   int    `json:"freq"`
+	Signal int    `json:"signal"`
+}
+
+// Network is a network block configured in wpa_supplicant.
+type Network struct {
+	SSID  string `json:"ssid"`
+	BSSID string `json:"bssid"`
+	Flags string `json:"flags"`
+	ID    int    `json:"id"`
+}
+
+// Current reports whether the network is the one in use.
+func (n *Network) Current() bool {
+	return strings.Contains(n.Flags, "[CURRENT]")
+}
+
+// Disabled reports whether the network is disabled.
+func (n *Network) Disabled() bool {
+	return strings.Contains(n.Flags, "[DISABLED]")
+}
+
+// NetworkConfig describes a network to add to a STA interface.
+type NetworkConfig struct {
+	SSID string `json:"ssid"`
+	// PSK is a WPA passphrase of 8 to 63 characters or a 64-digit hex key; empty for open networks.
+	PSK string `json:"psk,omitempty"`
+	// KeyMgmt overrides the key management, e.g. "SAE"; it defaults to "WPA-PSK" with a PSK and "NONE" without.
+	KeyMgmt string `json:"key_mgmt,omitempty"`
+	// BSSID pins the network to one AP.
+	BSSID    string `json:"bssid,omitempty"`
+	Priority int    `json:"priority,omitempty"`
+	// Hidden probes for the SSID, which is needed for networks that do not broadcast it.
+	Hidden bool `json:"hidden,omitempty"`
+}
@@ -493,7 +493,8 @@ func EncodeBlobmsgValue(value any) (uint8, []byte, error) {
 }
 
 var (
-	ErrNilValue = errors.New("nil value")
+	// ErrNilValue is returned for blobmsg values without a payload. It matches errdefs.ErrInvalidResponse.
+	ErrNilValue = fmt.Errorf("nil value: %w", errdefs.ErrInvalidResponse)
 )
 
 func EncodeBasicValue(value any) (uint8, []byte, error) {
@@ -1109,7 +1110,7 @@ func ReadMessage(reader io.Reader) (*UbusMessageHeader, []byte, error) {
 
 	_, err = io.ReadFull(reader, headerBytesBuf)
 	if err != nil {
-		return nil, nil, errdefs.Wrapf(errdefs.ErrConnectionFailed, "read header: %v", err)
+		return nil, nil, errdefs.Wrapf(errdefs.FromTransport(err), "read header")
 	}
 
 	hdr := &UbusMessageHeader{}
@@ -1123,7 +1124,7 @@ func ReadMessage(reader io.Reader) (*UbusMessageHeader, []byte, error) {
 
 	_, err = io.ReadFull(reader, blobHeader)
 	if err != nil {
-		return nil, nil, errdefs.Wrapf(errdefs.ErrConnectionFailed, "read blob header: %v", err)
+		return nil, nil, errdefs.Wrapf(errdefs.FromTransport(err), "read blob header")
 	}
 
 	blobLen := binary.BigEndian.Uint32(blobHeader)
@@ -1136,7 +1137,7 @@ func ReadMessage(reader io.Reader) (*UbusMessageHeader, []byte, error) {
 
 		_, err = io.ReadFull(reader, body)
 		if err != nil {
-			return nil, nil, errdefs.Wrapf(errdefs.ErrConnectionFailed, "read blob body: %v", err)
+			return nil, nil, errdefs.Wrapf(errdefs.FromTransport(err), "read blob body")
 		}
 
 		payload = append(payload, body...)
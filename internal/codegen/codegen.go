@@ -0,0 +1,207 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+// Package codegen turns ubus method signatures into typed Go managers.
+package codegen
+
+import (
+	"bytes"
+	"go/format"
+	"go/token"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+// goTypes maps the goubus.ArgType names to the Go types of the generated request fields.
+var goTypes = map[string]string{
+	goubus.ArgTypeArray:   "[]any",
+	goubus.ArgTypeObject:  "map[string]any",
+	goubus.ArgTypeString:  "string",
+	goubus.ArgTypeNumber:  "int",
+	goubus.ArgTypeBoolean: "bool",
+}
+
+// initialisms are kept upper case in generated identifiers.
+var initialisms = map[string]bool{
+	"ACL": true, "API": true, "DHCP": true, "DNS": true, "ID": true, "IP": true, "IPV4": true, "IPV6": true,
+	"JSON": true, "MAC": true, "RPC": true, "SSID": true, "UCI": true, "URL": true, "WPS": true,
+}
+
+type objectData struct {
+	Path    string
+	Name    string
+	Methods []methodData
+}
+
+type methodData struct {
+	Name   string
+	Method string
+	Args   []argData
+}
+
+type argData struct {
+	Field string
+	Type  string
+	Tag   string
+}
+
+type fileData struct {
+	Package string
+	Source  string
+	Objects []objectData
+}
+
+var fileTemplate = template.Must(template.New("file").Parse(`// Code generated by goubus-gen. DO NOT EDIT.
+{{- if .Source}}
+// Source: {{.Source}}
+{{- end}}
+
+package {{.Package}}
+
+import (
+	"context"
+
+	"github.com/honeybbq/goubus/v2"
+)
+{{range $obj := .Objects}}
+// {{$obj.Name}}Manager calls the methods of the "{{$obj.Path}}" ubus object.
+type {{$obj.Name}}Manager struct {
+	caller goubus.Transport
+}
+
+// New{{$obj.Name}}Manager creates a new {{$obj.Name}}Manager.
+func New{{$obj.Name}}Manager(t goubus.Transport) *{{$obj.Name}}Manager {
+	return &{{$obj.Name}}Manager{caller: t}
+}
+{{range $m := $obj.Methods}}{{if $m.Args}}
+// {{$obj.Name}}{{$m.Name}}Request holds the arguments of {{$obj.Path}}.{{$m.Method}}. Zero values are omitted.
+type {{$obj.Name}}{{$m.Name}}Request struct {
+{{- range $m.Args}}
+	{{.Field}} {{.Type}} ` + "`json:\"{{.Tag}},omitempty\"`" + `
+{{- end}}
+}
+{{end}}
+// {{$obj.Name}}{{$m.Name}}Response holds the reply of {{$obj.Path}}.{{$m.Method}}.
+// ubus signatures do not describe replies, so it is left untyped.
+type {{$obj.Name}}{{$m.Name}}Response map[string]any
+
+// {{$m.Name}} calls {{$obj.Path}}.{{$m.Method}}.
+func (m *{{$obj.Name}}Manager) {{$m.Name}}(ctx context.Context
+{{- if $m.Args}}, req {{$obj.Name}}{{$m.Name}}Request{{end}}) ({{$obj.Name}}{{$m.Name}}Response, error) {
+	res, err := goubus.Call[{{$obj.Name}}{{$m.Name}}Response](ctx, m.caller, "{{$obj.Path}}", "{{$m.Method}}",
+		{{- if $m.Args}} req{{else}} nil{{end}})
+	if err != nil {
+		return nil, err
+	}
+
+	return *res, nil
+}
+{{end}}{{end}}`))
+
+// Generate renders a Go source file declaring one typed manager per object in package pkg.
+// source is recorded in the file header when it is not empty.
+func Generate(pkg, source string, objects []goubus.ObjectInfo) ([]byte, error) {
+	if !token.IsIdentifier(pkg) {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid package name %q", pkg)
+	}
+
+	data := fileData{Package: pkg, Source: source}
+	names := map[string]bool{}
+
+	sorted := slices.SortedFunc(slices.Values(objects), func(a, b goubus.ObjectInfo) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	for _, obj := range sorted {
+		data.Objects = append(data.Objects, objectData{
+			Path:    obj.Path,
+			Name:    unique(names, Identifier(obj.Path)),
+			Methods: methodsOf(obj),
+		})
+	}
+
+	var buf bytes.Buffer
+
+	err := fileTemplate.Execute(&buf, data)
+	if err != nil {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "render: %v", err)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "format generated code: %v", err)
+	}
+
+	return src, nil
+}
+
+func methodsOf(obj goubus.ObjectInfo) []methodData {
+	methods := make([]methodData, 0, len(obj.Methods))
+	names := map[string]bool{}
+
+	for _, method := range slices.Sorted(maps.Keys(obj.Methods)) {
+		signature := obj.Methods[method]
+		fields := map[string]bool{}
+		args := make([]argData, 0, len(signature))
+
+		for _, arg := range slices.Sorted(maps.Keys(signature)) {
+			goType, ok := goTypes[signature[arg]]
+			if !ok {
+				goType = "any"
+			}
+
+			args = append(args, argData{Field: unique(fields, Identifier(arg)), Type: goType, Tag: arg})
+		}
+
+		methods = append(methods, methodData{Name: unique(names, Identifier(method)), Method: method, Args: args})
+	}
+
+	return methods
+}
+
+// Identifier converts a ubus object, method or argument name to an exported Go identifier,
+// e.g. "network.interface.lan" to "NetworkInterfaceLan" and "link-ext" to "LinkExt".
+func Identifier(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var ident strings.Builder
+
+	for _, word := range words {
+		if upper := strings.ToUpper(word); initialisms[upper] {
+			ident.WriteString(upper)
+
+			continue
+		}
+
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		ident.WriteString(string(runes))
+	}
+
+	if ident.Len() == 0 || !unicode.IsLetter([]rune(ident.String())[0]) {
+		return "X" + ident.String()
+	}
+
+	return ident.String()
+}
+
+// unique returns name, or name with a numeric suffix if it was already taken.
+func unique(taken map[string]bool, name string) string {
+	candidate := name
+	for i := 2; taken[candidate]; i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+
+	taken[candidate] = true
+
+	return candidate
+}
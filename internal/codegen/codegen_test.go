@@ -0,0 +1,110 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package codegen_test
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/codegen"
+)
+
+const cliDump = `'network.interface.lan' @5d3a1f2b
+	"up":{}
+	"add_device":{"name":"String","link-ext":"Boolean","vlan":"Array"}
+'system' @2ca2a7a9
+	"board":{}
+	"reboot":{"delay":"Integer"}
+`
+
+func TestParseDump(t *testing.T) {
+	t.Run("CLI", func(t *testing.T) {
+		objects, err := codegen.ParseDump([]byte(cliDump))
+		if err != nil {
+			t.Fatalf("ParseDump failed: %v", err)
+		}
+
+		if len(objects) != 2 || objects[0].Path != "network.interface.lan" || !objects[1].HasMethod("board") {
+			t.Fatalf("unexpected objects: %+v", objects)
+		}
+
+		args := objects[0].Methods["add_device"]
+		if args["link-ext"] != goubus.ArgTypeBoolean || args["vlan"] != goubus.ArgTypeArray {
+			t.Errorf("unexpected signature: %v", args)
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		objects, err := codegen.ParseDump([]byte(`{"system":{"reboot":{"delay":"number"}}}`))
+		if err != nil {
+			t.Fatalf("ParseDump failed: %v", err)
+		}
+
+		if len(objects) != 1 || objects[0].Methods["reboot"]["delay"] != goubus.ArgTypeNumber {
+			t.Errorf("unexpected objects: %+v", objects)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := codegen.ParseDump([]byte("not a dump"))
+		if !errdefs.IsInvalidParameter(err) {
+			t.Errorf("expected invalid parameter, got %v", err)
+		}
+	})
+}
+
+func TestGenerate(t *testing.T) {
+	objects, err := codegen.ParseDump([]byte(cliDump))
+	if err != nil {
+		t.Fatalf("ParseDump failed: %v", err)
+	}
+
+	src, err := codegen.Generate("ubus", "ubus -v list", objects)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	_, err = parser.ParseFile(token.NewFileSet(), "gen.go", src, parser.AllErrors)
+	if err != nil {
+		t.Fatalf("generated code does not parse: %v\n%s", err, src)
+	}
+
+	for _, want := range []string{
+		"func NewSystemManager(t goubus.Transport) *SystemManager",
+		"LinkExt bool   `json:\"link-ext,omitempty\"`",
+		"func (m *SystemManager) Board(ctx context.Context) (SystemBoardResponse, error)",
+		"func (m *SystemManager) Reboot(ctx context.Context, req SystemRebootRequest) (SystemRebootResponse, error)",
+		`goubus.Call[NetworkInterfaceLanUpResponse](ctx, m.caller, "network.interface.lan", "up", nil)`,
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("generated code lacks %q", want)
+		}
+	}
+
+	_, err = codegen.Generate("not-a-package", "", objects)
+	if !errdefs.IsInvalidParameter(err) {
+		t.Errorf("expected invalid parameter, got %v", err)
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	tests := map[string]string{
+		"network.interface.lan": "NetworkInterfaceLan",
+		"link-ext":              "LinkExt",
+		"ubus_rpc_session":      "UbusRPCSession",
+		"macaddr":               "Macaddr",
+		"mac":                   "MAC",
+		"6in4":                  "X6in4",
+	}
+
+	for name, want := range tests {
+		if got := codegen.Identifier(name); got != want {
+			t.Errorf("Identifier(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package codegen
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+var (
+	// objectLine matches an object header of "ubus -v list", e.g. 'system' @2ca2a7a9.
+	objectLine = regexp.MustCompile(`^'([^']+)' @([0-9a-fA-F]+)$`)
+	// methodLine matches a method signature of "ubus -v list", e.g. "reboot":{"delay":"Integer"}.
+	methodLine = regexp.MustCompile(`^\s+"([^"]+)":(\{.*\})$`)
+)
+
+// cliArgTypes maps the argument types printed by the ubus CLI to the goubus.ArgType names.
+var cliArgTypes = map[string]string{
+	"Array":   goubus.ArgTypeArray,
+	"Table":   goubus.ArgTypeObject,
+	"String":  goubus.ArgTypeString,
+	"Integer": goubus.ArgTypeNumber,
+	"Double":  goubus.ArgTypeNumber,
+	"Boolean": goubus.ArgTypeBoolean,
+}
+
+// ParseDump decodes a dump of the registered ubus objects. It accepts the text output of
+// "ubus -v list", the result of the JSON-RPC "list" method and a JSON array of goubus.ObjectInfo.
+func ParseDump(data []byte) ([]goubus.ObjectInfo, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "empty dump")
+	}
+
+	switch trimmed[0] {
+	case '[':
+		var objects []goubus.ObjectInfo
+
+		err := json.Unmarshal(trimmed, &objects)
+		if err != nil {
+			return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "decode object list: %v", err)
+		}
+
+		return objects, nil
+	case '{':
+		var listing map[string]map[string]map[string]string
+
+		err := json.Unmarshal(trimmed, &listing)
+		if err != nil {
+			return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "decode list result: %v", err)
+		}
+
+		objects := make([]goubus.ObjectInfo, 0, len(listing))
+		for path, methods := range listing {
+			objects = append(objects, goubus.ObjectInfo{Path: path, Methods: methods})
+		}
+
+		return objects, nil
+	default:
+		return parseCLIDump(trimmed)
+	}
+}
+
+func parseCLIDump(data []byte) ([]goubus.ObjectInfo, error) {
+	var objects []goubus.ObjectInfo
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+
+		if match := objectLine.FindStringSubmatch(text); match != nil {
+			objects = append(objects, goubus.ObjectInfo{Path: match[1], Methods: map[string]map[string]string{}})
+
+			continue
+		}
+
+		match := methodLine.FindStringSubmatch(text)
+		if match == nil || len(objects) == 0 {
+			if strings.TrimSpace(text) == "" {
+				continue
+			}
+
+			return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "line %d: unexpected %q", line, text)
+		}
+
+		var args map[string]string
+
+		err := json.Unmarshal([]byte(match[2]), &args)
+		if err != nil {
+			return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "line %d: %v", line, err)
+		}
+
+		for name, typ := range args {
+			if argType, ok := cliArgTypes[typ]; ok {
+				args[name] = argType
+			} else {
+				args[name] = goubus.ArgTypeUnknown
+			}
+		}
+
+		objects[len(objects)-1].Methods[match[1]] = args
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "read dump: %v", err)
+	}
+
+	return objects, nil
+}
@@ -13,11 +13,14 @@ import (
 	"github.com/honeybbq/goubus/v2/errdefs"
 )
 
+const mockEventBuffer = 16
+
 // MockTransport is a mock implementation of goubus.Transport for testing.
 type MockTransport struct {
 	Logger    *slog.Logger
 	Responses map[string]any // key: "service.method" or "service.method.jsonArgs"
 	Calls     []MockCall
+	subs      []*mockSubscription
 	mu        sync.Mutex
 }
 
@@ -65,12 +68,20 @@ func (m *MockTransport) Call(ctx context.Context, service, method string, data a
 
 	key := fmt.Sprintf("%s.%s", service, method)
 
-	resp, ok := m.Responses[key]
+	resp, ok := m.Responses[argsKey(key, data)]
+	if !ok {
+		resp, ok = m.Responses[key]
+	}
 
 	if !ok {
 		return nil, errdefs.Wrapf(errdefs.ErrNotFound, "no mock response for %s", key)
 	}
 
+	respErr, isErr := resp.(error)
+	if isErr {
+		return nil, respErr
+	}
+
 	return &MockResult{Data: resp}, nil
 }
 
@@ -83,6 +94,7 @@ func (m *MockTransport) Close() error {
 }
 
 // AddResponse adds a mock response for a service and method.
+// A response that is an error is returned as the call error.
 func (m *MockTransport) AddResponse(service, method string, response any) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -90,6 +102,25 @@ func (m *MockTransport) AddResponse(service, method string, response any) {
 	m.Responses[fmt.Sprintf("%s.%s", service, method)] = response
 }
 
+// AddResponseForArgs adds a mock response for a call with specific arguments. It takes precedence
+// over a response added with AddResponse for the same service and method.
+func (m *MockTransport) AddResponseForArgs(service, method string, args, response any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.Responses[argsKey(fmt.Sprintf("%s.%s", service, method), args)] = response
+}
+
+// argsKey returns the "service.method.jsonArgs" key of a call.
+func argsKey(key string, args any) string {
+	b, err := json.Marshal(args)
+	if err != nil {
+		return key
+	}
+
+	return key + "." + string(b)
+}
+
 // AddResponseFromFile loads a mock response from a JSON file in the testdata directory.
 // The path should be relative to the project root, e.g., "internal/testdata/rax3000m/system_board.json".
 func (m *MockTransport) AddResponseFromFile(service, method string, filePath string) error {
@@ -121,3 +152,78 @@ func (m *MockTransport) GetLastCall() MockCall {
 
 	return m.Calls[len(m.Calls)-1]
 }
+
+type mockSubscription struct {
+	events chan goubus.Event
+	done   <-chan struct{}
+	source string
+	listen bool
+}
+
+// Subscribe implements goubus.Subscriber; notifications are delivered with Emit.
+func (m *MockTransport) Subscribe(ctx context.Context, object string) (*goubus.Subscription, error) {
+	return m.subscribe(ctx, object, false), nil
+}
+
+// Listen implements goubus.Subscriber; events are delivered with EmitEvent.
+func (m *MockTransport) Listen(ctx context.Context, pattern string) (*goubus.Subscription, error) {
+	return m.subscribe(ctx, pattern, true), nil
+}
+
+func (m *MockTransport) subscribe(ctx context.Context, source string, listen bool) *goubus.Subscription {
+	sub := &mockSubscription{events: make(chan goubus.Event, mockEventBuffer), source: source, listen: listen}
+
+	subscription := goubus.NewSubscription(ctx, func(ctx context.Context, emit func(goubus.Event) bool) error {
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case ev := <-sub.events:
+				if !emit(ev) {
+					return nil
+				}
+			}
+		}
+	})
+	sub.done = subscription.Done()
+
+	m.mu.Lock()
+	m.subs = append(m.subs, sub)
+	m.mu.Unlock()
+
+	return subscription
+}
+
+// Emit delivers a notification of object to its active subscriptions.
+func (m *MockTransport) Emit(object, typ string, data map[string]any) {
+	m.deliver(goubus.Event{Object: object, Type: typ, Data: data}, func(sub *mockSubscription) bool {
+		return !sub.listen && sub.source == object
+	})
+}
+
+// EmitEvent delivers a ubus event to the active listeners whose pattern matches id.
+func (m *MockTransport) EmitEvent(id string, data map[string]any) {
+	m.deliver(goubus.Event{Type: id, Data: data}, func(sub *mockSubscription) bool {
+		return sub.listen && goubus.MatchEventPattern(sub.source, id)
+	})
+}
+
+func (m *MockTransport) deliver(event goubus.Event, match func(*mockSubscription) bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, sub := range m.subs {
+		if !match(sub) {
+			continue
+		}
+
+		if sub.listen {
+			event.Object = sub.source
+		}
+
+		select {
+		case sub.events <- event:
+		case <-sub.done:
+		}
+	}
+}
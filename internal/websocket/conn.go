@@ -0,0 +1,481 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+// Package websocket implements the parts of RFC 6455 the ubus WebSocket bridges need: the
+// opening handshake, text messages, ping/pong and the closing handshake.
+package websocket
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // SHA-1 is mandated by RFC 6455 for Sec-WebSocket-Accept.
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+	keyLength  = 16
+	maskLength = 4
+
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xa
+
+	finBit      = 0x80
+	maskBit     = 0x80
+	opcodeMask  = 0x0f
+	lengthMask  = 0x7f
+	length16    = 126
+	length64    = 127
+	maxControl  = 125
+	closeNormal = 1000
+
+	// controlTimeout bounds writing a pong or close frame.
+	controlTimeout = 5 * time.Second
+
+	// MaxMessageSize bounds the size of a received message.
+	MaxMessageSize = 16 << 20
+)
+
+var (
+	// ErrClosed is returned once the peer closed the connection.
+	ErrClosed = errors.New("websocket: connection closed")
+	// ErrProtocol is returned for frames that violate RFC 6455.
+	ErrProtocol = errors.New("websocket: protocol error")
+)
+
+// Conn is a WebSocket connection. ReadMessage must be called from one goroutine;
+// WriteMessage, Ping and Close may be called concurrently.
+type Conn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	// writing holds a token while a frame is written; unlike a mutex, waiting for it ends with
+	// the context of the write.
+	writing     chan struct{}
+	protocol    string
+	idleTimeout time.Duration
+	// broken is set once a write failed, possibly leaving a partial frame behind.
+	broken atomic.Bool
+	client bool
+}
+
+func newConn(conn net.Conn, reader *bufio.Reader, protocol string, client bool) *Conn {
+	return &Conn{
+		conn:     conn,
+		reader:   reader,
+		writing:  make(chan struct{}, 1),
+		protocol: protocol,
+		client:   client,
+	}
+}
+
+// Dial opens a WebSocket connection to rawURL ("ws://" or "wss://") offering the subprotocols.
+// tlsConfig is used for wss and may be nil.
+func Dial(
+	ctx context.Context, rawURL string, header http.Header, tlsConfig *tls.Config, protocols ...string,
+) (*Conn, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := dialTarget(ctx, target, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	stop := context.AfterFunc(ctx, func() { _ = conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	ws, err := handshake(conn, target, header, protocols)
+	if err != nil {
+		_ = conn.Close()
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		return nil, err
+	}
+
+	return ws, nil
+}
+
+func dialTarget(ctx context.Context, target *url.URL, tlsConfig *tls.Config) (net.Conn, error) {
+	host := target.Host
+
+	switch target.Scheme {
+	case "ws":
+		if target.Port() == "" {
+			host = net.JoinHostPort(target.Hostname(), "80")
+		}
+
+		var dialer net.Dialer
+
+		return dialer.DialContext(ctx, "tcp", host)
+	case "wss":
+		if target.Port() == "" {
+			host = net.JoinHostPort(target.Hostname(), "443")
+		}
+
+		dialer := tls.Dialer{Config: tlsConfig}
+
+		return dialer.DialContext(ctx, "tcp", host)
+	default:
+		return nil, errors.New("websocket: unsupported scheme " + target.Scheme)
+	}
+}
+
+func handshake(conn net.Conn, target *url.URL, header http.Header, protocols []string) (*Conn, error) {
+	nonce := make([]byte, keyLength)
+	_, _ = rand.Read(nonce)
+	key := base64.StdEncoding.EncodeToString(nonce)
+
+	req := &http.Request{
+		Method:     http.MethodGet,
+		URL:        target,
+		Host:       target.Host,
+		Header:     header.Clone(),
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+	}
+	if req.Header == nil {
+		req.Header = make(http.Header)
+	}
+
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Sec-WebSocket-Key", key)
+	req.Header.Set("Sec-WebSocket-Version", "13")
+
+	if len(protocols) > 0 {
+		req.Header.Set("Sec-WebSocket-Protocol", strings.Join(protocols, ", "))
+	}
+
+	err := req.Write(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		return nil, &HandshakeError{StatusCode: resp.StatusCode}
+	}
+
+	if resp.Header.Get("Sec-WebSocket-Accept") != acceptKey(key) {
+		return nil, ErrProtocol
+	}
+
+	return newConn(conn, reader, resp.Header.Get("Sec-WebSocket-Protocol"), true), nil
+}
+
+// HandshakeError reports a server that refused the upgrade.
+type HandshakeError struct {
+	StatusCode int
+}
+
+func (e *HandshakeError) Error() string {
+	return "websocket: handshake failed with HTTP status " + http.StatusText(e.StatusCode)
+}
+
+// Upgrade completes the server side of the handshake on an HTTP request, selecting protocol if
+// the client offered it. It is used to serve WebSocket bridges in tests.
+func Upgrade(w http.ResponseWriter, r *http.Request, protocol string) (*Conn, error) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if key == "" || !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		http.Error(w, "not a websocket handshake", http.StatusBadRequest)
+
+		return nil, ErrProtocol
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		return nil, errors.New("websocket: response does not support hijacking")
+	}
+
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, err
+	}
+
+	response := "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + acceptKey(key) + "\r\n"
+	if protocol != "" && strings.Contains(r.Header.Get("Sec-WebSocket-Protocol"), protocol) {
+		response += "Sec-WebSocket-Protocol: " + protocol + "\r\n"
+	}
+
+	_, err = rw.WriteString(response + "\r\n")
+	if err == nil {
+		err = rw.Flush()
+	}
+
+	if err != nil {
+		_ = conn.Close()
+
+		return nil, err
+	}
+
+	return newConn(conn, rw.Reader, protocol, false), nil
+}
+
+// Protocol returns the subprotocol the server selected.
+func (c *Conn) Protocol() string {
+	return c.protocol
+}
+
+// SetIdleTimeout makes ReadMessage fail when no frame, including a pong, arrives for d. Together
+// with periodic pings it detects a peer that went away without closing the connection. It must
+// not be called while ReadMessage runs; zero disables the timeout.
+func (c *Conn) SetIdleTimeout(d time.Duration) {
+	c.idleTimeout = d
+}
+
+// ReadMessage returns the next text or binary message. It answers pings and returns ErrClosed
+// once the peer closes the connection.
+func (c *Conn) ReadMessage() ([]byte, error) {
+	var message []byte
+
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+
+		switch opcode {
+		case opPing:
+			err = c.writeControl(opPong, payload)
+			if err != nil {
+				return nil, err
+			}
+		case opPong:
+		case opClose:
+			_ = c.writeControl(opClose, payload)
+			_ = c.conn.Close()
+
+			return nil, ErrClosed
+		case opText, opBinary, opContinuation:
+			message = append(message, payload...)
+			if len(message) > MaxMessageSize {
+				return nil, ErrProtocol
+			}
+
+			if fin {
+				return message, nil
+			}
+		default:
+			return nil, ErrProtocol
+		}
+	}
+}
+
+// WriteMessage sends p as a text message. Waiting for another write and the write itself end
+// with ctx; a write cut off midway leaves a partial frame, so the connection must be closed.
+func (c *Conn) WriteMessage(ctx context.Context, p []byte) error {
+	return c.writeFrame(ctx, opText, p)
+}
+
+// Ping sends a ping frame; the peer answers with a pong that ReadMessage consumes.
+func (c *Conn) Ping(ctx context.Context) error {
+	return c.writeFrame(ctx, opPing, nil)
+}
+
+// Close sends a close frame and closes the connection without waiting for the peer. The close
+// frame is left out after a failed write.
+func (c *Conn) Close() error {
+	if !c.broken.Load() {
+		payload := binary.BigEndian.AppendUint16(nil, closeNormal)
+		_ = c.writeControl(opClose, payload)
+	}
+
+	return c.conn.Close()
+}
+
+// writeControl writes a control frame within controlTimeout.
+func (c *Conn) writeControl(opcode byte, payload []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), controlTimeout)
+	defer cancel()
+
+	return c.writeFrame(ctx, opcode, payload)
+}
+
+func (c *Conn) readFrame() (bool, byte, []byte, error) {
+	var head [2]byte
+
+	if c.idleTimeout > 0 {
+		_ = c.conn.SetReadDeadline(time.Now().Add(c.idleTimeout))
+	}
+
+	_, err := io.ReadFull(c.reader, head[:])
+	if err != nil {
+		return false, 0, nil, err
+	}
+
+	fin := head[0]&finBit != 0
+	opcode := head[0] & opcodeMask
+	masked := head[1]&maskBit != 0
+
+	length, err := c.readLength(head[1] & lengthMask)
+	if err != nil {
+		return false, 0, nil, err
+	}
+
+	if length > MaxMessageSize || (opcode >= opClose && (length > maxControl || !fin)) {
+		return false, 0, nil, ErrProtocol
+	}
+
+	payload, err := c.readPayload(length, masked)
+	if err != nil {
+		return false, 0, nil, err
+	}
+
+	return fin, opcode, payload, nil
+}
+
+// readPayload reads a frame payload and removes the mask of client frames.
+func (c *Conn) readPayload(length uint64, masked bool) ([]byte, error) {
+	var mask [maskLength]byte
+	if masked {
+		_, err := io.ReadFull(c.reader, mask[:])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	payload := make([]byte, length)
+
+	_, err := io.ReadFull(c.reader, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if masked {
+		applyMask(payload, mask)
+	}
+
+	return payload, nil
+}
+
+func (c *Conn) readLength(short byte) (uint64, error) {
+	switch short {
+	case length16:
+		var ext [2]byte
+
+		_, err := io.ReadFull(c.reader, ext[:])
+
+		return uint64(binary.BigEndian.Uint16(ext[:])), err
+	case length64:
+		var ext [8]byte
+
+		_, err := io.ReadFull(c.reader, ext[:])
+
+		return binary.BigEndian.Uint64(ext[:]), err
+	default:
+		return uint64(short), nil
+	}
+}
+
+// writeFrame sends a single frame; clients mask their frames as RFC 6455 requires.
+func (c *Conn) writeFrame(ctx context.Context, opcode byte, payload []byte) error {
+	frame := []byte{finBit | opcode}
+
+	var lengthBits byte
+	if c.client {
+		lengthBits = maskBit
+	}
+
+	switch length := len(payload); {
+	case length < length16:
+		frame = append(frame, lengthBits|byte(length))
+	case length <= math.MaxUint16:
+		frame = append(frame, lengthBits|length16)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(length))
+	default:
+		frame = append(frame, lengthBits|length64)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(length))
+	}
+
+	body := payload
+
+	if c.client {
+		var mask [maskLength]byte
+		_, _ = rand.Read(mask[:])
+		frame = append(frame, mask[:]...)
+
+		body = append([]byte(nil), payload...)
+		applyMask(body, mask)
+	}
+
+	select {
+	case c.writing <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	defer func() { <-c.writing }()
+
+	return c.write(ctx, append(frame, body...))
+}
+
+// write writes frame, cutting the write off when ctx ends.
+func (c *Conn) write(ctx context.Context, frame []byte) error {
+	_ = c.conn.SetWriteDeadline(time.Time{})
+
+	interrupted := make(chan struct{})
+	stop := context.AfterFunc(ctx, func() {
+		_ = c.conn.SetWriteDeadline(time.Now())
+		close(interrupted)
+	})
+
+	_, err := c.conn.Write(frame)
+
+	if !stop() {
+		// The deadline was moved; wait for it so that it cannot hit the next write.
+		<-interrupted
+	}
+
+	if err != nil {
+		c.broken.Store(true)
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+
+	return err
+}
+
+func applyMask(payload []byte, mask [maskLength]byte) {
+	for i := range payload {
+		payload[i] ^= mask[i%maskLength]
+	}
+}
+
+func acceptKey(key string) string {
+	sum := sha1.Sum([]byte(key + acceptGUID)) //nolint:gosec // mandated by RFC 6455.
+
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
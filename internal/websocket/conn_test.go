@@ -0,0 +1,121 @@
+package websocket_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/honeybbq/goubus/v2/internal/websocket"
+)
+
+func TestConn(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Upgrade(w, r, "echo")
+		if err != nil {
+			return
+		}
+
+		defer func() { _ = conn.Close() }()
+
+		for {
+			message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+
+			err = conn.WriteMessage(context.Background(), message)
+			if err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+
+	conn, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(server.URL, "http"), nil, nil, "echo")
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+
+	if conn.Protocol() != "echo" {
+		t.Errorf("unexpected protocol %q", conn.Protocol())
+	}
+
+	for _, size := range []int{0, 10, 200, 70000} {
+		message := bytes.Repeat([]byte("x"), size)
+
+		err = conn.WriteMessage(ctx, message)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		echo, err := conn.ReadMessage()
+		if err != nil || !bytes.Equal(echo, message) {
+			t.Fatalf("unexpected echo of %d bytes: %d bytes, %v", size, len(echo), err)
+		}
+	}
+
+	_ = conn.Close()
+
+	_, err = websocket.Dial(ctx, server.URL, nil, nil)
+	if err == nil {
+		t.Error("expected an error for an http URL")
+	}
+
+	plain := httptest.NewServer(http.NotFoundHandler())
+	defer plain.Close()
+
+	_, err = websocket.Dial(ctx, "ws"+strings.TrimPrefix(plain.URL, "http"), nil, nil)
+
+	var handshakeErr *websocket.HandshakeError
+	if !errors.As(err, &handshakeErr) || handshakeErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected a handshake error, got %v", err)
+	}
+}
+
+func TestConn_WriteContext(t *testing.T) {
+	release := make(chan struct{})
+
+	// The server never reads, so the writes block once the socket buffers are full.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Upgrade(w, r, "")
+		if err != nil {
+			return
+		}
+
+		<-release
+
+		_ = conn.Close()
+	}))
+	defer server.Close()
+	defer close(release)
+
+	conn, err := websocket.Dial(context.Background(), "ws"+strings.TrimPrefix(server.URL, "http"), nil, nil)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+
+	defer func() { _ = conn.Close() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	message := bytes.Repeat([]byte("x"), 1<<20)
+
+	for range 1024 {
+		err = conn.WriteMessage(ctx, message)
+		if err != nil {
+			break
+		}
+	}
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the blocked write to end with the context, got %v", err)
+	}
+}
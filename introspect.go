@@ -0,0 +1,142 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package goubus
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/blobmsg"
+)
+
+// Argument type names reported in method signatures, as used by uhttpd-mod-ubus.
+const (
+	ArgTypeArray   = "array"
+	ArgTypeObject  = "object"
+	ArgTypeString  = "string"
+	ArgTypeNumber  = "number"
+	ArgTypeBoolean = "boolean"
+	ArgTypeUnknown = "unknown"
+)
+
+// ObjectInfo describes a registered ubus object and its method signatures, like "ubus -v list".
+type ObjectInfo struct {
+	// Methods maps each method name to its arguments and their types (see the ArgType constants).
+	Methods map[string]map[string]string `json:"methods"`
+	// Path is the object name, e.g. "network.interface.lan".
+	Path string `json:"path"`
+	// ID is the ubus object id. It is only reported by the socket and SSH transports.
+	ID uint32 `json:"id,omitempty"`
+}
+
+// HasMethod reports whether the object exposes the named method.
+func (o *ObjectInfo) HasMethod(method string) bool {
+	_, ok := o.Methods[method]
+
+	return ok
+}
+
+// Introspector is implemented by transports that can list the registered ubus objects.
+type Introspector interface {
+	// Objects lists the objects matching pattern, sorted by path.
+	// A trailing "*" matches any object with the given prefix; an empty pattern lists all objects.
+	Objects(ctx context.Context, pattern string) ([]ObjectInfo, error)
+}
+
+// Objects lists the ubus objects matching pattern if the transport supports it.
+func Objects(ctx context.Context, t Transport, pattern string) ([]ObjectInfo, error) {
+	introspector, ok := t.(Introspector)
+	if !ok {
+		return nil, errdefs.Wrapf(errdefs.ErrNotSupported, "transport %T does not support introspection", t)
+	}
+
+	return introspector.Objects(ctx, pattern)
+}
+
+// Lookup describes a single ubus object if the transport supports introspection.
+// It returns ErrNotFound when the object is not registered, which allows probing for optional
+// services such as mwan3 or umdns before calling them.
+func Lookup(ctx context.Context, t Transport, object string) (*ObjectInfo, error) {
+	if object == "" || strings.Contains(object, "*") {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid object name %q", object)
+	}
+
+	objects, err := Objects(ctx, t, object)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range objects {
+		if objects[i].Path == object {
+			return &objects[i], nil
+		}
+	}
+
+	return nil, errdefs.Wrapf(errdefs.ErrNotFound, "object '%s' not found", object)
+}
+
+// lookupObject describes object through t, using the lookup of t where it has one instead of
+// listing the objects.
+func lookupObject(ctx context.Context, t Transport, object string) (*ObjectInfo, error) {
+	if lookuper, ok := t.(interface {
+		Lookup(ctx context.Context, object string) (*ObjectInfo, error)
+	}); ok {
+		return lookuper.Lookup(ctx, object)
+	}
+
+	return Lookup(ctx, t, object)
+}
+
+func sortObjects(objects []ObjectInfo) []ObjectInfo {
+	slices.SortFunc(objects, func(a, b ObjectInfo) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return objects
+}
+
+// newObjectInfo converts the attributes of a lookup reply from ubusd.
+func newObjectInfo(attrs map[string]any) (ObjectInfo, bool) {
+	path, ok := attrs["objpath"].(string)
+	if !ok {
+		return ObjectInfo{}, false
+	}
+
+	info := ObjectInfo{Path: path, Methods: map[string]map[string]string{}}
+	info.ID, _ = blobmsg.ReadUint(attrs["objid"])
+
+	signature, _ := attrs["signature"].(map[string]any)
+	for method, rawArgs := range signature {
+		args := map[string]string{}
+
+		argTypes, _ := rawArgs.(map[string]any)
+		for name, rawType := range argTypes {
+			code, _ := blobmsg.ReadUint(rawType)
+			args[name] = argTypeName(code)
+		}
+
+		info.Methods[method] = args
+	}
+
+	return info, true
+}
+
+func argTypeName(code uint32) string {
+	switch code {
+	case blobmsg.TypeArray:
+		return ArgTypeArray
+	case blobmsg.TypeTable:
+		return ArgTypeObject
+	case blobmsg.TypeString:
+		return ArgTypeString
+	case blobmsg.TypeInt64, blobmsg.TypeInt32, blobmsg.TypeInt16, blobmsg.TypeDouble:
+		return ArgTypeNumber
+	case blobmsg.TypeBool:
+		return ArgTypeBoolean
+	default:
+		return ArgTypeUnknown
+	}
+}
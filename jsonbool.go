@@ -83,6 +83,115 @@ func (b *Bool) MarshalJSON() ([]byte, error) {
 	return []byte(boolStrFalse), nil
 }
 
+// BoolEncoding selects how a boolean is written to JSON. ubus services and UCI differ in
+// what they expect, so values written back to a device should keep the encoding it uses.
+type BoolEncoding uint8
+
+const (
+	// BoolEncodingJSON writes true / false.
+	BoolEncodingJSON BoolEncoding = iota
+	// BoolEncodingNumber writes 1 / 0.
+	BoolEncodingNumber
+	// BoolEncodingString writes "1" / "0", the UCI convention.
+	BoolEncodingString
+	// BoolEncodingWord writes "true" / "false".
+	BoolEncodingWord
+)
+
+// MarshalBool encodes value as JSON using enc.
+func MarshalBool(value bool, enc BoolEncoding) []byte {
+	switch enc {
+	case BoolEncodingNumber:
+		return []byte(strconv.Itoa(boolInt(value)))
+	case BoolEncodingString:
+		return []byte(strconv.Quote(strconv.Itoa(boolInt(value))))
+	case BoolEncodingWord:
+		return []byte(strconv.Quote(strconv.FormatBool(value)))
+	default:
+		return []byte(strconv.FormatBool(value))
+	}
+}
+
+// DetectBoolEncoding reports the encoding of a JSON boolean as accepted by Bool.
+func DetectBoolEncoding(data []byte) BoolEncoding {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return BoolEncodingJSON
+	}
+
+	switch trimmed[0] {
+	case 't', 'T', 'f', 'F', 'n':
+		return BoolEncodingJSON
+	case '"':
+		var str string
+
+		err := json.Unmarshal(trimmed, &str)
+		if err == nil {
+			_, err = strconv.ParseFloat(strings.TrimSpace(str), 64)
+		}
+
+		if err == nil {
+			return BoolEncodingString
+		}
+
+		return BoolEncodingWord
+	default:
+		return BoolEncodingNumber
+	}
+}
+
+// RoundTripBool is a boolean that remembers how it was encoded. It decodes like Bool and
+// marshals back to the exact original token while its value is unchanged, and otherwise
+// with Encoding. Use it in structs that are read from and written back to a device.
+type RoundTripBool struct {
+	raw      []byte
+	Value    bool
+	Encoding BoolEncoding
+}
+
+// NewRoundTripBool creates a RoundTripBool that is encoded with enc.
+func NewRoundTripBool(value bool, enc BoolEncoding) RoundTripBool {
+	return RoundTripBool{Value: value, Encoding: enc}
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting the same representations as Bool.
+func (b *RoundTripBool) UnmarshalJSON(data []byte) error {
+	var value Bool
+
+	err := value.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+
+	b.Value = bool(value)
+	b.Encoding = DetectBoolEncoding(data)
+	b.raw = append([]byte(nil), bytes.TrimSpace(data)...)
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, reproducing the decoded representation.
+func (b RoundTripBool) MarshalJSON() ([]byte, error) {
+	if b.raw != nil {
+		var decoded Bool
+
+		err := decoded.UnmarshalJSON(b.raw)
+		if err == nil && bool(decoded) == b.Value {
+			return b.raw, nil
+		}
+	}
+
+	return MarshalBool(b.Value, b.Encoding), nil
+}
+
+func boolInt(value bool) int {
+	if value {
+		return 1
+	}
+
+	return 0
+}
+
 // BoolValue safely dereferences a Bool, returning false when nil.
 func BoolValue(b Bool) bool {
 	return bool(b)
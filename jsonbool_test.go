@@ -116,3 +116,55 @@ func TestBool_Format(t *testing.T) {
 		t.Errorf("fmt.Sprintf(%%t) for nil got = %s, want false", str)
 	}
 }
+
+func TestMarshalBool(t *testing.T) {
+	tests := []struct {
+		expected string
+		encoding goubus.BoolEncoding
+	}{
+		{`true`, goubus.BoolEncodingJSON},
+		{`1`, goubus.BoolEncodingNumber},
+		{`"1"`, goubus.BoolEncodingString},
+		{`"true"`, goubus.BoolEncodingWord},
+	}
+
+	for _, tt := range tests {
+		if got := string(goubus.MarshalBool(true, tt.encoding)); got != tt.expected {
+			t.Errorf("MarshalBool(true, %d) = %s, want %s", tt.encoding, got, tt.expected)
+		}
+	}
+}
+
+func TestRoundTripBool(t *testing.T) {
+	var config struct {
+		Enabled  goubus.RoundTripBool `json:"enabled"`
+		Disabled goubus.RoundTripBool `json:"disabled"`
+		Wmm      goubus.RoundTripBool `json:"wmm"`
+	}
+
+	err := json.Unmarshal([]byte(`{"enabled":"yes","disabled":"0","wmm":1}`), &config)
+	if err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+
+	if !config.Enabled.Value || config.Disabled.Value || config.Disabled.Encoding != goubus.BoolEncodingString {
+		t.Errorf("unexpected values: %+v", config)
+	}
+
+	config.Disabled.Value = true
+	config.Wmm.Value = false
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+
+	if string(data) != `{"enabled":"yes","disabled":"1","wmm":0}` {
+		t.Errorf("unexpected round trip: %s", data)
+	}
+
+	data, _ = json.Marshal(goubus.NewRoundTripBool(false, goubus.BoolEncodingWord))
+	if string(data) != `"false"` {
+		t.Errorf("unexpected new value: %s", data)
+	}
+}
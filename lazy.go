@@ -0,0 +1,94 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package goubus
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+// LazyMap is a JSON object whose values are kept in their raw form and only decoded into T when
+// they are accessed. It keeps large replies, such as host hints on big networks, cheap to hold
+// when only a few entries are needed. Decoded values are not cached.
+type LazyMap[T any] struct {
+	raw map[string]json.RawMessage
+}
+
+// UnmarshalJSON retains the raw values of a JSON object.
+func (m *LazyMap[T]) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return errdefs.Wrapf(errdefs.ErrInvalidResponse, "decode lazy map: %v", err)
+	}
+
+	m.raw = raw
+
+	return nil
+}
+
+// MarshalJSON encodes the retained raw values.
+func (m LazyMap[T]) MarshalJSON() ([]byte, error) {
+	if m.raw == nil {
+		return []byte("{}"), nil
+	}
+
+	return json.Marshal(m.raw)
+}
+
+// Len returns the number of entries.
+func (m *LazyMap[T]) Len() int {
+	return len(m.raw)
+}
+
+// Keys returns the keys of all entries in sorted order.
+func (m *LazyMap[T]) Keys() []string {
+	return slices.Sorted(maps.Keys(m.raw))
+}
+
+// Has reports whether an entry exists for key.
+func (m *LazyMap[T]) Has(key string) bool {
+	_, ok := m.raw[key]
+
+	return ok
+}
+
+// Get decodes the entry for key. It returns ErrNotFound if there is no such entry.
+func (m *LazyMap[T]) Get(key string) (*T, error) {
+	raw, ok := m.raw[key]
+	if !ok {
+		return nil, errdefs.Wrapf(errdefs.ErrNotFound, "entry '%s' not found", key)
+	}
+
+	var value T
+
+	err := json.Unmarshal(raw, &value)
+	if err != nil {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidResponse, "decode entry '%s': %v", key, err)
+	}
+
+	return &value, nil
+}
+
+// Range decodes the entries one at a time in key order and calls fn for each of them.
+// It stops at the first decoding error or error returned by fn.
+func (m *LazyMap[T]) Range(fn func(key string, value *T) error) error {
+	for _, key := range m.Keys() {
+		value, err := m.Get(key)
+		if err != nil {
+			return err
+		}
+
+		err = fn(key, value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package goubus_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+type lazyHost struct {
+	Name string `json:"name"`
+}
+
+func TestLazyMap(t *testing.T) {
+	var hosts goubus.LazyMap[lazyHost]
+
+	err := json.Unmarshal([]byte(`{"b":{"name":"beta"},"a":{"name":"alpha"},"bad":{"name":1}}`), &hosts)
+	if err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+
+	if hosts.Len() != 3 || !hosts.Has("a") || hosts.Keys()[0] != "a" {
+		t.Errorf("unexpected keys: %v", hosts.Keys())
+	}
+
+	host, err := hosts.Get("b")
+	if err != nil || host.Name != "beta" {
+		t.Errorf("unexpected host: %+v (%v)", host, err)
+	}
+
+	_, err = hosts.Get("missing")
+	if !errdefs.IsNotFound(err) {
+		t.Errorf("expected not found, got %v", err)
+	}
+
+	var names []string
+
+	err = hosts.Range(func(_ string, host *lazyHost) error {
+		names = append(names, host.Name)
+
+		return nil
+	})
+	if !errdefs.IsInvalidResponse(err) || len(names) != 2 {
+		t.Errorf("expected decoding error after 2 hosts, got %v %v", names, err)
+	}
+
+	raw, err := json.Marshal(hosts)
+	if err != nil || len(raw) == 0 {
+		t.Errorf("marshal failed: %v", err)
+	}
+}
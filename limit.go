@@ -0,0 +1,155 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package goubus
+
+import (
+	"cmp"
+	"context"
+	"log/slog"
+	"maps"
+	"slices"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/logging"
+)
+
+// LimitOption defines a functional option for a LimitedTransport.
+type LimitOption func(*LimitedTransport)
+
+// WithObjectLimit allows at most n concurrent calls to the objects matching pattern.
+// The pattern is an object path or a "ubus listen" style wildcard such as "hostapd.*";
+// all objects matching one wildcard share its n slots. An object matching several wildcards
+// is limited by the longest, so "hostapd.*" overrides a catch-all "*". A non-positive n
+// removes the limit.
+func WithObjectLimit(pattern string, n int) LimitOption {
+	return func(lt *LimitedTransport) {
+		if n <= 0 {
+			delete(lt.limits, pattern)
+
+			return
+		}
+
+		lt.limits[pattern] = make(chan struct{}, n)
+	}
+}
+
+// WithObjectLimits applies WithObjectLimit for every entry of limits.
+func WithObjectLimits(limits map[string]int) LimitOption {
+	return func(lt *LimitedTransport) {
+		for pattern, n := range limits {
+			WithObjectLimit(pattern, n)(lt)
+		}
+	}
+}
+
+// LimitedTransport wraps a Transport and caps the number of concurrent calls per ubus object,
+// protecting daemons such as iwinfo or odhcpd that misbehave under parallel calls.
+// Objects without a configured limit are not restricted. Batches are sent as individual calls,
+// each within the limits; subscriptions, events and introspection are forwarded to the wrapped
+// transport without taking a slot.
+type LimitedTransport struct {
+	Transport
+
+	logger   *slog.Logger
+	limits   map[string]chan struct{}
+	patterns []string
+}
+
+var (
+	_ Transport    = (*LimitedTransport)(nil)
+	_ Subscriber   = (*LimitedTransport)(nil)
+	_ Introspector = (*LimitedTransport)(nil)
+)
+
+// NewLimitedTransport wraps t with per-object concurrency limits.
+func NewLimitedTransport(t Transport, opts ...LimitOption) *LimitedTransport {
+	lt := &LimitedTransport{
+		Transport: t,
+		logger:    logging.Discard(),
+		limits:    make(map[string]chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(lt)
+	}
+
+	lt.patterns = slices.SortedFunc(maps.Keys(lt.limits), func(a, b string) int {
+		return cmp.Or(cmp.Compare(len(b), len(a)), cmp.Compare(a, b))
+	})
+
+	return lt
+}
+
+// Call waits for a free slot of the object's limit, if any, and performs the call.
+// Waiting ends with the context; the returned error then matches errdefs.ErrTimeout or errdefs.ErrCanceled.
+func (lt *LimitedTransport) Call(ctx context.Context, service, method string, data any) (Result, error) {
+	slots := lt.slots(service)
+	if slots == nil {
+		return lt.Transport.Call(ctx, service, method, data)
+	}
+
+	select {
+	case slots <- struct{}{}:
+	default:
+		lt.logger.Debug("waiting for call slot", slog.String("object", service), slog.Int("limit", cap(slots)))
+
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, errdefs.Wrapf(errdefs.FromTransport(ctx.Err()), "wait for %s.%s", service, method)
+		}
+	}
+
+	defer func() { <-slots }()
+
+	return lt.Transport.Call(ctx, service, method, data)
+}
+
+// Subscribe subscribes to the notifications of object through the wrapped transport.
+func (lt *LimitedTransport) Subscribe(ctx context.Context, object string) (*Subscription, error) {
+	return Subscribe(ctx, lt.Transport, object)
+}
+
+// Listen listens for the events matching pattern through the wrapped transport.
+func (lt *LimitedTransport) Listen(ctx context.Context, pattern string) (*Subscription, error) {
+	return Listen(ctx, lt.Transport, pattern)
+}
+
+// Objects lists the objects matching pattern through the wrapped transport.
+func (lt *LimitedTransport) Objects(ctx context.Context, pattern string) ([]ObjectInfo, error) {
+	return Objects(ctx, lt.Transport, pattern)
+}
+
+// Lookup describes object through the wrapped transport.
+func (lt *LimitedTransport) Lookup(ctx context.Context, object string) (*ObjectInfo, error) {
+	return lookupObject(ctx, lt.Transport, object)
+}
+
+// SetLogger sets the logger for the limited transport and the wrapped transport.
+func (lt *LimitedTransport) SetLogger(logger *slog.Logger) {
+	if logger == nil {
+		lt.logger = logging.Discard()
+	} else {
+		lt.logger = logger
+	}
+
+	lt.Transport.SetLogger(logger)
+}
+
+// slots returns the semaphore limiting object: an exact entry wins over wildcards, and
+// longer wildcards, which match fewer objects, win over shorter ones.
+func (lt *LimitedTransport) slots(object string) chan struct{} {
+	slots, ok := lt.limits[object]
+	if ok {
+		return slots
+	}
+
+	for _, pattern := range lt.patterns {
+		if MatchEventPattern(pattern, object) {
+			return lt.limits[pattern]
+		}
+	}
+
+	return nil
+}
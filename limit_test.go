@@ -0,0 +1,132 @@
+package goubus_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+// peakTransport records the highest number of concurrent calls per object name before the first dot.
+func peakTransport(peaks map[string]int) *mockTransport {
+	var mu sync.Mutex
+
+	running := make(map[string]int)
+
+	return &mockTransport{
+		callFunc: func(_ context.Context, service, _ string, _ any) (goubus.Result, error) {
+			name, _, _ := strings.Cut(service, ".")
+
+			mu.Lock()
+			running[name]++
+			peaks[name] = max(peaks[name], running[name])
+			mu.Unlock()
+
+			time.Sleep(5 * time.Millisecond)
+
+			mu.Lock()
+			running[name]--
+			mu.Unlock()
+
+			return &mockResult{unmarshalFunc: func(any) error { return nil }}, nil
+		},
+	}
+}
+
+func TestLimitedTransport(t *testing.T) {
+	objects := []string{"iwinfo", "hostapd.phy0-ap0", "hostapd.phy1-ap0", "system"}
+	peaks := make(map[string]int)
+
+	lt := goubus.NewLimitedTransport(peakTransport(peaks),
+		goubus.WithObjectLimit("iwinfo", 1),
+		goubus.WithObjectLimits(map[string]int{"hostapd.*": 1, "system": 0}))
+
+	var wg sync.WaitGroup
+
+	for range 4 {
+		for _, object := range objects {
+			wg.Go(func() {
+				_, err := lt.Call(context.Background(), object, "info", nil)
+				if err != nil {
+					t.Errorf("Call %s failed: %v", object, err)
+				}
+			})
+		}
+	}
+
+	wg.Wait()
+
+	if peaks["iwinfo"] != 1 || peaks["hostapd"] != 1 {
+		t.Errorf("limits exceeded: %v", peaks)
+	}
+
+	if peaks["system"] < 2 {
+		t.Errorf("unlimited object was serialized: %v", peaks)
+	}
+}
+
+func TestLimitedTransport_MostSpecificWildcard(t *testing.T) {
+	objects := []string{"hostapd.phy0-ap0", "hostapd.phy1-ap0", "system"}
+	peaks := make(map[string]int)
+
+	lt := goubus.NewLimitedTransport(peakTransport(peaks),
+		goubus.WithObjectLimits(map[string]int{"*": 8, "hostapd.*": 1}))
+
+	var wg sync.WaitGroup
+
+	for range 4 {
+		for _, object := range objects {
+			wg.Go(func() {
+				_, err := lt.Call(context.Background(), object, "info", nil)
+				if err != nil {
+					t.Errorf("Call %s failed: %v", object, err)
+				}
+			})
+		}
+	}
+
+	wg.Wait()
+
+	// "hostapd.*" applies to hostapd objects although "*" sorts first.
+	if peaks["hostapd"] != 1 || peaks["system"] < 2 {
+		t.Errorf("unexpected peaks: %v", peaks)
+	}
+}
+
+func TestLimitedTransport_ContextWhileWaiting(t *testing.T) {
+	release := make(chan struct{})
+	mock := &mockTransport{
+		callFunc: func(_ context.Context, _, _ string, _ any) (goubus.Result, error) {
+			<-release
+
+			return &mockResult{unmarshalFunc: func(any) error { return nil }}, nil
+		},
+	}
+
+	lt := goubus.NewLimitedTransport(mock, goubus.WithObjectLimit("iwinfo", 1))
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		_, _ = lt.Call(context.Background(), "iwinfo", "scan", nil)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := lt.Call(ctx, "iwinfo", "scan", nil)
+	if !errdefs.IsTimeout(err) {
+		t.Errorf("expected timeout while waiting for a slot, got %v", err)
+	}
+
+	close(release)
+	<-done
+}
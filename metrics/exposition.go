@@ -0,0 +1,144 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package metrics
+
+import (
+	"bufio"
+	"cmp"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ContentType is the media type of the Prometheus text format written by a Collector.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// labelEscaper escapes label values as the text format requires.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// ServeHTTP serves the metrics in the Prometheus text format.
+func (c *Collector) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", ContentType)
+
+	_, _ = c.WriteTo(w)
+}
+
+// WriteTo writes the metrics in the Prometheus text format, sorted by their labels.
+func (c *Collector) WriteTo(w io.Writer) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	counter := &countingWriter{w: w}
+	out := bufio.NewWriter(counter)
+
+	keys := slices.SortedFunc(maps.Keys(c.calls), func(a, b callKey) int {
+		return cmp.Or(cmp.Compare(a.device, b.device), cmp.Compare(a.object, b.object), cmp.Compare(a.method, b.method))
+	})
+
+	c.writeCalls(out, keys)
+	c.writeDurations(out, keys)
+	c.writeReconnects(out)
+
+	err := out.Flush()
+
+	return counter.n, err
+}
+
+func (c *Collector) writeCalls(out *bufio.Writer, keys []callKey) {
+	name := c.namespace + "_calls_total"
+	writeHeader(out, name, "counter", "Calls made through goubus by device, ubus object, method and result.")
+
+	for _, key := range keys {
+		results := c.calls[key].results
+		for _, result := range slices.Sorted(maps.Keys(results)) {
+			writeSample(out, name, key.labels("result", result), strconv.FormatUint(results[result], 10))
+		}
+	}
+}
+
+func (c *Collector) writeDurations(out *bufio.Writer, keys []callKey) {
+	name := c.namespace + "_call_duration_seconds"
+	writeHeader(out, name, "histogram", "Duration of the calls made through goubus.")
+
+	for _, key := range keys {
+		series := c.calls[key]
+
+		var cumulative uint64
+
+		for i, bound := range c.buckets {
+			cumulative += series.buckets[i]
+			writeSample(out, name+"_bucket", key.labels("le", formatFloat(bound)), strconv.FormatUint(cumulative, 10))
+		}
+
+		count := strconv.FormatUint(series.count, 10)
+		writeSample(out, name+"_bucket", key.labels("le", "+Inf"), count)
+		writeSample(out, name+"_sum", key.labels(), formatFloat(series.sum))
+		writeSample(out, name+"_count", key.labels(), count)
+	}
+}
+
+func (c *Collector) writeReconnects(out *bufio.Writer) {
+	name := c.namespace + "_reconnects_total"
+	writeHeader(out, name, "counter", "Reconnects of goubus transports by device and transport.")
+
+	keys := slices.SortedFunc(maps.Keys(c.reconnects), func(a, b reconnectKey) int {
+		return cmp.Or(cmp.Compare(a.device, b.device), cmp.Compare(a.transport, b.transport))
+	})
+
+	for _, key := range keys {
+		labels := formatLabels("device", key.device, "transport", key.transport)
+		writeSample(out, name, labels, strconv.FormatUint(c.reconnects[key], 10))
+	}
+}
+
+// labels formats the labels of the key followed by extra name/value pairs.
+func (k callKey) labels(extra ...string) string {
+	return formatLabels(append([]string{"device", k.device, "object", k.object, "method", k.method}, extra...)...)
+}
+
+func formatLabels(pairs ...string) string {
+	var b strings.Builder
+
+	b.WriteByte('{')
+
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		b.WriteString(pairs[i] + `="` + labelEscaper.Replace(pairs[i+1]) + `"`)
+	}
+
+	b.WriteByte('}')
+
+	return b.String()
+}
+
+func writeHeader(out *bufio.Writer, name, kind, help string) {
+	_, _ = out.WriteString("# HELP " + name + " " + help + "\n# TYPE " + name + " " + kind + "\n")
+}
+
+func writeSample(out *bufio.Writer, name, labels, value string) {
+	_, _ = out.WriteString(name + labels + " " + value + "\n")
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// countingWriter counts the bytes written for WriteTo.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+
+	return n, err
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+// Package metrics exports the calls made through goubus transports as Prometheus metrics. A
+// Collector is fed by a goubus.CallInterceptor and serves the Prometheus text format, so agents
+// built on goubus can be scraped without pulling a metrics library into the module:
+//
+//	collector := metrics.New()
+//	client, err := goubus.NewRpcClient(ctx, host, user, pass,
+//		goubus.WithRpcInterceptors(collector.Interceptor("router1")))
+//	http.Handle("/metrics", collector)
+//
+// It exports
+//
+//	goubus_calls_total{device, object, method, result}          counter
+//	goubus_call_duration_seconds{device, object, method}        histogram
+//	goubus_reconnects_total{device, transport}                  counter
+//
+// where result is "ok" or the class of the error, e.g. "timeout" or "permission_denied".
+// Calls made by managers go through the transport, so they are covered by object and method.
+//
+// The exposition is written by hand to keep the module free of dependencies, which limits it:
+//
+//   - Only the Prometheus text format 0.0.4 is served, whatever the scraper accepts; there is no
+//     OpenMetrics or protobuf output and there are no exemplars.
+//   - A Collector is not a prometheus.Collector and cannot be added to a client_golang registry.
+//     Serve it on its own path next to the handler of the registry.
+//   - Transports do not report their own reconnects. goubus_reconnects_total only counts what is
+//     passed to Reconnected, usually through FailoverHandler, so it stays empty without failover.
+//   - Series are never dropped, so every device, object and method seen stays in memory.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+// ResultOK is the result label of successful calls.
+const ResultOK = "ok"
+
+// DefaultBuckets are the upper bounds in seconds of the call duration histogram, the default
+// buckets of the Prometheus client libraries.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Option configures a Collector.
+type Option func(*Collector)
+
+// WithNamespace replaces the "goubus" prefix of the metric names.
+func WithNamespace(namespace string) Option {
+	return func(c *Collector) {
+		c.namespace = namespace
+	}
+}
+
+// WithBuckets sets the upper bounds in seconds of the call duration histogram, in increasing
+// order.
+func WithBuckets(buckets ...float64) Option {
+	return func(c *Collector) {
+		c.buckets = buckets
+	}
+}
+
+// Collector accumulates call metrics. It is safe for concurrent use.
+type Collector struct {
+	calls      map[callKey]*callSeries
+	reconnects map[reconnectKey]uint64
+	namespace  string
+	buckets    []float64
+	mu         sync.Mutex
+}
+
+type callKey struct {
+	device string
+	object string
+	method string
+}
+
+type reconnectKey struct {
+	device    string
+	transport string
+}
+
+// callSeries holds the counters of one device, object and method.
+type callSeries struct {
+	results map[string]uint64
+	// buckets counts the calls per histogram bucket, not cumulatively.
+	buckets []uint64
+	sum     float64
+	count   uint64
+}
+
+// New creates an empty Collector.
+func New(opts ...Option) *Collector {
+	c := &Collector{
+		calls:      make(map[callKey]*callSeries),
+		reconnects: make(map[reconnectKey]uint64),
+		namespace:  "goubus",
+		buckets:    DefaultBuckets,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Interceptor returns an interceptor recording the calls of a transport under the device label.
+// Register it first so that the duration includes retries of later interceptors.
+func (c *Collector) Interceptor(device string) goubus.CallInterceptor {
+	return goubus.InterceptorFunc(func(
+		ctx context.Context, service, method string, data any, next goubus.Invoker,
+	) (goubus.Result, error) {
+		start := time.Now()
+		res, err := next(ctx, service, method, data)
+		c.Observe(device, service, method, time.Since(start), err)
+
+		return res, err
+	})
+}
+
+// Instrument wraps t so that its calls are recorded under the device label, for transports
+// without an interceptor option.
+func (c *Collector) Instrument(device string, t goubus.Transport) goubus.Transport {
+	return goubus.NewInterceptedTransport(t, c.Interceptor(device))
+}
+
+// Observe records a call. Interceptor calls it for every call.
+func (c *Collector) Observe(device, object, method string, duration time.Duration, err error) {
+	key := callKey{device: device, object: object, method: method}
+	seconds := duration.Seconds()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	series := c.calls[key]
+	if series == nil {
+		series = &callSeries{results: make(map[string]uint64), buckets: make([]uint64, len(c.buckets))}
+		c.calls[key] = series
+	}
+
+	series.results[Result(err)]++
+	series.count++
+	series.sum += seconds
+
+	for i, bound := range c.buckets {
+		if seconds <= bound {
+			series.buckets[i]++
+
+			break
+		}
+	}
+}
+
+// Reconnected counts a reconnect of device over transport, e.g. from the Dial function of a
+// goubus.FailoverMember.
+func (c *Collector) Reconnected(device, transport string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.reconnects[reconnectKey{device: device, transport: transport}]++
+}
+
+// FailoverHandler returns a handler for goubus.WithFailoverHandler that counts every switch of
+// the active transport as a reconnect over the new transport.
+func (c *Collector) FailoverHandler(device string) func(goubus.FailoverEvent) {
+	return func(event goubus.FailoverEvent) {
+		c.Reconnected(device, event.To)
+	}
+}
+
+// errorClasses maps errors to result labels; the first match wins.
+var errorClasses = []struct {
+	match func(error) bool
+	label string
+}{
+	{errdefs.IsCanceled, "canceled"},
+	{errdefs.IsTimeout, "timeout"},
+	{isCapability, "missing_object"},
+	{errdefs.IsPermissionDenied, "permission_denied"},
+	{errdefs.IsNotFound, "not_found"},
+	{errdefs.IsMethodNotFound, "method_not_found"},
+	{errdefs.IsInvalidParameter, "invalid_argument"},
+	{errdefs.IsNoData, "no_data"},
+	{errdefs.IsNotSupported, "not_supported"},
+	{errdefs.IsClosed, "closed"},
+	{errdefs.IsConnectionFailed, "connection_failed"},
+	{errdefs.IsInvalidResponse, "invalid_response"},
+}
+
+// Result returns the result label of a call that returned err.
+func Result(err error) string {
+	if err == nil {
+		return ResultOK
+	}
+
+	for _, class := range errorClasses {
+		if class.match(err) {
+			return class.label
+		}
+	}
+
+	return "error"
+}
+
+func isCapability(err error) bool {
+	var capErr *errdefs.CapabilityError
+
+	return errors.As(err, &capErr)
+}
@@ -0,0 +1,92 @@
+package metrics_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/metrics"
+)
+
+type fakeTransport struct {
+	err error
+}
+
+func (f *fakeTransport) Call(context.Context, string, string, any) (goubus.Result, error) {
+	return nil, f.err
+}
+
+func (f *fakeTransport) SetLogger(*slog.Logger) {}
+
+func (f *fakeTransport) Close() error { return nil }
+
+func TestCollector(t *testing.T) {
+	collector := metrics.New(metrics.WithBuckets(0.1, 1))
+	ctx := context.Background()
+
+	ok := collector.Instrument("router1", &fakeTransport{})
+	_, _ = ok.Call(ctx, "system", "board", nil)
+	_, _ = ok.Call(ctx, "system", "board", nil)
+
+	denied := collector.Instrument(`edge"1`, &fakeTransport{err: errdefs.ErrPermissionDenied})
+	_, _ = denied.Call(ctx, "file", "exec", nil)
+
+	collector.Observe("router1", "iwinfo", "scan", 2*time.Second, errdefs.ErrTimeout)
+	collector.FailoverHandler("router1")(goubus.FailoverEvent{From: "socket", To: "rpc"})
+
+	recorder := httptest.NewRecorder()
+	collector.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+	if recorder.Header().Get("Content-Type") != metrics.ContentType {
+		t.Errorf("unexpected content type %q", recorder.Header().Get("Content-Type"))
+	}
+
+	body, _ := io.ReadAll(recorder.Body)
+	output := string(body)
+
+	for _, want := range []string{
+		"# TYPE goubus_calls_total counter\n",
+		`goubus_calls_total{device="edge\"1",object="file",method="exec",result="permission_denied"} 1`,
+		`goubus_calls_total{device="router1",object="system",method="board",result="ok"} 2`,
+		`goubus_calls_total{device="router1",object="iwinfo",method="scan",result="timeout"} 1`,
+		`goubus_call_duration_seconds_bucket{device="router1",object="system",method="board",le="0.1"} 2`,
+		`goubus_call_duration_seconds_bucket{device="router1",object="iwinfo",method="scan",le="1"} 0`,
+		`goubus_call_duration_seconds_bucket{device="router1",object="iwinfo",method="scan",le="+Inf"} 1`,
+		`goubus_call_duration_seconds_sum{device="router1",object="iwinfo",method="scan"} 2`,
+		`goubus_call_duration_seconds_count{device="router1",object="system",method="board"} 2`,
+		`goubus_reconnects_total{device="router1",transport="rpc"} 1`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("missing %q in:\n%s", want, output)
+		}
+	}
+}
+
+func TestResult(t *testing.T) {
+	tests := map[string]error{
+		metrics.ResultOK:    nil,
+		"missing_object":    &errdefs.CapabilityError{Object: "iwinfo"},
+		"not_found":         errdefs.Wrapf(errdefs.ErrNotFound, "uci"),
+		"connection_failed": errdefs.ErrConnectionFailed,
+		"error":             io.EOF,
+	}
+
+	for want, err := range tests {
+		if got := metrics.Result(err); got != want {
+			t.Errorf("Result(%v) = %q, want %q", err, got, want)
+		}
+	}
+
+	var builder strings.Builder
+
+	_, err := metrics.New(metrics.WithNamespace("agent")).WriteTo(&builder)
+	if err != nil || !strings.Contains(builder.String(), "# TYPE agent_calls_total counter") {
+		t.Errorf("unexpected empty output %q (%v)", builder.String(), err)
+	}
+}
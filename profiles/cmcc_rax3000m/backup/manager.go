@@ -0,0 +1,42 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package backup
+
+import (
+	"context"
+	"io"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/internal/base/backup"
+)
+
+// Manager handles configuration backup operations for CMCC RAX3000M.
+type Manager struct {
+	base *backup.Manager
+}
+
+func New(t goubus.Transport) *Manager {
+	return &Manager{
+		base: backup.New(t),
+	}
+}
+
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
+func (m *Manager) CreateArchive(ctx context.Context, w io.Writer) (int64, error) {
+	return m.base.CreateArchive(ctx, w)
+}
+
+func (m *Manager) RestoreArchive(ctx context.Context, archive io.Reader) error {
+	return m.base.RestoreArchive(ctx, archive)
+}
+
+func (m *Manager) ListChangedFiles(ctx context.Context) ([]string, error) {
+	return m.base.ListChangedFiles(ctx)
+}
+
+// ArchivePath is where archives are staged on the device.
+const ArchivePath = backup.ArchivePath
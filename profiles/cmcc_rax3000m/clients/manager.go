@@ -0,0 +1,48 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package clients
+
+import (
+	"context"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/internal/base/clients"
+)
+
+// Manager merges DHCP, neighbor and wireless data into one record per client for CMCC RAX3000M.
+type Manager struct {
+	base *clients.Manager
+}
+
+func New(t goubus.Transport) *Manager {
+	return &Manager{
+		base: clients.New(t),
+	}
+}
+
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
+func (m *Manager) Connected(ctx context.Context) ([]Client, error) {
+	return m.base.Connected(ctx)
+}
+
+// Type aliases for public use.
+type (
+	Client  = clients.Client
+	Station = clients.Station
+)
+
+// Sources a client can be known from.
+const (
+	SourceDHCP     = clients.SourceDHCP
+	SourceNeighbor = clients.SourceNeighbor
+	SourceWireless = clients.SourceWireless
+)
+
+// DUIDMAC returns the MAC address embedded in a DUID-LLT or DUID-LL, or "" for other DUIDs.
+func DUIDMAC(duid string) string {
+	return clients.DUIDMAC(duid)
+}
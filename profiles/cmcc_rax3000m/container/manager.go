@@ -21,6 +21,10 @@ func New(t goubus.Transport) *Manager {
 	}
 }
 
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
 func (m *Manager) Set(ctx context.Context, req SetRequest) error {
 	return m.base.Set(ctx, req)
 }
@@ -33,6 +37,18 @@ func (m *Manager) List(ctx context.Context, name string, verbose bool) (map[stri
 	return m.base.List(ctx, name, verbose)
 }
 
+func (m *Manager) Containers(ctx context.Context) ([]Container, error) {
+	return m.base.Containers(ctx)
+}
+
+func (m *Manager) Start(ctx context.Context, name string) error {
+	return m.base.Start(ctx, name)
+}
+
+func (m *Manager) Stop(ctx context.Context, name string) error {
+	return m.base.Stop(ctx, name)
+}
+
 func (m *Manager) Delete(ctx context.Context, name, instance string) error {
 	return m.base.Delete(ctx, name, instance)
 }
@@ -56,4 +72,5 @@ func (m *Manager) ConsoleAttach(ctx context.Context, name, instance string) erro
 // Type aliases for public use.
 type (
 	SetRequest = container.SetRequest
+	Container  = container.Container
 )
@@ -0,0 +1,62 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package cron
+
+import (
+	"context"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/internal/base/cron"
+)
+
+// Manager manages scheduled jobs of the root crontab for CMCC RAX3000M.
+type Manager struct {
+	base *cron.Manager
+}
+
+func New(t goubus.Transport) *Manager {
+	return &Manager{
+		base: cron.New(t),
+	}
+}
+
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
+func (m *Manager) Entries(ctx context.Context) ([]Entry, error) {
+	return m.base.Entries(ctx)
+}
+
+func (m *Manager) AddEntry(ctx context.Context, entry Entry) error {
+	return m.base.AddEntry(ctx, entry)
+}
+
+func (m *Manager) RemoveEntry(ctx context.Context, entry Entry) error {
+	return m.base.RemoveEntry(ctx, entry)
+}
+
+func (m *Manager) SetEntries(ctx context.Context, entries []Entry) error {
+	return m.base.SetEntries(ctx, entries)
+}
+
+// Type aliases for public use.
+type (
+	Entry = cron.Entry
+)
+
+// ValidateSchedule checks the five time fields of a crontab line.
+func ValidateSchedule(schedule string) error {
+	return cron.ValidateSchedule(schedule)
+}
+
+// ParseCrontab parses the jobs of a crontab, including disabled ones.
+func ParseCrontab(content string) []Entry {
+	return cron.ParseCrontab(content)
+}
+
+// FormatCrontab renders entries in crontab format.
+func FormatCrontab(entries []Entry) string {
+	return cron.FormatCrontab(entries)
+}
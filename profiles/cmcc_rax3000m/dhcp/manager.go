@@ -36,6 +36,10 @@ func New(t goubus.Transport) *Manager {
 	}
 }
 
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
 func (m *Manager) AddLease(ctx context.Context, req AddLeaseRequest) error {
 	return m.base.AddLease(ctx, req)
 }
@@ -48,10 +52,38 @@ func (m *Manager) IPv6RA(ctx context.Context) ([]IPv6RA, error) {
 	return m.base.IPv6RA(ctx)
 }
 
+func (m *Manager) StaticLeases(ctx context.Context) ([]StaticLease, error) {
+	return m.base.StaticLeases(ctx)
+}
+
+func (m *Manager) SaveStaticLease(ctx context.Context, lease StaticLease) error {
+	return m.base.SaveStaticLease(ctx, lease)
+}
+
+func (m *Manager) RemoveLease(ctx context.Context, mac string) error {
+	return m.base.RemoveLease(ctx, mac)
+}
+
+func (m *Manager) WatchLeases(ctx context.Context) (*goubus.Subscription, error) {
+	return m.base.WatchLeases(ctx)
+}
+
 // Type aliases for public use.
 type (
 	IPv4Lease       = dhcp.IPv4Lease
 	IPv6Lease       = dhcp.IPv6Lease
 	IPv6RA          = dhcp.IPv6RA
 	AddLeaseRequest = dhcp.AddLeaseRequest
+	StaticLease     = dhcp.StaticLease
+	LeaseEvent      = dhcp.LeaseEvent
 )
+
+// Lease event types sent by dnsmasq.
+const (
+	LeaseEventAck     = dhcp.LeaseEventAck
+	LeaseEventRelease = dhcp.LeaseEventRelease
+)
+
+func ParseLeaseEvent(ev goubus.Event) (LeaseEvent, bool) {
+	return dhcp.ParseLeaseEvent(ev)
+}
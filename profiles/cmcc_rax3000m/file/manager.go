@@ -5,6 +5,7 @@ package file
 
 import (
 	"context"
+	"io"
 	"os"
 
 	"github.com/honeybbq/goubus/v2"
@@ -22,6 +23,10 @@ func New(t goubus.Transport) *Manager {
 	}
 }
 
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
 func (m *Manager) Read(ctx context.Context, path string, base64 bool) (*Read, error) {
 	return m.base.Read(ctx, path, base64)
 }
@@ -34,6 +39,26 @@ func (m *Manager) Write(ctx context.Context, path, data string, isAppend bool, m
 	return m.base.Write(ctx, path, data, isAppend, mode, base64)
 }
 
+func (m *Manager) Replace(ctx context.Context, path, data string, mode os.FileMode, base64 bool) error {
+	return m.base.Replace(ctx, path, data, mode, base64)
+}
+
+func (m *Manager) Upload(ctx context.Context, path string, src io.Reader, mode os.FileMode, chunk int) (int64, error) {
+	return m.base.Upload(ctx, path, src, mode, chunk)
+}
+
+func (m *Manager) Download(ctx context.Context, path string, w io.Writer, chunkSize int) (int64, error) {
+	return m.base.Download(ctx, path, w, chunkSize)
+}
+
+func (m *Manager) ReadStream(ctx context.Context, path string, offset int64, chunk int) (io.ReadCloser, error) {
+	return m.base.ReadStream(ctx, path, offset, chunk)
+}
+
+func (m *Manager) WriteStream(ctx context.Context, path string, mode os.FileMode, chunk int) (io.WriteCloser, error) {
+	return m.base.WriteStream(ctx, path, mode, chunk)
+}
+
 func (m *Manager) Stat(ctx context.Context, path string) (*Stat, error) {
 	return m.base.Stat(ctx, path)
 }
@@ -46,6 +71,10 @@ func (m *Manager) MD5(ctx context.Context, path string) (string, error) {
 	return m.base.MD5(ctx, path)
 }
 
+func (m *Manager) Checksum(ctx context.Context, path string, algo ChecksumAlgorithm) (*Checksum, error) {
+	return m.base.Checksum(ctx, path, algo)
+}
+
 func (m *Manager) Exec(ctx context.Context, command string, params []string, env map[string]string) (*Exec, error) {
 	return m.base.Exec(ctx, command, params, env)
 }
@@ -56,8 +85,19 @@ func (m *Manager) LStat(ctx context.Context, path string) (*Stat, error) {
 
 // Type aliases for public use.
 type (
-	Read = file.Read
-	List = file.List
-	Stat = file.Stat
-	Exec = file.Exec
+	Read              = file.Read
+	List              = file.List
+	Stat              = file.Stat
+	Exec              = file.Exec
+	Checksum          = file.Checksum
+	ChecksumAlgorithm = file.ChecksumAlgorithm
+)
+
+// DefaultChunkSize is the default number of bytes moved per call by the chunked transfers.
+const DefaultChunkSize = file.DefaultChunkSize
+
+// Checksum algorithms.
+const (
+	ChecksumMD5    = file.ChecksumMD5
+	ChecksumSHA256 = file.ChecksumSHA256
 )
@@ -0,0 +1,82 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package firmware
+
+import (
+	"context"
+	"io"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/internal/base/firmware"
+)
+
+// Manager handles firmware upgrade operations for CMCC RAX3000M.
+type Manager struct {
+	base *firmware.Manager
+}
+
+func New(t goubus.Transport) *Manager {
+	return &Manager{
+		base: firmware.New(t),
+	}
+}
+
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
+func (m *Manager) Upgrade(ctx context.Context, image []byte, opts *Options) (*Report, error) {
+	return m.base.Upgrade(ctx, image, opts)
+}
+
+func (m *Manager) Upload(ctx context.Context, image []byte, opts *Options) error {
+	return m.base.Upload(ctx, image, opts)
+}
+
+func (m *Manager) Check(ctx context.Context, path string, progress func(Progress)) (*Report, error) {
+	return m.base.Check(ctx, path, progress)
+}
+
+func (m *Manager) Validate(ctx context.Context, path string) (*Validation, error) {
+	return m.base.Validate(ctx, path)
+}
+
+func (m *Manager) ValidateImage(ctx context.Context, image io.Reader, opts *Options) (*Report, error) {
+	return m.base.ValidateImage(ctx, image, opts)
+}
+
+func (m *Manager) Test(ctx context.Context, path string) (string, error) {
+	return m.base.Test(ctx, path)
+}
+
+func (m *Manager) Start(ctx context.Context, opts *Options) error {
+	return m.base.Start(ctx, opts)
+}
+
+// Type aliases for public use.
+type (
+	Options       = firmware.Options
+	Progress      = firmware.Progress
+	Stage         = firmware.Stage
+	Validation    = firmware.Validation
+	Report        = firmware.Report
+	ImageMetadata = firmware.ImageMetadata
+	ImageVersion  = firmware.ImageVersion
+)
+
+// Upgrade workflow stages and defaults.
+const (
+	StageUpload      = firmware.StageUpload
+	StageVerify      = firmware.StageVerify
+	StageValidate    = firmware.StageValidate
+	StageTest        = firmware.StageTest
+	StageBackup      = firmware.StageBackup
+	StageUpgrade     = firmware.StageUpgrade
+	DefaultImagePath = firmware.DefaultImagePath
+	DefaultChunkSize = firmware.DefaultChunkSize
+)
+
+func ParseMetadata(image []byte) (*ImageMetadata, error) {
+	return firmware.ParseMetadata(image)
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package guest
+
+import (
+	"context"
+	"time"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/internal/base/guest"
+)
+
+// Manager provisions guest Wi-Fi networks for CMCC RAX3000M.
+type Manager struct {
+	base *guest.Manager
+}
+
+func New(t goubus.Transport) *Manager {
+	return &Manager{
+		base: guest.New(t),
+	}
+}
+
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
+func (m *Manager) Provision(ctx context.Context, n Network, timeout time.Duration) error {
+	return m.base.Provision(ctx, n, timeout)
+}
+
+func (m *Manager) Remove(ctx context.Context, name string, timeout time.Duration) error {
+	return m.base.Remove(ctx, name, timeout)
+}
+
+// Type aliases for public use.
+type (
+	Network = guest.Network
+)
+
+const (
+	// DefaultLeaseTime is the DHCP lease time of guest networks that set none.
+	DefaultLeaseTime = guest.DefaultLeaseTime
+	// DefaultDHCPStart is the pool offset of guest networks that set none.
+	DefaultDHCPStart = guest.DefaultDHCPStart
+	// DefaultDHCPLimit is the pool size of guest networks that set none.
+	DefaultDHCPLimit = guest.DefaultDHCPLimit
+)
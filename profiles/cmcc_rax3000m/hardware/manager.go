@@ -0,0 +1,78 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package hardware
+
+import (
+	"context"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/internal/base/hardware"
+)
+
+// Manager lists USB and PCI devices for CMCC RAX3000M.
+type Manager struct {
+	base *hardware.Manager
+}
+
+func New(t goubus.Transport) *Manager {
+	return &Manager{
+		base: hardware.New(t),
+	}
+}
+
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
+func (m *Manager) USBDevices(ctx context.Context) ([]USBDevice, error) {
+	return m.base.USBDevices(ctx)
+}
+
+func (m *Manager) PCIDevices(ctx context.Context) ([]PCIDevice, error) {
+	return m.base.PCIDevices(ctx)
+}
+
+// Type aliases for public use.
+type (
+	USBDevice    = hardware.USBDevice
+	USBInterface = hardware.USBInterface
+	PCIDevice    = hardware.PCIDevice
+	IDs          = hardware.IDs
+)
+
+// ID databases of the usbids and pciids packages.
+const (
+	USBIDsFile = hardware.USBIDsFile
+	PCIIDsFile = hardware.PCIIDsFile
+)
+
+// USB device and interface class codes.
+const (
+	USBClassPerInterface   = hardware.USBClassPerInterface
+	USBClassAudio          = hardware.USBClassAudio
+	USBClassCommunications = hardware.USBClassCommunications
+	USBClassHID            = hardware.USBClassHID
+	USBClassPrinter        = hardware.USBClassPrinter
+	USBClassMassStorage    = hardware.USBClassMassStorage
+	USBClassHub            = hardware.USBClassHub
+	USBClassCDCData        = hardware.USBClassCDCData
+	USBClassVideo          = hardware.USBClassVideo
+	USBClassWireless       = hardware.USBClassWireless
+	USBClassVendorSpecific = hardware.USBClassVendorSpecific
+)
+
+// PCI base class codes.
+const (
+	PCIClassStorage  = hardware.PCIClassStorage
+	PCIClassNetwork  = hardware.PCIClassNetwork
+	PCIClassDisplay  = hardware.PCIClassDisplay
+	PCIClassBridge   = hardware.PCIClassBridge
+	PCIClassSerial   = hardware.PCIClassSerial
+	PCIClassWireless = hardware.PCIClassWireless
+)
+
+// ParseIDs parses the vendor and product lists of a usb.ids or pci.ids file.
+func ParseIDs(data string) *IDs {
+	return hardware.ParseIDs(data)
+}
@@ -21,6 +21,10 @@ func New(t goubus.Transport) *Manager {
 	}
 }
 
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
 func (m *Manager) Reload(ctx context.Context, phy string, radio int) error {
 	return m.base.Reload(ctx, phy, radio)
 }
@@ -33,7 +37,76 @@ func (m *Manager) AP(name string) *APContext {
 	return m.base.AP(name)
 }
 
+func (m *Manager) WatchDFS(ctx context.Context, aps ...string) (*goubus.Subscription, error) {
+	return m.base.WatchDFS(ctx, aps...)
+}
+
+func (m *Manager) DFSState(ctx context.Context, aps ...string) ([]ChannelCAC, error) {
+	return m.base.DFSState(ctx, aps...)
+}
+
+// ParseDFSEvent decodes a radar notification delivered by WatchDFS.
+func ParseDFSEvent(ev goubus.Event) (DFSEvent, bool) {
+	return hostapd.ParseDFSEvent(ev)
+}
+
+// SyncNeighbors makes every AP advertise the own neighbor reports of all other APs.
+func SyncNeighbors(ctx context.Context, aps ...*APContext) error {
+	return hostapd.SyncNeighbors(ctx, aps...)
+}
+
 // Type aliases for public use.
 type (
-	APContext = hostapd.APContext
+	APContext            = hostapd.APContext
+	Client               = hostapd.Client
+	ClientCounters       = hostapd.ClientCounters
+	ClientCapabilities   = hostapd.ClientCapabilities
+	VHTCapabilities      = hostapd.VHTCapabilities
+	VHTMCSMap            = hostapd.VHTMCSMap
+	Features             = hostapd.Features
+	Status               = hostapd.Status
+	DFSStatus            = hostapd.DFSStatus
+	SwitchChanRequest    = hostapd.SwitchChanRequest
+	DFSEvent             = hostapd.DFSEvent
+	ChannelCAC           = hostapd.ChannelCAC
+	Airtime              = hostapd.Airtime
+	DelClientRequest     = hostapd.DelClientRequest
+	WPSStatus            = hostapd.WPSStatus
+	NeighborReport       = hostapd.NeighborReport
+	BSSManagement        = hostapd.BSSManagement
+	BSSTransitionRequest = hostapd.BSSTransitionRequest
 )
+
+// IEEE 802.11 reason codes commonly sent when removing a station.
+const (
+	ReasonUnspecified   = hostapd.ReasonUnspecified
+	ReasonInactivity    = hostapd.ReasonInactivity
+	ReasonAPBusy        = hostapd.ReasonAPBusy
+	ReasonBSSTransition = hostapd.ReasonBSSTransition
+)
+
+// DFSEventRadarDetected is the notification hostapd sends when radar is detected on its channel.
+const DFSEventRadarDetected = hostapd.DFSEventRadarDetected
+
+// WPS push-button states reported in WPSStatus.PBCStatus.
+const (
+	WPSActive   = hostapd.WPSActive
+	WPSDisabled = hostapd.WPSDisabled
+	WPSTimedOut = hostapd.WPSTimedOut
+	WPSOverlap  = hostapd.WPSOverlap
+)
+
+// WPS results reported in WPSStatus.LastResult.
+const (
+	WPSResultNone    = hostapd.WPSResultNone
+	WPSResultSuccess = hostapd.WPSResultSuccess
+	WPSResultFailed  = hostapd.WPSResultFailed
+)
+
+// DefaultWPSPINTimeout is how long PIN accepts the PIN when no timeout is given.
+const DefaultWPSPINTimeout = hostapd.DefaultWPSPINTimeout
+
+// ValidateWPSPIN checks that pin is a 4-digit PIN or an 8-digit PIN with a valid checksum.
+func ValidateWPSPIN(pin string) error {
+	return hostapd.ValidateWPSPIN(pin)
+}
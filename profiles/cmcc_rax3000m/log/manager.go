@@ -21,6 +21,10 @@ func New(t goubus.Transport) *Manager {
 	}
 }
 
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
 func (m *Manager) Read(ctx context.Context, lines int, stream bool, oneshot bool) (*Log, error) {
 	return m.base.Read(ctx, lines, stream, oneshot)
 }
@@ -29,7 +33,64 @@ func (m *Manager) Write(ctx context.Context, event string) error {
 	return m.base.Write(ctx, event)
 }
 
+func (m *Manager) WritePriority(ctx context.Context, event string, priority Severity) error {
+	return m.base.WritePriority(ctx, event, priority)
+}
+
+func (m *Manager) Follow(ctx context.Context, filter FollowFilter) (*Follower, error) {
+	return m.base.Follow(ctx, filter)
+}
+
 // Type aliases for public use.
 type (
-	Log = log.Log
+	Log          = log.Log
+	Data         = log.Data
+	Follower     = log.Follower
+	FollowFilter = log.FollowFilter
+	Severity     = log.Severity
+	Facility     = log.Facility
+)
+
+// Syslog severities.
+const (
+	SeverityEmerg   = log.SeverityEmerg
+	SeverityAlert   = log.SeverityAlert
+	SeverityCrit    = log.SeverityCrit
+	SeverityErr     = log.SeverityErr
+	SeverityWarning = log.SeverityWarning
+	SeverityNotice  = log.SeverityNotice
+	SeverityInfo    = log.SeverityInfo
+	SeverityDebug   = log.SeverityDebug
+)
+
+// Syslog facilities.
+const (
+	FacilityKern     = log.FacilityKern
+	FacilityUser     = log.FacilityUser
+	FacilityMail     = log.FacilityMail
+	FacilityDaemon   = log.FacilityDaemon
+	FacilityAuth     = log.FacilityAuth
+	FacilitySyslog   = log.FacilitySyslog
+	FacilityLPR      = log.FacilityLPR
+	FacilityNews     = log.FacilityNews
+	FacilityUUCP     = log.FacilityUUCP
+	FacilityCron     = log.FacilityCron
+	FacilityAuthPriv = log.FacilityAuthPriv
+	FacilityFTP      = log.FacilityFTP
+	FacilityLocal0   = log.FacilityLocal0
+	FacilityLocal1   = log.FacilityLocal1
+	FacilityLocal2   = log.FacilityLocal2
+	FacilityLocal3   = log.FacilityLocal3
+	FacilityLocal4   = log.FacilityLocal4
+	FacilityLocal5   = log.FacilityLocal5
+	FacilityLocal6   = log.FacilityLocal6
+	FacilityLocal7   = log.FacilityLocal7
 )
+
+func SeverityAtLeast(s Severity) []Severity {
+	return log.SeverityAtLeast(s)
+}
+
+func ParseEntry(ev goubus.Event) (Data, bool) {
+	return log.ParseEntry(ev)
+}
@@ -28,6 +28,10 @@ func New(t goubus.Transport) *Manager {
 	}
 }
 
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
 func (m *Manager) GetVersion(ctx context.Context) (*Version, error) {
 	return m.base.GetVersion(ctx)
 }
@@ -72,11 +76,11 @@ func (m *Manager) GetFeatures(ctx context.Context) (map[string]goubus.Bool, erro
 	return m.base.GetFeatures(ctx)
 }
 
-func (m *Manager) GetSwconfigFeatures(ctx context.Context, switchName string) (map[string]any, error) {
+func (m *Manager) GetSwconfigFeatures(ctx context.Context, switchName string) (*SwconfigFeatures, error) {
 	return m.base.GetSwconfigFeatures(ctx, switchName)
 }
 
-func (m *Manager) GetSwconfigPortState(ctx context.Context, switchName string) (map[string]any, error) {
+func (m *Manager) GetSwconfigPortState(ctx context.Context, switchName string) ([]SwconfigPort, error) {
 	return m.base.GetSwconfigPortState(ctx, switchName)
 }
 
@@ -128,7 +132,11 @@ func (m *Manager) GetHostHints(ctx context.Context) (map[string]HostHint, error)
 	return m.base.GetHostHints(ctx)
 }
 
-func (m *Manager) GetDUIDHints(ctx context.Context) (map[string]any, error) {
+func (m *Manager) LazyHostHints(ctx context.Context) (*goubus.LazyMap[HostHint], error) {
+	return m.base.LazyHostHints(ctx)
+}
+
+func (m *Manager) GetDUIDHints(ctx context.Context) (map[string]DUIDHint, error) {
 	return m.base.GetDUIDHints(ctx)
 }
 
@@ -136,18 +144,32 @@ func (m *Manager) GetBoardJSON(ctx context.Context) (*BoardJSON, error) {
 	return m.base.GetBoardJSON(ctx)
 }
 
+func (m *Manager) Wol(ctx context.Context, mac string, opts WakeOptions) error {
+	return m.base.Wol(ctx, mac, opts)
+}
+
 // Type aliases for public use.
 type (
-	Version        = luci.Version
-	DHCPLeases     = luci.DHCPLeases
-	LED            = luci.LED
-	USBDevice      = luci.USBDevice
-	BlockDevice    = luci.BlockDevice
-	MountPoint     = luci.MountPoint
-	RealtimeStats  = luci.RealtimeStats
-	Process        = luci.Process
-	NetworkDevice  = luci.NetworkDevice
-	WirelessDevice = luci.WirelessDevice
-	HostHint       = luci.HostHint
-	BoardJSON      = luci.BoardJSON
+	Version          = luci.Version
+	DHCPLeases       = luci.DHCPLeases
+	LED              = luci.LED
+	USBDevice        = luci.USBDevice
+	BlockDevice      = luci.BlockDevice
+	MountPoint       = luci.MountPoint
+	RealtimeStats    = luci.RealtimeStats
+	Process          = luci.Process
+	NetworkDevice    = luci.NetworkDevice
+	WirelessDevice   = luci.WirelessDevice
+	HostHint         = luci.HostHint
+	BoardJSON        = luci.BoardJSON
+	BoardSwitch      = luci.BoardSwitch
+	DUIDHint         = luci.DUIDHint
+	SwconfigFeatures = luci.SwconfigFeatures
+	SwconfigPort     = luci.SwconfigPort
+	WakeOptions      = luci.WakeOptions
 )
+
+// MagicPacket returns the Wake-on-LAN payload for a MAC address.
+func MagicPacket(mac string) ([]byte, error) {
+	return luci.MagicPacket(mac)
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package modem
+
+import (
+	"context"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/internal/base/modem"
+)
+
+// Manager reports WWAN modem status and controls the data connection for CMCC RAX3000M.
+type Manager struct {
+	base *modem.Manager
+}
+
+func New(t goubus.Transport) *Manager {
+	return &Manager{
+		base: modem.New(t),
+	}
+}
+
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
+func (m *Manager) Status(ctx context.Context, iface string) (*Status, error) {
+	return m.base.Status(ctx, iface)
+}
+
+func (m *Manager) SIM(ctx context.Context, iface string) (*SIM, error) {
+	return m.base.SIM(ctx, iface)
+}
+
+func (m *Manager) Connect(ctx context.Context, iface string) error {
+	return m.base.Connect(ctx, iface)
+}
+
+func (m *Manager) Disconnect(ctx context.Context, iface string) error {
+	return m.base.Disconnect(ctx, iface)
+}
+
+// Type aliases for public use.
+type (
+	Status   = modem.Status
+	Operator = modem.Operator
+	Signal   = modem.Signal
+	SIM      = modem.SIM
+)
+
+// Backends a modem interface is driven by.
+const (
+	BackendQMI          = modem.BackendQMI
+	BackendModemManager = modem.BackendModemManager
+)
+
+// Registration states of a modem in the mobile network.
+const (
+	RegistrationRegistered    = modem.RegistrationRegistered
+	RegistrationSearching     = modem.RegistrationSearching
+	RegistrationDenied        = modem.RegistrationDenied
+	RegistrationNotRegistered = modem.RegistrationNotRegistered
+	RegistrationUnknown       = modem.RegistrationUnknown
+)
+
+// SignalQuality maps an RSSI in dBm to a signal quality in percent.
+func SignalQuality(rssi float64) int {
+	return modem.SignalQuality(rssi)
+}
@@ -5,6 +5,7 @@ package network
 
 import (
 	"context"
+	"time"
 
 	"github.com/honeybbq/goubus/v2"
 	"github.com/honeybbq/goubus/v2/internal/base/network"
@@ -23,6 +24,10 @@ func New(t goubus.Transport) *Manager {
 	}
 }
 
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
 func (m *Manager) Dump(ctx context.Context) ([]InterfaceInfo, error) {
 	return m.base.DumpInterfaces(ctx)
 }
@@ -63,6 +68,76 @@ func (m *Manager) Wireless() *WirelessContext {
 	return m.base.Wireless()
 }
 
+func (m *Manager) Routes(ctx context.Context) ([]StaticRoute, error) {
+	return m.base.Routes(ctx)
+}
+
+func (m *Manager) SaveRoute(ctx context.Context, route StaticRoute) error {
+	return m.base.SaveRoute(ctx, route)
+}
+
+func (m *Manager) DeleteRoute(ctx context.Context, section string) error {
+	return m.base.DeleteRoute(ctx, section)
+}
+
+func (m *Manager) Rules(ctx context.Context) ([]RoutingRule, error) {
+	return m.base.Rules(ctx)
+}
+
+func (m *Manager) SaveRule(ctx context.Context, rule RoutingRule) error {
+	return m.base.SaveRule(ctx, rule)
+}
+
+func (m *Manager) DeleteRule(ctx context.Context, section string) error {
+	return m.base.DeleteRule(ctx, section)
+}
+
+func (m *Manager) RouteTable(ctx context.Context) ([]RouteEntry, error) {
+	return m.base.RouteTable(ctx)
+}
+
+func (m *Manager) DeviceConfigs(ctx context.Context) ([]DeviceConfig, error) {
+	return m.base.DeviceConfigs(ctx)
+}
+
+func (m *Manager) SaveDeviceConfig(ctx context.Context, device DeviceConfig) error {
+	return m.base.SaveDeviceConfig(ctx, device)
+}
+
+func (m *Manager) BridgeVLANs(ctx context.Context) ([]BridgeVLAN, error) {
+	return m.base.BridgeVLANs(ctx)
+}
+
+func (m *Manager) SaveBridgeVLAN(ctx context.Context, vlan BridgeVLAN) error {
+	return m.base.SaveBridgeVLAN(ctx, vlan)
+}
+
+func (m *Manager) AddBridgePort(ctx context.Context, bridge, port string, timeout time.Duration) error {
+	return m.base.AddBridgePort(ctx, bridge, port, timeout)
+}
+
+func (m *Manager) SetVLANPort(
+	ctx context.Context, bridge string, vlan int, port BridgeVLANPort, timeout time.Duration,
+) error {
+	return m.base.SetVLANPort(ctx, bridge, vlan, port, timeout)
+}
+
+func (m *Manager) ApplyChanges(ctx context.Context, timeout time.Duration) error {
+	return m.base.ApplyChanges(ctx, timeout)
+}
+
+func (m *Manager) Neighbors(ctx context.Context) ([]NeighborEntry, error) {
+	return m.base.Neighbors(ctx)
+}
+
+func (m *Manager) AddStaticNeighbor(ctx context.Context, ip, mac, device string) error {
+	return m.base.AddStaticNeighbor(ctx, ip, mac, device)
+}
+
+func (m *Manager) DeleteNeighbor(ctx context.Context, ip, device string) error {
+	return m.base.DeleteNeighbor(ctx, ip, device)
+}
+
 // Type aliases for public use.
 type (
 	InterfaceInfo          = network.InterfaceInfo
@@ -77,4 +152,27 @@ type (
 	DeviceSetStateRequest  = network.DeviceSetStateRequest
 	InterfaceDeviceRequest = network.InterfaceDeviceRequest
 	WirelessNotifyRequest  = network.WirelessNotifyRequest
+	VLANRequest            = network.VLANRequest
+	Route                  = network.Route
+	StaticRoute            = network.StaticRoute
+	RoutingRule            = network.RoutingRule
+	RouteEntry             = network.RouteEntry
+	DeviceConfig           = network.DeviceConfig
+	BridgeVLAN             = network.BridgeVLAN
+	BridgeVLANPort         = network.BridgeVLANPort
+	NeighborEntry          = network.NeighborEntry
 )
+
+// VLAN protocols of a VLANRequest.
+const (
+	VLANProtocol8021Q  = network.VLANProtocol8021Q
+	VLANProtocol8021AD = network.VLANProtocol8021AD
+)
+
+// DefaultApplyTimeout is the rollback timeout ApplyChanges uses when none is given.
+const DefaultApplyTimeout = network.DefaultApplyTimeout
+
+// ParseBridgeVLANPort parses a bridge-vlan port entry such as "lan1", "lan1:t" or "lan1:u*".
+func ParseBridgeVLANPort(entry string) (BridgeVLANPort, error) {
+	return network.ParseBridgeVLANPort(entry)
+}
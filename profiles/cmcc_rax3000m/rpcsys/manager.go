@@ -21,6 +21,10 @@ func New(t goubus.Transport) *Manager {
 	}
 }
 
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
 func (m *Manager) PackageList(ctx context.Context, all bool) (map[string]any, error) {
 	return m.base.PackageList(ctx, all)
 }
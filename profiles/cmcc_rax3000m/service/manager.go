@@ -21,6 +21,10 @@ func New(t goubus.Transport) *Manager {
 	}
 }
 
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
 func (m *Manager) List(ctx context.Context, name string, verbose bool) (map[string]Info, error) {
 	return m.base.List(ctx, name, verbose)
 }
@@ -49,6 +53,14 @@ func (m *Manager) UpdateComplete(ctx context.Context, name string) error {
 	return m.base.UpdateComplete(ctx, name)
 }
 
+func (m *Manager) Update(ctx context.Context, name string, fn func() error) error {
+	return m.base.Update(ctx, name, fn)
+}
+
+func (m *Manager) Watch(ctx context.Context, services ...string) (*goubus.Subscription, error) {
+	return m.base.Watch(ctx, services...)
+}
+
 func (m *Manager) Event(ctx context.Context, req EventRequest) error {
 	return m.base.Event(ctx, req)
 }
@@ -77,7 +89,32 @@ func (m *Manager) Watchdog(ctx context.Context, name, instance string, mode, tim
 type (
 	Info            = service.Info
 	Instance        = service.Instance
+	InstanceEvent   = service.InstanceEvent
+	InstanceConfig  = service.InstanceConfig
+	Respawn         = service.Respawn
+	Jail            = service.Jail
+	Limits          = service.Limits
 	SetRequest      = service.SetRequest
 	EventRequest    = service.EventRequest
 	ValidateRequest = service.ValidateRequest
 )
+
+// Signals for Signal.
+const (
+	SignalHUP  = service.SignalHUP
+	SignalINT  = service.SignalINT
+	SignalKILL = service.SignalKILL
+	SignalTERM = service.SignalTERM
+)
+
+// Instance event types sent by procd.
+const (
+	InstanceEventStart   = service.InstanceEventStart
+	InstanceEventStop    = service.InstanceEventStop
+	InstanceEventRespawn = service.InstanceEventRespawn
+	InstanceEventCrash   = service.InstanceEventCrash
+)
+
+func ParseInstanceEvent(ev goubus.Event) (InstanceEvent, bool) {
+	return service.ParseInstanceEvent(ev)
+}
@@ -38,8 +38,8 @@ func TestRaxSessionManager(t *testing.T) {
 
 		mgr := session.New(mock)
 		_, _ = mgr.Login(ctx, session.LoginRequest{Username: "root", Password: "password"})
-		_ = mgr.Grant(ctx, session.GrantRequest{Session: "test", Scope: "ubus", Objects: []string{"*"}})
-		_ = mgr.Revoke(ctx, session.GrantRequest{Session: "test", Scope: "ubus", Objects: []string{"*"}})
+		_ = mgr.Grant(ctx, "test", session.ScopeUbus, "*")
+		_ = mgr.Revoke(ctx, "test", session.ScopeUbus, "*")
 		_, _ = mgr.Access(ctx, session.AccessRequest{Session: "test", Scope: "ubus", Object: "system", Function: "info"})
 		_ = mgr.Destroy(ctx, "test")
 	})
@@ -0,0 +1,30 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package umdns_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/honeybbq/goubus/v2/internal/testutil"
+	"github.com/honeybbq/goubus/v2/profiles/cmcc_rax3000m/umdns"
+)
+
+func TestRaxUmdnsManager(t *testing.T) {
+	ctx := context.Background()
+	mock := testutil.NewMockTransport()
+
+	t.Run("Methods", func(t *testing.T) {
+		mock.AddResponse("umdns", "update", map[string]any{})
+		mock.AddResponse("umdns", "browse", map[string]any{})
+		mock.AddResponse("umdns", "hosts", map[string]any{})
+		mock.AddResponse("umdns", "announcements", map[string]any{})
+
+		mgr := umdns.New(mock)
+		_ = mgr.Update(ctx)
+		_, _ = mgr.Browse(ctx, "_ssh._tcp")
+		_, _ = mgr.Hosts(ctx)
+		_, _ = mgr.Announcements(ctx)
+	})
+}
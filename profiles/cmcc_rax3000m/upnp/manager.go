@@ -0,0 +1,53 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package upnp
+
+import (
+	"context"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/internal/base/upnp"
+)
+
+// Manager handles UPnP (miniupnpd) operations for CMCC RAX3000M.
+type Manager struct {
+	base *upnp.Manager
+}
+
+func New(t goubus.Transport) *Manager {
+	return &Manager{
+		base: upnp.New(t),
+	}
+}
+
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
+func (m *Manager) Config(ctx context.Context) (*Config, error) {
+	return m.base.Config(ctx)
+}
+
+func (m *Manager) SetConfig(ctx context.Context, cfg Config) error {
+	return m.base.SetConfig(ctx, cfg)
+}
+
+func (m *Manager) PermRules(ctx context.Context) ([]PermRule, error) {
+	return m.base.PermRules(ctx)
+}
+
+func (m *Manager) SetEnabled(ctx context.Context, enabled bool) error {
+	return m.base.SetEnabled(ctx, enabled)
+}
+
+func (m *Manager) Leases(ctx context.Context) ([]Lease, error) {
+	return m.base.Leases(ctx)
+}
+
+// Type aliases for public use.
+type (
+	Config   = upnp.Config
+	PermRule = upnp.PermRule
+	Lease    = upnp.Lease
+)
@@ -68,8 +68,12 @@ func testRaxAssocList(t *testing.T, ctx context.Context, mock *testutil.MockTran
 		t.Fatalf("AssocList failed: %v", err)
 	}
 
-	if len(assoc) != 0 {
-		t.Logf("Found %d associated stations", len(assoc))
+	if len(assoc) != 1 || assoc[0].ExpectedThroughput != 202384 || assoc[0].Tx.Failed != 44 {
+		t.Fatalf("unexpected stations: %+v", assoc)
+	}
+
+	if key := assoc[0].Rx.Key(); key.String() != "HE-MCS11 2SS 20MHz" {
+		t.Errorf("unexpected rx rate key: %v", key)
 	}
 }
 
@@ -21,6 +21,10 @@ func New(t goubus.Transport) *Manager {
 	}
 }
 
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
 func (m *Manager) IfaceStatus(ctx context.Context, name string) (map[string]any, error) {
 	return m.base.IfaceStatus(ctx, name)
 }
@@ -31,5 +35,20 @@ func (m *Manager) STA(name string) *STAContext {
 
 // Type aliases for public use.
 type (
-	STAContext = wpa_supplicant.STAContext
+	STAContext    = wpa_supplicant.STAContext
+	STAStatus     = wpa_supplicant.STAStatus
+	ScanResult    = wpa_supplicant.ScanResult
+	Network       = wpa_supplicant.Network
+	NetworkConfig = wpa_supplicant.NetworkConfig
+)
+
+// Connection states reported as wpa_state.
+const (
+	StateDisconnected     = wpa_supplicant.StateDisconnected
+	StateInactive         = wpa_supplicant.StateInactive
+	StateScanning         = wpa_supplicant.StateScanning
+	StateAssociating      = wpa_supplicant.StateAssociating
+	StateAssociated       = wpa_supplicant.StateAssociated
+	StateFourWayHandshake = wpa_supplicant.StateFourWayHandshake
+	StateCompleted        = wpa_supplicant.StateCompleted
 )
@@ -0,0 +1,70 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package acl
+
+import (
+	"context"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/base/acl"
+)
+
+// Manager handles rpcd access control lists for standard x86/generic OpenWrt.
+type Manager struct {
+	base *acl.Manager
+}
+
+func New(t goubus.Transport) *Manager {
+	return &Manager{
+		base: acl.New(t),
+	}
+}
+
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
+func (m *Manager) Groups(ctx context.Context) ([]Group, error) {
+	return m.base.Groups(ctx)
+}
+
+func (m *Manager) Logins(ctx context.Context) ([]Login, error) {
+	return m.base.Logins(ctx)
+}
+
+func (m *Manager) Effective(ctx context.Context, username string) (*Access, error) {
+	return m.base.Effective(ctx, username)
+}
+
+func (m *Manager) Install(ctx context.Context, name string, groups ...Group) error {
+	return m.base.Install(ctx, name, groups...)
+}
+
+func (m *Manager) Remove(ctx context.Context, name string) error {
+	return m.base.Remove(ctx, name)
+}
+
+// Type aliases for public use.
+type (
+	Permissions = acl.Permissions
+	Group       = acl.Group
+	Login       = acl.Login
+	Access      = acl.Access
+)
+
+// Dir is where rpcd reads its ACL definitions from.
+const Dir = acl.Dir
+
+// GroupForError returns a group named name that grants the permission a PermissionError
+// reports as missing, ready to be installed.
+func GroupForError(name string, e *errdefs.PermissionError) Group {
+	return acl.GroupForError(name, e)
+}
+
+// Resolve computes the permissions login gets from groups, the way rpcd does when a session
+// is created.
+func Resolve(login Login, groups []Group) *Access {
+	return acl.Resolve(login, groups)
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package conntrack
+
+import (
+	"context"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/internal/base/conntrack"
+)
+
+// Manager handles connection tracking for standard x86/generic OpenWrt.
+type Manager struct {
+	base *conntrack.Manager
+}
+
+func New(t goubus.Transport) *Manager {
+	return &Manager{
+		base: conntrack.New(t),
+	}
+}
+
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
+func (m *Manager) Flows(ctx context.Context) ([]Flow, error) {
+	return m.base.Flows(ctx)
+}
+
+func (m *Manager) Delete(ctx context.Context, filter Filter) (int, error) {
+	return m.base.Delete(ctx, filter)
+}
+
+func (m *Manager) Flush(ctx context.Context) error {
+	return m.base.Flush(ctx)
+}
+
+// Type aliases for public use.
+type (
+	Flow   = conntrack.Flow
+	Tuple  = conntrack.Tuple
+	Usage  = conntrack.Usage
+	Filter = conntrack.Filter
+)
+
+// UsageByHost sums the flows per original source address, e.g. per LAN client.
+func UsageByHost(flows []Flow) map[string]Usage {
+	return conntrack.UsageByHost(flows)
+}
+
+// ParseFlows parses the contents of /proc/net/nf_conntrack. Malformed lines are skipped.
+func ParseFlows(data string) []Flow {
+	return conntrack.ParseFlows(data)
+}
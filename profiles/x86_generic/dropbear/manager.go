@@ -0,0 +1,56 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package dropbear
+
+import (
+	"context"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/internal/base/dropbear"
+)
+
+// Manager handles dropbear SSH server operations for standard x86/generic OpenWrt.
+type Manager struct {
+	base *dropbear.Manager
+}
+
+func New(t goubus.Transport) *Manager {
+	return &Manager{
+		base: dropbear.New(t),
+	}
+}
+
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
+func (m *Manager) Configs(ctx context.Context) ([]Config, error) {
+	return m.base.Configs(ctx)
+}
+
+func (m *Manager) SetConfig(ctx context.Context, cfg Config) error {
+	return m.base.SetConfig(ctx, cfg)
+}
+
+func (m *Manager) AuthorizedKeys(ctx context.Context) ([]AuthorizedKey, error) {
+	return m.base.AuthorizedKeys(ctx)
+}
+
+func (m *Manager) AddAuthorizedKey(ctx context.Context, line string) error {
+	return m.base.AddAuthorizedKey(ctx, line)
+}
+
+func (m *Manager) RemoveAuthorizedKey(ctx context.Context, keyOrComment string) error {
+	return m.base.RemoveAuthorizedKey(ctx, keyOrComment)
+}
+
+func (m *Manager) SetAuthorizedKeys(ctx context.Context, keys []AuthorizedKey) error {
+	return m.base.SetAuthorizedKeys(ctx, keys)
+}
+
+// Type aliases for public use.
+type (
+	Config        = dropbear.Config
+	AuthorizedKey = dropbear.AuthorizedKey
+)
@@ -0,0 +1,81 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package firewall
+
+import (
+	"context"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/internal/base/firewall"
+)
+
+// Manager handles runtime firewall (fw4) operations for standard x86/generic OpenWrt.
+type Manager struct {
+	base *firewall.Manager
+}
+
+func New(t goubus.Transport) *Manager {
+	return &Manager{
+		base: firewall.New(t),
+	}
+}
+
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
+func (m *Manager) Reload(ctx context.Context) error {
+	return m.base.Reload(ctx)
+}
+
+func (m *Manager) Restart(ctx context.Context) error {
+	return m.base.Restart(ctx)
+}
+
+func (m *Manager) Print(ctx context.Context) (string, error) {
+	return m.base.Print(ctx)
+}
+
+func (m *Manager) Ruleset(ctx context.Context) ([]Chain, error) {
+	return m.base.Ruleset(ctx)
+}
+
+func (m *Manager) Zones(ctx context.Context) ([]string, error) {
+	return m.base.Zones(ctx)
+}
+
+func (m *Manager) AddTemporaryRule(ctx context.Context, rule TemporaryRule) (*InstalledRule, error) {
+	return m.base.AddTemporaryRule(ctx, rule)
+}
+
+func (m *Manager) DeleteRule(ctx context.Context, rule InstalledRule) error {
+	return m.base.DeleteRule(ctx, rule)
+}
+
+func (m *Manager) TemporaryRules(ctx context.Context) ([]InstalledRule, error) {
+	return m.base.TemporaryRules(ctx)
+}
+
+func (m *Manager) PortForwards() *PortForwardContext {
+	return m.base.PortForwards()
+}
+
+// Type aliases for public use.
+type (
+	Chain         = firewall.Chain
+	Rule          = firewall.Rule
+	TemporaryRule = firewall.TemporaryRule
+	InstalledRule = firewall.InstalledRule
+
+	PortForward        = firewall.PortForward
+	PortForwardContext = firewall.PortForwardContext
+)
+
+// TemporaryCommentPrefix starts the generated comments of temporary rules.
+const TemporaryCommentPrefix = firewall.TemporaryCommentPrefix
+
+// ParseRuleset parses the output of "nft -a list table" into its chains.
+func ParseRuleset(output string) []Chain {
+	return firewall.ParseRuleset(output)
+}
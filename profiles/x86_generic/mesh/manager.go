@@ -0,0 +1,63 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package mesh
+
+import (
+	"context"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/internal/base/mesh"
+)
+
+// Manager reports 802.11s mesh and batman-adv status for standard x86/generic OpenWrt.
+type Manager struct {
+	base *mesh.Manager
+}
+
+func New(t goubus.Transport) *Manager {
+	return &Manager{
+		base: mesh.New(t),
+	}
+}
+
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
+func (m *Manager) Interfaces(ctx context.Context) ([]Interface, error) {
+	return m.base.Interfaces(ctx)
+}
+
+func (m *Manager) Peers(ctx context.Context, ifname string) ([]Peer, error) {
+	return m.base.Peers(ctx, ifname)
+}
+
+func (m *Manager) Paths(ctx context.Context, ifname string) ([]Path, error) {
+	return m.base.Paths(ctx, ifname)
+}
+
+func (m *Manager) Originators(ctx context.Context, meshif string) ([]Originator, error) {
+	return m.base.Originators(ctx, meshif)
+}
+
+func (m *Manager) Neighbors(ctx context.Context, meshif string) ([]Neighbor, error) {
+	return m.base.Neighbors(ctx, meshif)
+}
+
+// Type aliases for public use.
+type (
+	Interface  = mesh.Interface
+	Peer       = mesh.Peer
+	Path       = mesh.Path
+	Originator = mesh.Originator
+	Neighbor   = mesh.Neighbor
+)
+
+// DefaultMeshInterface is the batman-adv soft interface OpenWrt creates by default.
+const DefaultMeshInterface = mesh.DefaultMeshInterface
+
+// ParsePaths parses the output of "iw dev <ifname> mpath dump".
+func ParsePaths(out string) []Path {
+	return mesh.ParsePaths(out)
+}
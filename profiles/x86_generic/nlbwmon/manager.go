@@ -0,0 +1,77 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package nlbwmon
+
+import (
+	"context"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/internal/base/nlbwmon"
+)
+
+// Manager handles nlbwmon bandwidth accounting for standard x86/generic OpenWrt.
+type Manager struct {
+	base *nlbwmon.Manager
+}
+
+func New(t goubus.Transport) *Manager {
+	return &Manager{
+		base: nlbwmon.New(t),
+	}
+}
+
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
+func (m *Manager) Dump(ctx context.Context, q Query) ([]Record, error) {
+	return m.base.Dump(ctx, q)
+}
+
+func (m *Manager) Clients(ctx context.Context, period string) ([]Record, error) {
+	return m.base.Clients(ctx, period)
+}
+
+func (m *Manager) Export(ctx context.Context, period, format string) (string, error) {
+	return m.base.Export(ctx, period, format)
+}
+
+func (m *Manager) Periods(ctx context.Context) ([]string, error) {
+	return m.base.Periods(ctx)
+}
+
+func (m *Manager) Commit(ctx context.Context) error {
+	return m.base.Commit(ctx)
+}
+
+func (m *Manager) Restart(ctx context.Context) error {
+	return m.base.Restart(ctx)
+}
+
+// Type aliases for public use.
+type (
+	Query  = nlbwmon.Query
+	Record = nlbwmon.Record
+)
+
+// Columns of a Query.GroupBy.
+const (
+	GroupFamily   = nlbwmon.GroupFamily
+	GroupProtocol = nlbwmon.GroupProtocol
+	GroupPort     = nlbwmon.GroupPort
+	GroupMAC      = nlbwmon.GroupMAC
+	GroupIP       = nlbwmon.GroupIP
+	GroupLayer7   = nlbwmon.GroupLayer7
+)
+
+// Export formats of nlbw.
+const (
+	FormatCSV  = nlbwmon.FormatCSV
+	FormatJSON = nlbwmon.FormatJSON
+)
+
+// ParseDump decodes the output of "nlbw -c json".
+func ParseDump(data string) ([]Record, error) {
+	return nlbwmon.ParseDump(data)
+}
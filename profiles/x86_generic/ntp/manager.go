@@ -0,0 +1,58 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package ntp
+
+import (
+	"context"
+	"time"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/internal/base/ntp"
+	"github.com/honeybbq/goubus/v2/profiles/x86_generic/luci"
+)
+
+// Manager handles time synchronization for standard x86/generic OpenWrt.
+type Manager struct {
+	base *ntp.Manager
+}
+
+func New(t goubus.Transport) *Manager {
+	return &Manager{
+		base: ntp.New(t, luci.StandardDialect{}),
+	}
+}
+
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
+func (m *Manager) Status(ctx context.Context) (*Status, error) {
+	return m.base.Status(ctx)
+}
+
+func (m *Manager) Config(ctx context.Context) (*Config, error) {
+	return m.base.Config(ctx)
+}
+
+func (m *Manager) SetConfig(ctx context.Context, cfg Config) error {
+	return m.base.SetConfig(ctx, cfg)
+}
+
+func (m *Manager) SetServers(ctx context.Context, servers ...string) error {
+	return m.base.SetServers(ctx, servers...)
+}
+
+func (m *Manager) Resync(ctx context.Context) error {
+	return m.base.Resync(ctx)
+}
+
+func (m *Manager) SetClock(ctx context.Context, t time.Time) error {
+	return m.base.SetClock(ctx, t)
+}
+
+// Type aliases for public use.
+type (
+	Config = ntp.Config
+	Status = ntp.Status
+)
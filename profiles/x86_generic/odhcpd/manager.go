@@ -0,0 +1,51 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package odhcpd
+
+import (
+	"context"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/internal/base/dhcp"
+	"github.com/honeybbq/goubus/v2/internal/base/odhcpd"
+)
+
+// Manager handles odhcpd lease operations for standard x86/generic OpenWrt.
+type Manager struct {
+	base *odhcpd.Manager
+}
+
+func New(t goubus.Transport) *Manager {
+	return &Manager{
+		base: odhcpd.New(t),
+	}
+}
+
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
+func (m *Manager) IPv6Leases(ctx context.Context) ([]IPv6Lease, error) {
+	return m.base.IPv6Leases(ctx)
+}
+
+func (m *Manager) IPv4Leases(ctx context.Context) ([]IPv4Lease, error) {
+	return m.base.IPv4Leases(ctx)
+}
+
+func (m *Manager) HostLeases(ctx context.Context) ([]HostLease, error) {
+	return m.base.HostLeases(ctx)
+}
+
+// Type aliases for public use.
+type (
+	IPv6Lease   = odhcpd.IPv6Lease
+	IPv6Address = odhcpd.IPv6Address
+	IPv4Lease   = odhcpd.IPv4Lease
+	HostLease   = odhcpd.HostLease
+)
+
+func MatchHost(lease IPv6Lease, hosts []dhcp.StaticLease) *dhcp.StaticLease {
+	return odhcpd.MatchHost(lease, hosts)
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package opkg
+
+import (
+	"context"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/internal/base/opkg"
+)
+
+// Manager handles opkg operations for standard x86/generic OpenWrt.
+type Manager struct {
+	base *opkg.Manager
+}
+
+func New(t goubus.Transport) *Manager {
+	return &Manager{
+		base: opkg.New(t),
+	}
+}
+
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
+func (m *Manager) Update(ctx context.Context) (*Result, error) {
+	return m.base.Update(ctx)
+}
+
+func (m *Manager) List(ctx context.Context) ([]Package, error) {
+	return m.base.List(ctx)
+}
+
+func (m *Manager) ListInstalled(ctx context.Context) ([]Package, error) {
+	return m.base.ListInstalled(ctx)
+}
+
+func (m *Manager) ListUpgradable(ctx context.Context) ([]Upgrade, error) {
+	return m.base.ListUpgradable(ctx)
+}
+
+func (m *Manager) Info(ctx context.Context, name string) (*Info, error) {
+	return m.base.Info(ctx, name)
+}
+
+func (m *Manager) Install(ctx context.Context, packages []string, opts *Options) (*Result, error) {
+	return m.base.Install(ctx, packages, opts)
+}
+
+func (m *Manager) Remove(ctx context.Context, packages []string, opts *Options) (*Result, error) {
+	return m.base.Remove(ctx, packages, opts)
+}
+
+// Type aliases for public use.
+type (
+	Package  = opkg.Package
+	Upgrade  = opkg.Upgrade
+	Info     = opkg.Info
+	Result   = opkg.Result
+	Options  = opkg.Options
+	Progress = opkg.Progress
+)
@@ -21,6 +21,10 @@ func New(t goubus.Transport) *Manager {
 	}
 }
 
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
 func (m *Manager) List(ctx context.Context, name string, skipRunningCheck bool) (map[string]ListInfo, error) {
 	return m.base.List(ctx, name, skipRunningCheck)
 }
@@ -29,7 +33,22 @@ func (m *Manager) Init(ctx context.Context, name, action string) error {
 	return m.base.Init(ctx, name, action)
 }
 
+func (m *Manager) Status(ctx context.Context, name string) (*Status, error) {
+	return m.base.Status(ctx, name)
+}
+
 // Type aliases for public use.
 type (
 	ListInfo = rc.ListInfo
+	Status   = rc.Status
+)
+
+// Init script actions.
+const (
+	ActionStart   = rc.ActionStart
+	ActionStop    = rc.ActionStop
+	ActionRestart = rc.ActionRestart
+	ActionReload  = rc.ActionReload
+	ActionEnable  = rc.ActionEnable
+	ActionDisable = rc.ActionDisable
 )
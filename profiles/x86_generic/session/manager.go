@@ -21,6 +21,10 @@ func New(t goubus.Transport) *Manager {
 	}
 }
 
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
 func (m *Manager) Create(ctx context.Context, timeout int) (*Data, error) {
 	return m.base.Create(ctx, timeout)
 }
@@ -29,12 +33,16 @@ func (m *Manager) List(ctx context.Context) (map[string]any, error) {
 	return m.base.List(ctx)
 }
 
-func (m *Manager) Grant(ctx context.Context, req GrantRequest) error {
-	return m.base.Grant(ctx, req)
+func (m *Manager) Grant(ctx context.Context, sessionID, scope, object string, methods ...string) error {
+	return m.base.Grant(ctx, sessionID, scope, object, methods...)
 }
 
-func (m *Manager) Revoke(ctx context.Context, req GrantRequest) error {
-	return m.base.Revoke(ctx, req)
+func (m *Manager) Revoke(ctx context.Context, sessionID, scope, object string, methods ...string) error {
+	return m.base.Revoke(ctx, sessionID, scope, object, methods...)
+}
+
+func (m *Manager) CreateRestricted(ctx context.Context, timeout int, acls ACLs) (*Data, error) {
+	return m.base.CreateRestricted(ctx, timeout, acls)
 }
 
 func (m *Manager) Access(ctx context.Context, req AccessRequest) (bool, error) {
@@ -53,6 +61,14 @@ func (m *Manager) Unset(ctx context.Context, sessionID string, keys []string) er
 	return m.base.Unset(ctx, sessionID, keys)
 }
 
+func (m *Manager) SetData(ctx context.Context, sessionID, key string, value any) error {
+	return m.base.SetData(ctx, sessionID, key, value)
+}
+
+func (m *Manager) GetData(ctx context.Context, sessionID, key string) (any, error) {
+	return m.base.GetData(ctx, sessionID, key)
+}
+
 func (m *Manager) Destroy(ctx context.Context, sessionID string) error {
 	return m.base.Destroy(ctx, sessionID)
 }
@@ -64,7 +80,14 @@ func (m *Manager) Login(ctx context.Context, req LoginRequest) (*Data, error) {
 // Type aliases for public use.
 type (
 	Data          = session.Data
+	ACLs          = session.ACLs
 	GrantRequest  = session.GrantRequest
 	AccessRequest = session.AccessRequest
 	LoginRequest  = session.LoginRequest
 )
+
+// ACL scopes of Grant, Revoke and AccessRequest.
+const (
+	ScopeUbus = session.ScopeUbus
+	ScopeUCI  = session.ScopeUCI
+)
@@ -0,0 +1,63 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package stats
+
+import (
+	"context"
+	"time"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/internal/base/stats"
+)
+
+// Manager handles traffic statistics for standard x86/generic OpenWrt.
+type Manager struct {
+	base *stats.Manager
+}
+
+func New(t goubus.Transport) *Manager {
+	return &Manager{
+		base: stats.New(t),
+	}
+}
+
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
+func (m *Manager) Read(ctx context.Context, opts Options) (map[string]Counters, error) {
+	return m.base.Read(ctx, opts)
+}
+
+func (m *Manager) Poll(ctx context.Context, opts Options) (*Poller, error) {
+	return m.base.Poll(ctx, opts)
+}
+
+// Type aliases for public use.
+type (
+	Options  = stats.Options
+	Counters = stats.Counters
+	Rate     = stats.Rate
+	Sample   = stats.Sample
+	Poller   = stats.Poller
+)
+
+// Sources of a Poller.
+const (
+	SourceDevice = stats.SourceDevice
+	SourceLuCI   = stats.SourceLuCI
+)
+
+// DefaultInterval is the poll interval when Options.Interval is not set.
+const DefaultInterval = stats.DefaultInterval
+
+// Rates computes the rates of the devices present in both prev and cur over elapsed.
+func Rates(prev, cur map[string]Counters, elapsed time.Duration) []Rate {
+	return stats.Rates(prev, cur, elapsed)
+}
+
+// Delta returns the traffic between two reads of the same device.
+func Delta(prev, cur Counters) Counters {
+	return stats.Delta(prev, cur)
+}
@@ -5,6 +5,7 @@ package system
 
 import (
 	"context"
+	"time"
 
 	"github.com/honeybbq/goubus/v2"
 	"github.com/honeybbq/goubus/v2/internal/base/system"
@@ -21,6 +22,10 @@ func New(t goubus.Transport) *Manager {
 	}
 }
 
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
 func (m *Manager) Info(ctx context.Context) (*Info, error) {
 	return m.base.Info(ctx)
 }
@@ -33,6 +38,26 @@ func (m *Manager) Reboot(ctx context.Context) error {
 	return m.base.Reboot(ctx)
 }
 
+func (m *Manager) PowerOff(ctx context.Context) error {
+	return m.base.PowerOff(ctx)
+}
+
+func (m *Manager) FactoryReset(ctx context.Context) error {
+	return m.base.FactoryReset(ctx)
+}
+
+func (m *Manager) SetConfirm(confirm ConfirmFunc) {
+	m.base.SetConfirm(confirm)
+}
+
+func (m *Manager) WatchdogStatus(ctx context.Context) (*WatchdogStatus, error) {
+	return m.base.WatchdogStatus(ctx)
+}
+
+func (m *Manager) SetWatchdog(ctx context.Context, req WatchdogRequest) (*WatchdogStatus, error) {
+	return m.base.SetWatchdog(ctx, req)
+}
+
 func (m *Manager) Watchdog(ctx context.Context, req WatchdogRequest) error {
 	return m.base.Watchdog(ctx, req)
 }
@@ -49,12 +74,185 @@ func (m *Manager) Sysupgrade(ctx context.Context, req SysupgradeRequest) error {
 	return m.base.Sysupgrade(ctx, req)
 }
 
+func (m *Manager) RebootWhenIdle(ctx context.Context, policy IdlePolicy) error {
+	return m.base.RebootWhenIdle(ctx, policy)
+}
+
+func (m *Manager) LEDs(ctx context.Context) ([]LEDConfig, error) {
+	return m.base.LEDs(ctx)
+}
+
+func (m *Manager) SaveLED(ctx context.Context, led LEDConfig) error {
+	return m.base.SaveLED(ctx, led)
+}
+
+func (m *Manager) Buttons(ctx context.Context) ([]ButtonConfig, error) {
+	return m.base.Buttons(ctx)
+}
+
+func (m *Manager) SaveButton(ctx context.Context, button ButtonConfig) error {
+	return m.base.SaveButton(ctx, button)
+}
+
+func (m *Manager) Identify(ctx context.Context, sysfs string, duration time.Duration) error {
+	return m.base.Identify(ctx, sysfs, duration)
+}
+
+func (m *Manager) SetSensorPaths(paths []SensorPath) {
+	m.base.SetSensorPaths(paths)
+}
+
+func (m *Manager) Sensors(ctx context.Context) ([]Sensor, error) {
+	return m.base.Sensors(ctx)
+}
+
+func (m *Manager) CPUStats(ctx context.Context) (*CPUStats, error) {
+	return m.base.CPUStats(ctx)
+}
+
+func (m *Manager) Processes(ctx context.Context) ([]Process, error) {
+	return m.base.Processes(ctx)
+}
+
+func (m *Manager) Mounts(ctx context.Context) ([]Mount, error) {
+	return m.base.Mounts(ctx)
+}
+
+func (m *Manager) BlockDevices(ctx context.Context) ([]BlockDevice, error) {
+	return m.base.BlockDevices(ctx)
+}
+
+func (m *Manager) Modules(ctx context.Context) ([]KernelModule, error) {
+	return m.base.Modules(ctx)
+}
+
+func (m *Manager) LoadModule(ctx context.Context, name string, params ...string) error {
+	return m.base.LoadModule(ctx, name, params...)
+}
+
+func (m *Manager) UnloadModule(ctx context.Context, name string) error {
+	return m.base.UnloadModule(ctx, name)
+}
+
+func (m *Manager) Sysctl(ctx context.Context, key string) (string, error) {
+	return m.base.Sysctl(ctx, key)
+}
+
+func (m *Manager) SetSysctl(ctx context.Context, key, value string) error {
+	return m.base.SetSysctl(ctx, key, value)
+}
+
+func (m *Manager) SysctlConf(ctx context.Context, name string) (map[string]string, error) {
+	return m.base.SysctlConf(ctx, name)
+}
+
+func (m *Manager) PersistSysctl(ctx context.Context, name string, values map[string]string) error {
+	return m.base.PersistSysctl(ctx, name, values)
+}
+
 // Type aliases for public use.
 type (
 	Info                         = system.Info
 	BoardInfo                    = system.BoardInfo
 	WatchdogRequest              = system.WatchdogRequest
+	WatchdogStatus               = system.WatchdogStatus
 	SignalRequest                = system.SignalRequest
 	ValidateFirmwareImageRequest = system.ValidateFirmwareImageRequest
 	SysupgradeRequest            = system.SysupgradeRequest
+	Action                       = system.Action
+	ConfirmFunc                  = system.ConfirmFunc
+	IdlePolicy                   = system.IdlePolicy
+	LEDTrigger                   = system.LEDTrigger
+	LEDConfig                    = system.LEDConfig
+	ButtonConfig                 = system.ButtonConfig
+	SensorKind                   = system.SensorKind
+	Sensor                       = system.Sensor
+	SensorPath                   = system.SensorPath
+	CPUStats                     = system.CPUStats
+	CPUTimes                     = system.CPUTimes
+	CPUUsage                     = system.CPUUsage
+	LoadAverage                  = system.LoadAverage
+	Process                      = system.Process
+	Mount                        = system.Mount
+	BlockDevice                  = system.BlockDevice
+	KernelModule                 = system.KernelModule
+)
+
+// Destructive system actions.
+const (
+	ActionReboot       = system.ActionReboot
+	ActionPowerOff     = system.ActionPowerOff
+	ActionFactoryReset = system.ActionFactoryReset
+	ActionSysupgrade   = system.ActionSysupgrade
+)
+
+// LED triggers configurable in an led section.
+const (
+	LEDTriggerNone      = system.LEDTriggerNone
+	LEDTriggerDefaultOn = system.LEDTriggerDefaultOn
+	LEDTriggerTimer     = system.LEDTriggerTimer
+	LEDTriggerHeartbeat = system.LEDTriggerHeartbeat
+	LEDTriggerNetdev    = system.LEDTriggerNetdev
 )
+
+// Sensor kinds.
+const (
+	SensorTemperature = system.SensorTemperature
+	SensorVoltage     = system.SensorVoltage
+	SensorFan         = system.SensorFan
+)
+
+// ProcessCPU returns the CPU usage of the processes of cur since prev in percent, keyed by PID.
+func ProcessCPU(prev, cur []Process, prevCPU, cpu *CPUStats) map[int]float64 {
+	return system.ProcessCPU(prev, cur, prevCPU, cpu)
+}
+
+// ParseProcStat parses the cpu lines and counters of /proc/stat.
+func ParseProcStat(data string) (*CPUStats, error) {
+	return system.ParseProcStat(data)
+}
+
+// ParseLoadAvg parses /proc/loadavg.
+func ParseLoadAvg(data string) (LoadAverage, error) {
+	return system.ParseLoadAvg(data)
+}
+
+// ParseProcessStat parses /proc/<pid>/stat.
+func ParseProcessStat(data string) (Process, error) {
+	return system.ParseProcessStat(data)
+}
+
+// ParseMounts parses /proc/mounts.
+func ParseMounts(data string) []Mount {
+	return system.ParseMounts(data)
+}
+
+// ParseDF parses the output of "df -Pk" into the usage of each mount point in bytes.
+func ParseDF(out string) map[string]Mount {
+	return system.ParseDF(out)
+}
+
+// ParseBlockInfo parses the output of "block info".
+func ParseBlockInfo(out string) []BlockDevice {
+	return system.ParseBlockInfo(out)
+}
+
+// ParseModules parses /proc/modules.
+func ParseModules(data string) []KernelModule {
+	return system.ParseModules(data)
+}
+
+// ParseSysctlConf parses the "key = value" lines of a sysctl.conf file.
+func ParseSysctlConf(data string) map[string]string {
+	return system.ParseSysctlConf(data)
+}
+
+// FormatSysctlConf formats parameters as sysctl.conf lines, sorted by key.
+func FormatSysctlConf(conf map[string]string) string {
+	return system.FormatSysctlConf(conf)
+}
+
+// UpdateSysctlConf applies values to the content of a sysctl.conf file line by line.
+func UpdateSysctlConf(data string, values map[string]string) string {
+	return system.UpdateSysctlConf(data, values)
+}
@@ -5,6 +5,8 @@ package uci
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/honeybbq/goubus/v2"
 	"github.com/honeybbq/goubus/v2/internal/base/uci"
@@ -23,6 +25,10 @@ func New(t goubus.Transport) *Manager {
 	}
 }
 
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
 func (m *Manager) Package(name string) *PackageContext {
 	return m.base.Package(name)
 }
@@ -31,10 +37,22 @@ func (m *Manager) Configs(ctx context.Context) ([]string, error) {
 	return m.base.Configs(ctx)
 }
 
+func (m *Manager) GetPackages(ctx context.Context, names ...string) (map[string]map[string]*Section, error) {
+	return m.base.GetPackages(ctx, names...)
+}
+
 func (m *Manager) State(ctx context.Context, req StateRequest) (*GetResponse, error) {
 	return m.base.State(ctx, req)
 }
 
+func (m *Manager) Changes(ctx context.Context) (Changes, error) {
+	return m.base.Changes(ctx)
+}
+
+func (m *Manager) Transaction() *Transaction {
+	return m.base.Transaction()
+}
+
 func (m *Manager) Apply(ctx context.Context, rollback bool, timeout int) error {
 	return m.base.Apply(ctx, rollback, timeout)
 }
@@ -47,6 +65,38 @@ func (m *Manager) Rollback(ctx context.Context) error {
 	return m.base.Rollback(ctx)
 }
 
+func (m *Manager) ApplyWithConfirm(
+	ctx context.Context, timeout time.Duration, probe func(ctx context.Context) error,
+) error {
+	return m.base.ApplyWithConfirm(ctx, timeout, probe)
+}
+
+func (m *Manager) Export(ctx context.Context, pkg string, w io.Writer) error {
+	return m.base.Export(ctx, pkg, w)
+}
+
+func (m *Manager) Import(ctx context.Context, pkg string, r io.Reader) error {
+	return m.base.Import(ctx, pkg, r)
+}
+
+func (m *Manager) DiffAgainst(
+	ctx context.Context, other *Manager, names ...string,
+) (map[string][]SectionDiff, error) {
+	return m.base.DiffAgainst(ctx, other.base, names...)
+}
+
+func (m *Manager) SetSchema(pkg, sectionType string, schema Schema) {
+	m.base.SetSchema(pkg, sectionType, schema)
+}
+
+func (m *Manager) Validate(pkg, sectionType string, v ConfigModel) error {
+	return m.base.Validate(pkg, sectionType, v)
+}
+
+func (m *Manager) Watch(ctx context.Context, pkg string, opts ...WatchOption) (*goubus.Subscription, error) {
+	return m.base.Watch(ctx, pkg, opts...)
+}
+
 func (m *Manager) ReloadConfig(ctx context.Context) error {
 	return m.base.ReloadConfig(ctx)
 }
@@ -56,13 +106,146 @@ type (
 	SectionValues   = uci.SectionValues
 	Section         = uci.Section
 	PackageContext  = uci.PackageContext
+	SectionQuery    = uci.SectionQuery
+	SectionRef      = uci.SectionRef
+	LazySections    = uci.LazySections
 	SectionContext  = uci.SectionContext
 	OptionContext   = uci.OptionContext
 	StateRequest    = uci.StateRequest
 	GetResponse     = uci.GetResponse
 	ChangesResponse = uci.ChangesResponse
+	Changes         = uci.Changes
+	Change          = uci.Change
+	ChangeKind      = uci.ChangeKind
+	Transaction     = uci.Transaction
+	Operation       = uci.Operation
+	OperationKind   = uci.OperationKind
+	SectionDiff     = uci.SectionDiff
+	OptionDiff      = uci.OptionDiff
+	DiffKind        = uci.DiffKind
+	Schema          = uci.Schema
+	OptionRule      = uci.OptionRule
+	Violation       = uci.Violation
+	ValidationError = uci.ValidationError
+	ConfigModel     = uci.ConfigModel
+	PackageChange   = uci.PackageChange
+	WatchOption     = uci.WatchOption
+	BoolStyle       = uci.BoolStyle
+)
+
+// Kinds of staged UCI changes.
+const (
+	ChangeSet     = uci.ChangeSet
+	ChangeAdd     = uci.ChangeAdd
+	ChangeRemove  = uci.ChangeRemove
+	ChangeRename  = uci.ChangeRename
+	ChangeOrder   = uci.ChangeOrder
+	ChangeListAdd = uci.ChangeListAdd
+	ChangeListDel = uci.ChangeListDel
+)
+
+// Kinds of transaction operations.
+const (
+	OperationSet     = uci.OperationSet
+	OperationAdd     = uci.OperationAdd
+	OperationDelete  = uci.OperationDelete
+	OperationAddList = uci.OperationAddList
+	OperationDelList = uci.OperationDelList
+)
+
+// Kinds of configuration differences.
+const (
+	DiffAdded   = uci.DiffAdded
+	DiffRemoved = uci.DiffRemoved
+	DiffChanged = uci.DiffChanged
+)
+
+// Effective is the value an option takes and whether it was set explicitly.
+type Effective[T any] = uci.Effective[T]
+
+// DefaultApplyTimeout is the rollback timeout of ApplyWithConfirm when none is given.
+const DefaultApplyTimeout = uci.DefaultApplyTimeout
+
+const (
+	// DefaultWatchInterval is how often Watch reads a package.
+	DefaultWatchInterval = uci.DefaultWatchInterval
+	// ChangeEvent is the type of the events Watch delivers.
+	ChangeEvent = uci.ChangeEvent
+)
+
+// Boolean spellings understood by UCI consumers.
+var (
+	BoolStyleNumeric   = uci.BoolStyleNumeric
+	BoolStyleTrueFalse = uci.BoolStyleTrueFalse
+	BoolStyleYesNo     = uci.BoolStyleYesNo
+	BoolStyleOnOff     = uci.BoolStyleOnOff
+	BoolStyleEnabled   = uci.BoolStyleEnabled
 )
 
 func NewSectionValues() SectionValues {
 	return uci.NewSectionValues()
 }
+
+func DetectBoolStyle(value string) (BoolStyle, bool) {
+	return uci.DetectBoolStyle(value)
+}
+
+// WriteConfig writes sections in the UCI file format, preceded by a "package" line unless pkg is empty.
+func WriteConfig(w io.Writer, pkg string, sections []*Section) error {
+	return uci.WriteConfig(w, pkg, sections)
+}
+
+// ParseConfig parses a package in the UCI file format into its name and sections.
+func ParseConfig(r io.Reader) (string, []*Section, error) {
+	return uci.ParseConfig(r)
+}
+
+// Diff returns the differences that turn package a into b.
+func Diff(a, b map[string]*Section) []SectionDiff {
+	return uci.Diff(a, b)
+}
+
+// SectionsByRef keys sections by name, or by their "@type[index]" reference if they are anonymous.
+func SectionsByRef(sections []*Section) map[string]*Section {
+	return uci.SectionsByRef(sections)
+}
+
+// SectionValuesFromStruct encodes the `uci:"option"` tagged fields of a struct, the reverse of Section.As.
+func SectionValuesFromStruct(v any) (SectionValues, error) {
+	return uci.SectionValuesFromStruct(v)
+}
+
+// ApplyDefaults sets the nil pointer fields of a struct from their `default:"..."` tags.
+func ApplyDefaults(v any) error {
+	return uci.ApplyDefaults(v)
+}
+
+// EffectiveValues reports the effective value of each optional field of a struct, keyed by option.
+func EffectiveValues(v any) (map[string]Effective[string], error) {
+	return uci.EffectiveValues(v)
+}
+
+// EffectiveValue returns *value when it is set and def otherwise.
+func EffectiveValue[T any](value *T, def T) Effective[T] {
+	return uci.EffectiveValue(value, def)
+}
+
+// WithWatchInterval sets how often Watch reads the package.
+func WithWatchInterval(interval time.Duration) WatchOption {
+	return uci.WithWatchInterval(interval)
+}
+
+// ParsePackageChange decodes an event delivered by Watch. It returns false for other events.
+func ParsePackageChange(ev goubus.Event) (PackageChange, bool) {
+	return uci.ParsePackageChange(ev)
+}
+
+// TypedRef returns the "@type[index]" reference of the section of sectionType at index.
+func TypedRef(sectionType string, index int) string {
+	return uci.TypedRef(sectionType, index)
+}
+
+// ParseChanges parses the output of "uci changes" into changes keyed by package.
+func ParseChanges(output string) (Changes, error) {
+	return uci.ParseChanges(output)
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package uhttpd
+
+import (
+	"context"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/internal/base/uhttpd"
+)
+
+// Manager handles uhttpd operations for standard x86/generic OpenWrt.
+type Manager struct {
+	base *uhttpd.Manager
+}
+
+func New(t goubus.Transport) *Manager {
+	return &Manager{
+		base: uhttpd.New(t),
+	}
+}
+
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
+func (m *Manager) Configs(ctx context.Context) ([]Config, error) {
+	return m.base.Configs(ctx)
+}
+
+func (m *Manager) Config(ctx context.Context, name string) (*Config, error) {
+	return m.base.Config(ctx, name)
+}
+
+func (m *Manager) SetConfig(ctx context.Context, cfg Config) error {
+	return m.base.SetConfig(ctx, cfg)
+}
+
+func (m *Manager) CertDefaults(ctx context.Context) ([]CertDefaults, error) {
+	return m.base.CertDefaults(ctx)
+}
+
+func (m *Manager) InstallCertificate(ctx context.Context, name, certPEM, keyPEM string) error {
+	return m.base.InstallCertificate(ctx, name, certPEM, keyPEM)
+}
+
+func (m *Manager) Restart(ctx context.Context) error {
+	return m.base.Restart(ctx)
+}
+
+// Type aliases for public use.
+type (
+	Config       = uhttpd.Config
+	CertDefaults = uhttpd.CertDefaults
+)
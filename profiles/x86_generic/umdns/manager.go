@@ -0,0 +1,49 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package umdns
+
+import (
+	"context"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/internal/base/umdns"
+)
+
+// Manager handles mDNS discovery for standard x86/generic OpenWrt.
+type Manager struct {
+	base *umdns.Manager
+}
+
+func New(t goubus.Transport) *Manager {
+	return &Manager{
+		base: umdns.New(t),
+	}
+}
+
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
+func (m *Manager) Update(ctx context.Context) error {
+	return m.base.Update(ctx)
+}
+
+func (m *Manager) Browse(ctx context.Context, serviceType string) ([]Service, error) {
+	return m.base.Browse(ctx, serviceType)
+}
+
+func (m *Manager) Hosts(ctx context.Context) ([]Host, error) {
+	return m.base.Hosts(ctx)
+}
+
+func (m *Manager) Announcements(ctx context.Context) ([]Announcement, error) {
+	return m.base.Announcements(ctx)
+}
+
+// Type aliases for public use.
+type (
+	Service      = umdns.Service
+	Host         = umdns.Host
+	Announcement = umdns.Announcement
+)
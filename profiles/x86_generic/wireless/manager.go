@@ -5,8 +5,10 @@ package wireless
 
 import (
 	"context"
+	"time"
 
 	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/internal/base/hostapd"
 	"github.com/honeybbq/goubus/v2/internal/base/wireless"
 )
 
@@ -21,6 +23,10 @@ func New(t goubus.Transport) *Manager {
 	}
 }
 
+func (m *Manager) RequiredObjects() []string {
+	return m.base.RequiredObjects()
+}
+
 func (m *Manager) Devices(ctx context.Context) ([]string, error) {
 	return m.base.Devices(ctx)
 }
@@ -29,6 +35,10 @@ func (m *Manager) AssocList(ctx context.Context, device string) ([]Assoc, error)
 	return m.base.AssocList(ctx, device)
 }
 
+func (m *Manager) Stations(ctx context.Context, device string) ([]Station, error) {
+	return m.base.Stations(ctx, device)
+}
+
 func (m *Manager) Info(ctx context.Context, device string) (*Info, error) {
 	return m.base.Info(ctx, device)
 }
@@ -41,6 +51,14 @@ func (m *Manager) FreqList(ctx context.Context, device string) ([]any, error) {
 	return m.base.FreqList(ctx, device)
 }
 
+func (m *Manager) Frequencies(ctx context.Context, device string) ([]Frequency, error) {
+	return m.base.Frequencies(ctx, device)
+}
+
+func (m *Manager) SwitchChannel(ctx context.Context, device string, req ChannelSwitch) error {
+	return m.base.SwitchChannel(ctx, device, req)
+}
+
 func (m *Manager) TxPowerList(ctx context.Context, device string) ([]any, error) {
 	return m.base.TxPowerList(ctx, device)
 }
@@ -53,14 +71,187 @@ func (m *Manager) Survey(ctx context.Context, device string) ([]any, error) {
 	return m.base.Survey(ctx, device)
 }
 
+func (m *Manager) ChannelSurvey(ctx context.Context, device string) ([]SurveyEntry, error) {
+	return m.base.ChannelSurvey(ctx, device)
+}
+
+func (m *Manager) PlanChannels(ctx context.Context, device string, opts ChannelPlanOptions) ([]ChannelScore, error) {
+	return m.base.PlanChannels(ctx, device, opts)
+}
+
+func (m *Manager) ApplyBestChannel(
+	ctx context.Context, device, radio string, opts ChannelPlanOptions,
+) (*ChannelScore, error) {
+	return m.base.ApplyBestChannel(ctx, device, radio, opts)
+}
+
+func (m *Manager) SetChannel(ctx context.Context, radio string, channel int) error {
+	return m.base.SetChannel(ctx, radio, channel)
+}
+
+func (m *Manager) StartScanner(ctx context.Context, devices []string, opts ...ScannerOption) (*Scanner, error) {
+	return m.base.StartScanner(ctx, devices, opts...)
+}
+
+func (m *Manager) WatchStations(
+	ctx context.Context, devices []string, opts ...StationWatchOption,
+) (*goubus.Subscription, error) {
+	return m.base.WatchStations(ctx, devices, opts...)
+}
+
 func (m *Manager) PhyName(ctx context.Context, section string) (string, error) {
 	return m.base.PhyName(ctx, section)
 }
 
+func (m *Manager) SetSSID(ctx context.Context, target, ssid string) error {
+	return m.base.SetSSID(ctx, target, ssid)
+}
+
+func (m *Manager) SetKey(ctx context.Context, target, key string) error {
+	return m.base.SetKey(ctx, target, key)
+}
+
+func (m *Manager) SetWPS(ctx context.Context, target string, cfg WPSConfig) error {
+	return m.base.SetWPS(ctx, target, cfg)
+}
+
+func (m *Manager) EnableRadio(ctx context.Context, radio string) error {
+	return m.base.EnableRadio(ctx, radio)
+}
+
+func (m *Manager) DisableRadio(ctx context.Context, radio string) error {
+	return m.base.DisableRadio(ctx, radio)
+}
+
+func (m *Manager) Apply(ctx context.Context) error {
+	return m.base.Apply(ctx)
+}
+
 // Type aliases for public use.
 type (
 	Info       = wireless.Info
 	ScanResult = wireless.ScanResult
 	Assoc      = wireless.Assoc
 	AssocRate  = wireless.AssocRate
+	Station    = wireless.Station
+	Features   = hostapd.Features
+	Frequency  = wireless.Frequency
+
+	RateKey       = wireless.RateKey
+	RateUsage     = wireless.RateUsage
+	RateBreakdown = wireless.RateBreakdown
+	RateTracker   = wireless.RateTracker
+
+	ChannelSwitch     = wireless.ChannelSwitch
+	SwitchChanRequest = hostapd.SwitchChanRequest
+	WPSConfig         = wireless.WPSConfig
+
+	SurveyEntry        = wireless.SurveyEntry
+	ChannelPlanOptions = wireless.ChannelPlanOptions
+	ChannelScore       = wireless.ChannelScore
+
+	Scanner       = wireless.Scanner
+	ScannerOption = wireless.ScannerOption
+	BSS           = wireless.BSS
+	RSSISample    = wireless.RSSISample
+
+	StationWatchOption = wireless.StationWatchOption
+	StationEvent       = wireless.StationEvent
+)
+
+// PHY modes of a RateKey.
+const (
+	ModeLegacy = wireless.ModeLegacy
+	ModeHT     = wireless.ModeHT
+	ModeVHT    = wireless.ModeVHT
+	ModeHE     = wireless.ModeHE
+	ModeEHT    = wireless.ModeEHT
+)
+
+func NewRateTracker() *RateTracker {
+	return wireless.NewRateTracker()
+}
+
+// ScoreChannels scores the frequencies that allow the operation opts describes, best channel first.
+func ScoreChannels(
+	freqs []Frequency, survey []SurveyEntry, networks []ScanResult, opts ChannelPlanOptions,
+) []ChannelScore {
+	return wireless.ScoreChannels(freqs, survey, networks, opts)
+}
+
+const (
+	// DefaultScanInterval is how often a Scanner scans each interface.
+	DefaultScanInterval = wireless.DefaultScanInterval
+	// DefaultRSSIHistory is how many signal samples a Scanner keeps for each BSS.
+	DefaultRSSIHistory = wireless.DefaultRSSIHistory
+	// DefaultSignalChange is the signal change in dB that makes a Scanner report a BSS again.
+	DefaultSignalChange = wireless.DefaultSignalChange
+)
+
+// Types of the events a Scanner delivers.
+const (
+	BSSAddedEvent   = wireless.BSSAddedEvent
+	BSSUpdatedEvent = wireless.BSSUpdatedEvent
+	BSSRemovedEvent = wireless.BSSRemovedEvent
+)
+
+// WithScanInterval sets how often each interface is scanned.
+func WithScanInterval(interval time.Duration) ScannerOption {
+	return wireless.WithScanInterval(interval)
+}
+
+// WithBSSExpiry sets how long a BSS may go unseen before it is removed.
+func WithBSSExpiry(expiry time.Duration) ScannerOption {
+	return wireless.WithBSSExpiry(expiry)
+}
+
+// WithRSSIHistory sets how many signal samples are kept for each BSS.
+func WithRSSIHistory(samples int) ScannerOption {
+	return wireless.WithRSSIHistory(samples)
+}
+
+// WithSignalChange sets the signal change in dB that makes the scanner report a BSS again.
+func WithSignalChange(db int) ScannerOption {
+	return wireless.WithSignalChange(db)
+}
+
+// ParseBSSEvent decodes an event delivered by a Scanner. It returns false for other events.
+func ParseBSSEvent(ev goubus.Event) (BSS, bool) {
+	return wireless.ParseBSSEvent(ev)
+}
+
+const (
+	// DefaultStationInterval is how often WatchStations reads the association lists.
+	DefaultStationInterval = wireless.DefaultStationInterval
+	// DefaultLeaveDelay is how long a station must stay away before WatchStations reports it left.
+	DefaultLeaveDelay = wireless.DefaultLeaveDelay
+	// DefaultSignalThreshold is the signal change in dB WatchStations reports.
+	DefaultSignalThreshold = wireless.DefaultSignalThreshold
+)
+
+// Types of the events WatchStations delivers.
+const (
+	StationJoinEvent   = wireless.StationJoinEvent
+	StationLeaveEvent  = wireless.StationLeaveEvent
+	StationSignalEvent = wireless.StationSignalEvent
 )
+
+// WithStationInterval sets how often the association lists are read.
+func WithStationInterval(interval time.Duration) StationWatchOption {
+	return wireless.WithStationInterval(interval)
+}
+
+// WithLeaveDelay sets how long a station must stay away before it is reported to have left.
+func WithLeaveDelay(delay time.Duration) StationWatchOption {
+	return wireless.WithLeaveDelay(delay)
+}
+
+// WithSignalThreshold sets the signal change in dB that is reported.
+func WithSignalThreshold(db int) StationWatchOption {
+	return wireless.WithSignalThreshold(db)
+}
+
+// ParseStationEvent decodes an event delivered by WatchStations. It returns false for other events.
+func ParseStationEvent(ev goubus.Event) (StationEvent, bool) {
+	return wireless.ParseStationEvent(ev)
+}
@@ -6,11 +6,13 @@ package goubus
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"strings"
 	"sync"
 	"time"
@@ -31,6 +33,12 @@ const (
 const (
 	jsonRPCVersion    = "2.0"
 	jsonRPCMethodCall = "call"
+	jsonRPCMethodList = "list"
+
+	// jsonRPCObjectNotFound is the error code uhttpd-mod-ubus returns when the called object is not registered.
+	jsonRPCObjectNotFound = -32000
+	// jsonRPCAccessDenied is the error code uhttpd-mod-ubus returns when the session ACLs reject a call.
+	jsonRPCAccessDenied = -32002
 )
 
 const (
@@ -41,14 +49,24 @@ const (
 // RpcClient handles communication with the ubus JSON-RPC endpoint.
 // It manages authentication and session state internally.
 type RpcClient struct {
-	logger      *slog.Logger
-	host        string
-	username    string
-	password    string
-	sessionData rpc.SessionData
-	id          int
-	rwMutex     sync.RWMutex
-	closed      bool
+	logger       *slog.Logger
+	httpClient   *http.Client
+	tlsConfig    *tls.Config
+	optionErr    error
+	proxy        *url.URL
+	headers      http.Header
+	customClient *http.Client
+	host         string
+	baseURL      string
+	username     string
+	password     string
+	sessionData  rpc.SessionData
+	interceptors []CallInterceptor
+	metrics      callMetrics
+	registered   registeredObjects
+	id           int
+	rwMutex      sync.RWMutex
+	closed       bool
 }
 
 var _ Transport = (*RpcClient)(nil)
@@ -63,7 +81,31 @@ func WithRpcLogger(logger *slog.Logger) RpcOption {
 	}
 }
 
-// NewRpcClient creates an authenticated RPC client.
+// WithRpcMetrics sets a hook that receives the phase timing of every call.
+func WithRpcMetrics(hook MetricsHook) RpcOption {
+	return func(rc *RpcClient) {
+		rc.metrics.hook = hook
+	}
+}
+
+// WithRpcErrorTiming attaches the phase timing to the errors of failed calls as a *TimingError.
+func WithRpcErrorTiming() RpcOption {
+	return func(rc *RpcClient) {
+		rc.metrics.errorTiming = true
+	}
+}
+
+// WithRpcInterceptors adds interceptors that run around every Call, the first one outermost.
+// CallBatch then sends its calls one at a time, so that batched calls are intercepted too.
+func WithRpcInterceptors(interceptors ...CallInterceptor) RpcOption {
+	return func(rc *RpcClient) {
+		rc.interceptors = append(rc.interceptors, interceptors...)
+	}
+}
+
+// NewRpcClient creates an authenticated RPC client. host is "address[:port]", optionally prefixed
+// with "http://" or "https://"; without a prefix the client uses https if a TLS option is given
+// and plain http otherwise.
 func NewRpcClient(ctx context.Context, host, username, password string, opts ...RpcOption) (*RpcClient, error) {
 	client := &RpcClient{
 		host:     host,
@@ -77,8 +119,13 @@ func NewRpcClient(ctx context.Context, host, username, password string, opts ...
 		opt(client)
 	}
 
+	err := client.configureHTTP()
+	if err != nil {
+		return nil, err
+	}
+
 	// Perform initial authentication
-	err := client.authenticate(ctx)
+	err = client.authenticate(ctx)
 	if err != nil {
 		return nil, errdefs.Wrapf(err, "failed to authenticate")
 	}
@@ -97,17 +144,36 @@ func (rc *RpcClient) SetLogger(logger *slog.Logger) {
 
 // Call performs a JSON-RPC call with automatic session management.
 func (rc *RpcClient) Call(ctx context.Context, service, method string, data any) (Result, error) {
+	return chainInterceptors(rc.interceptors, rc.call)(ctx, service, method, data)
+}
+
+// call performs a call without the interceptors.
+func (rc *RpcClient) call(ctx context.Context, service, method string, data any) (Result, error) {
 	if rc.closed {
 		return nil, errdefs.ErrClosed
 	}
 
+	timing := CallTiming{Service: service, Method: method}
+	start := time.Now()
+
 	// Get current session ID, re-authenticate if needed
 	sessionID, err := rc.getValidSessionID(ctx)
+	lap(&timing.Queue, start)
+
 	if err != nil {
-		return nil, err
+		return nil, rc.metrics.finish(ctx, &timing, err)
+	}
+
+	res, err := rc.timedCall(ctx, &timing, sessionID, service, method, data)
+	if errdefs.IsPermissionDenied(err) || isAccessDeniedResult(res) {
+		return nil, rc.metrics.finish(ctx, &timing, rc.accessDenied(ctx, sessionID, service, method, data))
 	}
 
-	return rc.rawCall(ctx, sessionID, service, method, data)
+	if errdefs.IsNotFound(err) {
+		err = rc.objectNotFound(ctx, service, err)
+	}
+
+	return res, rc.metrics.finish(ctx, &timing, err)
 }
 
 func (rc *RpcClient) Close() error {
@@ -125,6 +191,22 @@ func (rc *RpcClient) Close() error {
 	return nil
 }
 
+// objectNotFound checks with a lookup whether service is registered after a call to it failed with
+// ErrNotFound, and returns a *errdefs.CapabilityError if it is not. Services found registered are
+// not looked up again.
+func (rc *RpcClient) objectNotFound(ctx context.Context, service string, err error) error {
+	missing := rc.registered.missing(service, func() error {
+		_, lookupErr := Lookup(ctx, rc, service)
+
+		return lookupErr
+	})
+	if missing {
+		return missingObject(service)
+	}
+
+	return err
+}
+
 // getValidSessionID returns a valid session ID.
 func (rc *RpcClient) getValidSessionID(ctx context.Context) (string, error) {
 	rc.rwMutex.RLock()
@@ -189,7 +271,16 @@ func (rc *RpcClient) authenticate(ctx context.Context) error {
 
 // rawCall performs the actual JSON-RPC call without session management.
 func (rc *RpcClient) rawCall(ctx context.Context, sessionID, service, method string, data any) (Result, error) {
+	return rc.timedCall(ctx, &CallTiming{}, sessionID, service, method, data)
+}
+
+// timedCall performs a JSON-RPC call without session management, adding its phases to timing.
+func (rc *RpcClient) timedCall(
+	ctx context.Context, timing *CallTiming, sessionID, service, method string, data any,
+) (Result, error) {
+	start := time.Now()
 	requestBody := rc.prepareRequestBody(sessionID, service, method, data)
+	start = lap(&timing.Encode, start)
 
 	rc.logger.Debug("Request",
 		slog.Int("id", rc.id),
@@ -197,10 +288,25 @@ func (rc *RpcClient) rawCall(ctx context.Context, sessionID, service, method str
 		slog.String("method", method),
 		slog.String("body", requestBody))
 
+	bodyBytes, err := rc.post(ctx, requestBody)
+	start = lap(&timing.Network, start)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := rc.parseUbusResponse(bodyBytes)
+	lap(&timing.Decode, start)
+
+	return res, err
+}
+
+// post sends a JSON-RPC request body to the ubus endpoint and returns the response body.
+func (rc *RpcClient) post(ctx context.Context, requestBody string) ([]byte, error) {
 	req, err := http.NewRequestWithContext(
 		ctx,
 		http.MethodPost,
-		"http://"+rc.host+ubusEndpointPath,
+		rc.baseURL+ubusEndpointPath,
 		bytes.NewBufferString(requestBody),
 	)
 	if err != nil {
@@ -209,9 +315,9 @@ func (rc *RpcClient) rawCall(ctx context.Context, sessionID, service, method str
 
 	req.Header.Set("Content-Type", contentTypeJSON)
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := rc.do(req)
 	if err != nil {
-		return nil, errdefs.Wrapf(errdefs.ErrConnectionFailed, "http post error: %v", err)
+		return nil, errdefs.Wrapf(errdefs.FromTransport(err), "http post error")
 	}
 
 	defer func() {
@@ -227,28 +333,11 @@ func (rc *RpcClient) rawCall(ctx context.Context, sessionID, service, method str
 		slog.String("status", resp.Status),
 		slog.String("body", previewText(bodyBytes, logBodyLimit)))
 
-	return rc.parseUbusResponse(bodyBytes)
+	return bodyBytes, nil
 }
 
 func (rc *RpcClient) prepareRequestBody(sessionID, service, method string, data any) string {
-	var dataJSON string
-	if data == nil {
-		dataJSON = "{}"
-	} else {
-		switch v := data.(type) {
-		case string:
-			dataJSON = v
-		case []byte:
-			dataJSON = string(v)
-		default:
-			jsonBytes, err := json.Marshal(data)
-			if err != nil {
-				dataJSON = "{}"
-			} else {
-				dataJSON = string(jsonBytes)
-			}
-		}
-	}
+	dataJSON := encodeRequestData(data)
 
 	return fmt.Sprintf(`{
 		"jsonrpc": "%s",
@@ -271,6 +360,24 @@ func (rc *RpcClient) prepareRequestBody(sessionID, service, method string, data
 	)
 }
 
+func encodeRequestData(data any) string {
+	switch v := data.(type) {
+	case nil:
+		return "{}"
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		jsonBytes, err := json.Marshal(data)
+		if err != nil {
+			return "{}"
+		}
+
+		return string(jsonBytes)
+	}
+}
+
 func (rc *RpcClient) parseUbusResponse(body []byte) (Result, error) {
 	ubusResp := &rpc.UbusResponse{}
 
@@ -279,9 +386,20 @@ func (rc *RpcClient) parseUbusResponse(body []byte) (Result, error) {
 		return nil, errdefs.Wrapf(errdefs.ErrInvalidResponse, "json decode error: %v", err)
 	}
 
+	return resultFromResponse(ubusResp)
+}
+
+func resultFromResponse(ubusResp *rpc.UbusResponse) (Result, error) {
 	if ubusResp.Error != nil {
 		mappedErr := MapUbusCodeToError(ubusResp.Error.Code)
 
+		switch ubusResp.Error.Code {
+		case jsonRPCAccessDenied:
+			mappedErr = errdefs.ErrPermissionDenied
+		case jsonRPCObjectNotFound:
+			mappedErr = errdefs.ErrNotFound
+		}
+
 		return nil, errdefs.Wrapf(mappedErr, "json-rpc error: %s", ubusResp.Error.Message)
 	}
 
@@ -0,0 +1,150 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package goubus
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/rpc"
+)
+
+var _ Batcher = (*RpcClient)(nil)
+
+type rpcBatchRequest struct {
+	Jsonrpc string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  []any  `json:"params"`
+	ID      int    `json:"id"`
+}
+
+// Batch creates an empty batch whose calls are sent as one JSON-RPC batch request.
+func (rc *RpcClient) Batch() *Batch {
+	return NewBatch(rc)
+}
+
+// CallBatch sends calls as a single JSON-RPC batch array and matches the replies to their calls by id.
+// Every call reports the phases of the batch to the metrics hook. A client with interceptors
+// sends the calls one at a time instead, so that every call passes through them.
+func (rc *RpcClient) CallBatch(ctx context.Context, calls []BatchCall) ([]BatchResult, error) {
+	if len(rc.interceptors) > 0 {
+		return callEach(ctx, rc, calls)
+	}
+
+	if rc.closed {
+		return nil, errdefs.ErrClosed
+	}
+
+	var timing CallTiming
+
+	start := time.Now()
+
+	sessionID, err := rc.getValidSessionID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	start = lap(&timing.Queue, start)
+
+	requestBody, err := encodeBatch(sessionID, calls)
+	if err != nil {
+		return nil, err
+	}
+
+	start = lap(&timing.Encode, start)
+
+	rc.logger.Debug("Batch request", slog.Int("calls", len(calls)), slog.String("body", string(requestBody)))
+
+	body, err := rc.post(ctx, string(requestBody))
+	if err != nil {
+		return nil, err
+	}
+
+	start = lap(&timing.Network, start)
+
+	var responses []rpc.UbusResponse
+
+	err = json.Unmarshal(body, &responses)
+	if err != nil {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidResponse, "json decode error: %v", err)
+	}
+
+	lap(&timing.Decode, start)
+
+	results := rc.batchResults(ctx, sessionID, calls, responses)
+
+	timings := make([]CallTiming, len(calls))
+	for i, call := range calls {
+		timings[i] = timing
+		timings[i].Service, timings[i].Method = call.Service, call.Method
+	}
+
+	rc.metrics.finishBatch(ctx, timings, results)
+
+	return results, nil
+}
+
+// batchResults matches the replies of a batch to its calls.
+func (rc *RpcClient) batchResults(
+	ctx context.Context, sessionID string, calls []BatchCall, responses []rpc.UbusResponse,
+) []BatchResult {
+	results := make([]BatchResult, len(calls))
+	for i := range results {
+		results[i].Err = errdefs.Wrapf(errdefs.ErrInvalidResponse, "no reply for batch call %d", i)
+	}
+
+	for i := range responses {
+		index := responses[i].ID - 1
+		if index < 0 || index >= len(calls) {
+			continue
+		}
+
+		call := calls[index]
+
+		res, err := resultFromResponse(&responses[i])
+		if errdefs.IsPermissionDenied(err) || isAccessDeniedResult(res) {
+			res, err = nil, rc.accessDenied(ctx, sessionID, call.Service, call.Method, call.Data)
+		} else if err == nil {
+			err = statusError(res)
+		}
+
+		results[index] = BatchResult{Result: res, Err: err}
+	}
+
+	return results
+}
+
+func encodeBatch(sessionID string, calls []BatchCall) ([]byte, error) {
+	requests := make([]rpcBatchRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = rpcBatchRequest{
+			Jsonrpc: jsonRPCVersion,
+			ID:      i + 1,
+			Method:  jsonRPCMethodCall,
+			Params:  []any{sessionID, call.Service, call.Method, json.RawMessage(encodeRequestData(call.Data))},
+		}
+	}
+
+	requestBody, err := json.Marshal(requests)
+	if err != nil {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "encode batch: %v", err)
+	}
+
+	return requestBody, nil
+}
+
+// statusError maps the ubus status code carried by a JSON-RPC reply to an error.
+func statusError(res Result) error {
+	result, ok := res.(rpcResult)
+	if !ok || len(result) == 0 {
+		return nil
+	}
+
+	code, _ := result[0].(float64)
+
+	return MapUbusCodeToError(int(code))
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package goubus
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+const (
+	schemeHTTP  = "http"
+	schemeHTTPS = "https"
+)
+
+// WithHTTPClient sends the requests through client, e.g. to share its connection pool with the
+// host application. The TLS and proxy options do not apply to it; configure its Transport
+// instead, and give the host an "https://" prefix to use HTTPS.
+func WithHTTPClient(client *http.Client) RpcOption {
+	return func(rc *RpcClient) {
+		rc.customClient = client
+	}
+}
+
+// WithHeader adds a header to every request, e.g. the credentials of a reverse proxy in front of
+// uhttpd. It does not replace the Content-Type and Authorization headers the client sets itself.
+func WithHeader(key, value string) RpcOption {
+	return func(rc *RpcClient) {
+		if rc.headers == nil {
+			rc.headers = make(http.Header)
+		}
+
+		rc.headers.Add(key, value)
+	}
+}
+
+// WithProxy sends the requests through the HTTP, HTTPS or SOCKS5 proxy at proxyURL, e.g.
+// "http://bastion:3128" or "socks5://localhost:1080". Without it the proxy of the
+// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables is used.
+func WithProxy(proxyURL string) RpcOption {
+	return func(rc *RpcClient) {
+		proxy, err := url.Parse(proxyURL)
+		if err != nil || proxy.Host == "" {
+			rc.optionErr = errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid proxy URL %q", proxyURL)
+
+			return
+		}
+
+		rc.proxy = proxy
+	}
+}
+
+// configureHTTP derives the base URL from the host and builds the HTTP client. A host with an
+// "http://" or "https://" prefix uses that scheme; without one it uses https if a TLS option was
+// given and plain http otherwise.
+func (rc *RpcClient) configureHTTP() error {
+	if rc.optionErr != nil {
+		return rc.optionErr
+	}
+
+	scheme := schemeHTTP
+	if rc.tlsConfig != nil {
+		scheme = schemeHTTPS
+	}
+
+	host := rc.host
+
+	prefix, rest, found := strings.Cut(host, "://")
+	if found {
+		scheme, host = strings.ToLower(prefix), rest
+	}
+
+	if scheme != schemeHTTP && scheme != schemeHTTPS {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "unsupported scheme %q, use http or https", scheme)
+	}
+
+	rc.baseURL = scheme + "://" + strings.TrimSuffix(host, "/")
+	rc.httpClient = rc.newHTTPClient()
+
+	return nil
+}
+
+// newHTTPClient returns the custom client, or a client with its own transport if the TLS or proxy
+// options need one, or the default client.
+func (rc *RpcClient) newHTTPClient() *http.Client {
+	if rc.customClient != nil {
+		return rc.customClient
+	}
+
+	if rc.tlsConfig == nil && rc.proxy == nil {
+		return http.DefaultClient
+	}
+
+	transport, _ := http.DefaultTransport.(*http.Transport)
+	transport = transport.Clone()
+
+	if rc.tlsConfig != nil {
+		transport.TLSClientConfig = rc.tlsConfig
+	}
+
+	if rc.proxy != nil {
+		transport.Proxy = http.ProxyURL(rc.proxy)
+	}
+
+	return &http.Client{Transport: transport}
+}
+
+// do adds the custom headers to req, keeping the headers already set, and sends it.
+func (rc *RpcClient) do(req *http.Request) (*http.Response, error) {
+	for key, values := range rc.headers {
+		if req.Header.Get(key) == "" {
+			req.Header[key] = values
+		}
+	}
+
+	return rc.httpClient.Do(req)
+}
@@ -0,0 +1,82 @@
+package goubus_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+type countingRoundTripper struct {
+	calls atomic.Int32
+}
+
+func (c *countingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.calls.Add(1)
+
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestRpcClient_HTTPOptions(t *testing.T) {
+	var lastRequest atomic.Pointer[http.Request]
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastRequest.Store(r)
+
+		_, _ = fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":[0,`+
+			`{"ubus_rpc_session":"12345678901234567890123456789012","timeout":3600}]}`)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	t.Run("Header", func(t *testing.T) {
+		_, err := goubus.NewRpcClient(ctx, host, "user", "pass",
+			goubus.WithHeader("X-Proxy-Token", "secret"), goubus.WithHeader("Content-Type", "text/plain"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req := lastRequest.Load()
+		if req.Header.Get("X-Proxy-Token") != "secret" || req.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected headers: %v", req.Header)
+		}
+	})
+
+	t.Run("HTTPClient", func(t *testing.T) {
+		rt := &countingRoundTripper{}
+
+		_, err := goubus.NewRpcClient(ctx, host, "user", "pass", goubus.WithHTTPClient(&http.Client{Transport: rt}))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if rt.calls.Load() != 1 {
+			t.Errorf("expected the login to use the custom client, got %d requests", rt.calls.Load())
+		}
+	})
+
+	t.Run("Proxy", func(t *testing.T) {
+		_, err := goubus.NewRpcClient(ctx, "router.lan", "user", "pass", goubus.WithProxy(server.URL))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req := lastRequest.Load()
+		if req.Host != "router.lan" || req.URL.Path != "/ubus" {
+			t.Errorf("expected a proxied request for router.lan, got %s %s", req.Host, req.URL)
+		}
+
+		_, err = goubus.NewRpcClient(ctx, host, "user", "pass", goubus.WithProxy("::bad"))
+		if !errdefs.IsInvalidParameter(err) {
+			t.Errorf("expected invalid parameter, got %v", err)
+		}
+	})
+}
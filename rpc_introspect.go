@@ -0,0 +1,75 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package goubus
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/rpc"
+)
+
+// Objects lists the registered ubus objects matching pattern together with their method signatures,
+// using the JSON-RPC "list" method. It does not need a session, but only objects visible to uhttpd are listed.
+func (rc *RpcClient) Objects(ctx context.Context, pattern string) ([]ObjectInfo, error) {
+	if rc.closed {
+		return nil, errdefs.ErrClosed
+	}
+
+	if pattern == "" {
+		pattern = "*"
+	}
+
+	params, err := json.Marshal([]string{pattern})
+	if err != nil {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "encode pattern: %v", err)
+	}
+
+	requestBody := `{"jsonrpc":"` + jsonRPCVersion + `","id":1,"method":"` + jsonRPCMethodList +
+		`","params":` + string(params) + `}`
+
+	rc.logger.Debug("Request", slog.String("method", jsonRPCMethodList), slog.String("body", requestBody))
+
+	body, err := rc.post(ctx, requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseListResponse(body)
+}
+
+// Lookup describes a single registered ubus object.
+func (rc *RpcClient) Lookup(ctx context.Context, object string) (*ObjectInfo, error) {
+	return Lookup(ctx, rc, object)
+}
+
+func parseListResponse(body []byte) ([]ObjectInfo, error) {
+	var resp struct {
+		Result map[string]map[string]map[string]string `json:"result"`
+		Error  *rpc.UbusJsonRpcError                   `json:"error"`
+	}
+
+	err := json.Unmarshal(body, &resp)
+	if err != nil {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidResponse, "json decode error: %v", err)
+	}
+
+	if resp.Error != nil {
+		return nil, errdefs.Wrapf(MapUbusCodeToError(resp.Error.Code), "json-rpc error: %s", resp.Error.Message)
+	}
+
+	objects := make([]ObjectInfo, 0, len(resp.Result))
+
+	for path, methods := range resp.Result {
+		if methods == nil {
+			methods = map[string]map[string]string{}
+		}
+
+		objects = append(objects, ObjectInfo{Path: path, Methods: methods})
+	}
+
+	return sortObjects(objects), nil
+}
@@ -0,0 +1,249 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package goubus
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"sync"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/logging"
+)
+
+// DefaultRpcPoolSize is the number of sessions of an RpcPool created with a size of 0.
+const DefaultRpcPoolSize = 4
+
+// RpcPool keeps up to size authenticated RpcClient sessions to one router and checks one out
+// for every call, so at most size calls run against the router's uhttpd at a time and sessions
+// are reused instead of logging in per request. The sessions share one HTTP connection pool.
+// Sessions beyond the first are created on demand.
+type RpcPool struct {
+	logger   *slog.Logger
+	slots    chan *RpcClient
+	first    *RpcClient
+	host     string
+	username string
+	password string
+	opts     []RpcOption
+	clients  []*RpcClient
+	mu       sync.Mutex
+	closed   bool
+}
+
+var (
+	_ Transport    = (*RpcPool)(nil)
+	_ Batcher      = (*RpcPool)(nil)
+	_ Subscriber   = (*RpcPool)(nil)
+	_ Introspector = (*RpcPool)(nil)
+)
+
+// NewRpcPool creates a pool of size sessions to host and logs in the first one, so wrong
+// credentials are reported here. opts apply to every session.
+func NewRpcPool(ctx context.Context, host, username, password string, size int, opts ...RpcOption) (*RpcPool, error) {
+	if size <= 0 {
+		size = DefaultRpcPoolSize
+	}
+
+	first, err := NewRpcClient(ctx, host, username, password, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := &RpcPool{
+		logger:   first.logger,
+		slots:    make(chan *RpcClient, size),
+		first:    first,
+		host:     host,
+		username: username,
+		password: password,
+		opts:     slices.Concat(opts, []RpcOption{WithHTTPClient(first.httpClient)}),
+		clients:  []*RpcClient{first},
+	}
+
+	pool.slots <- first
+	for range size - 1 {
+		pool.slots <- nil
+	}
+
+	return pool, nil
+}
+
+// Size returns the maximum number of sessions and concurrent calls.
+func (p *RpcPool) Size() int {
+	return cap(p.slots)
+}
+
+// SetLogger sets the logger of the pool and its sessions.
+func (p *RpcPool) SetLogger(logger *slog.Logger) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if logger == nil {
+		logger = logging.Discard()
+	}
+
+	p.logger = logger
+	for _, client := range p.clients {
+		client.SetLogger(logger)
+	}
+}
+
+// Call performs the call on a pooled session, waiting for one to become free.
+func (p *RpcPool) Call(ctx context.Context, service, method string, data any) (Result, error) {
+	var res Result
+
+	err := p.with(ctx, func(client *RpcClient) error {
+		var err error
+
+		res, err = client.Call(ctx, service, method, data)
+
+		return err
+	})
+
+	return res, err
+}
+
+// CallBatch sends the calls as one JSON-RPC batch on a pooled session.
+func (p *RpcPool) CallBatch(ctx context.Context, calls []BatchCall) ([]BatchResult, error) {
+	var results []BatchResult
+
+	err := p.with(ctx, func(client *RpcClient) error {
+		var err error
+
+		results, err = client.CallBatch(ctx, calls)
+
+		return err
+	})
+
+	return results, err
+}
+
+// Subscribe subscribes with a pooled session. The event stream does not hold the session, so
+// it does not count against the pool size.
+func (p *RpcPool) Subscribe(ctx context.Context, object string) (*Subscription, error) {
+	var sub *Subscription
+
+	err := p.with(ctx, func(client *RpcClient) error {
+		var err error
+
+		sub, err = client.Subscribe(ctx, object)
+
+		return err
+	})
+
+	return sub, err
+}
+
+// Listen is not supported over HTTP.
+func (p *RpcPool) Listen(ctx context.Context, pattern string) (*Subscription, error) {
+	return p.first.Listen(ctx, pattern)
+}
+
+// Objects lists the registered objects using a pooled session.
+func (p *RpcPool) Objects(ctx context.Context, pattern string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+
+	err := p.with(ctx, func(client *RpcClient) error {
+		var err error
+
+		objects, err = client.Objects(ctx, pattern)
+
+		return err
+	})
+
+	return objects, err
+}
+
+// Lookup returns the signature of object using a pooled session.
+func (p *RpcPool) Lookup(ctx context.Context, object string) (*ObjectInfo, error) {
+	var info *ObjectInfo
+
+	err := p.with(ctx, func(client *RpcClient) error {
+		var err error
+
+		info, err = client.Lookup(ctx, object)
+
+		return err
+	})
+
+	return info, err
+}
+
+// Close closes every session of the pool and reports the errors of destroying them.
+// Calls still running finish on their session.
+func (p *RpcPool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil
+	}
+
+	p.closed = true
+
+	var errs []error
+	for _, client := range p.clients {
+		errs = append(errs, client.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+// with checks out a session, logging it in first if the slot is still empty, and runs fn on it.
+func (p *RpcPool) with(ctx context.Context, fn func(*RpcClient) error) error {
+	var client *RpcClient
+
+	select {
+	case client = <-p.slots:
+	case <-ctx.Done():
+		return errdefs.Wrapf(errdefs.FromTransport(ctx.Err()), "wait for a session to %s", p.host)
+	}
+
+	defer func() { p.slots <- client }()
+
+	if client == nil {
+		var err error
+
+		client, err = p.connect(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return fn(client)
+}
+
+// connect creates another session of the pool.
+func (p *RpcPool) connect(ctx context.Context) (*RpcClient, error) {
+	p.mu.Lock()
+	closed, logger := p.closed, p.logger
+	p.mu.Unlock()
+
+	if closed {
+		return nil, errdefs.ErrClosed
+	}
+
+	opts := slices.Concat(p.opts, []RpcOption{WithRpcLogger(logger)})
+
+	client, err := NewRpcClient(ctx, p.host, p.username, p.password, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		_ = client.Close()
+
+		return nil, errdefs.ErrClosed
+	}
+
+	p.clients = append(p.clients, client)
+
+	return client, nil
+}
@@ -0,0 +1,95 @@
+package goubus_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+func TestRpcPool(t *testing.T) {
+	var logins, running, peak atomic.Int32
+
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		params, _ := decodeRpcRequestBody(r)["params"].([]any)
+		if params[0] == testUbusAuthSession {
+			n := logins.Add(1)
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":[0,`+
+				`{"ubus_rpc_session":"session-%d","timeout":3600}]}`, n)
+
+			return
+		}
+
+		n := running.Add(1)
+		defer running.Add(-1)
+
+		for {
+			current := peak.Load()
+			if n <= current || peak.CompareAndSwap(current, n) {
+				break
+			}
+		}
+
+		<-release
+		_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":[0,{"session":%q}]}`, params[0])
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+
+	pool, err := goubus.NewRpcPool(ctx, strings.TrimPrefix(server.URL, "http://"), "user", "pass", 2)
+	if err != nil {
+		t.Fatalf("NewRpcPool failed: %v", err)
+	}
+
+	if pool.Size() != 2 || logins.Load() != 1 {
+		t.Errorf("expected size 2 with one login, got size %d and %d logins", pool.Size(), logins.Load())
+	}
+
+	var wg sync.WaitGroup
+
+	for range 6 {
+		wg.Go(func() {
+			_, err := goubus.Call[map[string]any](ctx, pool, "system", "board", nil)
+			if err != nil {
+				t.Errorf("Call failed: %v", err)
+			}
+		})
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+
+	for running.Load() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+
+	_, err = pool.Call(waitCtx, "system", "info", nil)
+	if !errdefs.IsTimeout(err) {
+		t.Errorf("expected a timeout while all sessions are busy, got %v", err)
+	}
+
+	close(release)
+	wg.Wait()
+
+	if peak.Load() != 2 || logins.Load() != 2 {
+		t.Errorf("expected 2 concurrent calls on 2 sessions, got %d calls and %d logins", peak.Load(), logins.Load())
+	}
+
+	_ = pool.Close()
+
+	_, err = pool.Call(ctx, "system", "board", nil)
+	if !errdefs.IsClosed(err) {
+		t.Errorf("expected closed, got %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package goubus
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+const ubusSubscribePath = ubusEndpointPath + "/subscribe/"
+
+var _ Subscriber = (*RpcClient)(nil)
+
+// Subscribe delivers the notifications sent by a ubus object using the server-sent events
+// endpoint of uhttpd-mod-ubus. The session needs the ":subscribe" permission on the object.
+func (rc *RpcClient) Subscribe(ctx context.Context, object string) (*Subscription, error) {
+	if rc.closed {
+		return nil, errdefs.ErrClosed
+	}
+
+	sessionID, err := rc.getValidSessionID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rc.baseURL+ubusSubscribePath+object, nil)
+	if err != nil {
+		return nil, errdefs.Wrapf(errdefs.ErrConnectionFailed, "create request: %v", err)
+	}
+
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Authorization", "Bearer "+sessionID)
+
+	resp, err := rc.do(req)
+	if err != nil {
+		return nil, errdefs.Wrapf(errdefs.FromTransport(err), "http get error")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+
+		return nil, subscribeStatusError(resp.StatusCode, object)
+	}
+
+	rc.logger.Debug("Subscribed", slog.String("object", object))
+
+	return NewSubscription(ctx, func(ctx context.Context, emit func(Event) bool) error {
+		stop := context.AfterFunc(ctx, func() { _ = resp.Body.Close() })
+		defer stop()
+		defer func() { _ = resp.Body.Close() }()
+
+		return readEventStream(ctx, resp.Body, object, emit)
+	}), nil
+}
+
+// Listen is not supported over HTTP: uhttpd-mod-ubus only exposes object subscriptions.
+func (rc *RpcClient) Listen(_ context.Context, pattern string) (*Subscription, error) {
+	return nil, errdefs.Wrapf(errdefs.ErrNotSupported, "listen for %s over JSON-RPC", pattern)
+}
+
+// subscribeStatusError maps the HTTP status of a rejected subscription. uhttpd-mod-ubus answers
+// 403 when the session lacks the ubus ":subscribe" permission on the object, which is reported
+// as a *errdefs.PermissionError.
+func subscribeStatusError(status int, object string) error {
+	switch status {
+	case http.StatusForbidden:
+		return &errdefs.PermissionError{
+			Scope:  errdefs.ACLScopeUbus,
+			Object: object,
+			Method: ubusSubscribeFunction,
+			Access: errdefs.ACLAccessRead,
+		}
+	case http.StatusUnauthorized:
+		return errdefs.Wrapf(errdefs.ErrPermissionDenied, "subscribe to %s", object)
+	case http.StatusNotFound:
+		return errdefs.Wrapf(errdefs.ErrNotFound, "subscribe to %s", object)
+	default:
+		return errdefs.Wrapf(errdefs.ErrInvalidResponse, "subscribe to %s: HTTP %d", object, status)
+	}
+}
+
+// readEventStream parses a text/event-stream body into events.
+func readEventStream(ctx context.Context, body io.Reader, object string, emit func(Event) bool) error {
+	scanner := bufio.NewScanner(body)
+	event := Event{Object: object}
+
+	var data strings.Builder
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line != "" {
+			field, value, _ := strings.Cut(line, ":")
+			value = strings.TrimPrefix(value, " ")
+
+			switch field {
+			case "event":
+				event.Type = value
+			case "data":
+				data.WriteString(value)
+			}
+
+			continue
+		}
+
+		if data.Len() == 0 {
+			continue
+		}
+
+		err := json.Unmarshal([]byte(data.String()), &event.Data)
+		if err != nil {
+			return errdefs.Wrapf(errdefs.ErrInvalidResponse, "decode event %s: %v", event.Type, err)
+		}
+
+		if !emit(event) {
+			return nil
+		}
+
+		event = Event{Object: object}
+
+		data.Reset()
+	}
+
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return errdefs.Wrapf(errdefs.FromTransport(err), "read event stream")
+	}
+
+	return errdefs.Wrapf(errdefs.ErrConnectionFailed, "event stream of %s closed", object)
+}
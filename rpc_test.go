@@ -3,7 +3,9 @@ package goubus_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -192,6 +194,98 @@ func TestRpcClient_SessionExpiry(t *testing.T) {
 	}
 }
 
+func TestRpcClient_Objects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, _ := io.ReadAll(request.Body)
+		if !strings.Contains(string(body), `"method":"list"`) {
+			_, _ = fmt.Fprint(writer, `{"jsonrpc":"2.0","id":1,"result":[0,`+
+				`{"ubus_rpc_session":"12345678901234567890123456789012","timeout":3600}]}`)
+
+			return
+		}
+
+		if !strings.Contains(string(body), `"params":["network.*"]`) {
+			t.Errorf("unexpected list request: %s", body)
+		}
+
+		_, _ = fmt.Fprint(writer, `{"jsonrpc":"2.0","id":1,"result":{`+
+			`"network.interface":{"dump":{}},`+
+			`"network.device":{"status":{"name":"string"}}}}`)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+
+	client, err := goubus.NewRpcClient(ctx, strings.TrimPrefix(server.URL, "http://"), "user", "pass")
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	objects, err := client.Objects(ctx, "network.*")
+	if err != nil {
+		t.Fatalf("Objects failed: %v", err)
+	}
+
+	if len(objects) != 2 || objects[0].Path != "network.device" ||
+		objects[0].Methods["status"]["name"] != goubus.ArgTypeString || !objects[1].HasMethod("dump") {
+		t.Errorf("unexpected objects: %+v", objects)
+	}
+}
+
+func TestRpcClient_CallBatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, _ := io.ReadAll(request.Body)
+		if !strings.HasPrefix(string(body), "[") {
+			_, _ = fmt.Fprint(writer, `{"jsonrpc":"2.0","id":1,"result":[0,`+
+				`{"ubus_rpc_session":"12345678901234567890123456789012","timeout":3600}]}`)
+
+			return
+		}
+
+		var requests []struct {
+			Params []any `json:"params"`
+			ID     int   `json:"id"`
+		}
+
+		err := json.Unmarshal(body, &requests)
+		if err != nil || len(requests) != 2 {
+			t.Errorf("unexpected batch request: %s", body)
+		}
+
+		// Replies may arrive in any order.
+		_, _ = fmt.Fprint(writer, `[{"jsonrpc":"2.0","id":2,"result":[4]},`+
+			`{"jsonrpc":"2.0","id":1,"result":[0,{"up":true}]}]`)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+
+	client, err := goubus.NewRpcClient(ctx, strings.TrimPrefix(server.URL, "http://"), "user", "pass")
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	batch := client.Batch()
+	batch.Add("network.interface.lan", "status", nil)
+	batch.Add("network.interface.wan6", "status", nil)
+
+	results, err := batch.Do(ctx)
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+
+	status, err := goubus.BatchAs[struct {
+		Up bool `json:"up"`
+	}](results[0])
+	if err != nil || !status.Up {
+		t.Errorf("unexpected first result: %+v (%v)", status, err)
+	}
+
+	if !errdefs.IsNotFound(results[1].Err) {
+		t.Errorf("expected not found, got %v", results[1].Err)
+	}
+}
+
 func TestRpcClient_ErrorHandling(t *testing.T) {
 	tests := []struct {
 		wantErr  error
@@ -301,3 +395,146 @@ func assertErrorContains(t *testing.T, got error, want error) {
 		t.Errorf("expected error containing %v, got %v", want, got)
 	}
 }
+
+func TestRpcClient_PermissionDenied(t *testing.T) {
+	t.Run("MissingUbusPermission", func(t *testing.T) {
+		perm := callDeniedRpc(t, "network.interface.wan", "up", nil,
+			`{"jsonrpc":"2.0","id":1,"error":{"code":-32002,"message":"Access denied"}}`, map[string]bool{})
+
+		if perm.Scope != errdefs.ACLScopeUbus || perm.Object != "network.interface.wan" || perm.Method != "up" {
+			t.Errorf("unexpected permission error: %+v", perm)
+		}
+
+		if perm.Access != errdefs.ACLAccessWrite {
+			t.Errorf("expected write access hint, got %q", perm.Access)
+		}
+
+		want := `"write":{"ubus":{"network.interface.wan":["up"]}}`
+		if !strings.Contains(perm.ACLSnippet(), want) {
+			t.Errorf("expected snippet containing %s, got %s", want, perm.ACLSnippet())
+		}
+	})
+
+	t.Run("MissingUCIPermission", func(t *testing.T) {
+		perm := callDeniedRpc(t, "uci", "get", map[string]any{"config": "network"},
+			`{"jsonrpc":"2.0","id":1,"result":[6]}`, map[string]bool{"ubus/uci/get": true})
+
+		if perm.Scope != errdefs.ACLScopeUCI || perm.Object != "network" || perm.Access != errdefs.ACLAccessRead {
+			t.Errorf("unexpected permission error: %+v", perm)
+		}
+
+		want := `"read":{"uci":["network"]}`
+		if !strings.Contains(perm.Error(), want) {
+			t.Errorf("expected error containing %s, got %s", want, perm.Error())
+		}
+	})
+}
+
+// callDeniedRpc performs a call against a server that rejects it with denied and answers
+// session access lookups from grants, keyed by "scope/object/function".
+func callDeniedRpc(
+	t *testing.T,
+	service, method string,
+	data any,
+	denied string,
+	grants map[string]bool,
+) *errdefs.PermissionError {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		params, _ := decodeRpcRequestBody(request)["params"].([]any)
+
+		switch {
+		case params[0] == testUbusAuthSession:
+			_, _ = fmt.Fprint(writer, `{"jsonrpc":"2.0","id":1,"result":[0,`+
+				`{"ubus_rpc_session":"test-session","timeout":3600}]}`)
+		case params[1] == "session" && params[2] == "access":
+			req, _ := params[3].(map[string]any)
+			key := fmt.Sprintf("%v/%v/%v", req["scope"], req["object"], req["function"])
+			_, _ = fmt.Fprintf(writer, `{"jsonrpc":"2.0","id":1,"result":[0,{"access":%t}]}`, grants[key])
+		default:
+			_, _ = fmt.Fprint(writer, denied)
+		}
+	}))
+	defer server.Close()
+
+	client, err := goubus.NewRpcClient(context.Background(), strings.TrimPrefix(server.URL, "http://"), "u", "p")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.Call(context.Background(), service, method, data)
+	if !errdefs.IsPermissionDenied(err) {
+		t.Fatalf("expected permission denied, got %v", err)
+	}
+
+	var perm *errdefs.PermissionError
+	if !errors.As(err, &perm) {
+		t.Fatalf("expected *errdefs.PermissionError, got %T", err)
+	}
+
+	return perm
+}
+
+func TestRpcClient_Subscribe(t *testing.T) {
+	sessionID := "12345678901234567890123456789012"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			handleRpcCall(t, w, r, sessionID)
+
+			return
+		}
+
+		if r.URL.Path != "/ubus/subscribe/hostapd.phy1-ap0" || r.Header.Get("Authorization") != "Bearer "+sessionID {
+			w.WriteHeader(http.StatusForbidden)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = fmt.Fprint(w, ": keepalive\n\n")
+		_, _ = fmt.Fprint(w, "event: radar-detected\ndata: {\"frequency\":5500,\"width\":3}\n\n")
+	}))
+	defer server.Close()
+
+	host := strings.TrimPrefix(server.URL, "http://")
+	ctx := context.Background()
+
+	client, err := goubus.NewRpcClient(ctx, host, "user", "pass")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sub, err := client.Subscribe(ctx, "hostapd.phy1-ap0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ev, ok := <-sub.Events()
+	if !ok || ev.Type != "radar-detected" || ev.Object != "hostapd.phy1-ap0" || ev.Data["frequency"] != float64(5500) {
+		t.Errorf("unexpected event: %+v", ev)
+	}
+
+	<-sub.Done()
+
+	if !errdefs.IsConnectionFailed(sub.Err()) {
+		t.Errorf("expected connection failure once the stream ends, got %v", sub.Err())
+	}
+
+	_, err = client.Subscribe(ctx, "system")
+	if !errdefs.IsPermissionDenied(err) {
+		t.Errorf("expected permission denied, got %v", err)
+	}
+
+	var perm *errdefs.PermissionError
+	if !errors.As(err, &perm) || perm.Object != "system" || perm.Method != ":subscribe" ||
+		perm.Access != errdefs.ACLAccessRead {
+		t.Errorf("expected the missing subscribe permission, got %v", err)
+	}
+
+	_, err = client.Listen(ctx, "*")
+	if !errdefs.IsNotSupported(err) {
+		t.Errorf("expected not supported, got %v", err)
+	}
+}
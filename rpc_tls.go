@@ -0,0 +1,61 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package goubus
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+// WithTLSConfig sets the TLS configuration of HTTPS connections and makes a host without a scheme
+// use https. The other TLS options modify this configuration, so pass it first.
+func WithTLSConfig(config *tls.Config) RpcOption {
+	return func(rc *RpcClient) {
+		rc.tlsConfig = config.Clone()
+	}
+}
+
+// WithInsecureSkipVerify accepts any server certificate, e.g. the self-signed certificate uhttpd
+// generates on first boot, and makes a host without a scheme use https. The connection is then
+// open to man-in-the-middle attacks; prefer WithCACert with the router's certificate.
+func WithInsecureSkipVerify() RpcOption {
+	return func(rc *RpcClient) {
+		rc.tls().InsecureSkipVerify = true
+	}
+}
+
+// WithCACert trusts the PEM encoded certificates instead of the system roots, e.g. the
+// /etc/uhttpd.crt of the router, and makes a host without a scheme use https.
+func WithCACert(pem []byte) RpcOption {
+	return func(rc *RpcClient) {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			rc.optionErr = errdefs.Wrapf(errdefs.ErrInvalidParameter, "no CA certificate found in PEM data")
+
+			return
+		}
+
+		rc.tls().RootCAs = pool
+	}
+}
+
+// WithClientCertificate presents cert to servers that request a client certificate and makes a
+// host without a scheme use https.
+func WithClientCertificate(cert tls.Certificate) RpcOption {
+	return func(rc *RpcClient) {
+		config := rc.tls()
+		config.Certificates = append(config.Certificates, cert)
+	}
+}
+
+// tls returns the TLS configuration the options modify, creating it on first use.
+func (rc *RpcClient) tls() *tls.Config {
+	if rc.tlsConfig == nil {
+		rc.tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	return rc.tlsConfig
+}
@@ -0,0 +1,63 @@
+package goubus_test
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+func TestRpcClient_TLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":[0,`+
+			`{"ubus_rpc_session":"12345678901234567890123456789012","timeout":3600}]}`)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	host := strings.TrimPrefix(server.URL, "https://")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	serverTLS := server.Client().Transport.(*http.Transport).TLSClientConfig
+
+	_, err := goubus.NewRpcClient(ctx, server.URL, "user", "pass")
+	if !errdefs.IsConnectionFailed(err) {
+		t.Errorf("expected the self-signed certificate to be rejected, got %v", err)
+	}
+
+	tests := []struct {
+		name string
+		host string
+		opts []goubus.RpcOption
+	}{
+		{"CACert", host, []goubus.RpcOption{goubus.WithCACert(caPEM)}},
+		{"InsecureSkipVerify", server.URL, []goubus.RpcOption{goubus.WithInsecureSkipVerify()}},
+		{"TLSConfig", host, []goubus.RpcOption{goubus.WithTLSConfig(serverTLS)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := goubus.NewRpcClient(ctx, tt.host, "user", "pass", tt.opts...)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			_ = client.Close()
+		})
+	}
+
+	_, err = goubus.NewRpcClient(ctx, host, "user", "pass", goubus.WithCACert([]byte("not a certificate")))
+	if !errdefs.IsInvalidParameter(err) {
+		t.Errorf("expected invalid parameter for a bad CA certificate, got %v", err)
+	}
+
+	_, err = goubus.NewRpcClient(ctx, "ftp://"+host, "user", "pass")
+	if !errdefs.IsInvalidParameter(err) {
+		t.Errorf("expected invalid parameter for an unsupported scheme, got %v", err)
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+// Package rules reacts to ubus events with operator-defined rules. A rule pairs a condition,
+// written in a small sandboxed expression language (see Expr), with a Go action:
+//
+//	engine := rules.NewEngine()
+//	err := engine.Add(rules.Rule{
+//		Name:     "modem-watchdog",
+//		When:     `data.action == "ifdown" && data.interface == "wan"`,
+//		Unless:   `data.action == "ifup" && data.interface == "wan"`,
+//		For:      time.Minute,
+//		Cooldown: 10 * time.Minute,
+//		Action: func(ctx context.Context, f rules.Firing) error {
+//			return svc.Restart(ctx, "modemmanager")
+//		},
+//	})
+//
+//	sub, err := goubus.Listen(ctx, t, "network.interface")
+//	err = engine.Run(ctx, sub)
+package rules
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/logging"
+)
+
+// Rule reacts to the events matching a condition.
+type Rule struct {
+	// Action runs in its own goroutine when the rule fires.
+	Action func(ctx context.Context, f Firing) error
+	// Name identifies the rule in firings and logs.
+	Name string
+	// When is the condition that triggers the rule.
+	When string
+	// Unless is an optional condition that cancels a pending trigger, e.g. the interface coming back up.
+	Unless string
+	// For delays the action until When has held for this long without an Unless event.
+	For time.Duration
+	// Cooldown is the minimum time between two firings; triggers within it are dropped.
+	Cooldown time.Duration
+}
+
+// Firing describes a rule that fired.
+type Firing struct {
+	// Time is when the rule fired; with a For delay, it is later than the triggering event.
+	Time time.Time
+	// Event is the event that triggered the rule.
+	Event goubus.Event
+	Rule  string
+}
+
+// Option defines a functional option for an Engine.
+type Option func(*Engine)
+
+// WithLogger sets the logger that reports rule firings, skipped triggers and failing actions.
+func WithLogger(logger *slog.Logger) Option {
+	return func(e *Engine) {
+		if logger != nil {
+			e.logger = logger
+		}
+	}
+}
+
+// Engine evaluates rules against events.
+type Engine struct {
+	logger *slog.Logger
+	rules  []*rule
+	mu     sync.Mutex
+	wg     sync.WaitGroup
+	// stopped is set once Run ends, so that late timers and events start no actions while it
+	// waits for the running ones.
+	stopped bool
+}
+
+type rule struct {
+	Rule
+
+	when    *Expr
+	unless  *Expr
+	pending *time.Timer
+	fired   time.Time
+}
+
+// NewEngine creates an engine without rules.
+func NewEngine(opts ...Option) *Engine {
+	e := &Engine{logger: logging.Discard()}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// Add compiles and registers a rule.
+func (e *Engine) Add(r Rule) error {
+	if r.Name == "" || r.Action == nil {
+		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "rule name and action are required")
+	}
+
+	compiled := &rule{Rule: r}
+
+	var err error
+
+	compiled.when, err = Compile(r.When)
+	if err != nil {
+		return errdefs.Wrapf(err, "rule %s", r.Name)
+	}
+
+	if r.Unless != "" {
+		compiled.unless, err = Compile(r.Unless)
+		if err != nil {
+			return errdefs.Wrapf(err, "rule %s", r.Name)
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, existing := range e.rules {
+		if existing.Name == r.Name {
+			return errdefs.Wrapf(errdefs.ErrInvalidParameter, "rule %s already exists", r.Name)
+		}
+	}
+
+	e.rules = append(e.rules, compiled)
+
+	return nil
+}
+
+// Run handles the events of sub until it ends or ctx is done, then cancels pending triggers
+// and waits for running actions. Events handled after that are ignored until Run is called again.
+// It returns the error that ended the subscription.
+func (e *Engine) Run(ctx context.Context, sub *goubus.Subscription) error {
+	e.mu.Lock()
+	e.stopped = false
+	e.mu.Unlock()
+
+	defer e.stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-sub.Events():
+			if !ok {
+				return sub.Err()
+			}
+
+			e.Handle(ctx, ev)
+		}
+	}
+}
+
+// Handle evaluates all rules for one event. Conditions that fail to evaluate are logged and do not match.
+func (e *Engine) Handle(ctx context.Context, ev goubus.Event) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.stopped {
+		return
+	}
+
+	for _, r := range e.rules {
+		if r.unless != nil && r.pending != nil && e.match(r, r.unless, ev) {
+			r.pending.Stop()
+			r.pending = nil
+
+			e.logger.Debug("rule trigger cancelled", slog.String("rule", r.Name))
+
+			continue
+		}
+
+		if !e.match(r, r.when, ev) {
+			continue
+		}
+
+		if r.For <= 0 {
+			e.fire(ctx, r, ev)
+
+			continue
+		}
+
+		if r.pending == nil {
+			r.pending = time.AfterFunc(r.For, func() { e.expire(ctx, r, ev) })
+		}
+	}
+}
+
+func (e *Engine) match(r *rule, expr *Expr, ev goubus.Event) bool {
+	matched, err := expr.Match(ev)
+	if err != nil {
+		e.logger.Warn("rule condition failed", slog.String("rule", r.Name), slog.String("error", err.Error()))
+	}
+
+	return matched
+}
+
+// expire fires a rule whose For delay elapsed without cancellation.
+func (e *Engine) expire(ctx context.Context, r *rule, ev goubus.Event) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if r.pending == nil {
+		return
+	}
+
+	r.pending = nil
+
+	e.fire(ctx, r, ev)
+}
+
+// fire runs the action of r unless it is cooling down or the engine stopped. The caller holds e.mu.
+func (e *Engine) fire(ctx context.Context, r *rule, ev goubus.Event) {
+	if e.stopped {
+		return
+	}
+
+	now := time.Now()
+
+	if r.Cooldown > 0 && !r.fired.IsZero() && now.Sub(r.fired) < r.Cooldown {
+		e.logger.Debug("rule cooling down", slog.String("rule", r.Name))
+
+		return
+	}
+
+	r.fired = now
+
+	e.logger.Info("rule fired", slog.String("rule", r.Name), slog.String("event", ev.Type))
+
+	e.wg.Go(func() {
+		err := r.Action(ctx, Firing{Rule: r.Name, Event: ev, Time: now})
+		if err != nil {
+			e.logger.Warn("rule action failed", slog.String("rule", r.Name), slog.String("error", err.Error()))
+		}
+	})
+}
+
+func (e *Engine) stop() {
+	e.mu.Lock()
+
+	e.stopped = true
+
+	for _, r := range e.rules {
+		if r.pending != nil {
+			r.pending.Stop()
+			r.pending = nil
+		}
+	}
+
+	e.mu.Unlock()
+
+	e.wg.Wait()
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package rules_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/rules"
+)
+
+func ifaceEvent(action string) goubus.Event {
+	return goubus.Event{
+		Type: "network.interface",
+		Data: map[string]any{"action": action, "interface": "wan"},
+	}
+}
+
+func TestEngine_Hold(t *testing.T) {
+	ctx := context.Background()
+
+	var fired atomic.Int32
+
+	engine := rules.NewEngine()
+
+	err := engine.Add(rules.Rule{
+		Name:   "wan-down",
+		When:   `data.action == "ifdown" && data.interface == "wan"`,
+		Unless: `data.action == "ifup" && data.interface == "wan"`,
+		For:    30 * time.Millisecond,
+		Action: func(context.Context, rules.Firing) error {
+			fired.Add(1)
+
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	engine.Handle(ctx, ifaceEvent("ifdown"))
+	engine.Handle(ctx, ifaceEvent("ifup"))
+	time.Sleep(60 * time.Millisecond)
+
+	if fired.Load() != 0 {
+		t.Fatal("rule fired although the interface came back up")
+	}
+
+	engine.Handle(ctx, ifaceEvent("ifdown"))
+	time.Sleep(60 * time.Millisecond)
+
+	if fired.Load() != 1 {
+		t.Fatalf("expected the rule to fire once, got %d", fired.Load())
+	}
+}
+
+func TestEngine_RunWithCooldown(t *testing.T) {
+	events := make(chan goubus.Event)
+	firings := make(chan rules.Firing, 4)
+
+	engine := rules.NewEngine()
+
+	err := engine.Add(rules.Rule{
+		Name:     "restart",
+		When:     `data.action == "ifdown"`,
+		Cooldown: time.Hour,
+		Action: func(_ context.Context, f rules.Firing) error {
+			firings <- f
+
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	sub := goubus.NewSubscription(context.Background(), func(_ context.Context, emit func(goubus.Event) bool) error {
+		for ev := range events {
+			emit(ev)
+		}
+
+		return nil
+	})
+
+	done := make(chan error)
+
+	go func() { done <- engine.Run(context.Background(), sub) }()
+
+	for range 3 {
+		events <- ifaceEvent("ifdown")
+	}
+
+	close(events)
+
+	err = <-done
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if len(firings) != 1 {
+		t.Errorf("expected one firing within the cooldown, got %d", len(firings))
+	}
+
+	noop := func(context.Context, rules.Firing) error { return nil }
+
+	err = engine.Add(rules.Rule{Name: "restart", When: "true", Action: noop})
+	if !errdefs.IsInvalidParameter(err) {
+		t.Errorf("expected duplicate rule to be rejected, got %v", err)
+	}
+}
+
+func TestEngine_StoppedIgnoresEvents(t *testing.T) {
+	var fired atomic.Int32
+
+	engine := rules.NewEngine()
+
+	err := engine.Add(rules.Rule{
+		Name: "restart",
+		When: `data.action == "ifdown"`,
+		Action: func(context.Context, rules.Firing) error {
+			fired.Add(1)
+
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	sub := goubus.NewSubscription(ctx, func(ctx context.Context, _ func(goubus.Event) bool) error {
+		<-ctx.Done()
+
+		return nil
+	})
+
+	err = engine.Run(ctx, sub)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	engine.Handle(context.Background(), ifaceEvent("ifdown"))
+	time.Sleep(20 * time.Millisecond)
+
+	if fired.Load() != 0 {
+		t.Errorf("expected no firing after Run returned, got %d", fired.Load())
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package rules
+
+import (
+	"cmp"
+	"encoding/json"
+	"reflect"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+type exprNode interface {
+	eval(ev goubus.Event) (any, error)
+}
+
+type literalNode struct {
+	value any
+}
+
+func (n literalNode) eval(goubus.Event) (any, error) {
+	return n.value, nil
+}
+
+type fieldNode struct {
+	path []string
+}
+
+func (n fieldNode) eval(ev goubus.Event) (any, error) {
+	switch n.path[0] {
+	case "type":
+		return ev.Type, nil
+	case "object":
+		return ev.Object, nil
+	}
+
+	var value any = ev.Data
+
+	for _, key := range n.path[1:] {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, nil
+		}
+
+		value = object[key]
+	}
+
+	return normalize(value), nil
+}
+
+type notNode struct {
+	operand exprNode
+}
+
+func (n notNode) eval(ev goubus.Event) (any, error) {
+	value, err := evalBool(n.operand, ev)
+
+	return !value, err
+}
+
+type logicalNode struct {
+	left  exprNode
+	right exprNode
+	or    bool
+}
+
+func (n logicalNode) eval(ev goubus.Event) (any, error) {
+	left, err := evalBool(n.left, ev)
+	if err != nil || left == n.or {
+		return left, err
+	}
+
+	return evalBool(n.right, ev)
+}
+
+type compareNode struct {
+	left  exprNode
+	right exprNode
+	op    string
+}
+
+func (n compareNode) eval(ev goubus.Event) (any, error) {
+	left, err := n.left.eval(ev)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := n.right.eval(ev)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return reflect.DeepEqual(left, right), nil
+	case "!=":
+		return !reflect.DeepEqual(left, right), nil
+	}
+
+	order, ok := compareOrdered(left, right)
+	if !ok {
+		return false, nil
+	}
+
+	switch n.op {
+	case "<":
+		return order < 0, nil
+	case "<=":
+		return order <= 0, nil
+	case ">":
+		return order > 0, nil
+	default:
+		return order >= 0, nil
+	}
+}
+
+func evalBool(node exprNode, ev goubus.Event) (bool, error) {
+	value, err := node.eval(ev)
+	if err != nil {
+		return false, err
+	}
+
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case float64:
+		// blobmsg carries booleans as 8-bit integers.
+		return v != 0, nil
+	default:
+		return false, errdefs.Wrapf(errdefs.ErrInvalidParameter, "%v is not a boolean", value)
+	}
+}
+
+// compareOrdered orders two numbers or two strings; other combinations are unordered.
+func compareOrdered(left, right any) (int, bool) {
+	switch l := left.(type) {
+	case float64:
+		r, ok := right.(float64)
+
+		return cmp.Compare(l, r), ok
+	case string:
+		r, ok := right.(string)
+
+		return cmp.Compare(l, r), ok
+	default:
+		return 0, false
+	}
+}
+
+// normalize converts the numbers of an event payload to float64, so they compare equal to literals.
+func normalize(value any) any {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return v.String()
+		}
+
+		return f
+	default:
+		return value
+	}
+}
@@ -0,0 +1,341 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package rules
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+const (
+	maxExprLength = 1024
+	maxExprDepth  = 32
+)
+
+// Expr is a compiled rule condition. It is evaluated against an event and can only read it:
+// the language has no assignments, loops or function calls, so evaluation always terminates.
+//
+// Conditions combine comparisons with &&, || and !, and parentheses:
+//
+//	type == "network.interface" && data.action == "ifdown" && data.interface == "wan"
+//	object == "hostapd.phy1-ap0" && (data.signal < -80 || !data.authorized)
+//
+// The identifiers type and object refer to the event; data.a.b reads nested payload keys and
+// yields null when a key is missing. Literals are double- or single-quoted strings, numbers,
+// true, false and null. Ordering operators compare numbers with numbers and strings with strings.
+type Expr struct {
+	root   exprNode
+	source string
+}
+
+// Compile parses a condition.
+func Compile(source string) (*Expr, error) {
+	if len(source) > maxExprLength {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "expression longer than %d bytes", maxExprLength)
+	}
+
+	tokens, err := lex(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+
+	root, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek().kind != tokenEOF {
+		return nil, p.errorf("unexpected %q", p.peek().text)
+	}
+
+	return &Expr{root: root, source: source}, nil
+}
+
+// String returns the source of the expression.
+func (e *Expr) String() string {
+	return e.source
+}
+
+// Match evaluates the expression for ev. It fails if the expression does not yield a boolean;
+// numbers count as booleans, as blobmsg encodes booleans as integers.
+func (e *Expr) Match(ev goubus.Event) (bool, error) {
+	result, err := evalBool(e.root, ev)
+	if err != nil {
+		return false, errdefs.Wrapf(err, "evaluate %q", e.source)
+	}
+
+	return result, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenOp
+)
+
+type token struct {
+	text  string
+	kind  tokenKind
+	value any
+}
+
+var operators = []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "(", ")"}
+
+func lex(source string) ([]token, error) {
+	var tokens []token
+
+	for pos := 0; pos < len(source); {
+		if isSpace(source[pos]) {
+			pos++
+
+			continue
+		}
+
+		tok, width, err := lexToken(source, pos)
+		if err != nil {
+			return nil, err
+		}
+
+		tokens = append(tokens, tok)
+		pos += width
+	}
+
+	return append(tokens, token{kind: tokenEOF, text: "end of expression"}), nil
+}
+
+// lexToken scans the token starting at pos and returns it with its width in bytes.
+func lexToken(source string, pos int) (token, int, error) {
+	c := source[pos]
+	rest := source[pos:]
+
+	switch {
+	case c == '"' || c == '\'':
+		end := strings.IndexByte(rest[1:], c)
+		if end < 0 {
+			return token{}, 0, errdefs.Wrapf(errdefs.ErrInvalidParameter, "unterminated string at %d", pos)
+		}
+
+		text := rest[1 : 1+end]
+
+		return token{kind: tokenString, text: text, value: text}, end + 2, nil
+	case isDigit(c) || (c == '-' && len(rest) > 1 && isDigit(rest[1])):
+		text := rest[:1+scan(rest[1:], func(b byte) bool { return isDigit(b) || b == '.' })]
+
+		number, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return token{}, 0, errdefs.Wrapf(errdefs.ErrInvalidParameter, "invalid number %q", text)
+		}
+
+		return token{kind: tokenNumber, text: text, value: number}, len(text), nil
+	case isIdentStart(c):
+		text := rest[:1+scan(rest[1:], func(b byte) bool { return isIdentStart(b) || isDigit(b) || b == '.' })]
+
+		return token{kind: tokenIdent, text: text}, len(text), nil
+	default:
+		op := matchOperator(rest)
+		if op == "" {
+			return token{}, 0, errdefs.Wrapf(errdefs.ErrInvalidParameter, "unexpected %q at %d", c, pos)
+		}
+
+		return token{kind: tokenOp, text: op}, len(op), nil
+	}
+}
+
+// scan returns the length of the prefix of s whose bytes satisfy ok.
+func scan(s string, ok func(byte) bool) int {
+	n := 0
+	for n < len(s) && ok(s[n]) {
+		n++
+	}
+
+	return n
+}
+
+func matchOperator(rest string) string {
+	for _, op := range operators {
+		if strings.HasPrefix(rest, op) {
+			return op
+		}
+	}
+
+	return ""
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+
+	return tok
+}
+
+func (p *parser) accept(op string) bool {
+	if p.peek().kind == tokenOp && p.peek().text == op {
+		p.pos++
+
+		return true
+	}
+
+	return false
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return errdefs.Wrapf(errdefs.ErrInvalidParameter, format, args...)
+}
+
+func (p *parser) parseOr(depth int) (exprNode, error) {
+	left, err := p.parseAnd(depth)
+	for err == nil && p.accept("||") {
+		var right exprNode
+
+		right, err = p.parseAnd(depth)
+		left = logicalNode{left: left, right: right, or: true}
+	}
+
+	return left, err
+}
+
+func (p *parser) parseAnd(depth int) (exprNode, error) {
+	left, err := p.parseComparison(depth)
+	for err == nil && p.accept("&&") {
+		var right exprNode
+
+		right, err = p.parseComparison(depth)
+		left = logicalNode{left: left, right: right}
+	}
+
+	return left, err
+}
+
+func (p *parser) parseComparison(depth int) (exprNode, error) {
+	left, err := p.parseUnary(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	tok := p.peek()
+	if tok.kind != tokenOp || !isComparison(tok.text) {
+		return left, nil
+	}
+
+	p.next()
+
+	right, err := p.parseUnary(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	return compareNode{left: left, right: right, op: tok.text}, nil
+}
+
+func (p *parser) parseUnary(depth int) (exprNode, error) {
+	if depth > maxExprDepth {
+		return nil, p.errorf("expression nested deeper than %d", maxExprDepth)
+	}
+
+	if p.accept("!") {
+		operand, err := p.parseUnary(depth + 1)
+
+		return notNode{operand: operand}, err
+	}
+
+	if p.accept("(") {
+		inner, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		if !p.accept(")") {
+			return nil, p.errorf("expected ) before %q", p.peek().text)
+		}
+
+		return inner, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (exprNode, error) {
+	tok := p.next()
+
+	switch tok.kind {
+	case tokenString, tokenNumber:
+		return literalNode{value: tok.value}, nil
+	case tokenIdent:
+		switch tok.text {
+		case "true":
+			return literalNode{value: true}, nil
+		case "false":
+			return literalNode{value: false}, nil
+		case "null":
+			return literalNode{}, nil
+		}
+
+		path := strings.Split(tok.text, ".")
+		if !validPath(path) {
+			return nil, p.errorf("unknown identifier %q", tok.text)
+		}
+
+		return fieldNode{path: path}, nil
+	default:
+		return nil, p.errorf("unexpected %q", tok.text)
+	}
+}
+
+func validPath(path []string) bool {
+	for _, part := range path {
+		if part == "" {
+			return false
+		}
+	}
+
+	switch path[0] {
+	case "type", "object":
+		return len(path) == 1
+	case "data":
+		return true
+	default:
+		return false
+	}
+}
+
+func isComparison(op string) bool {
+	switch op {
+	case "==", "!=", "<", "<=", ">", ">=":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package rules_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/rules"
+)
+
+func TestExpr_Match(t *testing.T) {
+	ev := goubus.Event{
+		Object: "hostapd.phy1-ap0",
+		Type:   "assoc",
+		Data: map[string]any{
+			"interface":  "wan",
+			"signal":     int64(-85),
+			"authorized": int64(1),
+			"ipv4":       map[string]any{"address": "192.0.2.1"},
+		},
+	}
+
+	tests := map[string]bool{
+		`type == "assoc" && object == 'hostapd.phy1-ap0'`:     true,
+		`data.signal < -80 && data.authorized`:                true,
+		`data.signal >= -80 || !data.authorized`:              false,
+		`data.ipv4.address == "192.0.2.1"`:                    true,
+		`data.missing == null && data.ipv4.missing.x == null`: true,
+		`!(data.interface != "wan")`:                          true,
+		`data.interface < "xyz" && data.interface > 3`:        false,
+	}
+
+	for source, want := range tests {
+		expr, err := rules.Compile(source)
+		if err != nil {
+			t.Fatalf("Compile(%q) failed: %v", source, err)
+		}
+
+		got, err := expr.Match(ev)
+		if err != nil || got != want {
+			t.Errorf("%s = %v, %v; want %v", source, got, err, want)
+		}
+	}
+
+	expr, _ := rules.Compile(`data.interface`)
+
+	_, err := expr.Match(ev)
+	if !errdefs.IsInvalidParameter(err) {
+		t.Errorf("expected a non-boolean result to fail, got %v", err)
+	}
+}
+
+func TestCompile_Errors(t *testing.T) {
+	sources := []string{
+		``,
+		`data.a ==`,
+		`"unterminated`,
+		`exec("reboot")`,
+		`env.HOME == "x"`,
+		`(type == "a"`,
+		`type == "a" type`,
+		`data..a == 1`,
+		strings.Repeat("(", 40) + "true" + strings.Repeat(")", 40),
+		strings.Repeat(" ", 2000),
+	}
+
+	for _, source := range sources {
+		_, err := rules.Compile(source)
+		if !errdefs.IsInvalidParameter(err) {
+			t.Errorf("Compile(%q): expected invalid parameter, got %v", source, err)
+		}
+	}
+}
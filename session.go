@@ -0,0 +1,167 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package goubus
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+// sessionArgKey is the argument rpcd reads the session of a call from.
+const sessionArgKey = "ubus_rpc_session"
+
+// SessionTransport wraps a Transport and adds an rpcd session ID to the arguments of every call.
+// Objects served by rpcd, such as file, uci or luci, then check the call against the ACLs of that
+// session instead of trusting the caller. It is meant for a SocketClient running on the device;
+// an RpcClient already sends its own session with every call. Subscriptions, events and
+// introspection are forwarded to the wrapped transport, which delivers them without a session.
+type SessionTransport struct {
+	Transport
+
+	sessionID string
+}
+
+var (
+	_ Transport    = (*SessionTransport)(nil)
+	_ Subscriber   = (*SessionTransport)(nil)
+	_ Introspector = (*SessionTransport)(nil)
+	_ Batcher      = (*SessionTransport)(nil)
+)
+
+// NewSessionTransport wraps t so that its calls are made with the session sessionID.
+func NewSessionTransport(t Transport, sessionID string) *SessionTransport {
+	return &SessionTransport{Transport: t, sessionID: sessionID}
+}
+
+// Login authenticates username with rpcd through session.login on t and returns a transport that
+// makes its calls with the new session. A timeout of 0 uses the rpcd default of 300 seconds; the
+// session expires once it has been idle for that long.
+func Login(ctx context.Context, t Transport, username, password string, timeout int) (*SessionTransport, error) {
+	req := map[string]any{
+		"username": username,
+		"password": password,
+	}
+	if timeout > 0 {
+		req["timeout"] = timeout
+	}
+
+	res, err := Call[struct {
+		SessionID string `json:"ubus_rpc_session"`
+	}](ctx, t, "session", "login", req)
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to log in as %s", username)
+	}
+
+	if res.SessionID == "" {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidResponse, "session.login returned no session for %s", username)
+	}
+
+	return NewSessionTransport(t, res.SessionID), nil
+}
+
+// SessionID returns the session the calls are made with.
+func (st *SessionTransport) SessionID() string {
+	return st.sessionID
+}
+
+// Call adds the session to data and performs the call. data must encode to a JSON object.
+func (st *SessionTransport) Call(ctx context.Context, service, method string, data any) (Result, error) {
+	args, err := st.args(service, method, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return st.Transport.Call(ctx, service, method, args)
+}
+
+// CallBatch adds the session to the arguments of every call and executes them through the wrapped
+// transport, in one round trip if it supports batches. A call whose arguments do not encode to a
+// JSON object fails on its own without being sent.
+func (st *SessionTransport) CallBatch(ctx context.Context, calls []BatchCall) ([]BatchResult, error) {
+	results := make([]BatchResult, len(calls))
+	sent := make([]BatchCall, 0, len(calls))
+	index := make([]int, 0, len(calls))
+
+	for i, call := range calls {
+		args, err := st.args(call.Service, call.Method, call.Data)
+		if err != nil {
+			results[i].Err = err
+
+			continue
+		}
+
+		sent = append(sent, BatchCall{Service: call.Service, Method: call.Method, Data: args})
+		index = append(index, i)
+	}
+
+	if len(sent) == 0 {
+		return results, nil
+	}
+
+	replies, err := CallBatch(ctx, st.Transport, sent)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, reply := range replies {
+		results[index[i]] = reply
+	}
+
+	return results, nil
+}
+
+// Subscribe subscribes to the notifications of object through the wrapped transport.
+func (st *SessionTransport) Subscribe(ctx context.Context, object string) (*Subscription, error) {
+	return Subscribe(ctx, st.Transport, object)
+}
+
+// Listen listens for the events matching pattern through the wrapped transport.
+func (st *SessionTransport) Listen(ctx context.Context, pattern string) (*Subscription, error) {
+	return Listen(ctx, st.Transport, pattern)
+}
+
+// Objects lists the objects matching pattern through the wrapped transport.
+func (st *SessionTransport) Objects(ctx context.Context, pattern string) ([]ObjectInfo, error) {
+	return Objects(ctx, st.Transport, pattern)
+}
+
+// Lookup describes object through the wrapped transport.
+func (st *SessionTransport) Lookup(ctx context.Context, object string) (*ObjectInfo, error) {
+	return lookupObject(ctx, st.Transport, object)
+}
+
+// args returns the arguments of a call with the session added.
+func (st *SessionTransport) args(service, method string, data any) (map[string]any, error) {
+	args, err := sessionArgs(data)
+	if err != nil {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "add session to %s.%s arguments: %v", service, method, err)
+	}
+
+	args[sessionArgKey] = st.sessionID
+
+	return args, nil
+}
+
+// sessionArgs decodes the arguments of a call into a map. Numbers are kept as json.Number, so
+// that the socket transport still encodes integers as blobmsg integers rather than doubles.
+func sessionArgs(data any) (map[string]any, error) {
+	var args map[string]any
+
+	dec := json.NewDecoder(strings.NewReader(encodeRequestData(data)))
+	dec.UseNumber()
+
+	err := dec.Decode(&args)
+	if err != nil {
+		return nil, err
+	}
+
+	if args == nil {
+		args = make(map[string]any)
+	}
+
+	return args, nil
+}
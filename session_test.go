@@ -0,0 +1,207 @@
+package goubus_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/blobmsg"
+	"github.com/honeybbq/goubus/v2/internal/testutil"
+)
+
+func TestLogin(t *testing.T) {
+	var calls []map[string]any
+
+	inner := &mockTransport{
+		callFunc: func(_ context.Context, service, method string, data any) (goubus.Result, error) {
+			args, _ := data.(map[string]any)
+			calls = append(calls, args)
+
+			if service == "session" && method == "login" {
+				if args["password"] != "secret" {
+					return nil, errdefs.ErrPermissionDenied
+				}
+
+				return &mockResult{unmarshalFunc: func(target any) error {
+					return json.Unmarshal([]byte(`{"ubus_rpc_session":"sid","timeout":60}`), target)
+				}}, nil
+			}
+
+			return &mockResult{unmarshalFunc: func(any) error { return nil }}, nil
+		},
+	}
+
+	ctx := context.Background()
+
+	_, err := goubus.Login(ctx, inner, "root", "wrong", 0)
+	if !errdefs.IsPermissionDenied(err) {
+		t.Fatalf("expected permission denied, got %v", err)
+	}
+
+	st, err := goubus.Login(ctx, inner, "root", "secret", 60)
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	if st.SessionID() != "sid" || calls[1]["timeout"] != 60 {
+		t.Errorf("unexpected session %q, login args %v", st.SessionID(), calls[1])
+	}
+
+	_, err = st.Call(ctx, "file", "read", struct {
+		Path string `json:"path"`
+	}{Path: "/etc/banner"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = st.Call(ctx, "system", "board", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if args := calls[2]; args["path"] != "/etc/banner" || args["ubus_rpc_session"] != "sid" {
+		t.Errorf("unexpected file.read args: %v", args)
+	}
+
+	if args := calls[3]; len(args) != 1 || args["ubus_rpc_session"] != "sid" {
+		t.Errorf("unexpected system.board args: %v", args)
+	}
+
+	_, err = st.Call(ctx, "system", "board", []string{"not", "an", "object"})
+	if !errdefs.IsInvalidParameter(err) {
+		t.Errorf("expected invalid parameter, got %v", err)
+	}
+}
+
+func TestSessionTransport_SocketArgumentTypes(t *testing.T) {
+	sockPath := filepath.Join(t.TempDir(), "ubus.sock")
+
+	var lc net.ListenConfig
+
+	listener, err := lc.Listen(context.Background(), "unix", sockPath)
+	if err != nil {
+		t.Skipf("unix sockets not supported: %v", err)
+	}
+
+	defer func() {
+		_ = listener.Close()
+	}()
+
+	invoked := make(chan map[string]any, 1)
+
+	go func() {
+		conn, errAccept := listener.Accept()
+		if errAccept != nil {
+			return
+		}
+
+		defer func() {
+			_ = conn.Close()
+		}()
+
+		var buf bytes.Buffer
+
+		_ = blobmsg.EncodeHeader(&buf, &blobmsg.UbusMessageHeader{Type: blobmsg.UbusMsgHello, Peer: 1})
+		_, _ = buf.Write([]byte{0, 0, 0, 4})
+		_, _ = conn.Write(buf.Bytes())
+
+		for {
+			hdr, payload, errRead := blobmsg.ReadMessage(conn)
+			if errRead != nil {
+				return
+			}
+
+			switch hdr.Type {
+			case blobmsg.UbusMsgLookup:
+				handleLookup(conn, hdr.Seq, payload)
+			case blobmsg.UbusMsgInvoke:
+				attrs, _ := blobmsg.ParseTopLevelAttributes(payload)
+				args, _ := attrs["data"].(map[string]any)
+				invoked <- args
+
+				statusBody, _ := blobmsg.CreateBlobMessage(map[uint32]any{blobmsg.UbusAttrStatus: uint32(0)}, nil)
+				sendMsg(conn, blobmsg.UbusMsgStatus, hdr.Seq, statusBody)
+			}
+		}
+	}()
+
+	ctx := context.Background()
+
+	client, err := goubus.NewSocketClient(ctx, sockPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		_ = client.Close()
+	}()
+
+	_, err = goubus.NewSessionTransport(client, "sid").Call(ctx, "system", "reboot", map[string]any{"delay": 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// An integer must reach ubusd as a blobmsg integer; a double is rejected by the policy of the method.
+	args := <-invoked
+	if delay, ok := args["delay"].(int64); !ok || delay != 5 || args["ubus_rpc_session"] != "sid" {
+		t.Errorf("unexpected arguments %v (delay is %T)", args, args["delay"])
+	}
+}
+
+func TestSessionTransport_Forwarding(t *testing.T) {
+	ctx := context.Background()
+	mock := testutil.NewMockTransport()
+	mock.AddResponse("system", "board", map[string]any{})
+	st := goubus.NewSessionTransport(mock, "sid")
+
+	sub, err := goubus.Subscribe(ctx, st, "hostapd.phy0-ap0")
+	if err != nil {
+		t.Fatalf("expected the subscription to reach the wrapped transport, got %v", err)
+	}
+	defer sub.Close()
+
+	mock.Emit("hostapd.phy0-ap0", "assoc", map[string]any{"address": "aa:bb:cc:dd:ee:01"})
+
+	select {
+	case ev := <-sub.Events():
+		if ev.Type != "assoc" {
+			t.Errorf("unexpected event: %+v", ev)
+		}
+	case <-time.After(time.Second):
+		t.Error("no event delivered")
+	}
+
+	_, err = goubus.Objects(ctx, st, "")
+	if !errdefs.IsNotSupported(err) {
+		t.Errorf("expected the wrapped transport to decide on introspection, got %v", err)
+	}
+
+	results, err := goubus.CallBatch(ctx, st, []goubus.BatchCall{
+		{Service: "system", Method: "board"},
+		{Service: "system", Method: "board", Data: []string{"not", "an", "object"}},
+		{Service: "system", Method: "board", Data: map[string]any{"verbose": true}},
+	})
+	if err != nil {
+		t.Fatalf("CallBatch failed: %v", err)
+	}
+
+	if results[0].Err != nil || !errdefs.IsInvalidParameter(results[1].Err) || results[2].Err != nil {
+		t.Errorf("unexpected results: %+v", results)
+	}
+
+	if len(mock.Calls) != 2 {
+		t.Fatalf("expected the invalid call not to be sent, got %+v", mock.Calls)
+	}
+
+	for _, call := range mock.Calls {
+		if args, _ := call.Data.(map[string]any); args["ubus_rpc_session"] != "sid" {
+			t.Errorf("expected the session in every batched call, got %v", call.Data)
+		}
+	}
+}
@@ -39,6 +39,8 @@ type SocketClient struct {
 	conn         net.Conn
 	logger       *slog.Logger
 	objectCache  map[string]uint32
+	metrics      callMetrics
+	interceptors []CallInterceptor
 	sockPath     string
 	dialTimeout  time.Duration
 	readTimeout  time.Duration
@@ -83,6 +85,28 @@ func WithWriteTimeout(timeout time.Duration) SocketOption {
 	}
 }
 
+// WithSocketMetrics sets a hook that receives the phase timing of every call.
+func WithSocketMetrics(hook MetricsHook) SocketOption {
+	return func(c *SocketClient) {
+		c.metrics.hook = hook
+	}
+}
+
+// WithSocketErrorTiming attaches the phase timing to the errors of failed calls as a *TimingError.
+func WithSocketErrorTiming() SocketOption {
+	return func(c *SocketClient) {
+		c.metrics.errorTiming = true
+	}
+}
+
+// WithSocketInterceptors adds interceptors that run around every Call, the first one outermost.
+// CallBatch then sends its calls one at a time, so that batched calls are intercepted too.
+func WithSocketInterceptors(interceptors ...CallInterceptor) SocketOption {
+	return func(c *SocketClient) {
+		c.interceptors = append(c.interceptors, interceptors...)
+	}
+}
+
 // NewSocketClient creates a new ubus socket client and performs the HELLO handshake.
 // If sockPath is empty, it uses the default path (/tmp/run/ubus/ubus.sock).
 func NewSocketClient(ctx context.Context, sockPath string, opts ...SocketOption) (*SocketClient, error) {
@@ -113,7 +137,7 @@ func NewSocketClient(ctx context.Context, sockPath string, opts ...SocketOption)
 
 	conn, err := dialer.DialContext(ctx, "unix", client.sockPath)
 	if err != nil {
-		return nil, errdefs.Wrapf(errdefs.ErrConnectionFailed, "dial unix socket: %v", err)
+		return nil, errdefs.Wrapf(errdefs.FromTransport(err), "dial unix socket")
 	}
 
 	client.conn = conn
@@ -167,33 +191,39 @@ func (c *SocketClient) SetLogger(logger *slog.Logger) {
 
 // Call invokes a ubus method through the socket transport.
 func (c *SocketClient) Call(ctx context.Context, service, method string, data any) (Result, error) {
-	if service == "" || method == "" {
-		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "service and method required")
-	}
+	return chainInterceptors(c.interceptors, c.call)(ctx, service, method, data)
+}
 
-	args, err := blobmsg.NormalizeArgs(data)
-	if err != nil {
-		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "normalize arguments: %v", err)
-	}
+// call performs a call without the interceptors.
+func (c *SocketClient) call(ctx context.Context, service, method string, data any) (Result, error) {
+	timing := CallTiming{Service: service, Method: method}
 
-	objectID, err := c.getObjectID(service)
+	body, args, err := c.prepareInvoke(&timing, service, method, data)
 	if err != nil {
-		return nil, err
+		return nil, c.metrics.finish(ctx, &timing, err)
 	}
 
-	body, err := c.createInvokeBody(objectID, method, args)
-	if err != nil {
-		return nil, err
-	}
+	res, err := c.invoke(&timing, body, args)
+
+	return res, c.metrics.finish(ctx, &timing, err)
+}
+
+// invoke sends the encoded INVOKE message of a call and reads its reply.
+func (c *SocketClient) invoke(timing *CallTiming, body []byte, args map[string]any) (Result, error) {
+	start := time.Now()
 
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	start = lap(&timing.Queue, start)
+
 	if c.closed {
 		return nil, errdefs.ErrClosed
 	}
 
-	err = c.sendMessage(blobmsg.UbusMsgInvoke, body)
+	err := c.sendMessage(blobmsg.UbusMsgInvoke, body)
+	lap(&timing.Network, start)
+
 	if err != nil {
 		return nil, err
 	}
@@ -201,12 +231,47 @@ func (c *SocketClient) Call(ctx context.Context, service, method string, data an
 	const logBodyLimit = logJSONLimit * 2
 
 	c.logger.Debug("Invoke",
-		slog.String("service", service),
-		slog.String("method", method),
+		slog.String("service", timing.Service),
+		slog.String("method", timing.Method),
 		slog.String("args", previewJSON(args, logBodyLimit)),
 		slog.String("body", hexPreview(body, logLongHexLimit)))
 
-	return c.handleCallResponse()
+	return c.handleCallResponse(timing)
+}
+
+// prepareInvoke resolves the object of a call and encodes its INVOKE message body.
+// The object lookup counts as network time, the rest as encoding time.
+func (c *SocketClient) prepareInvoke(
+	timing *CallTiming, service, method string, data any,
+) ([]byte, map[string]any, error) {
+	if service == "" || method == "" {
+		return nil, nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "service and method required")
+	}
+
+	start := time.Now()
+
+	args, err := blobmsg.NormalizeArgs(data)
+	if err != nil {
+		return nil, nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "normalize arguments: %v", err)
+	}
+
+	start = lap(&timing.Encode, start)
+
+	objectID, err := c.getObjectID(service)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	start = lap(&timing.Network, start)
+
+	body, err := c.createInvokeBody(objectID, method, args)
+	lap(&timing.Encode, start)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return body, args, nil
 }
 
 func (c *SocketClient) DialTimeout() time.Duration {
@@ -241,7 +306,7 @@ func (c *SocketClient) Close() error {
 	return nil
 }
 
-func (c *SocketClient) handleCallResponse() (Result, error) {
+func (c *SocketClient) handleCallResponse(timing *CallTiming) (Result, error) {
 	var (
 		resultData map[string]any
 		statusCode uint32
@@ -249,11 +314,15 @@ func (c *SocketClient) handleCallResponse() (Result, error) {
 	)
 
 	for !statusSeen {
+		start := time.Now()
+
 		hdr, payload, err := blobmsg.ReadMessage(c.conn)
 		if err != nil {
 			return nil, err
 		}
 
+		start = lap(&timing.Network, start)
+
 		attrs, err := blobmsg.ParseTopLevelAttributes(payload)
 		if err != nil {
 			return nil, errdefs.Wrapf(errdefs.ErrInvalidResponse, "parse invoke response: %v", err)
@@ -280,6 +349,8 @@ func (c *SocketClient) handleCallResponse() (Result, error) {
 		default:
 			c.logger.Debug("ignored message during invoke", slog.Int("type", int(hdr.Type)))
 		}
+
+		lap(&timing.Decode, start)
 	}
 
 	return &socketResult{
@@ -301,6 +372,10 @@ func (c *SocketClient) getObjectID(path string) (uint32, error) {
 	c.objectMu.RUnlock()
 
 	objects, err := c.listObjects(path)
+	if errdefs.IsNotFound(err) {
+		return 0, missingObject(path)
+	}
+
 	if err != nil {
 		return 0, err
 	}
@@ -322,7 +397,35 @@ func (c *SocketClient) getObjectID(path string) (uint32, error) {
 		}
 	}
 
-	return 0, errdefs.Wrapf(errdefs.ErrNotFound, "object '%s' not found", path)
+	return 0, missingObject(path)
+}
+
+// Objects lists the registered ubus objects matching pattern together with their method signatures.
+func (c *SocketClient) Objects(ctx context.Context, pattern string) ([]ObjectInfo, error) {
+	err := ctx.Err()
+	if err != nil {
+		return nil, errdefs.Wrapf(errdefs.ErrTimeout, "lookup: %v", err)
+	}
+
+	replies, err := c.listObjects(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]ObjectInfo, 0, len(replies))
+
+	for _, attrs := range replies {
+		if info, ok := newObjectInfo(attrs); ok {
+			objects = append(objects, info)
+		}
+	}
+
+	return sortObjects(objects), nil
+}
+
+// Lookup describes a single registered ubus object.
+func (c *SocketClient) Lookup(ctx context.Context, object string) (*ObjectInfo, error) {
+	return Lookup(ctx, c, object)
 }
 
 func (c *SocketClient) listObjects(path string) ([]map[string]any, error) {
@@ -396,12 +499,12 @@ func (c *SocketClient) handleLookupResponse() ([]map[string]any, error) {
 func (c *SocketClient) exchangeHello() error {
 	err := c.conn.SetReadDeadline(time.Now().Add(c.readTimeout))
 	if err != nil {
-		return errdefs.Wrapf(errdefs.ErrConnectionFailed, "set read deadline: %v", err)
+		return errdefs.Wrapf(errdefs.FromTransport(err), "set read deadline")
 	}
 
 	hdr, payload, err := blobmsg.ReadMessage(c.conn)
 	if err != nil {
-		return errdefs.Wrapf(errdefs.ErrConnectionFailed, "read hello: %v", err)
+		return errdefs.Wrapf(errdefs.FromTransport(err), "read hello")
 	}
 
 	if hdr.Type != blobmsg.UbusMsgHello {
@@ -417,8 +520,6 @@ func (c *SocketClient) exchangeHello() error {
 }
 
 func (c *SocketClient) sendMessage(msgType uint8, body []byte) error {
-	var buf bytes.Buffer
-
 	header := &blobmsg.UbusMessageHeader{
 		Version: 0,
 		Type:    msgType,
@@ -427,6 +528,12 @@ func (c *SocketClient) sendMessage(msgType uint8, body []byte) error {
 	}
 	c.seq++
 
+	return c.writeMessage(header, body)
+}
+
+func (c *SocketClient) writeMessage(header *blobmsg.UbusMessageHeader, body []byte) error {
+	var buf bytes.Buffer
+
 	err := blobmsg.EncodeHeader(&buf, header)
 	if err != nil {
 		return errdefs.Wrapf(errdefs.ErrInvalidParameter, "encode header: %v", err)
@@ -447,12 +554,12 @@ func (c *SocketClient) sendMessage(msgType uint8, body []byte) error {
 
 	err = c.conn.SetWriteDeadline(time.Now().Add(c.writeTimeout))
 	if err != nil {
-		return errdefs.Wrapf(errdefs.ErrConnectionFailed, "set write deadline: %v", err)
+		return errdefs.Wrapf(errdefs.FromTransport(err), "set write deadline")
 	}
 
 	_, err = c.conn.Write(buf.Bytes())
 	if err != nil {
-		return errdefs.Wrapf(errdefs.ErrConnectionFailed, "write message: %v", err)
+		return errdefs.Wrapf(errdefs.FromTransport(err), "write message")
 	}
 
 	return nil
@@ -0,0 +1,148 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package goubus
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"time"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/blobmsg"
+)
+
+var _ Batcher = (*SocketClient)(nil)
+
+// Batch creates an empty batch whose calls are pipelined over the socket.
+func (c *SocketClient) Batch() *Batch {
+	return NewBatch(c)
+}
+
+// CallBatch pipelines calls over the socket: every INVOKE is written before any reply is read,
+// and the replies are matched to their calls by sequence number.
+// Calls that cannot be encoded or whose object does not exist fail individually. Every call
+// reports its timing to the metrics hook, sharing the wait for the socket and the round trip
+// with the other calls. A client with interceptors sends the calls one at a time instead, so
+// that every call passes through them.
+func (c *SocketClient) CallBatch(ctx context.Context, calls []BatchCall) ([]BatchResult, error) {
+	if len(c.interceptors) > 0 {
+		return callEach(ctx, c, calls)
+	}
+
+	results := make([]BatchResult, len(calls))
+	bodies := make([][]byte, len(calls))
+	timings := make([]CallTiming, len(calls))
+
+	for i, call := range calls {
+		timings[i] = CallTiming{Service: call.Service, Method: call.Method}
+		bodies[i], _, results[i].Err = c.prepareInvoke(&timings[i], call.Service, call.Method, call.Data)
+	}
+
+	err := ctx.Err()
+	if err != nil {
+		return nil, errdefs.Wrapf(errdefs.ErrTimeout, "batch: %v", err)
+	}
+
+	var shared CallTiming
+
+	err = c.pipeline(bodies, results, &shared)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range timings {
+		if bodies[i] != nil {
+			timings[i].Queue += shared.Queue
+			timings[i].Network += shared.Network
+		}
+	}
+
+	c.metrics.finishBatch(ctx, timings, results)
+
+	return results, nil
+}
+
+// pipeline writes the encoded invokes and reads their replies into results, adding the wait for
+// the socket and the round trip to timing.
+func (c *SocketClient) pipeline(bodies [][]byte, results []BatchResult, timing *CallTiming) error {
+	start := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	start = lap(&timing.Queue, start)
+
+	if c.closed {
+		return errdefs.ErrClosed
+	}
+
+	pending := make(map[uint16]int, len(bodies))
+
+	for i, body := range bodies {
+		if results[i].Err != nil {
+			continue
+		}
+
+		pending[c.seq] = i
+
+		err := c.sendMessage(blobmsg.UbusMsgInvoke, body)
+		if err != nil {
+			return err
+		}
+	}
+
+	c.logger.Debug("Batch invoke", slog.Int("calls", len(bodies)), slog.Int("sent", len(pending)))
+
+	err := c.readBatchReplies(pending, results)
+	lap(&timing.Network, start)
+
+	return err
+}
+
+// readBatchReplies collects the DATA and STATUS replies of the pending invokes, keyed by sequence number.
+func (c *SocketClient) readBatchReplies(pending map[uint16]int, results []BatchResult) error {
+	data := make(map[uint16]map[string]any, len(pending))
+
+	for len(pending) > 0 {
+		hdr, payload, err := blobmsg.ReadMessage(c.conn)
+		if err != nil {
+			return err
+		}
+
+		index, ok := pending[hdr.Seq]
+		if !ok {
+			c.logger.Debug("ignored message during batch", slog.Int("type", int(hdr.Type)), slog.Int("seq", int(hdr.Seq)))
+
+			continue
+		}
+
+		attrs, err := blobmsg.ParseTopLevelAttributes(payload)
+		if err != nil {
+			return errdefs.Wrapf(errdefs.ErrInvalidResponse, "parse batch response: %v", err)
+		}
+
+		switch hdr.Type {
+		case blobmsg.UbusMsgData:
+			extracted := blobmsg.ExtractDataSection(attrs)
+			if len(extracted) != 0 {
+				if data[hdr.Seq] == nil {
+					data[hdr.Seq] = make(map[string]any, len(extracted))
+				}
+
+				maps.Copy(data[hdr.Seq], extracted)
+			}
+		case blobmsg.UbusMsgStatus:
+			status, _ := blobmsg.ReadUint(attrs["status"])
+			results[index].Result = &socketResult{data: data[hdr.Seq], status: status}
+			results[index].Err = MapUbusCodeToError(int(status))
+
+			delete(pending, hdr.Seq)
+		default:
+			c.logger.Debug("ignored message during batch", slog.Int("type", int(hdr.Type)))
+		}
+	}
+
+	return nil
+}
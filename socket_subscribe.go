@@ -0,0 +1,235 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package goubus
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/blobmsg"
+)
+
+// ubusSystemObjectEvent is the id of the ubusd object that manages event listeners.
+const ubusSystemObjectEvent = 1
+
+var _ Subscriber = (*SocketClient)(nil)
+
+// Subscribe delivers the notifications sent by a ubus object.
+// Each subscription uses its own connection to ubusd, so calls on the client are not affected.
+func (c *SocketClient) Subscribe(ctx context.Context, object string) (*Subscription, error) {
+	targetID, err := c.getObjectID(object)
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := c.dialListener(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := blobmsg.CreateBlobMessage(map[uint32]any{
+		blobmsg.UbusAttrObjID:  listener.objectID,
+		blobmsg.UbusAttrTarget: targetID,
+	}, []uint32{blobmsg.UbusAttrObjID, blobmsg.UbusAttrTarget})
+	if err != nil {
+		_ = listener.Close()
+
+		return nil, err
+	}
+
+	err = listener.request(blobmsg.UbusMsgSubscribe, body)
+	if err != nil {
+		_ = listener.Close()
+
+		return nil, errdefs.Wrapf(err, "subscribe to %s", object)
+	}
+
+	return NewSubscription(ctx, func(ctx context.Context, emit func(Event) bool) error {
+		return listener.serve(ctx, object, emit)
+	}), nil
+}
+
+// Listen delivers the ubus events matching pattern.
+// Each listener uses its own connection to ubusd, so calls on the client are not affected.
+func (c *SocketClient) Listen(ctx context.Context, pattern string) (*Subscription, error) {
+	listener, err := c.dialListener(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := listener.createInvokeBody(ubusSystemObjectEvent, "register", map[string]any{
+		"object":  listener.objectID,
+		"pattern": pattern,
+	})
+	if err != nil {
+		_ = listener.Close()
+
+		return nil, err
+	}
+
+	err = listener.request(blobmsg.UbusMsgInvoke, body)
+	if err != nil {
+		_ = listener.Close()
+
+		return nil, errdefs.Wrapf(err, "listen for %s", pattern)
+	}
+
+	return NewSubscription(ctx, func(ctx context.Context, emit func(Event) bool) error {
+		return listener.serve(ctx, pattern, emit)
+	}), nil
+}
+
+// socketListener is a dedicated ubusd connection owning an anonymous object that receives
+// notifications and events.
+type socketListener struct {
+	*SocketClient
+
+	objectID uint32
+}
+
+func (c *SocketClient) dialListener(ctx context.Context) (*socketListener, error) {
+	client, err := NewSocketClient(ctx, c.sockPath,
+		WithSocketLogger(c.logger),
+		WithDialTimeout(c.dialTimeout),
+		WithReadTimeout(c.readTimeout),
+		WithWriteTimeout(c.writeTimeout))
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &socketListener{SocketClient: client}
+
+	body, err := blobmsg.CreateBlobMessage(map[uint32]any{}, nil)
+	if err != nil {
+		_ = client.Close()
+
+		return nil, err
+	}
+
+	data, err := listener.requestData(blobmsg.UbusMsgAddObject, body)
+	if err != nil {
+		_ = client.Close()
+
+		return nil, errdefs.Wrapf(err, "add subscriber object")
+	}
+
+	objectID, ok := blobmsg.ReadUint(data["objid"])
+	if !ok {
+		_ = client.Close()
+
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidResponse, "add object response without object id")
+	}
+
+	listener.objectID = objectID
+
+	return listener, nil
+}
+
+// request sends a message and waits for its status.
+func (l *socketListener) request(msgType uint8, body []byte) error {
+	_, err := l.requestData(msgType, body)
+
+	return err
+}
+
+// requestData sends a message and collects the attributes of the data replies until the status arrives.
+func (l *socketListener) requestData(msgType uint8, body []byte) (map[string]any, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	err := l.sendMessage(msgType, body)
+	if err != nil {
+		return nil, err
+	}
+
+	data := map[string]any{}
+
+	for {
+		hdr, payload, err := blobmsg.ReadMessage(l.conn)
+		if err != nil {
+			return nil, err
+		}
+
+		attrs, err := blobmsg.ParseTopLevelAttributes(payload)
+		if err != nil {
+			return nil, errdefs.Wrapf(errdefs.ErrInvalidResponse, "parse response: %v", err)
+		}
+
+		switch hdr.Type {
+		case blobmsg.UbusMsgData:
+			for k, v := range attrs {
+				data[k] = v
+			}
+		case blobmsg.UbusMsgStatus:
+			status, _ := blobmsg.ReadUint(attrs["status"])
+
+			return data, MapUbusCodeToError(int(status))
+		default:
+			l.logger.Debug("ignored message during request", slog.Int("type", int(hdr.Type)))
+		}
+	}
+}
+
+// serve reads notifications until ctx is cancelled or the connection fails.
+func (l *socketListener) serve(ctx context.Context, source string, emit func(Event) bool) error {
+	stop := context.AfterFunc(ctx, func() { _ = l.Close() })
+	defer stop()
+	defer func() { _ = l.Close() }()
+
+	err := l.conn.SetReadDeadline(time.Time{})
+	if err != nil {
+		return errdefs.Wrapf(errdefs.FromTransport(err), "clear read deadline")
+	}
+
+	for {
+		hdr, payload, err := blobmsg.ReadMessage(l.conn)
+		if err != nil {
+			return err
+		}
+
+		attrs, err := blobmsg.ParseTopLevelAttributes(payload)
+		if err != nil {
+			return errdefs.Wrapf(errdefs.ErrInvalidResponse, "parse notification: %v", err)
+		}
+
+		switch hdr.Type {
+		case blobmsg.UbusMsgInvoke:
+			if _, noReply := attrs["no_reply"]; !noReply {
+				l.replyStatus(hdr)
+			}
+
+			method, _ := attrs["method"].(string)
+			data, _ := attrs["data"].(map[string]any)
+
+			if !emit(Event{Object: source, Type: method, Data: data}) {
+				return nil
+			}
+		case blobmsg.UbusMsgUnsubscribe:
+			return errdefs.Wrapf(errdefs.ErrNotFound, "object %s was removed", source)
+		default:
+			l.logger.Debug("ignored message on listener", slog.Int("type", int(hdr.Type)))
+		}
+	}
+}
+
+// replyStatus acknowledges a notification that expects a reply.
+func (l *socketListener) replyStatus(req *blobmsg.UbusMessageHeader) {
+	body, err := blobmsg.CreateBlobMessage(map[uint32]any{
+		blobmsg.UbusAttrStatus: uint32(UbusStatusOK),
+		blobmsg.UbusAttrObjID:  l.objectID,
+	}, []uint32{blobmsg.UbusAttrStatus, blobmsg.UbusAttrObjID})
+	if err != nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	err = l.writeMessage(&blobmsg.UbusMessageHeader{Type: blobmsg.UbusMsgStatus, Seq: req.Seq, Peer: req.Peer}, body)
+	if err != nil {
+		l.logger.Debug("failed to acknowledge notification", slog.String("error", err.Error()))
+	}
+}
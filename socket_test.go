@@ -3,12 +3,14 @@ package goubus_test
 import (
 	"bytes"
 	"context"
+	"errors"
 	"net"
 	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
 	"github.com/honeybbq/goubus/v2/internal/blobmsg"
 	"github.com/honeybbq/goubus/v2/internal/logging"
 )
@@ -120,6 +122,105 @@ func TestSocketClient_Call(t *testing.T) {
 	}
 }
 
+func TestSocketClient_Lookup(t *testing.T) {
+	sockPath := filepath.Join(t.TempDir(), "ubus.sock")
+
+	var lc net.ListenConfig
+
+	listener, err := lc.Listen(context.Background(), "unix", sockPath)
+	if err != nil {
+		t.Skipf("unix sockets not supported: %v", err)
+	}
+
+	defer func() {
+		_ = listener.Close()
+	}()
+
+	go mockUbusd(t, listener)
+
+	ctx := context.Background()
+
+	client, err := goubus.NewSocketClient(ctx, sockPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		_ = client.Close()
+	}()
+
+	info, err := client.Lookup(ctx, "system")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.ID != 100 || !info.HasMethod("info") || info.HasMethod("board") {
+		t.Errorf("unexpected object: %+v", info)
+	}
+
+	reboot := info.Methods["reboot"]
+	if reboot["delay"] != goubus.ArgTypeNumber || reboot["force"] != goubus.ArgTypeBoolean {
+		t.Errorf("unexpected reboot signature: %v", reboot)
+	}
+
+	_, err = client.Lookup(ctx, "mwan3")
+	if !errdefs.IsNotFound(err) {
+		t.Errorf("expected not found, got %v", err)
+	}
+}
+
+func TestSocketClient_CallBatch(t *testing.T) {
+	sockPath := filepath.Join(t.TempDir(), "ubus.sock")
+
+	var lc net.ListenConfig
+
+	listener, err := lc.Listen(context.Background(), "unix", sockPath)
+	if err != nil {
+		t.Skipf("unix sockets not supported: %v", err)
+	}
+
+	defer func() {
+		_ = listener.Close()
+	}()
+
+	go mockUbusd(t, listener)
+
+	ctx := context.Background()
+
+	client, err := goubus.NewSocketClient(ctx, sockPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		_ = client.Close()
+	}()
+
+	batch := client.Batch()
+	first := batch.Add("system", "info", nil)
+	missing := batch.Add("mwan3", "status", nil)
+	second := batch.Add("system", "info", nil)
+
+	results, err := batch.Do(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, index := range []int{first, second} {
+		info, err := goubus.BatchAs[struct {
+			Hostname string `json:"hostname"`
+		}](results[index])
+		if err != nil || info.Hostname != "OpenWrt" {
+			t.Errorf("unexpected result %d: %+v (%v)", index, info, err)
+		}
+	}
+
+	var capErr *errdefs.CapabilityError
+	if !errors.As(results[missing].Err, &capErr) || capErr.Package != "mwan3" || !errdefs.IsNotSupported(capErr) {
+		t.Errorf("expected a capability error, got %v", results[missing].Err)
+	}
+}
+
 func mockUbusd(t *testing.T, l net.Listener) {
 	t.Helper()
 
@@ -165,20 +266,31 @@ func handleLookup(conn net.Conn, seq uint16, payload []byte) {
 		return
 	}
 
-	if path == "system" {
-		// Send Data
-		dataAttrs := map[uint32]any{
-			blobmsg.UbusAttrObjPath: "system",
-			blobmsg.UbusAttrObjID:   uint32(100),
-		}
-		dataBody, _ := blobmsg.CreateBlobMessage(dataAttrs, nil)
-		sendMsg(conn, blobmsg.UbusMsgData, seq, dataBody)
-
-		// Send Status
-		statusAttrs := map[uint32]any{blobmsg.UbusAttrStatus: uint32(0)}
+	if path != "system" {
+		statusAttrs := map[uint32]any{blobmsg.UbusAttrStatus: uint32(4)}
 		statusBody, _ := blobmsg.CreateBlobMessage(statusAttrs, nil)
 		sendMsg(conn, blobmsg.UbusMsgStatus, seq, statusBody)
+
+		return
 	}
+
+	// Send Data
+	signature, _ := blobmsg.CreateBlobmsgTable(map[string]any{
+		"info":   map[string]any{},
+		"reboot": map[string]any{"delay": blobmsg.TypeInt32, "force": blobmsg.TypeBool},
+	})
+	dataAttrs := map[uint32]any{
+		blobmsg.UbusAttrObjPath:   "system",
+		blobmsg.UbusAttrObjID:     uint32(100),
+		blobmsg.UbusAttrSignature: signature[4:],
+	}
+	dataBody, _ := blobmsg.CreateBlobMessage(dataAttrs, nil)
+	sendMsg(conn, blobmsg.UbusMsgData, seq, dataBody)
+
+	// Send Status
+	statusAttrs := map[uint32]any{blobmsg.UbusAttrStatus: uint32(0)}
+	statusBody, _ := blobmsg.CreateBlobMessage(statusAttrs, nil)
+	sendMsg(conn, blobmsg.UbusMsgStatus, seq, statusBody)
 }
 
 func handleInvoke(conn net.Conn, seq uint16, payload []byte) {
@@ -294,3 +406,106 @@ func TestSocketClient_Options(t *testing.T) {
 		t.Errorf("writeTimeout mismatch")
 	}
 }
+
+func TestSocketClient_Subscribe(t *testing.T) {
+	sockPath := filepath.Join(t.TempDir(), "ubus_subscribe.sock")
+
+	var lc net.ListenConfig
+
+	listener, err := lc.Listen(context.Background(), "unix", sockPath)
+	if err != nil {
+		t.Skipf("unix sockets not supported: %v", err)
+	}
+
+	defer func() {
+		_ = listener.Close()
+	}()
+
+	go func() {
+		for {
+			conn, errAccept := listener.Accept()
+			if errAccept != nil {
+				return
+			}
+
+			go mockUbusdSubscriber(conn)
+		}
+	}()
+
+	ctx := context.Background()
+
+	client, err := goubus.NewSocketClient(ctx, sockPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		_ = client.Close()
+	}()
+
+	sub, err := client.Subscribe(ctx, "system")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case ev := <-sub.Events():
+		var radar struct {
+			Frequency int `json:"frequency"`
+		}
+
+		errUnmarshal := ev.Unmarshal(&radar)
+		if errUnmarshal != nil || ev.Object != "system" || ev.Type != "radar-detected" || radar.Frequency != 5500 {
+			t.Errorf("unexpected event: %+v (%v)", ev, errUnmarshal)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for notification")
+	}
+
+	_ = sub.Close()
+
+	if sub.Err() != nil {
+		t.Errorf("unexpected error after close: %v", sub.Err())
+	}
+}
+
+// mockUbusdSubscriber serves lookups, registers subscriber objects and sends one notification per subscription.
+func mockUbusdSubscriber(conn net.Conn) {
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	var buf bytes.Buffer
+
+	_ = blobmsg.EncodeHeader(&buf, &blobmsg.UbusMessageHeader{Type: blobmsg.UbusMsgHello, Peer: 1})
+	_, _ = buf.Write([]byte{0, 0, 0, 4})
+	_, _ = conn.Write(buf.Bytes())
+
+	statusBody, _ := blobmsg.CreateBlobMessage(map[uint32]any{blobmsg.UbusAttrStatus: uint32(0)}, nil)
+
+	for {
+		hdr, payload, errRead := blobmsg.ReadMessage(conn)
+		if errRead != nil {
+			return
+		}
+
+		switch hdr.Type {
+		case blobmsg.UbusMsgLookup:
+			handleLookup(conn, hdr.Seq, payload)
+		case blobmsg.UbusMsgAddObject:
+			dataBody, _ := blobmsg.CreateBlobMessage(map[uint32]any{blobmsg.UbusAttrObjID: uint32(200)}, nil)
+			sendMsg(conn, blobmsg.UbusMsgData, hdr.Seq, dataBody)
+			sendMsg(conn, blobmsg.UbusMsgStatus, hdr.Seq, statusBody)
+		case blobmsg.UbusMsgSubscribe:
+			sendMsg(conn, blobmsg.UbusMsgStatus, hdr.Seq, statusBody)
+
+			notifyData, _ := blobmsg.CreateBlobmsgTable(map[string]any{"frequency": 5500, "width": 3})
+			notifyBody, _ := blobmsg.CreateBlobMessage(map[uint32]any{
+				blobmsg.UbusAttrObjID:  uint32(200),
+				blobmsg.UbusAttrMethod: "radar-detected",
+				blobmsg.UbusAttrData:   notifyData[4:],
+			}, []uint32{blobmsg.UbusAttrObjID, blobmsg.UbusAttrMethod, blobmsg.UbusAttrData})
+			sendMsg(conn, blobmsg.UbusMsgInvoke, 1, notifyBody)
+		}
+	}
+}
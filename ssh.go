@@ -0,0 +1,352 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package goubus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/logging"
+)
+
+const (
+	defaultSSHBinary = "ssh"
+	// sshExitNotExecutable and sshExitNotFound are the exit codes of the remote shell when ubus
+	// cannot be run.
+	sshExitNotExecutable = 126
+	sshExitNotFound      = 127
+	// sshExitUsage is the exit code of ubus when it rejects its command line.
+	sshExitUsage = 254
+	// sshExitConnectionFailed is the exit code of ssh(1) when the connection or authentication fails.
+	sshExitConnectionFailed = 255
+)
+
+// SSHConfig describes how an SSHClient reaches the device. Everything not set here comes from
+// the ssh configuration of the user, e.g. ~/.ssh/config and the ssh agent.
+type SSHConfig struct {
+	// User is the login user; OpenWrt usually only has root.
+	User string
+	// IdentityFile is the private key to authenticate with.
+	IdentityFile string
+	// Binary is the ssh client to run; it defaults to "ssh" from PATH.
+	Binary string
+	// Options are passed as "-o" options, e.g. "StrictHostKeyChecking=accept-new", or
+	// "ControlMaster=auto", "ControlPath=~/.ssh/goubus-%C" and "ControlPersist=60" to reuse one
+	// connection for all calls.
+	Options []string
+	// Port defaults to 22.
+	Port int
+}
+
+// SSHClient implements Transport by running "ubus call" on the device over ssh(1), for devices
+// that accept SSH logins but do not expose rpcd over HTTP.
+//
+// The client needs an OpenSSH compatible ssh binary on the local machine; it does not speak the
+// SSH protocol itself. Every call starts an ssh process and, unless connection sharing is enabled
+// in SSHConfig.Options, a new connection, so calls are far slower than over the other transports.
+// ssh runs in batch mode, so password logins are not supported; authenticate with a key or the
+// ssh agent.
+type SSHClient struct {
+	logger     *slog.Logger
+	host       string
+	config     SSHConfig
+	registered registeredObjects
+	closed     atomic.Bool
+}
+
+var (
+	_ Transport    = (*SSHClient)(nil)
+	_ Introspector = (*SSHClient)(nil)
+)
+
+// SSHOption defines a functional option for an SSHClient.
+type SSHOption func(*SSHClient)
+
+// WithSSHLogger sets the logger for the SSH client.
+func WithSSHLogger(logger *slog.Logger) SSHOption {
+	return func(c *SSHClient) {
+		c.SetLogger(logger)
+	}
+}
+
+// NewSSHClient creates a client for host and checks that it can log in and reach ubusd.
+func NewSSHClient(ctx context.Context, host string, config SSHConfig, opts ...SSHOption) (*SSHClient, error) {
+	if host == "" {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "ssh host is required")
+	}
+
+	if config.Binary == "" {
+		config.Binary = defaultSSHBinary
+	}
+
+	client := &SSHClient{
+		logger: logging.Discard(),
+		host:   host,
+		config: config,
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	_, err := client.run(ctx, "list", "session")
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "failed to reach ubus on %s", host)
+	}
+
+	return client, nil
+}
+
+// SetLogger sets the logger for the SSH client.
+func (c *SSHClient) SetLogger(logger *slog.Logger) {
+	if logger == nil {
+		c.logger = logging.Discard()
+	} else {
+		c.logger = logger
+	}
+}
+
+// Call runs "ubus call" for service and method on the device.
+func (c *SSHClient) Call(ctx context.Context, service, method string, data any) (Result, error) {
+	if c.closed.Load() {
+		return nil, errdefs.ErrClosed
+	}
+
+	out, err := c.run(ctx, "call", service, method, encodeRequestData(data))
+	// A missing object and a method reply such as a missing uci section both fail with "Not found";
+	// the object is listed once to tell them apart.
+	if errdefs.IsNotFound(err) && c.registered.missing(service, func() error {
+		_, lookupErr := c.run(ctx, "list", service)
+
+		return lookupErr
+	}) {
+		return nil, missingObject(service)
+	}
+
+	if err != nil {
+		return nil, errdefs.Wrapf(err, "%s.%s", service, method)
+	}
+
+	return sshResult(out), nil
+}
+
+// Objects lists the registered ubus objects matching pattern together with their method signatures,
+// by parsing the output of "ubus -v list".
+func (c *SSHClient) Objects(ctx context.Context, pattern string) ([]ObjectInfo, error) {
+	if c.closed.Load() {
+		return nil, errdefs.ErrClosed
+	}
+
+	args := []string{"-v", "list"}
+	if pattern != "" {
+		args = append(args, pattern)
+	}
+
+	out, err := c.run(ctx, args...)
+	// ubus fails with "Not found" when nothing matches the pattern.
+	if errdefs.IsNotFound(err) {
+		return []ObjectInfo{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parseVerboseList(out)
+}
+
+// Lookup describes a single registered ubus object.
+func (c *SSHClient) Lookup(ctx context.Context, object string) (*ObjectInfo, error) {
+	return Lookup(ctx, c, object)
+}
+
+// Supports reports whether every ubus object required by r is registered on the device.
+func (c *SSHClient) Supports(ctx context.Context, r Requirer) (bool, error) {
+	return Supports(ctx, c, r)
+}
+
+// Close marks the client as closed. Connections shared through ControlPersist are left to ssh.
+func (c *SSHClient) Close() error {
+	c.closed.Store(true)
+
+	return nil
+}
+
+// run executes "ubus -S <args>" on the device and returns its standard output. A failure is
+// mapped by its exit code, see sshExitError.
+func (c *SSHClient) run(ctx context.Context, args ...string) ([]byte, error) {
+	quoted := make([]string, 0, len(args)+2)
+	quoted = append(quoted, "ubus", "-S")
+
+	for _, arg := range args {
+		quoted = append(quoted, shellQuote(arg))
+	}
+
+	//nolint:gosec // the binary and its arguments come from the caller's configuration
+	cmd := exec.CommandContext(ctx, c.config.Binary, append(c.sshArgs(), strings.Join(quoted, " "))...)
+
+	var stdout, stderr bytes.Buffer
+
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	c.logger.Debug("ssh ubus", slog.String("host", c.host), slog.Any("args", args))
+
+	err := cmd.Run()
+	if err == nil {
+		return stdout.Bytes(), nil
+	}
+
+	if ctx.Err() != nil {
+		return nil, errdefs.FromTransport(ctx.Err())
+	}
+
+	message := strings.TrimPrefix(strings.TrimSpace(stderr.String()), "Command failed: ")
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return nil, errdefs.Wrapf(errdefs.ErrConnectionFailed, "run %s: %v", c.config.Binary, err)
+	}
+
+	if message == "" {
+		return nil, errdefs.Wrapf(sshExitError(exitErr.ExitCode()), "ubus %s", args[0])
+	}
+
+	return nil, errdefs.Wrapf(sshExitError(exitErr.ExitCode()), "ubus %s: %s", args[0], message)
+}
+
+// sshExitError maps the exit code of ssh running ubus to an error. ssh exits with the code of the
+// remote command, which is the ubus status for a failed call, unless ssh itself or the remote
+// shell failed.
+func sshExitError(code int) error {
+	switch {
+	case code >= UbusStatusInvalidCommand && code <= UbusStatusConnectionFailed:
+		return MapUbusCodeToError(code)
+	case code == sshExitNotExecutable || code == sshExitNotFound:
+		return errdefs.Wrapf(errdefs.ErrNotSupported, "ubus cannot be run on the device")
+	case code == sshExitUsage:
+		return errdefs.Wrapf(errdefs.ErrInvalidCommand, "ubus rejected the command line")
+	case code == sshExitConnectionFailed:
+		return errdefs.Wrapf(errdefs.ErrConnectionFailed, "ssh failed to connect")
+	default:
+		return errdefs.Wrapf(errdefs.ErrUnknown, "exit code %d", code)
+	}
+}
+
+func (c *SSHClient) sshArgs() []string {
+	args := []string{"-o", "BatchMode=yes"}
+
+	if c.config.User != "" {
+		args = append(args, "-l", c.config.User)
+	}
+
+	if c.config.Port != 0 {
+		args = append(args, "-p", strconv.Itoa(c.config.Port))
+	}
+
+	if c.config.IdentityFile != "" {
+		args = append(args, "-i", c.config.IdentityFile)
+	}
+
+	for _, option := range c.config.Options {
+		args = append(args, "-o", option)
+	}
+
+	return append(args, "--", c.host)
+}
+
+// parseVerboseList parses the output of "ubus -v list": a line "'<path>' @<id>" per object,
+// followed by a tab indented line "<method>":{"<arg>":"<type>",...} per method.
+func parseVerboseList(out []byte) ([]ObjectInfo, error) {
+	objects := []ObjectInfo{}
+
+	for line := range strings.Lines(string(out)) {
+		line = strings.TrimRight(line, "\r\n")
+
+		switch {
+		case strings.TrimSpace(line) == "":
+			continue
+		case strings.HasPrefix(line, "'"):
+			quoted, id, _ := strings.Cut(line, " @")
+			info := ObjectInfo{Path: strings.Trim(quoted, "'"), Methods: map[string]map[string]string{}}
+
+			parsed, err := strconv.ParseUint(strings.TrimSpace(id), 16, 32)
+			if err == nil {
+				info.ID = uint32(parsed)
+			}
+
+			objects = append(objects, info)
+		case len(objects) > 0:
+			var method map[string]map[string]string
+
+			err := json.Unmarshal([]byte("{"+strings.TrimSpace(line)+"}"), &method)
+			if err != nil {
+				return nil, errdefs.Wrapf(errdefs.ErrInvalidResponse, "unexpected ubus list line %q", line)
+			}
+
+			for name, args := range method {
+				if args == nil {
+					args = map[string]string{}
+				}
+
+				for arg, typ := range args {
+					args[arg] = cliArgTypeName(typ)
+				}
+
+				objects[len(objects)-1].Methods[name] = args
+			}
+		default:
+			return nil, errdefs.Wrapf(errdefs.ErrInvalidResponse, "unexpected ubus list line %q", line)
+		}
+	}
+
+	return sortObjects(objects), nil
+}
+
+// cliArgTypeName converts an argument type printed by the ubus command line tool.
+func cliArgTypeName(typ string) string {
+	switch typ {
+	case "Array":
+		return ArgTypeArray
+	case "Table":
+		return ArgTypeObject
+	case "String":
+		return ArgTypeString
+	case "Integer":
+		return ArgTypeNumber
+	case "Boolean":
+		return ArgTypeBoolean
+	default:
+		return ArgTypeUnknown
+	}
+}
+
+// shellQuote quotes s for the remote shell ssh passes the command to.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// sshResult is the JSON reply printed by "ubus call".
+type sshResult []byte
+
+func (r sshResult) Unmarshal(target any) error {
+	if len(bytes.TrimSpace(r)) == 0 {
+		return errdefs.ErrNoData
+	}
+
+	err := json.Unmarshal(r, target)
+	if err != nil {
+		return errdefs.Wrapf(errdefs.ErrInvalidResponse, "unmarshal result: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package goubus_test
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+// fakeSSH is an ssh stand-in that logs its arguments and answers the remote ubus commands.
+const fakeSSH = `#!/bin/sh
+for last; do :; done
+printf '%s\n' "$*" >> "$0.log"
+case "$last" in
+"ubus -S 'list' 'session'") exit 0 ;;
+"ubus -S 'list' 'uci'") exit 0 ;;
+"ubus -S '-v' 'list' 'network.interface*'")
+	printf "'network.interface.wan' @2c1a9f0e\n\t\"up\":{}\n\t\"add_device\":{\"name\":\"String\",\"vlan\":\"Array\",\"link-ext\":\"Boolean\"}\n"
+	printf "'network.interface' @1b2e3f40\n\t\"dump\":{}\n" ;;
+"ubus -S '-v' 'list' 'hostapd'") echo "Command failed: Not found" >&2; exit 4 ;;
+"ubus -S 'list' 'hostapd'") echo "Command failed: Not found" >&2; exit 4 ;;
+"ubus -S 'call' 'hostapd' 'get_clients' '{}'") echo "Command failed: Not found" >&2; exit 4 ;;
+"ubus -S 'call' 'system' 'board' '{}'") echo '{"hostname":"OpenWrt","model":"x86"}' ;;
+"ubus -S 'call' 'file' 'read' '{\"path\":\"/tmp/it'\''s\"}'") echo '{"data":"ok"}' ;;
+"ubus -S 'call' 'uci' 'get' '{\"config\":\"nope\"}'") echo "Command failed: Not found" >&2; exit 4 ;;
+"ubus -S 'call' 'luci' 'getVersion' '{}'") echo "sh: ubus: not found" >&2; exit 127 ;;
+"ubus -S 'call' 'luci' 'getFeatures' '{}'") exit 42 ;;
+*) echo "Command failed: Permission denied" >&2; exit 6 ;;
+esac
+`
+
+func TestSSHClient(t *testing.T) {
+	binary := filepath.Join(t.TempDir(), "ssh")
+
+	err := os.WriteFile(binary, []byte(fakeSSH), 0o700)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+
+	client, err := goubus.NewSSHClient(ctx, "router.lan", goubus.SSHConfig{
+		Binary:  binary,
+		User:    "root",
+		Port:    2222,
+		Options: []string{"StrictHostKeyChecking=accept-new"},
+	})
+	if err != nil {
+		t.Fatalf("NewSSHClient failed: %v", err)
+	}
+
+	board, err := goubus.Call[map[string]any](ctx, client, "system", "board", nil)
+	if err != nil || (*board)["hostname"] != "OpenWrt" {
+		t.Fatalf("unexpected board %v: %v", board, err)
+	}
+
+	read, err := goubus.Call[map[string]any](ctx, client, "file", "read", map[string]string{"path": "/tmp/it's"})
+	if err != nil || (*read)["data"] != "ok" {
+		t.Errorf("unexpected read %v: %v", read, err)
+	}
+
+	var capErr *errdefs.CapabilityError
+
+	_, err = client.Call(ctx, "hostapd", "get_clients", nil)
+	if !errors.As(err, &capErr) || capErr.Object != "hostapd" {
+		t.Errorf("expected a capability error for a missing object, got %v", err)
+	}
+
+	for range 2 {
+		_, err = client.Call(ctx, "uci", "get", map[string]string{"config": "nope"})
+		if !errdefs.IsNotFound(err) || errors.As(err, &capErr) {
+			t.Errorf("expected not found, got %v", err)
+		}
+	}
+
+	_, err = client.Call(ctx, "luci", "getVersion", nil)
+	if !errdefs.IsNotSupported(err) {
+		t.Errorf("expected not supported without ubus, got %v", err)
+	}
+
+	_, err = client.Call(ctx, "luci", "getFeatures", nil)
+	if !errdefs.IsUnknown(err) || !strings.Contains(err.Error(), "exit code 42") {
+		t.Errorf("expected an unknown exit code, got %v", err)
+	}
+
+	_, err = client.Call(ctx, "session", "list", nil)
+	if !errdefs.IsPermissionDenied(err) {
+		t.Errorf("expected permission denied, got %v", err)
+	}
+
+	objects, err := client.Objects(ctx, "network.interface*")
+	if err != nil || len(objects) != 2 || objects[0].Path != "network.interface" || objects[0].ID != 0x1b2e3f40 {
+		t.Fatalf("unexpected objects %+v: %v", objects, err)
+	}
+
+	want := map[string]string{"name": goubus.ArgTypeString, "vlan": goubus.ArgTypeArray, "link-ext": goubus.ArgTypeBoolean}
+	if wan := objects[1]; !maps.Equal(wan.Methods["add_device"], want) || !wan.HasMethod("up") {
+		t.Errorf("unexpected methods of %s: %+v", wan.Path, wan.Methods)
+	}
+
+	_, err = goubus.Lookup(ctx, client, "hostapd")
+	if !errdefs.IsNotFound(err) {
+		t.Errorf("expected hostapd not to be found, got %v", err)
+	}
+
+	log, err := os.ReadFile(binary + ".log")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := strings.Count(string(log), "ubus -S 'list' 'uci'"); n != 1 {
+		t.Errorf("expected the registered uci object to be listed once, got %d lookups", n)
+	}
+
+	first, _, _ := strings.Cut(string(log), "\n")
+	if !strings.HasPrefix(first, "-o BatchMode=yes -l root -p 2222 -o StrictHostKeyChecking=accept-new -- router.lan ") {
+		t.Errorf("unexpected ssh arguments: %s", first)
+	}
+
+	_ = client.Close()
+
+	_, err = client.Call(ctx, "system", "board", nil)
+	if !errdefs.IsClosed(err) {
+		t.Errorf("expected closed, got %v", err)
+	}
+}
@@ -3,7 +3,11 @@
 
 package goubus
 
-import "github.com/honeybbq/goubus/v2/errdefs"
+import (
+	"errors"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
 
 // Ubus error codes.
 const (
@@ -43,3 +47,20 @@ func MapUbusCodeToError(code int) error {
 
 	return errdefs.Wrapf(errdefs.ErrUnknown, "unknown ubus error code: %d", code)
 }
+
+// UbusStatus returns the ubus status code carried by err: UbusStatusOK for nil and the code of
+// the errdefs error err wraps otherwise. ok is false for errors without a ubus status, such as a
+// canceled context or a closed client.
+func UbusStatus(err error) (int, bool) {
+	if err == nil {
+		return UbusStatusOK, true
+	}
+
+	for code := UbusStatusInvalidCommand; code <= UbusStatusConnectionFailed; code++ {
+		if errors.Is(err, ubusErrorMap[code]) {
+			return code, true
+		}
+	}
+
+	return UbusStatusUnknown, false
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package goubus
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+const subscriptionBuffer = 64
+
+// Event represents an asynchronous ubus notification or event.
+type Event struct {
+	// Data is the payload of the notification.
+	Data map[string]any `json:"data"`
+	// Object is the ubus object that sent a notification, or the pattern that matched an event.
+	Object string `json:"object"`
+	// Type is the notification type (e.g. "radar-detected") or the event id (e.g. "config.change").
+	Type string `json:"type"`
+}
+
+// Unmarshal decodes the event payload into target.
+func (e *Event) Unmarshal(target any) error {
+	raw, err := json.Marshal(e.Data)
+	if err != nil {
+		return errdefs.Wrapf(errdefs.ErrInvalidResponse, "marshal event: %v", err)
+	}
+
+	err = json.Unmarshal(raw, target)
+	if err != nil {
+		return errdefs.Wrapf(errdefs.ErrInvalidResponse, "unmarshal event: %v", err)
+	}
+
+	return nil
+}
+
+// Subscriber is implemented by transports that can deliver asynchronous ubus notifications and events.
+type Subscriber interface {
+	// Subscribe delivers the notifications sent by a ubus object, like "ubus subscribe".
+	Subscribe(ctx context.Context, object string) (*Subscription, error)
+	// Listen delivers the ubus events matching pattern, like "ubus listen".
+	// A trailing "*" matches any event id with the given prefix.
+	Listen(ctx context.Context, pattern string) (*Subscription, error)
+}
+
+// Subscribe subscribes to the notifications of a ubus object if the transport supports it.
+func Subscribe(ctx context.Context, t Transport, object string) (*Subscription, error) {
+	subscriber, ok := t.(Subscriber)
+	if !ok {
+		return nil, errdefs.Wrapf(errdefs.ErrNotSupported, "transport %T does not support subscriptions", t)
+	}
+
+	return subscriber.Subscribe(ctx, object)
+}
+
+// Listen listens for ubus events matching pattern if the transport supports it.
+func Listen(ctx context.Context, t Transport, pattern string) (*Subscription, error) {
+	subscriber, ok := t.(Subscriber)
+	if !ok {
+		return nil, errdefs.Wrapf(errdefs.ErrNotSupported, "transport %T does not support events", t)
+	}
+
+	return subscriber.Listen(ctx, pattern)
+}
+
+// Subscription delivers events until it is closed, its context is cancelled, or its source fails.
+type Subscription struct {
+	events chan Event
+	done   chan struct{}
+	cancel context.CancelFunc
+	err    error
+	mu     sync.Mutex
+}
+
+// Producer feeds a Subscription. It runs in its own goroutine and should call emit for every
+// event until ctx is done. emit returns false once the subscription is closed.
+type Producer func(ctx context.Context, emit func(Event) bool) error
+
+// NewSubscription starts a Subscription fed by produce. The error returned by produce is
+// reported by Err, unless the subscription was closed.
+// It is intended for Transport implementations and for composing subscriptions.
+func NewSubscription(ctx context.Context, produce Producer) *Subscription {
+	ctx, cancel := context.WithCancel(ctx)
+
+	sub := &Subscription{
+		events: make(chan Event, subscriptionBuffer),
+		done:   make(chan struct{}),
+		cancel: cancel,
+	}
+
+	emit := func(ev Event) bool {
+		select {
+		case sub.events <- ev:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	go func() {
+		defer close(sub.done)
+		defer close(sub.events)
+
+		err := produce(ctx, emit)
+		if err != nil && ctx.Err() == nil {
+			sub.mu.Lock()
+			sub.err = err
+			sub.mu.Unlock()
+		}
+
+		cancel()
+	}()
+
+	return sub
+}
+
+// Events returns the channel on which events are delivered. It is closed when the subscription ends.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Done returns a channel that is closed once the subscription has fully stopped.
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err reports why the subscription ended. It is nil while the subscription is active
+// and after it was closed or its context was cancelled.
+func (s *Subscription) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.err
+}
+
+// Close stops the subscription and waits for its source to shut down.
+func (s *Subscription) Close() error {
+	s.cancel()
+	<-s.done
+
+	return nil
+}
+
+// MergeSubscriptions fans the events of several subscriptions into one.
+// The merged subscription ends when all sources have ended and closes them when it is closed.
+// It reports the first source error.
+func MergeSubscriptions(ctx context.Context, subs ...*Subscription) *Subscription {
+	return NewSubscription(ctx, func(ctx context.Context, emit func(Event) bool) error {
+		var (
+			group    sync.WaitGroup
+			errMu    sync.Mutex
+			firstErr error
+		)
+
+		for _, sub := range subs {
+			group.Go(func() {
+				defer func() { _ = sub.Close() }()
+
+				forward(ctx, sub, emit)
+
+				if err := sub.Err(); err != nil {
+					errMu.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					errMu.Unlock()
+				}
+			})
+		}
+
+		group.Wait()
+
+		return firstErr
+	})
+}
+
+// FilterSubscription delivers only the events of sub accepted by keep.
+func FilterSubscription(ctx context.Context, sub *Subscription, keep func(Event) bool) *Subscription {
+	return NewSubscription(ctx, func(ctx context.Context, emit func(Event) bool) error {
+		defer func() { _ = sub.Close() }()
+
+		forward(ctx, sub, func(ev Event) bool {
+			return !keep(ev) || emit(ev)
+		})
+
+		return sub.Err()
+	})
+}
+
+func forward(ctx context.Context, sub *Subscription, emit func(Event) bool) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev, ok := <-sub.Events():
+			if !ok || !emit(ev) {
+				return
+			}
+		}
+	}
+}
+
+// MatchEventPattern reports whether an event id matches a "ubus listen" pattern.
+func MatchEventPattern(pattern, id string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(id, prefix)
+	}
+
+	return pattern == id
+}
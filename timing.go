@@ -0,0 +1,98 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package goubus
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CallTiming breaks the duration of a call down by phase, telling a slow device apart from
+// slow encoding or contention in the client.
+type CallTiming struct {
+	// Err is the error the call failed with, or nil.
+	Err     error
+	Service string
+	Method  string
+	// Queue is the time spent waiting for the client: the socket lock, or a valid RPC session.
+	Queue time.Duration
+	// Encode is the time spent encoding the arguments as blobmsg or JSON-RPC.
+	Encode time.Duration
+	// Network is the time spent sending the request and waiting for the reply, including the time
+	// the device takes to handle it and, on the socket, object ID lookups.
+	Network time.Duration
+	// Decode is the time spent parsing the reply. Unmarshalling into a result type happens later
+	// in Result.Unmarshal and is not included.
+	Decode time.Duration
+}
+
+// Total returns the sum of all phases.
+func (t CallTiming) Total() time.Duration {
+	return t.Queue + t.Encode + t.Network + t.Decode
+}
+
+// String formats the phases for logs.
+func (t CallTiming) String() string {
+	return fmt.Sprintf("queue %s, encode %s, network %s, decode %s", t.Queue, t.Encode, t.Network, t.Decode)
+}
+
+// MetricsHook receives the timing of every call made through a client. It runs synchronously
+// when the call completes and must be safe for concurrent use. The calls of a batch share the
+// phases of its round trip, and calls of a batch that failed as a whole are not reported.
+type MetricsHook func(ctx context.Context, timing CallTiming)
+
+// TimingError attaches the timing of a failed call to its error. Clients only return it when
+// created with WithRpcErrorTiming or WithSocketErrorTiming; extract it with errors.As.
+type TimingError struct {
+	Err    error
+	Timing CallTiming
+}
+
+// Error implements the error interface.
+func (e *TimingError) Error() string {
+	return fmt.Sprintf("%v (%s)", e.Err, e.Timing)
+}
+
+// Unwrap returns the error of the call.
+func (e *TimingError) Unwrap() error {
+	return e.Err
+}
+
+// callMetrics holds the timing options of a client.
+type callMetrics struct {
+	hook        MetricsHook
+	errorTiming bool
+}
+
+// finish reports the timing of a call and returns its error, carrying the timing if requested.
+func (m *callMetrics) finish(ctx context.Context, timing *CallTiming, err error) error {
+	timing.Err = err
+
+	if m.hook != nil {
+		m.hook(ctx, *timing)
+	}
+
+	if err != nil && m.errorTiming {
+		return &TimingError{Err: err, Timing: *timing}
+	}
+
+	return err
+}
+
+// finishBatch reports the timings of the calls of a batch, like finish, and replaces the errors
+// of the results.
+func (m *callMetrics) finishBatch(ctx context.Context, timings []CallTiming, results []BatchResult) {
+	for i := range results {
+		results[i].Err = m.finish(ctx, &timings[i], results[i].Err)
+	}
+}
+
+// lap adds the time elapsed since start to phase and returns the start of the next phase.
+func lap(phase *time.Duration, start time.Time) time.Time {
+	now := time.Now()
+	*phase += now.Sub(start)
+
+	return now
+}
@@ -0,0 +1,156 @@
+package goubus_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+)
+
+type timingRecorder struct {
+	timings []goubus.CallTiming
+	mu      sync.Mutex
+}
+
+func (r *timingRecorder) hook(_ context.Context, timing goubus.CallTiming) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.timings = append(r.timings, timing)
+}
+
+func (r *timingRecorder) last(t *testing.T) goubus.CallTiming {
+	t.Helper()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.timings) == 0 {
+		t.Fatal("no timing recorded")
+	}
+
+	return r.timings[len(r.timings)-1]
+}
+
+func TestCallTiming_Rpc(t *testing.T) {
+	sessionID := "12345678901234567890123456789012"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handleRpcCall(t, w, r, sessionID)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	recorder := &timingRecorder{}
+
+	client, err := goubus.NewRpcClient(ctx, strings.TrimPrefix(server.URL, "http://"), "user", "pass",
+		goubus.WithRpcMetrics(recorder.hook), goubus.WithRpcErrorTiming())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		_, err := client.Call(ctx, "system", "info", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		timing := recorder.last(t)
+		if timing.Service != "system" || timing.Method != "info" || timing.Err != nil {
+			t.Errorf("unexpected timing: %+v", timing)
+		}
+
+		if timing.Network <= 0 || timing.Total() < timing.Network {
+			t.Errorf("expected network time, got %s", timing)
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		_, err := client.Call(ctx, "system", "unknown", nil)
+		assertTimingError(t, err, "unknown")
+
+		if recorder.last(t).Err == nil {
+			t.Error("expected the hook to receive the error")
+		}
+	})
+}
+
+func TestCallTiming_Socket(t *testing.T) {
+	sockPath := filepath.Join(t.TempDir(), "ubus.sock")
+
+	var lc net.ListenConfig
+
+	listener, err := lc.Listen(context.Background(), "unix", sockPath)
+	if err != nil {
+		t.Skipf("unix sockets not supported: %v", err)
+	}
+
+	defer func() {
+		_ = listener.Close()
+	}()
+
+	go mockUbusd(t, listener)
+
+	ctx := context.Background()
+	recorder := &timingRecorder{}
+
+	client, err := goubus.NewSocketClient(ctx, sockPath,
+		goubus.WithSocketMetrics(recorder.hook), goubus.WithSocketErrorTiming())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		_ = client.Close()
+	}()
+
+	_, err = client.Call(ctx, "system", "info", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	timing := recorder.last(t)
+	if timing.Method != "info" || timing.Network <= 0 || timing.Encode <= 0 {
+		t.Errorf("unexpected timing: %+v", timing)
+	}
+
+	_, err = client.Call(ctx, "", "info", nil)
+	assertTimingError(t, err, "info")
+
+	batch := client.Batch()
+	batch.Add("system", "info", nil)
+	batch.Add("mwan3", "status", nil)
+
+	results, err := batch.Do(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	timing = recorder.timings[len(recorder.timings)-2]
+	if timing.Method != "info" || timing.Network <= 0 || timing.Err != nil {
+		t.Errorf("unexpected batch timing: %+v", timing)
+	}
+
+	assertTimingError(t, results[1].Err, "status")
+}
+
+func assertTimingError(t *testing.T, err error, method string) {
+	t.Helper()
+
+	var timingErr *goubus.TimingError
+	if !errors.As(err, &timingErr) {
+		t.Fatalf("expected a *TimingError, got %v", err)
+	}
+
+	if timingErr.Timing.Method != method || errdefs.Kind(err) == nil {
+		t.Errorf("unexpected timing error: %v", err)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+// Package tracing wraps the calls made through goubus transports in spans, so that controller
+// requests touching several routers show up in distributed traces. It does not depend on a
+// tracing library; an OpenTelemetry tracer is plugged in with a small adapter:
+//
+//	type otelTracer struct{ trace.Tracer }
+//
+//	func (t otelTracer) Start(ctx context.Context, name string) (context.Context, tracing.Span) {
+//		ctx, span := t.Tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+//		return ctx, otelSpan{span}
+//	}
+//
+//	type otelSpan struct{ trace.Span }
+//
+//	func (s otelSpan) SetAttributes(attrs ...tracing.Attribute) {
+//		for _, a := range attrs {
+//			s.Span.SetAttributes(attribute.String(a.Key, fmt.Sprint(a.Value)))
+//		}
+//	}
+//
+//	func (s otelSpan) RecordError(err error) {
+//		s.Span.RecordError(err)
+//		s.Span.SetStatus(codes.Error, err.Error())
+//	}
+//
+//	func (s otelSpan) End() { s.Span.End() }
+//
+// Every manager method takes the caller's context and passes it to the transport, so the spans
+// are children of the span in the context of the manager call.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/honeybbq/goubus/v2"
+)
+
+// Attribute keys set on every call span. The rpc.* keys follow the OpenTelemetry semantic
+// conventions for RPC client spans.
+const (
+	AttrRPCSystem  = "rpc.system"
+	AttrRPCService = "rpc.service"
+	AttrRPCMethod  = "rpc.method"
+	AttrTransport  = "goubus.transport"
+	AttrUbusStatus = "ubus.status"
+
+	// RPCSystem is the value of AttrRPCSystem.
+	RPCSystem = "ubus"
+)
+
+// Attribute is a key/value pair set on a span.
+type Attribute struct {
+	Value any
+	Key   string
+}
+
+// Span is the part of a tracing span the instrumentation uses.
+type Span interface {
+	// SetAttributes sets attributes on the span.
+	SetAttributes(attrs ...Attribute)
+	// RecordError records that the call failed with err.
+	RecordError(err error)
+	// End completes the span.
+	End()
+}
+
+// Tracer starts spans, e.g. an adapter around an OpenTelemetry trace.Tracer.
+type Tracer interface {
+	// Start starts a span named name as a child of the span in ctx and returns a context
+	// holding the new span.
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Interceptor returns an interceptor that runs every call in a span named "object/method".
+// transport names the transport type in the span attributes, e.g. "rpc" or "socket". Register
+// it first so that retries of later interceptors are part of the span.
+func Interceptor(tracer Tracer, transport string) goubus.CallInterceptor {
+	return goubus.InterceptorFunc(func(
+		ctx context.Context, service, method string, data any, next goubus.Invoker,
+	) (goubus.Result, error) {
+		ctx, span := tracer.Start(ctx, service+"/"+method)
+		defer span.End()
+
+		span.SetAttributes(
+			Attribute{Key: AttrRPCSystem, Value: RPCSystem},
+			Attribute{Key: AttrRPCService, Value: service},
+			Attribute{Key: AttrRPCMethod, Value: method},
+			Attribute{Key: AttrTransport, Value: transport},
+		)
+
+		res, err := next(ctx, service, method, data)
+
+		status, ok := goubus.UbusStatus(err)
+		if ok {
+			span.SetAttributes(Attribute{Key: AttrUbusStatus, Value: status})
+		}
+
+		if err != nil {
+			span.RecordError(err)
+		}
+
+		return res, err
+	})
+}
+
+// Instrument wraps t so that its calls run in spans, naming the transport after its type, e.g.
+// "SSHClient". Prefer Interceptor for transports with an interceptor option.
+func Instrument(tracer Tracer, t goubus.Transport) goubus.Transport {
+	return goubus.NewInterceptedTransport(t, Interceptor(tracer, TransportName(t)))
+}
+
+// TransportName returns the type name of t without its package, e.g. "RpcClient".
+func TransportName(t goubus.Transport) string {
+	name := strings.TrimPrefix(fmt.Sprintf("%T", t), "*")
+
+	_, typeName, found := strings.Cut(name, ".")
+	if !found {
+		return name
+	}
+
+	return typeName
+}
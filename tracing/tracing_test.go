@@ -0,0 +1,101 @@
+package tracing_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/tracing"
+)
+
+type spanKey struct{}
+
+type recordedSpan struct {
+	attrs  map[string]any
+	err    error
+	parent *recordedSpan
+	name   string
+	ended  bool
+}
+
+func (s *recordedSpan) SetAttributes(attrs ...tracing.Attribute) {
+	for _, attr := range attrs {
+		s.attrs[attr.Key] = attr.Value
+	}
+}
+
+func (s *recordedSpan) RecordError(err error) { s.err = err }
+
+func (s *recordedSpan) End() { s.ended = true }
+
+type recordingTracer struct {
+	spans []*recordedSpan
+}
+
+func (r *recordingTracer) Start(ctx context.Context, name string) (context.Context, tracing.Span) {
+	parent, _ := ctx.Value(spanKey{}).(*recordedSpan)
+	span := &recordedSpan{name: name, attrs: make(map[string]any), parent: parent}
+	r.spans = append(r.spans, span)
+
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+type fakeTransport struct {
+	err error
+	ctx context.Context
+}
+
+func (f *fakeTransport) Call(ctx context.Context, _, _ string, _ any) (goubus.Result, error) {
+	f.ctx = ctx
+
+	return nil, f.err
+}
+
+func (f *fakeTransport) SetLogger(*slog.Logger) {}
+
+func (f *fakeTransport) Close() error { return nil }
+
+func TestInstrument(t *testing.T) {
+	tracer := &recordingTracer{}
+	inner := &fakeTransport{err: errdefs.Wrapf(errdefs.ErrPermissionDenied, "file.exec")}
+	client := tracing.Instrument(tracer, inner)
+
+	ctx, parent := tracer.Start(context.Background(), "controller request")
+
+	_, err := client.Call(ctx, "file", "exec", nil)
+	if !errdefs.IsPermissionDenied(err) {
+		t.Fatalf("expected the call error, got %v", err)
+	}
+
+	span := tracer.spans[1]
+	if span.name != "file/exec" || !span.ended || span.err == nil || span.parent != parent {
+		t.Errorf("unexpected span %+v", span)
+	}
+
+	if inner.ctx.Value(spanKey{}) != span {
+		t.Error("expected the transport to receive the span context")
+	}
+
+	want := map[string]any{
+		tracing.AttrRPCSystem:  "ubus",
+		tracing.AttrRPCService: "file",
+		tracing.AttrRPCMethod:  "exec",
+		tracing.AttrTransport:  "fakeTransport",
+		tracing.AttrUbusStatus: goubus.UbusStatusPermissionDenied,
+	}
+	for key, value := range want {
+		if span.attrs[key] != value {
+			t.Errorf("attribute %s = %v, want %v", key, span.attrs[key], value)
+		}
+	}
+
+	inner.err = errdefs.ErrClosed
+
+	_, _ = client.Call(ctx, "system", "board", nil)
+
+	if _, ok := tracer.spans[2].attrs[tracing.AttrUbusStatus]; ok {
+		t.Error("expected no ubus status for a closed client")
+	}
+}
@@ -0,0 +1,480 @@
+// Copyright (c) 2026 honeybbq
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package goubus
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/logging"
+	"github.com/honeybbq/goubus/v2/internal/rpc"
+	"github.com/honeybbq/goubus/v2/internal/websocket"
+)
+
+const (
+	// webSocketProtocol is the subprotocol of the owsd JSON-RPC bridge.
+	webSocketProtocol = "ubus-json"
+
+	jsonRPCMethodSubscribe   = "subscribe"
+	jsonRPCMethodUnsubscribe = "unsubscribe"
+	jsonRPCMethodEvent       = "event"
+
+	// DefaultWebSocketPingInterval is how often a WebSocketClient pings the bridge by default.
+	DefaultWebSocketPingInterval = 30 * time.Second
+	// webSocketIdlePings is the number of ping intervals without any frame from the bridge after
+	// which the connection counts as lost.
+	webSocketIdlePings = 2
+)
+
+// WebSocketClient implements Transport and Subscriber over a ubus WebSocket bridge such as owsd,
+// which speaks the uhttpd-mod-ubus JSON-RPC dialect on a "ubus-json" WebSocket. Calls and events
+// share one persistent connection, so a controller can keep an event-driven link to a router
+// that dials out from behind NAT through a relay.
+//
+// The client pings the bridge and counts the connection as lost when nothing arrives for two
+// ping intervals, or when a write fails or is cut off by its context. It does not reconnect:
+// once the connection is lost, pending and later calls fail with errdefs.ErrConnectionFailed,
+// subscriptions end with that error, and a new client has to be created. The session is renewed
+// when its timeout passed since the login; a session the bridge drops earlier, for example
+// because rpcd restarted, makes calls fail with errdefs.ErrPermissionDenied.
+type WebSocketClient struct {
+	logger       *slog.Logger
+	conn         *websocket.Conn
+	tlsConfig    *tls.Config
+	header       http.Header
+	pending      map[int]chan *rpc.UbusResponse
+	listeners    map[*wsListener]struct{}
+	done         chan struct{}
+	err          error
+	username     string
+	password     string
+	session      rpc.SessionData
+	nextID       int
+	pingInterval time.Duration
+	mu           sync.Mutex
+	sessionMu    sync.Mutex
+}
+
+var (
+	_ Transport  = (*WebSocketClient)(nil)
+	_ Subscriber = (*WebSocketClient)(nil)
+)
+
+// wsListener receives the events matching pattern.
+type wsListener struct {
+	events  chan Event
+	pattern string
+}
+
+// WebSocketOption defines a functional option for a WebSocketClient.
+type WebSocketOption func(*WebSocketClient)
+
+// WithWebSocketLogger sets the logger for the WebSocket client.
+func WithWebSocketLogger(logger *slog.Logger) WebSocketOption {
+	return func(c *WebSocketClient) {
+		c.SetLogger(logger)
+	}
+}
+
+// WithWebSocketTLSConfig sets the TLS configuration of "wss://" connections.
+func WithWebSocketTLSConfig(config *tls.Config) WebSocketOption {
+	return func(c *WebSocketClient) {
+		c.tlsConfig = config
+	}
+}
+
+// WithWebSocketHeader adds a header to the opening handshake, e.g. the Origin owsd checks
+// against its whitelist or the credentials of a relay.
+func WithWebSocketHeader(key, value string) WebSocketOption {
+	return func(c *WebSocketClient) {
+		c.header.Add(key, value)
+	}
+}
+
+// WithWebSocketPingInterval sets how often the client pings the bridge to detect a lost
+// connection; zero disables the pings. It defaults to DefaultWebSocketPingInterval.
+func WithWebSocketPingInterval(interval time.Duration) WebSocketOption {
+	return func(c *WebSocketClient) {
+		c.pingInterval = interval
+	}
+}
+
+// NewWebSocketClient connects to the bridge at url ("ws://" or "wss://") and logs in with
+// session.login. Without a username the calls use the unauthenticated session.
+func NewWebSocketClient(
+	ctx context.Context, url, username, password string, opts ...WebSocketOption,
+) (*WebSocketClient, error) {
+	client := &WebSocketClient{
+		logger:       logging.Discard(),
+		header:       make(http.Header),
+		pending:      make(map[int]chan *rpc.UbusResponse),
+		listeners:    make(map[*wsListener]struct{}),
+		done:         make(chan struct{}),
+		username:     username,
+		password:     password,
+		nextID:       1,
+		pingInterval: DefaultWebSocketPingInterval,
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	conn, err := websocket.Dial(ctx, url, client.header, client.tlsConfig, webSocketProtocol)
+	if err != nil {
+		return nil, errdefs.Wrapf(errdefs.FromTransport(err), "connect to %s", url)
+	}
+
+	client.conn = conn
+
+	if client.pingInterval > 0 {
+		conn.SetIdleTimeout(webSocketIdlePings * client.pingInterval)
+
+		go client.pingLoop()
+	}
+
+	go client.readLoop()
+
+	if username != "" {
+		err = client.login(ctx)
+		if err != nil {
+			_ = client.Close()
+
+			return nil, errdefs.Wrapf(err, "failed to authenticate")
+		}
+	}
+
+	return client, nil
+}
+
+// SetLogger sets the logger for the WebSocket client.
+func (c *WebSocketClient) SetLogger(logger *slog.Logger) {
+	if logger == nil {
+		c.logger = logging.Discard()
+	} else {
+		c.logger = logger
+	}
+}
+
+// Call performs a ubus call over the connection, logging in again once the session expired.
+func (c *WebSocketClient) Call(ctx context.Context, service, method string, data any) (Result, error) {
+	sessionID, err := c.sessionID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.request(ctx, jsonRPCMethodCall, sessionID, service, method, json.RawMessage(encodeRequestData(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	return resultFromResponse(resp)
+}
+
+// Subscribe is not supported: the bridge only forwards ubus events.
+func (c *WebSocketClient) Subscribe(_ context.Context, object string) (*Subscription, error) {
+	return nil, errdefs.Wrapf(errdefs.ErrNotSupported, "subscribe to %s over WebSocket", object)
+}
+
+// Listen delivers the ubus events matching pattern over the shared connection.
+func (c *WebSocketClient) Listen(ctx context.Context, pattern string) (*Subscription, error) {
+	sessionID, err := c.sessionID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &wsListener{pattern: pattern, events: make(chan Event, subscriptionBuffer)}
+
+	c.mu.Lock()
+	c.listeners[listener] = struct{}{}
+	c.mu.Unlock()
+
+	resp, err := c.request(ctx, jsonRPCMethodSubscribe, sessionID, pattern)
+	if err == nil {
+		_, err = resultFromResponse(resp)
+	}
+
+	if err != nil {
+		c.removeListener(listener)
+
+		return nil, errdefs.Wrapf(err, "listen for %s", pattern)
+	}
+
+	return NewSubscription(ctx, func(ctx context.Context, emit func(Event) bool) error {
+		defer c.unsubscribe(listener, sessionID)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-c.done:
+				return c.err
+			case event := <-listener.events:
+				if !emit(event) {
+					return nil
+				}
+			}
+		}
+	}), nil
+}
+
+// Close closes the connection, failing pending calls and ending the subscriptions.
+func (c *WebSocketClient) Close() error {
+	c.mu.Lock()
+	if c.err == nil {
+		c.err = errdefs.ErrClosed
+	}
+	c.mu.Unlock()
+
+	return c.conn.Close()
+}
+
+func (c *WebSocketClient) login(ctx context.Context) error {
+	resp, err := c.request(ctx, jsonRPCMethodCall, ubusAuthSessionID, "session", "login", map[string]string{
+		"username": c.username,
+		"password": c.password,
+	})
+	if err != nil {
+		return err
+	}
+
+	res, err := resultFromResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	var session rpc.SessionData
+
+	err = res.Unmarshal(&session)
+	if err != nil {
+		return errdefs.Wrapf(err, "failed to parse session data")
+	}
+
+	session.ExpireTime = time.Now().Add(time.Duration(session.Timeout) * time.Second)
+	c.session = session
+
+	return nil
+}
+
+// sessionID returns the current session, logging in again if it expired.
+func (c *WebSocketClient) sessionID(ctx context.Context) (string, error) {
+	if c.username == "" {
+		return ubusAuthSessionID, nil
+	}
+
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+
+	if time.Now().After(c.session.ExpireTime) {
+		err := c.login(ctx)
+		if err != nil {
+			return "", errdefs.Wrapf(err, "failed to authenticate")
+		}
+	}
+
+	return c.session.UbusRPCSession, nil
+}
+
+// request sends a JSON-RPC request and waits for its response.
+func (c *WebSocketClient) request(ctx context.Context, method string, params ...any) (*rpc.UbusResponse, error) {
+	reply := make(chan *rpc.UbusResponse, 1)
+
+	c.mu.Lock()
+	if c.err != nil {
+		c.mu.Unlock()
+
+		return nil, c.err
+	}
+
+	id := c.nextID
+	c.nextID++
+	c.pending[id] = reply
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+	}()
+
+	body, err := json.Marshal(map[string]any{
+		"jsonrpc": jsonRPCVersion,
+		"id":      id,
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		return nil, errdefs.Wrapf(errdefs.ErrInvalidParameter, "encode request: %v", err)
+	}
+
+	err = c.conn.WriteMessage(ctx, body)
+	if err != nil {
+		// The frame may be cut off, which leaves the connection unusable.
+		c.fail(err)
+
+		if ctx.Err() != nil {
+			return nil, errdefs.Wrapf(errdefs.FromTransport(ctx.Err()), "send %s request", method)
+		}
+
+		return nil, errdefs.Wrapf(errdefs.ErrConnectionFailed, "send %s request: %v", method, err)
+	}
+
+	select {
+	case resp := <-reply:
+		return resp, nil
+	case <-c.done:
+		return nil, c.err
+	case <-ctx.Done():
+		return nil, errdefs.FromTransport(ctx.Err())
+	}
+}
+
+// fail records err as the reason the connection is lost and closes it, which ends readLoop and
+// with it the pending calls and the subscriptions.
+func (c *WebSocketClient) fail(err error) {
+	c.mu.Lock()
+	if c.err == nil {
+		c.err = errdefs.Wrapf(errdefs.ErrConnectionFailed, "websocket: %v", err)
+	}
+	c.mu.Unlock()
+
+	_ = c.conn.Close()
+}
+
+// pingLoop pings the bridge every ping interval until the connection ends. The pongs keep the
+// idle timeout of the reads from expiring.
+func (c *WebSocketClient) pingLoop() {
+	ticker := time.NewTicker(c.pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.pingInterval)
+		err := c.conn.Ping(ctx)
+
+		cancel()
+
+		if err != nil {
+			c.fail(err)
+
+			return
+		}
+	}
+}
+
+// readLoop dispatches responses to their requests and events to the matching listeners until
+// the connection fails.
+func (c *WebSocketClient) readLoop() {
+	for {
+		message, err := c.conn.ReadMessage()
+		if err != nil {
+			c.mu.Lock()
+			if c.err == nil {
+				c.err = errdefs.Wrapf(errdefs.ErrConnectionFailed, "websocket: %v", err)
+			}
+			c.mu.Unlock()
+			close(c.done)
+
+			return
+		}
+
+		var frame struct {
+			Params json.RawMessage `json:"params"`
+			rpc.UbusResponse
+			Method string `json:"method"`
+		}
+
+		err = json.Unmarshal(message, &frame)
+		if err != nil {
+			c.logger.Warn("Invalid WebSocket message", slog.String("error", err.Error()))
+
+			continue
+		}
+
+		if frame.Method == jsonRPCMethodEvent {
+			c.dispatchEvent(frame.Params)
+
+			continue
+		}
+
+		c.mu.Lock()
+		reply := c.pending[frame.ID]
+		c.mu.Unlock()
+
+		if reply != nil {
+			reply <- &frame.UbusResponse
+		}
+	}
+}
+
+// dispatchEvent hands an event to the listeners whose pattern matches it. Events for listeners
+// that fall behind are dropped so that call responses are never held up.
+func (c *WebSocketClient) dispatchEvent(params json.RawMessage) {
+	var event Event
+
+	err := json.Unmarshal(params, &event)
+	if err != nil {
+		c.logger.Warn("Invalid WebSocket event", slog.String("error", err.Error()))
+
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for listener := range c.listeners {
+		if !MatchEventPattern(listener.pattern, event.Type) {
+			continue
+		}
+
+		delivered := event
+		delivered.Object = listener.pattern
+
+		select {
+		case listener.events <- delivered:
+		default:
+			c.logger.Warn("Dropping WebSocket event", slog.String("type", event.Type))
+		}
+	}
+}
+
+// unsubscribe removes listener and tells the bridge to stop forwarding its pattern unless
+// another listener still uses it.
+func (c *WebSocketClient) unsubscribe(listener *wsListener, sessionID string) {
+	if c.removeListener(listener) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultReadTimeout)
+	defer cancel()
+
+	_, _ = c.request(ctx, jsonRPCMethodUnsubscribe, sessionID, listener.pattern)
+}
+
+// removeListener removes listener and reports whether another listener uses its pattern.
+func (c *WebSocketClient) removeListener(listener *wsListener) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.listeners, listener)
+
+	for other := range c.listeners {
+		if other.pattern == listener.pattern {
+			return true
+		}
+	}
+
+	return false
+}
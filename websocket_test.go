@@ -0,0 +1,165 @@
+package goubus_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/honeybbq/goubus/v2"
+	"github.com/honeybbq/goubus/v2/errdefs"
+	"github.com/honeybbq/goubus/v2/internal/websocket"
+)
+
+// serveOwsd answers JSON-RPC requests like owsd and sends an event after every subscribe.
+func serveOwsd(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Upgrade(w, r, "ubus-json")
+		if err != nil {
+			return
+		}
+
+		defer func() { _ = conn.Close() }()
+
+		ctx := context.Background()
+
+		for {
+			message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+
+			var req struct {
+				Method string `json:"method"`
+				Params []any  `json:"params"`
+				ID     int    `json:"id"`
+			}
+
+			_ = json.Unmarshal(message, &req)
+
+			result := `[0]`
+
+			switch {
+			case req.Method == "subscribe":
+				_ = conn.WriteMessage(ctx, []byte(`{"jsonrpc":"2.0","id":`+itoa(req.ID)+`,"result":[0]}`))
+				_ = conn.WriteMessage(ctx, []byte(`{"jsonrpc":"2.0","method":"event",`+
+					`"params":{"type":"network.interface","data":{"action":"ifup","interface":"wan"}}}`))
+
+				continue
+			case req.Params[1] == "session" && req.Params[2] == "login":
+				result = `[0,{"ubus_rpc_session":"sid","timeout":300}]`
+			case req.Params[0] != "sid":
+				result = `[6]`
+			case req.Params[1] == "system" && req.Params[2] == "board":
+				result = `[0,{"hostname":"OpenWrt"}]`
+			}
+
+			reply := `{"jsonrpc":"2.0","id":` + itoa(req.ID) + `,"result":` + result + `}`
+			_ = conn.WriteMessage(ctx, []byte(reply))
+		}
+	}))
+}
+
+func itoa(i int) string {
+	raw, _ := json.Marshal(i)
+
+	return string(raw)
+}
+
+func TestWebSocketClient(t *testing.T) {
+	server := serveOwsd(t)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client, err := goubus.NewWebSocketClient(ctx, "ws"+strings.TrimPrefix(server.URL, "http"), "root", "secret")
+	if err != nil {
+		t.Fatalf("NewWebSocketClient failed: %v", err)
+	}
+
+	board, err := goubus.Call[map[string]any](ctx, client, "system", "board", nil)
+	if err != nil || (*board)["hostname"] != "OpenWrt" {
+		t.Fatalf("unexpected board %v: %v", board, err)
+	}
+
+	sub, err := goubus.Listen(ctx, client, "network.*")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+
+	ev := <-sub.Events()
+	if ev.Type != "network.interface" || ev.Object != "network.*" || ev.Data["interface"] != "wan" {
+		t.Errorf("unexpected event: %+v", ev)
+	}
+
+	_, err = goubus.Subscribe(ctx, client, "hostapd.phy0-ap0")
+	if !errdefs.IsNotSupported(err) {
+		t.Errorf("expected not supported, got %v", err)
+	}
+
+	_ = client.Close()
+
+	<-sub.Done()
+
+	_, err = client.Call(ctx, "system", "board", nil)
+	if !errdefs.IsClosed(err) {
+		t.Errorf("expected closed, got %v", err)
+	}
+
+	anonymous, err := goubus.NewWebSocketClient(ctx, "ws"+strings.TrimPrefix(server.URL, "http"), "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _ = anonymous.Close() }()
+
+	_, err = goubus.Call[map[string]any](ctx, anonymous, "system", "board", nil)
+	if !errdefs.IsPermissionDenied(err) {
+		t.Errorf("expected permission denied for the unauthenticated session, got %v", err)
+	}
+}
+
+func TestWebSocketClient_ConnectionLost(t *testing.T) {
+	release := make(chan struct{})
+
+	// The bridge accepts the connection but neither answers calls nor pings.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Upgrade(w, r, "ubus-json")
+		if err != nil {
+			return
+		}
+
+		<-release
+
+		_ = conn.Close()
+	}))
+	defer server.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client, err := goubus.NewWebSocketClient(ctx, "ws"+strings.TrimPrefix(server.URL, "http"), "", "",
+		goubus.WithWebSocketPingInterval(20*time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewWebSocketClient failed: %v", err)
+	}
+
+	defer func() { _ = client.Close() }()
+
+	_, err = client.Call(ctx, "system", "board", nil)
+	if !errdefs.IsConnectionFailed(err) {
+		t.Errorf("expected the pending call to fail with the connection, got %v", err)
+	}
+
+	_, err = client.Call(ctx, "system", "board", nil)
+	if !errdefs.IsConnectionFailed(err) {
+		t.Errorf("expected later calls to fail with the connection, got %v", err)
+	}
+}